@@ -11,24 +11,54 @@ import (
 	"syscall"
 	"time"
 
+	"github.com/COS301-SE-2025/Swift-Signals/api-gateway/internal/cache"
 	"github.com/COS301-SE-2025/Swift-Signals/api-gateway/internal/client"
 	"github.com/COS301-SE-2025/Swift-Signals/api-gateway/internal/handler"
 	"github.com/COS301-SE-2025/Swift-Signals/api-gateway/internal/middleware"
+	"github.com/COS301-SE-2025/Swift-Signals/api-gateway/internal/repository"
 	"github.com/COS301-SE-2025/Swift-Signals/api-gateway/internal/service"
 	_ "github.com/COS301-SE-2025/Swift-Signals/api-gateway/swagger"
 	"github.com/COS301-SE-2025/Swift-Signals/shared/config"
+	"github.com/COS301-SE-2025/Swift-Signals/shared/events"
 	httpSwagger "github.com/swaggo/http-swagger"
+	"go.mongodb.org/mongo-driver/mongo"
+	"go.mongodb.org/mongo-driver/mongo/options"
 	"google.golang.org/grpc"
 	"google.golang.org/grpc/credentials/insecure"
 )
 
 type Config struct {
-	Port             int    `env:"PORT"           envDefault:"9090"`
-	JwtSecret        string `env:"JWT_SECRET"     envDefault:"a-string-secret-at-least-256-bits-long"`
-	UserServiceAddr  string `env:"USER_GRPC_ADDR" envDefault:"localhost:50051"` // TODO: Change to proper address
-	IntersectionAddr string `env:"INTR_GRPC_ADDR" envDefault:"localhost:50052"` // TODO: Change to proper address
-	SimulationAddr   string `env:"SIMU_GRPC_ADDR" envDefault:"localhost:50053"` // TODO: Change to proper address
-	OptimisationAddr string `env:"OPTI_GRPC_ADDR" envDefault:"localhost:50054"` // TODO: Change to proper address
+	Port             int    `env:"PORT"                 envDefault:"9090"`
+	JwtSecret        string `env:"JWT_SECRET"           envDefault:"a-string-secret-at-least-256-bits-long"`
+	UserServiceAddr  string `env:"USER_GRPC_ADDR"       envDefault:"localhost:50051"` // TODO: Change to proper address
+	IntersectionAddr string `env:"INTR_GRPC_ADDR"       envDefault:"localhost:50052"` // TODO: Change to proper address
+	SimulationAddr   string `env:"SIMU_GRPC_ADDR"       envDefault:"localhost:50053"` // TODO: Change to proper address
+	OptimisationAddr string `env:"OPTI_GRPC_ADDR"       envDefault:"localhost:50054"` // TODO: Change to proper address
+	SimCacheSizeMB   int    `env:"SIMU_CACHE_SIZE_MB"   envDefault:"256"`
+	SimCacheMongoURI string `env:"SIMU_CACHE_MONGO_URI" envDefault:""` // Persistent tier is disabled when empty
+	SimCacheTTLHours int    `env:"SIMU_CACHE_TTL_HOURS" envDefault:"168"`
+	SimConcurrency   int    `env:"SIMU_CONCURRENCY"     envDefault:"4"` // Replications simulated at a time
+	MaxReplications  int    `env:"MAX_REPLICATIONS"     envDefault:"30"`
+	OptiReplications int    `env:"OPTI_REPLICATIONS"    envDefault:"1"`   // Seeds optimised parameters are judged on
+	SweepConcurrency int    `env:"SWEEP_CONCURRENCY"    envDefault:"4"`   // Sweep points simulated at a time
+	SweepTimeoutSec  int    `env:"SWEEP_TIMEOUT_SEC"    envDefault:"120"` // Deadline per sweep point
+	MaxSweepPoints   int    `env:"MAX_SWEEP_POINTS"     envDefault:"1000"`
+	OptiHeartbeatSec int    `env:"OPTI_HEARTBEAT_SEC"   envDefault:"60"`    // A few times shorter than OPTIMISATION_LEASE (5m) of the intersection service
+	ReconcileMin     int    `env:"RECONCILE_MIN"        envDefault:"60"`    // Minutes between ownership checks
+	ReconcileRepair  bool   `env:"RECONCILE_REPAIR"     envDefault:"false"` // Otherwise only reported
+	OrphanGraceMin   int    `env:"ORPHAN_GRACE_MIN"     envDefault:"10"`    // Age before an unowned intersection is an orphan
+	StoreMongoURI    string `env:"STORE_MONGO_URI"      envDefault:""`      // Webhooks and notifications are kept in memory when empty
+	WebhookAttempts  int    `env:"WEBHOOK_MAX_ATTEMPTS" envDefault:"6"`
+	WebhookBackoffMs int    `env:"WEBHOOK_BACKOFF_MS"   envDefault:"2000"` // Doubles after each retry
+	WebhookTimeout   int    `env:"WEBHOOK_TIMEOUT_SEC"  envDefault:"10"`
+	WebhookLogTTLHrs int    `env:"WEBHOOK_LOG_TTL_HRS"  envDefault:"720"`
+	WebhookWorkers   int    `env:"WEBHOOK_WORKERS"      envDefault:"4"`     // Deliveries attempted at a time
+	WebhookPollMs    int    `env:"WEBHOOK_POLL_MS"      envDefault:"1000"`  // How often due retries are looked for
+	WebhookPrivate   bool   `env:"WEBHOOK_PRIVATE_NET"  envDefault:"false"` // Allows plain http and private addresses, for development only
+	NotificationTTL  int    `env:"NOTIFICATION_TTL_HRS" envDefault:"2160"`
+	RevocationTTLSec int    `env:"REVOCATION_CACHE_SEC" envDefault:"30"` // How long other gateways may accept a revoked token
+	EventsPort       int    `env:"EVENTS_PORT"          envDefault:"8081"`
+	EventSecret      string `env:"EVENT_SECRET"         envDefault:""` // Events relayed by other services are refused when empty
 }
 
 // @title Authentication API Gateway
@@ -54,16 +84,79 @@ func main() {
 		log.Fatalf("failed to load config: %v", err)
 	}
 
-	userClient := mustConnectUserService(cfg.UserServiceAddr)
+	userClient := client.NewCachedUserClient(
+		mustConnectUserService(cfg.UserServiceAddr),
+		time.Duration(cfg.RevocationTTLSec)*time.Second,
+	)
 	intrClient := mustConnectIntersectionService(cfg.IntersectionAddr)
 	simClient := mustConnectSimulationService(cfg.SimulationAddr)
 	optiClient := mustConnectOptimisationService(cfg.OptimisationAddr)
+	simCache := mustCreateSimulationCache(cfg)
+	store := mustConnectStore(cfg)
+	webhookRepo := mustCreateWebhookRepository(cfg, store)
+	notificationRepo := mustCreateNotificationRepository(cfg, store)
+	reconciler := service.NewOwnershipReconciler(
+		userClient,
+		intrClient,
+		time.Duration(cfg.OrphanGraceMin)*time.Minute,
+	)
 
 	baseLogger := slog.New(slog.NewJSONHandler(os.Stdout, &slog.HandlerOptions{
 		Level: slog.LevelDebug,
 	}))
 
-	mux := setupRoutes(baseLogger, cfg.JwtSecret, userClient, intrClient, simClient, optiClient)
+	webhookAddresses := service.WebhookAddresses{AllowPrivate: cfg.WebhookPrivate}
+	dispatcher := service.NewWebhookDispatcher(webhookRepo, userClient, service.WebhookConfig{
+		MaxAttempts:    cfg.WebhookAttempts,
+		InitialBackoff: time.Duration(cfg.WebhookBackoffMs) * time.Millisecond,
+		Timeout:        time.Duration(cfg.WebhookTimeout) * time.Second,
+		Concurrency:    cfg.WebhookWorkers,
+		Addresses:      webhookAddresses,
+	})
+	go service.RunWebhookDispatcher(
+		middleware.SetLogger(context.Background(), baseLogger),
+		dispatcher,
+		time.Duration(cfg.WebhookPollMs)*time.Millisecond,
+	)
+	// NOTE: Events on intersections reach webhooks from the intersection service's outbox,
+	// which relays each one until the dispatcher has queued it
+	serveEvents(cfg, func(ctx context.Context, event events.Event) error {
+		return dispatcher.HandleEvent(middleware.SetLogger(ctx, baseLogger), event)
+	})
+
+	mux := setupRoutes(
+		baseLogger,
+		cfg.JwtSecret,
+		userClient,
+		intrClient,
+		client.NewCachedSimulationClient(simClient, simCache),
+		optiClient,
+		simCache,
+		reconciler,
+		webhookRepo,
+		webhookAddresses,
+		notificationRepo,
+		service.ReplicationConfig{
+			Concurrency:              cfg.SimConcurrency,
+			MaxReplications:          cfg.MaxReplications,
+			OptimisationReplications: cfg.OptiReplications,
+		},
+		service.SweepConfig{
+			Concurrency: cfg.SweepConcurrency,
+			CallTimeout: time.Duration(cfg.SweepTimeoutSec) * time.Second,
+			MaxPoints:   cfg.MaxSweepPoints,
+		},
+		service.OptimisationConfig{
+			HeartbeatInterval: time.Duration(cfg.OptiHeartbeatSec) * time.Second,
+		},
+	)
+
+	go service.RunOwnershipReconciler(
+		middleware.SetLogger(context.Background(), baseLogger),
+		reconciler,
+		time.Duration(cfg.ReconcileMin)*time.Minute,
+		cfg.ReconcileRepair,
+	)
 
 	server := createServer(cfg.Port, mux)
 	runServer(server)
@@ -117,57 +210,251 @@ func mustConnectSimulationService(address string) *client.SimulationClient {
 	return client.NewSimulationClientFromConn(conn)
 }
 
+// mustCreateSimulationCache keeps simulation results in memory and, when a Mongo URI is
+// configured, persists them across restarts
+func mustCreateSimulationCache(cfg Config) *cache.SimulationCache {
+	maxBytes := cfg.SimCacheSizeMB << 20
+	if cfg.SimCacheMongoURI == "" {
+		log.Println("Simulation cache persistence disabled")
+		return cache.NewSimulationCache(maxBytes, nil)
+	}
+
+	ctx, cancel := context.WithTimeout(context.Background(), 10*time.Second)
+	defer cancel()
+
+	mongoClient, err := mongo.Connect(ctx, options.Client().ApplyURI(cfg.SimCacheMongoURI))
+	if err != nil {
+		log.Fatalf("failed to connect to simulation cache MongoDB: %v", err)
+	}
+	store, err := cache.NewMongoStore(
+		ctx,
+		mongoClient.Database("ApiGateway").Collection("SimulationCache"),
+		time.Duration(cfg.SimCacheTTLHours)*time.Hour,
+	)
+	if err != nil {
+		log.Fatalf("failed to prepare simulation cache collection: %v", err)
+	}
+	log.Println("Connected to simulation cache MongoDB")
+	return cache.NewSimulationCache(maxBytes, store)
+}
+
+// mustConnectStore connects to the Mongo database the gateway keeps its own data in, such as
+// webhooks, or returns nil when no URI is configured
+func mustConnectStore(cfg Config) *mongo.Database {
+	if cfg.StoreMongoURI == "" {
+		return nil
+	}
+
+	ctx, cancel := context.WithTimeout(context.Background(), 10*time.Second)
+	defer cancel()
+
+	mongoClient, err := mongo.Connect(ctx, options.Client().ApplyURI(cfg.StoreMongoURI))
+	if err != nil {
+		log.Fatalf("failed to connect to store MongoDB: %v", err)
+	}
+	log.Println("Connected to store MongoDB")
+	return mongoClient.Database("ApiGateway")
+}
+
+// mustCreateWebhookRepository keeps webhooks and their delivery logs in the store when there
+// is one, and otherwise in memory
+func mustCreateWebhookRepository(
+	cfg Config,
+	store *mongo.Database,
+) repository.WebhookRepositoryInterface {
+	if store == nil {
+		log.Println("Webhook persistence disabled")
+		return repository.NewMemoryWebhookRepository()
+	}
+
+	ctx, cancel := context.WithTimeout(context.Background(), 10*time.Second)
+	defer cancel()
+
+	repo, err := repository.NewMongoWebhookRepository(
+		ctx,
+		store,
+		time.Duration(cfg.WebhookLogTTLHrs)*time.Hour,
+	)
+	if err != nil {
+		log.Fatalf("failed to prepare webhook collections: %v", err)
+	}
+	return repo
+}
+
+// mustCreateNotificationRepository keeps notifications in the store when there is one, and
+// otherwise in memory
+func mustCreateNotificationRepository(
+	cfg Config,
+	store *mongo.Database,
+) repository.NotificationRepositoryInterface {
+	if store == nil {
+		log.Println("Notification persistence disabled")
+		return repository.NewMemoryNotificationRepository()
+	}
+
+	ctx, cancel := context.WithTimeout(context.Background(), 10*time.Second)
+	defer cancel()
+
+	repo, err := repository.NewMongoNotificationRepository(
+		ctx,
+		store,
+		time.Duration(cfg.NotificationTTL)*time.Hour,
+	)
+	if err != nil {
+		log.Fatalf("failed to prepare notification collection: %v", err)
+	}
+	return repo
+}
+
 func setupRoutes(
 	logger *slog.Logger,
 	JwtSecret string,
-	userClient *client.UserClient,
-	intrClient *client.IntersectionClient,
-	simClient *client.SimulationClient,
+	userClient client.UserClientInterface,
+	intrClient client.IntersectionClientInterface,
+	simClient client.SimulationClientInterface,
 	optiClient *client.OptimisationClient,
+	simCache cache.SimulationCacheInterface,
+	reconciler *service.OwnershipReconciler,
+	webhookRepo repository.WebhookRepositoryInterface,
+	webhookAddresses service.WebhookAddresses,
+	notificationRepo repository.NotificationRepositoryInterface,
+	replication service.ReplicationConfig,
+	sweep service.SweepConfig,
+	optimisation service.OptimisationConfig,
 ) http.Handler {
 	mux := http.NewServeMux()
 
+	// NOTE: Shared by the services that notify users of what happened while they were away
+	notificationService := service.NewNotificationService(notificationRepo)
+
 	// Auth routes
 	authService := service.NewAuthService(userClient)
 	authHandler := handler.NewAuthHandler(authService)
 	mux.HandleFunc("POST /login", authHandler.Login)
 	mux.HandleFunc("POST /register", authHandler.Register)
+	mux.HandleFunc("POST /refresh", authHandler.Refresh)
 	mux.HandleFunc("POST /logout", authHandler.Logout)
 	mux.HandleFunc("POST /reset-password", authHandler.ResetPassword)
+	mux.HandleFunc("POST /reset-password/confirm", authHandler.ConfirmResetPassword)
 	log.Println("Initialized Auth Handlers.")
 
 	// Profile routes
-	profileService := service.NewProfileService(userClient)
+	profileService := service.NewProfileService(userClient, intrClient, notificationService)
 	profileHandler := handler.NewProfileHandler(profileService)
 	mux.HandleFunc("GET /me", profileHandler.GetProfile)
 	mux.HandleFunc("PATCH /me", profileHandler.UpdateProfile)
 	mux.HandleFunc("DELETE /me", profileHandler.DeleteProfile)
 
 	// User (Admin Only) routes
-	adminService := service.NewAdminService(userClient)
+	adminService := service.NewAdminService(
+		userClient,
+		intrClient,
+		reconciler,
+		notificationService,
+	)
 	adminHandler := handler.NewAdminHandler(adminService)
 	mux.HandleFunc("GET /admin/users", adminHandler.GetAllUsers)
 	mux.HandleFunc("GET /admin/users/{id}", adminHandler.GetUserByID)
 	mux.HandleFunc("PATCH /admin/users/{id}", adminHandler.UpdateUserByID)
 	mux.HandleFunc("DELETE /admin/users/{id}", adminHandler.DeleteUserByID)
+	mux.HandleFunc("POST /admin/users/{id}/make-admin", adminHandler.MakeAdmin)
+	mux.HandleFunc("POST /admin/users/{id}/remove-admin", adminHandler.RemoveAdmin)
+	mux.HandleFunc("POST /admin/reconcile", adminHandler.ReconcileOwnership)
 
 	// Intersection routes
-	intersectionService := service.NewIntersectionService(intrClient, optiClient, userClient)
+	intersectionService := service.NewIntersectionService(intrClient, userClient, simCache)
 	intersectionHandler := handler.NewIntersectionHandler(intersectionService)
 	mux.HandleFunc("GET /intersections", intersectionHandler.GetAllIntersections)
 	mux.HandleFunc("GET /intersections/{id}", intersectionHandler.GetIntersection)
 	mux.HandleFunc("POST /intersections", intersectionHandler.CreateIntersection)
 	mux.HandleFunc("PATCH /intersections/{id}", intersectionHandler.UpdateIntersection)
 	mux.HandleFunc("DELETE /intersections/{id}", intersectionHandler.DeleteIntersection)
+	mux.HandleFunc("GET /intersections/trash", intersectionHandler.GetDeletedIntersections)
+	mux.HandleFunc("POST /intersections/{id}/restore", intersectionHandler.RestoreIntersection)
 	mux.HandleFunc("GET /intersections/simple", NotImplemented)
+	mux.HandleFunc(
+		"GET /intersections/{id}/parameter-versions",
+		intersectionHandler.GetParameterVersions,
+	)
+	mux.HandleFunc(
+		"POST /intersections/{id}/parameter-versions/{v}/revert",
+		intersectionHandler.RevertParameterVersion,
+	)
 	log.Println("Initialized Intersection Handlers.")
 
 	// Simulation routes
-	simulationService := service.NewSimulationService(intrClient, optiClient, userClient, simClient)
+	simulationService := service.NewSimulationService(
+		intrClient,
+		optiClient,
+		userClient,
+		simClient,
+		replication,
+		sweep,
+		optimisation,
+		notificationService,
+	)
 	simulationHandler := handler.NewSimulationHandler(simulationService)
 	mux.HandleFunc("GET /intersections/{id}/simulate", simulationHandler.GetSimulation)
+	mux.HandleFunc("POST /intersections/{id}/simulate", simulationHandler.RunWhatIfSimulation)
+	mux.HandleFunc("GET /intersections/{id}/compare", simulationHandler.GetComparison)
+	mux.HandleFunc("POST /intersections/{id}/compare", simulationHandler.CompareParameters)
 	mux.HandleFunc("GET /intersections/{id}/optimise", simulationHandler.GetOptimisedSimulation)
+	mux.HandleFunc(
+		"GET /intersections/{id}/simulate/output",
+		simulationHandler.GetSimulationOutput,
+	)
+	mux.HandleFunc("GET /intersections/{id}/optimise/output", simulationHandler.GetOptimisedOutput)
+	mux.HandleFunc(
+		"GET /intersections/{id}/simulate/replications",
+		simulationHandler.GetSimulationReplications,
+	)
+	mux.HandleFunc(
+		"GET /intersections/{id}/optimise/replications",
+		simulationHandler.GetOptimisedReplications,
+	)
 	mux.HandleFunc("POST /intersections/{id}/optimise", simulationHandler.RunOptimisation)
+	mux.HandleFunc(
+		"GET /intersections/{id}/optimise/events",
+		simulationHandler.GetOptimisationEvents,
+	)
+	mux.HandleFunc(
+		"GET /intersections/{id}/optimisation-jobs",
+		simulationHandler.GetOptimisationJobs,
+	)
+	mux.HandleFunc("GET /optimisation-jobs/{id}", simulationHandler.GetOptimisationJob)
+	mux.HandleFunc("DELETE /optimisation-jobs/{id}", simulationHandler.CancelOptimisationJob)
+	mux.HandleFunc("GET /intersections/{id}/runs", simulationHandler.GetRuns)
+	mux.HandleFunc("GET /intersections/{id}/runs/{runId}", simulationHandler.GetRun)
+	mux.HandleFunc("POST /intersections/{id}/sweeps", simulationHandler.StartSweep)
+	mux.HandleFunc("GET /intersections/{id}/sweeps", simulationHandler.GetSweeps)
+	mux.HandleFunc("GET /intersections/{id}/sweeps/{sweepId}", simulationHandler.GetSweep)
+	mux.HandleFunc("GET /intersections/{id}/sensitivity", simulationHandler.GetSensitivity)
+	recoverCtx, cancel := context.WithTimeout(
+		middleware.SetLogger(context.Background(), logger),
+		30*time.Second,
+	)
+	defer cancel()
+	// NOTE: Interrupted optimisation jobs are not recovered here, as another gateway may
+	// still be running them. The intersection service fails them once their lease expires.
+	if err := simulationService.RecoverSweeps(recoverCtx); err != nil {
+		log.Printf("failed to recover interrupted sweeps: %v", err)
+	}
+
+	// Webhook routes
+	webhookService := service.NewWebhookService(webhookRepo, webhookAddresses)
+	webhookHandler := handler.NewWebhookHandler(webhookService)
+	mux.HandleFunc("POST /webhooks", webhookHandler.CreateWebhook)
+	mux.HandleFunc("GET /webhooks", webhookHandler.GetWebhooks)
+	mux.HandleFunc("DELETE /webhooks/{id}", webhookHandler.DeleteWebhook)
+	mux.HandleFunc("GET /webhooks/{id}/deliveries", webhookHandler.GetWebhookDeliveries)
+	log.Println("Initialized Webhook Handlers.")
+
+	// Notification routes
+	notificationHandler := handler.NewNotificationHandler(notificationService)
+	mux.HandleFunc("GET /notifications", notificationHandler.GetNotifications)
+	mux.HandleFunc("POST /notifications/read-all", notificationHandler.MarkAllRead)
+	mux.HandleFunc("POST /notifications/{id}/read", notificationHandler.MarkRead)
+	log.Println("Initialized Notification Handlers.")
 
 	// Swagger
 	mux.Handle("/docs/", httpSwagger.WrapHandler)
@@ -179,8 +466,10 @@ func setupRoutes(
 		middleware.CORS,
 		middleware.AuthMiddleware(
 			JwtSecret,
+			userClient,
 			"/login",
 			"/register",
+			"/refresh",
 			"/reset-password",
 			"/docs",
 			"/favicon.ico",
@@ -190,13 +479,33 @@ func setupRoutes(
 	)
 }
 
+// serveEvents receives the events other services relay to the gateway, on a port of its own
+// so that it need not be exposed with the API
+func serveEvents(cfg Config, handle events.Handler) {
+	if cfg.EventSecret == "" {
+		log.Println("EVENT_SECRET is not set, not receiving events from other services")
+		return
+	}
+
+	mux := http.NewServeMux()
+	mux.Handle("POST /events", events.NewReceiver(handle, cfg.EventSecret))
+	server := createServer(cfg.EventsPort, mux)
+
+	go func() {
+		log.Printf("Events receiver starting on %s", server.Addr)
+		if err := server.ListenAndServe(); err != nil && err != http.ErrServerClosed {
+			log.Fatalf("Events receiver failed to start: %v", err)
+		}
+	}()
+}
+
 func createServer(port int, handler http.Handler) *http.Server {
 	addr := fmt.Sprintf(":%d", port)
 	return &http.Server{
 		Addr:         addr,
 		Handler:      handler,
 		ReadTimeout:  5 * time.Second,
-		WriteTimeout: 10 * time.Hour,
+		WriteTimeout: 30 * time.Second,
 		IdleTimeout:  15 * time.Second,
 	}
 }
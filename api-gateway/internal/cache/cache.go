@@ -0,0 +1,270 @@
+package cache
+
+import (
+	"context"
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"fmt"
+	"sync"
+
+	"github.com/COS301-SE-2025/Swift-Signals/api-gateway/internal/middleware"
+	"github.com/COS301-SE-2025/Swift-Signals/api-gateway/internal/model"
+	"golang.org/x/sync/singleflight"
+)
+
+// Kind is the simulation service call whose response is cached
+type Kind string
+
+const (
+	KindSimulation Kind = "simulation"
+	KindResults    Kind = "results"
+	// KindWhatIf is kept in memory only, as what-if simulations save nothing
+	KindWhatIf Kind = "whatif"
+)
+
+// persisted reports whether values of the kind are kept in the persistent tier
+func (k Kind) persisted() bool {
+	return k != KindWhatIf
+}
+
+type Key struct {
+	IntersectionID string
+	Kind           Kind
+	ParamsHash     string
+}
+
+func NewKey(intersectionID string, kind Kind, params model.SimulationParameters) Key {
+	return Key{
+		IntersectionID: intersectionID,
+		Kind:           kind,
+		ParamsHash:     HashParameters(params),
+	}
+}
+
+func (k Key) String() string {
+	return k.IntersectionID + "/" + string(k.Kind) + "/" + k.ParamsHash
+}
+
+// HashParameters returns a canonical hash of simulation parameters, so equal parameters
+// share cache entries however they were built
+func HashParameters(params model.SimulationParameters) string {
+	// NOTE: encoding/json writes struct fields in declaration order and cannot fail for
+	// this struct, so equal parameters always encode identically
+	encoded, _ := json.Marshal(params)
+	sum := sha256.Sum256(encoded)
+	return hex.EncodeToString(sum[:])
+}
+
+// Store is a persistent tier of the simulation cache, shared between gateway restarts
+type Store interface {
+	Get(ctx context.Context, key Key) ([]byte, bool, error)
+	Set(ctx context.Context, key Key, value []byte) error
+	Delete(ctx context.Context, key Key) error
+	DeleteIntersection(ctx context.Context, intersectionID string) error
+}
+
+// Loader fetches a value from the simulation service on a cache miss
+type Loader func(ctx context.Context) ([]byte, error)
+
+// SimulationCache caches simulation responses in memory, backed by an optional
+// persistent store. Concurrent fetches of the same key share a single load.
+type SimulationCache struct {
+	memory     *LRU
+	persistent Store
+	group      singleflight.Group
+
+	// NOTE: Only intersections with loads in flight are tracked. Invalidating one bumps
+	// its generation, so loads that started before never store their now stale values.
+	loadsMu sync.Mutex
+	loads   map[string]*intersectionLoads
+}
+
+// intersectionLoads is the generation of an intersection's cached values. It is dropped
+// once the last load of the intersection has finished, since loads that start afterwards
+// cannot be outdated by an earlier invalidation.
+type intersectionLoads struct {
+	generation uint64
+	// NOTE: Counts the callers waiting on loads. Each keeps the entry until its load has
+	// finished, even if the caller itself has gone away.
+	waiting int
+}
+
+// NewSimulationCache keeps up to maxBytes of responses in memory. persistent may be nil.
+func NewSimulationCache(maxBytes int, persistent Store) *SimulationCache {
+	return &SimulationCache{
+		memory:     NewLRU(maxBytes),
+		persistent: persistent,
+		loads:      make(map[string]*intersectionLoads),
+	}
+}
+
+// Fetch returns the cached value of key, calling load to fill the cache on a miss.
+// Failed loads are not cached.
+func (c *SimulationCache) Fetch(ctx context.Context, key Key, load Loader) ([]byte, error) {
+	logger := middleware.LoggerFromContext(ctx).With("cache_key", key.String())
+
+	if value, ok := c.memory.Get(key); ok {
+		logger.Debug("simulation cache hit")
+		return value, nil
+	}
+
+	generation := c.startLoad(key.IntersectionID)
+	flight := fmt.Sprintf("%s#%d", key, generation)
+
+	result := c.group.DoChan(flight, func() (any, error) {
+		// NOTE: The load is shared by every caller of this key, so one caller going away
+		// must not cancel it for the others
+		deadline, hasDeadline := ctx.Deadline()
+		ctx := context.WithoutCancel(ctx)
+
+		if c.persistent != nil && key.Kind.persisted() {
+			value, ok, err := c.persistent.Get(ctx, key)
+			if err != nil {
+				logger.Warn("could not read persistent simulation cache", "error", err.Error())
+			} else if ok {
+				logger.Debug("persistent simulation cache hit")
+				c.store(ctx, key, generation, value, false)
+				return value, nil
+			}
+		}
+
+		logger.Debug("simulation cache miss")
+		// NOTE: The deadline of the caller that started the load still bounds it, so that
+		// callers which need an answer sooner or later than the default get it
+		loadCtx := ctx
+		if hasDeadline {
+			var cancel context.CancelFunc
+			loadCtx, cancel = context.WithDeadline(ctx, deadline)
+			defer cancel()
+		}
+		value, err := load(loadCtx)
+		if err != nil {
+			return nil, err
+		}
+		c.store(ctx, key, generation, value, true)
+		return value, nil
+	})
+
+	select {
+	case <-ctx.Done():
+		// NOTE: The load carries on for other callers and may still store its value
+		go func() {
+			<-result
+			c.finishLoad(key.IntersectionID)
+		}()
+		return nil, ctx.Err()
+	case res := <-result:
+		c.finishLoad(key.IntersectionID)
+		if res.Err != nil {
+			return nil, res.Err
+		}
+		return res.Val.([]byte), nil
+	}
+}
+
+// InvalidateIntersection drops every cached response of an intersection
+func (c *SimulationCache) InvalidateIntersection(ctx context.Context, intersectionID string) {
+	logger := middleware.LoggerFromContext(ctx)
+
+	c.loadsMu.Lock()
+	if loads, ok := c.loads[intersectionID]; ok {
+		loads.generation++
+	}
+	c.loadsMu.Unlock()
+
+	c.memory.DeleteIntersection(intersectionID)
+
+	if c.persistent != nil {
+		if err := c.persistent.DeleteIntersection(ctx, intersectionID); err != nil {
+			logger.Warn("could not invalidate persistent simulation cache",
+				"intersection_id", intersectionID,
+				"error", err.Error(),
+			)
+		}
+	}
+}
+
+// startLoad registers a caller about to wait on a load of the intersection and returns
+// the generation the load belongs to
+func (c *SimulationCache) startLoad(intersectionID string) uint64 {
+	c.loadsMu.Lock()
+	defer c.loadsMu.Unlock()
+	loads, ok := c.loads[intersectionID]
+	if !ok {
+		loads = &intersectionLoads{}
+		c.loads[intersectionID] = loads
+	}
+	loads.waiting++
+	return loads.generation
+}
+
+// finishLoad is called once the load a caller waited on has finished
+func (c *SimulationCache) finishLoad(intersectionID string) {
+	c.loadsMu.Lock()
+	defer c.loadsMu.Unlock()
+	loads := c.loads[intersectionID]
+	loads.waiting--
+	if loads.waiting == 0 {
+		delete(c.loads, intersectionID)
+	}
+}
+
+// generation is only called while a load of the intersection is in flight
+func (c *SimulationCache) generation(intersectionID string) uint64 {
+	c.loadsMu.Lock()
+	defer c.loadsMu.Unlock()
+	return c.loads[intersectionID].generation
+}
+
+// store caches a loaded value unless its intersection was invalidated during the load
+func (c *SimulationCache) store(
+	ctx context.Context,
+	key Key,
+	generation uint64,
+	value []byte,
+	persist bool,
+) {
+	logger := middleware.LoggerFromContext(ctx)
+
+	// NOTE: Set under the lock InvalidateIntersection bumps the generation under, so an
+	// invalidation either stops the value being set or deletes it afterwards
+	c.loadsMu.Lock()
+	current := c.loads[key.IntersectionID].generation == generation
+	if current {
+		c.memory.Set(key, value)
+	}
+	c.loadsMu.Unlock()
+
+	if !current || !persist || c.persistent == nil || !key.Kind.persisted() {
+		return
+	}
+
+	if err := c.persistent.Set(ctx, key, value); err != nil {
+		logger.Warn("could not write persistent simulation cache",
+			"cache_key", key.String(),
+			"error", err.Error(),
+		)
+		return
+	}
+
+	// NOTE: The write may land after an invalidation made during it has already deleted
+	// the intersection's values, so it is taken back rather than outliving restarts
+	if c.generation(key.IntersectionID) != generation {
+		if err := c.persistent.Delete(ctx, key); err != nil {
+			logger.Warn("could not remove stale persistent simulation cache entry",
+				"cache_key", key.String(),
+				"error", err.Error(),
+			)
+		}
+	}
+}
+
+// NOTE: Creates stub for testing
+type SimulationCacheInterface interface {
+	Fetch(ctx context.Context, key Key, load Loader) ([]byte, error)
+	InvalidateIntersection(ctx context.Context, intersectionID string)
+}
+
+// NOTE: Asserts Interface Implementation
+var _ SimulationCacheInterface = (*SimulationCache)(nil)
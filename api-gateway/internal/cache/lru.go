@@ -0,0 +1,86 @@
+package cache
+
+import (
+	"container/list"
+	"sync"
+)
+
+// LRU is an in-memory cache which evicts the least recently used values once their
+// combined size exceeds its budget
+type LRU struct {
+	mu       sync.Mutex
+	maxBytes int
+	size     int
+	order    *list.List
+	entries  map[Key]*list.Element
+}
+
+type lruEntry struct {
+	key   Key
+	value []byte
+}
+
+func NewLRU(maxBytes int) *LRU {
+	return &LRU{
+		maxBytes: maxBytes,
+		order:    list.New(),
+		entries:  make(map[Key]*list.Element),
+	}
+}
+
+func (l *LRU) Get(key Key) ([]byte, bool) {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+
+	element, ok := l.entries[key]
+	if !ok {
+		return nil, false
+	}
+	l.order.MoveToFront(element)
+	return element.Value.(*lruEntry).value, true
+}
+
+// Set stores a value, evicting older ones as needed. Values larger than the whole
+// budget are not stored.
+func (l *LRU) Set(key Key, value []byte) {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+
+	if element, ok := l.entries[key]; ok {
+		l.remove(element)
+	}
+	if len(value) > l.maxBytes {
+		return
+	}
+
+	l.entries[key] = l.order.PushFront(&lruEntry{key: key, value: value})
+	l.size += len(value)
+
+	for l.size > l.maxBytes {
+		l.remove(l.order.Back())
+	}
+}
+
+func (l *LRU) DeleteIntersection(intersectionID string) {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+
+	for key, element := range l.entries {
+		if key.IntersectionID == intersectionID {
+			l.remove(element)
+		}
+	}
+}
+
+// Size returns the combined size of the stored values in bytes
+func (l *LRU) Size() int {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+	return l.size
+}
+
+func (l *LRU) remove(element *list.Element) {
+	entry := l.order.Remove(element).(*lruEntry)
+	delete(l.entries, entry.key)
+	l.size -= len(entry.value)
+}
@@ -0,0 +1,84 @@
+package cache
+
+import (
+	"context"
+	"errors"
+	"time"
+
+	"go.mongodb.org/mongo-driver/bson"
+	"go.mongodb.org/mongo-driver/mongo"
+	"go.mongodb.org/mongo-driver/mongo/options"
+)
+
+// MongoStore persists cached simulation responses in a Mongo collection, expiring them
+// after a fixed time to live
+type MongoStore struct {
+	collection *mongo.Collection
+}
+
+type mongoEntry struct {
+	ID             string `bson:"_id"`
+	IntersectionID string
+	Value          []byte
+	CreatedAt      time.Time
+}
+
+// NewMongoStore prepares the collection's indexes, so it needs a reachable database
+func NewMongoStore(
+	ctx context.Context,
+	collection *mongo.Collection,
+	ttl time.Duration,
+) (*MongoStore, error) {
+	_, err := collection.Indexes().CreateMany(ctx, []mongo.IndexModel{
+		{Keys: bson.D{{Key: "intersectionid", Value: 1}}},
+		{
+			Keys:    bson.D{{Key: "createdat", Value: 1}},
+			Options: options.Index().SetExpireAfterSeconds(int32(ttl.Seconds())),
+		},
+	})
+	if err != nil {
+		return nil, err
+	}
+	return &MongoStore{collection: collection}, nil
+}
+
+func (s *MongoStore) Get(ctx context.Context, key Key) ([]byte, bool, error) {
+	var entry mongoEntry
+	err := s.collection.FindOne(ctx, bson.M{"_id": key.String()}).Decode(&entry)
+	if errors.Is(err, mongo.ErrNoDocuments) {
+		return nil, false, nil
+	}
+	if err != nil {
+		return nil, false, err
+	}
+	return entry.Value, true, nil
+}
+
+func (s *MongoStore) Set(ctx context.Context, key Key, value []byte) error {
+	entry := mongoEntry{
+		ID:             key.String(),
+		IntersectionID: key.IntersectionID,
+		Value:          value,
+		CreatedAt:      time.Now(),
+	}
+	_, err := s.collection.ReplaceOne(
+		ctx,
+		bson.M{"_id": entry.ID},
+		entry,
+		options.Replace().SetUpsert(true),
+	)
+	return err
+}
+
+func (s *MongoStore) Delete(ctx context.Context, key Key) error {
+	_, err := s.collection.DeleteOne(ctx, bson.M{"_id": key.String()})
+	return err
+}
+
+func (s *MongoStore) DeleteIntersection(ctx context.Context, intersectionID string) error {
+	_, err := s.collection.DeleteMany(ctx, bson.M{"intersectionid": intersectionID})
+	return err
+}
+
+// NOTE: Asserts Interface Implementation
+var _ Store = (*MongoStore)(nil)
@@ -0,0 +1,108 @@
+package test
+
+import (
+	"context"
+	"errors"
+	"sync"
+	"testing"
+
+	"github.com/COS301-SE-2025/Swift-Signals/api-gateway/internal/cache"
+	"github.com/COS301-SE-2025/Swift-Signals/api-gateway/internal/model"
+	"github.com/stretchr/testify/suite"
+)
+
+type TestSuite struct {
+	suite.Suite
+	store *fakeStore
+	cache *cache.SimulationCache
+}
+
+func (suite *TestSuite) SetupTest() {
+	suite.store = newFakeStore()
+	suite.cache = cache.NewSimulationCache(1<<20, suite.store)
+}
+
+// fakeStore is an in-memory persistent tier
+type fakeStore struct {
+	mu      sync.Mutex
+	entries map[cache.Key][]byte
+	err     error
+	// beforeSet is called as a write starts, before it lands
+	beforeSet func()
+}
+
+func newFakeStore() *fakeStore {
+	return &fakeStore{entries: make(map[cache.Key][]byte)}
+}
+
+func (s *fakeStore) Get(ctx context.Context, key cache.Key) ([]byte, bool, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	if s.err != nil {
+		return nil, false, s.err
+	}
+	value, ok := s.entries[key]
+	return value, ok, nil
+}
+
+func (s *fakeStore) Set(ctx context.Context, key cache.Key, value []byte) error {
+	if s.beforeSet != nil {
+		s.beforeSet()
+	}
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	if s.err != nil {
+		return s.err
+	}
+	s.entries[key] = value
+	return nil
+}
+
+func (s *fakeStore) Delete(ctx context.Context, key cache.Key) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	delete(s.entries, key)
+	return nil
+}
+
+func (s *fakeStore) DeleteIntersection(ctx context.Context, intersectionID string) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	for key := range s.entries {
+		if key.IntersectionID == intersectionID {
+			delete(s.entries, key)
+		}
+	}
+	return nil
+}
+
+func (s *fakeStore) len() int {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	return len(s.entries)
+}
+
+var errLoad = errors.New("simulation failed")
+
+func createTestParameters(green int) model.SimulationParameters {
+	return model.SimulationParameters{
+		IntersectionType: "INTERSECTION_TYPE_TRAFFICLIGHT",
+		Green:            green,
+		Yellow:           3,
+		Red:              7,
+		Speed:            60,
+		Seed:             12345,
+	}
+}
+
+// countingLoader returns value and counts how often it was called
+func countingLoader(value []byte, calls *int) cache.Loader {
+	return func(ctx context.Context) ([]byte, error) {
+		*calls++
+		return value, nil
+	}
+}
+
+func TestCache(t *testing.T) {
+	suite.Run(t, new(TestSuite))
+}
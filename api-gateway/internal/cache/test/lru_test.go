@@ -0,0 +1,78 @@
+package test
+
+import (
+	"github.com/COS301-SE-2025/Swift-Signals/api-gateway/internal/cache"
+)
+
+func (suite *TestSuite) TestLRU_EvictsLeastRecentlyUsed() {
+	lru := cache.NewLRU(10)
+	first := cache.NewKey("intersection-123", cache.KindSimulation, createTestParameters(1))
+	second := cache.NewKey("intersection-123", cache.KindSimulation, createTestParameters(2))
+	third := cache.NewKey("intersection-123", cache.KindSimulation, createTestParameters(3))
+
+	lru.Set(first, []byte("aaaa"))
+	lru.Set(second, []byte("bbbb"))
+	_, ok := lru.Get(first)
+	suite.True(ok)
+
+	lru.Set(third, []byte("cccc"))
+
+	_, ok = lru.Get(second)
+	suite.False(ok)
+	_, ok = lru.Get(first)
+	suite.True(ok)
+	_, ok = lru.Get(third)
+	suite.True(ok)
+	suite.Equal(8, lru.Size())
+}
+
+func (suite *TestSuite) TestLRU_SkipsValuesOverBudget() {
+	lru := cache.NewLRU(4)
+	key := cache.NewKey("intersection-123", cache.KindSimulation, createTestParameters(1))
+
+	lru.Set(key, []byte("too large"))
+
+	_, ok := lru.Get(key)
+	suite.False(ok)
+	suite.Equal(0, lru.Size())
+}
+
+func (suite *TestSuite) TestLRU_ReplacesExistingValue() {
+	lru := cache.NewLRU(10)
+	key := cache.NewKey("intersection-123", cache.KindSimulation, createTestParameters(1))
+
+	lru.Set(key, []byte("aaaa"))
+	lru.Set(key, []byte("bb"))
+
+	value, ok := lru.Get(key)
+	suite.True(ok)
+	suite.Equal([]byte("bb"), value)
+	suite.Equal(2, lru.Size())
+}
+
+func (suite *TestSuite) TestLRU_DeleteIntersection() {
+	lru := cache.NewLRU(100)
+	deleted := cache.NewKey("intersection-123", cache.KindSimulation, createTestParameters(1))
+	kept := cache.NewKey("intersection-456", cache.KindSimulation, createTestParameters(1))
+
+	lru.Set(deleted, []byte("aaaa"))
+	lru.Set(kept, []byte("bbbb"))
+	lru.DeleteIntersection("intersection-123")
+
+	_, ok := lru.Get(deleted)
+	suite.False(ok)
+	_, ok = lru.Get(kept)
+	suite.True(ok)
+	suite.Equal(4, lru.Size())
+}
+
+func (suite *TestSuite) TestHashParameters_Canonical() {
+	suite.Equal(
+		cache.HashParameters(createTestParameters(10)),
+		cache.HashParameters(createTestParameters(10)),
+	)
+	suite.NotEqual(
+		cache.HashParameters(createTestParameters(10)),
+		cache.HashParameters(createTestParameters(11)),
+	)
+}
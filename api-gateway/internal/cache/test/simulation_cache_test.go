@@ -0,0 +1,297 @@
+package test
+
+import (
+	"context"
+	"sync"
+	"sync/atomic"
+	"time"
+
+	"github.com/COS301-SE-2025/Swift-Signals/api-gateway/internal/cache"
+)
+
+func (suite *TestSuite) TestFetch_CachesLoadedValue() {
+	ctx := context.Background()
+	key := cache.NewKey("intersection-123", cache.KindSimulation, createTestParameters(10))
+
+	calls := 0
+	first, err := suite.cache.Fetch(ctx, key, countingLoader([]byte("results"), &calls))
+	suite.Require().NoError(err)
+	second, err := suite.cache.Fetch(ctx, key, countingLoader([]byte("other"), &calls))
+	suite.Require().NoError(err)
+
+	suite.Equal([]byte("results"), first)
+	suite.Equal([]byte("results"), second)
+	suite.Equal(1, calls)
+	suite.Equal(1, suite.store.len())
+}
+
+func (suite *TestSuite) TestFetch_KeysDifferByParametersAndIntersection() {
+	ctx := context.Background()
+	calls := 0
+	load := countingLoader([]byte("value"), &calls)
+
+	keys := []cache.Key{
+		cache.NewKey("intersection-123", cache.KindSimulation, createTestParameters(10)),
+		cache.NewKey("intersection-123", cache.KindSimulation, createTestParameters(20)),
+		cache.NewKey("intersection-456", cache.KindSimulation, createTestParameters(10)),
+	}
+	for _, key := range keys {
+		_, err := suite.cache.Fetch(ctx, key, load)
+		suite.Require().NoError(err)
+	}
+
+	suite.Equal(len(keys), calls)
+}
+
+func (suite *TestSuite) TestFetch_ErrorsAreNotCached() {
+	ctx := context.Background()
+	key := cache.NewKey("intersection-123", cache.KindSimulation, createTestParameters(10))
+
+	_, err := suite.cache.Fetch(ctx, key, func(ctx context.Context) ([]byte, error) {
+		return nil, errLoad
+	})
+	suite.ErrorIs(err, errLoad)
+
+	calls := 0
+	value, err := suite.cache.Fetch(ctx, key, countingLoader([]byte("results"), &calls))
+	suite.Require().NoError(err)
+	suite.Equal([]byte("results"), value)
+	suite.Equal(1, calls)
+}
+
+func (suite *TestSuite) TestFetch_PersistentTierHit() {
+	ctx := context.Background()
+	key := cache.NewKey("intersection-123", cache.KindSimulation, createTestParameters(10))
+	suite.Require().NoError(suite.store.Set(ctx, key, []byte("persisted")))
+
+	calls := 0
+	value, err := suite.cache.Fetch(ctx, key, countingLoader([]byte("results"), &calls))
+
+	suite.Require().NoError(err)
+	suite.Equal([]byte("persisted"), value)
+	suite.Equal(0, calls)
+}
+
+func (suite *TestSuite) TestFetch_WhatIfKeptInMemoryOnly() {
+	ctx := context.Background()
+	key := cache.NewKey("intersection-123", cache.KindWhatIf, createTestParameters(10))
+	suite.Require().NoError(suite.store.Set(ctx, key, []byte("persisted")))
+
+	calls := 0
+	first, err := suite.cache.Fetch(ctx, key, countingLoader([]byte("results"), &calls))
+	suite.Require().NoError(err)
+	second, err := suite.cache.Fetch(ctx, key, countingLoader([]byte("other"), &calls))
+	suite.Require().NoError(err)
+
+	// NOTE: The persistent tier is neither read nor written
+	suite.Equal([]byte("results"), first)
+	suite.Equal([]byte("results"), second)
+	suite.Equal(1, calls)
+	suite.Equal(1, suite.store.len())
+}
+
+func (suite *TestSuite) TestFetch_PersistentTierFailureFallsBackToLoad() {
+	ctx := context.Background()
+	key := cache.NewKey("intersection-123", cache.KindSimulation, createTestParameters(10))
+	suite.store.err = errLoad
+
+	calls := 0
+	value, err := suite.cache.Fetch(ctx, key, countingLoader([]byte("results"), &calls))
+
+	suite.Require().NoError(err)
+	suite.Equal([]byte("results"), value)
+	suite.Equal(1, calls)
+}
+
+func (suite *TestSuite) TestFetch_CollapsesConcurrentLoads() {
+	ctx := context.Background()
+	key := cache.NewKey("intersection-123", cache.KindSimulation, createTestParameters(10))
+
+	var calls atomic.Int32
+	release := make(chan struct{})
+	load := func(ctx context.Context) ([]byte, error) {
+		calls.Add(1)
+		<-release
+		return []byte("results"), nil
+	}
+
+	const callers = 8
+	var wg sync.WaitGroup
+	values := make([][]byte, callers)
+	for i := range callers {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			value, err := suite.cache.Fetch(ctx, key, load)
+			suite.NoError(err)
+			values[i] = value
+		}()
+	}
+
+	// NOTE: Gives every caller the chance to join the load before it completes
+	time.Sleep(50 * time.Millisecond)
+	close(release)
+	wg.Wait()
+
+	suite.Equal(int32(1), calls.Load())
+	for _, value := range values {
+		suite.Equal([]byte("results"), value)
+	}
+}
+
+func (suite *TestSuite) TestFetch_CallerCancelled() {
+	ctx, cancel := context.WithCancel(context.Background())
+	key := cache.NewKey("intersection-123", cache.KindSimulation, createTestParameters(10))
+
+	release := make(chan struct{})
+	loaded := make(chan struct{})
+	go func() {
+		defer close(loaded)
+		_, err := suite.cache.Fetch(ctx, key, func(ctx context.Context) ([]byte, error) {
+			<-release
+			return []byte("results"), ctx.Err()
+		})
+		suite.ErrorIs(err, context.Canceled)
+	}()
+
+	cancel()
+	<-loaded
+	close(release)
+
+	// NOTE: The abandoned load still completes and fills the cache for later callers
+	suite.Eventually(func() bool {
+		return suite.store.len() == 1
+	}, time.Second, 10*time.Millisecond)
+}
+
+func (suite *TestSuite) TestFetch_LoadKeepsCallerDeadline() {
+	deadline := time.Now().Add(time.Minute)
+	ctx, cancel := context.WithDeadline(context.Background(), deadline)
+	defer cancel()
+	key := cache.NewKey("intersection-123", cache.KindSimulation, createTestParameters(10))
+
+	var loadDeadline time.Time
+	_, err := suite.cache.Fetch(ctx, key, func(ctx context.Context) ([]byte, error) {
+		loadDeadline, _ = ctx.Deadline()
+		return []byte("results"), nil
+	})
+
+	suite.Require().NoError(err)
+	suite.Equal(deadline, loadDeadline)
+}
+
+func (suite *TestSuite) TestInvalidateIntersection() {
+	ctx := context.Background()
+	invalidated := cache.NewKey("intersection-123", cache.KindSimulation, createTestParameters(10))
+	kept := cache.NewKey("intersection-456", cache.KindSimulation, createTestParameters(10))
+
+	calls := 0
+	load := countingLoader([]byte("results"), &calls)
+	for _, key := range []cache.Key{invalidated, kept} {
+		_, err := suite.cache.Fetch(ctx, key, load)
+		suite.Require().NoError(err)
+	}
+
+	suite.cache.InvalidateIntersection(ctx, "intersection-123")
+	suite.Equal(1, suite.store.len())
+
+	_, err := suite.cache.Fetch(ctx, kept, load)
+	suite.Require().NoError(err)
+	suite.Equal(2, calls)
+
+	_, err = suite.cache.Fetch(ctx, invalidated, load)
+	suite.Require().NoError(err)
+	suite.Equal(3, calls)
+}
+
+func (suite *TestSuite) TestInvalidateIntersection_DuringLoad() {
+	ctx := context.Background()
+	key := cache.NewKey("intersection-123", cache.KindSimulation, createTestParameters(10))
+
+	started := make(chan struct{})
+	release := make(chan struct{})
+	done := make(chan struct{})
+	go func() {
+		defer close(done)
+		value, err := suite.cache.Fetch(ctx, key, func(ctx context.Context) ([]byte, error) {
+			close(started)
+			<-release
+			return []byte("stale"), nil
+		})
+		suite.NoError(err)
+		suite.Equal([]byte("stale"), value)
+	}()
+
+	<-started
+	suite.cache.InvalidateIntersection(ctx, "intersection-123")
+	close(release)
+	<-done
+
+	calls := 0
+	value, err := suite.cache.Fetch(ctx, key, countingLoader([]byte("fresh"), &calls))
+	suite.Require().NoError(err)
+	suite.Equal([]byte("fresh"), value)
+	suite.Equal(1, calls)
+}
+
+func (suite *TestSuite) TestInvalidateIntersection_DuringAbandonedLoad() {
+	ctx, cancel := context.WithCancel(context.Background())
+	key := cache.NewKey("intersection-123", cache.KindSimulation, createTestParameters(10))
+
+	started := make(chan struct{})
+	release := make(chan struct{})
+	finished := make(chan struct{})
+	left := make(chan struct{})
+	go func() {
+		defer close(left)
+		_, err := suite.cache.Fetch(ctx, key, func(ctx context.Context) ([]byte, error) {
+			close(started)
+			<-release
+			defer close(finished)
+			return []byte("stale"), nil
+		})
+		suite.ErrorIs(err, context.Canceled)
+	}()
+
+	// NOTE: The caller leaves before the intersection is invalidated, but its load is still
+	// running and must not store its outdated value
+	<-started
+	cancel()
+	<-left
+	suite.cache.InvalidateIntersection(context.Background(), "intersection-123")
+	close(release)
+	<-finished
+
+	calls := 0
+	load := countingLoader([]byte("fresh"), &calls)
+	for range 2 {
+		value, err := suite.cache.Fetch(context.Background(), key, load)
+		suite.Require().NoError(err)
+		suite.Equal([]byte("fresh"), value)
+	}
+	suite.Equal(1, calls)
+}
+
+func (suite *TestSuite) TestInvalidateIntersection_DuringPersistentWrite() {
+	ctx := context.Background()
+	key := cache.NewKey("intersection-123", cache.KindSimulation, createTestParameters(10))
+
+	// NOTE: The invalidation deletes the intersection's values before the write lands
+	suite.store.beforeSet = func() {
+		suite.store.beforeSet = nil
+		suite.cache.InvalidateIntersection(ctx, "intersection-123")
+	}
+
+	value, err := suite.cache.Fetch(ctx, key, func(ctx context.Context) ([]byte, error) {
+		return []byte("stale"), nil
+	})
+	suite.Require().NoError(err)
+	suite.Equal([]byte("stale"), value)
+	suite.Equal(0, suite.store.len())
+
+	calls := 0
+	value, err = suite.cache.Fetch(ctx, key, countingLoader([]byte("fresh"), &calls))
+	suite.Require().NoError(err)
+	suite.Equal([]byte("fresh"), value)
+	suite.Equal(1, calls)
+}
@@ -0,0 +1,154 @@
+package client
+
+import (
+	"context"
+
+	"github.com/COS301-SE-2025/Swift-Signals/api-gateway/internal/cache"
+	"github.com/COS301-SE-2025/Swift-Signals/api-gateway/internal/model"
+	simulationpb "github.com/COS301-SE-2025/Swift-Signals/protos/gen/swiftsignals/simulation/v1"
+	errs "github.com/COS301-SE-2025/Swift-Signals/shared/error"
+	"google.golang.org/protobuf/proto"
+)
+
+// CachedSimulationClient answers simulation requests from a cache, only calling the
+// simulation service for parameters it has not simulated for an intersection before.
+// NOTE: Simulations are deterministic as their parameters include a fixed seed
+type CachedSimulationClient struct {
+	client SimulationClientInterface
+	cache  cache.SimulationCacheInterface
+}
+
+type cacheReportKey struct{}
+
+// CacheReport tells the caller of a simulation whether it was answered from the cache,
+// without the simulation service being called for it
+type CacheReport struct {
+	Hit bool
+}
+
+// WithCacheReport returns a context through which a cached client reports whether the
+// simulations asked for with it were answered from the cache
+func WithCacheReport(ctx context.Context) (context.Context, *CacheReport) {
+	report := &CacheReport{}
+	return context.WithValue(ctx, cacheReportKey{}, report), report
+}
+
+func NewCachedSimulationClient(
+	client SimulationClientInterface,
+	cache cache.SimulationCacheInterface,
+) *CachedSimulationClient {
+	return &CachedSimulationClient{
+		client: client,
+		cache:  cache,
+	}
+}
+
+func (cc *CachedSimulationClient) RunSimulation(
+	ctx context.Context,
+	id string,
+	simulation_parameters model.SimulationParameters,
+) (*simulationpb.SimulationResponse, error) {
+	resp := &simulationpb.SimulationResponse{}
+	err := cc.fetch(
+		ctx,
+		cache.NewKey(id, cache.KindSimulation, simulation_parameters),
+		resp,
+		func(ctx context.Context) (proto.Message, error) {
+			return cc.client.RunSimulation(ctx, id, simulation_parameters)
+		},
+	)
+	if err != nil {
+		return nil, err
+	}
+	return resp, nil
+}
+
+// RunWhatIfSimulation is cached in memory only, as what-if simulations save nothing
+func (cc *CachedSimulationClient) RunWhatIfSimulation(
+	ctx context.Context,
+	id string,
+	simulation_parameters model.SimulationParameters,
+) (*simulationpb.SimulationResponse, error) {
+	resp := &simulationpb.SimulationResponse{}
+	err := cc.fetch(
+		ctx,
+		cache.NewKey(id, cache.KindWhatIf, simulation_parameters),
+		resp,
+		func(ctx context.Context) (proto.Message, error) {
+			return cc.client.RunWhatIfSimulation(ctx, id, simulation_parameters)
+		},
+	)
+	if err != nil {
+		return nil, err
+	}
+	return resp, nil
+}
+
+// GetSimulationResults is cached apart from RunSimulation, so that callers after metrics
+// alone never hold whole outputs in the cache
+func (cc *CachedSimulationClient) GetSimulationResults(
+	ctx context.Context,
+	id string,
+	simulation_parameters model.SimulationParameters,
+) (*simulationpb.SimulationResultsResponse, error) {
+	resp := &simulationpb.SimulationResultsResponse{}
+	err := cc.fetch(
+		ctx,
+		cache.NewKey(id, cache.KindResults, simulation_parameters),
+		resp,
+		func(ctx context.Context) (proto.Message, error) {
+			return cc.client.GetSimulationResults(ctx, id, simulation_parameters)
+		},
+	)
+	if err != nil {
+		return nil, err
+	}
+	return resp, nil
+}
+
+// StreamSimulationOutput is not cached, as streaming exists so that large outputs are
+// never held in memory whole
+func (cc *CachedSimulationClient) StreamSimulationOutput(
+	ctx context.Context,
+	id string,
+	simulation_parameters model.SimulationParameters,
+) (simulationpb.SimulationService_StreamSimulationOutputClient, error) {
+	return cc.client.StreamSimulationOutput(ctx, id, simulation_parameters)
+}
+
+// fetch reads the response cached under key into resp, calling load on a cache miss
+func (cc *CachedSimulationClient) fetch(
+	ctx context.Context,
+	key cache.Key,
+	resp proto.Message,
+	load func(ctx context.Context) (proto.Message, error),
+) error {
+	// NOTE: Callers sharing a load started by another caller are answered from the cache
+	loaded := false
+	value, err := cc.cache.Fetch(ctx, key, func(ctx context.Context) ([]byte, error) {
+		loaded = true
+		msg, err := load(ctx)
+		if err != nil {
+			return nil, err
+		}
+		return proto.Marshal(msg)
+	})
+	if err != nil {
+		return err
+	}
+
+	if err := proto.Unmarshal(value, resp); err != nil {
+		return errs.NewInternalError(
+			"unable to decode cached simulation",
+			err,
+			map[string]any{"cacheKey": key.String()},
+		)
+	}
+	if report, ok := ctx.Value(cacheReportKey{}).(*CacheReport); ok {
+		report.Hit = !loaded
+	}
+	return nil
+}
+
+// NOTE: Asserts Interface Implementation
+var _ SimulationClientInterface = (*CachedSimulationClient)(nil)
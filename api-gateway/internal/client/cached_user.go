@@ -0,0 +1,154 @@
+package client
+
+import (
+	"context"
+	"sync"
+	"time"
+
+	"github.com/COS301-SE-2025/Swift-Signals/api-gateway/internal/middleware"
+	"google.golang.org/protobuf/types/known/emptypb"
+)
+
+// CachedUserClient remembers whether tokens are revoked for a short time, so that the
+// user service is not asked on every authenticated request. Logging out or deleting a user
+// through it forgets what it remembered about that user's tokens straight away, including
+// answers to lookups still in flight.
+// NOTE: Other gateway instances keep accepting a revoked token for up to the time to live,
+// as does every instance after a password reset, which is not made by a logged in user
+type CachedUserClient struct {
+	UserClientInterface
+	ttl time.Duration
+
+	mu        sync.Mutex
+	users     map[string]*userRevocations
+	nextPrune time.Time
+}
+
+// userRevocations holds what is remembered about a user's tokens. A lookup only caches
+// its answer if the generation is the one it started with, which forgetting the user's
+// tokens moves on. The user is kept while lookups are in flight, so that it does.
+type userRevocations struct {
+	tokens     map[tokenKey]revocation
+	generation uint64
+	lookups    int
+}
+
+// tokenKey tells tokens apart by their ID, and by when they were issued for tokens issued
+// before they had one
+type tokenKey struct {
+	id       string
+	issuedAt int64
+}
+
+type revocation struct {
+	revoked bool
+	expires time.Time
+}
+
+func NewCachedUserClient(client UserClientInterface, ttl time.Duration) *CachedUserClient {
+	return &CachedUserClient{
+		UserClientInterface: client,
+		ttl:                 ttl,
+		users:               make(map[string]*userRevocations),
+	}
+}
+
+func (cc *CachedUserClient) IsTokenRevoked(
+	ctx context.Context,
+	userID, tokenID string,
+	issuedAt time.Time,
+) (bool, error) {
+	now := time.Now()
+	key := tokenKey{id: tokenID, issuedAt: issuedAt.Unix()}
+
+	cc.mu.Lock()
+	user, ok := cc.users[userID]
+	if !ok {
+		user = &userRevocations{tokens: make(map[tokenKey]revocation)}
+		cc.users[userID] = user
+	}
+	if entry, ok := user.tokens[key]; ok && now.Before(entry.expires) {
+		cc.mu.Unlock()
+		return entry.revoked, nil
+	}
+	generation := user.generation
+	user.lookups++
+	cc.mu.Unlock()
+
+	revoked, err := cc.UserClientInterface.IsTokenRevoked(ctx, userID, tokenID, issuedAt)
+
+	cc.mu.Lock()
+	defer cc.mu.Unlock()
+	user.lookups--
+	if err != nil {
+		return false, err
+	}
+	// NOTE: An answer given before the user's tokens were forgotten may already be stale,
+	// so it is returned but not remembered
+	if user.generation == generation {
+		user.tokens[key] = revocation{revoked: revoked, expires: now.Add(cc.ttl)}
+	}
+	cc.prune(now)
+	return revoked, nil
+}
+
+// LogoutUser forgets the logged out user's tokens, as the token is only known by its
+// content and not its ID
+func (cc *CachedUserClient) LogoutUser(
+	ctx context.Context,
+	token, refreshToken string,
+) (*emptypb.Empty, error) {
+	resp, err := cc.UserClientInterface.LogoutUser(ctx, token, refreshToken)
+	if err != nil {
+		return nil, err
+	}
+	if userID, ok := middleware.GetUserID(ctx); ok {
+		cc.forget(userID)
+	}
+	return resp, nil
+}
+
+func (cc *CachedUserClient) DeleteUser(ctx context.Context, userID string) (*emptypb.Empty, error) {
+	resp, err := cc.UserClientInterface.DeleteUser(ctx, userID)
+	if err != nil {
+		return nil, err
+	}
+	cc.forget(userID)
+	return resp, nil
+}
+
+func (cc *CachedUserClient) forget(userID string) {
+	cc.mu.Lock()
+	defer cc.mu.Unlock()
+	user, ok := cc.users[userID]
+	if !ok {
+		return
+	}
+	user.tokens = make(map[tokenKey]revocation)
+	user.generation++
+	if user.lookups == 0 {
+		delete(cc.users, userID)
+	}
+}
+
+// prune drops expired entries at most once per time to live, so that tokens which are
+// never seen again do not pile up. The caller must hold mu.
+func (cc *CachedUserClient) prune(now time.Time) {
+	if now.Before(cc.nextPrune) {
+		return
+	}
+	cc.nextPrune = now.Add(cc.ttl)
+	for userID, user := range cc.users {
+		for key, entry := range user.tokens {
+			if !now.Before(entry.expires) {
+				delete(user.tokens, key)
+			}
+		}
+		if len(user.tokens) == 0 && user.lookups == 0 {
+			delete(cc.users, userID)
+		}
+	}
+}
+
+// NOTE: Asserts Interface Implementation
+var _ UserClientInterface = (*CachedUserClient)(nil)
@@ -10,7 +10,9 @@ import (
 	"github.com/COS301-SE-2025/Swift-Signals/api-gateway/internal/util"
 	commonpb "github.com/COS301-SE-2025/Swift-Signals/protos/gen/swiftsignals/common/v1"
 	intersectionpb "github.com/COS301-SE-2025/Swift-Signals/protos/gen/swiftsignals/intersection/v1"
+	simulationpb "github.com/COS301-SE-2025/Swift-Signals/protos/gen/swiftsignals/simulation/v1"
 	"google.golang.org/grpc"
+	"google.golang.org/protobuf/types/known/durationpb"
 	"google.golang.org/protobuf/types/known/emptypb"
 )
 
@@ -35,12 +37,14 @@ func NewIntersectionClientWithGrpcClient(
 func (ic *IntersectionClient) CreateIntersection(
 	ctx context.Context,
 	intersection model.Intersection,
+	userID string,
 ) (*intersectionpb.IntersectionResponse, error) {
 	req := &intersectionpb.CreateIntersectionRequest{
 		Name:              intersection.Name,
 		Details:           convertDetailsToProto(intersection.Details),
 		TrafficDensity:    StringToTrafficDensity(intersection.TrafficDensity),
 		DefaultParameters: convertParametersToProto(intersection.DefaultParameters),
+		UserId:            userID,
 	}
 
 	ctx, cancel := context.WithTimeout(ctx, 5*time.Second)
@@ -82,15 +86,59 @@ func (ic *IntersectionClient) GetAllIntersections(
 	return ic.client.GetAllIntersections(ctx, req)
 }
 
+// ListIntersectionsAfter streams a page of the intersections, in the trash or not, whose ID
+// sorts after afterID, starting at the first when afterID is empty
+func (ic *IntersectionClient) ListIntersectionsAfter(
+	ctx context.Context,
+	afterID string,
+	pageSize int,
+) (intersectionpb.IntersectionService_GetAllIntersectionsClient, error) {
+	req := &intersectionpb.GetAllIntersectionsRequest{
+		PageSize: int32(pageSize),
+		ById:     true,
+		AfterId:  afterID,
+	}
+
+	return ic.client.GetAllIntersections(ctx, req)
+}
+
+// GetDeletedIntersections streams those of the given intersections that are in the trash
+func (ic *IntersectionClient) GetDeletedIntersections(
+	ctx context.Context,
+	ids string,
+) (intersectionpb.IntersectionService_GetAllIntersectionsClient, error) {
+	req := &intersectionpb.GetAllIntersectionsRequest{
+		Page:     1,
+		PageSize: 100,
+		Filter:   ids,
+		Deleted:  true,
+	}
+
+	return ic.client.GetAllIntersections(ctx, req)
+}
+
 func (ic *IntersectionClient) UpdateIntersection(
 	ctx context.Context,
 	id, name string,
 	details model.Details,
+	density string,
+	defaultParameters *model.OptimisationParameters,
+	userID string,
+	expectedVersion int,
 ) (*intersectionpb.IntersectionResponse, error) {
 	req := &intersectionpb.UpdateIntersectionRequest{
-		Id:      id,
-		Name:    name,
-		Details: convertDetailsToProto(details),
+		Id:              id,
+		Name:            name,
+		Details:         convertDetailsToProto(details),
+		UserId:          userID,
+		ExpectedVersion: int32(expectedVersion),
+	}
+	// NOTE: Left unset, the intersection service keeps the current density and defaults
+	if density != "" {
+		req.TrafficDensity = StringToTrafficDensity(density)
+	}
+	if defaultParameters != nil {
+		req.DefaultParameters = convertParametersToProto(*defaultParameters)
 	}
 
 	ctx, cancel := context.WithTimeout(ctx, 5*time.Second)
@@ -102,17 +150,37 @@ func (ic *IntersectionClient) UpdateIntersection(
 	return resp, nil
 }
 
+// UpdateIntersectionStatus changes only the status of an intersection, so that it keeps
+// any edits made since the caller read it
 func (ic *IntersectionClient) UpdateIntersectionStatus(
 	ctx context.Context,
-	id, name string,
-	details model.Details,
+	id string,
 	status commonpb.IntersectionStatus,
 ) (*intersectionpb.IntersectionResponse, error) {
 	req := &intersectionpb.UpdateIntersectionRequest{
-		Id:      id,
-		Name:    name,
-		Details: convertDetailsToProto(details),
-		Status:  status,
+		Id:     id,
+		Status: status,
+	}
+
+	ctx, cancel := context.WithTimeout(ctx, 5*time.Second)
+	defer cancel()
+	resp, err := ic.client.UpdateIntersection(ctx, req)
+	if err != nil {
+		return nil, util.GrpcErrorToErr(err)
+	}
+	return resp, nil
+}
+
+// FailIntersection marks only the status of an intersection as failed, for the given reason
+func (ic *IntersectionClient) FailIntersection(
+	ctx context.Context,
+	id string,
+	reason string,
+) (*intersectionpb.IntersectionResponse, error) {
+	req := &intersectionpb.UpdateIntersectionRequest{
+		Id:            id,
+		Status:        commonpb.IntersectionStatus_INTERSECTION_STATUS_FAILED,
+		FailureReason: reason,
 	}
 
 	ctx, cancel := context.WithTimeout(ctx, 5*time.Second)
@@ -127,14 +195,33 @@ func (ic *IntersectionClient) UpdateIntersectionStatus(
 func (ic *IntersectionClient) DeleteIntersection(
 	ctx context.Context,
 	id string,
+	expectedVersion int,
 ) (*emptypb.Empty, error) {
+	req := &intersectionpb.DeleteIntersectionRequest{
+		Id:              id,
+		ExpectedVersion: int32(expectedVersion),
+	}
+
+	ctx, cancel := context.WithTimeout(ctx, 5*time.Second)
+	defer cancel()
+	resp, err := ic.client.DeleteIntersection(ctx, req)
+	if err != nil {
+		return nil, util.GrpcErrorToErr(err)
+	}
+	return resp, nil
+}
+
+func (ic *IntersectionClient) RestoreIntersection(
+	ctx context.Context,
+	id string,
+) (*intersectionpb.IntersectionResponse, error) {
 	req := &intersectionpb.IntersectionIDRequest{
 		Id: id,
 	}
 
 	ctx, cancel := context.WithTimeout(ctx, 5*time.Second)
 	defer cancel()
-	resp, err := ic.client.DeleteIntersection(ctx, req)
+	resp, err := ic.client.RestoreIntersection(ctx, req)
 	if err != nil {
 		return nil, util.GrpcErrorToErr(err)
 	}
@@ -145,10 +232,15 @@ func (ic *IntersectionClient) PutOptimisation(
 	ctx context.Context,
 	id string,
 	parameters model.OptimisationParameters,
+	metrics *simulationpb.SimulationResultsResponse,
+	userID, jobID string,
 ) (*intersectionpb.PutOptimisationResponse, error) {
 	req := &intersectionpb.PutOptimisationRequest{
 		Id:         id,
 		Parameters: convertParametersToProto(parameters),
+		Metrics:    metrics,
+		UserId:     userID,
+		JobId:      jobID,
 	}
 
 	resp, err := ic.client.PutOptimisation(ctx, req)
@@ -158,34 +250,417 @@ func (ic *IntersectionClient) PutOptimisation(
 	return resp, nil
 }
 
+func (ic *IntersectionClient) CreateOptimisationJob(
+	ctx context.Context,
+	intersectionID, userID string,
+	previousStatus commonpb.IntersectionStatus,
+) (*intersectionpb.OptimisationJobResponse, error) {
+	req := &intersectionpb.CreateOptimisationJobRequest{
+		IntersectionId: intersectionID,
+		UserId:         userID,
+		PreviousStatus: previousStatus,
+	}
+
+	ctx, cancel := context.WithTimeout(ctx, 5*time.Second)
+	defer cancel()
+	resp, err := ic.client.CreateOptimisationJob(ctx, req)
+	if err != nil {
+		return nil, util.GrpcErrorToErr(err)
+	}
+	return resp, nil
+}
+
+func (ic *IntersectionClient) GetOptimisationJob(
+	ctx context.Context,
+	id string,
+) (*intersectionpb.OptimisationJobResponse, error) {
+	req := &intersectionpb.OptimisationJobIDRequest{
+		Id: id,
+	}
+
+	ctx, cancel := context.WithTimeout(ctx, 5*time.Second)
+	defer cancel()
+	resp, err := ic.client.GetOptimisationJob(ctx, req)
+	if err != nil {
+		return nil, util.GrpcErrorToErr(err)
+	}
+	return resp, nil
+}
+
+func (ic *IntersectionClient) GetOptimisationJobs(
+	ctx context.Context,
+	intersectionID string,
+	statuses []intersectionpb.OptimisationJobStatus,
+) (intersectionpb.IntersectionService_GetOptimisationJobsClient, error) {
+	req := &intersectionpb.GetOptimisationJobsRequest{
+		IntersectionId: intersectionID,
+		Statuses:       statuses,
+	}
+
+	return ic.client.GetOptimisationJobs(ctx, req)
+}
+
+func (ic *IntersectionClient) UpdateOptimisationJob(
+	ctx context.Context,
+	id string,
+	status intersectionpb.OptimisationJobStatus,
+	improved bool,
+	errMsg string,
+) (*intersectionpb.OptimisationJobResponse, error) {
+	req := &intersectionpb.UpdateOptimisationJobRequest{
+		Id:       id,
+		Status:   status,
+		Improved: improved,
+		Error:    errMsg,
+	}
+
+	ctx, cancel := context.WithTimeout(ctx, 5*time.Second)
+	defer cancel()
+	resp, err := ic.client.UpdateOptimisationJob(ctx, req)
+	if err != nil {
+		return nil, util.GrpcErrorToErr(err)
+	}
+	return resp, nil
+}
+
+func (ic *IntersectionClient) RenewOptimisationLease(
+	ctx context.Context,
+	id string,
+) (*intersectionpb.OptimisationJobResponse, error) {
+	req := &intersectionpb.OptimisationJobIDRequest{
+		Id: id,
+	}
+
+	ctx, cancel := context.WithTimeout(ctx, 5*time.Second)
+	defer cancel()
+	resp, err := ic.client.RenewOptimisationLease(ctx, req)
+	if err != nil {
+		return nil, util.GrpcErrorToErr(err)
+	}
+	return resp, nil
+}
+
+func (ic *IntersectionClient) CreateRun(
+	ctx context.Context,
+	run model.Run,
+) (*intersectionpb.RunResponse, error) {
+	req := &intersectionpb.CreateRunRequest{
+		IntersectionId: run.IntersectionID,
+		UserId:         run.UserID,
+		Type:           intersectionpb.RunType(intersectionpb.RunType_value[run.Type]),
+		Parameters:     convertParametersToProto(run.Parameters),
+		Metrics:        convertSimResultsToProto(run.Metrics),
+		Duration:       durationpb.New(time.Duration(run.DurationMs) * time.Millisecond),
+		Outcome:        intersectionpb.RunOutcome(intersectionpb.RunOutcome_value[run.Outcome]),
+		Error:          run.Error,
+		Improved:       run.Improved,
+		JobId:          run.JobID,
+	}
+
+	ctx, cancel := context.WithTimeout(ctx, 5*time.Second)
+	defer cancel()
+	resp, err := ic.client.CreateRun(ctx, req)
+	if err != nil {
+		return nil, util.GrpcErrorToErr(err)
+	}
+	return resp, nil
+}
+
+func (ic *IntersectionClient) GetRun(
+	ctx context.Context,
+	intersectionID, id string,
+) (*intersectionpb.RunResponse, error) {
+	req := &intersectionpb.RunIDRequest{
+		IntersectionId: intersectionID,
+		Id:             id,
+	}
+
+	ctx, cancel := context.WithTimeout(ctx, 5*time.Second)
+	defer cancel()
+	resp, err := ic.client.GetRun(ctx, req)
+	if err != nil {
+		return nil, util.GrpcErrorToErr(err)
+	}
+	return resp, nil
+}
+
+func (ic *IntersectionClient) GetRuns(
+	ctx context.Context,
+	intersectionID string,
+	page, pageSize int,
+) (intersectionpb.IntersectionService_GetRunsClient, error) {
+	req := &intersectionpb.GetRunsRequest{
+		IntersectionId: intersectionID,
+		Page:           int32(page),
+		PageSize:       int32(pageSize),
+	}
+
+	return ic.client.GetRuns(ctx, req)
+}
+
+func (ic *IntersectionClient) GetParameterVersions(
+	ctx context.Context,
+	intersectionID string,
+	page, pageSize int,
+) (intersectionpb.IntersectionService_GetParameterVersionsClient, error) {
+	req := &intersectionpb.GetParameterVersionsRequest{
+		IntersectionId: intersectionID,
+		Page:           int32(page),
+		PageSize:       int32(pageSize),
+	}
+
+	return ic.client.GetParameterVersions(ctx, req)
+}
+
+func (ic *IntersectionClient) RevertParameterVersion(
+	ctx context.Context,
+	intersectionID string,
+	version int,
+	userID string,
+	expectedVersion int,
+) (*intersectionpb.IntersectionResponse, error) {
+	req := &intersectionpb.RevertParameterVersionRequest{
+		IntersectionId:  intersectionID,
+		Version:         int32(version),
+		UserId:          userID,
+		ExpectedVersion: int32(expectedVersion),
+	}
+
+	ctx, cancel := context.WithTimeout(ctx, 5*time.Second)
+	defer cancel()
+	resp, err := ic.client.RevertParameterVersion(ctx, req)
+	if err != nil {
+		return nil, util.GrpcErrorToErr(err)
+	}
+	return resp, nil
+}
+
+func (ic *IntersectionClient) CreateSweep(
+	ctx context.Context,
+	intersectionID, userID string,
+	baseParameters model.SimulationParameters,
+	ranges []model.SweepRange,
+	totalPoints int,
+) (*intersectionpb.SweepResponse, error) {
+	req := &intersectionpb.CreateSweepRequest{
+		IntersectionId: intersectionID,
+		UserId:         userID,
+		BaseParameters: convertSimParametersToProto(baseParameters),
+		Ranges:         make([]*intersectionpb.SweepRange, 0, len(ranges)),
+		TotalPoints:    int32(totalPoints),
+	}
+	for _, r := range ranges {
+		req.Ranges = append(req.Ranges, &intersectionpb.SweepRange{
+			Parameter: r.Parameter,
+			Min:       int32(r.Min),
+			Max:       int32(r.Max),
+			Step:      int32(r.Step),
+		})
+	}
+
+	ctx, cancel := context.WithTimeout(ctx, 5*time.Second)
+	defer cancel()
+	resp, err := ic.client.CreateSweep(ctx, req)
+	if err != nil {
+		return nil, util.GrpcErrorToErr(err)
+	}
+	return resp, nil
+}
+
+func (ic *IntersectionClient) GetSweep(
+	ctx context.Context,
+	intersectionID, id string,
+) (*intersectionpb.SweepResponse, error) {
+	req := &intersectionpb.SweepIDRequest{
+		IntersectionId: intersectionID,
+		Id:             id,
+	}
+
+	ctx, cancel := context.WithTimeout(ctx, 5*time.Second)
+	defer cancel()
+	resp, err := ic.client.GetSweep(ctx, req)
+	if err != nil {
+		return nil, util.GrpcErrorToErr(err)
+	}
+	return resp, nil
+}
+
+func (ic *IntersectionClient) GetSweeps(
+	ctx context.Context,
+	intersectionID string,
+	statuses []intersectionpb.SweepStatus,
+) (intersectionpb.IntersectionService_GetSweepsClient, error) {
+	req := &intersectionpb.GetSweepsRequest{
+		IntersectionId: intersectionID,
+		Statuses:       statuses,
+	}
+
+	return ic.client.GetSweeps(ctx, req)
+}
+
+func (ic *IntersectionClient) PutSweepPoint(
+	ctx context.Context,
+	id string,
+	point model.SweepPoint,
+) (*emptypb.Empty, error) {
+	req := &intersectionpb.PutSweepPointRequest{
+		Id: id,
+		Point: &intersectionpb.SweepPoint{
+			Index:      int32(point.Index),
+			Parameters: convertSimParametersToProto(point.Parameters),
+			Metrics:    convertSimResultsToProto(point.Results),
+			Error:      point.Error,
+			Duration:   durationpb.New(time.Duration(point.DurationMs) * time.Millisecond),
+		},
+	}
+
+	ctx, cancel := context.WithTimeout(ctx, 5*time.Second)
+	defer cancel()
+	resp, err := ic.client.PutSweepPoint(ctx, req)
+	if err != nil {
+		return nil, util.GrpcErrorToErr(err)
+	}
+	return resp, nil
+}
+
+func (ic *IntersectionClient) UpdateSweep(
+	ctx context.Context,
+	id string,
+	status intersectionpb.SweepStatus,
+	errMsg string,
+) (*intersectionpb.SweepResponse, error) {
+	req := &intersectionpb.UpdateSweepRequest{
+		Id:     id,
+		Status: status,
+		Error:  errMsg,
+	}
+
+	ctx, cancel := context.WithTimeout(ctx, 5*time.Second)
+	defer cancel()
+	resp, err := ic.client.UpdateSweep(ctx, req)
+	if err != nil {
+		return nil, util.GrpcErrorToErr(err)
+	}
+	return resp, nil
+}
+
 // NOTE: Creates stub for testing
 type IntersectionClientInterface interface {
 	CreateIntersection(
 		ctx context.Context,
 		intersection model.Intersection,
+		userID string,
 	) (*intersectionpb.IntersectionResponse, error)
 	GetIntersection(ctx context.Context, id string) (*intersectionpb.IntersectionResponse, error)
 	GetAllIntersections(
 		ctx context.Context,
 		ids string,
 	) (intersectionpb.IntersectionService_GetAllIntersectionsClient, error)
+	GetDeletedIntersections(
+		ctx context.Context,
+		ids string,
+	) (intersectionpb.IntersectionService_GetAllIntersectionsClient, error)
+	ListIntersectionsAfter(
+		ctx context.Context,
+		afterID string,
+		pageSize int,
+	) (intersectionpb.IntersectionService_GetAllIntersectionsClient, error)
 	UpdateIntersection(
 		ctx context.Context,
 		id, name string,
 		details model.Details,
+		density string,
+		defaultParameters *model.OptimisationParameters,
+		userID string,
+		expectedVersion int,
 	) (*intersectionpb.IntersectionResponse, error)
 	UpdateIntersectionStatus(
 		ctx context.Context,
-		id, name string,
-		details model.Details,
+		id string,
 		status commonpb.IntersectionStatus,
 	) (*intersectionpb.IntersectionResponse, error)
-	DeleteIntersection(ctx context.Context, id string) (*emptypb.Empty, error)
+	FailIntersection(
+		ctx context.Context,
+		id string,
+		reason string,
+	) (*intersectionpb.IntersectionResponse, error)
+	DeleteIntersection(
+		ctx context.Context,
+		id string,
+		expectedVersion int,
+	) (*emptypb.Empty, error)
+	RestoreIntersection(ctx context.Context, id string) (*intersectionpb.IntersectionResponse, error)
 	PutOptimisation(
 		ctx context.Context,
 		id string,
 		parameters model.OptimisationParameters,
+		metrics *simulationpb.SimulationResultsResponse,
+		userID, jobID string,
 	) (*intersectionpb.PutOptimisationResponse, error)
+	CreateOptimisationJob(
+		ctx context.Context,
+		intersectionID, userID string,
+		previousStatus commonpb.IntersectionStatus,
+	) (*intersectionpb.OptimisationJobResponse, error)
+	GetOptimisationJob(
+		ctx context.Context,
+		id string,
+	) (*intersectionpb.OptimisationJobResponse, error)
+	GetOptimisationJobs(
+		ctx context.Context,
+		intersectionID string,
+		statuses []intersectionpb.OptimisationJobStatus,
+	) (intersectionpb.IntersectionService_GetOptimisationJobsClient, error)
+	UpdateOptimisationJob(
+		ctx context.Context,
+		id string,
+		status intersectionpb.OptimisationJobStatus,
+		improved bool,
+		errMsg string,
+	) (*intersectionpb.OptimisationJobResponse, error)
+	RenewOptimisationLease(
+		ctx context.Context,
+		id string,
+	) (*intersectionpb.OptimisationJobResponse, error)
+	CreateRun(ctx context.Context, run model.Run) (*intersectionpb.RunResponse, error)
+	GetRun(ctx context.Context, intersectionID, id string) (*intersectionpb.RunResponse, error)
+	GetRuns(
+		ctx context.Context,
+		intersectionID string,
+		page, pageSize int,
+	) (intersectionpb.IntersectionService_GetRunsClient, error)
+	GetParameterVersions(
+		ctx context.Context,
+		intersectionID string,
+		page, pageSize int,
+	) (intersectionpb.IntersectionService_GetParameterVersionsClient, error)
+	RevertParameterVersion(
+		ctx context.Context,
+		intersectionID string,
+		version int,
+		userID string,
+		expectedVersion int,
+	) (*intersectionpb.IntersectionResponse, error)
+	CreateSweep(
+		ctx context.Context,
+		intersectionID, userID string,
+		baseParameters model.SimulationParameters,
+		ranges []model.SweepRange,
+		totalPoints int,
+	) (*intersectionpb.SweepResponse, error)
+	GetSweep(ctx context.Context, intersectionID, id string) (*intersectionpb.SweepResponse, error)
+	GetSweeps(
+		ctx context.Context,
+		intersectionID string,
+		statuses []intersectionpb.SweepStatus,
+	) (intersectionpb.IntersectionService_GetSweepsClient, error)
+	PutSweepPoint(ctx context.Context, id string, point model.SweepPoint) (*emptypb.Empty, error)
+	UpdateSweep(
+		ctx context.Context,
+		id string,
+		status intersectionpb.SweepStatus,
+		errMsg string,
+	) (*intersectionpb.SweepResponse, error)
 }
 
 // NOTE: Asserts Interface Implementation
@@ -208,20 +683,37 @@ func convertParametersToProto(
 ) *commonpb.OptimisationParameters {
 	return &commonpb.OptimisationParameters{
 		OptimisationType: StringToOptimisationType(parameters.OptimisationType),
-		Parameters: &commonpb.SimulationParameters{
-			IntersectionType: StringToIntersectionType(
-				parameters.SimulationParameters.IntersectionType,
-			),
-			Green:  int32(parameters.SimulationParameters.Green),
-			Yellow: int32(parameters.SimulationParameters.Yellow),
-			Red:    int32(parameters.SimulationParameters.Red),
-			Speed:  int32(parameters.SimulationParameters.Speed),
-			Seed:   int32(parameters.SimulationParameters.Seed),
-		},
+		Parameters:       convertSimParametersToProto(parameters.SimulationParameters),
 	}
 }
 
+func convertSimParametersToProto(
+	parameters model.SimulationParameters,
+) *commonpb.SimulationParameters {
+	return &commonpb.SimulationParameters{
+		IntersectionType: StringToIntersectionType(parameters.IntersectionType),
+		Green:            int32(parameters.Green),
+		Yellow:           int32(parameters.Yellow),
+		Red:              int32(parameters.Red),
+		Speed:            int32(parameters.Speed),
+		Seed:             int32(parameters.Seed),
+	}
+}
+
+func convertSimResultsToProto(
+	results *model.SimulationResults,
+) *simulationpb.SimulationResultsResponse {
+	if results == nil {
+		return nil
+	}
+	return util.SimResultsToRPCSimResults(*results)
+}
+
 func StringToOptimisationType(s string) commonpb.OptimisationType {
+	// NOTE: Parameters read back from the intersection service carry the enum names
+	if v, ok := commonpb.OptimisationType_value[s]; ok {
+		return commonpb.OptimisationType(v)
+	}
 	switch strings.ToLower(s) {
 	case "grid_search", "gridsearch":
 		return commonpb.OptimisationType_OPTIMISATION_TYPE_GRIDSEARCH
@@ -236,6 +728,9 @@ func StringToOptimisationType(s string) commonpb.OptimisationType {
 }
 
 func StringToIntersectionType(s string) commonpb.IntersectionType {
+	if v, ok := commonpb.IntersectionType_value[s]; ok {
+		return commonpb.IntersectionType(v)
+	}
 	switch strings.ToLower(strings.ReplaceAll(s, "-", "")) {
 	case "trafficlight", "traffic_light":
 		return commonpb.IntersectionType_INTERSECTION_TYPE_TRAFFICLIGHT
@@ -2,7 +2,6 @@ package client
 
 import (
 	"context"
-	"time"
 
 	"github.com/COS301-SE-2025/Swift-Signals/api-gateway/internal/model"
 	"github.com/COS301-SE-2025/Swift-Signals/api-gateway/internal/util"
@@ -29,7 +28,31 @@ func (oc *OptimisationClient) RunOptimisation(
 	ctx context.Context,
 	params model.OptimisationParameters,
 ) (*commonpb.OptimisationParameters, error) {
-	req := &commonpb.OptimisationParameters{
+	req := optiParamsToRPC(params)
+	// NOTE: No deadline is applied here as an optimisation run can take hours depending on
+	// the hardware. Callers run it as a background job and bound it through ctx cancellation.
+	resp, err := oc.client.RunOptimisation(ctx, req)
+	if err != nil {
+		return nil, util.GrpcErrorToErr(err)
+	}
+	return resp, nil
+}
+
+func (oc *OptimisationClient) StreamOptimisation(
+	ctx context.Context,
+	params model.OptimisationParameters,
+) (optimisationpb.OptimisationService_StreamOptimisationClient, error) {
+	req := optiParamsToRPC(params)
+	// NOTE: Like RunOptimisation, the stream is only bounded through ctx cancellation
+	stream, err := oc.client.StreamOptimisation(ctx, req)
+	if err != nil {
+		return nil, util.GrpcErrorToErr(err)
+	}
+	return stream, nil
+}
+
+func optiParamsToRPC(params model.OptimisationParameters) *commonpb.OptimisationParameters {
+	return &commonpb.OptimisationParameters{
 		OptimisationType: commonpb.OptimisationType(
 			commonpb.OptimisationType_value[params.OptimisationType],
 		),
@@ -42,19 +65,11 @@ func (oc *OptimisationClient) RunOptimisation(
 			Red:    int32(params.SimulationParameters.Red),
 			Speed:  int32(params.SimulationParameters.Speed),
 			Seed:   int32(params.SimulationParameters.Seed),
+			TrafficDensity: simulationDensityToRPC(
+				params.SimulationParameters.TrafficDensity,
+			),
 		},
 	}
-	ctx, cancel := context.WithTimeout(
-		ctx,
-		5*time.Hour,
-	) // NOTE: This time depends on the hardware and should be adjusted accordingly
-	defer cancel()
-
-	resp, err := oc.client.RunOptimisation(ctx, req)
-	if err != nil {
-		return nil, util.GrpcErrorToErr(err)
-	}
-	return resp, nil
 }
 
 // NOTE: Creates stub for testing
@@ -63,6 +78,10 @@ type OptimisationClientInterface interface {
 		ctx context.Context,
 		params model.OptimisationParameters,
 	) (*commonpb.OptimisationParameters, error)
+	StreamOptimisation(
+		ctx context.Context,
+		params model.OptimisationParameters,
+	) (optimisationpb.OptimisationService_StreamOptimisationClient, error)
 }
 
 // NOTE: Asserts Interface Implementation
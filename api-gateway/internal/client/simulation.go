@@ -12,6 +12,9 @@ import (
 	"google.golang.org/grpc"
 )
 
+// simulationTimeout bounds simulations whose callers set no deadline
+const simulationTimeout = 15 * time.Second
+
 type SimulationClient struct {
 	client simulationpb.SimulationServiceClient
 }
@@ -26,30 +29,58 @@ func NewSimulationClientFromConn(conn *grpc.ClientConn) *SimulationClient {
 	return NewSimulationClient(simulationpb.NewSimulationServiceClient(conn))
 }
 
-func (sc *SimulationClient) GetSimulationResults(
+// RunSimulation runs one simulation of an intersection, returning both its results and
+// its output
+func (sc *SimulationClient) RunSimulation(
 	ctx context.Context,
 	id string,
 	simulation_parameters model.SimulationParameters,
-) (*simulationpb.SimulationResultsResponse, error) {
-	intersection, ok := commonpb.IntersectionType_value[simulation_parameters.IntersectionType]
+) (*simulationpb.SimulationResponse, error) {
+	req, err := simulationRequest(id, simulation_parameters)
+	if err != nil {
+		return nil, err
+	}
 
-	if !ok {
-		return nil, errs.NewValidationError("invalid simulation parameters", map[string]any{})
+	ctx, cancel := withSimulationTimeout(ctx)
+	defer cancel()
+
+	resp, err := sc.client.RunSimulation(ctx, req)
+	if err != nil {
+		return nil, util.GrpcErrorToErr(err)
 	}
+	if resp.Results == nil || resp.Output == nil {
+		return nil, errs.NewInternalError(
+			"simulation service returned an incomplete simulation",
+			nil,
+			map[string]any{"intersectionID": id},
+		)
+	}
+	return resp, nil
+}
 
-	req := &simulationpb.SimulationRequest{
-		IntersectionId: id,
-		SimulationParameters: &commonpb.SimulationParameters{
-			IntersectionType: commonpb.IntersectionType(intersection),
-			Green:            int32(simulation_parameters.Green),
-			Yellow:           int32(simulation_parameters.Yellow),
-			Red:              int32(simulation_parameters.Red),
-			Speed:            int32(simulation_parameters.Speed),
-			Seed:             int32(simulation_parameters.Seed),
-		},
+// RunWhatIfSimulation runs one simulation of an intersection with ad-hoc parameters. It is
+// the same call as RunSimulation, kept apart so that its response is never persisted.
+func (sc *SimulationClient) RunWhatIfSimulation(
+	ctx context.Context,
+	id string,
+	simulation_parameters model.SimulationParameters,
+) (*simulationpb.SimulationResponse, error) {
+	return sc.RunSimulation(ctx, id, simulation_parameters)
+}
+
+// GetSimulationResults runs one simulation of an intersection, returning only its results.
+// Callers that only compare metrics use it, so that the output is never sent.
+func (sc *SimulationClient) GetSimulationResults(
+	ctx context.Context,
+	id string,
+	simulation_parameters model.SimulationParameters,
+) (*simulationpb.SimulationResultsResponse, error) {
+	req, err := simulationRequest(id, simulation_parameters)
+	if err != nil {
+		return nil, err
 	}
 
-	ctx, cancel := context.WithTimeout(ctx, 15*time.Second)
+	ctx, cancel := withSimulationTimeout(ctx)
 	defer cancel()
 
 	resp, err := sc.client.GetSimulationResults(ctx, req)
@@ -59,18 +90,38 @@ func (sc *SimulationClient) GetSimulationResults(
 	return resp, nil
 }
 
-func (sc *SimulationClient) GetSimulationOutput(
+// StreamSimulationOutput runs one simulation of an intersection, streaming its output in
+// chunks of vehicles
+func (sc *SimulationClient) StreamSimulationOutput(
 	ctx context.Context,
 	id string,
 	simulation_parameters model.SimulationParameters,
-) (*simulationpb.SimulationOutputResponse, error) {
+) (simulationpb.SimulationService_StreamSimulationOutputClient, error) {
+	req, err := simulationRequest(id, simulation_parameters)
+	if err != nil {
+		return nil, err
+	}
+
+	// NOTE: The stream lives as long as its reader, so it is only bounded through ctx
+	// cancellation
+	stream, err := sc.client.StreamSimulationOutput(ctx, req)
+	if err != nil {
+		return nil, util.GrpcErrorToErr(err)
+	}
+	return stream, nil
+}
+
+func simulationRequest(
+	id string,
+	simulation_parameters model.SimulationParameters,
+) (*simulationpb.SimulationRequest, error) {
 	intersection, ok := commonpb.IntersectionType_value[simulation_parameters.IntersectionType]
 
 	if !ok {
 		return nil, errs.NewValidationError("invalid simulation parameters", map[string]any{})
 	}
 
-	req := &simulationpb.SimulationRequest{
+	return &simulationpb.SimulationRequest{
 		IntersectionId: id,
 		SimulationParameters: &commonpb.SimulationParameters{
 			IntersectionType: commonpb.IntersectionType(intersection),
@@ -79,31 +130,50 @@ func (sc *SimulationClient) GetSimulationOutput(
 			Red:              int32(simulation_parameters.Red),
 			Speed:            int32(simulation_parameters.Speed),
 			Seed:             int32(simulation_parameters.Seed),
+			TrafficDensity:   simulationDensityToRPC(simulation_parameters.TrafficDensity),
 		},
-	}
+	}, nil
+}
 
-	ctx, cancel := context.WithTimeout(ctx, 15*time.Second)
-	defer cancel()
+// withSimulationTimeout bounds a simulation by the default timeout, unless the caller
+// already gave it a deadline of its own, which may be longer
+func withSimulationTimeout(ctx context.Context) (context.Context, context.CancelFunc) {
+	if _, ok := ctx.Deadline(); ok {
+		return context.WithCancel(ctx)
+	}
+	return context.WithTimeout(ctx, simulationTimeout)
+}
 
-	resp, err := sc.client.GetSimulationOutput(ctx, req)
-	if err != nil {
-		return nil, util.GrpcErrorToErr(err)
+// simulationDensityToRPC leaves the density unspecified when the parameters have none
+func simulationDensityToRPC(density string) commonpb.TrafficDensity {
+	if density == "" {
+		return commonpb.TrafficDensity_TRAFFIC_DENSITY_UNSPECIFIED
 	}
-	return resp, nil
+	return StringToTrafficDensity(density)
 }
 
 // NOTE: Creates stub for testing
 type SimulationClientInterface interface {
+	RunSimulation(
+		ctx context.Context,
+		id string,
+		simulation_parameters model.SimulationParameters,
+	) (*simulationpb.SimulationResponse, error)
+	RunWhatIfSimulation(
+		ctx context.Context,
+		id string,
+		simulation_parameters model.SimulationParameters,
+	) (*simulationpb.SimulationResponse, error)
 	GetSimulationResults(
 		ctx context.Context,
 		id string,
 		simulation_parameters model.SimulationParameters,
 	) (*simulationpb.SimulationResultsResponse, error)
-	GetSimulationOutput(
+	StreamSimulationOutput(
 		ctx context.Context,
 		id string,
 		simulation_parameters model.SimulationParameters,
-	) (*simulationpb.SimulationOutputResponse, error)
+	) (simulationpb.SimulationService_StreamSimulationOutputClient, error)
 }
 
 // NOTE: Asserts the SimulationClient implements the SimulationClientInterface
@@ -86,7 +86,7 @@ func (suite *TestSuite) TestCreateIntersection_Success() {
 		})).Return(expectedResponse, nil)
 
 	// Act
-	result, err := suite.client.CreateIntersection(ctx, intersection)
+	result, err := suite.client.CreateIntersection(ctx, intersection, "")
 
 	// Assert
 	suite.Require().NoError(err)
@@ -127,7 +127,7 @@ func (suite *TestSuite) TestCreateIntersection_GrpcError() {
 		Return(nil, grpcErr)
 
 	// Act
-	result, err := suite.client.CreateIntersection(ctx, intersection)
+	result, err := suite.client.CreateIntersection(ctx, intersection, "")
 
 	// Assert
 	suite.Require().Error(err)
@@ -167,7 +167,7 @@ func (suite *TestSuite) TestCreateIntersection_ContextTimeout() {
 		Return(nil, context.DeadlineExceeded)
 
 	// Act
-	result, err := suite.client.CreateIntersection(ctx, intersection)
+	result, err := suite.client.CreateIntersection(ctx, intersection, "")
 
 	// Assert
 	suite.Require().Error(err)
@@ -264,7 +264,7 @@ func (suite *TestSuite) TestCreateIntersection_StringToEnumConversions() {
 				})).Return(expectedResponse, nil)
 
 			// Act
-			result, err := suite.client.CreateIntersection(ctx, intersection)
+			result, err := suite.client.CreateIntersection(ctx, intersection, "")
 
 			// Assert
 			suite.Require().NoError(err)
@@ -318,7 +318,7 @@ func (suite *TestSuite) TestCreateIntersection_EmptyFields() {
 		})).Return(expectedResponse, nil)
 
 	// Act
-	result, err := suite.client.CreateIntersection(ctx, intersection)
+	result, err := suite.client.CreateIntersection(ctx, intersection, "")
 
 	// Assert
 	suite.Require().NoError(err)
@@ -29,12 +29,12 @@ func (suite *TestSuite) TestDeleteIntersection_Success() {
 			timeUntilDeadline := time.Until(deadline)
 			return timeUntilDeadline > 4*time.Second && timeUntilDeadline <= 5*time.Second
 		}),
-		mock.MatchedBy(func(req *intersectionpb.IntersectionIDRequest) bool {
+		mock.MatchedBy(func(req *intersectionpb.DeleteIntersectionRequest) bool {
 			return req.Id == intersectionID
 		})).Return(expectedResponse, nil)
 
 	// Act
-	result, err := suite.client.DeleteIntersection(ctx, intersectionID)
+	result, err := suite.client.DeleteIntersection(ctx, intersectionID, 0)
 
 	// Assert
 	suite.Require().NoError(err)
@@ -51,12 +51,12 @@ func (suite *TestSuite) TestDeleteIntersection_NotFound() {
 
 	suite.grpcClient.On("DeleteIntersection",
 		mock.AnythingOfType("*context.timerCtx"),
-		mock.MatchedBy(func(req *intersectionpb.IntersectionIDRequest) bool {
+		mock.MatchedBy(func(req *intersectionpb.DeleteIntersectionRequest) bool {
 			return req.Id == intersectionID
 		})).Return(nil, grpcErr)
 
 	// Act
-	result, err := suite.client.DeleteIntersection(ctx, intersectionID)
+	result, err := suite.client.DeleteIntersection(ctx, intersectionID, 0)
 
 	// Assert
 	suite.Require().Error(err)
@@ -73,12 +73,12 @@ func (suite *TestSuite) TestDeleteIntersection_EmptyID() {
 
 	suite.grpcClient.On("DeleteIntersection",
 		mock.AnythingOfType("*context.timerCtx"),
-		mock.MatchedBy(func(req *intersectionpb.IntersectionIDRequest) bool {
+		mock.MatchedBy(func(req *intersectionpb.DeleteIntersectionRequest) bool {
 			return req.Id == ""
 		})).Return(nil, grpcErr)
 
 	// Act
-	result, err := suite.client.DeleteIntersection(ctx, intersectionID)
+	result, err := suite.client.DeleteIntersection(ctx, intersectionID, 0)
 
 	// Assert
 	suite.Require().Error(err)
@@ -95,12 +95,12 @@ func (suite *TestSuite) TestDeleteIntersection_Unauthorized() {
 
 	suite.grpcClient.On("DeleteIntersection",
 		mock.AnythingOfType("*context.timerCtx"),
-		mock.MatchedBy(func(req *intersectionpb.IntersectionIDRequest) bool {
+		mock.MatchedBy(func(req *intersectionpb.DeleteIntersectionRequest) bool {
 			return req.Id == intersectionID
 		})).Return(nil, grpcErr)
 
 	// Act
-	result, err := suite.client.DeleteIntersection(ctx, intersectionID)
+	result, err := suite.client.DeleteIntersection(ctx, intersectionID, 0)
 
 	// Assert
 	suite.Require().Error(err)
@@ -117,12 +117,12 @@ func (suite *TestSuite) TestDeleteIntersection_InternalError() {
 
 	suite.grpcClient.On("DeleteIntersection",
 		mock.AnythingOfType("*context.timerCtx"),
-		mock.MatchedBy(func(req *intersectionpb.IntersectionIDRequest) bool {
+		mock.MatchedBy(func(req *intersectionpb.DeleteIntersectionRequest) bool {
 			return req.Id == intersectionID
 		})).Return(nil, grpcErr)
 
 	// Act
-	result, err := suite.client.DeleteIntersection(ctx, intersectionID)
+	result, err := suite.client.DeleteIntersection(ctx, intersectionID, 0)
 
 	// Assert
 	suite.Require().Error(err)
@@ -138,12 +138,12 @@ func (suite *TestSuite) TestDeleteIntersection_ContextTimeout() {
 
 	suite.grpcClient.On("DeleteIntersection",
 		mock.Anything,
-		mock.MatchedBy(func(req *intersectionpb.IntersectionIDRequest) bool {
+		mock.MatchedBy(func(req *intersectionpb.DeleteIntersectionRequest) bool {
 			return req.Id == intersectionID
 		})).Return(nil, context.DeadlineExceeded)
 
 	// Act
-	result, err := suite.client.DeleteIntersection(ctx, intersectionID)
+	result, err := suite.client.DeleteIntersection(ctx, intersectionID, 0)
 
 	// Assert
 	suite.Require().Error(err)
@@ -164,12 +164,12 @@ func (suite *TestSuite) TestDeleteIntersection_ConflictError() {
 
 	suite.grpcClient.On("DeleteIntersection",
 		mock.AnythingOfType("*context.timerCtx"),
-		mock.MatchedBy(func(req *intersectionpb.IntersectionIDRequest) bool {
+		mock.MatchedBy(func(req *intersectionpb.DeleteIntersectionRequest) bool {
 			return req.Id == intersectionID
 		})).Return(nil, grpcErr)
 
 	// Act
-	result, err := suite.client.DeleteIntersection(ctx, intersectionID)
+	result, err := suite.client.DeleteIntersection(ctx, intersectionID, 0)
 
 	// Assert
 	suite.Require().Error(err)
@@ -186,13 +186,13 @@ func (suite *TestSuite) TestDeleteIntersection_ValidatesRequestStructure() {
 
 	suite.grpcClient.On("DeleteIntersection",
 		mock.AnythingOfType("*context.timerCtx"),
-		mock.MatchedBy(func(req *intersectionpb.IntersectionIDRequest) bool {
+		mock.MatchedBy(func(req *intersectionpb.DeleteIntersectionRequest) bool {
 			// Validate that the request is properly structured
 			return req != nil && req.Id == intersectionID
 		})).Return(expectedResponse, nil)
 
 	// Act
-	result, err := suite.client.DeleteIntersection(ctx, intersectionID)
+	result, err := suite.client.DeleteIntersection(ctx, intersectionID, 0)
 
 	// Assert
 	suite.Require().NoError(err)
@@ -209,12 +209,12 @@ func (suite *TestSuite) TestDeleteIntersection_ServiceUnavailable() {
 
 	suite.grpcClient.On("DeleteIntersection",
 		mock.AnythingOfType("*context.timerCtx"),
-		mock.MatchedBy(func(req *intersectionpb.IntersectionIDRequest) bool {
+		mock.MatchedBy(func(req *intersectionpb.DeleteIntersectionRequest) bool {
 			return req.Id == intersectionID
 		})).Return(nil, grpcErr)
 
 	// Act
-	result, err := suite.client.DeleteIntersection(ctx, intersectionID)
+	result, err := suite.client.DeleteIntersection(ctx, intersectionID, 0)
 
 	// Assert
 	suite.Require().Error(err)
@@ -232,12 +232,12 @@ func (suite *TestSuite) TestDeleteIntersection_LongID() {
 
 	suite.grpcClient.On("DeleteIntersection",
 		mock.AnythingOfType("*context.timerCtx"),
-		mock.MatchedBy(func(req *intersectionpb.IntersectionIDRequest) bool {
+		mock.MatchedBy(func(req *intersectionpb.DeleteIntersectionRequest) bool {
 			return req.Id == intersectionID
 		})).Return(expectedResponse, nil)
 
 	// Act
-	result, err := suite.client.DeleteIntersection(ctx, intersectionID)
+	result, err := suite.client.DeleteIntersection(ctx, intersectionID, 0)
 
 	// Assert
 	suite.Require().NoError(err)
@@ -255,12 +255,12 @@ func (suite *TestSuite) TestDeleteIntersection_SpecialCharactersInID() {
 
 	suite.grpcClient.On("DeleteIntersection",
 		mock.AnythingOfType("*context.timerCtx"),
-		mock.MatchedBy(func(req *intersectionpb.IntersectionIDRequest) bool {
+		mock.MatchedBy(func(req *intersectionpb.DeleteIntersectionRequest) bool {
 			return req.Id == intersectionID
 		})).Return(expectedResponse, nil)
 
 	// Act
-	result, err := suite.client.DeleteIntersection(ctx, intersectionID)
+	result, err := suite.client.DeleteIntersection(ctx, intersectionID, 0)
 
 	// Assert
 	suite.Require().NoError(err)
@@ -278,12 +278,12 @@ func (suite *TestSuite) TestDeleteIntersection_UUIDFormat() {
 
 	suite.grpcClient.On("DeleteIntersection",
 		mock.AnythingOfType("*context.timerCtx"),
-		mock.MatchedBy(func(req *intersectionpb.IntersectionIDRequest) bool {
+		mock.MatchedBy(func(req *intersectionpb.DeleteIntersectionRequest) bool {
 			return req.Id == intersectionID
 		})).Return(expectedResponse, nil)
 
 	// Act
-	result, err := suite.client.DeleteIntersection(ctx, intersectionID)
+	result, err := suite.client.DeleteIntersection(ctx, intersectionID, 0)
 
 	// Assert
 	suite.Require().NoError(err)
@@ -7,6 +7,7 @@ import (
 	"github.com/COS301-SE-2025/Swift-Signals/api-gateway/internal/model"
 	commonpb "github.com/COS301-SE-2025/Swift-Signals/protos/gen/swiftsignals/common/v1"
 	intersectionpb "github.com/COS301-SE-2025/Swift-Signals/protos/gen/swiftsignals/intersection/v1"
+	simulationpb "github.com/COS301-SE-2025/Swift-Signals/protos/gen/swiftsignals/simulation/v1"
 	"github.com/stretchr/testify/mock"
 	"github.com/stretchr/testify/suite"
 	"google.golang.org/grpc/codes"
@@ -29,6 +30,8 @@ func (suite *TestSuite) TestPutOptimisation_Success() {
 		},
 	}
 
+	metrics := &simulationpb.SimulationResultsResponse{AverageWaitingTime: 42}
+
 	expectedResponse := &intersectionpb.PutOptimisationResponse{
 		Improved: true,
 	}
@@ -37,6 +40,7 @@ func (suite *TestSuite) TestPutOptimisation_Success() {
 		mock.Anything,
 		mock.MatchedBy(func(req *intersectionpb.PutOptimisationRequest) bool {
 			return req.Id == intersectionID &&
+				req.Metrics == metrics &&
 				req.Parameters.OptimisationType == commonpb.OptimisationType_OPTIMISATION_TYPE_GRIDSEARCH &&
 				req.Parameters.Parameters.IntersectionType == commonpb.IntersectionType_INTERSECTION_TYPE_TJUNCTION &&
 				req.Parameters.Parameters.Green == 12 &&
@@ -47,7 +51,7 @@ func (suite *TestSuite) TestPutOptimisation_Success() {
 		})).Return(expectedResponse, nil)
 
 	// Act
-	result, err := suite.client.PutOptimisation(ctx, intersectionID, parameters)
+	result, err := suite.client.PutOptimisation(ctx, intersectionID, parameters, metrics, "")
 
 	// Assert
 	suite.Require().NoError(err)
@@ -80,7 +84,7 @@ func (suite *TestSuite) TestPutOptimisation_NotFound() {
 		})).Return(nil, grpcErr)
 
 	// Act
-	result, err := suite.client.PutOptimisation(ctx, intersectionID, parameters)
+	result, err := suite.client.PutOptimisation(ctx, intersectionID, parameters, nil, "")
 
 	// Assert
 	suite.Require().Error(err)
@@ -113,7 +117,7 @@ func (suite *TestSuite) TestPutOptimisation_EmptyID() {
 		})).Return(nil, grpcErr)
 
 	// Act
-	result, err := suite.client.PutOptimisation(ctx, intersectionID, parameters)
+	result, err := suite.client.PutOptimisation(ctx, intersectionID, parameters, nil, "")
 
 	// Assert
 	suite.Require().Error(err)
@@ -146,7 +150,7 @@ func (suite *TestSuite) TestPutOptimisation_Unauthorized() {
 		})).Return(nil, grpcErr)
 
 	// Act
-	result, err := suite.client.PutOptimisation(ctx, intersectionID, parameters)
+	result, err := suite.client.PutOptimisation(ctx, intersectionID, parameters, nil, "")
 
 	// Assert
 	suite.Require().Error(err)
@@ -179,7 +183,7 @@ func (suite *TestSuite) TestPutOptimisation_InternalError() {
 		})).Return(nil, grpcErr)
 
 	// Act
-	result, err := suite.client.PutOptimisation(ctx, intersectionID, parameters)
+	result, err := suite.client.PutOptimisation(ctx, intersectionID, parameters, nil, "")
 
 	// Assert
 	suite.Require().Error(err)
@@ -255,7 +259,7 @@ func (suite *TestSuite) TestPutOptimisation_StringToEnumConversions() {
 				})).Return(expectedResponse, nil)
 
 			// Act
-			result, err := suite.client.PutOptimisation(ctx, intersectionID, parameters)
+			result, err := suite.client.PutOptimisation(ctx, intersectionID, parameters, nil, "")
 
 			// Assert
 			suite.Require().NoError(err)
@@ -294,7 +298,7 @@ func (suite *TestSuite) TestPutOptimisation_NoTimeout() {
 		mock.AnythingOfType("*intersection.PutOptimisationRequest")).Return(expectedResponse, nil)
 
 	// Act
-	result, err := suite.client.PutOptimisation(ctx, intersectionID, parameters)
+	result, err := suite.client.PutOptimisation(ctx, intersectionID, parameters, nil, "")
 
 	// Assert
 	suite.Require().NoError(err)
@@ -335,7 +339,7 @@ func (suite *TestSuite) TestPutOptimisation_ZeroValues() {
 		})).Return(expectedResponse, nil)
 
 	// Act
-	result, err := suite.client.PutOptimisation(ctx, intersectionID, parameters)
+	result, err := suite.client.PutOptimisation(ctx, intersectionID, parameters, nil, "")
 
 	// Assert
 	suite.Require().NoError(err)
@@ -376,7 +380,7 @@ func (suite *TestSuite) TestPutOptimisation_LargeValues() {
 		})).Return(expectedResponse, nil)
 
 	// Act
-	result, err := suite.client.PutOptimisation(ctx, intersectionID, parameters)
+	result, err := suite.client.PutOptimisation(ctx, intersectionID, parameters, nil, "")
 
 	// Assert
 	suite.Require().NoError(err)
@@ -410,7 +414,7 @@ func (suite *TestSuite) TestPutOptimisation_ConflictError() {
 		})).Return(nil, grpcErr)
 
 	// Act
-	result, err := suite.client.PutOptimisation(ctx, intersectionID, parameters)
+	result, err := suite.client.PutOptimisation(ctx, intersectionID, parameters, nil, "")
 
 	// Assert
 	suite.Require().Error(err)
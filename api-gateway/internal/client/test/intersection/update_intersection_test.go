@@ -59,7 +59,7 @@ func (suite *TestSuite) TestUpdateIntersection_Success() {
 		})).Return(expectedResponse, nil)
 
 	// Act
-	result, err := suite.client.UpdateIntersection(ctx, intersectionID, name, details)
+	result, err := suite.client.UpdateIntersection(ctx, intersectionID, name, details, "", nil, "", 0)
 
 	// Assert
 	suite.Require().NoError(err)
@@ -87,7 +87,7 @@ func (suite *TestSuite) TestUpdateIntersection_NotFound() {
 		})).Return(nil, grpcErr)
 
 	// Act
-	result, err := suite.client.UpdateIntersection(ctx, intersectionID, name, details)
+	result, err := suite.client.UpdateIntersection(ctx, intersectionID, name, details, "", nil, "", 0)
 
 	// Assert
 	suite.Require().Error(err)
@@ -115,7 +115,7 @@ func (suite *TestSuite) TestUpdateIntersection_EmptyID() {
 		})).Return(nil, grpcErr)
 
 	// Act
-	result, err := suite.client.UpdateIntersection(ctx, intersectionID, name, details)
+	result, err := suite.client.UpdateIntersection(ctx, intersectionID, name, details, "", nil, "", 0)
 
 	// Assert
 	suite.Require().Error(err)
@@ -155,7 +155,7 @@ func (suite *TestSuite) TestUpdateIntersection_EmptyName() {
 		})).Return(expectedResponse, nil)
 
 	// Act
-	result, err := suite.client.UpdateIntersection(ctx, intersectionID, name, details)
+	result, err := suite.client.UpdateIntersection(ctx, intersectionID, name, details, "", nil, "", 0)
 
 	// Assert
 	suite.Require().NoError(err)
@@ -195,7 +195,7 @@ func (suite *TestSuite) TestUpdateIntersection_EmptyDetails() {
 		})).Return(expectedResponse, nil)
 
 	// Act
-	result, err := suite.client.UpdateIntersection(ctx, intersectionID, name, details)
+	result, err := suite.client.UpdateIntersection(ctx, intersectionID, name, details, "", nil, "", 0)
 
 	// Assert
 	suite.Require().NoError(err)
@@ -223,7 +223,7 @@ func (suite *TestSuite) TestUpdateIntersection_Unauthorized() {
 		})).Return(nil, grpcErr)
 
 	// Act
-	result, err := suite.client.UpdateIntersection(ctx, intersectionID, name, details)
+	result, err := suite.client.UpdateIntersection(ctx, intersectionID, name, details, "", nil, "", 0)
 
 	// Assert
 	suite.Require().Error(err)
@@ -251,7 +251,7 @@ func (suite *TestSuite) TestUpdateIntersection_InternalError() {
 		})).Return(nil, grpcErr)
 
 	// Act
-	result, err := suite.client.UpdateIntersection(ctx, intersectionID, name, details)
+	result, err := suite.client.UpdateIntersection(ctx, intersectionID, name, details, "", nil, "", 0)
 
 	// Assert
 	suite.Require().Error(err)
@@ -278,7 +278,7 @@ func (suite *TestSuite) TestUpdateIntersection_ContextTimeout() {
 		})).Return(nil, context.DeadlineExceeded)
 
 	// Act
-	result, err := suite.client.UpdateIntersection(ctx, intersectionID, name, details)
+	result, err := suite.client.UpdateIntersection(ctx, intersectionID, name, details, "", nil, "", 0)
 
 	// Assert
 	suite.Require().Error(err)
@@ -318,7 +318,7 @@ func (suite *TestSuite) TestUpdateIntersection_LongValues() {
 		})).Return(expectedResponse, nil)
 
 	// Act
-	result, err := suite.client.UpdateIntersection(ctx, intersectionID, name, details)
+	result, err := suite.client.UpdateIntersection(ctx, intersectionID, name, details, "", nil, "", 0)
 
 	// Assert
 	suite.Require().NoError(err)
@@ -359,7 +359,7 @@ func (suite *TestSuite) TestUpdateIntersection_SpecialCharacters() {
 		})).Return(expectedResponse, nil)
 
 	// Act
-	result, err := suite.client.UpdateIntersection(ctx, intersectionID, name, details)
+	result, err := suite.client.UpdateIntersection(ctx, intersectionID, name, details, "", nil, "", 0)
 
 	// Assert
 	suite.Require().NoError(err)
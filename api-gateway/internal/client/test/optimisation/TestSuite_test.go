@@ -1,8 +1,11 @@
 package optimisation
 
 import (
+	"context"
+
 	"github.com/COS301-SE-2025/Swift-Signals/api-gateway/internal/client"
 	mocks "github.com/COS301-SE-2025/Swift-Signals/api-gateway/internal/mocks/grpc_client"
+	"github.com/stretchr/testify/mock"
 	"github.com/stretchr/testify/suite"
 )
 
@@ -16,3 +19,12 @@ func (suite *TestSuite) SetupTest() {
 	suite.grpcClient = new(mocks.MockOptimisationServiceClient)
 	suite.client = client.NewOptimisationClient(suite.grpcClient)
 }
+
+// noDeadline matches the context of an optimisation, which runs as a background job so
+// is given no deadline by the client
+func noDeadline() any {
+	return mock.MatchedBy(func(ctx context.Context) bool {
+		_, hasDeadline := ctx.Deadline()
+		return !hasDeadline
+	})
+}
@@ -42,14 +42,7 @@ func (suite *TestSuite) TestRunOptimisation_Success() {
 	}
 
 	suite.grpcClient.On("RunOptimisation",
-		mock.MatchedBy(func(ctx context.Context) bool {
-			deadline, hasDeadline := ctx.Deadline()
-			if !hasDeadline {
-				return false
-			}
-			timeUntilDeadline := time.Until(deadline)
-			return timeUntilDeadline > 4*time.Second && timeUntilDeadline <= 5*time.Second
-		}),
+		noDeadline(),
 		mock.MatchedBy(func(req *commonpb.OptimisationParameters) bool {
 			return req.Parameters.Green == 10 &&
 				req.Parameters.Yellow == 3 &&
@@ -86,8 +79,8 @@ func (suite *TestSuite) TestRunOptimisation_InternalError() {
 	grpcErr := status.Error(codes.Internal, "optimization service error")
 
 	suite.grpcClient.On("RunOptimisation",
-		mock.AnythingOfType("*context.timerCtx"),
-		mock.AnythingOfType("*optimisation.OptimisationParameters")).
+		noDeadline(),
+		mock.IsType(&commonpb.OptimisationParameters{})).
 		Return(nil, grpcErr)
 
 	// Act
@@ -118,8 +111,8 @@ func (suite *TestSuite) TestRunOptimisation_InvalidParameters() {
 	grpcErr := status.Error(codes.InvalidArgument, "invalid optimization parameters")
 
 	suite.grpcClient.On("RunOptimisation",
-		mock.AnythingOfType("*context.timerCtx"),
-		mock.AnythingOfType("*optimisation.OptimisationParameters")).
+		noDeadline(),
+		mock.IsType(&commonpb.OptimisationParameters{})).
 		Return(nil, grpcErr)
 
 	// Act
@@ -150,7 +143,7 @@ func (suite *TestSuite) TestRunOptimisation_ContextTimeout() {
 
 	suite.grpcClient.On("RunOptimisation",
 		mock.Anything,
-		mock.AnythingOfType("*optimisation.OptimisationParameters")).
+		mock.IsType(&commonpb.OptimisationParameters{})).
 		Return(nil, context.DeadlineExceeded)
 
 	// Act
@@ -181,8 +174,8 @@ func (suite *TestSuite) TestRunOptimisation_ServiceUnavailable() {
 	grpcErr := status.Error(codes.Unavailable, "optimization service unavailable")
 
 	suite.grpcClient.On("RunOptimisation",
-		mock.AnythingOfType("*context.timerCtx"),
-		mock.AnythingOfType("*optimisation.OptimisationParameters")).
+		noDeadline(),
+		mock.IsType(&commonpb.OptimisationParameters{})).
 		Return(nil, grpcErr)
 
 	// Act
@@ -224,7 +217,7 @@ func (suite *TestSuite) TestRunOptimisation_ZeroValues() {
 	}
 
 	suite.grpcClient.On("RunOptimisation",
-		mock.AnythingOfType("*context.timerCtx"),
+		noDeadline(),
 		mock.MatchedBy(func(req *commonpb.OptimisationParameters) bool {
 			return req.Parameters.Green == 0 &&
 				req.Parameters.Yellow == 0 &&
@@ -272,7 +265,7 @@ func (suite *TestSuite) TestRunOptimisation_LargeValues() {
 	}
 
 	suite.grpcClient.On("RunOptimisation",
-		mock.AnythingOfType("*context.timerCtx"),
+		noDeadline(),
 		mock.MatchedBy(func(req *commonpb.OptimisationParameters) bool {
 			return req.Parameters.Green == 999999 &&
 				req.Parameters.Yellow == 888888 &&
@@ -345,7 +338,7 @@ func (suite *TestSuite) TestRunOptimisation_OptimizationTypeMapping() {
 
 			// Verify that the client sends the correct optimization type in the request
 			suite.grpcClient.On("RunOptimisation",
-				mock.AnythingOfType("*context.timerCtx"),
+				noDeadline(),
 				mock.MatchedBy(func(req *commonpb.OptimisationParameters) bool {
 					return req.OptimisationType == tc.expectedOptimisationType
 				})).Return(expectedResponse, nil)
@@ -379,8 +372,8 @@ func (suite *TestSuite) TestRunOptimisation_ResourceExhausted() {
 	grpcErr := status.Error(codes.ResourceExhausted, "optimization queue full")
 
 	suite.grpcClient.On("RunOptimisation",
-		mock.AnythingOfType("*context.timerCtx"),
-		mock.AnythingOfType("*optimisation.OptimisationParameters")).
+		noDeadline(),
+		mock.IsType(&commonpb.OptimisationParameters{})).
 		Return(nil, grpcErr)
 
 	// Act
@@ -421,7 +414,7 @@ func (suite *TestSuite) TestRunOptimisation_ValidatesRequestStructure() {
 	}
 
 	suite.grpcClient.On("RunOptimisation",
-		mock.AnythingOfType("*context.timerCtx"),
+		noDeadline(),
 		mock.MatchedBy(func(req *commonpb.OptimisationParameters) bool {
 			// Validate that the request is properly structured
 			return req != nil &&
@@ -0,0 +1,68 @@
+package optimisation
+
+import (
+	"context"
+
+	mocks "github.com/COS301-SE-2025/Swift-Signals/api-gateway/internal/mocks/grpc_client"
+	"github.com/COS301-SE-2025/Swift-Signals/api-gateway/internal/model"
+	commonpb "github.com/COS301-SE-2025/Swift-Signals/protos/gen/swiftsignals/common/v1"
+	optimisationpb "github.com/COS301-SE-2025/Swift-Signals/protos/gen/swiftsignals/optimisation/v1"
+	errs "github.com/COS301-SE-2025/Swift-Signals/shared/error"
+	"github.com/stretchr/testify/mock"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+func (suite *TestSuite) TestStreamOptimisation_Success() {
+	ctx := context.Background()
+
+	params := model.OptimisationParameters{
+		OptimisationType: "OPTIMISATION_TYPE_GENETIC_EVALUATION",
+		SimulationParameters: model.SimulationParameters{
+			IntersectionType: "INTERSECTION_TYPE_TRAFFICLIGHT",
+			Green:            10,
+			Yellow:           3,
+			Red:              7,
+			Speed:            60,
+			Seed:             12345,
+		},
+	}
+
+	stream := mocks.NewMockOptimisationService_StreamOptimisationClient[optimisationpb.OptimisationProgress](
+		suite.T(),
+	)
+
+	suite.grpcClient.On("StreamOptimisation",
+		mock.MatchedBy(func(ctx context.Context) bool {
+			_, hasDeadline := ctx.Deadline()
+			return !hasDeadline
+		}),
+		mock.MatchedBy(func(req *commonpb.OptimisationParameters) bool {
+			return req.OptimisationType == commonpb.OptimisationType_OPTIMISATION_TYPE_GENETIC_EVALUATION &&
+				req.Parameters.IntersectionType == commonpb.IntersectionType_INTERSECTION_TYPE_TRAFFICLIGHT &&
+				req.Parameters.Green == 10 &&
+				req.Parameters.Seed == 12345
+		})).Return(stream, nil)
+
+	result, err := suite.client.StreamOptimisation(ctx, params)
+
+	suite.Require().NoError(err)
+	suite.Equal(stream, result)
+	suite.grpcClient.AssertExpectations(suite.T())
+}
+
+func (suite *TestSuite) TestStreamOptimisation_Unavailable() {
+	ctx := context.Background()
+
+	suite.grpcClient.On("StreamOptimisation", mock.Anything, mock.Anything).
+		Return(nil, status.Error(codes.Unavailable, "optimisation service unavailable"))
+
+	result, err := suite.client.StreamOptimisation(ctx, model.OptimisationParameters{})
+
+	suite.Require().Error(err)
+	suite.Nil(result)
+
+	svcErr, ok := err.(*errs.ServiceError)
+	suite.Require().True(ok)
+	suite.Equal(errs.ErrInternal, svcErr.Code)
+}
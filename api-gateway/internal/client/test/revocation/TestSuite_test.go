@@ -0,0 +1,25 @@
+package revocation
+
+import (
+	"testing"
+	"time"
+
+	"github.com/COS301-SE-2025/Swift-Signals/api-gateway/internal/client"
+	mocks "github.com/COS301-SE-2025/Swift-Signals/api-gateway/internal/mocks/client"
+	"github.com/stretchr/testify/suite"
+)
+
+type TestSuite struct {
+	suite.Suite
+	userClient *mocks.MockUserClientInterface
+	client     *client.CachedUserClient
+}
+
+func (suite *TestSuite) SetupTest() {
+	suite.userClient = new(mocks.MockUserClientInterface)
+	suite.client = client.NewCachedUserClient(suite.userClient, time.Minute)
+}
+
+func TestCachedUserClient(t *testing.T) {
+	suite.Run(t, new(TestSuite))
+}
@@ -0,0 +1,217 @@
+package revocation
+
+import (
+	"context"
+	"time"
+
+	"github.com/COS301-SE-2025/Swift-Signals/api-gateway/internal/client"
+	"github.com/COS301-SE-2025/Swift-Signals/api-gateway/internal/middleware"
+	errs "github.com/COS301-SE-2025/Swift-Signals/shared/error"
+	"github.com/stretchr/testify/mock"
+	"google.golang.org/protobuf/types/known/emptypb"
+)
+
+var issuedAt = time.Date(2025, 7, 1, 12, 0, 0, 0, time.UTC)
+
+func (suite *TestSuite) TestIsTokenRevoked_CachesResponse() {
+	ctx := context.Background()
+
+	suite.userClient.On("IsTokenRevoked", mock.Anything, "user-1", "token-1", issuedAt).
+		Return(false, nil).
+		Once()
+
+	for range 3 {
+		revoked, err := suite.client.IsTokenRevoked(ctx, "user-1", "token-1", issuedAt)
+		suite.Require().NoError(err)
+		suite.False(revoked)
+	}
+
+	suite.userClient.AssertExpectations(suite.T())
+}
+
+func (suite *TestSuite) TestIsTokenRevoked_CachesPerToken() {
+	ctx := context.Background()
+
+	suite.userClient.On("IsTokenRevoked", mock.Anything, "user-1", "token-1", issuedAt).
+		Return(true, nil).
+		Once()
+	suite.userClient.On("IsTokenRevoked", mock.Anything, "user-1", "token-2", issuedAt).
+		Return(false, nil).
+		Once()
+
+	revoked, err := suite.client.IsTokenRevoked(ctx, "user-1", "token-1", issuedAt)
+	suite.Require().NoError(err)
+	suite.True(revoked)
+	revoked, err = suite.client.IsTokenRevoked(ctx, "user-1", "token-2", issuedAt)
+	suite.Require().NoError(err)
+	suite.False(revoked)
+
+	suite.userClient.AssertExpectations(suite.T())
+}
+
+func (suite *TestSuite) TestIsTokenRevoked_CachesTokensWithoutIDPerIssue() {
+	ctx := context.Background()
+	reissuedAt := issuedAt.Add(time.Hour)
+
+	suite.userClient.On("IsTokenRevoked", mock.Anything, "user-1", "", issuedAt).
+		Return(true, nil).
+		Once()
+	suite.userClient.On("IsTokenRevoked", mock.Anything, "user-1", "", reissuedAt).
+		Return(false, nil).
+		Once()
+
+	revoked, err := suite.client.IsTokenRevoked(ctx, "user-1", "", issuedAt)
+	suite.Require().NoError(err)
+	suite.True(revoked)
+	revoked, err = suite.client.IsTokenRevoked(ctx, "user-1", "", reissuedAt)
+	suite.Require().NoError(err)
+	suite.False(revoked)
+
+	suite.userClient.AssertExpectations(suite.T())
+}
+
+func (suite *TestSuite) TestIsTokenRevoked_ErrorNotCached() {
+	ctx := context.Background()
+
+	suite.userClient.On("IsTokenRevoked", mock.Anything, "user-1", "token-1", issuedAt).
+		Return(false, errs.NewUnavailableError("user service unavailable", nil)).
+		Once()
+	suite.userClient.On("IsTokenRevoked", mock.Anything, "user-1", "token-1", issuedAt).
+		Return(false, nil).
+		Once()
+
+	_, err := suite.client.IsTokenRevoked(ctx, "user-1", "token-1", issuedAt)
+	suite.Require().Error(err)
+	revoked, err := suite.client.IsTokenRevoked(ctx, "user-1", "token-1", issuedAt)
+	suite.Require().NoError(err)
+	suite.False(revoked)
+
+	suite.userClient.AssertExpectations(suite.T())
+}
+
+func (suite *TestSuite) TestIsTokenRevoked_EntriesExpire() {
+	ctx := context.Background()
+	cachedClient := client.NewCachedUserClient(suite.userClient, 10*time.Millisecond)
+
+	suite.userClient.On("IsTokenRevoked", mock.Anything, "user-1", "token-1", issuedAt).
+		Return(false, nil).
+		Twice()
+
+	_, err := cachedClient.IsTokenRevoked(ctx, "user-1", "token-1", issuedAt)
+	suite.Require().NoError(err)
+	time.Sleep(20 * time.Millisecond)
+	_, err = cachedClient.IsTokenRevoked(ctx, "user-1", "token-1", issuedAt)
+	suite.Require().NoError(err)
+
+	suite.userClient.AssertExpectations(suite.T())
+}
+
+func (suite *TestSuite) TestLogoutUser_ForgetsUsersTokens() {
+	ctx := middleware.SetUserID(context.Background(), "user-1")
+
+	suite.userClient.On("IsTokenRevoked", mock.Anything, "user-1", "token-1", issuedAt).
+		Return(false, nil).
+		Once()
+	suite.userClient.On("LogoutUser", mock.Anything, "valid.jwt.token", "").
+		Return(&emptypb.Empty{}, nil)
+	suite.userClient.On("IsTokenRevoked", mock.Anything, "user-1", "token-1", issuedAt).
+		Return(true, nil).
+		Once()
+
+	revoked, err := suite.client.IsTokenRevoked(ctx, "user-1", "token-1", issuedAt)
+	suite.Require().NoError(err)
+	suite.False(revoked)
+
+	_, err = suite.client.LogoutUser(ctx, "valid.jwt.token", "")
+	suite.Require().NoError(err)
+
+	revoked, err = suite.client.IsTokenRevoked(ctx, "user-1", "token-1", issuedAt)
+	suite.Require().NoError(err)
+	suite.True(revoked)
+
+	suite.userClient.AssertExpectations(suite.T())
+}
+
+func (suite *TestSuite) TestLogoutUser_DuringLookupNotCached() {
+	ctx := middleware.SetUserID(context.Background(), "user-1")
+
+	suite.userClient.On("LogoutUser", mock.Anything, "valid.jwt.token", "").
+		Return(&emptypb.Empty{}, nil)
+	suite.userClient.On("IsTokenRevoked", mock.Anything, "user-1", "token-1", issuedAt).
+		Run(func(args mock.Arguments) {
+			// NOTE: The user logs out after the user service answered but before the
+			// answer is cached
+			_, err := suite.client.LogoutUser(ctx, "valid.jwt.token", "")
+			suite.Require().NoError(err)
+		}).
+		Return(false, nil).
+		Once()
+	suite.userClient.On("IsTokenRevoked", mock.Anything, "user-1", "token-1", issuedAt).
+		Return(true, nil).
+		Once()
+
+	revoked, err := suite.client.IsTokenRevoked(ctx, "user-1", "token-1", issuedAt)
+	suite.Require().NoError(err)
+	suite.False(revoked)
+
+	revoked, err = suite.client.IsTokenRevoked(ctx, "user-1", "token-1", issuedAt)
+	suite.Require().NoError(err)
+	suite.True(revoked)
+
+	suite.userClient.AssertExpectations(suite.T())
+}
+
+func (suite *TestSuite) TestLogoutUser_FailureKeepsCache() {
+	ctx := middleware.SetUserID(context.Background(), "user-1")
+
+	suite.userClient.On("IsTokenRevoked", mock.Anything, "user-1", "token-1", issuedAt).
+		Return(false, nil).
+		Once()
+	suite.userClient.On("LogoutUser", mock.Anything, "invalid-token", "").
+		Return(nil, errs.NewUnauthorizedError("invalid token", nil))
+
+	_, err := suite.client.IsTokenRevoked(ctx, "user-1", "token-1", issuedAt)
+	suite.Require().NoError(err)
+
+	_, err = suite.client.LogoutUser(ctx, "invalid-token", "")
+	suite.Require().Error(err)
+
+	_, err = suite.client.IsTokenRevoked(ctx, "user-1", "token-1", issuedAt)
+	suite.Require().NoError(err)
+
+	suite.userClient.AssertExpectations(suite.T())
+}
+
+func (suite *TestSuite) TestDeleteUser_ForgetsUsersTokens() {
+	ctx := context.Background()
+
+	suite.userClient.On("IsTokenRevoked", mock.Anything, "user-1", "token-1", issuedAt).
+		Return(false, nil).
+		Once()
+	suite.userClient.On("IsTokenRevoked", mock.Anything, "user-2", "token-2", issuedAt).
+		Return(false, nil).
+		Once()
+	suite.userClient.On("DeleteUser", mock.Anything, "user-1").
+		Return(&emptypb.Empty{}, nil)
+	suite.userClient.On("IsTokenRevoked", mock.Anything, "user-1", "token-1", issuedAt).
+		Return(true, nil).
+		Once()
+
+	_, err := suite.client.IsTokenRevoked(ctx, "user-1", "token-1", issuedAt)
+	suite.Require().NoError(err)
+	_, err = suite.client.IsTokenRevoked(ctx, "user-2", "token-2", issuedAt)
+	suite.Require().NoError(err)
+
+	_, err = suite.client.DeleteUser(ctx, "user-1")
+	suite.Require().NoError(err)
+
+	revoked, err := suite.client.IsTokenRevoked(ctx, "user-1", "token-1", issuedAt)
+	suite.Require().NoError(err)
+	suite.True(revoked)
+	// NOTE: Other users' tokens are still answered from the cache
+	revoked, err = suite.client.IsTokenRevoked(ctx, "user-2", "token-2", issuedAt)
+	suite.Require().NoError(err)
+	suite.False(revoked)
+
+	suite.userClient.AssertExpectations(suite.T())
+}
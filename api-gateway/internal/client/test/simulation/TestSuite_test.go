@@ -0,0 +1,60 @@
+package simulation
+
+import (
+	"testing"
+
+	"github.com/COS301-SE-2025/Swift-Signals/api-gateway/internal/cache"
+	"github.com/COS301-SE-2025/Swift-Signals/api-gateway/internal/client"
+	mocks "github.com/COS301-SE-2025/Swift-Signals/api-gateway/internal/mocks/client"
+	grpcmocks "github.com/COS301-SE-2025/Swift-Signals/api-gateway/internal/mocks/grpc_client"
+	"github.com/COS301-SE-2025/Swift-Signals/api-gateway/internal/model"
+	simulationpb "github.com/COS301-SE-2025/Swift-Signals/protos/gen/swiftsignals/simulation/v1"
+	"github.com/stretchr/testify/suite"
+)
+
+type TestSuite struct {
+	suite.Suite
+	grpcClient   *grpcmocks.MockSimulationServiceClient
+	client       *client.SimulationClient
+	simClient    *mocks.MockSimulationClientInterface
+	cache        *cache.SimulationCache
+	cachedClient *client.CachedSimulationClient
+}
+
+func (suite *TestSuite) SetupTest() {
+	suite.grpcClient = new(grpcmocks.MockSimulationServiceClient)
+	suite.client = client.NewSimulationClient(suite.grpcClient)
+	suite.simClient = new(mocks.MockSimulationClientInterface)
+	suite.cache = cache.NewSimulationCache(1<<20, nil)
+	suite.cachedClient = client.NewCachedSimulationClient(suite.simClient, suite.cache)
+}
+
+func createTestSimulation() *simulationpb.SimulationResponse {
+	return &simulationpb.SimulationResponse{
+		Results: &simulationpb.SimulationResultsResponse{
+			TotalVehicles:      100,
+			AverageWaitingTime: 12.5,
+		},
+		Output: &simulationpb.SimulationOutputResponse{
+			Intersection: &simulationpb.Intersection{},
+			Vehicles: []*simulationpb.Vehicle{
+				{Id: "vehicle-1", Positions: []*simulationpb.Position{{Time: 1, X: 2, Y: 3}}},
+			},
+		},
+	}
+}
+
+func createTestParameters(green int) model.SimulationParameters {
+	return model.SimulationParameters{
+		IntersectionType: "INTERSECTION_TYPE_TRAFFICLIGHT",
+		Green:            green,
+		Yellow:           3,
+		Red:              7,
+		Speed:            60,
+		Seed:             12345,
+	}
+}
+
+func TestClient(t *testing.T) {
+	suite.Run(t, new(TestSuite))
+}
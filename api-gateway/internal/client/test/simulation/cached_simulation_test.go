@@ -0,0 +1,147 @@
+package simulation
+
+import (
+	"context"
+
+	"github.com/COS301-SE-2025/Swift-Signals/api-gateway/internal/client"
+	simulationpb "github.com/COS301-SE-2025/Swift-Signals/protos/gen/swiftsignals/simulation/v1"
+	errs "github.com/COS301-SE-2025/Swift-Signals/shared/error"
+	"github.com/stretchr/testify/mock"
+)
+
+func (suite *TestSuite) TestCachedRunSimulation_CachesResponse() {
+	ctx := context.Background()
+	params := createTestParameters(10)
+
+	suite.simClient.On("RunSimulation", mock.Anything, "intersection-123", params).
+		Return(createTestSimulation(), nil).
+		Once()
+
+	for range 2 {
+		result, err := suite.cachedClient.RunSimulation(ctx, "intersection-123", params)
+		suite.Require().NoError(err)
+		suite.Equal(int64(100), result.Results.TotalVehicles)
+		suite.Equal(float32(12.5), result.Results.AverageWaitingTime)
+		suite.Require().Len(result.Output.Vehicles, 1)
+		suite.Equal("vehicle-1", result.Output.Vehicles[0].Id)
+	}
+
+	suite.simClient.AssertExpectations(suite.T())
+}
+
+func (suite *TestSuite) TestCachedRunSimulation_ReportsHit() {
+	params := createTestParameters(10)
+
+	suite.simClient.On("RunSimulation", mock.Anything, "intersection-123", params).
+		Return(createTestSimulation(), nil).
+		Once()
+
+	ctx, report := client.WithCacheReport(context.Background())
+	_, err := suite.cachedClient.RunSimulation(ctx, "intersection-123", params)
+	suite.Require().NoError(err)
+	suite.False(report.Hit)
+
+	ctx, report = client.WithCacheReport(context.Background())
+	_, err = suite.cachedClient.RunSimulation(ctx, "intersection-123", params)
+	suite.Require().NoError(err)
+	suite.True(report.Hit)
+
+	suite.simClient.AssertExpectations(suite.T())
+}
+
+func (suite *TestSuite) TestCachedRunSimulation_ParameterChangeMisses() {
+	ctx := context.Background()
+
+	suite.simClient.On("RunSimulation", mock.Anything, "intersection-123", mock.Anything).
+		Return(createTestSimulation(), nil).
+		Twice()
+
+	_, err := suite.cachedClient.RunSimulation(ctx, "intersection-123", createTestParameters(10))
+	suite.Require().NoError(err)
+	_, err = suite.cachedClient.RunSimulation(ctx, "intersection-123", createTestParameters(20))
+	suite.Require().NoError(err)
+
+	suite.simClient.AssertExpectations(suite.T())
+}
+
+func (suite *TestSuite) TestCachedRunSimulation_InvalidationMisses() {
+	ctx := context.Background()
+	params := createTestParameters(10)
+
+	suite.simClient.On("RunSimulation", mock.Anything, "intersection-123", params).
+		Return(createTestSimulation(), nil).
+		Twice()
+
+	_, err := suite.cachedClient.RunSimulation(ctx, "intersection-123", params)
+	suite.Require().NoError(err)
+	suite.cache.InvalidateIntersection(ctx, "intersection-123")
+	_, err = suite.cachedClient.RunSimulation(ctx, "intersection-123", params)
+	suite.Require().NoError(err)
+
+	suite.simClient.AssertExpectations(suite.T())
+}
+
+func (suite *TestSuite) TestCachedRunSimulation_ErrorNotCached() {
+	ctx := context.Background()
+	params := createTestParameters(10)
+
+	suite.simClient.On("RunSimulation", mock.Anything, "intersection-123", params).
+		Return(nil, errs.NewUnavailableError("simulation service unavailable", map[string]any{})).
+		Once()
+	suite.simClient.On("RunSimulation", mock.Anything, "intersection-123", params).
+		Return(createTestSimulation(), nil).
+		Once()
+
+	_, err := suite.cachedClient.RunSimulation(ctx, "intersection-123", params)
+	suite.Require().Error(err)
+	svcError, ok := err.(*errs.ServiceError)
+	suite.True(ok)
+	suite.Equal(errs.ErrUnavailable, svcError.Code)
+
+	result, err := suite.cachedClient.RunSimulation(ctx, "intersection-123", params)
+	suite.Require().NoError(err)
+	suite.Equal(int64(100), result.Results.TotalVehicles)
+
+	suite.simClient.AssertExpectations(suite.T())
+}
+
+func (suite *TestSuite) TestCachedRunSimulation_ReturnsCopies() {
+	ctx := context.Background()
+	params := createTestParameters(10)
+
+	suite.simClient.On("RunSimulation", mock.Anything, "intersection-123", params).
+		Return(createTestSimulation(), nil).
+		Once()
+
+	first, err := suite.cachedClient.RunSimulation(ctx, "intersection-123", params)
+	suite.Require().NoError(err)
+	first.Results.TotalVehicles = 0
+	first.Output.Vehicles = []*simulationpb.Vehicle{}
+
+	second, err := suite.cachedClient.RunSimulation(ctx, "intersection-123", params)
+	suite.Require().NoError(err)
+	suite.Equal(int64(100), second.Results.TotalVehicles)
+	suite.Len(second.Output.Vehicles, 1)
+}
+
+func (suite *TestSuite) TestCachedRunWhatIfSimulation_CachedApartFromRunSimulation() {
+	ctx := context.Background()
+	params := createTestParameters(10)
+
+	suite.simClient.On("RunSimulation", mock.Anything, "intersection-123", params).
+		Return(createTestSimulation(), nil).
+		Once()
+	suite.simClient.On("RunWhatIfSimulation", mock.Anything, "intersection-123", params).
+		Return(createTestSimulation(), nil).
+		Once()
+
+	_, err := suite.cachedClient.RunSimulation(ctx, "intersection-123", params)
+	suite.Require().NoError(err)
+	for range 2 {
+		result, err := suite.cachedClient.RunWhatIfSimulation(ctx, "intersection-123", params)
+		suite.Require().NoError(err)
+		suite.Equal(int64(100), result.Results.TotalVehicles)
+	}
+
+	suite.simClient.AssertExpectations(suite.T())
+}
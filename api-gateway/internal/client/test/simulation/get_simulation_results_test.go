@@ -0,0 +1,87 @@
+package simulation
+
+import (
+	"context"
+
+	simulationpb "github.com/COS301-SE-2025/Swift-Signals/protos/gen/swiftsignals/simulation/v1"
+	errs "github.com/COS301-SE-2025/Swift-Signals/shared/error"
+	"github.com/stretchr/testify/mock"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+func (suite *TestSuite) TestGetSimulationResults_Success() {
+	ctx := context.Background()
+	expected := createTestSimulation().Results
+
+	suite.grpcClient.On("GetSimulationResults",
+		mock.MatchedBy(func(ctx context.Context) bool {
+			_, hasDeadline := ctx.Deadline()
+			return hasDeadline
+		}),
+		mock.MatchedBy(func(req *simulationpb.SimulationRequest) bool {
+			return req.IntersectionId == "intersection-123" &&
+				req.SimulationParameters.Green == 10 &&
+				req.SimulationParameters.Seed == 12345
+		})).Return(expected, nil)
+
+	result, err := suite.client.GetSimulationResults(ctx, "intersection-123", createTestParameters(10))
+
+	suite.Require().NoError(err)
+	suite.Equal(expected, result)
+	suite.grpcClient.AssertExpectations(suite.T())
+	suite.grpcClient.AssertNotCalled(suite.T(), "RunSimulation", mock.Anything, mock.Anything)
+}
+
+func (suite *TestSuite) TestGetSimulationResults_ServiceError() {
+	ctx := context.Background()
+
+	suite.grpcClient.On("GetSimulationResults", mock.Anything, mock.Anything).
+		Return(nil, status.Error(codes.Internal, "simulation failed"))
+
+	result, err := suite.client.GetSimulationResults(ctx, "intersection-123", createTestParameters(10))
+
+	suite.Nil(result)
+	suite.Require().Error(err)
+	svcError, ok := err.(*errs.ServiceError)
+	suite.True(ok)
+	suite.Equal(errs.ErrInternal, svcError.Code)
+}
+
+func (suite *TestSuite) TestCachedGetSimulationResults_CachesResponse() {
+	ctx := context.Background()
+	params := createTestParameters(10)
+
+	suite.simClient.On("GetSimulationResults", mock.Anything, "intersection-123", params).
+		Return(createTestSimulation().Results, nil).
+		Once()
+
+	for range 2 {
+		result, err := suite.cachedClient.GetSimulationResults(ctx, "intersection-123", params)
+		suite.Require().NoError(err)
+		suite.Equal(int64(100), result.TotalVehicles)
+		suite.Equal(float32(12.5), result.AverageWaitingTime)
+	}
+
+	suite.simClient.AssertExpectations(suite.T())
+}
+
+func (suite *TestSuite) TestCachedGetSimulationResults_SeparateFromSimulations() {
+	ctx := context.Background()
+	params := createTestParameters(10)
+
+	suite.simClient.On("RunSimulation", mock.Anything, "intersection-123", params).
+		Return(createTestSimulation(), nil).
+		Once()
+	suite.simClient.On("GetSimulationResults", mock.Anything, "intersection-123", params).
+		Return(createTestSimulation().Results, nil).
+		Once()
+
+	_, err := suite.cachedClient.RunSimulation(ctx, "intersection-123", params)
+	suite.Require().NoError(err)
+	result, err := suite.cachedClient.GetSimulationResults(ctx, "intersection-123", params)
+	suite.Require().NoError(err)
+	suite.Equal(int64(100), result.TotalVehicles)
+
+	suite.simClient.AssertExpectations(suite.T())
+}
@@ -0,0 +1,127 @@
+package simulation
+
+import (
+	"context"
+	"time"
+
+	commonpb "github.com/COS301-SE-2025/Swift-Signals/protos/gen/swiftsignals/common/v1"
+	simulationpb "github.com/COS301-SE-2025/Swift-Signals/protos/gen/swiftsignals/simulation/v1"
+	errs "github.com/COS301-SE-2025/Swift-Signals/shared/error"
+	"github.com/stretchr/testify/mock"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+func (suite *TestSuite) TestRunSimulation_Success() {
+	ctx := context.Background()
+	expected := createTestSimulation()
+
+	suite.grpcClient.On("RunSimulation",
+		mock.MatchedBy(func(ctx context.Context) bool {
+			_, hasDeadline := ctx.Deadline()
+			return hasDeadline
+		}),
+		mock.MatchedBy(func(req *simulationpb.SimulationRequest) bool {
+			return req.IntersectionId == "intersection-123" &&
+				req.SimulationParameters.IntersectionType == commonpb.IntersectionType_INTERSECTION_TYPE_TRAFFICLIGHT &&
+				req.SimulationParameters.Green == 10 &&
+				req.SimulationParameters.Yellow == 3 &&
+				req.SimulationParameters.Red == 7 &&
+				req.SimulationParameters.Speed == 60 &&
+				req.SimulationParameters.Seed == 12345
+		})).Return(expected, nil)
+
+	result, err := suite.client.RunSimulation(ctx, "intersection-123", createTestParameters(10))
+
+	suite.Require().NoError(err)
+	suite.Equal(expected, result)
+	suite.grpcClient.AssertExpectations(suite.T())
+}
+
+func (suite *TestSuite) TestRunSimulation_KeepsCallerDeadline() {
+	deadline := time.Now().Add(time.Minute)
+	ctx, cancel := context.WithDeadline(context.Background(), deadline)
+	defer cancel()
+
+	suite.grpcClient.On("RunSimulation",
+		mock.MatchedBy(func(ctx context.Context) bool {
+			callDeadline, _ := ctx.Deadline()
+			return callDeadline.Equal(deadline)
+		}),
+		mock.Anything).Return(createTestSimulation(), nil)
+
+	_, err := suite.client.RunSimulation(ctx, "intersection-123", createTestParameters(10))
+
+	suite.Require().NoError(err)
+	suite.grpcClient.AssertExpectations(suite.T())
+}
+
+func (suite *TestSuite) TestRunSimulation_TrafficDensity() {
+	ctx := context.Background()
+	densities := map[string]commonpb.TrafficDensity{
+		"":     commonpb.TrafficDensity_TRAFFIC_DENSITY_UNSPECIFIED,
+		"low":  commonpb.TrafficDensity_TRAFFIC_DENSITY_LOW,
+		"high": commonpb.TrafficDensity_TRAFFIC_DENSITY_HIGH,
+	}
+
+	for density, expected := range densities {
+		params := createTestParameters(10)
+		params.TrafficDensity = density
+		suite.grpcClient.On("RunSimulation", mock.Anything,
+			mock.MatchedBy(func(req *simulationpb.SimulationRequest) bool {
+				return req.SimulationParameters.TrafficDensity == expected
+			})).Return(createTestSimulation(), nil).Once()
+
+		_, err := suite.client.RunSimulation(ctx, "intersection-123", params)
+
+		suite.Require().NoError(err)
+	}
+	suite.grpcClient.AssertExpectations(suite.T())
+}
+
+func (suite *TestSuite) TestRunSimulation_InvalidIntersectionType() {
+	ctx := context.Background()
+	params := createTestParameters(10)
+	params.IntersectionType = "roundabout"
+
+	result, err := suite.client.RunSimulation(ctx, "intersection-123", params)
+
+	suite.Nil(result)
+	suite.Require().Error(err)
+	svcError, ok := err.(*errs.ServiceError)
+	suite.True(ok)
+	suite.Equal(errs.ErrValidation, svcError.Code)
+	suite.grpcClient.AssertNotCalled(suite.T(), "RunSimulation", mock.Anything, mock.Anything)
+}
+
+func (suite *TestSuite) TestRunSimulation_IncompleteResponse() {
+	ctx := context.Background()
+
+	suite.grpcClient.On("RunSimulation", mock.Anything, mock.Anything).
+		Return(&simulationpb.SimulationResponse{
+			Results: &simulationpb.SimulationResultsResponse{},
+		}, nil)
+
+	result, err := suite.client.RunSimulation(ctx, "intersection-123", createTestParameters(10))
+
+	suite.Nil(result)
+	suite.Require().Error(err)
+	svcError, ok := err.(*errs.ServiceError)
+	suite.True(ok)
+	suite.Equal(errs.ErrInternal, svcError.Code)
+}
+
+func (suite *TestSuite) TestRunSimulation_ServiceError() {
+	ctx := context.Background()
+
+	suite.grpcClient.On("RunSimulation", mock.Anything, mock.Anything).
+		Return(nil, status.Error(codes.Internal, "simulation failed"))
+
+	result, err := suite.client.RunSimulation(ctx, "intersection-123", createTestParameters(10))
+
+	suite.Nil(result)
+	suite.Require().Error(err)
+	svcError, ok := err.(*errs.ServiceError)
+	suite.True(ok)
+	suite.Equal(errs.ErrInternal, svcError.Code)
+}
@@ -0,0 +1,80 @@
+package simulation
+
+import (
+	"context"
+
+	grpcmocks "github.com/COS301-SE-2025/Swift-Signals/api-gateway/internal/mocks/grpc_client"
+	commonpb "github.com/COS301-SE-2025/Swift-Signals/protos/gen/swiftsignals/common/v1"
+	simulationpb "github.com/COS301-SE-2025/Swift-Signals/protos/gen/swiftsignals/simulation/v1"
+	errs "github.com/COS301-SE-2025/Swift-Signals/shared/error"
+	"github.com/stretchr/testify/mock"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+func (suite *TestSuite) TestStreamSimulationOutput_Success() {
+	ctx := context.Background()
+	stream := grpcmocks.NewMockSimulationService_StreamSimulationOutputClient[simulationpb.SimulationOutputChunk](
+		suite.T(),
+	)
+
+	suite.grpcClient.On("StreamSimulationOutput",
+		mock.MatchedBy(func(ctx context.Context) bool {
+			// The stream lives as long as its reader, so no deadline is imposed by the client
+			_, hasDeadline := ctx.Deadline()
+			return !hasDeadline
+		}),
+		mock.MatchedBy(func(req *simulationpb.SimulationRequest) bool {
+			return req.IntersectionId == "intersection-123" &&
+				req.SimulationParameters.IntersectionType == commonpb.IntersectionType_INTERSECTION_TYPE_TRAFFICLIGHT &&
+				req.SimulationParameters.Green == 10
+		})).Return(stream, nil)
+
+	result, err := suite.client.StreamSimulationOutput(
+		ctx,
+		"intersection-123",
+		createTestParameters(10),
+	)
+
+	suite.Require().NoError(err)
+	suite.Equal(stream, result)
+	suite.grpcClient.AssertExpectations(suite.T())
+}
+
+func (suite *TestSuite) TestStreamSimulationOutput_ServiceUnavailable() {
+	ctx := context.Background()
+
+	suite.grpcClient.On("StreamSimulationOutput", mock.Anything, mock.Anything).
+		Return(nil, status.Error(codes.Unavailable, "simulation service unavailable"))
+
+	result, err := suite.client.StreamSimulationOutput(
+		ctx,
+		"intersection-123",
+		createTestParameters(10),
+	)
+
+	suite.Nil(result)
+	suite.Require().Error(err)
+	_, ok := err.(*errs.ServiceError)
+	suite.True(ok)
+}
+
+func (suite *TestSuite) TestCachedStreamSimulationOutput_NotCached() {
+	ctx := context.Background()
+	params := createTestParameters(10)
+	stream := grpcmocks.NewMockSimulationService_StreamSimulationOutputClient[simulationpb.SimulationOutputChunk](
+		suite.T(),
+	)
+
+	suite.simClient.On("StreamSimulationOutput", ctx, "intersection-123", params).
+		Return(stream, nil).
+		Twice()
+
+	for range 2 {
+		result, err := suite.cachedClient.StreamSimulationOutput(ctx, "intersection-123", params)
+		suite.Require().NoError(err)
+		suite.Equal(stream, result)
+	}
+
+	suite.simClient.AssertExpectations(suite.T())
+}
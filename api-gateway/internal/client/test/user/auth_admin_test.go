@@ -6,6 +6,7 @@ import (
 	"time"
 
 	userpb "github.com/COS301-SE-2025/Swift-Signals/protos/gen/swiftsignals/user/v1"
+	errs "github.com/COS301-SE-2025/Swift-Signals/shared/error"
 	"github.com/stretchr/testify/mock"
 	"github.com/stretchr/testify/suite"
 	"google.golang.org/grpc/codes"
@@ -17,7 +18,8 @@ import (
 func (suite *TestSuite) TestLogoutUser_Success() {
 	// Arrange
 	ctx := context.Background()
-	userID := "user-123"
+	token := "valid.jwt.token"
+	refreshToken := "refresh-token"
 
 	expectedResponse := &emptypb.Empty{}
 
@@ -30,12 +32,12 @@ func (suite *TestSuite) TestLogoutUser_Success() {
 			timeUntilDeadline := time.Until(deadline)
 			return timeUntilDeadline > 4*time.Second && timeUntilDeadline <= 5*time.Second
 		}),
-		mock.MatchedBy(func(req *userpb.UserIDRequest) bool {
-			return req.UserId == userID
+		mock.MatchedBy(func(req *userpb.LogoutUserRequest) bool {
+			return req.Token == token && req.RefreshToken == refreshToken
 		})).Return(expectedResponse, nil)
 
 	// Act
-	result, err := suite.client.LogoutUser(ctx, userID)
+	result, err := suite.client.LogoutUser(ctx, token, refreshToken)
 
 	// Assert
 	suite.Require().NoError(err)
@@ -48,12 +50,12 @@ func (suite *TestSuite) TestLogoutUser_BuildsCorrectRequest() {
 	ctx := context.Background()
 
 	suite.grpcClient.Mock.On("LogoutUser", mock.AnythingOfType("*context.timerCtx"),
-		mock.MatchedBy(func(req *userpb.UserIDRequest) bool {
-			return req.UserId == "logout-user-id"
+		mock.MatchedBy(func(req *userpb.LogoutUserRequest) bool {
+			return req.Token == "logout.jwt.token"
 		})).Return(&emptypb.Empty{}, nil)
 
 	// Act
-	_, err := suite.client.LogoutUser(ctx, "logout-user-id")
+	_, err := suite.client.LogoutUser(ctx, "logout.jwt.token", "")
 
 	// Assert
 	suite.Require().NoError(err)
@@ -73,30 +75,30 @@ func (suite *TestSuite) TestLogoutUser_SetsTimeoutContext() {
 			timeUntilDeadline := time.Until(deadline)
 			return timeUntilDeadline > 4*time.Second && timeUntilDeadline <= 5*time.Second
 		}),
-		mock.AnythingOfType("*user.UserIDRequest")).
+		mock.AnythingOfType("*user.LogoutUserRequest")).
 		Return(&emptypb.Empty{}, nil)
 
 	// Act
-	_, err := suite.client.LogoutUser(ctx, "test-user")
+	_, err := suite.client.LogoutUser(ctx, "valid.jwt.token", "")
 
 	// Assert
 	suite.Require().NoError(err)
 	suite.grpcClient.AssertExpectations(suite.T())
 }
 
-func (suite *TestSuite) TestLogoutUser_UserNotFound() {
+func (suite *TestSuite) TestLogoutUser_InvalidToken() {
 	// Arrange
 	ctx := context.Background()
 
-	grpcErr := status.Error(codes.NotFound, "user not found")
+	grpcErr := status.Error(codes.Unauthenticated, "invalid token")
 
 	suite.grpcClient.On("LogoutUser",
 		mock.AnythingOfType("*context.timerCtx"),
-		mock.AnythingOfType("*user.UserIDRequest")).
+		mock.AnythingOfType("*user.LogoutUserRequest")).
 		Return(nil, grpcErr)
 
 	// Act
-	result, err := suite.client.LogoutUser(ctx, "nonexistent-user")
+	result, err := suite.client.LogoutUser(ctx, "invalid-token", "")
 
 	// Assert
 	suite.Require().Error(err)
@@ -104,20 +106,20 @@ func (suite *TestSuite) TestLogoutUser_UserNotFound() {
 	suite.grpcClient.AssertExpectations(suite.T())
 }
 
-func (suite *TestSuite) TestLogoutUser_EmptyUserID() {
+func (suite *TestSuite) TestLogoutUser_EmptyToken() {
 	// Arrange
 	ctx := context.Background()
 
-	grpcErr := status.Error(codes.InvalidArgument, "user ID is required")
+	grpcErr := status.Error(codes.InvalidArgument, "token is required")
 
 	suite.grpcClient.On("LogoutUser",
 		mock.AnythingOfType("*context.timerCtx"),
-		mock.MatchedBy(func(req *userpb.UserIDRequest) bool {
-			return req.UserId == ""
+		mock.MatchedBy(func(req *userpb.LogoutUserRequest) bool {
+			return req.Token == ""
 		})).Return(nil, grpcErr)
 
 	// Act
-	result, err := suite.client.LogoutUser(ctx, "")
+	result, err := suite.client.LogoutUser(ctx, "", "")
 
 	// Assert
 	suite.Require().Error(err)
@@ -125,6 +127,97 @@ func (suite *TestSuite) TestLogoutUser_EmptyUserID() {
 	suite.grpcClient.AssertExpectations(suite.T())
 }
 
+// RefreshToken Tests
+func (suite *TestSuite) TestRefreshToken_Success() {
+	// Arrange
+	ctx := context.Background()
+
+	expectedResponse := &userpb.LoginUserResponse{
+		Token:        "new.jwt.token",
+		RefreshToken: "new-refresh-token",
+	}
+
+	suite.grpcClient.On("RefreshToken",
+		mock.AnythingOfType("*context.timerCtx"),
+		mock.MatchedBy(func(req *userpb.RefreshTokenRequest) bool {
+			return req.RefreshToken == "refresh-token"
+		})).Return(expectedResponse, nil)
+
+	// Act
+	result, err := suite.client.RefreshToken(ctx, "refresh-token")
+
+	// Assert
+	suite.Require().NoError(err)
+	suite.Equal(expectedResponse, result)
+	suite.grpcClient.AssertExpectations(suite.T())
+}
+
+func (suite *TestSuite) TestRefreshToken_Reused() {
+	// Arrange
+	ctx := context.Background()
+
+	grpcErr := status.Error(codes.Unauthenticated, "refresh token has already been used")
+
+	suite.grpcClient.On("RefreshToken",
+		mock.AnythingOfType("*context.timerCtx"),
+		mock.MatchedBy(func(req *userpb.RefreshTokenRequest) bool {
+			return req.RefreshToken == "refresh-token"
+		})).Return(nil, grpcErr)
+
+	// Act
+	result, err := suite.client.RefreshToken(ctx, "refresh-token")
+
+	// Assert
+	suite.Require().Error(err)
+	suite.Nil(result)
+	suite.grpcClient.AssertExpectations(suite.T())
+}
+
+// IsTokenRevoked Tests
+func (suite *TestSuite) TestIsTokenRevoked_Success() {
+	// Arrange
+	ctx := context.Background()
+
+	issuedAt := time.Date(2025, 7, 1, 12, 0, 0, 0, time.UTC)
+
+	suite.grpcClient.On("IsTokenRevoked",
+		mock.AnythingOfType("*context.timerCtx"),
+		mock.MatchedBy(func(req *userpb.TokenRevokedRequest) bool {
+			return req.UserId == "user-123" && req.TokenId == "token-1" &&
+				req.IssuedAt.AsTime().Equal(issuedAt)
+		})).Return(&userpb.TokenRevokedResponse{Revoked: true}, nil)
+
+	// Act
+	revoked, err := suite.client.IsTokenRevoked(ctx, "user-123", "token-1", issuedAt)
+
+	// Assert
+	suite.Require().NoError(err)
+	suite.True(revoked)
+	suite.grpcClient.AssertExpectations(suite.T())
+}
+
+func (suite *TestSuite) TestIsTokenRevoked_Error() {
+	// Arrange
+	ctx := context.Background()
+
+	grpcErr := status.Error(codes.Unavailable, "service unavailable")
+
+	suite.grpcClient.On("IsTokenRevoked",
+		mock.AnythingOfType("*context.timerCtx"),
+		mock.MatchedBy(func(req *userpb.TokenRevokedRequest) bool {
+			return req.UserId == "user-123"
+		})).
+		Return(nil, grpcErr)
+
+	// Act
+	revoked, err := suite.client.IsTokenRevoked(ctx, "user-123", "token-1", time.Now())
+
+	// Assert
+	suite.Require().Error(err)
+	suite.False(revoked)
+	suite.grpcClient.AssertExpectations(suite.T())
+}
+
 // ChangePassword Tests
 func (suite *TestSuite) TestChangePassword_Success() {
 	// Arrange
@@ -369,6 +462,75 @@ func (suite *TestSuite) TestResetPassword_EmptyEmail() {
 	suite.grpcClient.AssertExpectations(suite.T())
 }
 
+func (suite *TestSuite) TestResetPassword_RateLimited() {
+	// Arrange
+	ctx := context.Background()
+
+	grpcErr := status.Error(
+		codes.ResourceExhausted,
+		"too many password reset requests, try again later",
+	)
+
+	suite.grpcClient.On("ResetPassword",
+		mock.AnythingOfType("*context.timerCtx"),
+		mock.MatchedBy(func(req *userpb.ResetPasswordRequest) bool {
+			return req.Email == "user@example.com"
+		})).Return(nil, grpcErr)
+
+	// Act
+	result, err := suite.client.ResetPassword(ctx, "user@example.com")
+
+	// Assert
+	suite.Nil(result)
+	svcErr, ok := err.(*errs.ServiceError)
+	suite.Require().True(ok)
+	suite.Equal(errs.ErrRateLimited, svcErr.Code)
+	suite.grpcClient.AssertExpectations(suite.T())
+}
+
+// ConfirmPasswordReset Tests
+func (suite *TestSuite) TestConfirmPasswordReset_Success() {
+	// Arrange
+	ctx := context.Background()
+
+	suite.grpcClient.On("ConfirmPasswordReset",
+		mock.AnythingOfType("*context.timerCtx"),
+		mock.MatchedBy(func(req *userpb.ConfirmPasswordResetRequest) bool {
+			return req.Token == "reset-token" && req.NewPassword == "newpassword456"
+		})).Return(&emptypb.Empty{}, nil)
+
+	// Act
+	result, err := suite.client.ConfirmPasswordReset(ctx, "reset-token", "newpassword456")
+
+	// Assert
+	suite.Require().NoError(err)
+	suite.NotNil(result)
+	suite.grpcClient.AssertExpectations(suite.T())
+}
+
+func (suite *TestSuite) TestConfirmPasswordReset_InvalidToken() {
+	// Arrange
+	ctx := context.Background()
+
+	grpcErr := status.Error(codes.Unauthenticated, "invalid or expired reset token")
+
+	suite.grpcClient.On("ConfirmPasswordReset",
+		mock.AnythingOfType("*context.timerCtx"),
+		mock.MatchedBy(func(req *userpb.ConfirmPasswordResetRequest) bool {
+			return req.Token == "used-token"
+		})).Return(nil, grpcErr)
+
+	// Act
+	result, err := suite.client.ConfirmPasswordReset(ctx, "used-token", "newpassword456")
+
+	// Assert
+	suite.Nil(result)
+	svcErr, ok := err.(*errs.ServiceError)
+	suite.Require().True(ok)
+	suite.Equal(errs.ErrUnauthorized, svcErr.Code)
+	suite.grpcClient.AssertExpectations(suite.T())
+}
+
 // MakeAdmin Tests
 func (suite *TestSuite) TestMakeAdmin_Success() {
 	// Arrange
@@ -244,11 +244,11 @@ func (suite *TestSuite) TestContextCancellation_LogoutUser() {
 
 	suite.grpcClient.On("LogoutUser",
 		mock.Anything,
-		mock.AnythingOfType("*user.UserIDRequest")).
+		mock.AnythingOfType("*user.LogoutUserRequest")).
 		Return(nil, context.Canceled)
 
 	// Act
-	result, err := suite.client.LogoutUser(ctx, "user-123")
+	result, err := suite.client.LogoutUser(ctx, "valid.jwt.token", "")
 
 	// Assert
 	suite.Require().Error(err)
@@ -380,11 +380,11 @@ func (suite *TestSuite) TestLogoutUser_NilResponse() {
 
 	suite.grpcClient.On("LogoutUser",
 		mock.AnythingOfType("*context.timerCtx"),
-		mock.AnythingOfType("*user.UserIDRequest")).
+		mock.AnythingOfType("*user.LogoutUserRequest")).
 		Return((*emptypb.Empty)(nil), nil)
 
 	// Act
-	result, err := suite.client.LogoutUser(ctx, "user-123")
+	result, err := suite.client.LogoutUser(ctx, "valid.jwt.token", "")
 
 	// Assert
 	suite.Require().NoError(err)
@@ -8,6 +8,7 @@ import (
 	userpb "github.com/COS301-SE-2025/Swift-Signals/protos/gen/swiftsignals/user/v1"
 	"google.golang.org/grpc"
 	"google.golang.org/protobuf/types/known/emptypb"
+	"google.golang.org/protobuf/types/known/timestamppb"
 )
 
 type UserClient struct {
@@ -61,9 +62,32 @@ func (uc *UserClient) LoginUser(
 	return resp, nil
 }
 
-func (uc *UserClient) LogoutUser(ctx context.Context, userID string) (*emptypb.Empty, error) {
-	req := &userpb.UserIDRequest{
-		UserId: userID,
+func (uc *UserClient) RefreshToken(
+	ctx context.Context,
+	refreshToken string,
+) (*userpb.LoginUserResponse, error) {
+	req := &userpb.RefreshTokenRequest{
+		RefreshToken: refreshToken,
+	}
+	ctx, cancel := context.WithTimeout(ctx, 5*time.Second)
+	defer cancel()
+
+	resp, err := uc.client.RefreshToken(ctx, req)
+	if err != nil {
+		return nil, util.GrpcErrorToErr(err)
+	}
+	return resp, nil
+}
+
+// LogoutUser revokes the token, so it is rejected before it expires, along with the
+// session's refresh tokens when a refresh token is given
+func (uc *UserClient) LogoutUser(
+	ctx context.Context,
+	token, refreshToken string,
+) (*emptypb.Empty, error) {
+	req := &userpb.LogoutUserRequest{
+		Token:        token,
+		RefreshToken: refreshToken,
 	}
 	ctx, cancel := context.WithTimeout(ctx, 5*time.Second)
 	defer cancel()
@@ -75,6 +99,28 @@ func (uc *UserClient) LogoutUser(ctx context.Context, userID string) (*emptypb.E
 	return resp, nil
 }
 
+// IsTokenRevoked reports whether the user's token was logged out, issued before the user's
+// password was reset or the user deleted
+func (uc *UserClient) IsTokenRevoked(
+	ctx context.Context,
+	userID, tokenID string,
+	issuedAt time.Time,
+) (bool, error) {
+	req := &userpb.TokenRevokedRequest{
+		UserId:   userID,
+		TokenId:  tokenID,
+		IssuedAt: timestamppb.New(issuedAt),
+	}
+	ctx, cancel := context.WithTimeout(ctx, 5*time.Second)
+	defer cancel()
+
+	resp, err := uc.client.IsTokenRevoked(ctx, req)
+	if err != nil {
+		return false, util.GrpcErrorToErr(err)
+	}
+	return resp.GetRevoked(), nil
+}
+
 func (uc *UserClient) GetUserByID(
 	ctx context.Context,
 	userID string,
@@ -127,6 +173,26 @@ func (uc *UserClient) GetAllUsers(
 	return resp, nil
 }
 
+// GetUsersAfter streams a page of the users whose ID sorts after afterID, or the first page
+// without one
+func (uc *UserClient) GetUsersAfter(
+	ctx context.Context,
+	afterID string,
+	pageSize int32,
+) (userpb.UserService_GetAllUsersClient, error) {
+	req := &userpb.GetAllUsersRequest{
+		Page:     1,
+		PageSize: pageSize,
+		AfterId:  afterID,
+	}
+
+	resp, err := uc.client.GetAllUsers(ctx, req)
+	if err != nil {
+		return nil, util.GrpcErrorToErr(err)
+	}
+	return resp, nil
+}
+
 func (uc *UserClient) UpdateUser(
 	ctx context.Context,
 	user_id, name, email string,
@@ -259,6 +325,24 @@ func (uc *UserClient) ResetPassword(ctx context.Context, email string) (*emptypb
 	return resp, nil
 }
 
+func (uc *UserClient) ConfirmPasswordReset(
+	ctx context.Context,
+	token, newPassword string,
+) (*emptypb.Empty, error) {
+	req := &userpb.ConfirmPasswordResetRequest{
+		Token:       token,
+		NewPassword: newPassword,
+	}
+	ctx, cancel := context.WithTimeout(ctx, 5*time.Second)
+	defer cancel()
+
+	resp, err := uc.client.ConfirmPasswordReset(ctx, req)
+	if err != nil {
+		return nil, util.GrpcErrorToErr(err)
+	}
+	return resp, nil
+}
+
 func (uc *UserClient) MakeAdmin(
 	ctx context.Context,
 	user_id, admin_user_id string,
@@ -299,7 +383,9 @@ func (uc *UserClient) RemoveAdmin(
 type UserClientInterface interface {
 	RegisterUser(ctx context.Context, name, email, password string) (*userpb.UserResponse, error)
 	LoginUser(ctx context.Context, email, password string) (*userpb.LoginUserResponse, error)
-	LogoutUser(ctx context.Context, userID string) (*emptypb.Empty, error)
+	RefreshToken(ctx context.Context, refreshToken string) (*userpb.LoginUserResponse, error)
+	LogoutUser(ctx context.Context, token, refreshToken string) (*emptypb.Empty, error)
+	IsTokenRevoked(ctx context.Context, userID, tokenID string, issuedAt time.Time) (bool, error)
 	GetUserByID(ctx context.Context, userID string) (*userpb.UserResponse, error)
 	GetUserByEmail(ctx context.Context, email string) (*userpb.UserResponse, error)
 	GetAllUsers(
@@ -307,6 +393,11 @@ type UserClientInterface interface {
 		page, page_size int32,
 		filter string,
 	) (userpb.UserService_GetAllUsersClient, error)
+	GetUsersAfter(
+		ctx context.Context,
+		afterID string,
+		pageSize int32,
+	) (userpb.UserService_GetAllUsersClient, error)
 	UpdateUser(ctx context.Context, user_id, name, email string) (*userpb.UserResponse, error)
 	DeleteUser(ctx context.Context, userID string) (*emptypb.Empty, error)
 	GetUserIntersectionIDs(
@@ -333,6 +424,7 @@ type UserClientInterface interface {
 		userID, current_password, new_password string,
 	) (*emptypb.Empty, error)
 	ResetPassword(ctx context.Context, email string) (*emptypb.Empty, error)
+	ConfirmPasswordReset(ctx context.Context, token, newPassword string) (*emptypb.Empty, error)
 	MakeAdmin(ctx context.Context, user_id, admin_user_id string) (*emptypb.Empty, error)
 	RemoveAdmin(ctx context.Context, user_id, admin_user_id string) (*emptypb.Empty, error)
 }
@@ -274,3 +274,148 @@ func (h *AdminHandler) DeleteUserByID(w http.ResponseWriter, r *http.Request) {
 	)
 	w.WriteHeader(http.StatusNoContent)
 }
+
+// @Summary Make User Admin
+// @Description Grants a user admin rights and notifies them. Only accessible by admins.
+// @Tags Admin
+// @Accept json
+// @Produce json
+// @Security BearerAuth
+// @Param id path string true "User ID"
+// @Success 204 "User made admin successfully"
+// @Failure 403 {object} model.ErrorResponse "Forbidden - Only admins can access this endpoint"
+// @Failure 404 {object} model.ErrorResponse "User not found"
+// @Failure 500 {object} model.ErrorResponse "Internal server error"
+// @Router /admin/users/{id}/make-admin [post]
+func (h *AdminHandler) MakeAdmin(w http.ResponseWriter, r *http.Request) {
+	logger := middleware.LoggerFromContext(r.Context()).With(
+		"handler", "admin",
+		"action", "make_admin",
+	)
+	logger.Info("processing make user admin request")
+
+	userID := r.PathValue("id")
+	if userID == "" {
+		util.SendErrorResponse(
+			w,
+			errs.NewValidationError("User ID is required", map[string]any{}),
+		)
+		return
+	}
+
+	if err := h.service.MakeAdmin(r.Context(), userID); err != nil {
+		logger.Error("failed to make user admin",
+			"error", err.Error(),
+			"user_id", userID,
+		)
+		util.SendErrorResponse(
+			w,
+			err,
+		)
+		return
+	}
+
+	logger.Info("successfully made user admin",
+		"user_id", userID,
+	)
+	w.WriteHeader(http.StatusNoContent)
+}
+
+// @Summary Remove User Admin
+// @Description Revokes a user's admin rights and notifies them. Only accessible by admins.
+// @Tags Admin
+// @Accept json
+// @Produce json
+// @Security BearerAuth
+// @Param id path string true "User ID"
+// @Success 204 "User's admin rights removed successfully"
+// @Failure 403 {object} model.ErrorResponse "Forbidden - Only admins can access this endpoint"
+// @Failure 404 {object} model.ErrorResponse "User not found"
+// @Failure 500 {object} model.ErrorResponse "Internal server error"
+// @Router /admin/users/{id}/remove-admin [post]
+func (h *AdminHandler) RemoveAdmin(w http.ResponseWriter, r *http.Request) {
+	logger := middleware.LoggerFromContext(r.Context()).With(
+		"handler", "admin",
+		"action", "remove_admin",
+	)
+	logger.Info("processing remove user admin request")
+
+	userID := r.PathValue("id")
+	if userID == "" {
+		util.SendErrorResponse(
+			w,
+			errs.NewValidationError("User ID is required", map[string]any{}),
+		)
+		return
+	}
+
+	if err := h.service.RemoveAdmin(r.Context(), userID); err != nil {
+		logger.Error("failed to remove user admin",
+			"error", err.Error(),
+			"user_id", userID,
+		)
+		util.SendErrorResponse(
+			w,
+			err,
+		)
+		return
+	}
+
+	logger.Info("successfully removed user admin",
+		"user_id", userID,
+	)
+	w.WriteHeader(http.StatusNoContent)
+}
+
+// @Summary Reconcile Intersection Ownership
+// @Description Reports the intersections that belong to no user and the intersection IDs users hold for intersections that no longer exist. With repair set, orphans are moved to the trash and dangling IDs are removed. Only accessible by admins.
+// @Tags Admin
+// @Accept json
+// @Produce json
+// @Security BearerAuth
+// @Param repair query bool false "Repair the inconsistencies found (default is false)"
+// @Success 200 {object} model.OwnershipReport "Ownership report"
+// @Failure 400 {object} model.ErrorResponse "Invalid repair flag"
+// @Failure 403 {object} model.ErrorResponse "Forbidden - Only admins can access this endpoint"
+// @Failure 500 {object} model.ErrorResponse "Internal server error"
+// @Router /admin/reconcile [post]
+func (h *AdminHandler) ReconcileOwnership(w http.ResponseWriter, r *http.Request) {
+	logger := middleware.LoggerFromContext(r.Context()).With(
+		"handler", "admin",
+		"action", "reconcile_ownership",
+	)
+	logger.Info("processing reconcile ownership request")
+
+	repair := false
+	if repairStr := r.URL.Query().Get("repair"); repairStr != "" {
+		parsed, err := strconv.ParseBool(repairStr)
+		if err != nil {
+			logger.Warn("invalid repair flag",
+				"repair", repairStr,
+				"error", err.Error(),
+			)
+			util.SendErrorResponse(
+				w,
+				errs.NewValidationError("Invalid repair flag", map[string]any{"repair": repairStr}),
+			)
+			return
+		}
+		repair = parsed
+	}
+
+	report, err := h.service.ReconcileOwnership(r.Context(), repair)
+	if err != nil {
+		logger.Error("failed to reconcile ownership",
+			"error", err.Error(),
+		)
+		util.SendErrorResponse(w, err)
+		return
+	}
+
+	logger.Info("successfully reconciled ownership",
+		"orphaned_intersections", len(report.OrphanedIntersections),
+		"users_with_dangling_ids", len(report.DanglingIntersectionIDs),
+		"repaired", report.Repaired,
+	)
+	util.SendJSONResponse(w, http.StatusOK, report)
+}
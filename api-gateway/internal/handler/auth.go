@@ -2,6 +2,8 @@ package handler
 
 import (
 	"encoding/json"
+	"errors"
+	"io"
 	"net/http"
 
 	"github.com/COS301-SE-2025/Swift-Signals/api-gateway/internal/middleware"
@@ -135,12 +137,68 @@ func (h *AuthHandler) Login(w http.ResponseWriter, r *http.Request) {
 	util.SendJSONResponse(w, http.StatusOK, resp)
 }
 
+// @Summary Refresh Token
+// @Description Exchanges a refresh token for a new access token and refresh token. Each refresh token can only be used once: using one again revokes every refresh token of its session.
+// @Tags Authentication
+// @Accept json
+// @Produce json
+// @Param refreshRequest body model.RefreshRequest true "Refresh token"
+// @Success 200 {object} model.LoginResponse "Successful refresh"
+// @Failure 400 {object} model.ErrorResponse "Invalid request payload or missing refresh token"
+// @Failure 401 {object} model.ErrorResponse "Unauthorized: Refresh token invalid, expired or already used"
+// @Failure 500 {object} model.ErrorResponse "Internal server error"
+// @Router /refresh [post]
+func (h *AuthHandler) Refresh(w http.ResponseWriter, r *http.Request) {
+	logger := middleware.LoggerFromContext(r.Context()).With(
+		"handler", "auth",
+		"action", "refresh",
+	)
+	logger.Info("processing refresh request")
+
+	var req model.RefreshRequest
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+		logger.Warn("failed to decode request body",
+			"error", err.Error(),
+		)
+		util.SendErrorResponse(
+			w,
+			errs.NewValidationError("Invalid request payload", map[string]any{}),
+		)
+		return
+	}
+
+	logger.Debug("validating request")
+	if err := h.validator.Struct(req); err != nil {
+		logger.Warn("validation failed",
+			"error", err.Error(),
+		)
+		util.SendErrorResponse(
+			w,
+			errs.NewValidationError("Refresh token is required", map[string]any{}),
+		)
+		return
+	}
+
+	resp, err := h.service.RefreshToken(r.Context(), req)
+	if err != nil {
+		logger.Error("refresh failed",
+			"error", err.Error(),
+		)
+		util.SendErrorResponse(w, err)
+		return
+	}
+
+	logger.Info("refresh successful")
+	util.SendJSONResponse(w, http.StatusOK, resp)
+}
+
 // @Summary User Logout
-// @Description Invalidates the user's session or token on the server-side.
+// @Description Revokes the bearer token, so it is rejected from then on rather than only once it expires, along with the session's refresh tokens when one is given.
 // @Tags Authentication
 // @Accept json
 // @Produce json
 // @Security BearerAuth
+// @Param logoutRequest body model.LogoutRequest false "Refresh token of the session"
 // @Success 200 {object} model.LogoutResponse "Successful logout"
 // @Failure 401 {object} model.ErrorResponse "Unauthorized: Token missing or invalid"
 // @Failure 500 {object} model.ErrorResponse "Internal server error"
@@ -161,7 +219,19 @@ func (h *AuthHandler) Logout(w http.ResponseWriter, r *http.Request) {
 		return
 	}
 
-	resp, err := h.service.LogoutUser(r.Context(), token)
+	var req model.LogoutRequest
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil && !errors.Is(err, io.EOF) {
+		logger.Warn("failed to decode request body",
+			"error", err.Error(),
+		)
+		util.SendErrorResponse(
+			w,
+			errs.NewValidationError("Invalid request payload", map[string]any{}),
+		)
+		return
+	}
+
+	resp, err := h.service.LogoutUser(r.Context(), token, req)
 	if err != nil {
 		util.SendErrorResponse(w, err)
 		return
@@ -171,24 +241,114 @@ func (h *AuthHandler) Logout(w http.ResponseWriter, r *http.Request) {
 }
 
 // @Summary Reset Password
-// @Description Reset's a user's password in case they forgot it.
+// @Description Emails the user a link to choose a new password, in case they forgot it. The response is the same whether or not an account uses the email, and each email can only be sent a few links an hour.
 // @Tags Authentication
 // @Accept json
 // @Produce json
 // @Param resetPasswordRequest body model.ResetPasswordRequest true "User Email"
-// @Success 200 {object} model.ResetPasswordResponse "Successful password reset"
+// @Success 200 {object} model.ResetPasswordResponse "Reset link sent if the account exists"
 // @Failure 400 {object} model.ErrorResponse "Invalid request payload or email"
+// @Failure 429 {object} model.ErrorResponse "Too many reset requests for the email"
 // @Failure 500 {object} model.ErrorResponse "Internal server error"
 // @Router /reset-password [post]
 func (h *AuthHandler) ResetPassword(w http.ResponseWriter, r *http.Request) {
+	logger := middleware.LoggerFromContext(r.Context()).With(
+		"handler", "auth",
+		"action", "resetPassword",
+	)
+	logger.Info("processing reset password request")
+
 	var req model.ResetPasswordRequest
 	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+		logger.Warn("failed to decode request body",
+			"error", err.Error(),
+		)
+		util.SendErrorResponse(
+			w,
+			errs.NewValidationError("Invalid request payload", map[string]any{}),
+		)
+		return
+	}
+
+	logger.Debug("validating request")
+	if err := h.validator.Struct(req); err != nil {
+		logger.Warn("validation failed",
+			"error", err.Error(),
+		)
+		util.SendErrorResponse(
+			w,
+			errs.NewValidationError("A valid email is required", map[string]any{}),
+		)
+		return
+	}
+
+	resp, err := h.service.ResetPassword(r.Context(), req)
+	if err != nil {
+		logger.Error("reset password failed",
+			"error", err.Error(),
+		)
 		util.SendErrorResponse(w, err)
 		return
 	}
 
-	resp := &model.ResetPasswordResponse{
-		Message: "Reset Example Successful",
+	logger.Info("reset password successful")
+	util.SendJSONResponse(w, http.StatusOK, resp)
+}
+
+// @Summary Confirm Password Reset
+// @Description Sets a new password using the token from a password reset email. Each token works once and expires after an hour, and using it logs the user out of every session.
+// @Tags Authentication
+// @Accept json
+// @Produce json
+// @Param confirmResetPasswordRequest body model.ConfirmResetPasswordRequest true "Reset token and new password"
+// @Success 200 {object} model.ConfirmResetPasswordResponse "Successful password reset"
+// @Failure 400 {object} model.ErrorResponse "Invalid request payload or password"
+// @Failure 401 {object} model.ErrorResponse "Unauthorized: Reset token invalid, used or expired"
+// @Failure 500 {object} model.ErrorResponse "Internal server error"
+// @Router /reset-password/confirm [post]
+func (h *AuthHandler) ConfirmResetPassword(w http.ResponseWriter, r *http.Request) {
+	logger := middleware.LoggerFromContext(r.Context()).With(
+		"handler", "auth",
+		"action", "confirmResetPassword",
+	)
+	logger.Info("processing confirm reset password request")
+
+	var req model.ConfirmResetPasswordRequest
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+		logger.Warn("failed to decode request body",
+			"error", err.Error(),
+		)
+		util.SendErrorResponse(
+			w,
+			errs.NewValidationError("Invalid request payload", map[string]any{}),
+		)
+		return
+	}
+
+	logger.Debug("validating request")
+	if err := h.validator.Struct(req); err != nil {
+		logger.Warn("validation failed",
+			"error", err.Error(),
+		)
+		util.SendErrorResponse(
+			w,
+			errs.NewValidationError(
+				"Token and a new password of 8 to 64 characters are required",
+				map[string]any{},
+			),
+		)
+		return
+	}
+
+	resp, err := h.service.ConfirmPasswordReset(r.Context(), req)
+	if err != nil {
+		logger.Error("confirm reset password failed",
+			"error", err.Error(),
+		)
+		util.SendErrorResponse(w, err)
+		return
 	}
+
+	logger.Info("confirm reset password successful")
 	util.SendJSONResponse(w, http.StatusOK, resp)
 }
@@ -3,6 +3,7 @@ package handler
 import (
 	"encoding/json"
 	"net/http"
+	"strconv"
 
 	"github.com/COS301-SE-2025/Swift-Signals/api-gateway/internal/middleware"
 	"github.com/COS301-SE-2025/Swift-Signals/api-gateway/internal/model"
@@ -76,6 +77,7 @@ func (h *IntersectionHandler) GetAllIntersections(w http.ResponseWriter, r *http
 // @Security BearerAuth
 // @Param id path string true "Intersection ID"
 // @Success 200 {object} model.Intersection "Successful intersection retrieval"
+// @Header 200 {string} ETag "Version of the intersection, for use in If-Match"
 // @Failure 400 {object} model.ErrorResponse "Bad Request: Invalid or missing ID parameter"
 // @Failure 401 {object} model.ErrorResponse "Unauthorized: Token missing or invalid"
 // @Failure 404 {object} model.ErrorResponse "Not Found: Intersection does not exist"
@@ -116,6 +118,7 @@ func (h *IntersectionHandler) GetIntersection(w http.ResponseWriter, r *http.Req
 	logger.Info("request successful",
 		"intersection_id", resp.ID,
 	)
+	util.SetETag(w, resp.Version)
 	util.SendJSONResponse(w, http.StatusOK, resp)
 }
 
@@ -192,17 +195,21 @@ func (h *IntersectionHandler) CreateIntersection(w http.ResponseWriter, r *http.
 }
 
 // @Summary Update Intersection
-// @Description Partially updates fields of an existing intersection by ID.
+// @Description Partially updates fields of an existing intersection by ID. Omitted fields keep their current values. Changing the traffic density or default parameters flags the best parameters as stale and is refused while the intersection is being optimised. With If-Match, the update is only made if the intersection is still at that version. Without it, an update that omits fields is only made if the intersection has not changed since their current values were read.
 // @Tags Intersections
 // @Accept json
 // @Produce json
 // @Security BearerAuth
 // @Param id path string true "Intersection ID"
+// @Param If-Match header string false "ETag of the version the update is based on"
 // @Param body body model.UpdateIntersectionRequest true "Fields to update"
 // @Success 200 {object} model.Intersection "Successful update"
+// @Header 200 {string} ETag "Version of the updated intersection"
 // @Failure 400 {object} model.ErrorResponse "Bad Request: Invalid input"
 // @Failure 401 {object} model.ErrorResponse "Unauthorized: Token missing or invalid"
 // @Failure 404 {object} model.ErrorResponse "Not Found: Intersection does not exist"
+// @Failure 409 {object} model.ErrorResponse "Conflict: Defaults cannot change while the intersection is being optimised"
+// @Failure 412 {object} model.ErrorResponse "Precondition Failed: Intersection modified since the If-Match version"
 // @Failure 500 {object} model.ErrorResponse "Internal server error"
 // @Router /intersections/{id} [patch]
 func (h *IntersectionHandler) UpdateIntersection(w http.ResponseWriter, r *http.Request) {
@@ -252,7 +259,22 @@ func (h *IntersectionHandler) UpdateIntersection(w http.ResponseWriter, r *http.
 
 	intersectionID := r.PathValue("id")
 
-	resp, err := h.service.UpdateIntersectionByID(r.Context(), userID, intersectionID, req)
+	expectedVersion, err := util.GetIfMatch(r)
+	if err != nil {
+		logger.Warn("If-Match does not name a version",
+			"if_match", r.Header.Get("If-Match"),
+		)
+		util.SendErrorResponse(w, err)
+		return
+	}
+
+	resp, err := h.service.UpdateIntersectionByID(
+		r.Context(),
+		userID,
+		intersectionID,
+		req,
+		expectedVersion,
+	)
 	if err != nil {
 		logger.Error("request failed",
 			"error", err.Error(),
@@ -264,20 +286,23 @@ func (h *IntersectionHandler) UpdateIntersection(w http.ResponseWriter, r *http.
 	logger.Info("request successful",
 		"intersection_id", resp.ID,
 	)
+	util.SetETag(w, resp.Version)
 	util.SendJSONResponse(w, http.StatusOK, resp)
 }
 
 // @Summary Delete Intersection
-// @Description Deletes the intersection with the given ID.
+// @Description Moves the intersection with the given ID to the trash, from which it can be restored until it is purged. With If-Match, it is only moved if the intersection is still at that version.
 // @Tags Intersections
 // @Accept json
 // @Produce json
 // @Security BearerAuth
 // @Param id path string true "Intersection ID"
+// @Param If-Match header string false "ETag of the version the deletion is based on"
 // @Success 204 "No Content"
 // @Failure 400 {object} model.ErrorResponse "Bad Request: Invalid input"
 // @Failure 401 {object} model.ErrorResponse "Unauthorized: Token missing or invalid"
 // @Failure 404 {object} model.ErrorResponse "Not Found: Intersection does not exist"
+// @Failure 412 {object} model.ErrorResponse "Precondition Failed: Intersection modified since the If-Match version"
 // @Failure 500 {object} model.ErrorResponse "Internal server error"
 // @Router /intersections/{id} [delete]
 func (h *IntersectionHandler) DeleteIntersection(w http.ResponseWriter, r *http.Request) {
@@ -303,7 +328,16 @@ func (h *IntersectionHandler) DeleteIntersection(w http.ResponseWriter, r *http.
 
 	intersectionID := r.PathValue("id")
 
-	err := h.service.DeleteIntersectionByID(r.Context(), userID, intersectionID)
+	expectedVersion, err := util.GetIfMatch(r)
+	if err != nil {
+		logger.Warn("If-Match does not name a version",
+			"if_match", r.Header.Get("If-Match"),
+		)
+		util.SendErrorResponse(w, err)
+		return
+	}
+
+	err = h.service.DeleteIntersectionByID(r.Context(), userID, intersectionID, expectedVersion)
 	if err != nil {
 		logger.Error("request failed",
 			"error", err.Error(),
@@ -317,3 +351,271 @@ func (h *IntersectionHandler) DeleteIntersection(w http.ResponseWriter, r *http.
 	)
 	w.WriteHeader(http.StatusNoContent)
 }
+
+// @Summary Get Deleted Intersections
+// @Description Retrieves the intersections associated with the user that are in the trash.
+// @Tags Intersections
+// @Accept json
+// @Produce json
+// @Security BearerAuth
+// @Success 200 {object} model.Intersections "Successful deleted intersections retrieval"
+// @Failure 401 {object} model.ErrorResponse "Unauthorized: Token missing or invalid"
+// @Failure 500 {object} model.ErrorResponse "Internal server error"
+// @Router /intersections/trash [get]
+func (h *IntersectionHandler) GetDeletedIntersections(w http.ResponseWriter, r *http.Request) {
+	logger := middleware.LoggerFromContext(r.Context()).With(
+		"handler", "intersection",
+		"action", "getDeletedIntersections",
+	)
+	logger.Info("processing getDeletedIntersections request")
+
+	userID, ok := middleware.GetUserID(r.Context())
+	if !ok {
+		logger.Error("user ID missing inside of handler")
+		util.SendErrorResponse(
+			w,
+			errs.NewInternalError(
+				"user ID missing inside of handler",
+				nil,
+				map[string]any{},
+			),
+		)
+		return
+	}
+
+	resp, err := h.service.GetDeletedIntersections(r.Context(), userID)
+	if err != nil {
+		logger.Error("request failed",
+			"error", err.Error(),
+		)
+		util.SendErrorResponse(w, err)
+		return
+	}
+
+	logger.Info("request successful")
+	util.SendJSONResponse(w, http.StatusOK, resp)
+}
+
+// @Summary Restore Intersection
+// @Description Takes the intersection with the given ID back out of the trash.
+// @Tags Intersections
+// @Accept json
+// @Produce json
+// @Security BearerAuth
+// @Param id path string true "Intersection ID"
+// @Success 200 {object} model.Intersection "Successful intersection restore"
+// @Header 200 {string} ETag "Version of the intersection, for use in If-Match"
+// @Failure 401 {object} model.ErrorResponse "Unauthorized: Token missing or invalid"
+// @Failure 403 {object} model.ErrorResponse "Forbidden: Intersection does not belong to the user"
+// @Failure 404 {object} model.ErrorResponse "Not Found: Intersection is not in the trash"
+// @Failure 500 {object} model.ErrorResponse "Internal server error"
+// @Router /intersections/{id}/restore [post]
+func (h *IntersectionHandler) RestoreIntersection(w http.ResponseWriter, r *http.Request) {
+	logger := middleware.LoggerFromContext(r.Context()).With(
+		"handler", "intersection",
+		"action", "restoreIntersection",
+	)
+	logger.Info("processing restoreIntersection request")
+
+	userID, ok := middleware.GetUserID(r.Context())
+	if !ok {
+		logger.Error("user ID missing inside of handler")
+		util.SendErrorResponse(
+			w,
+			errs.NewInternalError(
+				"user ID missing inside of handler",
+				nil,
+				map[string]any{},
+			),
+		)
+		return
+	}
+
+	intersectionID := r.PathValue("id")
+
+	resp, err := h.service.RestoreIntersectionByID(r.Context(), userID, intersectionID)
+	if err != nil {
+		logger.Error("request failed",
+			"error", err.Error(),
+		)
+		util.SendErrorResponse(w, err)
+		return
+	}
+
+	logger.Info("request successful",
+		"intersection_id", resp.ID,
+	)
+	util.SetETag(w, resp.Version)
+	util.SendJSONResponse(w, http.StatusOK, resp)
+}
+
+// @Summary Get Parameter Versions
+// @Description Returns a page of the parameter history of a specific intersection, newest first.
+// @Tags Intersections
+// @Produce json
+// @Security BearerAuth
+// @Param id path string true "Intersection ID"
+// @Param page query int false "Page number (default is 1)"
+// @Param page_size query int false "Number of versions per page (default is 20 and max is 100)"
+// @Success 200 {object} model.ParameterVersions "Successful parameter versions retrieval"
+// @Failure 400 {object} model.ErrorResponse "Bad Request: Invalid page or page size"
+// @Failure 401 {object} model.ErrorResponse "Unauthorized: Token missing or invalid"
+// @Failure 403 {object} model.ErrorResponse "Forbidden: Intersection not owned by user"
+// @Failure 404 {object} model.ErrorResponse "Not Found: Intersection not found"
+// @Failure 500 {object} model.ErrorResponse "Internal server error"
+// @Router /intersections/{id}/parameter-versions [get]
+func (h *IntersectionHandler) GetParameterVersions(w http.ResponseWriter, r *http.Request) {
+	logger := middleware.LoggerFromContext(r.Context()).With(
+		"handler", "intersection",
+		"action", "getParameterVersions",
+	)
+	logger.Info("processing getParameterVersions request")
+
+	userID, ok := middleware.GetUserID(r.Context())
+	if !ok {
+		logger.Error("user ID missing inside of handler")
+		util.SendErrorResponse(
+			w,
+			errs.NewInternalError(
+				"user ID missing inside of handler",
+				nil,
+				map[string]any{},
+			),
+		)
+		return
+	}
+
+	intersectionID := r.PathValue("id")
+
+	pageStr := r.URL.Query().Get("page")
+	page := 1
+	if pageStr != "" {
+		if p, err := strconv.Atoi(pageStr); err == nil && p > 0 {
+			page = p
+		} else {
+			logger.Warn("invalid page number", "page", pageStr)
+			util.SendErrorResponse(
+				w,
+				errs.NewValidationError("Invalid page number", map[string]any{"page": pageStr}),
+			)
+			return
+		}
+	}
+
+	pageSizeStr := r.URL.Query().Get("page_size")
+	pageSize := 20
+	if pageSizeStr != "" {
+		if ps, err := strconv.Atoi(pageSizeStr); err == nil && ps > 0 && ps <= 100 {
+			pageSize = ps
+		} else {
+			logger.Warn("invalid page size", "page_size", pageSizeStr)
+			util.SendErrorResponse(
+				w,
+				errs.NewValidationError("Invalid page size", map[string]any{"page_size": pageSizeStr}),
+			)
+			return
+		}
+	}
+
+	resp, err := h.service.GetParameterVersions(
+		r.Context(),
+		userID,
+		intersectionID,
+		page,
+		pageSize,
+	)
+	if err != nil {
+		logger.Error("request failed",
+			"error", err.Error(),
+		)
+		util.SendErrorResponse(w, err)
+		return
+	}
+
+	logger.Info("request successful")
+	util.SendJSONResponse(w, http.StatusOK, resp)
+}
+
+// @Summary Revert Parameter Version
+// @Description Restores the parameters set by a version in the parameter history of a specific intersection, recording the revert as a new version. With If-Match, the revert is only made if the intersection is still at that version.
+// @Tags Intersections
+// @Produce json
+// @Security BearerAuth
+// @Param id path string true "Intersection ID"
+// @Param v path int true "Parameter version"
+// @Param If-Match header string false "ETag of the version the revert is based on"
+// @Success 200 {object} model.Intersection "Successful parameter revert"
+// @Header 200 {string} ETag "Version of the reverted intersection"
+// @Failure 400 {object} model.ErrorResponse "Bad Request: Invalid version"
+// @Failure 401 {object} model.ErrorResponse "Unauthorized: Token missing or invalid"
+// @Failure 403 {object} model.ErrorResponse "Forbidden: Intersection not owned by user"
+// @Failure 404 {object} model.ErrorResponse "Not Found: Intersection or version not found"
+// @Failure 409 {object} model.ErrorResponse "Conflict: Intersection is being optimised"
+// @Failure 412 {object} model.ErrorResponse "Precondition Failed: Intersection modified since the If-Match version"
+// @Failure 500 {object} model.ErrorResponse "Internal server error"
+// @Router /intersections/{id}/parameter-versions/{v}/revert [post]
+func (h *IntersectionHandler) RevertParameterVersion(w http.ResponseWriter, r *http.Request) {
+	logger := middleware.LoggerFromContext(r.Context()).With(
+		"handler", "intersection",
+		"action", "revertParameterVersion",
+	)
+	logger.Info("processing revertParameterVersion request")
+
+	userID, ok := middleware.GetUserID(r.Context())
+	if !ok {
+		logger.Error("user ID missing inside of handler")
+		util.SendErrorResponse(
+			w,
+			errs.NewInternalError(
+				"user ID missing inside of handler",
+				nil,
+				map[string]any{},
+			),
+		)
+		return
+	}
+
+	intersectionID := r.PathValue("id")
+
+	versionStr := r.PathValue("v")
+	version, err := strconv.Atoi(versionStr)
+	if err != nil || version <= 0 {
+		logger.Warn("invalid parameter version", "version", versionStr)
+		util.SendErrorResponse(
+			w,
+			errs.NewValidationError("Invalid parameter version", map[string]any{"version": versionStr}),
+		)
+		return
+	}
+
+	expectedVersion, err := util.GetIfMatch(r)
+	if err != nil {
+		logger.Warn("If-Match does not name a version",
+			"if_match", r.Header.Get("If-Match"),
+		)
+		util.SendErrorResponse(w, err)
+		return
+	}
+
+	resp, err := h.service.RevertParameterVersion(
+		r.Context(),
+		userID,
+		intersectionID,
+		version,
+		expectedVersion,
+	)
+	if err != nil {
+		logger.Error("request failed",
+			"error", err.Error(),
+		)
+		util.SendErrorResponse(w, err)
+		return
+	}
+
+	logger.Info("request successful",
+		"intersection_id", intersectionID,
+		"version", version,
+	)
+	util.SetETag(w, resp.Version)
+	util.SendJSONResponse(w, http.StatusOK, resp)
+}
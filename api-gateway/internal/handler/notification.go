@@ -0,0 +1,175 @@
+package handler
+
+import (
+	"net/http"
+	"strconv"
+
+	"github.com/COS301-SE-2025/Swift-Signals/api-gateway/internal/middleware"
+	"github.com/COS301-SE-2025/Swift-Signals/api-gateway/internal/service"
+	"github.com/COS301-SE-2025/Swift-Signals/api-gateway/internal/util"
+	errs "github.com/COS301-SE-2025/Swift-Signals/shared/error"
+)
+
+type NotificationHandler struct {
+	service service.NotificationServiceInterface
+}
+
+func NewNotificationHandler(s service.NotificationServiceInterface) *NotificationHandler {
+	return &NotificationHandler{
+		service: s,
+	}
+}
+
+// @Summary Get Notifications
+// @Description Returns the user's most recent notifications, newest first, such as those about their optimisations finishing, along with how many of their notifications are unread.
+// @Tags Notifications
+// @Produce json
+// @Security BearerAuth
+// @Param limit query int false "Number of notifications (default is 50 and max is 100)"
+// @Success 200 {object} model.Notifications "Successful notifications retrieval"
+// @Failure 400 {object} model.ErrorResponse "Bad Request: Invalid limit"
+// @Failure 401 {object} model.ErrorResponse "Unauthorized: Token missing or invalid"
+// @Failure 500 {object} model.ErrorResponse "Internal server error"
+// @Router /notifications [get]
+func (h *NotificationHandler) GetNotifications(w http.ResponseWriter, r *http.Request) {
+	logger := middleware.LoggerFromContext(r.Context()).With(
+		"handler", "notification",
+		"action", "getNotifications",
+	)
+	logger.Info("processing getNotifications request")
+
+	limitStr := r.URL.Query().Get("limit")
+	limit := 50
+	if limitStr != "" {
+		if l, err := strconv.Atoi(limitStr); err == nil && l > 0 && l <= 100 {
+			limit = l
+		} else {
+			logger.Warn("invalid limit", "limit", limitStr)
+			util.SendErrorResponse(
+				w,
+				errs.NewValidationError("Invalid limit", map[string]any{"limit": limitStr}),
+			)
+			return
+		}
+	}
+
+	userID, ok := middleware.GetUserID(r.Context())
+	if !ok {
+		logger.Error("user ID missing inside of handler")
+		util.SendErrorResponse(
+			w,
+			errs.NewInternalError(
+				"user ID missing inside of handler",
+				nil,
+				map[string]any{},
+			),
+		)
+		return
+	}
+
+	resp, err := h.service.GetNotifications(r.Context(), userID, limit)
+	if err != nil {
+		logger.Error("request failed",
+			"error", err.Error(),
+		)
+		util.SendErrorResponse(w, err)
+		return
+	}
+
+	logger.Info("request successful")
+	util.SendJSONResponse(w, http.StatusOK, resp)
+}
+
+// @Summary Mark Notification Read
+// @Description Marks one of the user's notifications as read.
+// @Tags Notifications
+// @Produce json
+// @Security BearerAuth
+// @Param id path string true "Notification ID"
+// @Success 204 "No Content"
+// @Failure 401 {object} model.ErrorResponse "Unauthorized: Token missing or invalid"
+// @Failure 404 {object} model.ErrorResponse "Not Found: Notification does not exist"
+// @Failure 500 {object} model.ErrorResponse "Internal server error"
+// @Router /notifications/{id}/read [post]
+func (h *NotificationHandler) MarkRead(w http.ResponseWriter, r *http.Request) {
+	logger := middleware.LoggerFromContext(r.Context()).With(
+		"handler", "notification",
+		"action", "markRead",
+	)
+	logger.Info("processing markRead request")
+
+	userID, ok := middleware.GetUserID(r.Context())
+	if !ok {
+		logger.Error("user ID missing inside of handler")
+		util.SendErrorResponse(
+			w,
+			errs.NewInternalError(
+				"user ID missing inside of handler",
+				nil,
+				map[string]any{},
+			),
+		)
+		return
+	}
+
+	notificationID := r.PathValue("id")
+
+	err := h.service.MarkRead(r.Context(), userID, notificationID)
+	if err != nil {
+		logger.Error("request failed",
+			"error", err.Error(),
+		)
+		util.SendErrorResponse(w, err)
+		return
+	}
+
+	logger.Info("request successful",
+		"notification_id", notificationID,
+	)
+	w.WriteHeader(http.StatusNoContent)
+}
+
+// @Summary Mark All Notifications Read
+// @Description Marks all of the user's unread notifications as read.
+// @Tags Notifications
+// @Produce json
+// @Security BearerAuth
+// @Success 200 {object} model.MarkAllNotificationsReadResponse "Number of notifications marked read"
+// @Failure 401 {object} model.ErrorResponse "Unauthorized: Token missing or invalid"
+// @Failure 500 {object} model.ErrorResponse "Internal server error"
+// @Router /notifications/read-all [post]
+func (h *NotificationHandler) MarkAllRead(w http.ResponseWriter, r *http.Request) {
+	logger := middleware.LoggerFromContext(r.Context()).With(
+		"handler", "notification",
+		"action", "markAllRead",
+	)
+	logger.Info("processing markAllRead request")
+
+	userID, ok := middleware.GetUserID(r.Context())
+	if !ok {
+		logger.Error("user ID missing inside of handler")
+		util.SendErrorResponse(
+			w,
+			errs.NewInternalError(
+				"user ID missing inside of handler",
+				nil,
+				map[string]any{},
+			),
+		)
+		return
+	}
+
+	resp, err := h.service.MarkAllRead(r.Context(), userID)
+	if err != nil {
+		logger.Error("request failed",
+			"error", err.Error(),
+		)
+		util.SendErrorResponse(w, err)
+		return
+	}
+
+	logger.Info("request successful",
+		"marked", resp.Marked,
+	)
+	util.SendJSONResponse(w, http.StatusOK, resp)
+}
@@ -1,20 +1,42 @@
 package handler
 
 import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"log/slog"
 	"net/http"
+	"net/url"
+	"strconv"
+	"strings"
+	"time"
 
 	"github.com/COS301-SE-2025/Swift-Signals/api-gateway/internal/middleware"
+	"github.com/COS301-SE-2025/Swift-Signals/api-gateway/internal/model"
 	"github.com/COS301-SE-2025/Swift-Signals/api-gateway/internal/service"
 	"github.com/COS301-SE-2025/Swift-Signals/api-gateway/internal/util"
+	errs "github.com/COS301-SE-2025/Swift-Signals/shared/error"
+	"github.com/go-playground/validator/v10"
 )
 
+// NOTE: Comments are sent on idle event streams so that proxies keep them open
+const eventStreamHeartbeat = 15 * time.Second
+
+const defaultReplications = 10
+
+const defaultSensitivitySteps = 10
+
+const defaultSensitivityReplications = 3
+
 type SimulationHandler struct {
-	service service.SimulationServiceInterface
+	service   service.SimulationServiceInterface
+	validator *validator.Validate
 }
 
 func NewSimulationHandler(s service.SimulationServiceInterface) *SimulationHandler {
 	return &SimulationHandler{
-		service: s,
+		service:   s,
+		validator: validator.New(),
 	}
 }
 
@@ -25,6 +47,11 @@ func NewSimulationHandler(s service.SimulationServiceInterface) *SimulationHandl
 // @Produce json
 // @Security BearerAuth
 // @Param id path string true "Intersection ID"
+// @Param from query int false "Only keep positions from this many seconds into the simulation"
+// @Param to query int false "Only keep positions up to this many seconds into the simulation"
+// @Param vehicles query []string false "Only keep these vehicle IDs (comma separated or repeated)" collectionFormat(csv)
+// @Param sample_every query int false "Keep at most one position per vehicle every this many seconds"
+// @Param fields query []string false "Position fields to return out of time, x, y and speed (default is all)" collectionFormat(csv)
 // @Success 200 {object} model.SimulationResponse "Successful simulation data retrieval"
 // @Failure 400 {object} model.ErrorResponse "Bad Request: Invalid input parameters"
 // @Failure 401 {object} model.ErrorResponse "Unauthorized: Token missing or invalid"
@@ -40,7 +67,14 @@ func (h *SimulationHandler) GetSimulation(w http.ResponseWriter, r *http.Request
 
 	intersectionID := r.PathValue("id")
 
-	resp, err := h.service.GetSimulationData(r.Context(), intersectionID)
+	filter, err := parseOutputFilter(r.URL.Query())
+	if err != nil {
+		logger.Warn("invalid output filter", "error", err.Error())
+		util.SendErrorResponse(w, err)
+		return
+	}
+
+	resp, err := h.service.GetSimulationData(r.Context(), intersectionID, filter)
 	if err != nil {
 		logger.Error("request failed",
 			"error", err.Error(),
@@ -56,6 +90,314 @@ func (h *SimulationHandler) GetSimulation(w http.ResponseWriter, r *http.Request
 	util.SendJSONResponse(w, http.StatusOK, resp)
 }
 
+// @Summary Run What-If Simulation
+// @Description Simulates a specific intersection with the given parameters instead of its stored ones. Without a traffic density, the intersection's own density is simulated. Nothing is saved, so timings can be tried out freely.
+// @Tags Simulation
+// @Accept json
+// @Produce json
+// @Security BearerAuth
+// @Param id path string true "Intersection ID"
+// @Param request body model.SimulationParameters true "Simulation parameters"
+// @Param from query int false "Only keep positions from this many seconds into the simulation"
+// @Param to query int false "Only keep positions up to this many seconds into the simulation"
+// @Param vehicles query []string false "Only keep these vehicle IDs (comma separated or repeated)" collectionFormat(csv)
+// @Param sample_every query int false "Keep at most one position per vehicle every this many seconds"
+// @Param fields query []string false "Position fields to return out of time, x, y and speed (default is all)" collectionFormat(csv)
+// @Success 200 {object} model.SimulationResponse "Successful what-if simulation"
+// @Failure 400 {object} model.ErrorResponse "Bad Request: Invalid simulation parameters"
+// @Failure 401 {object} model.ErrorResponse "Unauthorized: Token missing or invalid"
+// @Failure 403 {object} model.ErrorResponse "Forbidden: Intersection not owned by user"
+// @Failure 500 {object} model.ErrorResponse "Internal server error"
+// @Router /intersections/{id}/simulate [post]
+func (h *SimulationHandler) RunWhatIfSimulation(w http.ResponseWriter, r *http.Request) {
+	logger := middleware.LoggerFromContext(r.Context()).With(
+		"handler", "simulation",
+		"action", "runWhatIfSimulation",
+	)
+	logger.Info("processing runWhatIfSimulation request")
+
+	intersectionID := r.PathValue("id")
+
+	var req model.SimulationParameters
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+		logger.Warn("failed to decode request body",
+			"error", err.Error(),
+		)
+		util.SendErrorResponse(
+			w,
+			errs.NewValidationError("Invalid request payload", map[string]any{}),
+		)
+		return
+	}
+
+	logger.Debug("validating request")
+	if err := h.validator.Struct(req); err != nil {
+		logger.Warn("validation failed",
+			"error", err.Error(),
+		)
+		util.SendErrorResponse(
+			w,
+			errs.NewValidationError(
+				"intersection_type, seed and positive green, yellow, red and speed are required",
+				map[string]any{},
+			),
+		)
+		return
+	}
+
+	filter, err := parseOutputFilter(r.URL.Query())
+	if err != nil {
+		logger.Warn("invalid output filter", "error", err.Error())
+		util.SendErrorResponse(w, err)
+		return
+	}
+
+	resp, err := h.service.RunWhatIfSimulation(r.Context(), intersectionID, req, filter)
+	if err != nil {
+		logger.Error("request failed",
+			"error", err.Error(),
+		)
+		util.SendErrorResponse(w, err)
+		return
+	}
+
+	logger.Info("request successful")
+	util.SendJSONResponse(w, http.StatusOK, resp)
+}
+
+// @Summary Compare Simulations
+// @Description Compares two simulations of a specific intersection metric by metric, with the absolute and percentage change from the baseline to the candidate and whether it is an improvement. By default the intersection's default parameters are compared with its optimised ones. Given both baseline_run and candidate_run, two stored runs are compared instead without simulating again.
+// @Tags Simulation
+// @Produce json
+// @Security BearerAuth
+// @Param id path string true "Intersection ID"
+// @Param baseline_run query string false "ID of the run to compare against"
+// @Param candidate_run query string false "ID of the run to compare"
+// @Param replications query int false "Number of seeds to simulate both parameter sets over (default is 1, not used with runs)"
+// @Success 200 {object} model.Comparison "Successful comparison"
+// @Failure 400 {object} model.ErrorResponse "Bad Request: Only one run given, run has no metrics or invalid replications"
+// @Failure 401 {object} model.ErrorResponse "Unauthorized: Token missing or invalid"
+// @Failure 403 {object} model.ErrorResponse "Forbidden: Intersection not owned by user"
+// @Failure 404 {object} model.ErrorResponse "Not Found: Optimised parameters or run not found"
+// @Failure 500 {object} model.ErrorResponse "Internal server error"
+// @Router /intersections/{id}/compare [get]
+func (h *SimulationHandler) GetComparison(w http.ResponseWriter, r *http.Request) {
+	logger := middleware.LoggerFromContext(r.Context()).With(
+		"handler", "simulation",
+		"action", "getComparison",
+	)
+	logger.Info("processing getComparison request")
+
+	intersectionID := r.PathValue("id")
+	baselineRunID := r.URL.Query().Get("baseline_run")
+	candidateRunID := r.URL.Query().Get("candidate_run")
+
+	replications, err := parseReplications(r.URL.Query(), 1)
+	if err != nil {
+		logger.Warn("invalid replications", "error", err.Error())
+		util.SendErrorResponse(w, err)
+		return
+	}
+
+	var resp model.Comparison
+	switch {
+	case baselineRunID == "" && candidateRunID == "":
+		clearWriteDeadline(w, logger)
+		resp, err = h.service.CompareOptimisation(r.Context(), intersectionID, replications)
+	case replications > 1:
+		logger.Warn("replications given for stored runs")
+		err = errs.NewValidationError(
+			"stored runs cannot be replicated",
+			map[string]any{"replications": replications},
+		)
+	case baselineRunID != "" && candidateRunID != "":
+		resp, err = h.service.CompareRuns(
+			r.Context(),
+			intersectionID,
+			baselineRunID,
+			candidateRunID,
+		)
+	default:
+		logger.Warn("only one run to compare given")
+		err = errs.NewValidationError(
+			"baseline_run and candidate_run must be given together",
+			map[string]any{"baseline_run": baselineRunID, "candidate_run": candidateRunID},
+		)
+	}
+	if err != nil {
+		logger.Error("request failed",
+			"error", err.Error(),
+		)
+		util.SendErrorResponse(w, err)
+		return
+	}
+
+	logger.Info("request successful")
+	util.SendJSONResponse(w, http.StatusOK, resp)
+}
+
+// @Summary Compare Parameters
+// @Description Compares the simulations of a specific intersection with two ad-hoc parameter sets metric by metric, with the absolute and percentage change from the baseline to the candidate and whether it is an improvement. Nothing is saved.
+// @Tags Simulation
+// @Accept json
+// @Produce json
+// @Security BearerAuth
+// @Param id path string true "Intersection ID"
+// @Param request body model.CompareParametersRequest true "Baseline and candidate simulation parameters"
+// @Param replications query int false "Number of seeds to simulate both parameter sets over (default is 1)"
+// @Success 200 {object} model.Comparison "Successful comparison"
+// @Failure 400 {object} model.ErrorResponse "Bad Request: Invalid simulation parameters or replications"
+// @Failure 401 {object} model.ErrorResponse "Unauthorized: Token missing or invalid"
+// @Failure 403 {object} model.ErrorResponse "Forbidden: Intersection not owned by user"
+// @Failure 500 {object} model.ErrorResponse "Internal server error"
+// @Router /intersections/{id}/compare [post]
+func (h *SimulationHandler) CompareParameters(w http.ResponseWriter, r *http.Request) {
+	logger := middleware.LoggerFromContext(r.Context()).With(
+		"handler", "simulation",
+		"action", "compareParameters",
+	)
+	logger.Info("processing compareParameters request")
+
+	intersectionID := r.PathValue("id")
+
+	var req model.CompareParametersRequest
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+		logger.Warn("failed to decode request body",
+			"error", err.Error(),
+		)
+		util.SendErrorResponse(
+			w,
+			errs.NewValidationError("Invalid request payload", map[string]any{}),
+		)
+		return
+	}
+
+	logger.Debug("validating request")
+	if err := h.validator.Struct(req); err != nil {
+		logger.Warn("validation failed",
+			"error", err.Error(),
+		)
+		util.SendErrorResponse(
+			w,
+			errs.NewValidationError(
+				"baseline and candidate need an intersection_type, seed and positive green, "+
+					"yellow, red and speed",
+				map[string]any{},
+			),
+		)
+		return
+	}
+
+	replications, err := parseReplications(r.URL.Query(), 1)
+	if err != nil {
+		logger.Warn("invalid replications", "error", err.Error())
+		util.SendErrorResponse(w, err)
+		return
+	}
+
+	clearWriteDeadline(w, logger)
+
+	resp, err := h.service.CompareParameters(
+		r.Context(),
+		intersectionID,
+		req.Baseline,
+		req.Candidate,
+		replications,
+	)
+	if err != nil {
+		logger.Error("request failed",
+			"error", err.Error(),
+		)
+		util.SendErrorResponse(w, err)
+		return
+	}
+
+	logger.Info("request successful")
+	util.SendJSONResponse(w, http.StatusOK, resp)
+}
+
+// @Summary Replicate Simulation
+// @Description Simulates a specific intersection with its default parameters over several consecutive seeds, starting at the parameters' own, and summarises every metric with its mean, standard deviation, range and 95% confidence interval.
+// @Tags Simulation
+// @Produce json
+// @Security BearerAuth
+// @Param id path string true "Intersection ID"
+// @Param replications query int false "Number of seeds to simulate over (default is 10)"
+// @Success 200 {object} model.ReplicatedResults "Successful replicated simulation"
+// @Failure 400 {object} model.ErrorResponse "Bad Request: Invalid replications"
+// @Failure 401 {object} model.ErrorResponse "Unauthorized: Token missing or invalid"
+// @Failure 403 {object} model.ErrorResponse "Forbidden: Intersection not owned by user"
+// @Failure 404 {object} model.ErrorResponse "Not Found: Intersection not found"
+// @Failure 500 {object} model.ErrorResponse "Internal server error"
+// @Router /intersections/{id}/simulate/replications [get]
+func (h *SimulationHandler) GetSimulationReplications(w http.ResponseWriter, r *http.Request) {
+	logger := middleware.LoggerFromContext(r.Context()).With(
+		"handler", "simulation",
+		"action", "getSimulationReplications",
+	)
+	logger.Info("processing getSimulationReplications request")
+
+	h.replicate(w, r, logger, h.service.ReplicateSimulation)
+}
+
+// @Summary Replicate Optimised Simulation
+// @Description Simulates a specific intersection with its optimised parameters over several consecutive seeds, starting at the parameters' own, and summarises every metric with its mean, standard deviation, range and 95% confidence interval.
+// @Tags Simulation
+// @Produce json
+// @Security BearerAuth
+// @Param id path string true "Intersection ID"
+// @Param replications query int false "Number of seeds to simulate over (default is 10)"
+// @Success 200 {object} model.ReplicatedResults "Successful replicated simulation"
+// @Failure 400 {object} model.ErrorResponse "Bad Request: Invalid replications"
+// @Failure 401 {object} model.ErrorResponse "Unauthorized: Token missing or invalid"
+// @Failure 403 {object} model.ErrorResponse "Forbidden: Intersection not owned by user"
+// @Failure 404 {object} model.ErrorResponse "Not Found: Intersection or optimised parameters not found"
+// @Failure 500 {object} model.ErrorResponse "Internal server error"
+// @Router /intersections/{id}/optimise/replications [get]
+func (h *SimulationHandler) GetOptimisedReplications(w http.ResponseWriter, r *http.Request) {
+	logger := middleware.LoggerFromContext(r.Context()).With(
+		"handler", "simulation",
+		"action", "getOptimisedReplications",
+	)
+	logger.Info("processing getOptimisedReplications request")
+
+	h.replicate(w, r, logger, h.service.ReplicateOptimised)
+}
+
+func (h *SimulationHandler) replicate(
+	w http.ResponseWriter,
+	r *http.Request,
+	logger *slog.Logger,
+	replicate func(
+		ctx context.Context,
+		intersectionID string,
+		replications int,
+	) (model.ReplicatedResults, error),
+) {
+	intersectionID := r.PathValue("id")
+
+	replications, err := parseReplications(r.URL.Query(), defaultReplications)
+	if err != nil {
+		logger.Warn("invalid replications", "error", err.Error())
+		util.SendErrorResponse(w, err)
+		return
+	}
+
+	clearWriteDeadline(w, logger)
+
+	resp, err := replicate(r.Context(), intersectionID, replications)
+	if err != nil {
+		logger.Error("request failed",
+			"error", err.Error(),
+		)
+		util.SendErrorResponse(w, err)
+		return
+	}
+
+	logger.Info("request successful")
+	util.SendJSONResponse(w, http.StatusOK, resp)
+}
+
 // @Summary Get Optimised Simulation Data
 // @Description Generates and returns optimised simulation data for a specific intersection.
 // @Tags Simulation
@@ -63,6 +405,11 @@ func (h *SimulationHandler) GetSimulation(w http.ResponseWriter, r *http.Request
 // @Produce json
 // @Security BearerAuth
 // @Param id path string true "Intersection ID"
+// @Param from query int false "Only keep positions from this many seconds into the simulation"
+// @Param to query int false "Only keep positions up to this many seconds into the simulation"
+// @Param vehicles query []string false "Only keep these vehicle IDs (comma separated or repeated)" collectionFormat(csv)
+// @Param sample_every query int false "Keep at most one position per vehicle every this many seconds"
+// @Param fields query []string false "Position fields to return out of time, x, y and speed (default is all)" collectionFormat(csv)
 // @Success 200 {object} model.SimulationResponse "Successful optimised simulation data retrieval"
 // @Failure 400 {object} model.ErrorResponse "Bad Request: Invalid input parameters"
 // @Failure 401 {object} model.ErrorResponse "Unauthorized: Token missing or invalid"
@@ -78,7 +425,14 @@ func (h *SimulationHandler) GetOptimisedSimulation(w http.ResponseWriter, r *htt
 
 	intersectionID := r.PathValue("id")
 
-	resp, err := h.service.GetOptimisedData(r.Context(), intersectionID)
+	filter, err := parseOutputFilter(r.URL.Query())
+	if err != nil {
+		logger.Warn("invalid output filter", "error", err.Error())
+		util.SendErrorResponse(w, err)
+		return
+	}
+
+	resp, err := h.service.GetOptimisedData(r.Context(), intersectionID, filter)
 	if err != nil {
 		logger.Error("request failed",
 			"error", err.Error(),
@@ -91,17 +445,256 @@ func (h *SimulationHandler) GetOptimisedSimulation(w http.ResponseWriter, r *htt
 	util.SendJSONResponse(w, http.StatusOK, resp)
 }
 
+// @Summary Stream Simulation Output
+// @Description Simulates a specific intersection with its default parameters and streams the vehicle trajectories as newline delimited JSON, one SimulationOutputChunk per line. The intersection layout is only sent with the first chunk. A stream that fails after it has started ends with a chunk carrying an error.
+// @Tags Simulation
+// @Produce application/x-ndjson
+// @Security BearerAuth
+// @Param id path string true "Intersection ID"
+// @Param from query int false "Only keep positions from this many seconds into the simulation"
+// @Param to query int false "Only keep positions up to this many seconds into the simulation"
+// @Param vehicles query []string false "Only keep these vehicle IDs (comma separated or repeated)" collectionFormat(csv)
+// @Param sample_every query int false "Keep at most one position per vehicle every this many seconds"
+// @Param fields query []string false "Position fields to return out of time, x, y and speed (default is all)" collectionFormat(csv)
+// @Success 200 {object} model.SimulationOutputChunk "Stream of simulation output chunks"
+// @Failure 400 {object} model.ErrorResponse "Bad Request: Invalid output filter"
+// @Failure 401 {object} model.ErrorResponse "Unauthorized: Token missing or invalid"
+// @Failure 403 {object} model.ErrorResponse "Forbidden: Intersection not owned by user"
+// @Failure 404 {object} model.ErrorResponse "Not Found: Intersection not found"
+// @Failure 500 {object} model.ErrorResponse "Internal server error"
+// @Router /intersections/{id}/simulate/output [get]
+func (h *SimulationHandler) GetSimulationOutput(w http.ResponseWriter, r *http.Request) {
+	logger := middleware.LoggerFromContext(r.Context()).With(
+		"handler", "simulation",
+		"action", "getSimulationOutput",
+	)
+	logger.Info("processing getSimulationOutput request")
+
+	h.streamOutput(w, r, logger, h.service.StreamSimulationOutput)
+}
+
+// @Summary Stream Optimised Simulation Output
+// @Description Simulates a specific intersection with its optimised parameters and streams the vehicle trajectories as newline delimited JSON, one SimulationOutputChunk per line. The intersection layout is only sent with the first chunk. A stream that fails after it has started ends with a chunk carrying an error.
+// @Tags Simulation
+// @Produce application/x-ndjson
+// @Security BearerAuth
+// @Param id path string true "Intersection ID"
+// @Param from query int false "Only keep positions from this many seconds into the simulation"
+// @Param to query int false "Only keep positions up to this many seconds into the simulation"
+// @Param vehicles query []string false "Only keep these vehicle IDs (comma separated or repeated)" collectionFormat(csv)
+// @Param sample_every query int false "Keep at most one position per vehicle every this many seconds"
+// @Param fields query []string false "Position fields to return out of time, x, y and speed (default is all)" collectionFormat(csv)
+// @Success 200 {object} model.SimulationOutputChunk "Stream of optimised simulation output chunks"
+// @Failure 400 {object} model.ErrorResponse "Bad Request: Invalid output filter"
+// @Failure 401 {object} model.ErrorResponse "Unauthorized: Token missing or invalid"
+// @Failure 403 {object} model.ErrorResponse "Forbidden: Intersection not owned by user"
+// @Failure 404 {object} model.ErrorResponse "Not Found: Intersection or optimised parameters not found"
+// @Failure 500 {object} model.ErrorResponse "Internal server error"
+// @Router /intersections/{id}/optimise/output [get]
+func (h *SimulationHandler) GetOptimisedOutput(w http.ResponseWriter, r *http.Request) {
+	logger := middleware.LoggerFromContext(r.Context()).With(
+		"handler", "simulation",
+		"action", "getOptimisedOutput",
+	)
+	logger.Info("processing getOptimisedOutput request")
+
+	h.streamOutput(w, r, logger, h.service.StreamOptimisedOutput)
+}
+
+// streamOutput writes simulation output chunks as newline delimited JSON as they arrive.
+// Failures before the first chunk get a regular error response, while later ones end the
+// stream with an error chunk as its status has already been sent.
+func (h *SimulationHandler) streamOutput(
+	w http.ResponseWriter,
+	r *http.Request,
+	logger *slog.Logger,
+	stream func(
+		ctx context.Context,
+		intersectionID string,
+		filter model.OutputFilter,
+		send func(chunk model.SimulationOutputChunk) error,
+	) error,
+) {
+	intersectionID := r.PathValue("id")
+
+	filter, err := parseOutputFilter(r.URL.Query())
+	if err != nil {
+		logger.Warn("invalid output filter", "error", err.Error())
+		util.SendErrorResponse(w, err)
+		return
+	}
+
+	rc := http.NewResponseController(w)
+	// NOTE: Simulating and sending a busy intersection takes longer than the server's
+	// write timeout allows
+	if err := rc.SetWriteDeadline(time.Time{}); err != nil {
+		logger.Debug("could not clear write deadline", "error", err.Error())
+	}
+
+	started := false
+	start := func() {
+		w.Header().Set("Content-Type", "application/x-ndjson")
+		w.Header().Set("Cache-Control", "no-cache")
+		w.Header().Set("X-Accel-Buffering", "no")
+		w.WriteHeader(http.StatusOK)
+		started = true
+	}
+
+	chunks := 0
+	var writeErr error
+	err = stream(r.Context(), intersectionID, filter, func(chunk model.SimulationOutputChunk) error {
+		if !started {
+			start()
+		}
+		if writeErr = util.SendJSONLine(w, chunk); writeErr == nil {
+			writeErr = rc.Flush()
+		}
+		chunks++
+		return writeErr
+	})
+
+	switch {
+	case writeErr != nil:
+		logger.Info("output stream closed", "error", writeErr.Error())
+	case err != nil && !started:
+		logger.Error("request failed",
+			"error", err.Error(),
+		)
+		util.SendErrorResponse(w, err)
+	case err != nil:
+		logger.Error("output stream failed",
+			"error", err.Error(),
+			"chunks", chunks,
+		)
+		errResp := util.ErrorToErrorResponse(err)
+		if err := util.SendJSONLine(w, model.SimulationOutputChunk{Error: &errResp}); err != nil {
+			logger.Debug("could not send error chunk", "error", err.Error())
+		}
+	default:
+		if !started {
+			start()
+		}
+		logger.Info("request successful", "chunks", chunks)
+	}
+}
+
+// parseOutputFilter reads the output filter of the simulation endpoints from their query
+func parseOutputFilter(query url.Values) (model.OutputFilter, error) {
+	var filter model.OutputFilter
+
+	parseSeconds := func(name string) (*int, error) {
+		value := query.Get(name)
+		if value == "" {
+			return nil, nil
+		}
+		seconds, err := strconv.Atoi(value)
+		if err != nil || seconds < 0 {
+			return nil, errs.NewValidationError(
+				"Invalid "+name+" time",
+				map[string]any{name: value},
+			)
+		}
+		return &seconds, nil
+	}
+
+	var err error
+	if filter.From, err = parseSeconds("from"); err != nil {
+		return model.OutputFilter{}, err
+	}
+	if filter.To, err = parseSeconds("to"); err != nil {
+		return model.OutputFilter{}, err
+	}
+	if filter.From != nil && filter.To != nil && *filter.To < *filter.From {
+		return model.OutputFilter{}, errs.NewValidationError(
+			"to must not be before from",
+			map[string]any{"from": *filter.From, "to": *filter.To},
+		)
+	}
+
+	filter.VehicleIDs = splitQueryList(query["vehicles"])
+
+	if value := query.Get("sample_every"); value != "" {
+		sampleEvery, err := strconv.Atoi(value)
+		if err != nil || sampleEvery < 1 {
+			return model.OutputFilter{}, errs.NewValidationError(
+				"Invalid sample interval",
+				map[string]any{"sample_every": value},
+			)
+		}
+		filter.SampleEvery = sampleEvery
+	}
+
+	if fields := splitQueryList(query["fields"]); len(fields) > 0 {
+		filter.OmitSpeed = true
+		for _, field := range fields {
+			switch field {
+			case model.PositionFieldTime, model.PositionFieldX, model.PositionFieldY:
+			case model.PositionFieldSpeed:
+				filter.OmitSpeed = false
+			default:
+				return model.OutputFilter{}, errs.NewValidationError(
+					"Unknown position field",
+					map[string]any{"field": field},
+				)
+			}
+		}
+	}
+
+	return filter, nil
+}
+
+// parseReplications reads the number of seeds to simulate over, which the service checks
+// against its maximum
+func parseReplications(query url.Values, defaultValue int) (int, error) {
+	value := query.Get("replications")
+	if value == "" {
+		return defaultValue, nil
+	}
+	replications, err := strconv.Atoi(value)
+	if err != nil || replications < 1 {
+		return 0, errs.NewValidationError(
+			"Invalid number of replications",
+			map[string]any{"replications": value},
+		)
+	}
+	return replications, nil
+}
+
+// clearWriteDeadline lifts the server's write timeout for requests that run several
+// simulations before answering, which can take longer than it allows
+func clearWriteDeadline(w http.ResponseWriter, logger *slog.Logger) {
+	rc := http.NewResponseController(w)
+	if err := rc.SetWriteDeadline(time.Time{}); err != nil {
+		logger.Debug("could not clear write deadline", "error", err.Error())
+	}
+}
+
+// splitQueryList flattens a query parameter that was given repeatedly and/or comma separated
+func splitQueryList(values []string) []string {
+	var list []string
+	for _, value := range values {
+		for item := range strings.SplitSeq(value, ",") {
+			if item = strings.TrimSpace(item); item != "" {
+				list = append(list, item)
+			}
+		}
+	}
+	return list
+}
+
 // @Summary Run Optimisation
-// @Description Runs optimisation for a specific intersection and returns if there was an improvement.
+// @Description Starts an optimisation job for a specific intersection. The job runs in the background and can be polled or cancelled through its ID.
 // @Tags Simulation
 // @Accept json
 // @Produce json
 // @Security BearerAuth
 // @Param id path string true "Intersection ID"
-// @Success 200 {object} model.OptimisationResponse "Successful optimisation run"
+// @Success 202 {object} model.OptimisationJob "Optimisation job accepted"
 // @Failure 400 {object} model.ErrorResponse "Bad Request: Invalid input parameters"
 // @Failure 401 {object} model.ErrorResponse "Unauthorized: Token missing or invalid"
+// @Failure 403 {object} model.ErrorResponse "Forbidden: Intersection not owned by user"
 // @Failure 404 {object} model.ErrorResponse "Not Found: Intersection not found"
+// @Failure 409 {object} model.ErrorResponse "Conflict: An optimisation job is already active for this intersection"
 // @Failure 500 {object} model.ErrorResponse "Internal server error"
 // @Router /intersections/{id}/optimise [post]
 func (h *SimulationHandler) RunOptimisation(w http.ResponseWriter, r *http.Request) {
@@ -123,5 +716,600 @@ func (h *SimulationHandler) RunOptimisation(w http.ResponseWriter, r *http.Reque
 	}
 
 	logger.Info("request successful")
-	util.SendJSONResponse(w, http.StatusOK, resp)
+	w.Header().Set("Location", "/optimisation-jobs/"+resp.ID)
+	util.SendJSONResponse(w, http.StatusAccepted, resp)
+}
+
+// @Summary Get Optimisation Job
+// @Description Returns the current state of an optimisation job.
+// @Tags Simulation
+// @Produce json
+// @Security BearerAuth
+// @Param id path string true "Optimisation Job ID"
+// @Success 200 {object} model.OptimisationJob "Successful optimisation job retrieval"
+// @Failure 401 {object} model.ErrorResponse "Unauthorized: Token missing or invalid"
+// @Failure 403 {object} model.ErrorResponse "Forbidden: Job not owned by user"
+// @Failure 404 {object} model.ErrorResponse "Not Found: Optimisation job not found"
+// @Failure 500 {object} model.ErrorResponse "Internal server error"
+// @Router /optimisation-jobs/{id} [get]
+func (h *SimulationHandler) GetOptimisationJob(w http.ResponseWriter, r *http.Request) {
+	logger := middleware.LoggerFromContext(r.Context()).With(
+		"handler", "simulation",
+		"action", "getOptimisationJob",
+	)
+	logger.Info("processing getOptimisationJob request")
+
+	jobID := r.PathValue("id")
+
+	resp, err := h.service.GetOptimisationJob(r.Context(), jobID)
+	if err != nil {
+		logger.Error("request failed",
+			"error", err.Error(),
+		)
+		util.SendErrorResponse(w, err)
+		return
+	}
+
+	logger.Info("request successful")
+	util.SendJSONResponse(w, http.StatusOK, resp)
+}
+
+// @Summary Get Intersection Optimisation Jobs
+// @Description Returns all optimisation jobs of a specific intersection, newest first.
+// @Tags Simulation
+// @Produce json
+// @Security BearerAuth
+// @Param id path string true "Intersection ID"
+// @Success 200 {object} model.OptimisationJobs "Successful optimisation jobs retrieval"
+// @Failure 401 {object} model.ErrorResponse "Unauthorized: Token missing or invalid"
+// @Failure 403 {object} model.ErrorResponse "Forbidden: Intersection not owned by user"
+// @Failure 500 {object} model.ErrorResponse "Internal server error"
+// @Router /intersections/{id}/optimisation-jobs [get]
+func (h *SimulationHandler) GetOptimisationJobs(w http.ResponseWriter, r *http.Request) {
+	logger := middleware.LoggerFromContext(r.Context()).With(
+		"handler", "simulation",
+		"action", "getOptimisationJobs",
+	)
+	logger.Info("processing getOptimisationJobs request")
+
+	intersectionID := r.PathValue("id")
+
+	resp, err := h.service.GetOptimisationJobs(r.Context(), intersectionID)
+	if err != nil {
+		logger.Error("request failed",
+			"error", err.Error(),
+		)
+		util.SendErrorResponse(w, err)
+		return
+	}
+
+	logger.Info("request successful")
+	util.SendJSONResponse(w, http.StatusOK, resp)
+}
+
+// @Summary Cancel Optimisation Job
+// @Description Cancels a pending or running optimisation job and restores the intersection's previous status. Cancelling a finished job returns it unchanged.
+// @Tags Simulation
+// @Produce json
+// @Security BearerAuth
+// @Param id path string true "Optimisation Job ID"
+// @Success 200 {object} model.OptimisationJob "Optimisation job cancelled"
+// @Failure 401 {object} model.ErrorResponse "Unauthorized: Token missing or invalid"
+// @Failure 403 {object} model.ErrorResponse "Forbidden: Job not owned by user"
+// @Failure 404 {object} model.ErrorResponse "Not Found: Optimisation job not found"
+// @Failure 500 {object} model.ErrorResponse "Internal server error"
+// @Router /optimisation-jobs/{id} [delete]
+func (h *SimulationHandler) CancelOptimisationJob(w http.ResponseWriter, r *http.Request) {
+	logger := middleware.LoggerFromContext(r.Context()).With(
+		"handler", "simulation",
+		"action", "cancelOptimisationJob",
+	)
+	logger.Info("processing cancelOptimisationJob request")
+
+	jobID := r.PathValue("id")
+
+	resp, err := h.service.CancelOptimisationJob(r.Context(), jobID)
+	if err != nil {
+		logger.Error("request failed",
+			"error", err.Error(),
+		)
+		util.SendErrorResponse(w, err)
+		return
+	}
+
+	logger.Info("request successful")
+	util.SendJSONResponse(w, http.StatusOK, resp)
+}
+
+// @Summary Get Intersection Runs
+// @Description Returns a page of the simulation and optimisation runs of a specific intersection, newest first.
+// @Tags Simulation
+// @Produce json
+// @Security BearerAuth
+// @Param id path string true "Intersection ID"
+// @Param page query int false "Page number (default is 1)"
+// @Param page_size query int false "Number of runs per page (default is 20 and max is 100)"
+// @Success 200 {object} model.Runs "Successful runs retrieval"
+// @Failure 400 {object} model.ErrorResponse "Bad Request: Invalid page or page size"
+// @Failure 401 {object} model.ErrorResponse "Unauthorized: Token missing or invalid"
+// @Failure 403 {object} model.ErrorResponse "Forbidden: Intersection not owned by user"
+// @Failure 404 {object} model.ErrorResponse "Not Found: Intersection not found"
+// @Failure 500 {object} model.ErrorResponse "Internal server error"
+// @Router /intersections/{id}/runs [get]
+func (h *SimulationHandler) GetRuns(w http.ResponseWriter, r *http.Request) {
+	logger := middleware.LoggerFromContext(r.Context()).With(
+		"handler", "simulation",
+		"action", "getRuns",
+	)
+	logger.Info("processing getRuns request")
+
+	intersectionID := r.PathValue("id")
+
+	pageStr := r.URL.Query().Get("page")
+	page := 1
+	if pageStr != "" {
+		if p, err := strconv.Atoi(pageStr); err == nil && p > 0 {
+			page = p
+		} else {
+			logger.Warn("invalid page number", "page", pageStr)
+			util.SendErrorResponse(
+				w,
+				errs.NewValidationError("Invalid page number", map[string]any{"page": pageStr}),
+			)
+			return
+		}
+	}
+
+	pageSizeStr := r.URL.Query().Get("page_size")
+	pageSize := 20
+	if pageSizeStr != "" {
+		if ps, err := strconv.Atoi(pageSizeStr); err == nil && ps > 0 && ps <= 100 {
+			pageSize = ps
+		} else {
+			logger.Warn("invalid page size", "page_size", pageSizeStr)
+			util.SendErrorResponse(
+				w,
+				errs.NewValidationError("Invalid page size", map[string]any{"page_size": pageSizeStr}),
+			)
+			return
+		}
+	}
+
+	resp, err := h.service.GetRuns(r.Context(), intersectionID, page, pageSize)
+	if err != nil {
+		logger.Error("request failed",
+			"error", err.Error(),
+		)
+		util.SendErrorResponse(w, err)
+		return
+	}
+
+	logger.Info("request successful")
+	util.SendJSONResponse(w, http.StatusOK, resp)
+}
+
+// @Summary Get Intersection Run
+// @Description Returns a single simulation or optimisation run of a specific intersection.
+// @Tags Simulation
+// @Produce json
+// @Security BearerAuth
+// @Param id path string true "Intersection ID"
+// @Param runId path string true "Run ID"
+// @Success 200 {object} model.Run "Successful run retrieval"
+// @Failure 401 {object} model.ErrorResponse "Unauthorized: Token missing or invalid"
+// @Failure 403 {object} model.ErrorResponse "Forbidden: Intersection not owned by user"
+// @Failure 404 {object} model.ErrorResponse "Not Found: Run not found"
+// @Failure 500 {object} model.ErrorResponse "Internal server error"
+// @Router /intersections/{id}/runs/{runId} [get]
+func (h *SimulationHandler) GetRun(w http.ResponseWriter, r *http.Request) {
+	logger := middleware.LoggerFromContext(r.Context()).With(
+		"handler", "simulation",
+		"action", "getRun",
+	)
+	logger.Info("processing getRun request")
+
+	intersectionID := r.PathValue("id")
+	runID := r.PathValue("runId")
+
+	resp, err := h.service.GetRun(r.Context(), intersectionID, runID)
+	if err != nil {
+		logger.Error("request failed",
+			"error", err.Error(),
+		)
+		util.SendErrorResponse(w, err)
+		return
+	}
+
+	logger.Info("request successful")
+	util.SendJSONResponse(w, http.StatusOK, resp)
+}
+
+// @Summary Follow Intersection Optimisation
+// @Description Streams the optimisation progress of an intersection as Server-Sent Events until the client disconnects. "progress" events carry an OptimisationProgress with the generation, best fitness so far and the candidate parameters evaluated. "job" events carry the OptimisationJob whenever a job starts or finishes.
+// @Tags Simulation
+// @Produce text/event-stream
+// @Security BearerAuth
+// @Param id path string true "Intersection ID"
+// @Success 200 {object} model.OptimisationProgress "Stream of optimisation events"
+// @Failure 401 {object} model.ErrorResponse "Unauthorized: Token missing or invalid"
+// @Failure 403 {object} model.ErrorResponse "Forbidden: Intersection not owned by user"
+// @Failure 500 {object} model.ErrorResponse "Internal server error"
+// @Router /intersections/{id}/optimise/events [get]
+func (h *SimulationHandler) GetOptimisationEvents(w http.ResponseWriter, r *http.Request) {
+	logger := middleware.LoggerFromContext(r.Context()).With(
+		"handler", "simulation",
+		"action", "getOptimisationEvents",
+	)
+	logger.Info("processing getOptimisationEvents request")
+
+	intersectionID := r.PathValue("id")
+
+	events, unsubscribe, err := h.service.SubscribeOptimisationEvents(r.Context(), intersectionID)
+	if err != nil {
+		logger.Error("request failed",
+			"error", err.Error(),
+		)
+		util.SendErrorResponse(w, err)
+		return
+	}
+	defer unsubscribe()
+
+	rc := http.NewResponseController(w)
+	// NOTE: The stream stays open far longer than the server's write timeout allows
+	if err := rc.SetWriteDeadline(time.Time{}); err != nil {
+		logger.Debug("could not clear write deadline", "error", err.Error())
+	}
+
+	w.Header().Set("Content-Type", "text/event-stream")
+	w.Header().Set("Cache-Control", "no-cache")
+	w.Header().Set("X-Accel-Buffering", "no")
+	w.WriteHeader(http.StatusOK)
+	if err := rc.Flush(); err != nil {
+		logger.Error("response writer does not support streaming", "error", err.Error())
+		return
+	}
+
+	heartbeat := time.NewTicker(eventStreamHeartbeat)
+	defer heartbeat.Stop()
+
+	for {
+		select {
+		case <-r.Context().Done():
+			logger.Info("client disconnected")
+			return
+		case <-heartbeat.C:
+			_, err = fmt.Fprint(w, ": keep-alive\n\n")
+		case event := <-events:
+			err = util.SendEvent(w, event.Type, event.Data)
+		}
+		if err == nil {
+			err = rc.Flush()
+		}
+		if err != nil {
+			logger.Info("event stream closed", "error", err.Error())
+			return
+		}
+	}
+}
+
+// @Summary Start Parameter Sweep
+// @Description Starts a sweep that simulates a specific intersection over every combination of the given parameter ranges, with its default parameters for everything not swept. The sweep runs in the background and its table can be fetched while it runs.
+// @Tags Simulation
+// @Accept json
+// @Produce json
+// @Security BearerAuth
+// @Param id path string true "Intersection ID"
+// @Param request body model.CreateSweepRequest true "Parameter ranges to sweep"
+// @Success 202 {object} model.Sweep "Sweep accepted"
+// @Failure 400 {object} model.ErrorResponse "Bad Request: Invalid ranges or too many points"
+// @Failure 401 {object} model.ErrorResponse "Unauthorized: Token missing or invalid"
+// @Failure 403 {object} model.ErrorResponse "Forbidden: Intersection not owned by user"
+// @Failure 404 {object} model.ErrorResponse "Not Found: Intersection not found"
+// @Failure 500 {object} model.ErrorResponse "Internal server error"
+// @Router /intersections/{id}/sweeps [post]
+func (h *SimulationHandler) StartSweep(w http.ResponseWriter, r *http.Request) {
+	logger := middleware.LoggerFromContext(r.Context()).With(
+		"handler", "simulation",
+		"action", "startSweep",
+	)
+	logger.Info("processing startSweep request")
+
+	intersectionID := r.PathValue("id")
+
+	var req model.CreateSweepRequest
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+		logger.Warn("failed to decode request body",
+			"error", err.Error(),
+		)
+		util.SendErrorResponse(
+			w,
+			errs.NewValidationError("Invalid request payload", map[string]any{}),
+		)
+		return
+	}
+
+	logger.Debug("validating request")
+	if err := h.validator.Struct(req); err != nil {
+		logger.Warn("validation failed",
+			"error", err.Error(),
+		)
+		util.SendErrorResponse(
+			w,
+			errs.NewValidationError(
+				"between 1 and 5 ranges are needed, each with a parameter of green, yellow, "+
+					"red, speed or seed, a positive min, a max of at least min and a positive step",
+				map[string]any{},
+			),
+		)
+		return
+	}
+
+	resp, err := h.service.StartSweep(r.Context(), intersectionID, req.Ranges)
+	if err != nil {
+		logger.Error("request failed",
+			"error", err.Error(),
+		)
+		util.SendErrorResponse(w, err)
+		return
+	}
+
+	logger.Info("request successful")
+	w.Header().Set("Location", "/intersections/"+intersectionID+"/sweeps/"+resp.ID)
+	util.SendJSONResponse(w, http.StatusAccepted, resp)
+}
+
+// @Summary Get Parameter Sweep
+// @Description Returns a sweep with its progress and the points simulated so far, as JSON or as a CSV table with one row per point.
+// @Tags Simulation
+// @Produce json
+// @Produce text/csv
+// @Security BearerAuth
+// @Param id path string true "Intersection ID"
+// @Param sweepId path string true "Sweep ID"
+// @Param format query string false "Response format, json (default) or csv"
+// @Success 200 {object} model.Sweep "Successful sweep retrieval"
+// @Failure 400 {object} model.ErrorResponse "Bad Request: Invalid format"
+// @Failure 401 {object} model.ErrorResponse "Unauthorized: Token missing or invalid"
+// @Failure 403 {object} model.ErrorResponse "Forbidden: Intersection not owned by user"
+// @Failure 404 {object} model.ErrorResponse "Not Found: Sweep not found"
+// @Failure 500 {object} model.ErrorResponse "Internal server error"
+// @Router /intersections/{id}/sweeps/{sweepId} [get]
+func (h *SimulationHandler) GetSweep(w http.ResponseWriter, r *http.Request) {
+	logger := middleware.LoggerFromContext(r.Context()).With(
+		"handler", "simulation",
+		"action", "getSweep",
+	)
+	logger.Info("processing getSweep request")
+
+	intersectionID := r.PathValue("id")
+	sweepID := r.PathValue("sweepId")
+
+	format := r.URL.Query().Get("format")
+	if format != "" && format != "json" && format != "csv" {
+		logger.Warn("invalid format", "format", format)
+		util.SendErrorResponse(
+			w,
+			errs.NewValidationError(
+				"format must be json or csv",
+				map[string]any{"format": format},
+			),
+		)
+		return
+	}
+
+	resp, err := h.service.GetSweep(r.Context(), intersectionID, sweepID)
+	if err != nil {
+		logger.Error("request failed",
+			"error", err.Error(),
+		)
+		util.SendErrorResponse(w, err)
+		return
+	}
+
+	logger.Info("request successful")
+	if format == "csv" {
+		util.SendCSVResponse(w, http.StatusOK, "sweep-"+resp.ID+".csv", sweepRecords(resp))
+		return
+	}
+	util.SendJSONResponse(w, http.StatusOK, resp)
+}
+
+// sweepRecords tabulates the points of a sweep, with a header row naming the columns
+func sweepRecords(sweep model.Sweep) [][]string {
+	records := [][]string{{
+		"index",
+		model.SweepParameterGreen,
+		model.SweepParameterYellow,
+		model.SweepParameterRed,
+		model.SweepParameterSpeed,
+		model.SweepParameterSeed,
+		model.MetricTotalVehicles,
+		model.MetricAverageTravelTime,
+		model.MetricTotalTravelTime,
+		model.MetricAverageSpeed,
+		model.MetricAverageWaitingTime,
+		model.MetricTotalWaitingTime,
+		model.MetricGeneratedVehicles,
+		model.MetricEmergencyBrakes,
+		model.MetricEmergencyStops,
+		model.MetricNearCollisions,
+		"duration_ms",
+		"error",
+	}}
+
+	for _, point := range sweep.Points {
+		params := point.Parameters
+		record := []string{
+			strconv.Itoa(point.Index),
+			strconv.Itoa(params.Green),
+			strconv.Itoa(params.Yellow),
+			strconv.Itoa(params.Red),
+			strconv.Itoa(params.Speed),
+			strconv.Itoa(params.Seed),
+		}
+		if results := point.Results; results != nil {
+			record = append(record,
+				strconv.Itoa(results.TotalVehicles),
+				formatMetric(results.AverageTravelTime),
+				formatMetric(results.TotalTravelTime),
+				formatMetric(results.AverageSpeed),
+				formatMetric(results.AverageWaitingTime),
+				formatMetric(results.TotalWaitingTime),
+				strconv.Itoa(results.GeneratedVehicles),
+				strconv.Itoa(results.EmergencyBrakes),
+				strconv.Itoa(results.EmergencyStops),
+				strconv.Itoa(results.NearCollisions),
+			)
+		} else {
+			// NOTE: Failed points leave their metric columns empty
+			record = append(record, make([]string, 10)...)
+		}
+		record = append(record, strconv.FormatInt(point.DurationMs, 10), point.Error)
+		records = append(records, record)
+	}
+
+	return records
+}
+
+func formatMetric(value float64) string {
+	return strconv.FormatFloat(value, 'f', -1, 64)
+}
+
+// @Summary Get Intersection Parameter Sweeps
+// @Description Returns all sweeps of a specific intersection with their progress, newest first. Points are left out; fetch a single sweep for its table.
+// @Tags Simulation
+// @Produce json
+// @Security BearerAuth
+// @Param id path string true "Intersection ID"
+// @Success 200 {object} model.Sweeps "Successful sweeps retrieval"
+// @Failure 401 {object} model.ErrorResponse "Unauthorized: Token missing or invalid"
+// @Failure 403 {object} model.ErrorResponse "Forbidden: Intersection not owned by user"
+// @Failure 500 {object} model.ErrorResponse "Internal server error"
+// @Router /intersections/{id}/sweeps [get]
+func (h *SimulationHandler) GetSweeps(w http.ResponseWriter, r *http.Request) {
+	logger := middleware.LoggerFromContext(r.Context()).With(
+		"handler", "simulation",
+		"action", "getSweeps",
+	)
+	logger.Info("processing getSweeps request")
+
+	intersectionID := r.PathValue("id")
+
+	resp, err := h.service.GetSweeps(r.Context(), intersectionID)
+	if err != nil {
+		logger.Error("request failed",
+			"error", err.Error(),
+		)
+		util.SendErrorResponse(w, err)
+		return
+	}
+
+	logger.Info("request successful")
+	util.SendJSONResponse(w, http.StatusOK, resp)
+}
+
+// @Summary Get Parameter Sensitivity
+// @Description Simulates a specific intersection at evenly spaced values of one parameter, keeping the others at its defaults, and returns the curve of every metric with its elasticity and the best value in the range. Each value is simulated over several consecutive seeds and the curves follow the mean of every metric.
+// @Tags Simulation
+// @Produce json
+// @Security BearerAuth
+// @Param id path string true "Intersection ID"
+// @Param param query string true "Parameter to vary, one of green, yellow, red or speed"
+// @Param min query int true "Smallest value of the parameter"
+// @Param max query int true "Largest value of the parameter"
+// @Param steps query int false "Number of values to simulate (default is 10, at most 50)"
+// @Param replications query int false "Number of seeds to simulate each value over (default is 3)"
+// @Success 200 {object} model.Sensitivity "Successful sensitivity analysis"
+// @Failure 400 {object} model.ErrorResponse "Bad Request: Invalid parameter or range"
+// @Failure 401 {object} model.ErrorResponse "Unauthorized: Token missing or invalid"
+// @Failure 403 {object} model.ErrorResponse "Forbidden: Intersection not owned by user"
+// @Failure 404 {object} model.ErrorResponse "Not Found: Intersection not found"
+// @Failure 500 {object} model.ErrorResponse "Internal server error"
+// @Router /intersections/{id}/sensitivity [get]
+func (h *SimulationHandler) GetSensitivity(w http.ResponseWriter, r *http.Request) {
+	logger := middleware.LoggerFromContext(r.Context()).With(
+		"handler", "simulation",
+		"action", "getSensitivity",
+	)
+	logger.Info("processing getSensitivity request")
+
+	intersectionID := r.PathValue("id")
+
+	req, err := parseSensitivityRequest(r.URL.Query())
+	if err != nil {
+		logger.Warn("invalid sensitivity query", "error", err.Error())
+		util.SendErrorResponse(w, err)
+		return
+	}
+
+	logger.Debug("validating request")
+	if err := h.validator.Struct(req); err != nil {
+		logger.Warn("validation failed",
+			"error", err.Error(),
+		)
+		util.SendErrorResponse(
+			w,
+			errs.NewValidationError(
+				"param must be green, yellow, red or speed, min positive, max above min, "+
+					"steps between 2 and 50 and replications positive",
+				map[string]any{},
+			),
+		)
+		return
+	}
+
+	clearWriteDeadline(w, logger)
+
+	resp, err := h.service.AnalyseSensitivity(r.Context(), intersectionID, req)
+	if err != nil {
+		logger.Error("request failed",
+			"error", err.Error(),
+		)
+		util.SendErrorResponse(w, err)
+		return
+	}
+
+	logger.Info("request successful")
+	util.SendJSONResponse(w, http.StatusOK, resp)
+}
+
+// parseSensitivityRequest reads the varied parameter and its range, leaving the limits to
+// the validator
+func parseSensitivityRequest(query url.Values) (model.SensitivityRequest, error) {
+	req := model.SensitivityRequest{
+		Parameter:    query.Get("param"),
+		Steps:        defaultSensitivitySteps,
+		Replications: defaultSensitivityReplications,
+	}
+	fields := []struct {
+		name     string
+		value    *int
+		required bool
+	}{
+		{name: "min", value: &req.Min, required: true},
+		{name: "max", value: &req.Max, required: true},
+		{name: "steps", value: &req.Steps},
+		{name: "replications", value: &req.Replications},
+	}
+	for _, field := range fields {
+		value := query.Get(field.name)
+		if value == "" {
+			if !field.required {
+				continue
+			}
+			return model.SensitivityRequest{}, errs.NewValidationError(
+				"min and max are required",
+				map[string]any{"missing": field.name},
+			)
+		}
+		parsed, err := strconv.Atoi(value)
+		if err != nil {
+			return model.SensitivityRequest{}, errs.NewValidationError(
+				"min, max, steps and replications must be whole numbers",
+				map[string]any{field.name: value},
+			)
+		}
+		*field.value = parsed
+	}
+	return req, nil
 }
@@ -0,0 +1,86 @@
+package admin
+
+import (
+	"net/http"
+	"net/http/httptest"
+
+	errs "github.com/COS301-SE-2025/Swift-Signals/shared/error"
+	"github.com/stretchr/testify/mock"
+)
+
+func (suite *TestSuite) TestMakeAdmin_Success() {
+	suite.service.On("MakeAdmin", mock.Anything, "test-user-id").
+		Return(nil)
+
+	req := httptest.NewRequest(http.MethodPost, "/admin/users/test-user-id/make-admin", nil)
+	req.SetPathValue("id", "test-user-id")
+	req = req.WithContext(suite.ctx)
+	w := httptest.NewRecorder()
+
+	suite.handler.MakeAdmin(w, req)
+
+	suite.Equal(http.StatusNoContent, w.Code)
+	suite.Empty(w.Body.String())
+
+	suite.service.AssertExpectations(suite.T())
+}
+
+func (suite *TestSuite) TestMakeAdmin_EmptyPathValue() {
+	req := httptest.NewRequest(http.MethodPost, "/admin/users//make-admin", nil)
+	req.SetPathValue("id", "")
+	req = req.WithContext(suite.ctx)
+	w := httptest.NewRecorder()
+
+	suite.handler.MakeAdmin(w, req)
+
+	suite.Equal(http.StatusBadRequest, w.Code)
+	suite.Contains(w.Body.String(), "User ID is required")
+
+	suite.service.AssertNotCalled(suite.T(), "MakeAdmin")
+}
+
+func (suite *TestSuite) TestMakeAdmin_ServiceForbiddenError() {
+	suite.service.On("MakeAdmin", mock.Anything, "test-user-id").
+		Return(errs.NewForbiddenError("only admins can access this endpoint", map[string]any{}))
+
+	req := httptest.NewRequest(http.MethodPost, "/admin/users/test-user-id/make-admin", nil)
+	req.SetPathValue("id", "test-user-id")
+	req = req.WithContext(suite.ctx)
+	w := httptest.NewRecorder()
+
+	suite.handler.MakeAdmin(w, req)
+
+	suite.Equal(http.StatusForbidden, w.Code)
+	suite.Contains(w.Body.String(), "only admins can access this endpoint")
+}
+
+func (suite *TestSuite) TestRemoveAdmin_Success() {
+	suite.service.On("RemoveAdmin", mock.Anything, "test-user-id").
+		Return(nil)
+
+	req := httptest.NewRequest(http.MethodPost, "/admin/users/test-user-id/remove-admin", nil)
+	req.SetPathValue("id", "test-user-id")
+	req = req.WithContext(suite.ctx)
+	w := httptest.NewRecorder()
+
+	suite.handler.RemoveAdmin(w, req)
+
+	suite.Equal(http.StatusNoContent, w.Code)
+
+	suite.service.AssertExpectations(suite.T())
+}
+
+func (suite *TestSuite) TestRemoveAdmin_NotFound() {
+	suite.service.On("RemoveAdmin", mock.Anything, "nonexistent-id").
+		Return(errs.NewNotFoundError("user not found", map[string]any{}))
+
+	req := httptest.NewRequest(http.MethodPost, "/admin/users/nonexistent-id/remove-admin", nil)
+	req.SetPathValue("id", "nonexistent-id")
+	req = req.WithContext(suite.ctx)
+	w := httptest.NewRecorder()
+
+	suite.handler.RemoveAdmin(w, req)
+
+	suite.Equal(http.StatusNotFound, w.Code)
+	suite.Contains(w.Body.String(), "user not found")
+}
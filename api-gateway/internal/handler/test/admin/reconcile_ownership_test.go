@@ -0,0 +1,83 @@
+package admin
+
+import (
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+
+	"github.com/COS301-SE-2025/Swift-Signals/api-gateway/internal/model"
+	errs "github.com/COS301-SE-2025/Swift-Signals/shared/error"
+	"github.com/stretchr/testify/mock"
+)
+
+func (suite *TestSuite) TestReconcileOwnership_Success() {
+	expected := model.OwnershipReport{
+		OrphanedIntersections:   []string{"orphan-1"},
+		DanglingIntersectionIDs: map[string][]string{"user-1": {"dangling-1"}},
+	}
+	suite.service.On("ReconcileOwnership", mock.Anything, false).
+		Return(expected, nil)
+
+	req := httptest.NewRequest(http.MethodPost, "/admin/reconcile", nil)
+	req = req.WithContext(suite.ctx)
+	w := httptest.NewRecorder()
+
+	suite.handler.ReconcileOwnership(w, req)
+
+	suite.Equal(http.StatusOK, w.Code)
+
+	var actual model.OwnershipReport
+	err := json.Unmarshal(w.Body.Bytes(), &actual)
+	suite.Require().NoError(err)
+	suite.Equal(expected.OrphanedIntersections, actual.OrphanedIntersections)
+	suite.Equal(expected.DanglingIntersectionIDs, actual.DanglingIntersectionIDs)
+	suite.False(actual.Repaired)
+
+	suite.service.AssertExpectations(suite.T())
+}
+
+func (suite *TestSuite) TestReconcileOwnership_Repair() {
+	suite.service.On("ReconcileOwnership", mock.Anything, true).
+		Return(model.OwnershipReport{Repaired: true}, nil)
+
+	req := httptest.NewRequest(http.MethodPost, "/admin/reconcile?repair=true", nil)
+	req = req.WithContext(suite.ctx)
+	w := httptest.NewRecorder()
+
+	suite.handler.ReconcileOwnership(w, req)
+
+	suite.Equal(http.StatusOK, w.Code)
+	suite.Contains(w.Body.String(), `"repaired":true`)
+
+	suite.service.AssertExpectations(suite.T())
+}
+
+func (suite *TestSuite) TestReconcileOwnership_InvalidRepairFlag() {
+	req := httptest.NewRequest(http.MethodPost, "/admin/reconcile?repair=maybe", nil)
+	req = req.WithContext(suite.ctx)
+	w := httptest.NewRecorder()
+
+	suite.handler.ReconcileOwnership(w, req)
+
+	suite.Equal(http.StatusBadRequest, w.Code)
+	suite.Contains(w.Body.String(), "Invalid repair flag")
+
+	suite.service.AssertNotCalled(suite.T(), "ReconcileOwnership", mock.Anything, mock.Anything)
+}
+
+func (suite *TestSuite) TestReconcileOwnership_ServiceForbiddenError() {
+	suite.service.On("ReconcileOwnership", mock.Anything, false).
+		Return(model.OwnershipReport{},
+			errs.NewForbiddenError("only admins can access this endpoint", map[string]any{}))
+
+	req := httptest.NewRequest(http.MethodPost, "/admin/reconcile", nil)
+	req = req.WithContext(suite.ctx)
+	w := httptest.NewRecorder()
+
+	suite.handler.ReconcileOwnership(w, req)
+
+	suite.Equal(http.StatusForbidden, w.Code)
+	suite.Contains(w.Body.String(), "only admins can access this endpoint")
+
+	suite.service.AssertExpectations(suite.T())
+}
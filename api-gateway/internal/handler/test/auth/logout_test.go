@@ -0,0 +1,64 @@
+package auth
+
+import (
+	"bytes"
+	"net/http"
+	"net/http/httptest"
+
+	"github.com/COS301-SE-2025/Swift-Signals/api-gateway/internal/model"
+	"github.com/stretchr/testify/mock"
+)
+
+func (suite *TestSuite) TestLogout_WithRefreshToken() {
+	suite.service.On(
+		"LogoutUser",
+		mock.Anything,
+		"very.real.token",
+		model.LogoutRequest{RefreshToken: "refresh-token"},
+	).Return(model.LogoutResponse{Message: "Logout Successful"}, nil)
+
+	req := httptest.NewRequest(
+		http.MethodPost,
+		"/logout",
+		bytes.NewBufferString(`{"refresh_token":"refresh-token"}`),
+	)
+	req.Header.Set("Content-Type", "application/json")
+	req.Header.Set("Authorization", "Bearer very.real.token")
+	w := httptest.NewRecorder()
+
+	suite.handler.Logout(w, req)
+
+	suite.Equal(http.StatusOK, w.Code)
+	suite.service.AssertExpectations(suite.T())
+}
+
+func (suite *TestSuite) TestLogout_WithoutBody() {
+	suite.service.On("LogoutUser", mock.Anything, "very.real.token", model.LogoutRequest{}).
+		Return(model.LogoutResponse{Message: "Logout Successful"}, nil)
+
+	req := httptest.NewRequest(http.MethodPost, "/logout", nil)
+	req.Header.Set("Authorization", "Bearer very.real.token")
+	w := httptest.NewRecorder()
+
+	suite.handler.Logout(w, req)
+
+	suite.Equal(http.StatusOK, w.Code)
+	suite.service.AssertExpectations(suite.T())
+}
+
+func (suite *TestSuite) TestLogout_InvalidJSON() {
+	req := httptest.NewRequest(
+		http.MethodPost,
+		"/logout",
+		bytes.NewBufferString(`{"refresh_token":`),
+	)
+	req.Header.Set("Content-Type", "application/json")
+	req.Header.Set("Authorization", "Bearer very.real.token")
+	w := httptest.NewRecorder()
+
+	suite.handler.Logout(w, req)
+
+	suite.Equal(http.StatusBadRequest, w.Code)
+	suite.Contains(w.Body.String(), "Invalid request payload")
+	suite.service.AssertNotCalled(suite.T(), "LogoutUser", mock.Anything, mock.Anything, mock.Anything)
+}
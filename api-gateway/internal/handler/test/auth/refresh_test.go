@@ -0,0 +1,94 @@
+package auth
+
+import (
+	"bytes"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"time"
+
+	"github.com/COS301-SE-2025/Swift-Signals/api-gateway/internal/model"
+	errs "github.com/COS301-SE-2025/Swift-Signals/shared/error"
+	"github.com/stretchr/testify/mock"
+)
+
+func (suite *TestSuite) TestRefresh_Success() {
+	expectedRequest := model.RefreshRequest{
+		RefreshToken: "refresh-token",
+	}
+	expectedResponse := model.LoginResponse{
+		Message:          "Refresh Successful",
+		Token:            "very.real.token",
+		ExpiresAt:        time.Date(2025, 6, 24, 15, 19, 5, 0, time.UTC),
+		RefreshToken:     "new-refresh-token",
+		RefreshExpiresAt: time.Date(2025, 7, 1, 15, 4, 5, 0, time.UTC),
+	}
+
+	suite.service.On("RefreshToken", mock.Anything, expectedRequest).
+		Return(expectedResponse, nil)
+
+	body, _ := json.Marshal(expectedRequest)
+	req := httptest.NewRequest(http.MethodPost, "/refresh", bytes.NewBuffer(body))
+	req.Header.Set("Content-Type", "application/json")
+	w := httptest.NewRecorder()
+
+	suite.handler.Refresh(w, req)
+
+	suite.Equal(http.StatusOK, w.Code)
+
+	var response model.LoginResponse
+	err := json.Unmarshal(w.Body.Bytes(), &response)
+	suite.Require().NoError(err)
+	suite.Equal(expectedResponse, response)
+	suite.service.AssertExpectations(suite.T())
+}
+
+func (suite *TestSuite) TestRefresh_InvalidJSON() {
+	req := httptest.NewRequest(
+		http.MethodPost,
+		"/refresh",
+		bytes.NewBufferString(`{"refresh_token":`),
+	)
+	req.Header.Set("Content-Type", "application/json")
+	w := httptest.NewRecorder()
+
+	suite.handler.Refresh(w, req)
+
+	suite.Equal(http.StatusBadRequest, w.Code)
+	suite.Contains(w.Body.String(), "Invalid request payload")
+}
+
+func (suite *TestSuite) TestRefresh_MissingRefreshToken() {
+	req := httptest.NewRequest(http.MethodPost, "/refresh", bytes.NewBufferString(`{}`))
+	req.Header.Set("Content-Type", "application/json")
+	w := httptest.NewRecorder()
+
+	suite.handler.Refresh(w, req)
+
+	suite.Equal(http.StatusBadRequest, w.Code)
+	suite.Contains(w.Body.String(), "Refresh token is required")
+	suite.service.AssertNotCalled(suite.T(), "RefreshToken", mock.Anything, mock.Anything)
+}
+
+func (suite *TestSuite) TestRefresh_Reused() {
+	expectedRequest := model.RefreshRequest{
+		RefreshToken: "refresh-token",
+	}
+
+	suite.service.On("RefreshToken", mock.Anything, expectedRequest).
+		Return(
+			model.LoginResponse{},
+			errs.NewUnauthorizedError("refresh token has already been used", map[string]any{}),
+		)
+
+	body, _ := json.Marshal(expectedRequest)
+	req := httptest.NewRequest(http.MethodPost, "/refresh", bytes.NewBuffer(body))
+	req.Header.Set("Content-Type", "application/json")
+	w := httptest.NewRecorder()
+
+	suite.handler.Refresh(w, req)
+
+	suite.Equal(http.StatusUnauthorized, w.Code)
+	suite.Contains(w.Body.String(), "refresh token has already been used")
+	suite.service.AssertExpectations(suite.T())
+}
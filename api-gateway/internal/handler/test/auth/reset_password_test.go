@@ -0,0 +1,163 @@
+package auth
+
+import (
+	"bytes"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+
+	"github.com/COS301-SE-2025/Swift-Signals/api-gateway/internal/model"
+	errs "github.com/COS301-SE-2025/Swift-Signals/shared/error"
+	"github.com/stretchr/testify/mock"
+)
+
+func (suite *TestSuite) TestResetPassword_Success() {
+	expectedRequest := model.ResetPasswordRequest{
+		Email: "user@example.com",
+	}
+	expectedResponse := model.ResetPasswordResponse{
+		Message: "If an account uses that email, a password reset link has been sent to it",
+	}
+
+	suite.service.On("ResetPassword", mock.Anything, expectedRequest).
+		Return(expectedResponse, nil)
+
+	body, _ := json.Marshal(expectedRequest)
+	req := httptest.NewRequest(http.MethodPost, "/reset-password", bytes.NewBuffer(body))
+	req.Header.Set("Content-Type", "application/json")
+	w := httptest.NewRecorder()
+
+	suite.handler.ResetPassword(w, req)
+
+	suite.Equal(http.StatusOK, w.Code)
+
+	var response model.ResetPasswordResponse
+	err := json.Unmarshal(w.Body.Bytes(), &response)
+	suite.Require().NoError(err)
+	suite.Equal(expectedResponse, response)
+	suite.service.AssertExpectations(suite.T())
+}
+
+func (suite *TestSuite) TestResetPassword_InvalidEmail() {
+	req := httptest.NewRequest(
+		http.MethodPost,
+		"/reset-password",
+		bytes.NewBufferString(`{"email":"not-an-email"}`),
+	)
+	req.Header.Set("Content-Type", "application/json")
+	w := httptest.NewRecorder()
+
+	suite.handler.ResetPassword(w, req)
+
+	suite.Equal(http.StatusBadRequest, w.Code)
+	suite.Contains(w.Body.String(), "A valid email is required")
+	suite.service.AssertNotCalled(suite.T(), "ResetPassword", mock.Anything, mock.Anything)
+}
+
+func (suite *TestSuite) TestResetPassword_RateLimited() {
+	expectedRequest := model.ResetPasswordRequest{
+		Email: "user@example.com",
+	}
+
+	suite.service.On("ResetPassword", mock.Anything, expectedRequest).
+		Return(
+			model.ResetPasswordResponse{},
+			errs.NewRateLimitedError(
+				"too many password reset requests, try again later",
+				map[string]any{},
+			),
+		)
+
+	body, _ := json.Marshal(expectedRequest)
+	req := httptest.NewRequest(http.MethodPost, "/reset-password", bytes.NewBuffer(body))
+	req.Header.Set("Content-Type", "application/json")
+	w := httptest.NewRecorder()
+
+	suite.handler.ResetPassword(w, req)
+
+	suite.Equal(http.StatusTooManyRequests, w.Code)
+	suite.Contains(w.Body.String(), "too many password reset requests")
+	suite.service.AssertExpectations(suite.T())
+}
+
+func (suite *TestSuite) TestConfirmResetPassword_Success() {
+	expectedRequest := model.ConfirmResetPasswordRequest{
+		Token:       "reset-token",
+		NewPassword: "newpass1234",
+	}
+	expectedResponse := model.ConfirmResetPasswordResponse{
+		Message: "Password Reset Successful",
+	}
+
+	suite.service.On("ConfirmPasswordReset", mock.Anything, expectedRequest).
+		Return(expectedResponse, nil)
+
+	body, _ := json.Marshal(expectedRequest)
+	req := httptest.NewRequest(http.MethodPost, "/reset-password/confirm", bytes.NewBuffer(body))
+	req.Header.Set("Content-Type", "application/json")
+	w := httptest.NewRecorder()
+
+	suite.handler.ConfirmResetPassword(w, req)
+
+	suite.Equal(http.StatusOK, w.Code)
+
+	var response model.ConfirmResetPasswordResponse
+	err := json.Unmarshal(w.Body.Bytes(), &response)
+	suite.Require().NoError(err)
+	suite.Equal(expectedResponse, response)
+	suite.service.AssertExpectations(suite.T())
+}
+
+func (suite *TestSuite) TestConfirmResetPassword_InvalidJSON() {
+	req := httptest.NewRequest(
+		http.MethodPost,
+		"/reset-password/confirm",
+		bytes.NewBufferString(`{"token":`),
+	)
+	req.Header.Set("Content-Type", "application/json")
+	w := httptest.NewRecorder()
+
+	suite.handler.ConfirmResetPassword(w, req)
+
+	suite.Equal(http.StatusBadRequest, w.Code)
+	suite.Contains(w.Body.String(), "Invalid request payload")
+}
+
+func (suite *TestSuite) TestConfirmResetPassword_ShortPassword() {
+	req := httptest.NewRequest(
+		http.MethodPost,
+		"/reset-password/confirm",
+		bytes.NewBufferString(`{"token":"reset-token","new_password":"short"}`),
+	)
+	req.Header.Set("Content-Type", "application/json")
+	w := httptest.NewRecorder()
+
+	suite.handler.ConfirmResetPassword(w, req)
+
+	suite.Equal(http.StatusBadRequest, w.Code)
+	suite.service.AssertNotCalled(suite.T(), "ConfirmPasswordReset", mock.Anything, mock.Anything)
+}
+
+func (suite *TestSuite) TestConfirmResetPassword_InvalidToken() {
+	expectedRequest := model.ConfirmResetPasswordRequest{
+		Token:       "used-token",
+		NewPassword: "newpass1234",
+	}
+
+	suite.service.On("ConfirmPasswordReset", mock.Anything, expectedRequest).
+		Return(
+			model.ConfirmResetPasswordResponse{},
+			errs.NewUnauthorizedError("invalid or expired reset token", map[string]any{}),
+		)
+
+	body, _ := json.Marshal(expectedRequest)
+	req := httptest.NewRequest(http.MethodPost, "/reset-password/confirm", bytes.NewBuffer(body))
+	req.Header.Set("Content-Type", "application/json")
+	w := httptest.NewRecorder()
+
+	suite.handler.ConfirmResetPassword(w, req)
+
+	suite.Equal(http.StatusUnauthorized, w.Code)
+	suite.Contains(w.Body.String(), "invalid or expired reset token")
+	suite.service.AssertExpectations(suite.T())
+}
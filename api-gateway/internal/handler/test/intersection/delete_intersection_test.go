@@ -9,7 +9,7 @@ import (
 )
 
 func (suite *TestSuite) TestDeleteIntersection_Success() {
-	suite.service.On("DeleteIntersectionByID", mock.Anything, "test-user-id", "test-intersection-id").
+	suite.service.On("DeleteIntersectionByID", mock.Anything, "test-user-id", "test-intersection-id", 0).
 		Return(nil)
 
 	req := httptest.NewRequest(http.MethodDelete, "/intersections/test-intersection-id", nil)
@@ -25,6 +25,54 @@ func (suite *TestSuite) TestDeleteIntersection_Success() {
 	suite.service.AssertExpectations(suite.T())
 }
 
+func (suite *TestSuite) TestDeleteIntersection_IfMatch() {
+	suite.service.On("DeleteIntersectionByID", mock.Anything, "test-user-id", "test-intersection-id", 4).
+		Return(nil)
+
+	req := httptest.NewRequest(http.MethodDelete, "/intersections/test-intersection-id", nil)
+	req.Header.Set("If-Match", `"4"`)
+	req.SetPathValue("id", "test-intersection-id")
+	req = req.WithContext(suite.ctx)
+	w := httptest.NewRecorder()
+
+	suite.handler.DeleteIntersection(w, req)
+
+	suite.Equal(http.StatusNoContent, w.Code)
+	suite.service.AssertExpectations(suite.T())
+}
+
+func (suite *TestSuite) TestDeleteIntersection_IfMatchUnknownTag() {
+	req := httptest.NewRequest(http.MethodDelete, "/intersections/test-intersection-id", nil)
+	req.Header.Set("If-Match", `W/"4"`)
+	req.SetPathValue("id", "test-intersection-id")
+	req = req.WithContext(suite.ctx)
+	w := httptest.NewRecorder()
+
+	suite.handler.DeleteIntersection(w, req)
+
+	suite.Equal(http.StatusPreconditionFailed, w.Code)
+	suite.service.AssertNotCalled(suite.T(), "DeleteIntersectionByID")
+}
+
+func (suite *TestSuite) TestDeleteIntersection_StaleVersion() {
+	suite.service.On("DeleteIntersectionByID", mock.Anything, "test-user-id", "test-intersection-id", 4).
+		Return(errs.NewPreconditionError(
+			"intersection has been modified since it was read",
+			map[string]any{},
+		))
+
+	req := httptest.NewRequest(http.MethodDelete, "/intersections/test-intersection-id", nil)
+	req.Header.Set("If-Match", `"4"`)
+	req.SetPathValue("id", "test-intersection-id")
+	req = req.WithContext(suite.ctx)
+	w := httptest.NewRecorder()
+
+	suite.handler.DeleteIntersection(w, req)
+
+	suite.Equal(http.StatusPreconditionFailed, w.Code)
+	suite.Contains(w.Body.String(), "modified since it was read")
+}
+
 func (suite *TestSuite) TestDeleteIntersection_MissingUserID() {
 	req := httptest.NewRequest(http.MethodDelete, "/intersections/test-intersection-id", nil)
 	req.SetPathValue("id", "test-intersection-id")
@@ -40,7 +88,7 @@ func (suite *TestSuite) TestDeleteIntersection_MissingUserID() {
 }
 
 func (suite *TestSuite) TestDeleteIntersection_NotFound() {
-	suite.service.On("DeleteIntersectionByID", mock.Anything, "test-user-id", "nonexistent-id").
+	suite.service.On("DeleteIntersectionByID", mock.Anything, "test-user-id", "nonexistent-id", 0).
 		Return(errs.NewNotFoundError("intersection not found", map[string]any{}))
 
 	req := httptest.NewRequest(http.MethodDelete, "/intersections/nonexistent-id", nil)
@@ -57,7 +105,7 @@ func (suite *TestSuite) TestDeleteIntersection_NotFound() {
 }
 
 func (suite *TestSuite) TestDeleteIntersection_Forbidden() {
-	suite.service.On("DeleteIntersectionByID", mock.Anything, "test-user-id", "forbidden-id").
+	suite.service.On("DeleteIntersectionByID", mock.Anything, "test-user-id", "forbidden-id", 0).
 		Return(errs.NewForbiddenError("intersection not in user's intersection list", map[string]any{}))
 
 	req := httptest.NewRequest(http.MethodDelete, "/intersections/forbidden-id", nil)
@@ -74,7 +122,7 @@ func (suite *TestSuite) TestDeleteIntersection_Forbidden() {
 }
 
 func (suite *TestSuite) TestDeleteIntersection_InternalError() {
-	suite.service.On("DeleteIntersectionByID", mock.Anything, "test-user-id", "test-intersection-id").
+	suite.service.On("DeleteIntersectionByID", mock.Anything, "test-user-id", "test-intersection-id", 0).
 		Return(errs.NewInternalError("database connection failed", nil, map[string]any{}))
 
 	req := httptest.NewRequest(http.MethodDelete, "/intersections/test-intersection-id", nil)
@@ -91,7 +139,7 @@ func (suite *TestSuite) TestDeleteIntersection_InternalError() {
 }
 
 func (suite *TestSuite) TestDeleteIntersection_ValidationError() {
-	suite.service.On("DeleteIntersectionByID", mock.Anything, "test-user-id", "invalid-id").
+	suite.service.On("DeleteIntersectionByID", mock.Anything, "test-user-id", "invalid-id", 0).
 		Return(errs.NewValidationError("invalid intersection ID format", map[string]any{}))
 
 	req := httptest.NewRequest(http.MethodDelete, "/intersections/invalid-id", nil)
@@ -108,7 +156,7 @@ func (suite *TestSuite) TestDeleteIntersection_ValidationError() {
 }
 
 func (suite *TestSuite) TestDeleteIntersection_UnauthorizedError() {
-	suite.service.On("DeleteIntersectionByID", mock.Anything, "test-user-id", "test-intersection-id").
+	suite.service.On("DeleteIntersectionByID", mock.Anything, "test-user-id", "test-intersection-id", 0).
 		Return(errs.NewUnauthorizedError("token expired", map[string]any{}))
 
 	req := httptest.NewRequest(http.MethodDelete, "/intersections/test-intersection-id", nil)
@@ -125,7 +173,7 @@ func (suite *TestSuite) TestDeleteIntersection_UnauthorizedError() {
 }
 
 func (suite *TestSuite) TestDeleteIntersection_EmptyPathValue() {
-	suite.service.On("DeleteIntersectionByID", mock.Anything, "test-user-id", "").
+	suite.service.On("DeleteIntersectionByID", mock.Anything, "test-user-id", "", 0).
 		Return(nil)
 
 	req := httptest.NewRequest(http.MethodDelete, "/intersections/", nil)
@@ -143,7 +191,7 @@ func (suite *TestSuite) TestDeleteIntersection_EmptyPathValue() {
 
 func (suite *TestSuite) TestDeleteIntersection_AlreadyExistsError() {
 	// This is an edge case - intersection exists but cannot be deleted due to constraints
-	suite.service.On("DeleteIntersectionByID", mock.Anything, "test-user-id", "constrained-id").
+	suite.service.On("DeleteIntersectionByID", mock.Anything, "test-user-id", "constrained-id", 0).
 		Return(errs.NewAlreadyExistsError("intersection has dependent resources and cannot be deleted", map[string]any{}))
 
 	req := httptest.NewRequest(http.MethodDelete, "/intersections/constrained-id", nil)
@@ -164,7 +212,7 @@ func (suite *TestSuite) TestDeleteIntersection_MultipleSuccessfulDeletes() {
 	intersectionIds := []string{"id1", "id2", "id3"}
 
 	for _, id := range intersectionIds {
-		suite.service.On("DeleteIntersectionByID", mock.Anything, "test-user-id", id).
+		suite.service.On("DeleteIntersectionByID", mock.Anything, "test-user-id", id, 0).
 			Return(nil)
 
 		req := httptest.NewRequest(http.MethodDelete, "/intersections/"+id, nil)
@@ -184,7 +232,7 @@ func (suite *TestSuite) TestDeleteIntersection_MultipleSuccessfulDeletes() {
 func (suite *TestSuite) TestDeleteIntersection_LongIntersectionID() {
 	longId := "very-long-intersection-id-that-might-cause-issues-with-some-systems-but-should-still-be-handled-properly-by-the-delete-endpoint-implementation"
 
-	suite.service.On("DeleteIntersectionByID", mock.Anything, "test-user-id", longId).
+	suite.service.On("DeleteIntersectionByID", mock.Anything, "test-user-id", longId, 0).
 		Return(nil)
 
 	req := httptest.NewRequest(http.MethodDelete, "/intersections/"+longId, nil)
@@ -203,7 +251,7 @@ func (suite *TestSuite) TestDeleteIntersection_LongIntersectionID() {
 func (suite *TestSuite) TestDeleteIntersection_SpecialCharactersInID() {
 	specialId := "test-id-with-special-chars-123_456"
 
-	suite.service.On("DeleteIntersectionByID", mock.Anything, "test-user-id", specialId).
+	suite.service.On("DeleteIntersectionByID", mock.Anything, "test-user-id", specialId, 0).
 		Return(nil)
 
 	req := httptest.NewRequest(http.MethodDelete, "/intersections/"+specialId, nil)
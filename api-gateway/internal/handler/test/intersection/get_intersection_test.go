@@ -218,3 +218,33 @@ func (suite *TestSuite) TestGetIntersection_ResponseHeadersSet() {
 
 	suite.service.AssertExpectations(suite.T())
 }
+
+func (suite *TestSuite) TestGetIntersection_ETag() {
+	suite.service.On("GetIntersectionByID", mock.Anything, "test-user-id", "test-intersection-id").
+		Return(model.Intersection{ID: "test-intersection-id", Version: 5}, nil)
+
+	req := httptest.NewRequest(http.MethodGet, "/intersections/test-intersection-id", nil)
+	req.SetPathValue("id", "test-intersection-id")
+	req = req.WithContext(suite.ctx)
+	w := httptest.NewRecorder()
+
+	suite.handler.GetIntersection(w, req)
+
+	suite.Equal(http.StatusOK, w.Code)
+	suite.Equal(`"5"`, w.Header().Get("ETag"))
+}
+
+func (suite *TestSuite) TestGetIntersection_UnversionedHasNoETag() {
+	suite.service.On("GetIntersectionByID", mock.Anything, "test-user-id", "test-intersection-id").
+		Return(model.Intersection{ID: "test-intersection-id"}, nil)
+
+	req := httptest.NewRequest(http.MethodGet, "/intersections/test-intersection-id", nil)
+	req.SetPathValue("id", "test-intersection-id")
+	req = req.WithContext(suite.ctx)
+	w := httptest.NewRecorder()
+
+	suite.handler.GetIntersection(w, req)
+
+	suite.Equal(http.StatusOK, w.Code)
+	suite.Empty(w.Header().Get("ETag"))
+}
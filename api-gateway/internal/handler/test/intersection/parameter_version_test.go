@@ -0,0 +1,236 @@
+package intersection
+
+import (
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+
+	"github.com/COS301-SE-2025/Swift-Signals/api-gateway/internal/model"
+	errs "github.com/COS301-SE-2025/Swift-Signals/shared/error"
+	"github.com/stretchr/testify/mock"
+)
+
+func (suite *TestSuite) TestGetParameterVersions_Success() {
+	expected := model.ParameterVersions{
+		Versions: []model.ParameterVersion{
+			{
+				IntersectionID: "test-intersection-id",
+				Version:        2,
+				UserID:         "test-user-id",
+				Reason:         model.ParametersUpdated,
+				Changes: []model.ParameterChange{
+					{Field: model.ParameterFieldDefault},
+				},
+			},
+		},
+		Page:     2,
+		PageSize: 10,
+	}
+	suite.service.On("GetParameterVersions", mock.Anything, "test-user-id", "test-intersection-id", 2, 10).
+		Return(expected, nil)
+
+	req := httptest.NewRequest(
+		http.MethodGet,
+		"/intersections/test-intersection-id/parameter-versions?page=2&page_size=10",
+		nil,
+	)
+	req.SetPathValue("id", "test-intersection-id")
+	req = req.WithContext(suite.ctx)
+	w := httptest.NewRecorder()
+
+	suite.handler.GetParameterVersions(w, req)
+
+	suite.Equal(http.StatusOK, w.Code)
+
+	var resp model.ParameterVersions
+	err := json.Unmarshal(w.Body.Bytes(), &resp)
+	suite.Require().NoError(err)
+	suite.Require().Len(resp.Versions, 1)
+	suite.Equal(2, resp.Versions[0].Version)
+	suite.Equal(model.ParametersUpdated, resp.Versions[0].Reason)
+
+	suite.service.AssertExpectations(suite.T())
+}
+
+func (suite *TestSuite) TestGetParameterVersions_DefaultPagination() {
+	suite.service.On("GetParameterVersions", mock.Anything, "test-user-id", "test-intersection-id", 1, 20).
+		Return(model.ParameterVersions{Versions: []model.ParameterVersion{}, Page: 1, PageSize: 20}, nil)
+
+	req := httptest.NewRequest(
+		http.MethodGet,
+		"/intersections/test-intersection-id/parameter-versions",
+		nil,
+	)
+	req.SetPathValue("id", "test-intersection-id")
+	req = req.WithContext(suite.ctx)
+	w := httptest.NewRecorder()
+
+	suite.handler.GetParameterVersions(w, req)
+
+	suite.Equal(http.StatusOK, w.Code)
+	suite.service.AssertExpectations(suite.T())
+}
+
+func (suite *TestSuite) TestGetParameterVersions_InvalidPageSize() {
+	req := httptest.NewRequest(
+		http.MethodGet,
+		"/intersections/test-intersection-id/parameter-versions?page_size=500",
+		nil,
+	)
+	req.SetPathValue("id", "test-intersection-id")
+	req = req.WithContext(suite.ctx)
+	w := httptest.NewRecorder()
+
+	suite.handler.GetParameterVersions(w, req)
+
+	suite.Equal(http.StatusBadRequest, w.Code)
+	suite.Contains(w.Body.String(), "Invalid page size")
+	suite.service.AssertNotCalled(suite.T(), "GetParameterVersions")
+}
+
+func (suite *TestSuite) TestGetParameterVersions_MissingUserID() {
+	req := httptest.NewRequest(
+		http.MethodGet,
+		"/intersections/test-intersection-id/parameter-versions",
+		nil,
+	)
+	req.SetPathValue("id", "test-intersection-id")
+	w := httptest.NewRecorder()
+
+	suite.handler.GetParameterVersions(w, req)
+
+	suite.Equal(http.StatusInternalServerError, w.Code)
+	suite.service.AssertNotCalled(suite.T(), "GetParameterVersions")
+}
+
+func (suite *TestSuite) TestRevertParameterVersion_Success() {
+	expected := model.Intersection{ID: "test-intersection-id", ParameterVersion: 4}
+	suite.service.On("RevertParameterVersion", mock.Anything, "test-user-id", "test-intersection-id", 2, 0).
+		Return(expected, nil)
+
+	req := httptest.NewRequest(
+		http.MethodPost,
+		"/intersections/test-intersection-id/parameter-versions/2/revert",
+		nil,
+	)
+	req.SetPathValue("id", "test-intersection-id")
+	req.SetPathValue("v", "2")
+	req = req.WithContext(suite.ctx)
+	w := httptest.NewRecorder()
+
+	suite.handler.RevertParameterVersion(w, req)
+
+	suite.Equal(http.StatusOK, w.Code)
+
+	var resp model.Intersection
+	err := json.Unmarshal(w.Body.Bytes(), &resp)
+	suite.Require().NoError(err)
+	suite.Equal(4, resp.ParameterVersion)
+
+	suite.service.AssertExpectations(suite.T())
+}
+
+func (suite *TestSuite) TestRevertParameterVersion_IfMatch() {
+	suite.service.On("RevertParameterVersion", mock.Anything, "test-user-id", "test-intersection-id", 2, 4).
+		Return(model.Intersection{ID: "test-intersection-id", Version: 5}, nil)
+
+	req := httptest.NewRequest(
+		http.MethodPost,
+		"/intersections/test-intersection-id/parameter-versions/2/revert",
+		nil,
+	)
+	req.Header.Set("If-Match", `"4"`)
+	req.SetPathValue("id", "test-intersection-id")
+	req.SetPathValue("v", "2")
+	req = req.WithContext(suite.ctx)
+	w := httptest.NewRecorder()
+
+	suite.handler.RevertParameterVersion(w, req)
+
+	suite.Equal(http.StatusOK, w.Code)
+	suite.Equal(`"5"`, w.Header().Get("ETag"))
+	suite.service.AssertExpectations(suite.T())
+}
+
+func (suite *TestSuite) TestRevertParameterVersion_IfMatchUnknownTag() {
+	req := httptest.NewRequest(
+		http.MethodPost,
+		"/intersections/test-intersection-id/parameter-versions/2/revert",
+		nil,
+	)
+	req.Header.Set("If-Match", `"abc"`)
+	req.SetPathValue("id", "test-intersection-id")
+	req.SetPathValue("v", "2")
+	req = req.WithContext(suite.ctx)
+	w := httptest.NewRecorder()
+
+	suite.handler.RevertParameterVersion(w, req)
+
+	suite.Equal(http.StatusPreconditionFailed, w.Code)
+	suite.service.AssertNotCalled(suite.T(), "RevertParameterVersion")
+}
+
+func (suite *TestSuite) TestRevertParameterVersion_InvalidVersion() {
+	for _, version := range []string{"abc", "0", "-1"} {
+		req := httptest.NewRequest(
+			http.MethodPost,
+			"/intersections/test-intersection-id/parameter-versions/"+version+"/revert",
+			nil,
+		)
+		req.SetPathValue("id", "test-intersection-id")
+		req.SetPathValue("v", version)
+		req = req.WithContext(suite.ctx)
+		w := httptest.NewRecorder()
+
+		suite.handler.RevertParameterVersion(w, req)
+
+		suite.Equal(http.StatusBadRequest, w.Code, version)
+		suite.Contains(w.Body.String(), "Invalid parameter version")
+	}
+	suite.service.AssertNotCalled(suite.T(), "RevertParameterVersion")
+}
+
+func (suite *TestSuite) TestRevertParameterVersion_VersionNotFound() {
+	suite.service.On("RevertParameterVersion", mock.Anything, "test-user-id", "test-intersection-id", 7, 0).
+		Return(model.Intersection{}, errs.NewNotFoundError(
+			"parameter version not found for intersection",
+			map[string]any{},
+		))
+
+	req := httptest.NewRequest(
+		http.MethodPost,
+		"/intersections/test-intersection-id/parameter-versions/7/revert",
+		nil,
+	)
+	req.SetPathValue("id", "test-intersection-id")
+	req.SetPathValue("v", "7")
+	req = req.WithContext(suite.ctx)
+	w := httptest.NewRecorder()
+
+	suite.handler.RevertParameterVersion(w, req)
+
+	suite.Equal(http.StatusNotFound, w.Code)
+	suite.Contains(w.Body.String(), "parameter version not found")
+}
+
+func (suite *TestSuite) TestRevertParameterVersion_WhileOptimising() {
+	suite.service.On("RevertParameterVersion", mock.Anything, "test-user-id", "test-intersection-id", 2, 0).
+		Return(model.Intersection{}, errs.NewConflictError(
+			"parameters cannot be reverted while the intersection is being optimised",
+			map[string]any{},
+		))
+
+	req := httptest.NewRequest(
+		http.MethodPost,
+		"/intersections/test-intersection-id/parameter-versions/2/revert",
+		nil,
+	)
+	req.SetPathValue("id", "test-intersection-id")
+	req.SetPathValue("v", "2")
+	req = req.WithContext(suite.ctx)
+	w := httptest.NewRecorder()
+
+	suite.handler.RevertParameterVersion(w, req)
+
+	suite.Equal(http.StatusConflict, w.Code)
+}
@@ -0,0 +1,115 @@
+package intersection
+
+import (
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"time"
+
+	"github.com/COS301-SE-2025/Swift-Signals/api-gateway/internal/model"
+	errs "github.com/COS301-SE-2025/Swift-Signals/shared/error"
+	"github.com/stretchr/testify/mock"
+)
+
+func (suite *TestSuite) TestGetDeletedIntersections_Success() {
+	deletedAt := time.Now().UTC().Truncate(time.Second)
+	expected := model.Intersections{
+		Intersections: []model.Intersection{
+			{ID: "test-intersection-id", Name: "Trashed Intersection", DeletedAt: &deletedAt},
+		},
+	}
+	suite.service.On("GetDeletedIntersections", mock.Anything, "test-user-id").
+		Return(expected, nil)
+
+	req := httptest.NewRequest(http.MethodGet, "/intersections/trash", nil)
+	req = req.WithContext(suite.ctx)
+	w := httptest.NewRecorder()
+
+	suite.handler.GetDeletedIntersections(w, req)
+
+	suite.Equal(http.StatusOK, w.Code)
+
+	var resp model.Intersections
+	err := json.Unmarshal(w.Body.Bytes(), &resp)
+	suite.Require().NoError(err)
+	suite.Require().Len(resp.Intersections, 1)
+	suite.Require().NotNil(resp.Intersections[0].DeletedAt)
+	suite.True(deletedAt.Equal(*resp.Intersections[0].DeletedAt))
+
+	suite.service.AssertExpectations(suite.T())
+}
+
+func (suite *TestSuite) TestGetDeletedIntersections_MissingUserID() {
+	req := httptest.NewRequest(http.MethodGet, "/intersections/trash", nil)
+	w := httptest.NewRecorder()
+
+	suite.handler.GetDeletedIntersections(w, req)
+
+	suite.Equal(http.StatusInternalServerError, w.Code)
+	suite.service.AssertNotCalled(suite.T(), "GetDeletedIntersections")
+}
+
+func (suite *TestSuite) TestRestoreIntersection_Success() {
+	suite.service.On("RestoreIntersectionByID", mock.Anything, "test-user-id", "test-intersection-id").
+		Return(model.Intersection{ID: "test-intersection-id", Version: 7}, nil)
+
+	req := httptest.NewRequest(
+		http.MethodPost,
+		"/intersections/test-intersection-id/restore",
+		nil,
+	)
+	req.SetPathValue("id", "test-intersection-id")
+	req = req.WithContext(suite.ctx)
+	w := httptest.NewRecorder()
+
+	suite.handler.RestoreIntersection(w, req)
+
+	suite.Equal(http.StatusOK, w.Code)
+	suite.Equal(`"7"`, w.Header().Get("ETag"))
+	suite.NotContains(w.Body.String(), "deleted_at")
+
+	suite.service.AssertExpectations(suite.T())
+}
+
+func (suite *TestSuite) TestRestoreIntersection_NotInTrash() {
+	suite.service.On("RestoreIntersectionByID", mock.Anything, "test-user-id", "test-intersection-id").
+		Return(model.Intersection{}, errs.NewNotFoundError(
+			"intersection ID not found in trash",
+			map[string]any{},
+		))
+
+	req := httptest.NewRequest(
+		http.MethodPost,
+		"/intersections/test-intersection-id/restore",
+		nil,
+	)
+	req.SetPathValue("id", "test-intersection-id")
+	req = req.WithContext(suite.ctx)
+	w := httptest.NewRecorder()
+
+	suite.handler.RestoreIntersection(w, req)
+
+	suite.Equal(http.StatusNotFound, w.Code)
+	suite.Contains(w.Body.String(), "not found in trash")
+}
+
+func (suite *TestSuite) TestRestoreIntersection_Forbidden() {
+	suite.service.On("RestoreIntersectionByID", mock.Anything, "test-user-id", "test-intersection-id").
+		Return(model.Intersection{}, errs.NewForbiddenError(
+			"intersection not in user's intersection list",
+			map[string]any{},
+		))
+
+	req := httptest.NewRequest(
+		http.MethodPost,
+		"/intersections/test-intersection-id/restore",
+		nil,
+	)
+	req.SetPathValue("id", "test-intersection-id")
+	req = req.WithContext(suite.ctx)
+	w := httptest.NewRecorder()
+
+	suite.handler.RestoreIntersection(w, req)
+
+	suite.Equal(http.StatusForbidden, w.Code)
+}
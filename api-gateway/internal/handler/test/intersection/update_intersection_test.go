@@ -35,7 +35,7 @@ func (suite *TestSuite) TestUpdateIntersection_Success() {
 		},
 	}
 
-	suite.service.On("UpdateIntersectionByID", mock.Anything, "test-user-id", "test-intersection-id", expectedRequest).
+	suite.service.On("UpdateIntersectionByID", mock.Anything, "test-user-id", "test-intersection-id", expectedRequest, 0).
 		Return(expectedResponse, nil)
 
 	body, _ := json.Marshal(expectedRequest)
@@ -119,7 +119,7 @@ func (suite *TestSuite) TestUpdateIntersection_NotFound() {
 	requestBody.Details.Province = "Western Cape"
 
 	emptyResponse := model.Intersection{}
-	suite.service.On("UpdateIntersectionByID", mock.Anything, "test-user-id", "nonexistent-id", requestBody).
+	suite.service.On("UpdateIntersectionByID", mock.Anything, "test-user-id", "nonexistent-id", requestBody, 0).
 		Return(emptyResponse, errs.NewNotFoundError("intersection not found", map[string]any{}))
 
 	body, _ := json.Marshal(requestBody)
@@ -150,7 +150,7 @@ func (suite *TestSuite) TestUpdateIntersection_Forbidden() {
 	requestBody.Details.Province = "Western Cape"
 
 	emptyResponse := model.Intersection{}
-	suite.service.On("UpdateIntersectionByID", mock.Anything, "test-user-id", "forbidden-id", requestBody).
+	suite.service.On("UpdateIntersectionByID", mock.Anything, "test-user-id", "forbidden-id", requestBody, 0).
 		Return(emptyResponse, errs.NewForbiddenError("intersection not in user's intersection list", map[string]any{}))
 
 	body, _ := json.Marshal(requestBody)
@@ -181,7 +181,7 @@ func (suite *TestSuite) TestUpdateIntersection_ValidationError() {
 	requestBody.Details.Province = "Western Cape"
 
 	emptyResponse := model.Intersection{}
-	suite.service.On("UpdateIntersectionByID", mock.Anything, "test-user-id", "test-intersection-id", requestBody).
+	suite.service.On("UpdateIntersectionByID", mock.Anything, "test-user-id", "test-intersection-id", requestBody, 0).
 		Return(emptyResponse, errs.NewValidationError("intersection name too long", map[string]any{}))
 
 	body, _ := json.Marshal(requestBody)
@@ -212,7 +212,7 @@ func (suite *TestSuite) TestUpdateIntersection_InternalError() {
 	requestBody.Details.Province = "Western Cape"
 
 	emptyResponse := model.Intersection{}
-	suite.service.On("UpdateIntersectionByID", mock.Anything, "test-user-id", "test-intersection-id", requestBody).
+	suite.service.On("UpdateIntersectionByID", mock.Anything, "test-user-id", "test-intersection-id", requestBody, 0).
 		Return(emptyResponse, errs.NewInternalError("database connection failed", nil, map[string]any{}))
 
 	body, _ := json.Marshal(requestBody)
@@ -288,7 +288,7 @@ func (suite *TestSuite) TestUpdateIntersection_PartialUpdate_NameOnly() {
 		},
 	}
 
-	suite.service.On("UpdateIntersectionByID", mock.Anything, "test-user-id", "test-intersection-id", requestBody).
+	suite.service.On("UpdateIntersectionByID", mock.Anything, "test-user-id", "test-intersection-id", requestBody, 0).
 		Return(expectedResponse, nil)
 
 	body, _ := json.Marshal(requestBody)
@@ -336,7 +336,7 @@ func (suite *TestSuite) TestUpdateIntersection_PartialUpdate_DetailsOnly() {
 		},
 	}
 
-	suite.service.On("UpdateIntersectionByID", mock.Anything, "test-user-id", "test-intersection-id", requestBody).
+	suite.service.On("UpdateIntersectionByID", mock.Anything, "test-user-id", "test-intersection-id", requestBody, 0).
 		Return(expectedResponse, nil)
 
 	body, _ := json.Marshal(requestBody)
@@ -371,7 +371,7 @@ func (suite *TestSuite) TestUpdateIntersection_UnauthorizedError() {
 	}
 
 	emptyResponse := model.Intersection{}
-	suite.service.On("UpdateIntersectionByID", mock.Anything, "test-user-id", "test-intersection-id", requestBody).
+	suite.service.On("UpdateIntersectionByID", mock.Anything, "test-user-id", "test-intersection-id", requestBody, 0).
 		Return(emptyResponse, errs.NewUnauthorizedError("token expired", map[string]any{}))
 
 	body, _ := json.Marshal(requestBody)
@@ -392,3 +392,197 @@ func (suite *TestSuite) TestUpdateIntersection_UnauthorizedError() {
 
 	suite.service.AssertExpectations(suite.T())
 }
+
+func (suite *TestSuite) TestUpdateIntersection_PartialUpdate_Defaults() {
+	requestBody := model.UpdateIntersectionRequest{
+		TrafficDensity: "high",
+		DefaultParameters: &model.SimulationParameters{
+			IntersectionType: "t-junction",
+			Green:            15,
+			Yellow:           3,
+			Red:              8,
+			Speed:            60,
+			Seed:             42,
+		},
+	}
+
+	expectedResponse := model.Intersection{
+		ID:                  "test-intersection-id",
+		Name:                "Original Name",
+		TrafficDensity:      "TRAFFIC_DENSITY_HIGH",
+		BestParametersStale: true,
+	}
+
+	suite.service.On("UpdateIntersectionByID", mock.Anything, "test-user-id", "test-intersection-id", requestBody, 0).
+		Return(expectedResponse, nil)
+
+	body, _ := json.Marshal(requestBody)
+	req := httptest.NewRequest(
+		http.MethodPatch,
+		"/intersections/test-intersection-id",
+		bytes.NewBuffer(body),
+	)
+	req.Header.Set("Content-Type", "application/json")
+	req.SetPathValue("id", "test-intersection-id")
+	req = req.WithContext(suite.ctx)
+	w := httptest.NewRecorder()
+
+	suite.handler.UpdateIntersection(w, req)
+
+	suite.Equal(http.StatusOK, w.Code)
+
+	var response model.Intersection
+	err := json.Unmarshal(w.Body.Bytes(), &response)
+	suite.Require().NoError(err)
+	suite.True(response.BestParametersStale)
+
+	suite.service.AssertExpectations(suite.T())
+}
+
+func (suite *TestSuite) TestUpdateIntersection_InvalidDefaults() {
+	for name, body := range map[string]string{
+		"unknown density": `{"traffic_density": "gridlock"}`,
+		"zero green": `{"default_parameters": {"intersection_type": "t-junction",
+			"green": 0, "yellow": 3, "red": 8, "speed": 60, "seed": 42}}`,
+		"missing type": `{"default_parameters": {"green": 15, "yellow": 3, "red": 8,
+			"speed": 60, "seed": 42}}`,
+	} {
+		req := httptest.NewRequest(
+			http.MethodPatch,
+			"/intersections/test-intersection-id",
+			bytes.NewBufferString(body),
+		)
+		req.Header.Set("Content-Type", "application/json")
+		req.SetPathValue("id", "test-intersection-id")
+		req = req.WithContext(suite.ctx)
+		w := httptest.NewRecorder()
+
+		suite.handler.UpdateIntersection(w, req)
+
+		suite.Equal(http.StatusBadRequest, w.Code, name)
+	}
+
+	suite.service.AssertNotCalled(suite.T(), "UpdateIntersectionByID")
+}
+
+func (suite *TestSuite) TestUpdateIntersection_DefaultsWhileOptimising() {
+	requestBody := model.UpdateIntersectionRequest{TrafficDensity: "low"}
+
+	suite.service.On("UpdateIntersectionByID", mock.Anything, "test-user-id", "test-intersection-id", requestBody, 0).
+		Return(model.Intersection{}, errs.NewConflictError(
+			"defaults cannot change while the intersection is being optimised",
+			map[string]any{},
+		))
+
+	body, _ := json.Marshal(requestBody)
+	req := httptest.NewRequest(
+		http.MethodPatch,
+		"/intersections/test-intersection-id",
+		bytes.NewBuffer(body),
+	)
+	req.Header.Set("Content-Type", "application/json")
+	req.SetPathValue("id", "test-intersection-id")
+	req = req.WithContext(suite.ctx)
+	w := httptest.NewRecorder()
+
+	suite.handler.UpdateIntersection(w, req)
+
+	suite.Equal(http.StatusConflict, w.Code)
+}
+
+func (suite *TestSuite) TestUpdateIntersection_IfMatch() {
+	requestBody := model.UpdateIntersectionRequest{Name: "Updated Intersection"}
+
+	suite.service.On("UpdateIntersectionByID", mock.Anything, "test-user-id", "test-intersection-id", requestBody, 4).
+		Return(model.Intersection{ID: "test-intersection-id", Name: "Updated Intersection", Version: 5}, nil)
+
+	body, _ := json.Marshal(requestBody)
+	req := httptest.NewRequest(
+		http.MethodPatch,
+		"/intersections/test-intersection-id",
+		bytes.NewBuffer(body),
+	)
+	req.Header.Set("Content-Type", "application/json")
+	req.Header.Set("If-Match", `"4"`)
+	req.SetPathValue("id", "test-intersection-id")
+	req = req.WithContext(suite.ctx)
+	w := httptest.NewRecorder()
+
+	suite.handler.UpdateIntersection(w, req)
+
+	suite.Equal(http.StatusOK, w.Code)
+	suite.Equal(`"5"`, w.Header().Get("ETag"))
+	suite.service.AssertExpectations(suite.T())
+}
+
+func (suite *TestSuite) TestUpdateIntersection_IfMatchAny() {
+	requestBody := model.UpdateIntersectionRequest{Name: "Updated Intersection"}
+
+	suite.service.On("UpdateIntersectionByID", mock.Anything, "test-user-id", "test-intersection-id", requestBody, 0).
+		Return(model.Intersection{ID: "test-intersection-id", Version: 5}, nil)
+
+	body, _ := json.Marshal(requestBody)
+	req := httptest.NewRequest(
+		http.MethodPatch,
+		"/intersections/test-intersection-id",
+		bytes.NewBuffer(body),
+	)
+	req.Header.Set("If-Match", "*")
+	req.SetPathValue("id", "test-intersection-id")
+	req = req.WithContext(suite.ctx)
+	w := httptest.NewRecorder()
+
+	suite.handler.UpdateIntersection(w, req)
+
+	suite.Equal(http.StatusOK, w.Code)
+	suite.service.AssertExpectations(suite.T())
+}
+
+func (suite *TestSuite) TestUpdateIntersection_IfMatchUnknownTag() {
+	requestBody := model.UpdateIntersectionRequest{Name: "Updated Intersection"}
+
+	for _, tag := range []string{`W/"4"`, "4", `"0"`, `"abc"`, `"4", "5"`} {
+		body, _ := json.Marshal(requestBody)
+		req := httptest.NewRequest(
+			http.MethodPatch,
+			"/intersections/test-intersection-id",
+			bytes.NewBuffer(body),
+		)
+		req.Header.Set("If-Match", tag)
+		req.SetPathValue("id", "test-intersection-id")
+		req = req.WithContext(suite.ctx)
+		w := httptest.NewRecorder()
+
+		suite.handler.UpdateIntersection(w, req)
+
+		suite.Equal(http.StatusPreconditionFailed, w.Code, tag)
+	}
+	suite.service.AssertNotCalled(suite.T(), "UpdateIntersectionByID")
+}
+
+func (suite *TestSuite) TestUpdateIntersection_StaleVersion() {
+	requestBody := model.UpdateIntersectionRequest{Name: "Updated Intersection"}
+
+	suite.service.On("UpdateIntersectionByID", mock.Anything, "test-user-id", "test-intersection-id", requestBody, 4).
+		Return(model.Intersection{}, errs.NewPreconditionError(
+			"intersection has been modified since it was read",
+			map[string]any{},
+		))
+
+	body, _ := json.Marshal(requestBody)
+	req := httptest.NewRequest(
+		http.MethodPatch,
+		"/intersections/test-intersection-id",
+		bytes.NewBuffer(body),
+	)
+	req.Header.Set("If-Match", `"4"`)
+	req.SetPathValue("id", "test-intersection-id")
+	req = req.WithContext(suite.ctx)
+	w := httptest.NewRecorder()
+
+	suite.handler.UpdateIntersection(w, req)
+
+	suite.Equal(http.StatusPreconditionFailed, w.Code)
+	suite.Contains(w.Body.String(), "modified since it was read")
+	suite.Empty(w.Header().Get("ETag"))
+}
@@ -0,0 +1,127 @@
+package notification
+
+import (
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+
+	"github.com/COS301-SE-2025/Swift-Signals/api-gateway/internal/model"
+	errs "github.com/COS301-SE-2025/Swift-Signals/shared/error"
+	"github.com/stretchr/testify/mock"
+)
+
+func (suite *TestSuite) TestGetNotifications_DefaultLimit() {
+	expected := model.Notifications{
+		Notifications: []model.Notification{{
+			ID:             "notification-1",
+			Type:           model.NotificationTypeOptimisationCompleted,
+			Message:        "Optimisation of Main & 5th finished: waiting time −18%",
+			IntersectionID: "int-1",
+		}},
+		UnreadCount: 1,
+	}
+	suite.service.On("GetNotifications", mock.Anything, "test-user-id", 50).
+		Return(expected, nil)
+
+	req := httptest.NewRequest(http.MethodGet, "/notifications", nil)
+	req = req.WithContext(suite.ctx)
+	w := httptest.NewRecorder()
+
+	suite.handler.GetNotifications(w, req)
+
+	suite.Equal(http.StatusOK, w.Code)
+	var actual model.Notifications
+	suite.Require().NoError(json.Unmarshal(w.Body.Bytes(), &actual))
+	suite.Equal(expected.UnreadCount, actual.UnreadCount)
+	suite.Equal(expected.Notifications[0].Message, actual.Notifications[0].Message)
+	suite.NotContains(w.Body.String(), "test-user-id")
+}
+
+func (suite *TestSuite) TestGetNotifications_Limit() {
+	suite.service.On("GetNotifications", mock.Anything, "test-user-id", 5).
+		Return(model.Notifications{Notifications: []model.Notification{}}, nil)
+
+	req := httptest.NewRequest(http.MethodGet, "/notifications?limit=5", nil)
+	req = req.WithContext(suite.ctx)
+	w := httptest.NewRecorder()
+
+	suite.handler.GetNotifications(w, req)
+
+	suite.Equal(http.StatusOK, w.Code)
+	suite.service.AssertExpectations(suite.T())
+}
+
+func (suite *TestSuite) TestGetNotifications_InvalidLimit() {
+	for _, limit := range []string{"0", "101", "abc"} {
+		req := httptest.NewRequest(http.MethodGet, "/notifications?limit="+limit, nil)
+		req = req.WithContext(suite.ctx)
+		w := httptest.NewRecorder()
+
+		suite.handler.GetNotifications(w, req)
+
+		suite.Equal(http.StatusBadRequest, w.Code)
+		suite.Contains(w.Body.String(), "Invalid limit")
+	}
+	suite.service.AssertNotCalled(
+		suite.T(),
+		"GetNotifications",
+		mock.Anything,
+		mock.Anything,
+		mock.Anything,
+	)
+}
+
+func (suite *TestSuite) TestMarkRead_Success() {
+	suite.service.On("MarkRead", mock.Anything, "test-user-id", "notification-1").Return(nil)
+
+	req := httptest.NewRequest(http.MethodPost, "/notifications/notification-1/read", nil)
+	req.SetPathValue("id", "notification-1")
+	req = req.WithContext(suite.ctx)
+	w := httptest.NewRecorder()
+
+	suite.handler.MarkRead(w, req)
+
+	suite.Equal(http.StatusNoContent, w.Code)
+	suite.service.AssertExpectations(suite.T())
+}
+
+func (suite *TestSuite) TestMarkRead_NotFound() {
+	suite.service.On("MarkRead", mock.Anything, "test-user-id", "notification-1").
+		Return(errs.NewNotFoundError("notification not found", map[string]any{}))
+
+	req := httptest.NewRequest(http.MethodPost, "/notifications/notification-1/read", nil)
+	req.SetPathValue("id", "notification-1")
+	req = req.WithContext(suite.ctx)
+	w := httptest.NewRecorder()
+
+	suite.handler.MarkRead(w, req)
+
+	suite.Equal(http.StatusNotFound, w.Code)
+	suite.Contains(w.Body.String(), "notification not found")
+}
+
+func (suite *TestSuite) TestMarkAllRead_Success() {
+	suite.service.On("MarkAllRead", mock.Anything, "test-user-id").
+		Return(model.MarkAllNotificationsReadResponse{Marked: 3}, nil)
+
+	req := httptest.NewRequest(http.MethodPost, "/notifications/read-all", nil)
+	req = req.WithContext(suite.ctx)
+	w := httptest.NewRecorder()
+
+	suite.handler.MarkAllRead(w, req)
+
+	suite.Equal(http.StatusOK, w.Code)
+	var actual model.MarkAllNotificationsReadResponse
+	suite.Require().NoError(json.Unmarshal(w.Body.Bytes(), &actual))
+	suite.Equal(3, actual.Marked)
+}
+
+func (suite *TestSuite) TestMarkAllRead_MissingUserID() {
+	req := httptest.NewRequest(http.MethodPost, "/notifications/read-all", nil)
+	w := httptest.NewRecorder()
+
+	suite.handler.MarkAllRead(w, req)
+
+	suite.Equal(http.StatusInternalServerError, w.Code)
+	suite.service.AssertNotCalled(suite.T(), "MarkAllRead", mock.Anything, mock.Anything)
+}
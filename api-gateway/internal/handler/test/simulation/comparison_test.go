@@ -0,0 +1,141 @@
+package simulation
+
+import (
+	"bytes"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+
+	"github.com/COS301-SE-2025/Swift-Signals/api-gateway/internal/model"
+	errs "github.com/COS301-SE-2025/Swift-Signals/shared/error"
+	"github.com/stretchr/testify/mock"
+)
+
+func (suite *TestSuite) newCompareRequest(method, query string, body []byte) *http.Request {
+	req := httptest.NewRequest(
+		method,
+		"/intersections/test-intersection-id/compare"+query,
+		bytes.NewBuffer(body),
+	)
+	req.SetPathValue("id", "test-intersection-id")
+	return req.WithContext(suite.ctx)
+}
+
+func createTestComparison() model.Comparison {
+	percent := -25.0
+	return model.Comparison{
+		IntersectionID: "test-intersection-id",
+		Baseline:       model.ComparedSimulation{Source: model.ComparisonSourceDefault},
+		Candidate:      model.ComparedSimulation{Source: model.ComparisonSourceOptimised},
+		Metrics: []model.MetricComparison{{
+			Metric:       "average_waiting_time",
+			Direction:    model.MetricDirectionLower,
+			Baseline:     40,
+			Candidate:    30,
+			Delta:        -10,
+			DeltaPercent: &percent,
+			Improved:     true,
+		}},
+	}
+}
+
+func (suite *TestSuite) TestGetComparison_DefaultAndOptimised() {
+	suite.service.On("CompareOptimisation", mock.Anything, "test-intersection-id", 1).
+		Return(createTestComparison(), nil)
+
+	w := httptest.NewRecorder()
+	suite.handler.GetComparison(w, suite.newCompareRequest(http.MethodGet, "", nil))
+
+	suite.Equal(http.StatusOK, w.Code)
+
+	var response model.Comparison
+	suite.Require().NoError(json.Unmarshal(w.Body.Bytes(), &response))
+	suite.Require().Len(response.Metrics, 1)
+	suite.Equal(-10.0, response.Metrics[0].Delta)
+	suite.Require().NotNil(response.Metrics[0].DeltaPercent)
+	suite.Equal(-25.0, *response.Metrics[0].DeltaPercent)
+	suite.True(response.Metrics[0].Improved)
+	suite.service.AssertExpectations(suite.T())
+}
+
+func (suite *TestSuite) TestGetComparison_Runs() {
+	suite.service.On("CompareRuns", mock.Anything, "test-intersection-id", "run-1", "run-2").
+		Return(createTestComparison(), nil)
+
+	w := httptest.NewRecorder()
+	suite.handler.GetComparison(
+		w,
+		suite.newCompareRequest(http.MethodGet, "?baseline_run=run-1&candidate_run=run-2", nil),
+	)
+
+	suite.Equal(http.StatusOK, w.Code)
+	suite.service.AssertExpectations(suite.T())
+	suite.service.AssertNotCalled(suite.T(), "CompareOptimisation",
+		mock.Anything, mock.Anything, mock.Anything)
+}
+
+func (suite *TestSuite) TestGetComparison_OneRun() {
+	w := httptest.NewRecorder()
+	suite.handler.GetComparison(
+		w,
+		suite.newCompareRequest(http.MethodGet, "?baseline_run=run-1", nil),
+	)
+
+	suite.Equal(http.StatusBadRequest, w.Code)
+	suite.service.AssertNotCalled(suite.T(), "CompareRuns",
+		mock.Anything, mock.Anything, mock.Anything, mock.Anything)
+}
+
+func (suite *TestSuite) TestGetComparison_NotOptimised() {
+	suite.service.On("CompareOptimisation", mock.Anything, "test-intersection-id", 1).
+		Return(
+			model.Comparison{},
+			errs.NewNotFoundError("no optimised parameters found for this intersection", nil),
+		)
+
+	w := httptest.NewRecorder()
+	suite.handler.GetComparison(w, suite.newCompareRequest(http.MethodGet, "", nil))
+
+	suite.Equal(http.StatusNotFound, w.Code)
+}
+
+func (suite *TestSuite) TestCompareParameters_Success() {
+	baseline := createWhatIfParameters()
+	candidate := createWhatIfParameters()
+	candidate.Green = 35
+
+	suite.service.On(
+		"CompareParameters",
+		mock.Anything,
+		"test-intersection-id",
+		baseline,
+		candidate,
+		1,
+	).Return(createTestComparison(), nil)
+
+	body, _ := json.Marshal(model.CompareParametersRequest{
+		Baseline:  baseline,
+		Candidate: candidate,
+	})
+	w := httptest.NewRecorder()
+	suite.handler.CompareParameters(w, suite.newCompareRequest(http.MethodPost, "", body))
+
+	suite.Equal(http.StatusOK, w.Code)
+	suite.service.AssertExpectations(suite.T())
+}
+
+func (suite *TestSuite) TestCompareParameters_ValidationError() {
+	candidate := createWhatIfParameters()
+	candidate.Red = 0
+
+	body, _ := json.Marshal(model.CompareParametersRequest{
+		Baseline:  createWhatIfParameters(),
+		Candidate: candidate,
+	})
+	w := httptest.NewRecorder()
+	suite.handler.CompareParameters(w, suite.newCompareRequest(http.MethodPost, "", body))
+
+	suite.Equal(http.StatusBadRequest, w.Code)
+	suite.service.AssertNotCalled(suite.T(), "CompareParameters",
+		mock.Anything, mock.Anything, mock.Anything, mock.Anything, mock.Anything)
+}
@@ -0,0 +1,107 @@
+package simulation
+
+import (
+	"bufio"
+	"context"
+	"log/slog"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"strings"
+
+	"github.com/COS301-SE-2025/Swift-Signals/api-gateway/internal/middleware"
+	"github.com/COS301-SE-2025/Swift-Signals/api-gateway/internal/model"
+	errs "github.com/COS301-SE-2025/Swift-Signals/shared/error"
+	"github.com/stretchr/testify/mock"
+)
+
+func (suite *TestSuite) TestGetOptimisationEvents_StreamsEvents() {
+	events := make(chan model.OptimisationEvent, 2)
+	events <- model.OptimisationEvent{
+		Type: model.OptimisationEventProgress,
+		Data: model.OptimisationProgress{JobID: "job-1", Generation: 2, BestFitness: 1532.7},
+	}
+	unsubscribed := make(chan struct{})
+
+	suite.service.On("SubscribeOptimisationEvents", mock.Anything, "test-intersection-id").
+		Return((<-chan model.OptimisationEvent)(events), func() { close(unsubscribed) }, nil)
+
+	// NOTE: Served through the logging middleware, whose writer has to pass flushes on
+	logger := slog.New(slog.NewTextHandler(os.NewFile(0, os.DevNull), nil))
+	mux := http.NewServeMux()
+	mux.HandleFunc("GET /intersections/{id}/optimise/events", suite.handler.GetOptimisationEvents)
+	server := httptest.NewServer(middleware.Logging(logger)(mux))
+	defer server.Close()
+
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+	req, err := http.NewRequestWithContext(
+		ctx,
+		http.MethodGet,
+		server.URL+"/intersections/test-intersection-id/optimise/events",
+		nil,
+	)
+	suite.Require().NoError(err)
+
+	resp, err := server.Client().Do(req)
+	suite.Require().NoError(err)
+	defer resp.Body.Close()
+
+	suite.Equal(http.StatusOK, resp.StatusCode)
+	suite.Equal("text/event-stream", resp.Header.Get("Content-Type"))
+
+	reader := bufio.NewReader(resp.Body)
+	readEvent := func() []string {
+		lines := []string{}
+		for {
+			line, err := reader.ReadString('\n')
+			suite.Require().NoError(err)
+			line = strings.TrimSuffix(line, "\n")
+			if line == "" {
+				return lines
+			}
+			lines = append(lines, line)
+		}
+	}
+
+	first := readEvent()
+	suite.Require().Len(first, 2)
+	suite.Equal("event: progress", first[0])
+	suite.Contains(first[1], `"generation":2`)
+	suite.Contains(first[1], `"job_id":"job-1"`)
+
+	// Events published after the stream opened arrive without the response ending
+	events <- model.OptimisationEvent{
+		Type: model.OptimisationEventJob,
+		Data: model.OptimisationJob{ID: "job-1", Status: "OPTIMISATION_JOB_STATUS_SUCCEEDED"},
+	}
+	second := readEvent()
+	suite.Require().Len(second, 2)
+	suite.Equal("event: job", second[0])
+	suite.Contains(second[1], `"status":"OPTIMISATION_JOB_STATUS_SUCCEEDED"`)
+
+	cancel()
+	<-unsubscribed
+}
+
+func (suite *TestSuite) TestGetOptimisationEvents_Forbidden() {
+	suite.service.On("SubscribeOptimisationEvents", mock.Anything, "test-intersection-id").
+		Return(nil, nil, errs.NewForbiddenError(
+			"you do not have access to this intersection",
+			map[string]any{},
+		))
+
+	req := httptest.NewRequest(
+		http.MethodGet,
+		"/intersections/test-intersection-id/optimise/events",
+		nil,
+	)
+	req.SetPathValue("id", "test-intersection-id")
+	req = req.WithContext(suite.ctx)
+	w := httptest.NewRecorder()
+
+	suite.handler.GetOptimisationEvents(w, req)
+
+	suite.Equal(http.StatusForbidden, w.Code)
+	suite.Equal("application/json", w.Header().Get("Content-Type"))
+}
@@ -0,0 +1,128 @@
+package simulation
+
+import (
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+
+	"github.com/COS301-SE-2025/Swift-Signals/api-gateway/internal/model"
+	errs "github.com/COS301-SE-2025/Swift-Signals/shared/error"
+	"github.com/stretchr/testify/mock"
+)
+
+func (suite *TestSuite) TestGetOptimisationJob_Success() {
+	expectedJob := model.OptimisationJob{
+		ID:             "job-1",
+		IntersectionID: "test-intersection-id",
+		Status:         "OPTIMISATION_JOB_STATUS_SUCCEEDED",
+		Improved:       true,
+	}
+
+	suite.service.On("GetOptimisationJob", mock.Anything, "job-1").Return(expectedJob, nil)
+
+	req := httptest.NewRequest(http.MethodGet, "/optimisation-jobs/job-1", nil)
+	req.SetPathValue("id", "job-1")
+	req = req.WithContext(suite.ctx)
+	w := httptest.NewRecorder()
+
+	suite.handler.GetOptimisationJob(w, req)
+
+	suite.Equal(http.StatusOK, w.Code)
+
+	var response model.OptimisationJob
+	err := json.Unmarshal(w.Body.Bytes(), &response)
+	suite.Require().NoError(err)
+	suite.Equal(expectedJob.Status, response.Status)
+	suite.True(response.Improved)
+
+	suite.service.AssertExpectations(suite.T())
+}
+
+func (suite *TestSuite) TestGetOptimisationJob_NotFound() {
+	suite.service.On("GetOptimisationJob", mock.Anything, "missing-job").
+		Return(model.OptimisationJob{}, errs.NewNotFoundError(
+			"optimisation job ID not found in collection",
+			map[string]any{},
+		))
+
+	req := httptest.NewRequest(http.MethodGet, "/optimisation-jobs/missing-job", nil)
+	req.SetPathValue("id", "missing-job")
+	req = req.WithContext(suite.ctx)
+	w := httptest.NewRecorder()
+
+	suite.handler.GetOptimisationJob(w, req)
+
+	suite.Equal(http.StatusNotFound, w.Code)
+}
+
+func (suite *TestSuite) TestGetOptimisationJobs_Success() {
+	expectedJobs := model.OptimisationJobs{Jobs: []model.OptimisationJob{
+		{ID: "job-2", IntersectionID: "test-intersection-id", Status: "OPTIMISATION_JOB_STATUS_RUNNING"},
+		{ID: "job-1", IntersectionID: "test-intersection-id", Status: "OPTIMISATION_JOB_STATUS_FAILED"},
+	}}
+
+	suite.service.On("GetOptimisationJobs", mock.Anything, "test-intersection-id").
+		Return(expectedJobs, nil)
+
+	req := httptest.NewRequest(
+		http.MethodGet,
+		"/intersections/test-intersection-id/optimisation-jobs",
+		nil,
+	)
+	req.SetPathValue("id", "test-intersection-id")
+	req = req.WithContext(suite.ctx)
+	w := httptest.NewRecorder()
+
+	suite.handler.GetOptimisationJobs(w, req)
+
+	suite.Equal(http.StatusOK, w.Code)
+
+	var response model.OptimisationJobs
+	err := json.Unmarshal(w.Body.Bytes(), &response)
+	suite.Require().NoError(err)
+	suite.Len(response.Jobs, 2)
+	suite.Equal("job-2", response.Jobs[0].ID)
+}
+
+func (suite *TestSuite) TestCancelOptimisationJob_Success() {
+	expectedJob := model.OptimisationJob{
+		ID:             "job-1",
+		IntersectionID: "test-intersection-id",
+		Status:         "OPTIMISATION_JOB_STATUS_CANCELLED",
+	}
+
+	suite.service.On("CancelOptimisationJob", mock.Anything, "job-1").Return(expectedJob, nil)
+
+	req := httptest.NewRequest(http.MethodDelete, "/optimisation-jobs/job-1", nil)
+	req.SetPathValue("id", "job-1")
+	req = req.WithContext(suite.ctx)
+	w := httptest.NewRecorder()
+
+	suite.handler.CancelOptimisationJob(w, req)
+
+	suite.Equal(http.StatusOK, w.Code)
+
+	var response model.OptimisationJob
+	err := json.Unmarshal(w.Body.Bytes(), &response)
+	suite.Require().NoError(err)
+	suite.Equal("OPTIMISATION_JOB_STATUS_CANCELLED", response.Status)
+
+	suite.service.AssertExpectations(suite.T())
+}
+
+func (suite *TestSuite) TestCancelOptimisationJob_Forbidden() {
+	suite.service.On("CancelOptimisationJob", mock.Anything, "job-1").
+		Return(model.OptimisationJob{}, errs.NewForbiddenError(
+			"you do not have access to this optimisation job",
+			map[string]any{},
+		))
+
+	req := httptest.NewRequest(http.MethodDelete, "/optimisation-jobs/job-1", nil)
+	req.SetPathValue("id", "job-1")
+	req = req.WithContext(suite.ctx)
+	w := httptest.NewRecorder()
+
+	suite.handler.CancelOptimisationJob(w, req)
+
+	suite.Equal(http.StatusForbidden, w.Code)
+}
@@ -0,0 +1,139 @@
+package simulation
+
+import (
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+
+	"github.com/COS301-SE-2025/Swift-Signals/api-gateway/internal/model"
+	errs "github.com/COS301-SE-2025/Swift-Signals/shared/error"
+	"github.com/stretchr/testify/mock"
+)
+
+func (suite *TestSuite) newReplicationsRequest(kind, query string) *http.Request {
+	req := httptest.NewRequest(
+		http.MethodGet,
+		"/intersections/test-intersection-id/"+kind+"/replications"+query,
+		nil,
+	)
+	req.SetPathValue("id", "test-intersection-id")
+	return req.WithContext(suite.ctx)
+}
+
+func createTestReplicatedResults(replications int) model.ReplicatedResults {
+	seeds := make([]int, replications)
+	for i := range seeds {
+		seeds[i] = 12345 + i
+	}
+	return model.ReplicatedResults{
+		Replications: replications,
+		Seeds:        seeds,
+		Metrics: map[string]model.MetricSummary{
+			model.MetricAverageWaitingTime: {
+				Mean:    20,
+				StdDev:  10,
+				Min:     10,
+				Max:     30,
+				CILower: 12.85,
+				CIUpper: 27.15,
+			},
+		},
+	}
+}
+
+func (suite *TestSuite) TestGetSimulationReplications_DefaultReplications() {
+	suite.service.On("ReplicateSimulation", mock.Anything, "test-intersection-id", 10).
+		Return(createTestReplicatedResults(10), nil)
+
+	w := httptest.NewRecorder()
+	suite.handler.GetSimulationReplications(w, suite.newReplicationsRequest("simulate", ""))
+
+	suite.Equal(http.StatusOK, w.Code)
+
+	var response model.ReplicatedResults
+	suite.Require().NoError(json.Unmarshal(w.Body.Bytes(), &response))
+	suite.Equal(10, response.Replications)
+	suite.Len(response.Seeds, 10)
+	suite.Equal(20.0, response.Metrics[model.MetricAverageWaitingTime].Mean)
+	suite.Equal(12.85, response.Metrics[model.MetricAverageWaitingTime].CILower)
+	suite.service.AssertExpectations(suite.T())
+}
+
+func (suite *TestSuite) TestGetOptimisedReplications_Success() {
+	suite.service.On("ReplicateOptimised", mock.Anything, "test-intersection-id", 5).
+		Return(createTestReplicatedResults(5), nil)
+
+	w := httptest.NewRecorder()
+	suite.handler.GetOptimisedReplications(
+		w,
+		suite.newReplicationsRequest("optimise", "?replications=5"),
+	)
+
+	suite.Equal(http.StatusOK, w.Code)
+	suite.service.AssertExpectations(suite.T())
+	suite.service.AssertNotCalled(suite.T(), "ReplicateSimulation",
+		mock.Anything, mock.Anything, mock.Anything)
+}
+
+func (suite *TestSuite) TestGetSimulationReplications_InvalidReplications() {
+	for _, query := range []string{"?replications=0", "?replications=-3", "?replications=many"} {
+		w := httptest.NewRecorder()
+		suite.handler.GetSimulationReplications(w, suite.newReplicationsRequest("simulate", query))
+
+		suite.Equal(http.StatusBadRequest, w.Code, query)
+	}
+	suite.service.AssertNotCalled(suite.T(), "ReplicateSimulation",
+		mock.Anything, mock.Anything, mock.Anything)
+}
+
+func (suite *TestSuite) TestGetSimulationReplications_TooManyReplications() {
+	suite.service.On("ReplicateSimulation", mock.Anything, "test-intersection-id", 100).
+		Return(
+			model.ReplicatedResults{},
+			errs.NewValidationError("replications must be between 2 and the maximum", nil),
+		)
+
+	w := httptest.NewRecorder()
+	suite.handler.GetSimulationReplications(
+		w,
+		suite.newReplicationsRequest("simulate", "?replications=100"),
+	)
+
+	suite.Equal(http.StatusBadRequest, w.Code)
+}
+
+func (suite *TestSuite) TestGetComparison_Replicated() {
+	comparison := createTestComparison()
+	replicated := createTestReplicatedResults(5)
+	comparison.Baseline.Replications = &replicated
+	comparison.Candidate.Replications = &replicated
+	suite.service.On("CompareOptimisation", mock.Anything, "test-intersection-id", 5).
+		Return(comparison, nil)
+
+	w := httptest.NewRecorder()
+	suite.handler.GetComparison(w, suite.newCompareRequest(http.MethodGet, "?replications=5", nil))
+
+	suite.Equal(http.StatusOK, w.Code)
+
+	var response model.Comparison
+	suite.Require().NoError(json.Unmarshal(w.Body.Bytes(), &response))
+	suite.Require().NotNil(response.Baseline.Replications)
+	suite.Equal(5, response.Baseline.Replications.Replications)
+	suite.service.AssertExpectations(suite.T())
+}
+
+func (suite *TestSuite) TestGetComparison_ReplicatedRuns() {
+	w := httptest.NewRecorder()
+	suite.handler.GetComparison(
+		w,
+		suite.newCompareRequest(
+			http.MethodGet,
+			"?baseline_run=run-1&candidate_run=run-2&replications=5",
+			nil,
+		),
+	)
+
+	suite.Equal(http.StatusBadRequest, w.Code)
+	suite.service.AssertNotCalled(suite.T(), "CompareRuns",
+		mock.Anything, mock.Anything, mock.Anything, mock.Anything)
+}
@@ -0,0 +1,78 @@
+package simulation
+
+import (
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"time"
+
+	"github.com/COS301-SE-2025/Swift-Signals/api-gateway/internal/model"
+	errs "github.com/COS301-SE-2025/Swift-Signals/shared/error"
+	"github.com/stretchr/testify/mock"
+)
+
+func (suite *TestSuite) TestRunOptimisation_Accepted() {
+	expectedJob := model.OptimisationJob{
+		ID:             "job-1",
+		IntersectionID: "test-intersection-id",
+		Status:         "OPTIMISATION_JOB_STATUS_PENDING",
+		CreatedAt:      time.Now().UTC(),
+	}
+
+	suite.service.On("OptimiseIntersection", mock.Anything, "test-intersection-id").
+		Return(expectedJob, nil)
+
+	req := httptest.NewRequest(http.MethodPost, "/intersections/test-intersection-id/optimise", nil)
+	req.SetPathValue("id", "test-intersection-id")
+	req = req.WithContext(suite.ctx)
+	w := httptest.NewRecorder()
+
+	suite.handler.RunOptimisation(w, req)
+
+	suite.Equal(http.StatusAccepted, w.Code)
+	suite.Equal("/optimisation-jobs/job-1", w.Header().Get("Location"))
+
+	var response model.OptimisationJob
+	err := json.Unmarshal(w.Body.Bytes(), &response)
+	suite.Require().NoError(err)
+	suite.Equal(expectedJob.ID, response.ID)
+	suite.Equal(expectedJob.Status, response.Status)
+	suite.Nil(response.StartedAt)
+
+	suite.service.AssertExpectations(suite.T())
+}
+
+func (suite *TestSuite) TestRunOptimisation_JobAlreadyActive() {
+	suite.service.On("OptimiseIntersection", mock.Anything, "test-intersection-id").
+		Return(model.OptimisationJob{}, errs.NewAlreadyExistsError(
+			"an optimisation job is already active for this intersection",
+			map[string]any{},
+		))
+
+	req := httptest.NewRequest(http.MethodPost, "/intersections/test-intersection-id/optimise", nil)
+	req.SetPathValue("id", "test-intersection-id")
+	req = req.WithContext(suite.ctx)
+	w := httptest.NewRecorder()
+
+	suite.handler.RunOptimisation(w, req)
+
+	suite.Equal(http.StatusConflict, w.Code)
+	suite.Contains(w.Body.String(), "already active")
+}
+
+func (suite *TestSuite) TestRunOptimisation_Forbidden() {
+	suite.service.On("OptimiseIntersection", mock.Anything, "forbidden-id").
+		Return(model.OptimisationJob{}, errs.NewForbiddenError(
+			"you do not have access to this intersection",
+			map[string]any{},
+		))
+
+	req := httptest.NewRequest(http.MethodPost, "/intersections/forbidden-id/optimise", nil)
+	req.SetPathValue("id", "forbidden-id")
+	req = req.WithContext(suite.ctx)
+	w := httptest.NewRecorder()
+
+	suite.handler.RunOptimisation(w, req)
+
+	suite.Equal(http.StatusForbidden, w.Code)
+}
@@ -0,0 +1,132 @@
+package simulation
+
+import (
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+
+	"github.com/COS301-SE-2025/Swift-Signals/api-gateway/internal/model"
+	errs "github.com/COS301-SE-2025/Swift-Signals/shared/error"
+	"github.com/stretchr/testify/mock"
+)
+
+func (suite *TestSuite) TestGetRuns_Success() {
+	expectedRuns := model.Runs{
+		Runs: []model.Run{
+			{ID: "run-2", IntersectionID: "test-intersection-id", Type: model.RunTypeSimulation},
+			{ID: "run-1", IntersectionID: "test-intersection-id", Type: model.RunTypeOptimisation},
+		},
+		Page:     2,
+		PageSize: 5,
+	}
+
+	suite.service.On("GetRuns", mock.Anything, "test-intersection-id", 2, 5).
+		Return(expectedRuns, nil)
+
+	req := httptest.NewRequest(
+		http.MethodGet,
+		"/intersections/test-intersection-id/runs?page=2&page_size=5",
+		nil,
+	)
+	req.SetPathValue("id", "test-intersection-id")
+	req = req.WithContext(suite.ctx)
+	w := httptest.NewRecorder()
+
+	suite.handler.GetRuns(w, req)
+
+	suite.Equal(http.StatusOK, w.Code)
+
+	var response model.Runs
+	err := json.Unmarshal(w.Body.Bytes(), &response)
+	suite.Require().NoError(err)
+	suite.Require().Len(response.Runs, 2)
+	suite.Equal("run-2", response.Runs[0].ID)
+	suite.Equal(2, response.Page)
+
+	suite.service.AssertExpectations(suite.T())
+}
+
+func (suite *TestSuite) TestGetRuns_DefaultPagination() {
+	suite.service.On("GetRuns", mock.Anything, "test-intersection-id", 1, 20).
+		Return(model.Runs{Runs: []model.Run{}, Page: 1, PageSize: 20}, nil)
+
+	req := httptest.NewRequest(http.MethodGet, "/intersections/test-intersection-id/runs", nil)
+	req.SetPathValue("id", "test-intersection-id")
+	req = req.WithContext(suite.ctx)
+	w := httptest.NewRecorder()
+
+	suite.handler.GetRuns(w, req)
+
+	suite.Equal(http.StatusOK, w.Code)
+	suite.service.AssertExpectations(suite.T())
+}
+
+func (suite *TestSuite) TestGetRuns_InvalidPagination() {
+	for _, query := range []string{"page=0", "page=abc", "page_size=0", "page_size=101"} {
+		req := httptest.NewRequest(
+			http.MethodGet,
+			"/intersections/test-intersection-id/runs?"+query,
+			nil,
+		)
+		req.SetPathValue("id", "test-intersection-id")
+		req = req.WithContext(suite.ctx)
+		w := httptest.NewRecorder()
+
+		suite.handler.GetRuns(w, req)
+
+		suite.Equal(http.StatusBadRequest, w.Code, query)
+	}
+
+	suite.service.AssertNotCalled(suite.T(), "GetRuns",
+		mock.Anything, mock.Anything, mock.Anything, mock.Anything)
+}
+
+func (suite *TestSuite) TestGetRun_Success() {
+	expectedRun := model.Run{
+		ID:             "run-1",
+		IntersectionID: "test-intersection-id",
+		Type:           model.RunTypeOptimisation,
+		Outcome:        model.RunOutcomeSucceeded,
+		DurationMs:     5400000,
+	}
+
+	suite.service.On("GetRun", mock.Anything, "test-intersection-id", "run-1").
+		Return(expectedRun, nil)
+
+	req := httptest.NewRequest(http.MethodGet, "/intersections/test-intersection-id/runs/run-1", nil)
+	req.SetPathValue("id", "test-intersection-id")
+	req.SetPathValue("runId", "run-1")
+	req = req.WithContext(suite.ctx)
+	w := httptest.NewRecorder()
+
+	suite.handler.GetRun(w, req)
+
+	suite.Equal(http.StatusOK, w.Code)
+
+	var response model.Run
+	err := json.Unmarshal(w.Body.Bytes(), &response)
+	suite.Require().NoError(err)
+	suite.Equal(expectedRun.Outcome, response.Outcome)
+	suite.Equal(expectedRun.DurationMs, response.DurationMs)
+
+	suite.service.AssertExpectations(suite.T())
+}
+
+func (suite *TestSuite) TestGetRun_NotFound() {
+	suite.service.On("GetRun", mock.Anything, "test-intersection-id", "missing-run").
+		Return(model.Run{}, errs.NewNotFoundError("run not found", map[string]any{}))
+
+	req := httptest.NewRequest(
+		http.MethodGet,
+		"/intersections/test-intersection-id/runs/missing-run",
+		nil,
+	)
+	req.SetPathValue("id", "test-intersection-id")
+	req.SetPathValue("runId", "missing-run")
+	req = req.WithContext(suite.ctx)
+	w := httptest.NewRecorder()
+
+	suite.handler.GetRun(w, req)
+
+	suite.Equal(http.StatusNotFound, w.Code)
+}
@@ -0,0 +1,129 @@
+package simulation
+
+import (
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+
+	"github.com/COS301-SE-2025/Swift-Signals/api-gateway/internal/model"
+	errs "github.com/COS301-SE-2025/Swift-Signals/shared/error"
+	"github.com/stretchr/testify/mock"
+)
+
+func newSensitivityRequest(query string) *http.Request {
+	req := httptest.NewRequest(
+		http.MethodGet,
+		"/intersections/test-intersection-id/sensitivity?"+query,
+		nil,
+	)
+	req.SetPathValue("id", "test-intersection-id")
+	return req
+}
+
+func (suite *TestSuite) TestGetSensitivity_Success() {
+	optimal := 35
+	elasticity := -0.4
+	expected := model.Sensitivity{
+		IntersectionID:  "test-intersection-id",
+		Parameter:       model.SweepParameterGreen,
+		ParameterValues: []int{10, 35, 60},
+		Curves: []model.SensitivityCurve{
+			{
+				Metric:       model.MetricAverageWaitingTime,
+				Direction:    model.MetricDirectionLower,
+				Values:       []float64{60, 40, 55},
+				Elasticities: []*float64{&elasticity, nil, &elasticity},
+				Elasticity:   &elasticity,
+				OptimalValue: &optimal,
+			},
+		},
+	}
+
+	suite.service.On("AnalyseSensitivity", mock.Anything, "test-intersection-id",
+		model.SensitivityRequest{
+			Parameter:    model.SweepParameterGreen,
+			Min:          10,
+			Max:          60,
+			Steps:        3,
+			Replications: 5,
+		}).
+		Return(expected, nil)
+
+	req := newSensitivityRequest("param=green&min=10&max=60&steps=3&replications=5").
+		WithContext(suite.ctx)
+	w := httptest.NewRecorder()
+
+	suite.handler.GetSensitivity(w, req)
+
+	suite.Equal(http.StatusOK, w.Code)
+
+	var response model.Sensitivity
+	err := json.Unmarshal(w.Body.Bytes(), &response)
+	suite.Require().NoError(err)
+	suite.Equal([]int{10, 35, 60}, response.ParameterValues)
+	suite.Require().Len(response.Curves, 1)
+	suite.Equal(35, *response.Curves[0].OptimalValue)
+	suite.Nil(response.Curves[0].Elasticities[1])
+
+	suite.service.AssertExpectations(suite.T())
+}
+
+func (suite *TestSuite) TestGetSensitivity_Defaults() {
+	suite.service.On("AnalyseSensitivity", mock.Anything, "test-intersection-id",
+		model.SensitivityRequest{
+			Parameter:    model.SweepParameterRed,
+			Min:          5,
+			Max:          30,
+			Steps:        10,
+			Replications: 3,
+		}).
+		Return(model.Sensitivity{}, nil)
+
+	req := newSensitivityRequest("param=red&min=5&max=30").WithContext(suite.ctx)
+	w := httptest.NewRecorder()
+
+	suite.handler.GetSensitivity(w, req)
+
+	suite.Equal(http.StatusOK, w.Code)
+	suite.service.AssertExpectations(suite.T())
+}
+
+func (suite *TestSuite) TestGetSensitivity_InvalidQuery() {
+	for _, query := range []string{
+		"",
+		"param=green&max=60",
+		"param=green&min=ten&max=60",
+		"param=seed&min=1&max=60",
+		"param=green&min=60&max=10",
+		"param=green&min=0&max=10",
+		"param=green&min=10&max=60&steps=1",
+		"param=green&min=10&max=60&steps=51",
+		"param=green&min=10&max=60&replications=0",
+		"param=green&min=10&max=60&replications=few",
+	} {
+		req := newSensitivityRequest(query).WithContext(suite.ctx)
+		w := httptest.NewRecorder()
+
+		suite.handler.GetSensitivity(w, req)
+
+		suite.Equal(http.StatusBadRequest, w.Code, query)
+	}
+
+	suite.service.AssertNotCalled(suite.T(), "AnalyseSensitivity",
+		mock.Anything, mock.Anything, mock.Anything)
+}
+
+func (suite *TestSuite) TestGetSensitivity_Forbidden() {
+	suite.service.On("AnalyseSensitivity", mock.Anything, "test-intersection-id", mock.Anything).
+		Return(model.Sensitivity{}, errs.NewForbiddenError(
+			"you do not have access to this intersection",
+			map[string]any{},
+		))
+
+	req := newSensitivityRequest("param=speed&min=40&max=80").WithContext(suite.ctx)
+	w := httptest.NewRecorder()
+
+	suite.handler.GetSensitivity(w, req)
+
+	suite.Equal(http.StatusForbidden, w.Code)
+}
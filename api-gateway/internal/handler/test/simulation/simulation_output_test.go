@@ -0,0 +1,265 @@
+package simulation
+
+import (
+	"bufio"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"slices"
+
+	"github.com/COS301-SE-2025/Swift-Signals/api-gateway/internal/model"
+	errs "github.com/COS301-SE-2025/Swift-Signals/shared/error"
+	"github.com/stretchr/testify/mock"
+)
+
+// sendChunks makes a mocked output stream pass the given chunks to the handler
+func sendChunks(chunks ...model.SimulationOutputChunk) func(args mock.Arguments) {
+	return func(args mock.Arguments) {
+		send := args.Get(3).(func(chunk model.SimulationOutputChunk) error)
+		for _, chunk := range chunks {
+			if err := send(chunk); err != nil {
+				return
+			}
+		}
+	}
+}
+
+func (suite *TestSuite) readChunks(w *httptest.ResponseRecorder) []model.SimulationOutputChunk {
+	chunks := []model.SimulationOutputChunk{}
+	scanner := bufio.NewScanner(w.Body)
+	for scanner.Scan() {
+		var chunk model.SimulationOutputChunk
+		suite.Require().NoError(json.Unmarshal(scanner.Bytes(), &chunk))
+		chunks = append(chunks, chunk)
+	}
+	return chunks
+}
+
+func (suite *TestSuite) newOutputRequest(path string) *http.Request {
+	req := httptest.NewRequest(http.MethodGet, path, nil)
+	req.SetPathValue("id", "test-intersection-id")
+	return req.WithContext(suite.ctx)
+}
+
+func (suite *TestSuite) TestGetSimulationOutput_StreamsChunks() {
+	suite.service.On(
+		"StreamSimulationOutput",
+		mock.Anything,
+		"test-intersection-id",
+		mock.Anything,
+		mock.Anything,
+	).Run(sendChunks(
+		model.SimulationOutputChunk{
+			Intersection: &model.SimulationIntersection{
+				Nodes: []model.SimulationNode{{ID: "node-1"}},
+			},
+			Vehicles: []model.SimulationVehicle{{ID: "vehicle-1"}},
+		},
+		model.SimulationOutputChunk{
+			Vehicles: []model.SimulationVehicle{{ID: "vehicle-2"}},
+		},
+	)).
+		Return(nil)
+
+	w := httptest.NewRecorder()
+	suite.handler.GetSimulationOutput(
+		w,
+		suite.newOutputRequest("/intersections/test-intersection-id/simulate/output"),
+	)
+
+	suite.Equal(http.StatusOK, w.Code)
+	suite.Equal("application/x-ndjson", w.Header().Get("Content-Type"))
+	suite.True(w.Flushed)
+
+	chunks := suite.readChunks(w)
+	suite.Require().Len(chunks, 2)
+	suite.Require().NotNil(chunks[0].Intersection)
+	suite.Equal("node-1", chunks[0].Intersection.Nodes[0].ID)
+	suite.Equal("vehicle-1", chunks[0].Vehicles[0].ID)
+	suite.Nil(chunks[1].Intersection)
+	suite.Equal("vehicle-2", chunks[1].Vehicles[0].ID)
+
+	suite.service.AssertExpectations(suite.T())
+}
+
+func (suite *TestSuite) TestGetSimulationOutput_EmptyStream() {
+	suite.service.On(
+		"StreamSimulationOutput",
+		mock.Anything,
+		"test-intersection-id",
+		mock.Anything,
+		mock.Anything,
+	).Return(nil)
+
+	w := httptest.NewRecorder()
+	suite.handler.GetSimulationOutput(
+		w,
+		suite.newOutputRequest("/intersections/test-intersection-id/simulate/output"),
+	)
+
+	suite.Equal(http.StatusOK, w.Code)
+	suite.Equal("application/x-ndjson", w.Header().Get("Content-Type"))
+	suite.Empty(w.Body.String())
+}
+
+func (suite *TestSuite) TestGetSimulationOutput_ErrorBeforeFirstChunk() {
+	suite.service.On(
+		"StreamSimulationOutput",
+		mock.Anything,
+		"test-intersection-id",
+		mock.Anything,
+		mock.Anything,
+	).Return(errs.NewForbiddenError("you do not have access to this intersection", nil))
+
+	w := httptest.NewRecorder()
+	suite.handler.GetSimulationOutput(
+		w,
+		suite.newOutputRequest("/intersections/test-intersection-id/simulate/output"),
+	)
+
+	suite.Equal(http.StatusForbidden, w.Code)
+	suite.Equal("application/json", w.Header().Get("Content-Type"))
+
+	var response model.ErrorResponse
+	suite.Require().NoError(json.Unmarshal(w.Body.Bytes(), &response))
+	suite.Equal("you do not have access to this intersection", response.Message)
+}
+
+func (suite *TestSuite) TestGetSimulationOutput_ErrorAfterFirstChunk() {
+	suite.service.On(
+		"StreamSimulationOutput",
+		mock.Anything,
+		"test-intersection-id",
+		mock.Anything,
+		mock.Anything,
+	).Run(sendChunks(model.SimulationOutputChunk{
+		Vehicles: []model.SimulationVehicle{{ID: "vehicle-1"}},
+	})).
+		Return(errs.NewInternalError("stream broke", nil, map[string]any{}))
+
+	w := httptest.NewRecorder()
+	suite.handler.GetSimulationOutput(
+		w,
+		suite.newOutputRequest("/intersections/test-intersection-id/simulate/output"),
+	)
+
+	suite.Equal(http.StatusOK, w.Code)
+
+	chunks := suite.readChunks(w)
+	suite.Require().Len(chunks, 2)
+	suite.Equal("vehicle-1", chunks[0].Vehicles[0].ID)
+	suite.Require().NotNil(chunks[1].Error)
+	suite.Equal(http.StatusInternalServerError, chunks[1].Error.Code)
+	suite.Equal("something went wrong", chunks[1].Error.Message)
+}
+
+func (suite *TestSuite) TestGetOptimisedOutput_NotOptimised() {
+	suite.service.On(
+		"StreamOptimisedOutput",
+		mock.Anything,
+		"test-intersection-id",
+		mock.Anything,
+		mock.Anything,
+	).Return(errs.NewNotFoundError("no optimised parameters found for this intersection", nil))
+
+	w := httptest.NewRecorder()
+	suite.handler.GetOptimisedOutput(
+		w,
+		suite.newOutputRequest("/intersections/test-intersection-id/optimise/output"),
+	)
+
+	suite.Equal(http.StatusNotFound, w.Code)
+	suite.service.AssertNotCalled(suite.T(), "StreamSimulationOutput",
+		mock.Anything, mock.Anything, mock.Anything, mock.Anything)
+}
+
+func (suite *TestSuite) TestGetOptimisedOutput_StreamsChunks() {
+	suite.service.On(
+		"StreamOptimisedOutput",
+		mock.Anything,
+		"test-intersection-id",
+		mock.Anything,
+		mock.Anything,
+	).Run(sendChunks(model.SimulationOutputChunk{
+		Vehicles: []model.SimulationVehicle{{ID: "vehicle-1"}},
+	})).
+		Return(nil)
+
+	w := httptest.NewRecorder()
+	suite.handler.GetOptimisedOutput(
+		w,
+		suite.newOutputRequest("/intersections/test-intersection-id/optimise/output"),
+	)
+
+	suite.Equal(http.StatusOK, w.Code)
+	chunks := suite.readChunks(w)
+	suite.Require().Len(chunks, 1)
+	suite.Equal("vehicle-1", chunks[0].Vehicles[0].ID)
+}
+
+func (suite *TestSuite) TestGetSimulationOutput_ParsesFilter() {
+	suite.service.On(
+		"StreamSimulationOutput",
+		mock.Anything,
+		"test-intersection-id",
+		mock.MatchedBy(func(filter model.OutputFilter) bool {
+			return filter.From != nil && *filter.From == 10 &&
+				filter.To != nil && *filter.To == 20 &&
+				slices.Equal(filter.VehicleIDs, []string{"vehicle-1", "vehicle-2", "vehicle-3"}) &&
+				filter.SampleEvery == 5 &&
+				filter.OmitSpeed
+		}),
+		mock.Anything,
+	).Return(nil)
+
+	w := httptest.NewRecorder()
+	suite.handler.GetSimulationOutput(
+		w,
+		suite.newOutputRequest(
+			"/intersections/test-intersection-id/simulate/output"+
+				"?from=10&to=20&vehicles=vehicle-1,vehicle-2&vehicles=vehicle-3"+
+				"&sample_every=5&fields=time,x,y",
+		),
+	)
+
+	suite.Equal(http.StatusOK, w.Code)
+	suite.service.AssertExpectations(suite.T())
+}
+
+func (suite *TestSuite) TestGetSimulationOutput_InvalidFilter() {
+	queries := []string{
+		"from=-1",
+		"to=soon",
+		"from=20&to=10",
+		"sample_every=0",
+		"fields=time,acceleration",
+	}
+
+	for _, query := range queries {
+		w := httptest.NewRecorder()
+		suite.handler.GetSimulationOutput(
+			w,
+			suite.newOutputRequest("/intersections/test-intersection-id/simulate/output?"+query),
+		)
+
+		suite.Equal(http.StatusBadRequest, w.Code, query)
+	}
+	suite.service.AssertNotCalled(suite.T(), "StreamSimulationOutput",
+		mock.Anything, mock.Anything, mock.Anything, mock.Anything)
+}
+
+func (suite *TestSuite) TestGetSimulation_InvalidFilter() {
+	req := httptest.NewRequest(
+		http.MethodGet,
+		"/intersections/test-intersection-id/simulate?sample_every=often",
+		nil,
+	)
+	req.SetPathValue("id", "test-intersection-id")
+	w := httptest.NewRecorder()
+
+	suite.handler.GetSimulation(w, req.WithContext(suite.ctx))
+
+	suite.Equal(http.StatusBadRequest, w.Code)
+	suite.service.AssertNotCalled(suite.T(), "GetSimulationData",
+		mock.Anything, mock.Anything, mock.Anything)
+}
@@ -0,0 +1,249 @@
+package simulation
+
+import (
+	"bytes"
+	"encoding/csv"
+	"encoding/json"
+	"math"
+	"net/http"
+	"net/http/httptest"
+
+	"github.com/COS301-SE-2025/Swift-Signals/api-gateway/internal/model"
+	errs "github.com/COS301-SE-2025/Swift-Signals/shared/error"
+	"github.com/stretchr/testify/mock"
+)
+
+func createTestSweep() model.Sweep {
+	return model.Sweep{
+		ID:             "sweep-1",
+		IntersectionID: "test-intersection-id",
+		Status:         model.SweepStatusRunning,
+		Ranges: []model.SweepRange{
+			{Parameter: model.SweepParameterGreen, Min: 10, Max: 15, Step: 5},
+		},
+		TotalPoints:     2,
+		CompletedPoints: 2,
+		FailedPoints:    1,
+		Progress:        1,
+		Points: []model.SweepPoint{
+			{
+				Index:      0,
+				Parameters: model.SimulationParameters{Green: 10, Yellow: 3, Red: 7, Speed: 60},
+				Results: &model.SimulationResults{
+					TotalVehicles:      100,
+					AverageWaitingTime: 12.5,
+				},
+				DurationMs: 5400,
+			},
+			{
+				Index:      1,
+				Parameters: model.SimulationParameters{Green: 15, Yellow: 3, Red: 7, Speed: 60},
+				Error:      "simulation ran past its deadline of 1m0s",
+				DurationMs: 60000,
+			},
+		},
+	}
+}
+
+func newSweepRequest(body any) *http.Request {
+	payload, _ := json.Marshal(body)
+	return httptest.NewRequest(
+		http.MethodPost,
+		"/intersections/test-intersection-id/sweeps",
+		bytes.NewReader(payload),
+	)
+}
+
+func (suite *TestSuite) TestStartSweep_Accepted() {
+	ranges := []model.SweepRange{
+		{Parameter: model.SweepParameterGreen, Min: 10, Max: 60, Step: 5},
+		{Parameter: model.SweepParameterRed, Min: 5, Max: 30, Step: 5},
+	}
+	expectedSweep := model.Sweep{
+		ID:             "sweep-1",
+		IntersectionID: "test-intersection-id",
+		Status:         model.SweepStatusPending,
+		Ranges:         ranges,
+		TotalPoints:    66,
+	}
+
+	suite.service.On("StartSweep", mock.Anything, "test-intersection-id", ranges).
+		Return(expectedSweep, nil)
+
+	req := newSweepRequest(model.CreateSweepRequest{Ranges: ranges})
+	req.SetPathValue("id", "test-intersection-id")
+	req = req.WithContext(suite.ctx)
+	w := httptest.NewRecorder()
+
+	suite.handler.StartSweep(w, req)
+
+	suite.Equal(http.StatusAccepted, w.Code)
+	suite.Equal("/intersections/test-intersection-id/sweeps/sweep-1", w.Header().Get("Location"))
+
+	var response model.Sweep
+	err := json.Unmarshal(w.Body.Bytes(), &response)
+	suite.Require().NoError(err)
+	suite.Equal("sweep-1", response.ID)
+	suite.Equal(66, response.TotalPoints)
+
+	suite.service.AssertExpectations(suite.T())
+}
+
+func (suite *TestSuite) TestStartSweep_InvalidRanges() {
+	for name, body := range map[string]any{
+		"no ranges": model.CreateSweepRequest{},
+		"unknown parameter": model.CreateSweepRequest{Ranges: []model.SweepRange{
+			{Parameter: "intersection_type", Min: 1, Max: 2, Step: 1},
+		}},
+		"max below min": model.CreateSweepRequest{Ranges: []model.SweepRange{
+			{Parameter: model.SweepParameterGreen, Min: 20, Max: 10, Step: 5},
+		}},
+		"zero step": model.CreateSweepRequest{Ranges: []model.SweepRange{
+			{Parameter: model.SweepParameterGreen, Min: 10, Max: 20},
+		}},
+		"past the simulator's range": model.CreateSweepRequest{Ranges: []model.SweepRange{
+			{Parameter: model.SweepParameterSeed, Min: math.MaxInt, Max: math.MaxInt, Step: 1},
+		}},
+		"malformed": "ranges",
+	} {
+		req := newSweepRequest(body)
+		req.SetPathValue("id", "test-intersection-id")
+		req = req.WithContext(suite.ctx)
+		w := httptest.NewRecorder()
+
+		suite.handler.StartSweep(w, req)
+
+		suite.Equal(http.StatusBadRequest, w.Code, name)
+	}
+
+	suite.service.AssertNotCalled(suite.T(), "StartSweep",
+		mock.Anything, mock.Anything, mock.Anything)
+}
+
+func (suite *TestSuite) TestGetSweep_JSON() {
+	suite.service.On("GetSweep", mock.Anything, "test-intersection-id", "sweep-1").
+		Return(createTestSweep(), nil)
+
+	req := httptest.NewRequest(
+		http.MethodGet,
+		"/intersections/test-intersection-id/sweeps/sweep-1",
+		nil,
+	)
+	req.SetPathValue("id", "test-intersection-id")
+	req.SetPathValue("sweepId", "sweep-1")
+	req = req.WithContext(suite.ctx)
+	w := httptest.NewRecorder()
+
+	suite.handler.GetSweep(w, req)
+
+	suite.Equal(http.StatusOK, w.Code)
+	suite.Equal("application/json", w.Header().Get("Content-Type"))
+
+	var response model.Sweep
+	err := json.Unmarshal(w.Body.Bytes(), &response)
+	suite.Require().NoError(err)
+	suite.Require().Len(response.Points, 2)
+	suite.Equal(1, response.FailedPoints)
+	suite.InDelta(12.5, response.Points[0].Results.AverageWaitingTime, 0.001)
+}
+
+func (suite *TestSuite) TestGetSweep_CSV() {
+	suite.service.On("GetSweep", mock.Anything, "test-intersection-id", "sweep-1").
+		Return(createTestSweep(), nil)
+
+	req := httptest.NewRequest(
+		http.MethodGet,
+		"/intersections/test-intersection-id/sweeps/sweep-1?format=csv",
+		nil,
+	)
+	req.SetPathValue("id", "test-intersection-id")
+	req.SetPathValue("sweepId", "sweep-1")
+	req = req.WithContext(suite.ctx)
+	w := httptest.NewRecorder()
+
+	suite.handler.GetSweep(w, req)
+
+	suite.Equal(http.StatusOK, w.Code)
+	suite.Equal("text/csv", w.Header().Get("Content-Type"))
+	suite.Contains(w.Header().Get("Content-Disposition"), "sweep-sweep-1.csv")
+
+	records, err := csv.NewReader(w.Body).ReadAll()
+	suite.Require().NoError(err)
+	suite.Require().Len(records, 3)
+	header := records[0]
+	suite.Equal("index", header[0])
+	suite.Equal(model.SweepParameterGreen, header[1])
+	suite.Equal(model.MetricAverageWaitingTime, header[10])
+	suite.Equal("error", header[len(header)-1])
+
+	suite.Equal([]string{"0", "10", "3", "7", "60", "0"}, records[1][:6])
+	suite.Equal("100", records[1][6])
+	suite.Equal("12.5", records[1][10])
+	suite.Equal("5400", records[1][16])
+	suite.Empty(records[1][17])
+
+	// NOTE: Failed points keep their parameters but leave the metrics empty
+	suite.Equal("15", records[2][1])
+	suite.Empty(records[2][6])
+	suite.Equal("simulation ran past its deadline of 1m0s", records[2][17])
+}
+
+func (suite *TestSuite) TestGetSweep_InvalidFormat() {
+	req := httptest.NewRequest(
+		http.MethodGet,
+		"/intersections/test-intersection-id/sweeps/sweep-1?format=xml",
+		nil,
+	)
+	req.SetPathValue("id", "test-intersection-id")
+	req.SetPathValue("sweepId", "sweep-1")
+	req = req.WithContext(suite.ctx)
+	w := httptest.NewRecorder()
+
+	suite.handler.GetSweep(w, req)
+
+	suite.Equal(http.StatusBadRequest, w.Code)
+	suite.service.AssertNotCalled(suite.T(), "GetSweep",
+		mock.Anything, mock.Anything, mock.Anything)
+}
+
+func (suite *TestSuite) TestGetSweep_NotFound() {
+	suite.service.On("GetSweep", mock.Anything, "test-intersection-id", "missing-sweep").
+		Return(model.Sweep{}, errs.NewNotFoundError("sweep not found", map[string]any{}))
+
+	req := httptest.NewRequest(
+		http.MethodGet,
+		"/intersections/test-intersection-id/sweeps/missing-sweep",
+		nil,
+	)
+	req.SetPathValue("id", "test-intersection-id")
+	req.SetPathValue("sweepId", "missing-sweep")
+	req = req.WithContext(suite.ctx)
+	w := httptest.NewRecorder()
+
+	suite.handler.GetSweep(w, req)
+
+	suite.Equal(http.StatusNotFound, w.Code)
+}
+
+func (suite *TestSuite) TestGetSweeps_Success() {
+	sweep := createTestSweep()
+	sweep.Points = nil
+	suite.service.On("GetSweeps", mock.Anything, "test-intersection-id").
+		Return(model.Sweeps{Sweeps: []model.Sweep{sweep}}, nil)
+
+	req := httptest.NewRequest(http.MethodGet, "/intersections/test-intersection-id/sweeps", nil)
+	req.SetPathValue("id", "test-intersection-id")
+	req = req.WithContext(suite.ctx)
+	w := httptest.NewRecorder()
+
+	suite.handler.GetSweeps(w, req)
+
+	suite.Equal(http.StatusOK, w.Code)
+
+	var response model.Sweeps
+	err := json.Unmarshal(w.Body.Bytes(), &response)
+	suite.Require().NoError(err)
+	suite.Require().Len(response.Sweeps, 1)
+	suite.InDelta(1.0, response.Sweeps[0].Progress, 0.001)
+	suite.Empty(response.Sweeps[0].Points)
+}
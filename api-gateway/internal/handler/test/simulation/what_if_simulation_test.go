@@ -0,0 +1,120 @@
+package simulation
+
+import (
+	"bytes"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+
+	"github.com/COS301-SE-2025/Swift-Signals/api-gateway/internal/model"
+	errs "github.com/COS301-SE-2025/Swift-Signals/shared/error"
+	"github.com/stretchr/testify/mock"
+)
+
+func (suite *TestSuite) newWhatIfRequest(query string, body []byte) *http.Request {
+	req := httptest.NewRequest(
+		http.MethodPost,
+		"/intersections/test-intersection-id/simulate"+query,
+		bytes.NewBuffer(body),
+	)
+	req.Header.Set("Content-Type", "application/json")
+	req.SetPathValue("id", "test-intersection-id")
+	return req.WithContext(suite.ctx)
+}
+
+func createWhatIfParameters() model.SimulationParameters {
+	return model.SimulationParameters{
+		IntersectionType: "INTERSECTION_TYPE_TRAFFICLIGHT",
+		Green:            25,
+		Yellow:           4,
+		Red:              12,
+		Speed:            50,
+		Seed:             98765,
+		TrafficDensity:   "medium",
+	}
+}
+
+func (suite *TestSuite) TestRunWhatIfSimulation_Success() {
+	params := createWhatIfParameters()
+	expectedResponse := model.SimulationResponse{
+		Results: model.SimulationResults{TotalVehicles: 40},
+		Output: model.SimulationOutput{
+			Vehicles: []model.SimulationVehicle{{ID: "vehicle-1"}},
+		},
+	}
+
+	suite.service.On(
+		"RunWhatIfSimulation",
+		mock.Anything,
+		"test-intersection-id",
+		params,
+		mock.MatchedBy(func(filter model.OutputFilter) bool {
+			return filter.SampleEvery == 2
+		}),
+	).Return(expectedResponse, nil)
+
+	body, _ := json.Marshal(params)
+	w := httptest.NewRecorder()
+	suite.handler.RunWhatIfSimulation(w, suite.newWhatIfRequest("?sample_every=2", body))
+
+	suite.Equal(http.StatusOK, w.Code)
+
+	var response model.SimulationResponse
+	suite.Require().NoError(json.Unmarshal(w.Body.Bytes(), &response))
+	suite.Equal(40, response.Results.TotalVehicles)
+	suite.Require().Len(response.Output.Vehicles, 1)
+	suite.Equal("vehicle-1", response.Output.Vehicles[0].ID)
+
+	suite.service.AssertExpectations(suite.T())
+}
+
+func (suite *TestSuite) TestRunWhatIfSimulation_InvalidJSON() {
+	w := httptest.NewRecorder()
+	suite.handler.RunWhatIfSimulation(w, suite.newWhatIfRequest("", []byte(`{"green": }`)))
+
+	suite.Equal(http.StatusBadRequest, w.Code)
+	suite.service.AssertNotCalled(suite.T(), "RunWhatIfSimulation",
+		mock.Anything, mock.Anything, mock.Anything, mock.Anything)
+}
+
+func (suite *TestSuite) TestRunWhatIfSimulation_ValidationError() {
+	invalid := []func(params *model.SimulationParameters){
+		func(params *model.SimulationParameters) { params.IntersectionType = "" },
+		func(params *model.SimulationParameters) { params.Green = 0 },
+		func(params *model.SimulationParameters) { params.Yellow = -1 },
+		func(params *model.SimulationParameters) { params.Speed = 0 },
+		func(params *model.SimulationParameters) { params.TrafficDensity = "extreme" },
+	}
+
+	for _, modify := range invalid {
+		params := createWhatIfParameters()
+		modify(&params)
+		body, _ := json.Marshal(params)
+
+		w := httptest.NewRecorder()
+		suite.handler.RunWhatIfSimulation(w, suite.newWhatIfRequest("", body))
+
+		suite.Equal(http.StatusBadRequest, w.Code)
+	}
+	suite.service.AssertNotCalled(suite.T(), "RunWhatIfSimulation",
+		mock.Anything, mock.Anything, mock.Anything, mock.Anything)
+}
+
+func (suite *TestSuite) TestRunWhatIfSimulation_Forbidden() {
+	suite.service.On(
+		"RunWhatIfSimulation",
+		mock.Anything,
+		"test-intersection-id",
+		mock.Anything,
+		mock.Anything,
+	).Return(
+		model.SimulationResponse{},
+		errs.NewForbiddenError("you do not have access to this intersection", nil),
+	)
+
+	body, _ := json.Marshal(createWhatIfParameters())
+	w := httptest.NewRecorder()
+	suite.handler.RunWhatIfSimulation(w, suite.newWhatIfRequest("", body))
+
+	suite.Equal(http.StatusForbidden, w.Code)
+}
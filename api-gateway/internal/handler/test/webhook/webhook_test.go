@@ -0,0 +1,197 @@
+package webhook
+
+import (
+	"bytes"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+
+	"github.com/COS301-SE-2025/Swift-Signals/api-gateway/internal/model"
+	errs "github.com/COS301-SE-2025/Swift-Signals/shared/error"
+	"github.com/stretchr/testify/mock"
+)
+
+func (suite *TestSuite) TestCreateWebhook_Success() {
+	reqBody := model.CreateWebhookRequest{
+		URL:    "https://ops.example.com/hook",
+		Events: []string{"optimisation.completed", "intersection.deleted"},
+	}
+	expected := model.Webhook{
+		ID:     "hook-1",
+		URL:    reqBody.URL,
+		Events: reqBody.Events,
+		Secret: "whsec_abc",
+	}
+	suite.service.On("CreateWebhook", mock.Anything, "test-user-id", reqBody).
+		Return(expected, nil)
+
+	body, _ := json.Marshal(reqBody)
+	req := httptest.NewRequest(http.MethodPost, "/webhooks", bytes.NewReader(body))
+	req = req.WithContext(suite.ctx)
+	w := httptest.NewRecorder()
+
+	suite.handler.CreateWebhook(w, req)
+
+	suite.Equal(http.StatusCreated, w.Code)
+	var actual model.Webhook
+	suite.Require().NoError(json.Unmarshal(w.Body.Bytes(), &actual))
+	suite.Equal(expected.ID, actual.ID)
+	suite.Equal("whsec_abc", actual.Secret)
+	suite.service.AssertExpectations(suite.T())
+}
+
+func (suite *TestSuite) TestCreateWebhook_InvalidBody() {
+	req := httptest.NewRequest(http.MethodPost, "/webhooks", bytes.NewBufferString("{"))
+	req = req.WithContext(suite.ctx)
+	w := httptest.NewRecorder()
+
+	suite.handler.CreateWebhook(w, req)
+
+	suite.Equal(http.StatusBadRequest, w.Code)
+	suite.Contains(w.Body.String(), "Invalid request payload")
+	suite.service.AssertNotCalled(
+		suite.T(),
+		"CreateWebhook",
+		mock.Anything,
+		mock.Anything,
+		mock.Anything,
+	)
+}
+
+func (suite *TestSuite) TestCreateWebhook_ValidationErrors() {
+	tests := map[string]model.CreateWebhookRequest{
+		"missing url": {Events: []string{"optimisation.completed"}},
+		"non http url": {
+			URL:    "ftp://ops.example.com/hook",
+			Events: []string{"optimisation.completed"},
+		},
+		"no events":     {URL: "https://ops.example.com/hook"},
+		"unknown event": {URL: "https://ops.example.com/hook", Events: []string{"user.deleted"}},
+	}
+
+	for name, reqBody := range tests {
+		suite.Run(name, func() {
+			body, _ := json.Marshal(reqBody)
+			req := httptest.NewRequest(http.MethodPost, "/webhooks", bytes.NewReader(body))
+			req = req.WithContext(suite.ctx)
+			w := httptest.NewRecorder()
+
+			suite.handler.CreateWebhook(w, req)
+
+			suite.Equal(http.StatusBadRequest, w.Code)
+			suite.Contains(w.Body.String(), "supported event")
+		})
+	}
+	suite.service.AssertNotCalled(
+		suite.T(),
+		"CreateWebhook",
+		mock.Anything,
+		mock.Anything,
+		mock.Anything,
+	)
+}
+
+func (suite *TestSuite) TestGetWebhooks_Success() {
+	expected := model.Webhooks{Webhooks: []model.Webhook{
+		{ID: "hook-1", URL: "https://ops.example.com/hook", Events: []string{"intersection.deleted"}},
+	}}
+	suite.service.On("GetWebhooks", mock.Anything, "test-user-id").Return(expected, nil)
+
+	req := httptest.NewRequest(http.MethodGet, "/webhooks", nil)
+	req = req.WithContext(suite.ctx)
+	w := httptest.NewRecorder()
+
+	suite.handler.GetWebhooks(w, req)
+
+	suite.Equal(http.StatusOK, w.Code)
+	var actual model.Webhooks
+	suite.Require().NoError(json.Unmarshal(w.Body.Bytes(), &actual))
+	suite.Equal(expected.Webhooks[0].ID, actual.Webhooks[0].ID)
+	suite.NotContains(w.Body.String(), "secret")
+}
+
+func (suite *TestSuite) TestDeleteWebhook_Success() {
+	suite.service.On("DeleteWebhook", mock.Anything, "test-user-id", "hook-1").Return(nil)
+
+	req := httptest.NewRequest(http.MethodDelete, "/webhooks/hook-1", nil)
+	req.SetPathValue("id", "hook-1")
+	req = req.WithContext(suite.ctx)
+	w := httptest.NewRecorder()
+
+	suite.handler.DeleteWebhook(w, req)
+
+	suite.Equal(http.StatusNoContent, w.Code)
+	suite.service.AssertExpectations(suite.T())
+}
+
+func (suite *TestSuite) TestDeleteWebhook_NotFound() {
+	suite.service.On("DeleteWebhook", mock.Anything, "test-user-id", "hook-1").
+		Return(errs.NewNotFoundError("webhook not found", map[string]any{}))
+
+	req := httptest.NewRequest(http.MethodDelete, "/webhooks/hook-1", nil)
+	req.SetPathValue("id", "hook-1")
+	req = req.WithContext(suite.ctx)
+	w := httptest.NewRecorder()
+
+	suite.handler.DeleteWebhook(w, req)
+
+	suite.Equal(http.StatusNotFound, w.Code)
+	suite.Contains(w.Body.String(), "webhook not found")
+}
+
+func (suite *TestSuite) TestGetWebhookDeliveries_DefaultLimit() {
+	expected := model.WebhookDeliveries{Deliveries: []model.WebhookDelivery{
+		{ID: "delivery-1", WebhookID: "hook-1", Attempt: 1, StatusCode: 200, Success: true},
+	}}
+	suite.service.On("GetWebhookDeliveries", mock.Anything, "test-user-id", "hook-1", 50).
+		Return(expected, nil)
+
+	req := httptest.NewRequest(http.MethodGet, "/webhooks/hook-1/deliveries", nil)
+	req.SetPathValue("id", "hook-1")
+	req = req.WithContext(suite.ctx)
+	w := httptest.NewRecorder()
+
+	suite.handler.GetWebhookDeliveries(w, req)
+
+	suite.Equal(http.StatusOK, w.Code)
+	var actual model.WebhookDeliveries
+	suite.Require().NoError(json.Unmarshal(w.Body.Bytes(), &actual))
+	suite.Equal(expected, actual)
+}
+
+func (suite *TestSuite) TestGetWebhookDeliveries_Limit() {
+	suite.service.On("GetWebhookDeliveries", mock.Anything, "test-user-id", "hook-1", 5).
+		Return(model.WebhookDeliveries{Deliveries: []model.WebhookDelivery{}}, nil)
+
+	req := httptest.NewRequest(http.MethodGet, "/webhooks/hook-1/deliveries?limit=5", nil)
+	req.SetPathValue("id", "hook-1")
+	req = req.WithContext(suite.ctx)
+	w := httptest.NewRecorder()
+
+	suite.handler.GetWebhookDeliveries(w, req)
+
+	suite.Equal(http.StatusOK, w.Code)
+	suite.service.AssertExpectations(suite.T())
+}
+
+func (suite *TestSuite) TestGetWebhookDeliveries_InvalidLimit() {
+	for _, limit := range []string{"0", "101", "abc"} {
+		req := httptest.NewRequest(http.MethodGet, "/webhooks/hook-1/deliveries?limit="+limit, nil)
+		req.SetPathValue("id", "hook-1")
+		req = req.WithContext(suite.ctx)
+		w := httptest.NewRecorder()
+
+		suite.handler.GetWebhookDeliveries(w, req)
+
+		suite.Equal(http.StatusBadRequest, w.Code)
+		suite.Contains(w.Body.String(), "Invalid limit")
+	}
+	suite.service.AssertNotCalled(
+		suite.T(),
+		"GetWebhookDeliveries",
+		mock.Anything,
+		mock.Anything,
+		mock.Anything,
+		mock.Anything,
+	)
+}
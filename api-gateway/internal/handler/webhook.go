@@ -0,0 +1,258 @@
+package handler
+
+import (
+	"encoding/json"
+	"net/http"
+	"strconv"
+
+	"github.com/COS301-SE-2025/Swift-Signals/api-gateway/internal/middleware"
+	"github.com/COS301-SE-2025/Swift-Signals/api-gateway/internal/model"
+	"github.com/COS301-SE-2025/Swift-Signals/api-gateway/internal/service"
+	"github.com/COS301-SE-2025/Swift-Signals/api-gateway/internal/util"
+	errs "github.com/COS301-SE-2025/Swift-Signals/shared/error"
+	"github.com/go-playground/validator/v10"
+)
+
+type WebhookHandler struct {
+	service   service.WebhookServiceInterface
+	validator *validator.Validate
+}
+
+func NewWebhookHandler(s service.WebhookServiceInterface) *WebhookHandler {
+	return &WebhookHandler{
+		service:   s,
+		validator: validator.New(),
+	}
+}
+
+// @Summary Create Webhook
+// @Description Subscribes a URL to events on the user's intersections. Each event is sent as a JSON POST signed with the returned secret, in the X-Swift-Signals-Signature header as "sha256=" followed by the hex HMAC-SHA256 of the body. Failed deliveries are retried with exponential backoff. Events are optimisation.started, optimisation.completed, optimisation.failed, intersection.status_changed and intersection.deleted.
+// @Tags Webhooks
+// @Accept json
+// @Produce json
+// @Security BearerAuth
+// @Param createWebhookRequest body model.CreateWebhookRequest true "Webhook URL and events"
+// @Success 201 {object} model.Webhook "Webhook successfully created, with its secret"
+// @Failure 400 {object} model.ErrorResponse "Invalid URL or events, or webhook limit reached"
+// @Failure 401 {object} model.ErrorResponse "Unauthorized: Token missing or invalid"
+// @Failure 500 {object} model.ErrorResponse "Internal server error"
+// @Router /webhooks [post]
+func (h *WebhookHandler) CreateWebhook(w http.ResponseWriter, r *http.Request) {
+	logger := middleware.LoggerFromContext(r.Context()).With(
+		"handler", "webhook",
+		"action", "createWebhook",
+	)
+	logger.Info("processing createWebhook request")
+
+	var req model.CreateWebhookRequest
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+		logger.Warn("failed to decode request body",
+			"error", err.Error(),
+		)
+		util.SendErrorResponse(
+			w,
+			errs.NewValidationError("Invalid request payload", map[string]any{}),
+		)
+		return
+	}
+
+	logger.Debug("validating request")
+	if err := h.validator.Struct(req); err != nil {
+		logger.Warn("validation failed",
+			"error", err.Error(),
+		)
+		util.SendErrorResponse(
+			w,
+			errs.NewValidationError(
+				"a http(s) URL and at least one supported event are required",
+				map[string]any{},
+			),
+		)
+		return
+	}
+
+	userID, ok := middleware.GetUserID(r.Context())
+	if !ok {
+		logger.Error("user ID missing inside of handler")
+		util.SendErrorResponse(
+			w,
+			errs.NewInternalError(
+				"user ID missing inside of handler",
+				nil,
+				map[string]any{},
+			),
+		)
+		return
+	}
+
+	resp, err := h.service.CreateWebhook(r.Context(), userID, req)
+	if err != nil {
+		logger.Error("request failed",
+			"error", err.Error(),
+		)
+		util.SendErrorResponse(w, err)
+		return
+	}
+
+	logger.Info("request successful",
+		"webhook_id", resp.ID,
+	)
+	util.SendJSONResponse(w, http.StatusCreated, resp)
+}
+
+// @Summary Get Webhooks
+// @Description Retrieves the user's webhooks. Their secrets are only returned when they are created.
+// @Tags Webhooks
+// @Produce json
+// @Security BearerAuth
+// @Success 200 {object} model.Webhooks "Successful webhooks retrieval"
+// @Failure 401 {object} model.ErrorResponse "Unauthorized: Token missing or invalid"
+// @Failure 500 {object} model.ErrorResponse "Internal server error"
+// @Router /webhooks [get]
+func (h *WebhookHandler) GetWebhooks(w http.ResponseWriter, r *http.Request) {
+	logger := middleware.LoggerFromContext(r.Context()).With(
+		"handler", "webhook",
+		"action", "getWebhooks",
+	)
+	logger.Info("processing getWebhooks request")
+
+	userID, ok := middleware.GetUserID(r.Context())
+	if !ok {
+		logger.Error("user ID missing inside of handler")
+		util.SendErrorResponse(
+			w,
+			errs.NewInternalError(
+				"user ID missing inside of handler",
+				nil,
+				map[string]any{},
+			),
+		)
+		return
+	}
+
+	resp, err := h.service.GetWebhooks(r.Context(), userID)
+	if err != nil {
+		logger.Error("request failed",
+			"error", err.Error(),
+		)
+		util.SendErrorResponse(w, err)
+		return
+	}
+
+	logger.Info("request successful")
+	util.SendJSONResponse(w, http.StatusOK, resp)
+}
+
+// @Summary Delete Webhook
+// @Description Deletes one of the user's webhooks along with its delivery log.
+// @Tags Webhooks
+// @Security BearerAuth
+// @Param id path string true "Webhook ID"
+// @Success 204 "No Content"
+// @Failure 401 {object} model.ErrorResponse "Unauthorized: Token missing or invalid"
+// @Failure 404 {object} model.ErrorResponse "Not Found: Webhook does not exist"
+// @Failure 500 {object} model.ErrorResponse "Internal server error"
+// @Router /webhooks/{id} [delete]
+func (h *WebhookHandler) DeleteWebhook(w http.ResponseWriter, r *http.Request) {
+	logger := middleware.LoggerFromContext(r.Context()).With(
+		"handler", "webhook",
+		"action", "deleteWebhook",
+	)
+	logger.Info("processing deleteWebhook request")
+
+	userID, ok := middleware.GetUserID(r.Context())
+	if !ok {
+		logger.Error("user ID missing inside of handler")
+		util.SendErrorResponse(
+			w,
+			errs.NewInternalError(
+				"user ID missing inside of handler",
+				nil,
+				map[string]any{},
+			),
+		)
+		return
+	}
+
+	webhookID := r.PathValue("id")
+
+	err := h.service.DeleteWebhook(r.Context(), userID, webhookID)
+	if err != nil {
+		logger.Error("request failed",
+			"error", err.Error(),
+		)
+		util.SendErrorResponse(w, err)
+		return
+	}
+
+	logger.Info("request successful",
+		"webhook_id", webhookID,
+	)
+	w.WriteHeader(http.StatusNoContent)
+}
+
+// @Summary Get Webhook Deliveries
+// @Description Returns the most recent attempts at delivering events to one of the user's webhooks, newest first.
+// @Tags Webhooks
+// @Produce json
+// @Security BearerAuth
+// @Param id path string true "Webhook ID"
+// @Param limit query int false "Number of deliveries (default is 50 and max is 100)"
+// @Success 200 {object} model.WebhookDeliveries "Successful deliveries retrieval"
+// @Failure 400 {object} model.ErrorResponse "Bad Request: Invalid limit"
+// @Failure 401 {object} model.ErrorResponse "Unauthorized: Token missing or invalid"
+// @Failure 404 {object} model.ErrorResponse "Not Found: Webhook does not exist"
+// @Failure 500 {object} model.ErrorResponse "Internal server error"
+// @Router /webhooks/{id}/deliveries [get]
+func (h *WebhookHandler) GetWebhookDeliveries(w http.ResponseWriter, r *http.Request) {
+	logger := middleware.LoggerFromContext(r.Context()).With(
+		"handler", "webhook",
+		"action", "getWebhookDeliveries",
+	)
+	logger.Info("processing getWebhookDeliveries request")
+
+	limitStr := r.URL.Query().Get("limit")
+	limit := 50
+	if limitStr != "" {
+		if l, err := strconv.Atoi(limitStr); err == nil && l > 0 && l <= 100 {
+			limit = l
+		} else {
+			logger.Warn("invalid limit", "limit", limitStr)
+			util.SendErrorResponse(
+				w,
+				errs.NewValidationError("Invalid limit", map[string]any{"limit": limitStr}),
+			)
+			return
+		}
+	}
+
+	userID, ok := middleware.GetUserID(r.Context())
+	if !ok {
+		logger.Error("user ID missing inside of handler")
+		util.SendErrorResponse(
+			w,
+			errs.NewInternalError(
+				"user ID missing inside of handler",
+				nil,
+				map[string]any{},
+			),
+		)
+		return
+	}
+
+	webhookID := r.PathValue("id")
+
+	resp, err := h.service.GetWebhookDeliveries(r.Context(), userID, webhookID, limit)
+	if err != nil {
+		logger.Error("request failed",
+			"error", err.Error(),
+		)
+		util.SendErrorResponse(w, err)
+		return
+	}
+
+	logger.Info("request successful",
+		"webhook_id", webhookID,
+	)
+	util.SendJSONResponse(w, http.StatusOK, resp)
+}
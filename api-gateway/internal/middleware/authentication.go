@@ -5,6 +5,7 @@ import (
 	"fmt"
 	"net/http"
 	"strings"
+	"time"
 
 	"github.com/COS301-SE-2025/Swift-Signals/api-gateway/internal/util"
 	errs "github.com/COS301-SE-2025/Swift-Signals/shared/error"
@@ -18,7 +19,19 @@ const (
 	roleKey   contextKey = "role"
 )
 
-func AuthMiddleware(secret string, paths ...string) Middleware {
+// TokenRevocationChecker reports whether a token was revoked before it expired, such as by
+// its user logging out or resetting their password after it was issued
+type TokenRevocationChecker interface {
+	IsTokenRevoked(ctx context.Context, userID, tokenID string, issuedAt time.Time) (bool, error)
+}
+
+// AuthMiddleware rejects requests without a valid token, except to the excluded paths.
+// NOTE: A token is rejected when its revocation cannot be checked
+func AuthMiddleware(
+	secret string,
+	revocations TokenRevocationChecker,
+	paths ...string,
+) Middleware {
 	return func(next http.Handler) http.Handler {
 		return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
 			excluded := NewPathSet(paths...)
@@ -66,6 +79,27 @@ func AuthMiddleware(secret string, paths ...string) Middleware {
 				return
 			}
 
+			var issuedAt time.Time
+			if claims.IssuedAt != nil {
+				issuedAt = claims.IssuedAt.Time
+			}
+			revoked, err := revocations.IsTokenRevoked(r.Context(), userID, claims.ID, issuedAt)
+			if err != nil {
+				logger.Error("failed to check token revocation",
+					"error", err.Error())
+				util.SendErrorResponse(w, err)
+				return
+			}
+			if revoked {
+				logger.Warn("token has been revoked",
+					"user_id", userID)
+				util.SendErrorResponse(
+					w,
+					errs.NewUnauthorizedError("token has been revoked", map[string]any{}),
+				)
+				return
+			}
+
 			ctx := SetUserID(r.Context(), userID)
 			ctx = SetRole(ctx, claims.Role)
 			r = r.WithContext(ctx)
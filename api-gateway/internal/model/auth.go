@@ -1,13 +1,24 @@
 package model
 
+import "time"
+
 type LoginRequest struct {
 	Email    string `json:"email"    example:"testuser@example.com" binding:"required" validate:"required,email"`
 	Password string `json:"password" example:"testpass1234"         binding:"required" validate:"required"`
 }
 
+// LoginResponse carries a short-lived access token, along with the refresh token that gets
+// a new one from POST /refresh once it expires
 type LoginResponse struct {
-	Message string `json:"message" example:"Login successful"`
-	Token   string `json:"token"   example:"header.payload.signature"`
+	Message          string    `json:"message"            example:"Login successful"`
+	Token            string    `json:"token"              example:"header.payload.signature"`
+	ExpiresAt        time.Time `json:"expires_at"         example:"2025-06-24T15:19:05Z"`
+	RefreshToken     string    `json:"refresh_token"      example:"IJKPGQ2XQZ7XR3YFV5LSEWMN4A"`
+	RefreshExpiresAt time.Time `json:"refresh_expires_at" example:"2025-07-01T15:04:05Z"`
+}
+
+type RefreshRequest struct {
+	RefreshToken string `json:"refresh_token" example:"IJKPGQ2XQZ7XR3YFV5LSEWMN4A" binding:"required" validate:"required"`
 }
 
 type RegisterRequest struct {
@@ -20,14 +31,30 @@ type RegisterResponse struct {
 	UserID string `json:"user_id" example:"1"`
 }
 
+// LogoutRequest is optional, but without the refresh token the session can be renewed
+// after logging out
+type LogoutRequest struct {
+	RefreshToken string `json:"refresh_token" example:"IJKPGQ2XQZ7XR3YFV5LSEWMN4A"`
+}
+
 type LogoutResponse struct {
 	Message string `json:"message" example:"Logout successful"`
 }
 
 type ResetPasswordRequest struct {
-	Email string `json:"email" example:"user@example.com" binding:"required"`
+	Email string `json:"email" example:"user@example.com" binding:"required" validate:"required,email"`
 }
 
 type ResetPasswordResponse struct {
-	Message string `json:"message" example:"Password reset instructions sent to your email."`
+	Message string `json:"message" example:"If an account uses that email, a password reset link has been sent to it"`
+}
+
+// ConfirmResetPasswordRequest carries the token from the link in a password reset email
+type ConfirmResetPasswordRequest struct {
+	Token       string `json:"token"        example:"IJKPGQ2XQZ7XR3YFV5LSEWMN4A" binding:"required" validate:"required"`
+	NewPassword string `json:"new_password" example:"newpass1234"                binding:"required" validate:"required,min=8,max=64"`
+}
+
+type ConfirmResetPasswordResponse struct {
+	Message string `json:"message" example:"Password Reset Successful"`
 }
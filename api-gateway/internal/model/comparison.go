@@ -0,0 +1,54 @@
+package model
+
+// Comparison puts the results of two simulations of an intersection side by side
+type Comparison struct {
+	IntersectionID string             `json:"intersection_id" example:"1"`
+	Baseline       ComparedSimulation `json:"baseline"`
+	Candidate      ComparedSimulation `json:"candidate"`
+	Metrics        []MetricComparison `json:"metrics"`
+}
+
+// ComparedSimulation is one side of a comparison along with where it came from. Replicated
+// sides carry the rounded means of their replications as results.
+type ComparedSimulation struct {
+	Source       string               `json:"source"                 example:"default"`
+	RunID        string               `json:"run_id,omitempty"       example:"9b2d7c4e-1f3a-4c5b-8d6e-7f8a9b0c1d2e"`
+	Parameters   SimulationParameters `json:"parameters"`
+	Results      SimulationResults    `json:"results"`
+	Replications *ReplicatedResults   `json:"replications,omitempty"`
+}
+
+// MetricComparison is the change of one simulation metric from the baseline to the
+// candidate. DeltaPercent is left out when the baseline is zero. For replicated
+// simulations the means are compared, and a change only counts as improved when the
+// confidence intervals of both means do not overlap.
+type MetricComparison struct {
+	Metric       string   `json:"metric"                  example:"average_waiting_time"`
+	Direction    string   `json:"direction"               example:"lower"`
+	Baseline     float64  `json:"baseline"                example:"60.0"`
+	Candidate    float64  `json:"candidate"               example:"45.0"`
+	Delta        float64  `json:"delta"                   example:"-15.0"`
+	DeltaPercent *float64 `json:"delta_percent,omitempty" example:"-25.0"`
+	Improved     bool     `json:"improved"                example:"true"`
+}
+
+type CompareParametersRequest struct {
+	Baseline  SimulationParameters `json:"baseline"  validate:"required"`
+	Candidate SimulationParameters `json:"candidate" validate:"required"`
+}
+
+// Sources of compared simulations
+const (
+	ComparisonSourceDefault    = "default"
+	ComparisonSourceOptimised  = "optimised"
+	ComparisonSourceRun        = "run"
+	ComparisonSourceParameters = "parameters"
+)
+
+// Directions in which a metric improves. Metrics without a direction, such as the
+// number of generated vehicles, never count as improved.
+const (
+	MetricDirectionLower  = "lower"
+	MetricDirectionHigher = "higher"
+	MetricDirectionNone   = "none"
+)
@@ -18,6 +18,8 @@ type UpdateIntersectionRequest struct {
 		City     string `json:"city"     example:"Pretoria"`
 		Province string `json:"province" example:"Gauteng"`
 	} `json:"details"`
+	TrafficDensity    string                `json:"traffic_density,omitempty"    example:"high" validate:"omitempty,oneof=low medium high"`
+	DefaultParameters *SimulationParameters `json:"default_parameters,omitempty"                validate:"omitempty"`
 }
 
 type CreateIntersectionResponse struct {
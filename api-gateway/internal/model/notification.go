@@ -0,0 +1,33 @@
+package model
+
+import "time"
+
+// Notification tells a user about something that happened while they were away, such as
+// an optimisation finishing
+type Notification struct {
+	ID             string     `json:"id"                        example:"7c1e2d3f-4a5b-6c7d-8e9f-0a1b2c3d4e5f"`
+	UserID         string     `json:"-"`
+	Type           string     `json:"type"                      example:"NOTIFICATION_TYPE_OPTIMISATION_COMPLETED"`
+	Message        string     `json:"message"                   example:"Optimisation of Main & 5th finished: waiting time −18%"`
+	IntersectionID string     `json:"intersection_id,omitempty" example:"1"`
+	Read           bool       `json:"read"                      example:"false"`
+	CreatedAt      time.Time  `json:"created_at"                example:"2025-06-24T15:04:05Z"`
+	ReadAt         *time.Time `json:"read_at,omitempty"         example:"2025-06-24T16:04:05Z"`
+}
+
+type Notifications struct {
+	Notifications []Notification `json:"notifications"`
+	UnreadCount   int            `json:"unread_count"  example:"3"`
+}
+
+type MarkAllNotificationsReadResponse struct {
+	Marked int `json:"marked" example:"3"`
+}
+
+const (
+	NotificationTypeOptimisationCompleted = "NOTIFICATION_TYPE_OPTIMISATION_COMPLETED"
+	NotificationTypeOptimisationFailed    = "NOTIFICATION_TYPE_OPTIMISATION_FAILED"
+	NotificationTypeAdminGranted          = "NOTIFICATION_TYPE_ADMIN_GRANTED"
+	NotificationTypeAdminRevoked          = "NOTIFICATION_TYPE_ADMIN_REVOKED"
+	NotificationTypeAccountUpdated        = "NOTIFICATION_TYPE_ACCOUNT_UPDATED"
+)
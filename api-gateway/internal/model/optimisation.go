@@ -0,0 +1,44 @@
+package model
+
+import "time"
+
+type OptimisationJob struct {
+	ID             string     `json:"id"                    example:"5f0c7b1e-3c1a-4b8e-9f4d-1a2b3c4d5e6f"`
+	IntersectionID string     `json:"intersection_id"       example:"1"`
+	Status         string     `json:"status"                example:"OPTIMISATION_JOB_STATUS_RUNNING"`
+	Improved       bool       `json:"improved"              example:"false"`
+	Error          string     `json:"error,omitempty"       example:""`
+	CreatedAt      time.Time  `json:"created_at"            example:"2025-06-24T15:04:05Z"`
+	StartedAt      *time.Time `json:"started_at,omitempty"  example:"2025-06-24T15:04:06Z"`
+	FinishedAt     *time.Time `json:"finished_at,omitempty" example:"2025-06-24T17:04:06Z"`
+}
+
+type OptimisationJobs struct {
+	Jobs []OptimisationJob `json:"jobs"`
+}
+
+type OptimisationProgress struct {
+	JobID            string                  `json:"job_id"            example:"5f0c7b1e-3c1a-4b8e-9f4d-1a2b3c4d5e6f"`
+	Generation       int                     `json:"generation"        example:"4"`
+	TotalGenerations int                     `json:"total_generations" example:"30"`
+	BestFitness      float64                 `json:"best_fitness"      example:"1532.7"`
+	BestParameters   *SimulationParameters   `json:"best_parameters,omitempty"`
+	Candidates       []OptimisationCandidate `json:"candidates"`
+}
+
+type OptimisationCandidate struct {
+	Parameters SimulationParameters `json:"parameters"`
+	Fitness    float64              `json:"fitness"    example:"1780.2"`
+}
+
+// OptimisationEvent is sent to clients following the optimisation of an intersection.
+// Data is an OptimisationProgress for progress events and an OptimisationJob for job events.
+type OptimisationEvent struct {
+	Type string
+	Data any
+}
+
+const (
+	OptimisationEventProgress = "progress"
+	OptimisationEventJob      = "job"
+)
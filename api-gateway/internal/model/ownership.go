@@ -0,0 +1,16 @@
+package model
+
+import "time"
+
+// OwnershipReport lists where the intersections users own and the intersections that exist
+// disagree
+type OwnershipReport struct {
+	// OrphanedIntersections exist but are in no user's intersection list
+	OrphanedIntersections []string `json:"orphaned_intersections"`
+	// DanglingIntersectionIDs are in the intersection lists of the users they are keyed by,
+	// but no longer exist
+	DanglingIntersectionIDs map[string][]string `json:"dangling_intersection_ids"`
+	// Repaired is set once the orphans have been deleted and the dangling IDs removed
+	Repaired  bool      `json:"repaired"`
+	CheckedAt time.Time `json:"checked_at" example:"2025-06-24T15:04:05Z"`
+}
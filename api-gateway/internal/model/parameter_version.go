@@ -0,0 +1,37 @@
+package model
+
+import "time"
+
+type ParameterVersion struct {
+	IntersectionID  string            `json:"intersection_id"            example:"1"`
+	Version         int               `json:"version"                    example:"3"`
+	UserID          string            `json:"user_id"                    example:"2"`
+	Reason          string            `json:"reason"                     example:"PARAMETER_CHANGE_REASON_REVERTED"`
+	RevertedVersion int               `json:"reverted_version,omitempty" example:"1"`
+	Changes         []ParameterChange `json:"changes"`
+	CreatedAt       time.Time         `json:"created_at"                 example:"2025-06-24T15:04:05Z"`
+}
+
+type ParameterChange struct {
+	Field         string                  `json:"field"                    example:"PARAMETER_FIELD_DEFAULT"`
+	OldParameters *OptimisationParameters `json:"old_parameters,omitempty"`
+	NewParameters OptimisationParameters  `json:"new_parameters"`
+	Metrics       *SimulationResults      `json:"metrics,omitempty"`
+}
+
+type ParameterVersions struct {
+	Versions []ParameterVersion `json:"versions"`
+	Page     int                `json:"page"      example:"1"`
+	PageSize int                `json:"page_size" example:"20"`
+}
+
+const (
+	ParameterFieldDefault = "PARAMETER_FIELD_DEFAULT"
+	ParameterFieldCurrent = "PARAMETER_FIELD_CURRENT"
+	ParameterFieldBest    = "PARAMETER_FIELD_BEST"
+
+	ParametersCreated   = "PARAMETER_CHANGE_REASON_CREATED"
+	ParametersUpdated   = "PARAMETER_CHANGE_REASON_UPDATED"
+	ParametersOptimised = "PARAMETER_CHANGE_REASON_OPTIMISED"
+	ParametersReverted  = "PARAMETER_CHANGE_REASON_REVERTED"
+)
@@ -0,0 +1,20 @@
+package model
+
+// ReplicatedResults aggregates the results of simulating the same parameters over
+// several seeds. Metrics are keyed by the JSON names of the SimulationResults fields.
+type ReplicatedResults struct {
+	Replications int                      `json:"replications" example:"10"`
+	Seeds        []int                    `json:"seeds"`
+	Metrics      map[string]MetricSummary `json:"metrics"`
+}
+
+// MetricSummary describes one simulation metric over replications, including the 95%
+// confidence interval of its mean
+type MetricSummary struct {
+	Mean    float64 `json:"mean"     example:"60.0"`
+	StdDev  float64 `json:"std_dev"  example:"4.2"`
+	Min     float64 `json:"min"      example:"53.0"`
+	Max     float64 `json:"max"      example:"67.5"`
+	CILower float64 `json:"ci_lower" example:"57.0"`
+	CIUpper float64 `json:"ci_upper" example:"63.0"`
+}
@@ -0,0 +1,33 @@
+package model
+
+import "time"
+
+type Run struct {
+	ID             string                 `json:"id"                example:"9b2d7c4e-1f3a-4c5b-8d6e-7f8a9b0c1d2e"`
+	IntersectionID string                 `json:"intersection_id"   example:"1"`
+	UserID         string                 `json:"user_id"           example:"2"`
+	Type           string                 `json:"type"              example:"RUN_TYPE_OPTIMISATION"`
+	Parameters     OptimisationParameters `json:"parameters"`
+	Metrics        *SimulationResults     `json:"metrics,omitempty"`
+	DurationMs     int64                  `json:"duration_ms"       example:"5400000"`
+	Outcome        string                 `json:"outcome"           example:"RUN_OUTCOME_SUCCEEDED"`
+	Error          string                 `json:"error,omitempty"   example:""`
+	Improved       bool                   `json:"improved"          example:"true"`
+	JobID          string                 `json:"job_id,omitempty"  example:"5f0c7b1e-3c1a-4b8e-9f4d-1a2b3c4d5e6f"`
+	CreatedAt      time.Time              `json:"created_at"        example:"2025-06-24T15:04:05Z"`
+}
+
+type Runs struct {
+	Runs     []Run `json:"runs"`
+	Page     int   `json:"page"      example:"1"`
+	PageSize int   `json:"page_size" example:"20"`
+}
+
+const (
+	RunTypeSimulation   = "RUN_TYPE_SIMULATION"
+	RunTypeOptimisation = "RUN_TYPE_OPTIMISATION"
+
+	RunOutcomeSucceeded = "RUN_OUTCOME_SUCCEEDED"
+	RunOutcomeFailed    = "RUN_OUTCOME_FAILED"
+	RunOutcomeCancelled = "RUN_OUTCOME_CANCELLED"
+)
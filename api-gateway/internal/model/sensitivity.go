@@ -0,0 +1,37 @@
+package model
+
+// Sensitivity shows how the metrics of an intersection respond to varying one simulation
+// parameter while the others stay at the intersection's defaults
+type Sensitivity struct {
+	IntersectionID  string               `json:"intersection_id"  example:"1"`
+	Parameter       string               `json:"parameter"        example:"green"`
+	BaseParameters  SimulationParameters `json:"base_parameters"`
+	ParameterValues []int                `json:"parameter_values"`
+	Replications    int                  `json:"replications"     example:"3"`
+	Curves          []SensitivityCurve   `json:"curves"`
+}
+
+// SensitivityCurve is one metric at each of the parameter values. Elasticities hold the
+// percentage change of the metric per percent change of the parameter at each value, and
+// Elasticity the same across the whole range; both are null where the metric is zero.
+// OptimalValue is the parameter value with the best metric in the range and is left out
+// for metrics without a direction. An optimum at either end of the range may lie beyond it.
+type SensitivityCurve struct {
+	Metric            string     `json:"metric"                  example:"average_waiting_time"`
+	Direction         string     `json:"direction"               example:"lower"`
+	Values            []float64  `json:"values"`
+	Elasticities      []*float64 `json:"elasticities"`
+	Elasticity        *float64   `json:"elasticity"              example:"-0.4"`
+	OptimalValue      *int       `json:"optimal_value,omitempty" example:"35"`
+	OptimumAtBoundary bool       `json:"optimum_at_boundary"     example:"false"`
+}
+
+// SensitivityRequest varies Parameter over Steps evenly spaced whole values from Min to Max,
+// simulating each value over Replications seeds
+type SensitivityRequest struct {
+	Parameter    string `json:"param"        validate:"required,oneof=green yellow red speed"`
+	Min          int    `json:"min"          validate:"min=1"`
+	Max          int    `json:"max"          validate:"gtfield=Min"`
+	Steps        int    `json:"steps"        validate:"min=2,max=50"`
+	Replications int    `json:"replications" validate:"min=1"`
+}
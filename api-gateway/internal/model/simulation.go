@@ -4,10 +4,6 @@ import "log/slog"
 
 type NodeType string
 
-type OptimisationResponse struct {
-	Improved bool `json:"improved" example:"true"`
-}
-
 const (
 	NodeTypePriority     NodeType = "PRIORITY"
 	NodeTypeTrafficLight NodeType = "TRAFFIC_LIGHT"
@@ -18,6 +14,14 @@ type SimulationResponse struct {
 	Output  SimulationOutput  `json:"output"`
 }
 
+// SimulationOutputChunk is one line of a streamed simulation output. The intersection is
+// only sent with the first chunk, and a stream that fails part way ends with an error.
+type SimulationOutputChunk struct {
+	Intersection *SimulationIntersection `json:"intersection,omitempty"`
+	Vehicles     []SimulationVehicle     `json:"vehicles,omitempty"`
+	Error        *ErrorResponse          `json:"error,omitempty"`
+}
+
 type SimulationResults struct {
 	TotalVehicles      int     `json:"total_vehicles"       example:"100"`
 	AverageTravelTime  float64 `json:"average_travel_time"  example:"300"`
@@ -31,6 +35,20 @@ type SimulationResults struct {
 	NearCollisions     int     `json:"near_collisions"      example:"2"`
 }
 
+// Names of the simulation metrics, matching the JSON fields of SimulationResults
+const (
+	MetricTotalVehicles      = "total_vehicles"
+	MetricAverageTravelTime  = "average_travel_time"
+	MetricTotalTravelTime    = "total_travel_time"
+	MetricAverageSpeed       = "average_speed"
+	MetricAverageWaitingTime = "average_waiting_time"
+	MetricTotalWaitingTime   = "total_waiting_time"
+	MetricGeneratedVehicles  = "generated_vehicles"
+	MetricEmergencyBrakes    = "emergency_brakes"
+	MetricEmergencyStops     = "emergency_stops"
+	MetricNearCollisions     = "near_collisions"
+)
+
 type SimulationOutput struct {
 	Intersection SimulationIntersection `json:"intersection"`
 	Vehicles     []SimulationVehicle    `json:"vehicles"`
@@ -83,10 +101,32 @@ type SimulationVehicle struct {
 }
 
 type Position struct {
-	Time  int     `json:"time"  example:"0"`
-	X     float64 `json:"x"     example:"100.0"`
-	Y     float64 `json:"y"     example:"200.0"`
-	Speed float64 `json:"speed" example:"50.0"`
+	Time  int      `json:"time"            example:"0"`
+	X     float64  `json:"x"               example:"100.0"`
+	Y     float64  `json:"y"               example:"200.0"`
+	Speed *float64 `json:"speed,omitempty" example:"50.0"`
+}
+
+// Position fields that can be requested from simulation outputs. Time and coordinates are
+// always returned.
+const (
+	PositionFieldTime  = "time"
+	PositionFieldX     = "x"
+	PositionFieldY     = "y"
+	PositionFieldSpeed = "speed"
+)
+
+// OutputFilter narrows down the vehicle trajectories of a simulation output. Its zero
+// value keeps everything.
+type OutputFilter struct {
+	// From and To bound the times of the kept positions in seconds, inclusively
+	From *int
+	To   *int
+	// VehicleIDs keeps only these vehicles when set
+	VehicleIDs []string
+	// SampleEvery keeps at most one position of a vehicle per this many seconds
+	SampleEvery int
+	OmitSpeed   bool
 }
 
 /* Logging */
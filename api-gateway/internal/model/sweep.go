@@ -0,0 +1,73 @@
+package model
+
+import (
+	"math"
+	"time"
+)
+
+// Sweep simulates an intersection over every combination of the given parameter ranges.
+// While it runs, Points only holds the points simulated so far, ordered by their index.
+type Sweep struct {
+	ID              string               `json:"id"                    example:"3f7a9c2e-8b1d-4e6f-a5c3-2d9b8e7f1a4c"`
+	IntersectionID  string               `json:"intersection_id"       example:"1"`
+	Status          string               `json:"status"                example:"SWEEP_STATUS_RUNNING"`
+	BaseParameters  SimulationParameters `json:"base_parameters"`
+	Ranges          []SweepRange         `json:"ranges"`
+	TotalPoints     int                  `json:"total_points"          example:"66"`
+	CompletedPoints int                  `json:"completed_points"      example:"24"`
+	FailedPoints    int                  `json:"failed_points"         example:"1"`
+	Progress        float64              `json:"progress"              example:"0.36"`
+	Points          []SweepPoint         `json:"points,omitempty"`
+	Error           string               `json:"error,omitempty"       example:""`
+	CreatedAt       time.Time            `json:"created_at"            example:"2025-06-24T15:04:05Z"`
+	StartedAt       *time.Time           `json:"started_at,omitempty"  example:"2025-06-24T15:04:06Z"`
+	FinishedAt      *time.Time           `json:"finished_at,omitempty" example:"2025-06-24T15:10:06Z"`
+}
+
+type Sweeps struct {
+	Sweeps []Sweep `json:"sweeps"`
+}
+
+// SweepRange sweeps one simulation parameter from Min to Max, both included, in steps.
+// Every parameter is bounded by SweepMax.
+type SweepRange struct {
+	Parameter string `json:"parameter" example:"green" validate:"required,oneof=green yellow red speed seed"`
+	Min       int    `json:"min"       example:"10"    validate:"min=1,max=2147483647"`
+	Max       int    `json:"max"       example:"60"    validate:"gtefield=Min,max=2147483647"`
+	Step      int    `json:"step"      example:"5"     validate:"gt=0"`
+}
+
+// SweepMax is the largest value any parameter can be swept to, as the simulator takes its
+// parameters as 32-bit integers
+const SweepMax = math.MaxInt32
+
+// SweepPoint is one simulated point of a sweep. Points whose simulation failed or ran past
+// the deadline have an error instead of results.
+type SweepPoint struct {
+	Index      int                  `json:"index"             example:"7"`
+	Parameters SimulationParameters `json:"parameters"`
+	Results    *SimulationResults   `json:"results,omitempty"`
+	Error      string               `json:"error,omitempty"   example:""`
+	DurationMs int64                `json:"duration_ms"       example:"5400"`
+}
+
+// CreateSweepRequest sweeps the given ranges over the intersection's default parameters
+type CreateSweepRequest struct {
+	Ranges []SweepRange `json:"ranges" validate:"required,min=1,max=5,dive"`
+}
+
+// Parameters that can be swept
+const (
+	SweepParameterGreen  = "green"
+	SweepParameterYellow = "yellow"
+	SweepParameterRed    = "red"
+	SweepParameterSpeed  = "speed"
+	SweepParameterSeed   = "seed"
+)
+
+const (
+	SweepStatusPending   = "SWEEP_STATUS_PENDING"
+	SweepStatusRunning   = "SWEEP_STATUS_RUNNING"
+	SweepStatusSucceeded = "SWEEP_STATUS_SUCCEEDED"
+	SweepStatusFailed    = "SWEEP_STATUS_FAILED"
+)
@@ -13,7 +13,20 @@ type Intersection struct {
 	TrafficDensity    string                 `json:"traffic_density"    example:"high"`
 	DefaultParameters OptimisationParameters `json:"default_parameters"`
 	BestParameters    OptimisationParameters `json:"best_parameters"`
-	CurrentParameters OptimisationParameters `json:"current_parameters"`
+	// BestParametersStale is set once the defaults change, until an optimisation replaces
+	// the best parameters found under the old ones
+	BestParametersStale bool                   `json:"best_parameters_stale"`
+	CurrentParameters   OptimisationParameters `json:"current_parameters"`
+	BestMetrics         *SimulationResults     `json:"best_metrics,omitempty"`
+	CurrentMetrics      *SimulationResults     `json:"current_metrics,omitempty"`
+	FailureReason       string                 `json:"failure_reason,omitempty" example:"optimiser unavailable"`
+	FailedAt            *time.Time             `json:"failed_at,omitempty"      example:"2025-06-24T15:04:05Z"`
+	// ParameterVersion is the latest version in the parameter history
+	ParameterVersion int `json:"parameter_version" example:"3"`
+	// Version changes with every write to the intersection and is sent as its ETag
+	Version int `json:"version" example:"5"`
+	// DeletedAt is set while the intersection is in the trash
+	DeletedAt *time.Time `json:"deleted_at,omitempty" example:"2025-06-24T15:04:05Z"`
 }
 
 type Intersections struct {
@@ -32,18 +45,21 @@ type OptimisationParameters struct {
 }
 
 type SimulationParameters struct {
-	IntersectionType string `json:"intersection_type" example:"t-junction" validate:"required"`
-	Green            int    `json:"green"             example:"10"         validate:"required,min=1"`
-	Yellow           int    `json:"yellow"            example:"2"          validate:"required,min=1"`
-	Red              int    `json:"red"               example:"6"          validate:"required,min=1"`
-	Speed            int    `json:"speed"             example:"60"         validate:"required,min=1"`
-	Seed             int    `json:"seed"              example:"3247128304" validate:"required"`
+	IntersectionType string `json:"intersection_type"         example:"t-junction" validate:"required"`
+	Green            int    `json:"green"                     example:"10"         validate:"required,min=1"`
+	Yellow           int    `json:"yellow"                    example:"2"          validate:"required,min=1"`
+	Red              int    `json:"red"                       example:"6"          validate:"required,min=1"`
+	Speed            int    `json:"speed"                     example:"60"         validate:"required,min=1"`
+	Seed             int    `json:"seed"                      example:"3247128304" validate:"required"`
+	TrafficDensity   string `json:"traffic_density,omitempty" example:"high"       validate:"omitempty,oneof=low medium high"`
 }
 
 type User struct {
-	ID              string   `json:"id"               example:"1"`
-	Username        string   `json:"username"         example:"johndoe"`
-	Email           string   `json:"email"            example:"user@example.com"`
-	IsAdmin         bool     `json:"is_admin"         example:"false"`
-	IntersectionIDs []string `json:"intersection_ids" example:"[1,2,3]"`
+	ID              string   `json:"id"                             example:"1"`
+	Username        string   `json:"username"                       example:"johndoe"`
+	Email           string   `json:"email"                          example:"user@example.com"`
+	IsAdmin         bool     `json:"is_admin"                       example:"false"`
+	IntersectionIDs []string `json:"intersection_ids"               example:"[1,2,3]"`
+	// UnreadNotifications is only given for the user's own profile, when their inbox can be read
+	UnreadNotifications *int `json:"unread_notifications,omitempty" example:"3"`
 }
@@ -0,0 +1,60 @@
+package model
+
+import (
+	"time"
+
+	"github.com/COS301-SE-2025/Swift-Signals/shared/events"
+)
+
+// Webhook is a URL that a user's events are delivered to as signed POST requests
+type Webhook struct {
+	ID     string   `json:"id"     example:"3f9a1c2e-5b7d-4e8f-9a0b-1c2d3e4f5a6b"`
+	UserID string   `json:"-"`
+	URL    string   `json:"url"    example:"https://ops.example.com/hooks/swift-signals"`
+	Events []string `json:"events" example:"optimisation.completed,optimisation.failed"`
+	// Secret signs each delivery, so it is only returned when the webhook is created
+	Secret    string    `json:"secret,omitempty" example:"whsec_6f1c0d4e9b8a7f2e3d5c"`
+	CreatedAt time.Time `json:"created_at"       example:"2025-06-24T15:04:05Z"`
+}
+
+type Webhooks struct {
+	Webhooks []Webhook `json:"webhooks"`
+}
+
+type CreateWebhookRequest struct {
+	URL    string   `json:"url"    example:"https://ops.example.com/hooks/swift-signals" validate:"required,http_url,max=2048"`
+	Events []string `json:"events" example:"optimisation.completed,optimisation.failed"  validate:"required,min=1,dive,oneof=optimisation.started optimisation.completed optimisation.failed intersection.status_changed intersection.deleted"`
+}
+
+// WebhookDelivery is one attempt at delivering an event to a webhook
+type WebhookDelivery struct {
+	ID        string `json:"id"         example:"9b2d4f6a-1c3e-4a5b-8d7f-0e1a2b3c4d5e"`
+	WebhookID string `json:"webhook_id" example:"3f9a1c2e-5b7d-4e8f-9a0b-1c2d3e4f5a6b"`
+	EventID   string `json:"event_id"   example:"J5MUWCH3PBIRE2NC6BJHZ3QXQM"`
+	EventType string `json:"event_type" example:"optimisation.completed"`
+	// Attempt counts the tries at delivering the event to the webhook, from 1
+	Attempt     int       `json:"attempt"               example:"1"`
+	StatusCode  int       `json:"status_code,omitempty" example:"200"`
+	Error       string    `json:"error,omitempty"       example:"context deadline exceeded"`
+	Success     bool      `json:"success"               example:"true"`
+	DurationMs  int64     `json:"duration_ms"           example:"84"`
+	AttemptedAt time.Time `json:"attempted_at"          example:"2025-06-24T15:04:05Z"`
+}
+
+type WebhookDeliveries struct {
+	Deliveries []WebhookDelivery `json:"deliveries"`
+}
+
+// PendingDelivery is an event waiting to be delivered to a webhook. It is kept until the
+// webhook accepts the event, rejects it outright or the attempts run out.
+type PendingDelivery struct {
+	// ID is made of the webhook's and the event's, so an event relayed again is not
+	// delivered twice
+	ID        string
+	WebhookID string
+	UserID    string
+	Event     events.Event
+	// Attempts counts the tries made so far
+	Attempts      int
+	NextAttemptAt time.Time
+}
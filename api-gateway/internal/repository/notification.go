@@ -0,0 +1,155 @@
+package repository
+
+import (
+	"cmp"
+	"context"
+	"slices"
+	"sync"
+	"time"
+
+	"github.com/COS301-SE-2025/Swift-Signals/api-gateway/internal/model"
+	errs "github.com/COS301-SE-2025/Swift-Signals/shared/error"
+)
+
+// NOTE: Creates stub for testing
+type NotificationRepositoryInterface interface {
+	CreateNotification(ctx context.Context, notification model.Notification) error
+	// GetNotifications returns a user's most recent notifications, newest first
+	GetNotifications(
+		ctx context.Context,
+		userID string,
+		limit int,
+	) ([]model.Notification, error)
+	CountUnread(ctx context.Context, userID string) (int, error)
+	// MarkRead marks one of a user's notifications read, keeping when it was first read
+	MarkRead(ctx context.Context, userID, id string, at time.Time) error
+	// MarkAllRead marks all of a user's unread notifications read, returning how many
+	MarkAllRead(ctx context.Context, userID string, at time.Time) (int, error)
+}
+
+// maxMemoryNotifications is how many notifications the in-memory repository keeps per
+// user
+const maxMemoryNotifications = 200
+
+// MemoryNotificationRepository keeps notifications in memory, so they are lost when the
+// gateway restarts
+type MemoryNotificationRepository struct {
+	mu            sync.RWMutex
+	notifications map[string][]model.Notification
+}
+
+func NewMemoryNotificationRepository() *MemoryNotificationRepository {
+	return &MemoryNotificationRepository{
+		notifications: make(map[string][]model.Notification),
+	}
+}
+
+func (r *MemoryNotificationRepository) CreateNotification(
+	_ context.Context,
+	notification model.Notification,
+) error {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	notifications := r.notifications[notification.UserID]
+	if slices.ContainsFunc(notifications, func(n model.Notification) bool {
+		return n.ID == notification.ID
+	}) {
+		return errs.NewAlreadyExistsError(
+			"notification already exists",
+			map[string]any{"notification_id": notification.ID},
+		)
+	}
+	notifications = append(notifications, notification)
+	if len(notifications) > maxMemoryNotifications {
+		notifications = slices.Clone(notifications[len(notifications)-maxMemoryNotifications:])
+	}
+	r.notifications[notification.UserID] = notifications
+	return nil
+}
+
+func (r *MemoryNotificationRepository) GetNotifications(
+	_ context.Context,
+	userID string,
+	limit int,
+) ([]model.Notification, error) {
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+	notifications := slices.Clone(r.notifications[userID])
+	slices.SortStableFunc(notifications, func(a, b model.Notification) int {
+		if c := b.CreatedAt.Compare(a.CreatedAt); c != 0 {
+			return c
+		}
+		return cmp.Compare(b.ID, a.ID)
+	})
+	if len(notifications) > limit {
+		notifications = notifications[:limit]
+	}
+	return notifications, nil
+}
+
+func (r *MemoryNotificationRepository) CountUnread(_ context.Context, userID string) (int, error) {
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+	unread := 0
+	for _, notification := range r.notifications[userID] {
+		if !notification.Read {
+			unread++
+		}
+	}
+	return unread, nil
+}
+
+func (r *MemoryNotificationRepository) MarkRead(
+	_ context.Context,
+	userID, id string,
+	at time.Time,
+) error {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	notifications := r.notifications[userID]
+	i := slices.IndexFunc(notifications, func(n model.Notification) bool {
+		return n.ID == id
+	})
+	if i < 0 {
+		return notificationNotFound(id)
+	}
+	markRead(&notifications[i], at)
+	return nil
+}
+
+func (r *MemoryNotificationRepository) MarkAllRead(
+	_ context.Context,
+	userID string,
+	at time.Time,
+) (int, error) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	notifications := r.notifications[userID]
+	marked := 0
+	for i := range notifications {
+		if markRead(&notifications[i], at) {
+			marked++
+		}
+	}
+	return marked, nil
+}
+
+// markRead marks the notification read unless it already is, reporting whether it did
+func markRead(notification *model.Notification, at time.Time) bool {
+	if notification.Read {
+		return false
+	}
+	notification.Read = true
+	notification.ReadAt = &at
+	return true
+}
+
+func notificationNotFound(id string) error {
+	return errs.NewNotFoundError(
+		"notification not found",
+		map[string]any{"notification_id": id},
+	)
+}
+
+// NOTE: Asserts Interface Implementation
+var _ NotificationRepositoryInterface = (*MemoryNotificationRepository)(nil)
@@ -0,0 +1,156 @@
+package repository
+
+import (
+	"context"
+	"time"
+
+	"github.com/COS301-SE-2025/Swift-Signals/api-gateway/internal/model"
+	errs "github.com/COS301-SE-2025/Swift-Signals/shared/error"
+	"go.mongodb.org/mongo-driver/bson"
+	"go.mongodb.org/mongo-driver/mongo"
+	"go.mongodb.org/mongo-driver/mongo/options"
+)
+
+// MongoNotificationRepository persists notifications in Mongo, expiring them after a
+// fixed time to live whether or not they were read
+type MongoNotificationRepository struct {
+	notifications *mongo.Collection
+}
+
+// NewMongoNotificationRepository prepares the collection's indexes, so it needs a
+// reachable database
+func NewMongoNotificationRepository(
+	ctx context.Context,
+	db *mongo.Database,
+	ttl time.Duration,
+) (*MongoNotificationRepository, error) {
+	notifications := db.Collection("Notifications")
+	_, err := notifications.Indexes().CreateMany(ctx, []mongo.IndexModel{
+		{Keys: bson.D{{Key: "id", Value: 1}}, Options: options.Index().SetUnique(true)},
+		{Keys: bson.D{{Key: "userid", Value: 1}, {Key: "createdat", Value: -1}}},
+		{Keys: bson.D{{Key: "userid", Value: 1}, {Key: "read", Value: 1}}},
+		{
+			Keys:    bson.D{{Key: "createdat", Value: 1}},
+			Options: options.Index().SetExpireAfterSeconds(int32(ttl.Seconds())),
+		},
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	return &MongoNotificationRepository{notifications: notifications}, nil
+}
+
+func (r *MongoNotificationRepository) CreateNotification(
+	ctx context.Context,
+	notification model.Notification,
+) error {
+	_, err := r.notifications.InsertOne(ctx, notification)
+	if mongo.IsDuplicateKeyError(err) {
+		return errs.NewAlreadyExistsError(
+			"notification already exists",
+			map[string]any{"notification_id": notification.ID},
+		)
+	}
+	if err != nil {
+		return errs.NewDatabaseError(
+			"failed to create notification",
+			err,
+			map[string]any{"notification_id": notification.ID},
+		)
+	}
+	return nil
+}
+
+func (r *MongoNotificationRepository) GetNotifications(
+	ctx context.Context,
+	userID string,
+	limit int,
+) ([]model.Notification, error) {
+	cursor, err := r.notifications.Find(
+		ctx,
+		bson.M{"userid": userID},
+		options.Find().
+			SetSort(bson.D{{Key: "createdat", Value: -1}, {Key: "id", Value: -1}}).
+			SetLimit(int64(limit)),
+	)
+	if err != nil {
+		return nil, errs.NewDatabaseError(
+			"failed to get notifications",
+			err,
+			map[string]any{"user_id": userID},
+		)
+	}
+
+	notifications := []model.Notification{}
+	if err := cursor.All(ctx, &notifications); err != nil {
+		return nil, errs.NewDatabaseError(
+			"failed to decode notifications",
+			err,
+			map[string]any{"user_id": userID},
+		)
+	}
+	return notifications, nil
+}
+
+func (r *MongoNotificationRepository) CountUnread(ctx context.Context, userID string) (int, error) {
+	count, err := r.notifications.CountDocuments(ctx, bson.M{"userid": userID, "read": false})
+	if err != nil {
+		return 0, errs.NewDatabaseError(
+			"failed to count unread notifications",
+			err,
+			map[string]any{"user_id": userID},
+		)
+	}
+	return int(count), nil
+}
+
+func (r *MongoNotificationRepository) MarkRead(
+	ctx context.Context,
+	userID, id string,
+	at time.Time,
+) error {
+	result, err := r.notifications.UpdateOne(
+		ctx,
+		bson.M{"id": id, "userid": userID},
+		// NOTE: The pipeline only sets readat the first time the notification is read
+		mongo.Pipeline{{{Key: "$set", Value: bson.M{
+			"read":   true,
+			"readat": bson.M{"$ifNull": bson.A{"$readat", at}},
+		}}}},
+	)
+	if err != nil {
+		return errs.NewDatabaseError(
+			"failed to mark notification read",
+			err,
+			map[string]any{"notification_id": id},
+		)
+	}
+	if result.MatchedCount == 0 {
+		return notificationNotFound(id)
+	}
+	return nil
+}
+
+func (r *MongoNotificationRepository) MarkAllRead(
+	ctx context.Context,
+	userID string,
+	at time.Time,
+) (int, error) {
+	result, err := r.notifications.UpdateMany(
+		ctx,
+		bson.M{"userid": userID, "read": false},
+		bson.M{"$set": bson.M{"read": true, "readat": at}},
+	)
+	if err != nil {
+		return 0, errs.NewDatabaseError(
+			"failed to mark notifications read",
+			err,
+			map[string]any{"user_id": userID},
+		)
+	}
+	return int(result.ModifiedCount), nil
+}
+
+// NOTE: Asserts Interface Implementation
+var _ NotificationRepositoryInterface = (*MongoNotificationRepository)(nil)
@@ -0,0 +1,244 @@
+package repository
+
+import (
+	"cmp"
+	"context"
+	"slices"
+	"sync"
+	"time"
+
+	"github.com/COS301-SE-2025/Swift-Signals/api-gateway/internal/model"
+	errs "github.com/COS301-SE-2025/Swift-Signals/shared/error"
+)
+
+// NOTE: Creates stub for testing
+type WebhookRepositoryInterface interface {
+	CreateWebhook(ctx context.Context, webhook model.Webhook) error
+	GetWebhook(ctx context.Context, userID, id string) (*model.Webhook, error)
+	GetWebhooks(ctx context.Context, userID string) ([]model.Webhook, error)
+	// GetWebhooksForEvent returns every user's webhooks subscribed to the event type
+	GetWebhooksForEvent(ctx context.Context, eventType string) ([]model.Webhook, error)
+	// DeleteWebhook deletes one of a user's webhooks along with its delivery log
+	DeleteWebhook(ctx context.Context, userID, id string) error
+	AddDelivery(ctx context.Context, delivery model.WebhookDelivery) error
+	// GetDeliveries returns a webhook's most recent deliveries, newest first
+	GetDeliveries(
+		ctx context.Context,
+		webhookID string,
+		limit int,
+	) ([]model.WebhookDelivery, error)
+	// AddPendingDelivery queues an event for delivery, unless it is already queued
+	AddPendingDelivery(ctx context.Context, pending model.PendingDelivery) error
+	// ClaimPendingDelivery returns a delivery due by now, hiding it from other claims until
+	// the lease has passed, or nil when none is due
+	ClaimPendingDelivery(
+		ctx context.Context,
+		now time.Time,
+		lease time.Duration,
+	) (*model.PendingDelivery, error)
+	// ReschedulePendingDelivery records an attempt and when to make the next one
+	ReschedulePendingDelivery(ctx context.Context, id string, attempts int, next time.Time) error
+	DeletePendingDelivery(ctx context.Context, id string) error
+}
+
+// maxMemoryDeliveries is how many deliveries the in-memory repository keeps per webhook
+const maxMemoryDeliveries = 100
+
+// MemoryWebhookRepository keeps webhooks in memory, so they are lost when the gateway
+// restarts. It suits running the gateway without a database, e.g. locally.
+type MemoryWebhookRepository struct {
+	mu         sync.RWMutex
+	webhooks   map[string]model.Webhook
+	deliveries map[string][]model.WebhookDelivery
+	pending    map[string]model.PendingDelivery
+}
+
+func NewMemoryWebhookRepository() *MemoryWebhookRepository {
+	return &MemoryWebhookRepository{
+		webhooks:   make(map[string]model.Webhook),
+		deliveries: make(map[string][]model.WebhookDelivery),
+		pending:    make(map[string]model.PendingDelivery),
+	}
+}
+
+func (r *MemoryWebhookRepository) CreateWebhook(_ context.Context, webhook model.Webhook) error {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	if _, ok := r.webhooks[webhook.ID]; ok {
+		return errs.NewAlreadyExistsError(
+			"webhook already exists",
+			map[string]any{"webhook_id": webhook.ID},
+		)
+	}
+	webhook.Events = slices.Clone(webhook.Events)
+	r.webhooks[webhook.ID] = webhook
+	return nil
+}
+
+func (r *MemoryWebhookRepository) GetWebhook(
+	_ context.Context,
+	userID, id string,
+) (*model.Webhook, error) {
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+	webhook, ok := r.webhooks[id]
+	if !ok || webhook.UserID != userID {
+		return nil, webhookNotFound(id)
+	}
+	return &webhook, nil
+}
+
+func (r *MemoryWebhookRepository) GetWebhooks(
+	_ context.Context,
+	userID string,
+) ([]model.Webhook, error) {
+	return r.filter(func(webhook model.Webhook) bool {
+		return webhook.UserID == userID
+	}), nil
+}
+
+func (r *MemoryWebhookRepository) GetWebhooksForEvent(
+	_ context.Context,
+	eventType string,
+) ([]model.Webhook, error) {
+	return r.filter(func(webhook model.Webhook) bool {
+		return slices.Contains(webhook.Events, eventType)
+	}), nil
+}
+
+func (r *MemoryWebhookRepository) DeleteWebhook(_ context.Context, userID, id string) error {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	webhook, ok := r.webhooks[id]
+	if !ok || webhook.UserID != userID {
+		return webhookNotFound(id)
+	}
+	delete(r.webhooks, id)
+	delete(r.deliveries, id)
+	for pendingID, pending := range r.pending {
+		if pending.WebhookID == id {
+			delete(r.pending, pendingID)
+		}
+	}
+	return nil
+}
+
+func (r *MemoryWebhookRepository) AddDelivery(
+	_ context.Context,
+	delivery model.WebhookDelivery,
+) error {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	// NOTE: Deliveries still in flight when their webhook is deleted are not logged
+	if _, ok := r.webhooks[delivery.WebhookID]; !ok {
+		return nil
+	}
+	deliveries := append(r.deliveries[delivery.WebhookID], delivery)
+	if len(deliveries) > maxMemoryDeliveries {
+		deliveries = slices.Clone(deliveries[len(deliveries)-maxMemoryDeliveries:])
+	}
+	r.deliveries[delivery.WebhookID] = deliveries
+	return nil
+}
+
+func (r *MemoryWebhookRepository) GetDeliveries(
+	_ context.Context,
+	webhookID string,
+	limit int,
+) ([]model.WebhookDelivery, error) {
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+	deliveries := slices.Clone(r.deliveries[webhookID])
+	slices.Reverse(deliveries)
+	if len(deliveries) > limit {
+		deliveries = deliveries[:limit]
+	}
+	return deliveries, nil
+}
+
+func (r *MemoryWebhookRepository) AddPendingDelivery(
+	_ context.Context,
+	pending model.PendingDelivery,
+) error {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	if _, ok := r.pending[pending.ID]; !ok {
+		r.pending[pending.ID] = pending
+	}
+	return nil
+}
+
+func (r *MemoryWebhookRepository) ClaimPendingDelivery(
+	_ context.Context,
+	now time.Time,
+	lease time.Duration,
+) (*model.PendingDelivery, error) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	var due *model.PendingDelivery
+	for _, pending := range r.pending {
+		if pending.NextAttemptAt.After(now) {
+			continue
+		}
+		if due == nil || pending.NextAttemptAt.Before(due.NextAttemptAt) {
+			due = &pending
+		}
+	}
+	if due == nil {
+		return nil, nil
+	}
+	claimed := *due
+	due.NextAttemptAt = now.Add(lease)
+	r.pending[due.ID] = *due
+	return &claimed, nil
+}
+
+func (r *MemoryWebhookRepository) ReschedulePendingDelivery(
+	_ context.Context,
+	id string,
+	attempts int,
+	next time.Time,
+) error {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	if pending, ok := r.pending[id]; ok {
+		pending.Attempts = attempts
+		pending.NextAttemptAt = next
+		r.pending[id] = pending
+	}
+	return nil
+}
+
+func (r *MemoryWebhookRepository) DeletePendingDelivery(_ context.Context, id string) error {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	delete(r.pending, id)
+	return nil
+}
+
+// filter returns the matching webhooks, oldest first
+func (r *MemoryWebhookRepository) filter(match func(model.Webhook) bool) []model.Webhook {
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+	webhooks := []model.Webhook{}
+	for _, webhook := range r.webhooks {
+		if match(webhook) {
+			webhook.Events = slices.Clone(webhook.Events)
+			webhooks = append(webhooks, webhook)
+		}
+	}
+	slices.SortFunc(webhooks, func(a, b model.Webhook) int {
+		if c := a.CreatedAt.Compare(b.CreatedAt); c != 0 {
+			return c
+		}
+		return cmp.Compare(a.ID, b.ID)
+	})
+	return webhooks
+}
+
+func webhookNotFound(id string) error {
+	return errs.NewNotFoundError("webhook not found", map[string]any{"webhook_id": id})
+}
+
+// NOTE: Asserts Interface Implementation
+var _ WebhookRepositoryInterface = (*MemoryWebhookRepository)(nil)
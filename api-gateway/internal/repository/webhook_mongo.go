@@ -0,0 +1,288 @@
+package repository
+
+import (
+	"context"
+	"errors"
+	"time"
+
+	"github.com/COS301-SE-2025/Swift-Signals/api-gateway/internal/model"
+	errs "github.com/COS301-SE-2025/Swift-Signals/shared/error"
+	"go.mongodb.org/mongo-driver/bson"
+	"go.mongodb.org/mongo-driver/mongo"
+	"go.mongodb.org/mongo-driver/mongo/options"
+)
+
+// MongoWebhookRepository persists webhooks in Mongo, expiring their deliveries after a
+// fixed time to live. Deliveries still to be made are kept too, so that a gateway that
+// restarts carries on retrying them.
+type MongoWebhookRepository struct {
+	webhooks   *mongo.Collection
+	deliveries *mongo.Collection
+	pending    *mongo.Collection
+}
+
+// NewMongoWebhookRepository prepares the collections' indexes, so it needs a reachable
+// database
+func NewMongoWebhookRepository(
+	ctx context.Context,
+	db *mongo.Database,
+	deliveryTTL time.Duration,
+) (*MongoWebhookRepository, error) {
+	webhooks := db.Collection("Webhooks")
+	_, err := webhooks.Indexes().CreateMany(ctx, []mongo.IndexModel{
+		{Keys: bson.D{{Key: "id", Value: 1}}, Options: options.Index().SetUnique(true)},
+		{Keys: bson.D{{Key: "userid", Value: 1}}},
+		{Keys: bson.D{{Key: "events", Value: 1}}},
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	deliveries := db.Collection("WebhookDeliveries")
+	_, err = deliveries.Indexes().CreateMany(ctx, []mongo.IndexModel{
+		{Keys: bson.D{{Key: "webhookid", Value: 1}, {Key: "attemptedat", Value: -1}}},
+		{
+			Keys:    bson.D{{Key: "attemptedat", Value: 1}},
+			Options: options.Index().SetExpireAfterSeconds(int32(deliveryTTL.Seconds())),
+		},
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	pending := db.Collection("PendingWebhookDeliveries")
+	_, err = pending.Indexes().CreateMany(ctx, []mongo.IndexModel{
+		{Keys: bson.D{{Key: "id", Value: 1}}, Options: options.Index().SetUnique(true)},
+		{Keys: bson.D{{Key: "nextattemptat", Value: 1}}},
+		{Keys: bson.D{{Key: "webhookid", Value: 1}}},
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	return &MongoWebhookRepository{
+		webhooks:   webhooks,
+		deliveries: deliveries,
+		pending:    pending,
+	}, nil
+}
+
+func (r *MongoWebhookRepository) CreateWebhook(ctx context.Context, webhook model.Webhook) error {
+	_, err := r.webhooks.InsertOne(ctx, webhook)
+	if mongo.IsDuplicateKeyError(err) {
+		return errs.NewAlreadyExistsError(
+			"webhook already exists",
+			map[string]any{"webhook_id": webhook.ID},
+		)
+	}
+	if err != nil {
+		return errs.NewDatabaseError(
+			"failed to create webhook",
+			err,
+			map[string]any{"webhook_id": webhook.ID},
+		)
+	}
+	return nil
+}
+
+func (r *MongoWebhookRepository) GetWebhook(
+	ctx context.Context,
+	userID, id string,
+) (*model.Webhook, error) {
+	var webhook model.Webhook
+	err := r.webhooks.FindOne(ctx, bson.M{"id": id, "userid": userID}).Decode(&webhook)
+	if errors.Is(err, mongo.ErrNoDocuments) {
+		return nil, webhookNotFound(id)
+	}
+	if err != nil {
+		return nil, errs.NewDatabaseError(
+			"failed to get webhook",
+			err,
+			map[string]any{"webhook_id": id},
+		)
+	}
+	return &webhook, nil
+}
+
+func (r *MongoWebhookRepository) GetWebhooks(
+	ctx context.Context,
+	userID string,
+) ([]model.Webhook, error) {
+	return r.findWebhooks(ctx, bson.M{"userid": userID})
+}
+
+func (r *MongoWebhookRepository) GetWebhooksForEvent(
+	ctx context.Context,
+	eventType string,
+) ([]model.Webhook, error) {
+	return r.findWebhooks(ctx, bson.M{"events": eventType})
+}
+
+func (r *MongoWebhookRepository) DeleteWebhook(ctx context.Context, userID, id string) error {
+	result, err := r.webhooks.DeleteOne(ctx, bson.M{"id": id, "userid": userID})
+	if err != nil {
+		return errs.NewDatabaseError(
+			"failed to delete webhook",
+			err,
+			map[string]any{"webhook_id": id},
+		)
+	}
+	if result.DeletedCount == 0 {
+		return webhookNotFound(id)
+	}
+
+	for _, collection := range []*mongo.Collection{r.deliveries, r.pending} {
+		if _, err := collection.DeleteMany(ctx, bson.M{"webhookid": id}); err != nil {
+			return errs.NewDatabaseError(
+				"failed to delete webhook deliveries",
+				err,
+				map[string]any{"webhook_id": id},
+			)
+		}
+	}
+	return nil
+}
+
+func (r *MongoWebhookRepository) AddDelivery(
+	ctx context.Context,
+	delivery model.WebhookDelivery,
+) error {
+	if _, err := r.deliveries.InsertOne(ctx, delivery); err != nil {
+		return errs.NewDatabaseError(
+			"failed to record webhook delivery",
+			err,
+			map[string]any{"webhook_id": delivery.WebhookID, "event_id": delivery.EventID},
+		)
+	}
+	return nil
+}
+
+func (r *MongoWebhookRepository) GetDeliveries(
+	ctx context.Context,
+	webhookID string,
+	limit int,
+) ([]model.WebhookDelivery, error) {
+	cursor, err := r.deliveries.Find(
+		ctx,
+		bson.M{"webhookid": webhookID},
+		options.Find().
+			SetSort(bson.D{{Key: "attemptedat", Value: -1}}).
+			SetLimit(int64(limit)),
+	)
+	if err != nil {
+		return nil, errs.NewDatabaseError(
+			"failed to get webhook deliveries",
+			err,
+			map[string]any{"webhook_id": webhookID},
+		)
+	}
+
+	deliveries := []model.WebhookDelivery{}
+	if err := cursor.All(ctx, &deliveries); err != nil {
+		return nil, errs.NewDatabaseError(
+			"failed to decode webhook deliveries",
+			err,
+			map[string]any{"webhook_id": webhookID},
+		)
+	}
+	return deliveries, nil
+}
+
+func (r *MongoWebhookRepository) AddPendingDelivery(
+	ctx context.Context,
+	pending model.PendingDelivery,
+) error {
+	_, err := r.pending.InsertOne(ctx, pending)
+	// NOTE: The event was relayed again, and is already queued
+	if mongo.IsDuplicateKeyError(err) {
+		return nil
+	}
+	if err != nil {
+		return errs.NewDatabaseError(
+			"failed to queue webhook delivery",
+			err,
+			map[string]any{"webhook_id": pending.WebhookID, "event_id": pending.Event.ID},
+		)
+	}
+	return nil
+}
+
+func (r *MongoWebhookRepository) ClaimPendingDelivery(
+	ctx context.Context,
+	now time.Time,
+	lease time.Duration,
+) (*model.PendingDelivery, error) {
+	var pending model.PendingDelivery
+	err := r.pending.FindOneAndUpdate(
+		ctx,
+		bson.M{"nextattemptat": bson.M{"$lte": now}},
+		bson.M{"$set": bson.M{"nextattemptat": now.Add(lease)}},
+		options.FindOneAndUpdate().SetSort(bson.D{{Key: "nextattemptat", Value: 1}}),
+	).Decode(&pending)
+	if errors.Is(err, mongo.ErrNoDocuments) {
+		return nil, nil
+	}
+	if err != nil {
+		return nil, errs.NewDatabaseError(
+			"failed to claim webhook delivery",
+			err,
+			map[string]any{},
+		)
+	}
+	return &pending, nil
+}
+
+func (r *MongoWebhookRepository) ReschedulePendingDelivery(
+	ctx context.Context,
+	id string,
+	attempts int,
+	next time.Time,
+) error {
+	_, err := r.pending.UpdateOne(
+		ctx,
+		bson.M{"id": id},
+		bson.M{"$set": bson.M{"attempts": attempts, "nextattemptat": next}},
+	)
+	if err != nil {
+		return errs.NewDatabaseError(
+			"failed to reschedule webhook delivery",
+			err,
+			map[string]any{"pending_id": id},
+		)
+	}
+	return nil
+}
+
+func (r *MongoWebhookRepository) DeletePendingDelivery(ctx context.Context, id string) error {
+	if _, err := r.pending.DeleteOne(ctx, bson.M{"id": id}); err != nil {
+		return errs.NewDatabaseError(
+			"failed to delete webhook delivery",
+			err,
+			map[string]any{"pending_id": id},
+		)
+	}
+	return nil
+}
+
+func (r *MongoWebhookRepository) findWebhooks(
+	ctx context.Context,
+	filter bson.M,
+) ([]model.Webhook, error) {
+	cursor, err := r.webhooks.Find(
+		ctx,
+		filter,
+		options.Find().SetSort(bson.D{{Key: "createdat", Value: 1}, {Key: "id", Value: 1}}),
+	)
+	if err != nil {
+		return nil, errs.NewDatabaseError("failed to get webhooks", err, map[string]any{})
+	}
+
+	webhooks := []model.Webhook{}
+	if err := cursor.All(ctx, &webhooks); err != nil {
+		return nil, errs.NewDatabaseError("failed to decode webhooks", err, map[string]any{})
+	}
+	return webhooks, nil
+}
+
+// NOTE: Asserts Interface Implementation
+var _ WebhookRepositoryInterface = (*MongoWebhookRepository)(nil)
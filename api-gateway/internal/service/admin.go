@@ -12,11 +12,22 @@ import (
 
 type AdminService struct {
 	userClient client.UserClientInterface
+	intrClient client.IntersectionClientInterface
+	reconciler *OwnershipReconciler
+	notifier   NotificationServiceInterface
 }
 
-func NewAdminService(uc client.UserClientInterface) AdminServiceInterface {
+func NewAdminService(
+	uc client.UserClientInterface,
+	ic client.IntersectionClientInterface,
+	reconciler *OwnershipReconciler,
+	notifier NotificationServiceInterface,
+) AdminServiceInterface {
 	return &AdminService{
 		userClient: uc,
+		intrClient: ic,
+		reconciler: reconciler,
+		notifier:   notifier,
 	}
 }
 
@@ -99,6 +110,14 @@ func (s *AdminService) UpdateUserByID(
 		return model.User{}, err
 	}
 
+	s.notifier.Notify(
+		ctx,
+		userID,
+		model.NotificationTypeAccountUpdated,
+		"",
+		"An administrator updated your account details",
+	)
+
 	return model.User{
 		ID:              rpcUser.Id,
 		Username:        rpcUser.Name,
@@ -117,20 +136,99 @@ func (s *AdminService) DeleteUserByID(ctx context.Context, userID string) error
 		)
 	}
 
-	_, err := s.userClient.DeleteUser(ctx, userID)
+	return deleteUserWithIntersections(ctx, s.userClient, s.intrClient, userID)
+}
+
+// MakeAdmin grants the user admin rights, which the user service only allows of an admin
+func (s *AdminService) MakeAdmin(ctx context.Context, userID string) error {
+	adminUserID, err := requireAdmin(ctx)
 	if err != nil {
 		return err
 	}
 
+	if _, err := s.userClient.MakeAdmin(ctx, userID, adminUserID); err != nil {
+		return err
+	}
+
+	s.notifier.Notify(
+		ctx,
+		userID,
+		model.NotificationTypeAdminGranted,
+		"",
+		"You have been made an administrator",
+	)
 	return nil
 }
 
+// RemoveAdmin revokes the user's admin rights, which the user service only allows of an
+// admin
+func (s *AdminService) RemoveAdmin(ctx context.Context, userID string) error {
+	adminUserID, err := requireAdmin(ctx)
+	if err != nil {
+		return err
+	}
+
+	if _, err := s.userClient.RemoveAdmin(ctx, userID, adminUserID); err != nil {
+		return err
+	}
+
+	s.notifier.Notify(
+		ctx,
+		userID,
+		model.NotificationTypeAdminRevoked,
+		"",
+		"Your administrator rights have been removed",
+	)
+	return nil
+}
+
+// ReconcileOwnership reports, and with repair set fixes, the intersections that belong to
+// no user and the intersection IDs users hold for intersections that no longer exist
+func (s *AdminService) ReconcileOwnership(
+	ctx context.Context,
+	repair bool,
+) (model.OwnershipReport, error) {
+	role, ok := middleware.GetRole(ctx)
+	if !ok || role != "admin" {
+		return model.OwnershipReport{}, errs.NewForbiddenError(
+			"only admins can access this endpoint",
+			map[string]any{"role": role},
+		)
+	}
+
+	return s.reconciler.Reconcile(ctx, repair)
+}
+
 // AuthServiceInterface creates stub for testing
 type AdminServiceInterface interface {
 	GetAllUsers(ctx context.Context, pgNo, pgSz int) ([]model.User, error)
 	GetUserByID(ctx context.Context, userID string) (model.User, error)
 	UpdateUserByID(ctx context.Context, userID, name, email string) (model.User, error)
 	DeleteUserByID(ctx context.Context, userID string) error
+	MakeAdmin(ctx context.Context, userID string) error
+	RemoveAdmin(ctx context.Context, userID string) error
+	ReconcileOwnership(ctx context.Context, repair bool) (model.OwnershipReport, error)
+}
+
+// requireAdmin returns the ID of the admin making the request
+func requireAdmin(ctx context.Context) (string, error) {
+	role, ok := middleware.GetRole(ctx)
+	if !ok || role != "admin" {
+		return "", errs.NewForbiddenError(
+			"only admins can access this endpoint",
+			map[string]any{"role": role},
+		)
+	}
+
+	adminUserID, ok := middleware.GetUserID(ctx)
+	if !ok {
+		return "", errs.NewInternalError(
+			"user ID missing inside of handler",
+			nil,
+			map[string]any{},
+		)
+	}
+	return adminUserID, nil
 }
 
 // NOTE: Asserts Interface Implementation
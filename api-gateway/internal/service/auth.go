@@ -6,6 +6,7 @@ import (
 	"github.com/COS301-SE-2025/Swift-Signals/api-gateway/internal/client"
 	"github.com/COS301-SE-2025/Swift-Signals/api-gateway/internal/middleware"
 	"github.com/COS301-SE-2025/Swift-Signals/api-gateway/internal/model"
+	userpb "github.com/COS301-SE-2025/Swift-Signals/protos/gen/swiftsignals/user/v1"
 )
 
 type AuthService struct {
@@ -54,22 +55,43 @@ func (s *AuthService) LoginUser(
 		return model.LoginResponse{}, err
 	}
 
-	resp := model.LoginResponse{
-		Message: "Login Successful",
-		Token:   loginResp.Token,
-	}
+	resp := loginResponse(loginResp)
+	resp.Message = "Login Successful"
 	return resp, nil
 }
 
-func (s *AuthService) LogoutUser(ctx context.Context, token string) (model.LogoutResponse, error) {
+// RefreshToken exchanges the refresh token for new tokens, after which it cannot be used
+// again
+func (s *AuthService) RefreshToken(
+	ctx context.Context,
+	req model.RefreshRequest,
+) (model.LoginResponse, error) {
 	logger := middleware.LoggerFromContext(ctx).With(
 		"service", "auth",
 	)
 
-	logger.Debug("calling user client to logout user",
-		"token", token,
+	logger.Debug("calling user client to refresh token")
+	refreshResp, err := s.userClient.RefreshToken(ctx, req.RefreshToken)
+	if err != nil {
+		return model.LoginResponse{}, err
+	}
+
+	resp := loginResponse(refreshResp)
+	resp.Message = "Refresh Successful"
+	return resp, nil
+}
+
+func (s *AuthService) LogoutUser(
+	ctx context.Context,
+	token string,
+	req model.LogoutRequest,
+) (model.LogoutResponse, error) {
+	logger := middleware.LoggerFromContext(ctx).With(
+		"service", "auth",
 	)
-	_, err := s.userClient.LogoutUser(ctx, token)
+
+	logger.Debug("calling user client to logout user")
+	_, err := s.userClient.LogoutUser(ctx, token, req.RefreshToken)
 	if err != nil {
 		return model.LogoutResponse{}, err
 	}
@@ -80,11 +102,82 @@ func (s *AuthService) LogoutUser(ctx context.Context, token string) (model.Logou
 	return resp, nil
 }
 
+// ResetPassword asks for a password reset email. The response is the same whether or not
+// anyone uses the email, so that it cannot be used to find out who is registered.
+func (s *AuthService) ResetPassword(
+	ctx context.Context,
+	req model.ResetPasswordRequest,
+) (model.ResetPasswordResponse, error) {
+	logger := middleware.LoggerFromContext(ctx).With(
+		"service", "auth",
+	)
+
+	logger.Debug("calling user client to reset password")
+	_, err := s.userClient.ResetPassword(ctx, req.Email)
+	if err != nil {
+		return model.ResetPasswordResponse{}, err
+	}
+
+	resp := model.ResetPasswordResponse{
+		Message: "If an account uses that email, a password reset link has been sent to it",
+	}
+	return resp, nil
+}
+
+// ConfirmPasswordReset sets a new password with the token from a reset email, which logs
+// the user out everywhere
+func (s *AuthService) ConfirmPasswordReset(
+	ctx context.Context,
+	req model.ConfirmResetPasswordRequest,
+) (model.ConfirmResetPasswordResponse, error) {
+	logger := middleware.LoggerFromContext(ctx).With(
+		"service", "auth",
+	)
+
+	logger.Debug("calling user client to confirm password reset")
+	_, err := s.userClient.ConfirmPasswordReset(ctx, req.Token, req.NewPassword)
+	if err != nil {
+		return model.ConfirmResetPasswordResponse{}, err
+	}
+
+	resp := model.ConfirmResetPasswordResponse{
+		Message: "Password Reset Successful",
+	}
+	return resp, nil
+}
+
+func loginResponse(resp *userpb.LoginUserResponse) model.LoginResponse {
+	loginResp := model.LoginResponse{
+		Token:        resp.GetToken(),
+		RefreshToken: resp.GetRefreshToken(),
+	}
+	if resp.GetExpiresAt() != nil {
+		loginResp.ExpiresAt = resp.GetExpiresAt().AsTime()
+	}
+	if resp.GetRefreshExpiresAt() != nil {
+		loginResp.RefreshExpiresAt = resp.GetRefreshExpiresAt().AsTime()
+	}
+	return loginResp
+}
+
 // AuthServiceInterface creates stub for testing
 type AuthServiceInterface interface {
 	RegisterUser(ctx context.Context, req model.RegisterRequest) (model.RegisterResponse, error)
 	LoginUser(ctx context.Context, req model.LoginRequest) (model.LoginResponse, error)
-	LogoutUser(ctx context.Context, token string) (model.LogoutResponse, error)
+	RefreshToken(ctx context.Context, req model.RefreshRequest) (model.LoginResponse, error)
+	LogoutUser(
+		ctx context.Context,
+		token string,
+		req model.LogoutRequest,
+	) (model.LogoutResponse, error)
+	ResetPassword(
+		ctx context.Context,
+		req model.ResetPasswordRequest,
+	) (model.ResetPasswordResponse, error)
+	ConfirmPasswordReset(
+		ctx context.Context,
+		req model.ConfirmResetPasswordRequest,
+	) (model.ConfirmResetPasswordResponse, error)
 }
 
 // NOTE: Asserts Interface Implementation
@@ -0,0 +1,292 @@
+package service
+
+import (
+	"context"
+	"math"
+
+	"github.com/COS301-SE-2025/Swift-Signals/api-gateway/internal/middleware"
+	"github.com/COS301-SE-2025/Swift-Signals/api-gateway/internal/model"
+	"github.com/COS301-SE-2025/Swift-Signals/api-gateway/internal/util"
+	errs "github.com/COS301-SE-2025/Swift-Signals/shared/error"
+	"golang.org/x/sync/errgroup"
+)
+
+// simulationMetric reads one metric out of simulation results, along with the direction in
+// which it improves
+type simulationMetric struct {
+	name      string
+	direction string
+	value     func(results model.SimulationResults) float64
+}
+
+var simulationMetrics = []simulationMetric{
+	{
+		name:      model.MetricTotalVehicles,
+		direction: model.MetricDirectionHigher,
+		value:     func(r model.SimulationResults) float64 { return float64(r.TotalVehicles) },
+	},
+	{
+		name:      model.MetricAverageTravelTime,
+		direction: model.MetricDirectionLower,
+		value:     func(r model.SimulationResults) float64 { return r.AverageTravelTime },
+	},
+	{
+		name:      model.MetricTotalTravelTime,
+		direction: model.MetricDirectionLower,
+		value:     func(r model.SimulationResults) float64 { return r.TotalTravelTime },
+	},
+	{
+		name:      model.MetricAverageSpeed,
+		direction: model.MetricDirectionHigher,
+		value:     func(r model.SimulationResults) float64 { return r.AverageSpeed },
+	},
+	{
+		name:      model.MetricAverageWaitingTime,
+		direction: model.MetricDirectionLower,
+		value:     func(r model.SimulationResults) float64 { return r.AverageWaitingTime },
+	},
+	{
+		name:      model.MetricTotalWaitingTime,
+		direction: model.MetricDirectionLower,
+		value:     func(r model.SimulationResults) float64 { return r.TotalWaitingTime },
+	},
+	{
+		name:      model.MetricGeneratedVehicles,
+		direction: model.MetricDirectionNone,
+		value:     func(r model.SimulationResults) float64 { return float64(r.GeneratedVehicles) },
+	},
+	{
+		name:      model.MetricEmergencyBrakes,
+		direction: model.MetricDirectionLower,
+		value:     func(r model.SimulationResults) float64 { return float64(r.EmergencyBrakes) },
+	},
+	{
+		name:      model.MetricEmergencyStops,
+		direction: model.MetricDirectionLower,
+		value:     func(r model.SimulationResults) float64 { return float64(r.EmergencyStops) },
+	},
+	{
+		name:      model.MetricNearCollisions,
+		direction: model.MetricDirectionLower,
+		value:     func(r model.SimulationResults) float64 { return float64(r.NearCollisions) },
+	},
+}
+
+// CompareOptimisation compares the default parameters of an intersection with its
+// optimised ones by simulating both, over the given number of seeds when more than one
+func (s *SimulationService) CompareOptimisation(
+	ctx context.Context,
+	intersectionID string,
+	replications int,
+) (model.Comparison, error) {
+	logger := middleware.LoggerFromContext(ctx).With(
+		"service", "simulation",
+	)
+
+	if replications > 1 {
+		if err := s.validateReplications(replications); err != nil {
+			return model.Comparison{}, err
+		}
+	}
+
+	if _, err := s.checkIntersectionAccess(ctx, intersectionID); err != nil {
+		return model.Comparison{}, err
+	}
+
+	logger.Debug("calling intersection service to get simulation parameters")
+	intersection, err := s.intrClient.GetIntersection(ctx, intersectionID)
+	if err != nil {
+		return model.Comparison{}, err
+	}
+	if intersection.BestParameters == nil {
+		return model.Comparison{}, errs.NewNotFoundError(
+			"no optimised parameters found for this intersection",
+			map[string]any{"intersectionID": intersectionID},
+		)
+	}
+
+	baseline := model.ComparedSimulation{
+		Source:     model.ComparisonSourceDefault,
+		Parameters: util.RPCSimParamToSimParam(intersection.DefaultParameters.Parameters),
+	}
+	candidate := model.ComparedSimulation{
+		Source:     model.ComparisonSourceOptimised,
+		Parameters: util.RPCSimParamToSimParam(intersection.BestParameters.Parameters),
+	}
+	err = s.simulateBoth(ctx, intersectionID, replications, &baseline, &candidate)
+	if err != nil {
+		return model.Comparison{}, err
+	}
+
+	return compare(intersectionID, baseline, candidate), nil
+}
+
+// CompareRuns compares the metrics of two stored runs of an intersection without
+// simulating either again
+func (s *SimulationService) CompareRuns(
+	ctx context.Context,
+	intersectionID, baselineRunID, candidateRunID string,
+) (model.Comparison, error) {
+	logger := middleware.LoggerFromContext(ctx).With(
+		"service", "simulation",
+	)
+
+	if _, err := s.checkIntersectionAccess(ctx, intersectionID); err != nil {
+		return model.Comparison{}, err
+	}
+
+	compared := make([]model.ComparedSimulation, 2)
+	for i, runID := range []string{baselineRunID, candidateRunID} {
+		logger.Debug("calling intersection service to get run", "runID", runID)
+		rpcRun, err := s.intrClient.GetRun(ctx, intersectionID, runID)
+		if err != nil {
+			return model.Comparison{}, err
+		}
+
+		run := util.RPCRunToRun(rpcRun)
+		if run.Metrics == nil {
+			return model.Comparison{}, errs.NewValidationError(
+				"run has no metrics to compare",
+				map[string]any{"runID": runID, "outcome": run.Outcome},
+			)
+		}
+		compared[i] = model.ComparedSimulation{
+			Source:     model.ComparisonSourceRun,
+			RunID:      run.ID,
+			Parameters: run.Parameters.SimulationParameters,
+			Results:    *run.Metrics,
+		}
+	}
+
+	return compare(intersectionID, compared[0], compared[1]), nil
+}
+
+// CompareParameters compares two ad-hoc parameter sets for an intersection by simulating
+// both, over the given number of seeds when more than one. Like what-if simulations,
+// nothing is saved.
+func (s *SimulationService) CompareParameters(
+	ctx context.Context,
+	intersectionID string,
+	baselineParams, candidateParams model.SimulationParameters,
+	replications int,
+) (model.Comparison, error) {
+	if replications > 1 {
+		if err := s.validateReplications(replications); err != nil {
+			return model.Comparison{}, err
+		}
+	}
+
+	if _, err := s.checkIntersectionAccess(ctx, intersectionID); err != nil {
+		return model.Comparison{}, err
+	}
+
+	baseline := model.ComparedSimulation{
+		Source:     model.ComparisonSourceParameters,
+		Parameters: baselineParams,
+	}
+	candidate := model.ComparedSimulation{
+		Source:     model.ComparisonSourceParameters,
+		Parameters: candidateParams,
+	}
+	err := s.simulateBoth(ctx, intersectionID, replications, &baseline, &candidate)
+	if err != nil {
+		return model.Comparison{}, err
+	}
+
+	return compare(intersectionID, baseline, candidate), nil
+}
+
+// simulateBoth fills in the results of both sides of a comparison. Single simulations run
+// concurrently, while replicated sides run one after the other so that the number of
+// simulations at a time stays within ReplicationConfig.Concurrency.
+func (s *SimulationService) simulateBoth(
+	ctx context.Context,
+	intersectionID string,
+	replications int,
+	baseline, candidate *model.ComparedSimulation,
+) error {
+	logger := middleware.LoggerFromContext(ctx)
+
+	if replications > 1 {
+		for _, compared := range []*model.ComparedSimulation{baseline, candidate} {
+			replicated, err := s.replicate(ctx, intersectionID, compared.Parameters, replications)
+			if err != nil {
+				return err
+			}
+			compared.Replications = &replicated
+			compared.Results = meanResults(replicated)
+		}
+		return nil
+	}
+
+	g, gctx := errgroup.WithContext(ctx)
+	for _, compared := range []*model.ComparedSimulation{baseline, candidate} {
+		g.Go(func() error {
+			logger.Debug("calling simulation service to run compared simulation",
+				"source", compared.Source,
+			)
+			results, err := s.simClient.GetSimulationResults(gctx, intersectionID, compared.Parameters)
+			if err != nil {
+				return err
+			}
+			compared.Results = util.RPCSimResultsToSimResults(results)
+			return nil
+		})
+	}
+	return g.Wait()
+}
+
+func compare(
+	intersectionID string,
+	baseline, candidate model.ComparedSimulation,
+) model.Comparison {
+	metrics := make([]model.MetricComparison, 0, len(simulationMetrics))
+	for _, metric := range simulationMetrics {
+		metrics = append(metrics, compareMetric(metric, baseline, candidate))
+	}
+
+	return model.Comparison{
+		IntersectionID: intersectionID,
+		Baseline:       baseline,
+		Candidate:      candidate,
+		Metrics:        metrics,
+	}
+}
+
+func compareMetric(
+	metric simulationMetric,
+	baseline, candidate model.ComparedSimulation,
+) model.MetricComparison {
+	comparison := model.MetricComparison{
+		Metric:    metric.name,
+		Direction: metric.direction,
+		Baseline:  metric.value(baseline.Results),
+		Candidate: metric.value(candidate.Results),
+	}
+
+	// NOTE: Without overlapping confidence intervals the change is unlikely to be down to
+	// the seeds alone
+	significant := true
+	if baseline.Replications != nil && candidate.Replications != nil {
+		baselineSummary := baseline.Replications.Metrics[metric.name]
+		candidateSummary := candidate.Replications.Metrics[metric.name]
+		comparison.Baseline = baselineSummary.Mean
+		comparison.Candidate = candidateSummary.Mean
+		significant = candidateSummary.CIUpper < baselineSummary.CILower ||
+			candidateSummary.CILower > baselineSummary.CIUpper
+	}
+
+	comparison.Delta = comparison.Candidate - comparison.Baseline
+	if comparison.Baseline != 0 {
+		percent := comparison.Delta / math.Abs(comparison.Baseline) * 100
+		comparison.DeltaPercent = &percent
+	}
+
+	switch metric.direction {
+	case model.MetricDirectionLower:
+		comparison.Improved = significant && comparison.Delta < 0
+	case model.MetricDirectionHigher:
+		comparison.Improved = significant && comparison.Delta > 0
+	}
+	return comparison
+}
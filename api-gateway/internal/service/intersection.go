@@ -6,6 +6,7 @@ import (
 	"slices"
 	"strings"
 
+	"github.com/COS301-SE-2025/Swift-Signals/api-gateway/internal/cache"
 	"github.com/COS301-SE-2025/Swift-Signals/api-gateway/internal/client"
 	"github.com/COS301-SE-2025/Swift-Signals/api-gateway/internal/middleware"
 	"github.com/COS301-SE-2025/Swift-Signals/api-gateway/internal/model"
@@ -15,19 +16,19 @@ import (
 
 type IntersectionService struct {
 	intrClient client.IntersectionClientInterface
-	optiClient client.OptimisationClientInterface
 	userClient client.UserClientInterface
+	simCache   cache.SimulationCacheInterface
 }
 
 func NewIntersectionService(
 	ic client.IntersectionClientInterface,
-	oc client.OptimisationClientInterface,
 	uc client.UserClientInterface,
+	simCache cache.SimulationCacheInterface,
 ) IntersectionServiceInterface {
 	return &IntersectionService{
 		intrClient: ic,
-		optiClient: oc,
 		userClient: uc,
+		simCache:   simCache,
 	}
 }
 
@@ -126,7 +127,7 @@ func (s *IntersectionService) CreateIntersection(
 			SimulationParameters: req.DefaultParameters,
 		},
 	}
-	intrResp, err := s.intrClient.CreateIntersection(ctx, intersection)
+	intrResp, err := s.intrClient.CreateIntersection(ctx, intersection, userId)
 	if err != nil {
 		return model.CreateIntersectionResponse{}, err
 	}
@@ -134,6 +135,15 @@ func (s *IntersectionService) CreateIntersection(
 	logger.Debug("calling user client to add intersection id")
 	_, err = s.userClient.AddIntersectionID(ctx, userId, intrResp.Id)
 	if err != nil {
+		// NOTE: Compensates for the created intersection, which no user would own. Should
+		// that fail too, the ownership reconciler finds the orphan.
+		logger.Debug("calling intersection client to delete unowned intersection")
+		if _, delErr := s.intrClient.DeleteIntersection(ctx, intrResp.Id, 0); delErr != nil {
+			logger.Error("could not delete unowned intersection",
+				"intersection_id", intrResp.Id,
+				"error", delErr.Error(),
+			)
+		}
 		return model.CreateIntersectionResponse{}, err
 	}
 
@@ -148,6 +158,7 @@ func (s *IntersectionService) UpdateIntersectionByID(
 	userID string,
 	intersectionID string,
 	req model.UpdateIntersectionRequest,
+	expectedVersion int,
 ) (model.Intersection, error) {
 	logger := middleware.LoggerFromContext(ctx).With(
 		"service", "intersection",
@@ -166,12 +177,52 @@ func (s *IntersectionService) UpdateIntersectionByID(
 		)
 	}
 
+	name, details := req.Name, model.Details(req.Details)
+	var defaultParameters *model.OptimisationParameters
+	if name == "" || details == (model.Details{}) || req.DefaultParameters != nil {
+		logger.Debug("calling intersection client to get current intersection")
+		current, err := s.intrClient.GetIntersection(ctx, intersectionID)
+		if err != nil {
+			return model.Intersection{}, err
+		}
+
+		// NOTE: Omitted fields keep their current values, which only holds while nobody
+		// has written the intersection since they were read
+		if expectedVersion == 0 {
+			expectedVersion = int(current.Version)
+		}
+		if name == "" {
+			name = current.Name
+		}
+		if details == (model.Details{}) {
+			details = util.RPCDetailsToDetails(current.Details)
+		}
+		if req.DefaultParameters != nil {
+			defaultParameters = &model.OptimisationParameters{
+				OptimisationType:     current.GetDefaultParameters().GetOptimisationType().String(),
+				SimulationParameters: *req.DefaultParameters,
+			}
+		}
+	}
+
 	logger.Debug("calling intersection client to update intersection")
-	pbResp, err := s.intrClient.UpdateIntersection(ctx, intersectionID, req.Name, req.Details)
+	pbResp, err := s.intrClient.UpdateIntersection(
+		ctx,
+		intersectionID,
+		name,
+		details,
+		req.TrafficDensity,
+		defaultParameters,
+		userID,
+		expectedVersion,
+	)
 	if err != nil {
 		return model.Intersection{}, err
 	}
 
+	logger.Debug("invalidating cached simulations of updated intersection")
+	s.simCache.InvalidateIntersection(ctx, intersectionID)
+
 	resp := util.RPCIntersectionToIntersection(pbResp)
 	return resp, nil
 }
@@ -180,6 +231,7 @@ func (s *IntersectionService) DeleteIntersectionByID(
 	ctx context.Context,
 	userID string,
 	intersectionID string,
+	expectedVersion int,
 ) error {
 	logger := middleware.LoggerFromContext(ctx).With(
 		"service", "intersection",
@@ -198,62 +250,17 @@ func (s *IntersectionService) DeleteIntersectionByID(
 		)
 	}
 
-	logger.Debug("calling user client to remove intersection id")
-	_, err = s.userClient.RemoveIntersectionID(ctx, userID, intersectionID)
-	if err != nil {
-		return err
-	}
-
+	// NOTE: The intersection stays in the user's list while it is in the trash, so that
+	// it can be listed and restored
 	logger.Debug("calling intersection client to delete intersection")
-	_, err = s.intrClient.DeleteIntersection(ctx, intersectionID)
-	return err
-}
-
-func (s *IntersectionService) OptimiseIntersectionByID(
-	ctx context.Context,
-	userID string,
-	intersectionID string,
-) error {
-	logger := middleware.LoggerFromContext(ctx).With(
-		"service", "intersection",
-	)
-
-	logger.Debug("calling user service to retrieve user's intersection list")
-	ids, err := s.GetUserIntersectionIDs(ctx, userID)
-	if err != nil {
-		return err
-	}
-
-	if !slices.Contains(ids, intersectionID) {
-		return errs.NewForbiddenError(
-			"intersection not in user's intersection list",
-			map[string]any{},
-		)
-	}
-
-	logger.Debug("calling intersection client to retrieve parameters")
-	intersection, err := s.intrClient.GetIntersection(ctx, intersectionID)
+	_, err = s.intrClient.DeleteIntersection(ctx, intersectionID, expectedVersion)
 	if err != nil {
 		return err
 	}
 
-	logger.Debug("calling optimisation client to optimise intersection")
-	optimisedParams, err := s.optiClient.RunOptimisation(
-		ctx,
-		util.RPCOptiParamToOptiParam(intersection.DefaultParameters),
-	)
-	if err != nil {
-		return err
-	}
-
-	logger.Debug("calling intersection client to update optimised parameters")
-	_, err = s.intrClient.PutOptimisation(
-		ctx,
-		intersectionID,
-		util.RPCOptiParamToOptiParamOp(optimisedParams),
-	)
-
-	return err
+	logger.Debug("invalidating cached simulations of deleted intersection")
+	s.simCache.InvalidateIntersection(ctx, intersectionID)
+	return nil
 }
 
 /******************/
@@ -306,9 +313,33 @@ type IntersectionServiceInterface interface {
 		userID string,
 		intersectionID string,
 		req model.UpdateIntersectionRequest,
+		expectedVersion int,
+	) (model.Intersection, error)
+	DeleteIntersectionByID(
+		ctx context.Context,
+		userID string,
+		intersectionID string,
+		expectedVersion int,
+	) error
+	GetDeletedIntersections(ctx context.Context, userID string) (model.Intersections, error)
+	RestoreIntersectionByID(
+		ctx context.Context,
+		userID string,
+		intersectionID string,
+	) (model.Intersection, error)
+	GetParameterVersions(
+		ctx context.Context,
+		userID string,
+		intersectionID string,
+		page, pageSize int,
+	) (model.ParameterVersions, error)
+	RevertParameterVersion(
+		ctx context.Context,
+		userID string,
+		intersectionID string,
+		version int,
+		expectedVersion int,
 	) (model.Intersection, error)
-	DeleteIntersectionByID(ctx context.Context, userID string, intersectionID string) error
-	OptimiseIntersectionByID(ctx context.Context, userID string, intersectionID string) error
 }
 
 // NOTE: Asserts Interface Implementation
@@ -0,0 +1,129 @@
+package service
+
+import (
+	"context"
+	"time"
+
+	"github.com/COS301-SE-2025/Swift-Signals/api-gateway/internal/middleware"
+	"github.com/COS301-SE-2025/Swift-Signals/api-gateway/internal/model"
+	"github.com/COS301-SE-2025/Swift-Signals/api-gateway/internal/repository"
+	"github.com/google/uuid"
+)
+
+type NotificationService struct {
+	repo repository.NotificationRepositoryInterface
+}
+
+func NewNotificationService(
+	repo repository.NotificationRepositoryInterface,
+) NotificationServiceInterface {
+	return &NotificationService{
+		repo: repo,
+	}
+}
+
+// Notify adds a notification to the user's inbox. It is best effort: a notification that
+// cannot be saved is logged rather than failing whatever it was about.
+func (s *NotificationService) Notify(
+	ctx context.Context,
+	userID, notificationType, intersectionID, message string,
+) {
+	logger := middleware.LoggerFromContext(ctx).With(
+		"service", "notification",
+		"user_id", userID,
+		"type", notificationType,
+	)
+
+	notification := model.Notification{
+		ID:             uuid.NewString(),
+		UserID:         userID,
+		Type:           notificationType,
+		Message:        message,
+		IntersectionID: intersectionID,
+		CreatedAt:      time.Now().UTC().Truncate(time.Millisecond),
+	}
+
+	logger.Debug("saving notification")
+	if err := s.repo.CreateNotification(ctx, notification); err != nil {
+		logger.Warn("failed to save notification", "error", err)
+	}
+}
+
+// GetNotifications returns the user's most recent notifications, newest first, along with
+// how many of all their notifications are unread
+func (s *NotificationService) GetNotifications(
+	ctx context.Context,
+	userID string,
+	limit int,
+) (model.Notifications, error) {
+	logger := middleware.LoggerFromContext(ctx).With(
+		"service", "notification",
+	)
+
+	logger.Debug("retrieving user's notifications")
+	notifications, err := s.repo.GetNotifications(ctx, userID, limit)
+	if err != nil {
+		return model.Notifications{}, err
+	}
+
+	logger.Debug("counting user's unread notifications")
+	unread, err := s.repo.CountUnread(ctx, userID)
+	if err != nil {
+		return model.Notifications{}, err
+	}
+
+	return model.Notifications{Notifications: notifications, UnreadCount: unread}, nil
+}
+
+func (s *NotificationService) CountUnread(ctx context.Context, userID string) (int, error) {
+	logger := middleware.LoggerFromContext(ctx).With(
+		"service", "notification",
+	)
+
+	logger.Debug("counting user's unread notifications")
+	return s.repo.CountUnread(ctx, userID)
+}
+
+func (s *NotificationService) MarkRead(ctx context.Context, userID, id string) error {
+	logger := middleware.LoggerFromContext(ctx).With(
+		"service", "notification",
+	)
+
+	logger.Debug("marking notification read")
+	return s.repo.MarkRead(ctx, userID, id, time.Now().UTC().Truncate(time.Millisecond))
+}
+
+func (s *NotificationService) MarkAllRead(
+	ctx context.Context,
+	userID string,
+) (model.MarkAllNotificationsReadResponse, error) {
+	logger := middleware.LoggerFromContext(ctx).With(
+		"service", "notification",
+	)
+
+	logger.Debug("marking all notifications read")
+	marked, err := s.repo.MarkAllRead(ctx, userID, time.Now().UTC().Truncate(time.Millisecond))
+	if err != nil {
+		return model.MarkAllNotificationsReadResponse{}, err
+	}
+	return model.MarkAllNotificationsReadResponse{Marked: marked}, nil
+}
+
+// NotificationServiceInterface creates stub for testing
+type NotificationServiceInterface interface {
+	Notify(ctx context.Context, userID, notificationType, intersectionID, message string)
+	GetNotifications(
+		ctx context.Context,
+		userID string,
+		limit int,
+	) (model.Notifications, error)
+	CountUnread(ctx context.Context, userID string) (int, error)
+	MarkRead(ctx context.Context, userID, id string) error
+	MarkAllRead(
+		ctx context.Context,
+		userID string,
+	) (model.MarkAllNotificationsReadResponse, error)
+}
+
+// NOTE: Asserts Interface Implementation
+var _ NotificationServiceInterface = (*NotificationService)(nil)
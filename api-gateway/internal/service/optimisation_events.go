@@ -0,0 +1,73 @@
+package service
+
+import (
+	"sync"
+
+	"github.com/COS301-SE-2025/Swift-Signals/api-gateway/internal/model"
+)
+
+// NOTE: Events are dropped for subscribers that fall this far behind, so a slow client
+// never holds up an optimisation job
+const optimisationEventBuffer = 16
+
+// optimisationEvents fans the events of the optimisation jobs running in this process
+// out to their subscribers, keyed by intersection ID
+type optimisationEvents struct {
+	mu          sync.Mutex
+	subscribers map[string]map[chan model.OptimisationEvent]struct{}
+	// NOTE: The latest progress of each running job is replayed to new subscribers so
+	// they do not wait a whole generation for their first event
+	latest map[string]model.OptimisationEvent
+}
+
+func newOptimisationEvents() *optimisationEvents {
+	return &optimisationEvents{
+		subscribers: make(map[string]map[chan model.OptimisationEvent]struct{}),
+		latest:      make(map[string]model.OptimisationEvent),
+	}
+}
+
+// subscribe returns a channel receiving the optimisation events of an intersection and
+// a function which must be called to stop receiving them
+func (e *optimisationEvents) subscribe(
+	intersectionID string,
+) (<-chan model.OptimisationEvent, func()) {
+	e.mu.Lock()
+	defer e.mu.Unlock()
+
+	ch := make(chan model.OptimisationEvent, optimisationEventBuffer)
+	if e.subscribers[intersectionID] == nil {
+		e.subscribers[intersectionID] = make(map[chan model.OptimisationEvent]struct{})
+	}
+	e.subscribers[intersectionID][ch] = struct{}{}
+	if event, ok := e.latest[intersectionID]; ok {
+		ch <- event
+	}
+
+	return ch, func() {
+		e.mu.Lock()
+		defer e.mu.Unlock()
+		delete(e.subscribers[intersectionID], ch)
+		if len(e.subscribers[intersectionID]) == 0 {
+			delete(e.subscribers, intersectionID)
+		}
+	}
+}
+
+func (e *optimisationEvents) publish(intersectionID string, event model.OptimisationEvent) {
+	e.mu.Lock()
+	defer e.mu.Unlock()
+
+	if event.Type == model.OptimisationEventProgress {
+		e.latest[intersectionID] = event
+	} else {
+		delete(e.latest, intersectionID)
+	}
+
+	for ch := range e.subscribers[intersectionID] {
+		select {
+		case ch <- event:
+		default:
+		}
+	}
+}
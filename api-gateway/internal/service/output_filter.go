@@ -0,0 +1,79 @@
+package service
+
+import (
+	"slices"
+
+	"github.com/COS301-SE-2025/Swift-Signals/api-gateway/internal/model"
+	simulationpb "github.com/COS301-SE-2025/Swift-Signals/protos/gen/swiftsignals/simulation/v1"
+)
+
+// filterVehicles narrows down vehicle trajectories to those the filter asks for. Vehicles
+// left without positions by the time window are dropped. It runs on the simulation
+// service's vehicles, so that unwanted trajectories are never converted.
+func filterVehicles(
+	vehicles []*simulationpb.Vehicle,
+	filter model.OutputFilter,
+) []*simulationpb.Vehicle {
+	if isEmptyOutputFilter(filter) {
+		return vehicles
+	}
+
+	filtered := make([]*simulationpb.Vehicle, 0, len(vehicles))
+	for _, vehicle := range vehicles {
+		if len(filter.VehicleIDs) > 0 && !slices.Contains(filter.VehicleIDs, vehicle.Id) {
+			continue
+		}
+
+		positions := filterPositions(vehicle.Positions, filter)
+		if len(positions) == 0 && (filter.From != nil || filter.To != nil) {
+			continue
+		}
+		filtered = append(filtered, &simulationpb.Vehicle{
+			Id:        vehicle.Id,
+			Positions: positions,
+		})
+	}
+	return filtered
+}
+
+func filterPositions(
+	positions []*simulationpb.Position,
+	filter model.OutputFilter,
+) []*simulationpb.Position {
+	filtered := make([]*simulationpb.Position, 0, len(positions))
+	for _, position := range positions {
+		time := int(position.Time)
+		if filter.From != nil && time < *filter.From {
+			continue
+		}
+		if filter.To != nil && time > *filter.To {
+			continue
+		}
+		if filter.SampleEvery > 1 && len(filtered) > 0 &&
+			time < int(filtered[len(filtered)-1].Time)+filter.SampleEvery {
+			continue
+		}
+		filtered = append(filtered, position)
+	}
+	return filtered
+}
+
+// omitSpeeds drops the speed of every position once converted, as the simulation service
+// always sends one
+func omitSpeeds(vehicles []model.SimulationVehicle, filter model.OutputFilter) {
+	if !filter.OmitSpeed {
+		return
+	}
+	for _, vehicle := range vehicles {
+		for i := range vehicle.Positions {
+			vehicle.Positions[i].Speed = nil
+		}
+	}
+}
+
+func isEmptyOutputFilter(filter model.OutputFilter) bool {
+	return filter.From == nil &&
+		filter.To == nil &&
+		len(filter.VehicleIDs) == 0 &&
+		filter.SampleEvery <= 1
+}
@@ -0,0 +1,300 @@
+package service
+
+import (
+	"context"
+	"errors"
+	"io"
+	"time"
+
+	"github.com/COS301-SE-2025/Swift-Signals/api-gateway/internal/client"
+	"github.com/COS301-SE-2025/Swift-Signals/api-gateway/internal/middleware"
+	"github.com/COS301-SE-2025/Swift-Signals/api-gateway/internal/model"
+	errs "github.com/COS301-SE-2025/Swift-Signals/shared/error"
+)
+
+// NOTE: The largest page either service will stream
+const reconcilePageSize = 100
+
+// OwnershipReconciler compares the intersection lists kept by the user service with the
+// intersections kept by the intersection service, which no transaction spans
+type OwnershipReconciler struct {
+	userClient client.UserClientInterface
+	intrClient client.IntersectionClientInterface
+	// gracePeriod spares intersections created so recently that they may not have been
+	// added to their owner's list yet
+	gracePeriod time.Duration
+}
+
+func NewOwnershipReconciler(
+	uc client.UserClientInterface,
+	ic client.IntersectionClientInterface,
+	gracePeriod time.Duration,
+) *OwnershipReconciler {
+	return &OwnershipReconciler{
+		userClient:  uc,
+		intrClient:  ic,
+		gracePeriod: gracePeriod,
+	}
+}
+
+// Reconcile reports the intersections that belong to no user and the intersection IDs that
+// users hold for intersections which no longer exist. With repair set, orphans are moved
+// to the trash and dangling IDs are removed from their users' lists.
+func (r *OwnershipReconciler) Reconcile(
+	ctx context.Context,
+	repair bool,
+) (model.OwnershipReport, error) {
+	logger := middleware.LoggerFromContext(ctx).With(
+		"service", "ownership",
+	)
+
+	// NOTE: Users are read first. An intersection created in between then shows up as an
+	// orphan, which the grace period spares, rather than as a dangling ID.
+	logger.Debug("calling user client to retrieve all intersection lists")
+	owned, err := r.getOwnedIntersectionIDs(ctx)
+	if err != nil {
+		return model.OwnershipReport{}, err
+	}
+
+	logger.Debug("calling intersection client to retrieve all intersections")
+	cutoff := time.Now().Add(-r.gracePeriod)
+	existing := map[string]bool{}
+	orphans := []string{}
+	err = r.forEachIntersection(ctx, func(id string, createdAt time.Time, deleted bool) {
+		existing[id] = true
+		// NOTE: Trashed orphans are left for the purge
+		if _, ok := owned[id]; !ok && !deleted && createdAt.Before(cutoff) {
+			orphans = append(orphans, id)
+		}
+	})
+	if err != nil {
+		return model.OwnershipReport{}, err
+	}
+
+	report := model.OwnershipReport{
+		OrphanedIntersections:   orphans,
+		DanglingIntersectionIDs: map[string][]string{},
+		CheckedAt:               time.Now(),
+	}
+	for id, userIDs := range owned {
+		if existing[id] {
+			continue
+		}
+		for _, userID := range userIDs {
+			report.DanglingIntersectionIDs[userID] = append(
+				report.DanglingIntersectionIDs[userID],
+				id,
+			)
+		}
+	}
+
+	if !repair {
+		return report, nil
+	}
+
+	report.Repaired = true
+	for _, id := range report.OrphanedIntersections {
+		logger.Debug("calling intersection client to delete orphaned intersection")
+		if _, err := r.intrClient.DeleteIntersection(ctx, id, 0); err != nil && !isNotFound(err) {
+			logger.Warn("could not delete orphaned intersection",
+				"intersection_id", id,
+				"error", err.Error(),
+			)
+			report.Repaired = false
+		}
+	}
+	for userID, ids := range report.DanglingIntersectionIDs {
+		logger.Debug("calling user client to remove dangling intersection IDs")
+		if _, err := r.userClient.RemoveIntersectionIDs(ctx, userID, ids); err != nil {
+			logger.Warn("could not remove dangling intersection IDs",
+				"user_id", userID,
+				"intersection_ids", ids,
+				"error", err.Error(),
+			)
+			report.Repaired = false
+		}
+	}
+	return report, nil
+}
+
+// getOwnedIntersectionIDs maps every intersection ID in a user's list to the users whose
+// list it is in
+func (r *OwnershipReconciler) getOwnedIntersectionIDs(
+	ctx context.Context,
+) (map[string][]string, error) {
+	owned := map[string][]string{}
+	// NOTE: Each page starts after the last user read rather than at an offset, which a
+	// user deleted in between would shift past one that was never read. The intersections
+	// in that user's list would be taken for orphans and moved to the trash.
+	afterID := ""
+	for {
+		stream, err := r.userClient.GetUsersAfter(ctx, afterID, reconcilePageSize)
+		if err != nil {
+			return nil, err
+		}
+
+		count := 0
+		for {
+			user, err := stream.Recv()
+			if err == io.EOF {
+				break
+			}
+			if err != nil {
+				return nil, errs.NewInternalError("unable to get all users", err, map[string]any{})
+			}
+			count++
+			afterID = user.Id
+			for _, id := range user.IntersectionIds {
+				owned[id] = append(owned[id], user.Id)
+			}
+		}
+		if count < reconcilePageSize {
+			return owned, nil
+		}
+	}
+}
+
+// forEachIntersection calls fn with every intersection, in the trash or not.
+//
+// NOTE: Both are read in one pass by ID, like users are. Reading the trash separately
+// would miss an intersection restored between the two reads, which would then be taken
+// for a dangling ID and removed from its owner's list.
+func (r *OwnershipReconciler) forEachIntersection(
+	ctx context.Context,
+	fn func(id string, createdAt time.Time, deleted bool),
+) error {
+	afterID := ""
+	for {
+		stream, err := r.intrClient.ListIntersectionsAfter(ctx, afterID, reconcilePageSize)
+		if err != nil {
+			return err
+		}
+
+		count := 0
+		for {
+			intersection, err := stream.Recv()
+			if err == io.EOF {
+				break
+			}
+			if err != nil {
+				return errs.NewInternalError(
+					"unable to get all intersections",
+					err,
+					map[string]any{},
+				)
+			}
+			count++
+			afterID = intersection.Id
+			fn(intersection.Id, intersection.CreatedAt.AsTime(), intersection.DeletedAt != nil)
+		}
+		if count < reconcilePageSize {
+			return nil
+		}
+	}
+}
+
+// RunOwnershipReconciler reconciles ownership once straight away and then on every interval
+// until ctx is cancelled
+func RunOwnershipReconciler(
+	ctx context.Context,
+	r *OwnershipReconciler,
+	interval time.Duration,
+	repair bool,
+) {
+	logger := middleware.LoggerFromContext(ctx).With("component", "ownership-reconciler")
+
+	reconcile := func() {
+		report, err := r.Reconcile(ctx, repair)
+		if err != nil {
+			logger.Error("failed to reconcile intersection ownership",
+				"error", err.Error(),
+			)
+			return
+		}
+		if len(report.OrphanedIntersections) > 0 || len(report.DanglingIntersectionIDs) > 0 {
+			logger.Warn("intersection ownership is inconsistent",
+				"orphaned_intersections", report.OrphanedIntersections,
+				"dangling_intersection_ids", report.DanglingIntersectionIDs,
+				"repaired", report.Repaired,
+			)
+		}
+	}
+
+	reconcile()
+
+	ticker := time.NewTicker(interval)
+	defer ticker.Stop()
+	for {
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+			reconcile()
+		}
+	}
+}
+
+// deleteUserWithIntersections deletes a user and the intersections they own as one saga.
+// The intersections go to the trash first, and are restored if the user cannot be deleted.
+func deleteUserWithIntersections(
+	ctx context.Context,
+	userClient client.UserClientInterface,
+	intrClient client.IntersectionClientInterface,
+	userID string,
+) error {
+	logger := middleware.LoggerFromContext(ctx)
+
+	logger.Debug("calling user client to retrieve user's intersection list")
+	user, err := userClient.GetUserByID(ctx, userID)
+	if err != nil {
+		return err
+	}
+
+	trashed := []string{}
+	for _, id := range user.GetIntersectionIds() {
+		logger.Debug("calling intersection client to delete user's intersection")
+		_, err := intrClient.DeleteIntersection(ctx, id, 0)
+		if err != nil {
+			// NOTE: Already in the trash, or purged from it
+			if isNotFound(err) {
+				continue
+			}
+			restoreIntersections(ctx, intrClient, trashed)
+			return err
+		}
+		trashed = append(trashed, id)
+	}
+
+	logger.Debug("calling user client to delete user")
+	_, err = userClient.DeleteUser(ctx, userID)
+	if err != nil {
+		restoreIntersections(ctx, intrClient, trashed)
+		return err
+	}
+	return nil
+}
+
+// restoreIntersections compensates for intersections moved to the trash by a saga that
+// did not complete. Any it cannot restore are left to the reconciler.
+func restoreIntersections(
+	ctx context.Context,
+	intrClient client.IntersectionClientInterface,
+	ids []string,
+) {
+	logger := middleware.LoggerFromContext(ctx)
+
+	for _, id := range ids {
+		logger.Debug("calling intersection client to restore intersection")
+		if _, err := intrClient.RestoreIntersection(ctx, id); err != nil {
+			logger.Error("could not restore intersection",
+				"intersection_id", id,
+				"error", err.Error(),
+			)
+		}
+	}
+}
+
+func isNotFound(err error) bool {
+	var svcErr *errs.ServiceError
+	return errors.As(err, &svcErr) && svcErr.Code == errs.ErrNotFound
+}
@@ -0,0 +1,104 @@
+package service
+
+import (
+	"context"
+	"io"
+	"slices"
+
+	"github.com/COS301-SE-2025/Swift-Signals/api-gateway/internal/middleware"
+	"github.com/COS301-SE-2025/Swift-Signals/api-gateway/internal/model"
+	"github.com/COS301-SE-2025/Swift-Signals/api-gateway/internal/util"
+	errs "github.com/COS301-SE-2025/Swift-Signals/shared/error"
+)
+
+func (s *IntersectionService) GetParameterVersions(
+	ctx context.Context,
+	userID string,
+	intersectionID string,
+	page, pageSize int,
+) (model.ParameterVersions, error) {
+	logger := middleware.LoggerFromContext(ctx).With(
+		"service", "intersection",
+	)
+
+	logger.Debug("calling user service to retrieve user's intersection list")
+	ids, err := s.GetUserIntersectionIDs(ctx, userID)
+	if err != nil {
+		return model.ParameterVersions{}, err
+	}
+
+	if !slices.Contains(ids, intersectionID) {
+		return model.ParameterVersions{}, errs.NewForbiddenError(
+			"intersection not in user's intersection list",
+			map[string]any{},
+		)
+	}
+
+	logger.Debug("calling intersection client to get parameter versions")
+	stream, err := s.intrClient.GetParameterVersions(ctx, intersectionID, page, pageSize)
+	if err != nil {
+		return model.ParameterVersions{}, err
+	}
+
+	result := model.ParameterVersions{
+		Versions: []model.ParameterVersion{},
+		Page:     page,
+		PageSize: pageSize,
+	}
+	for {
+		version, err := stream.Recv()
+		if err == io.EOF {
+			break
+		}
+		if err != nil {
+			return model.ParameterVersions{}, util.GrpcErrorToErr(err)
+		}
+		result.Versions = append(
+			result.Versions,
+			util.RPCParameterVersionToParameterVersion(version),
+		)
+	}
+	return result, nil
+}
+
+func (s *IntersectionService) RevertParameterVersion(
+	ctx context.Context,
+	userID string,
+	intersectionID string,
+	version int,
+	expectedVersion int,
+) (model.Intersection, error) {
+	logger := middleware.LoggerFromContext(ctx).With(
+		"service", "intersection",
+	)
+
+	logger.Debug("calling user service to retrieve user's intersection list")
+	ids, err := s.GetUserIntersectionIDs(ctx, userID)
+	if err != nil {
+		return model.Intersection{}, err
+	}
+
+	if !slices.Contains(ids, intersectionID) {
+		return model.Intersection{}, errs.NewForbiddenError(
+			"intersection not in user's intersection list",
+			map[string]any{},
+		)
+	}
+
+	logger.Debug("calling intersection client to revert parameters")
+	pbResp, err := s.intrClient.RevertParameterVersion(
+		ctx,
+		intersectionID,
+		version,
+		userID,
+		expectedVersion,
+	)
+	if err != nil {
+		return model.Intersection{}, err
+	}
+
+	logger.Debug("invalidating cached simulations of reverted intersection")
+	s.simCache.InvalidateIntersection(ctx, intersectionID)
+
+	return util.RPCIntersectionToIntersection(pbResp), nil
+}
@@ -10,14 +10,24 @@ import (
 
 type ProfileService struct {
 	userClient client.UserClientInterface
+	intrClient client.IntersectionClientInterface
+	notifier   NotificationServiceInterface
 }
 
-func NewProfileService(uc client.UserClientInterface) ProfileServiceInterface {
+func NewProfileService(
+	uc client.UserClientInterface,
+	ic client.IntersectionClientInterface,
+	notifier NotificationServiceInterface,
+) ProfileServiceInterface {
 	return &ProfileService{
 		userClient: uc,
+		intrClient: ic,
+		notifier:   notifier,
 	}
 }
 
+// GetProfile returns the user's profile with how many of their notifications are unread.
+// NOTE: The profile is still returned without the count when the inbox cannot be read
 func (s *ProfileService) GetProfile(ctx context.Context, userID string) (model.User, error) {
 	logger := middleware.LoggerFromContext(ctx).With(
 		"service", "profile",
@@ -29,13 +39,22 @@ func (s *ProfileService) GetProfile(ctx context.Context, userID string) (model.U
 		return model.User{}, err
 	}
 
-	return model.User{
+	user := model.User{
 		ID:              rpcUser.Id,
 		Username:        rpcUser.Name,
 		Email:           rpcUser.Email,
 		IsAdmin:         rpcUser.IsAdmin,
 		IntersectionIDs: rpcUser.IntersectionIds,
-	}, nil
+	}
+
+	logger.Debug("counting user's unread notifications")
+	unread, err := s.notifier.CountUnread(ctx, userID)
+	if err != nil {
+		logger.Warn("failed to count unread notifications", "error", err)
+		return user, nil
+	}
+	user.UnreadNotifications = &unread
+	return user, nil
 }
 
 func (s *ProfileService) UpdateProfile(
@@ -67,13 +86,8 @@ func (s *ProfileService) DeleteProfile(ctx context.Context, userID string) error
 		"service", "profile",
 	)
 
-	logger.Debug("calling user client to delete user profile")
-	_, err := s.userClient.DeleteUser(ctx, userID)
-	if err != nil {
-		return err
-	}
-
-	return nil
+	logger.Debug("deleting user profile along with its intersections")
+	return deleteUserWithIntersections(ctx, s.userClient, s.intrClient, userID)
 }
 
 // ProfileServiceInterface creates stub for testing
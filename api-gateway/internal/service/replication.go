@@ -0,0 +1,229 @@
+package service
+
+import (
+	"context"
+	"math"
+	"slices"
+
+	"github.com/COS301-SE-2025/Swift-Signals/api-gateway/internal/middleware"
+	"github.com/COS301-SE-2025/Swift-Signals/api-gateway/internal/model"
+	"github.com/COS301-SE-2025/Swift-Signals/api-gateway/internal/util"
+	errs "github.com/COS301-SE-2025/Swift-Signals/shared/error"
+	"golang.org/x/sync/errgroup"
+)
+
+// ReplicationConfig bounds simulations that are replicated over several seeds
+type ReplicationConfig struct {
+	// Concurrency is the number of replications simulated at the same time
+	Concurrency int
+	// MaxReplications is the largest number of replications a request may ask for
+	MaxReplications int
+	// OptimisationReplications is the number of replications optimised parameters are
+	// evaluated over before deciding whether they improve on the best ones. A single
+	// replication evaluates them with their own seed only.
+	OptimisationReplications int
+}
+
+// Two-sided 95% critical values of Student's t distribution, indexed by degrees of freedom
+var tCritical95 = []float64{
+	0, 12.706, 4.303, 3.182, 2.776, 2.571, 2.447, 2.365, 2.306, 2.262,
+	2.228, 2.201, 2.179, 2.160, 2.145, 2.131, 2.120, 2.110, 2.101, 2.093,
+	2.086, 2.080, 2.074, 2.069, 2.064, 2.060, 2.056, 2.052, 2.048, 2.045,
+	2.042,
+}
+
+// NOTE: Beyond the table the t distribution is close enough to the normal distribution
+const zCritical95 = 1.96
+
+func (s *SimulationService) ReplicateSimulation(
+	ctx context.Context,
+	intersectionID string,
+	replications int,
+) (model.ReplicatedResults, error) {
+	if err := s.validateReplications(replications); err != nil {
+		return model.ReplicatedResults{}, err
+	}
+
+	_, params, err := s.getSimulationParameters(ctx, intersectionID, false)
+	if err != nil {
+		return model.ReplicatedResults{}, err
+	}
+
+	return s.replicate(
+		ctx,
+		intersectionID,
+		util.RPCSimParamToSimParam(params.Parameters),
+		replications,
+	)
+}
+
+func (s *SimulationService) ReplicateOptimised(
+	ctx context.Context,
+	intersectionID string,
+	replications int,
+) (model.ReplicatedResults, error) {
+	if err := s.validateReplications(replications); err != nil {
+		return model.ReplicatedResults{}, err
+	}
+
+	_, params, err := s.getSimulationParameters(ctx, intersectionID, true)
+	if err != nil {
+		return model.ReplicatedResults{}, err
+	}
+
+	return s.replicate(
+		ctx,
+		intersectionID,
+		util.RPCSimParamToSimParam(params.Parameters),
+		replications,
+	)
+}
+
+func (s *SimulationService) validateReplications(replications int) error {
+	if replications < 2 || replications > s.replication.MaxReplications {
+		return errs.NewValidationError(
+			"replications must be between 2 and the maximum",
+			map[string]any{
+				"replications": replications,
+				"max":          s.replication.MaxReplications,
+			},
+		)
+	}
+	return nil
+}
+
+// replicate simulates the parameters with consecutive seeds starting at their own, at most
+// ReplicationConfig.Concurrency at a time, and summarises the results
+func (s *SimulationService) replicate(
+	ctx context.Context,
+	intersectionID string,
+	params model.SimulationParameters,
+	replications int,
+) (model.ReplicatedResults, error) {
+	logger := middleware.LoggerFromContext(ctx)
+
+	seeds := make([]int, replications)
+	results := make([]model.SimulationResults, replications)
+
+	g, gctx := errgroup.WithContext(ctx)
+	g.SetLimit(max(s.replication.Concurrency, 1))
+	for i := range replications {
+		seeds[i] = params.Seed + i
+		replication := params
+		replication.Seed = seeds[i]
+
+		g.Go(func() error {
+			logger.Debug("calling simulation service to run replication", "seed", replication.Seed)
+			simResults, err := s.simClient.GetSimulationResults(gctx, intersectionID, replication)
+			if err != nil {
+				return err
+			}
+			results[i] = util.RPCSimResultsToSimResults(simResults)
+			return nil
+		})
+	}
+	if err := g.Wait(); err != nil {
+		return model.ReplicatedResults{}, err
+	}
+
+	metrics := make(map[string]model.MetricSummary, len(simulationMetrics))
+	for _, metric := range simulationMetrics {
+		values := make([]float64, replications)
+		for i, result := range results {
+			values[i] = metric.value(result)
+		}
+		metrics[metric.name] = summarise(values)
+	}
+
+	return model.ReplicatedResults{
+		Replications: replications,
+		Seeds:        seeds,
+		Metrics:      metrics,
+	}, nil
+}
+
+func summarise(values []float64) model.MetricSummary {
+	n := float64(len(values))
+
+	sum := 0.0
+	for _, value := range values {
+		sum += value
+	}
+	mean := sum / n
+
+	squares := 0.0
+	for _, value := range values {
+		squares += (value - mean) * (value - mean)
+	}
+	stdDev := 0.0
+	if len(values) > 1 {
+		stdDev = math.Sqrt(squares / (n - 1))
+	}
+
+	critical := zCritical95
+	if df := len(values) - 1; df < len(tCritical95) {
+		critical = tCritical95[df]
+	}
+	margin := critical * stdDev / math.Sqrt(n)
+
+	return model.MetricSummary{
+		Mean:    mean,
+		StdDev:  stdDev,
+		Min:     slices.Min(values),
+		Max:     slices.Max(values),
+		CILower: mean - margin,
+		CIUpper: mean + margin,
+	}
+}
+
+// evaluate simulates parameters whose results decide whether they improve on others, over
+// ReplicationConfig.OptimisationReplications seeds when set so that the decision does not
+// hinge on a single draw
+func (s *SimulationService) evaluate(
+	ctx context.Context,
+	intersectionID string,
+	params model.SimulationParameters,
+) (model.SimulationResults, error) {
+	if s.replication.OptimisationReplications > 1 {
+		replicated, err := s.replicate(
+			ctx,
+			intersectionID,
+			params,
+			s.replication.OptimisationReplications,
+		)
+		if err != nil {
+			return model.SimulationResults{}, err
+		}
+		return meanResults(replicated), nil
+	}
+
+	results, err := s.simClient.GetSimulationResults(ctx, intersectionID, params)
+	if err != nil {
+		return model.SimulationResults{}, err
+	}
+	return util.RPCSimResultsToSimResults(results), nil
+}
+
+// meanResults rounds the mean of every metric into simulation results, so that replicated
+// simulations can stand in for single ones
+func meanResults(replicated model.ReplicatedResults) model.SimulationResults {
+	mean := func(name string) float64 {
+		return replicated.Metrics[name].Mean
+	}
+	count := func(name string) int {
+		return int(math.Round(mean(name)))
+	}
+
+	return model.SimulationResults{
+		TotalVehicles:      count(model.MetricTotalVehicles),
+		AverageTravelTime:  mean(model.MetricAverageTravelTime),
+		TotalTravelTime:    mean(model.MetricTotalTravelTime),
+		AverageSpeed:       mean(model.MetricAverageSpeed),
+		AverageWaitingTime: mean(model.MetricAverageWaitingTime),
+		TotalWaitingTime:   mean(model.MetricTotalWaitingTime),
+		GeneratedVehicles:  count(model.MetricGeneratedVehicles),
+		EmergencyBrakes:    count(model.MetricEmergencyBrakes),
+		EmergencyStops:     count(model.MetricEmergencyStops),
+		NearCollisions:     count(model.MetricNearCollisions),
+	}
+}
@@ -0,0 +1,82 @@
+package service
+
+import (
+	"context"
+	"io"
+	"time"
+
+	"github.com/COS301-SE-2025/Swift-Signals/api-gateway/internal/middleware"
+	"github.com/COS301-SE-2025/Swift-Signals/api-gateway/internal/model"
+	"github.com/COS301-SE-2025/Swift-Signals/api-gateway/internal/util"
+)
+
+func (s *SimulationService) GetRuns(
+	ctx context.Context,
+	intersectionID string,
+	page, pageSize int,
+) (model.Runs, error) {
+	logger := middleware.LoggerFromContext(ctx).With(
+		"service", "simulation",
+	)
+
+	if _, err := s.checkIntersectionAccess(ctx, intersectionID); err != nil {
+		return model.Runs{}, err
+	}
+
+	logger.Debug("calling intersection service to get runs")
+	stream, err := s.intrClient.GetRuns(ctx, intersectionID, page, pageSize)
+	if err != nil {
+		return model.Runs{}, err
+	}
+
+	result := model.Runs{Runs: []model.Run{}, Page: page, PageSize: pageSize}
+	for {
+		run, err := stream.Recv()
+		if err == io.EOF {
+			break
+		}
+		if err != nil {
+			return model.Runs{}, util.GrpcErrorToErr(err)
+		}
+		result.Runs = append(result.Runs, util.RPCRunToRun(run))
+	}
+	return result, nil
+}
+
+func (s *SimulationService) GetRun(
+	ctx context.Context,
+	intersectionID, runID string,
+) (model.Run, error) {
+	logger := middleware.LoggerFromContext(ctx).With(
+		"service", "simulation",
+	)
+
+	if _, err := s.checkIntersectionAccess(ctx, intersectionID); err != nil {
+		return model.Run{}, err
+	}
+
+	logger.Debug("calling intersection service to get run")
+	run, err := s.intrClient.GetRun(ctx, intersectionID, runID)
+	if err != nil {
+		return model.Run{}, err
+	}
+	return util.RPCRunToRun(run), nil
+}
+
+// recordRun adds a finished run to the history of its intersection. Failing to record
+// it does not fail the run itself.
+func (s *SimulationService) recordRun(ctx context.Context, run model.Run, started time.Time) {
+	logger := middleware.LoggerFromContext(ctx)
+
+	run.DurationMs = time.Since(started).Milliseconds()
+
+	// NOTE: A cancelled run is still recorded, so the context's cancellation is dropped
+	_, err := s.intrClient.CreateRun(context.WithoutCancel(ctx), run)
+	if err != nil {
+		logger.Warn("could not record run",
+			"intersection_id", run.IntersectionID,
+			"type", run.Type,
+			"error", err.Error(),
+		)
+	}
+}
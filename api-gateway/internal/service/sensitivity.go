@@ -0,0 +1,208 @@
+package service
+
+import (
+	"context"
+	"math"
+
+	"github.com/COS301-SE-2025/Swift-Signals/api-gateway/internal/middleware"
+	"github.com/COS301-SE-2025/Swift-Signals/api-gateway/internal/model"
+	"github.com/COS301-SE-2025/Swift-Signals/api-gateway/internal/util"
+	errs "github.com/COS301-SE-2025/Swift-Signals/shared/error"
+	"golang.org/x/sync/errgroup"
+)
+
+// AnalyseSensitivity simulates an intersection at evenly spaced values of one parameter,
+// keeping the others at its defaults, and derives the curve, elasticity and best value of
+// every metric. Each value is simulated over the requested number of seeds, taking the
+// mean of each metric, so that the curves do not follow the noise of single draws.
+func (s *SimulationService) AnalyseSensitivity(
+	ctx context.Context,
+	intersectionID string,
+	req model.SensitivityRequest,
+) (model.Sensitivity, error) {
+	switch req.Parameter {
+	case model.SweepParameterGreen,
+		model.SweepParameterYellow,
+		model.SweepParameterRed,
+		model.SweepParameterSpeed:
+	default:
+		return model.Sensitivity{}, errs.NewValidationError(
+			"parameter cannot be varied",
+			map[string]any{"parameter": req.Parameter},
+		)
+	}
+	if req.Steps < 2 || req.Min < 1 || req.Max <= req.Min {
+		return model.Sensitivity{}, errs.NewValidationError(
+			"sensitivity needs at least 2 steps and a positive min below max",
+			map[string]any{"min": req.Min, "max": req.Max, "steps": req.Steps},
+		)
+	}
+	if req.Replications > s.replication.MaxReplications {
+		return model.Sensitivity{}, errs.NewValidationError(
+			"replications must not be above the maximum",
+			map[string]any{
+				"replications": req.Replications,
+				"max":          s.replication.MaxReplications,
+			},
+		)
+	}
+	replications := max(req.Replications, 1)
+
+	_, params, err := s.getSimulationParameters(ctx, intersectionID, false)
+	if err != nil {
+		return model.Sensitivity{}, err
+	}
+	base := util.RPCSimParamToSimParam(params.Parameters)
+
+	values := sensitivityValues(req.Min, req.Max, req.Steps)
+	results, err := s.simulateSensitivity(
+		ctx,
+		intersectionID,
+		req.Parameter,
+		base,
+		values,
+		replications,
+	)
+	if err != nil {
+		return model.Sensitivity{}, err
+	}
+
+	curves := make([]model.SensitivityCurve, 0, len(simulationMetrics))
+	for _, metric := range simulationMetrics {
+		curves = append(curves, sensitivityCurve(metric, values, results))
+	}
+
+	return model.Sensitivity{
+		IntersectionID:  intersectionID,
+		Parameter:       req.Parameter,
+		BaseParameters:  base,
+		ParameterValues: values,
+		Replications:    replications,
+		Curves:          curves,
+	}, nil
+}
+
+// simulateSensitivity returns the results at each value of the parameter. Single seeds are
+// simulated concurrently, while replicated values run one after the other so that the
+// number of simulations at a time stays within ReplicationConfig.Concurrency.
+func (s *SimulationService) simulateSensitivity(
+	ctx context.Context,
+	intersectionID string,
+	parameter string,
+	base model.SimulationParameters,
+	values []int,
+	replications int,
+) ([]model.SimulationResults, error) {
+	logger := middleware.LoggerFromContext(ctx).With(
+		"service", "simulation",
+	)
+
+	results := make([]model.SimulationResults, len(values))
+	if replications > 1 {
+		for i, value := range values {
+			varied := base
+			setSweepParameter(&varied, parameter, value)
+
+			logger.Debug("replicating sensitivity point", "parameter", parameter, "value", value)
+			replicated, err := s.replicate(ctx, intersectionID, varied, replications)
+			if err != nil {
+				return nil, err
+			}
+			results[i] = meanResults(replicated)
+		}
+		return results, nil
+	}
+
+	g, gctx := errgroup.WithContext(ctx)
+	g.SetLimit(max(s.replication.Concurrency, 1))
+	for i, value := range values {
+		varied := base
+		setSweepParameter(&varied, parameter, value)
+
+		g.Go(func() error {
+			logger.Debug("calling simulation service to run sensitivity point",
+				"parameter", parameter,
+				"value", value,
+			)
+			simResults, err := s.simClient.GetSimulationResults(gctx, intersectionID, varied)
+			if err != nil {
+				return err
+			}
+			results[i] = util.RPCSimResultsToSimResults(simResults)
+			return nil
+		})
+	}
+	if err := g.Wait(); err != nil {
+		return nil, err
+	}
+	return results, nil
+}
+
+// sensitivityValues spreads steps whole values evenly from min to max, both included.
+// Narrow ranges yield fewer values, as neighbouring steps round to the same value.
+func sensitivityValues(minValue, maxValue, steps int) []int {
+	values := make([]int, 0, steps)
+	span := float64(maxValue - minValue)
+	for i := range steps {
+		value := minValue + int(math.Round(span*float64(i)/float64(steps-1)))
+		if len(values) > 0 && values[len(values)-1] == value {
+			continue
+		}
+		values = append(values, value)
+	}
+	return values
+}
+
+func sensitivityCurve(
+	metric simulationMetric,
+	values []int,
+	results []model.SimulationResults,
+) model.SensitivityCurve {
+	curve := model.SensitivityCurve{
+		Metric:       metric.name,
+		Direction:    metric.direction,
+		Values:       make([]float64, len(results)),
+		Elasticities: make([]*float64, len(results)),
+	}
+	for i, result := range results {
+		curve.Values[i] = metric.value(result)
+	}
+
+	last := len(values) - 1
+	for i := range values {
+		// NOTE: Central differences inside the range, one-sided ones at its ends
+		lo, hi := max(i-1, 0), min(i+1, last)
+		slope := (curve.Values[hi] - curve.Values[lo]) / float64(values[hi]-values[lo])
+		curve.Elasticities[i] = elasticity(slope, float64(values[i]), curve.Values[i])
+	}
+
+	// NOTE: Arc elasticity, taken at the midpoints so that it reads the same both ways
+	x0, x1 := float64(values[0]), float64(values[last])
+	y0, y1 := curve.Values[0], curve.Values[last]
+	curve.Elasticity = elasticity((y1-y0)/(x1-x0), (x0+x1)/2, (y0+y1)/2)
+
+	if metric.direction == model.MetricDirectionNone {
+		return curve
+	}
+	best := 0
+	for i, value := range curve.Values {
+		if metric.direction == model.MetricDirectionLower && value < curve.Values[best] ||
+			metric.direction == model.MetricDirectionHigher && value > curve.Values[best] {
+			best = i
+		}
+	}
+	curve.OptimalValue = &values[best]
+	curve.OptimumAtBoundary = best == 0 || best == last
+
+	return curve
+}
+
+// elasticity is the percentage change of y per percent change of x at the given point, or
+// nil where y is zero
+func elasticity(slope, x, y float64) *float64 {
+	if y == 0 {
+		return nil
+	}
+	e := slope * x / y
+	return &e
+}
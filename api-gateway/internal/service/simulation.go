@@ -2,22 +2,48 @@ package service
 
 import (
 	"context"
+	"errors"
+	"fmt"
 	"io"
+	"math"
 	"slices"
+	"sync"
+	"time"
 
 	"github.com/COS301-SE-2025/Swift-Signals/api-gateway/internal/client"
 	"github.com/COS301-SE-2025/Swift-Signals/api-gateway/internal/middleware"
 	"github.com/COS301-SE-2025/Swift-Signals/api-gateway/internal/model"
 	"github.com/COS301-SE-2025/Swift-Signals/api-gateway/internal/util"
 	commonpb "github.com/COS301-SE-2025/Swift-Signals/protos/gen/swiftsignals/common/v1"
+	intersectionpb "github.com/COS301-SE-2025/Swift-Signals/protos/gen/swiftsignals/intersection/v1"
+	simulationpb "github.com/COS301-SE-2025/Swift-Signals/protos/gen/swiftsignals/simulation/v1"
 	errs "github.com/COS301-SE-2025/Swift-Signals/shared/error"
 )
 
+// OptimisationConfig controls the optimisation jobs run in the background
+type OptimisationConfig struct {
+	// HeartbeatInterval is how often a running job renews its lease with the intersection
+	// service. It has to be well below the lease, or the job is failed as stale while it
+	// is still running. Jobs do not renew their lease when it is zero.
+	HeartbeatInterval time.Duration
+}
+
 type SimulationService struct {
 	intrClient client.IntersectionClientInterface
 	optiClient client.OptimisationClientInterface
 	userClient client.UserClientInterface
 	simClient  client.SimulationClientInterface
+
+	replication  ReplicationConfig
+	sweep        SweepConfig
+	optimisation OptimisationConfig
+
+	// NOTE: Cancels the optimisation jobs running in this process, keyed by job ID
+	jobsMu sync.Mutex
+	jobs   map[string]context.CancelFunc
+
+	events   *optimisationEvents
+	notifier NotificationServiceInterface
 }
 
 func NewSimulationService(
@@ -25,26 +51,93 @@ func NewSimulationService(
 	optiClient client.OptimisationClientInterface,
 	userClient client.UserClientInterface,
 	simClient client.SimulationClientInterface,
+	replication ReplicationConfig,
+	sweep SweepConfig,
+	optimisation OptimisationConfig,
+	notifier NotificationServiceInterface,
 ) SimulationServiceInterface {
 	return &SimulationService{
-		intrClient: intrClient,
-		optiClient: optiClient,
-		userClient: userClient,
-		simClient:  simClient,
+		intrClient:   intrClient,
+		optiClient:   optiClient,
+		userClient:   userClient,
+		simClient:    simClient,
+		replication:  replication,
+		sweep:        sweep,
+		optimisation: optimisation,
+		jobs:         make(map[string]context.CancelFunc),
+		events:       newOptimisationEvents(),
+		notifier:     notifier,
 	}
 }
 
 func (s *SimulationService) GetSimulationData(
 	ctx context.Context,
 	intersectionID string,
+	filter model.OutputFilter,
+) (model.SimulationResponse, error) {
+	userID, params, err := s.getSimulationParameters(ctx, intersectionID, false)
+	if err != nil {
+		return model.SimulationResponse{}, err
+	}
+
+	return s.simulate(ctx, userID, intersectionID, params, filter)
+}
+
+func (s *SimulationService) GetOptimisedData(
+	ctx context.Context,
+	intersectionID string,
+	filter model.OutputFilter,
 ) (model.SimulationResponse, error) {
+	userID, params, err := s.getSimulationParameters(ctx, intersectionID, true)
+	if err != nil {
+		return model.SimulationResponse{}, err
+	}
+
+	return s.simulate(ctx, userID, intersectionID, params, filter)
+}
+
+// StreamSimulationOutput simulates an intersection with its default parameters, passing
+// the output to send chunk by chunk as the simulation service streams it
+func (s *SimulationService) StreamSimulationOutput(
+	ctx context.Context,
+	intersectionID string,
+	filter model.OutputFilter,
+	send func(chunk model.SimulationOutputChunk) error,
+) error {
+	_, params, err := s.getSimulationParameters(ctx, intersectionID, false)
+	if err != nil {
+		return err
+	}
+
+	return s.streamOutput(ctx, intersectionID, params, filter, send)
+}
+
+// StreamOptimisedOutput is StreamSimulationOutput for the intersection's best parameters
+func (s *SimulationService) StreamOptimisedOutput(
+	ctx context.Context,
+	intersectionID string,
+	filter model.OutputFilter,
+	send func(chunk model.SimulationOutputChunk) error,
+) error {
+	_, params, err := s.getSimulationParameters(ctx, intersectionID, true)
+	if err != nil {
+		return err
+	}
+
+	return s.streamOutput(ctx, intersectionID, params, filter, send)
+}
+
+func (s *SimulationService) OptimiseIntersection(
+	ctx context.Context,
+	intersectionID string,
+) (model.OptimisationJob, error) {
 	logger := middleware.LoggerFromContext(ctx).With(
 		"service", "simulation",
 	)
 
 	userID, ok := middleware.GetUserID(ctx)
 	if !ok {
-		return model.SimulationResponse{}, errs.NewInternalError(
+		return model.OptimisationJob{}, errs.NewInternalError(
 			"user ID missing inside of handler",
 			nil,
 			map[string]any{},
@@ -54,53 +147,90 @@ func (s *SimulationService) GetSimulationData(
 	logger.Debug("calling user service to retrieve user's intersection IDs")
 	intersectionIDs, err := s.GetUserIntersectionIDs(ctx, userID)
 	if err != nil {
-		return model.SimulationResponse{}, err
+		return model.OptimisationJob{}, err
 	}
 
 	if !slices.Contains(intersectionIDs, intersectionID) {
-		return model.SimulationResponse{}, errs.NewForbiddenError(
+		return model.OptimisationJob{}, errs.NewForbiddenError(
 			"you do not have access to this intersection",
 			map[string]any{"intersectionID": intersectionID},
 		)
 	}
 
-	logger.Debug("calling intersection service to get simulation parameters")
+	logger.Debug("calling intersection service to get intersection details")
 	intersection, err := s.intrClient.GetIntersection(ctx, intersectionID)
 	if err != nil {
-		return model.SimulationResponse{}, err
+		return model.OptimisationJob{}, err
 	}
 
-	defaultParams := util.RPCSimParamToSimParam(intersection.DefaultParameters.Parameters)
-
-	logger.Debug("calling simulation service to get simulation results")
-	simulationResults, err := s.simClient.GetSimulationResults(ctx, intersection.Id, defaultParams)
+	logger.Debug("calling intersection service to create optimisation job")
+	job, err := s.intrClient.CreateOptimisationJob(
+		ctx,
+		intersection.Id,
+		userID,
+		intersection.Status,
+	)
 	if err != nil {
-		return model.SimulationResponse{}, err
+		return model.OptimisationJob{}, err
 	}
 
-	logger.Debug("calling simulation service to get simulation output")
-	simulationOutput, err := s.simClient.GetSimulationOutput(ctx, intersection.Id, defaultParams)
+	logger.Debug(
+		"calling intersection service to change status to 'INTERSECTION_STATUS_OPTIMISING'",
+	)
+	_, err = s.intrClient.UpdateIntersectionStatus(
+		ctx,
+		intersection.Id,
+		commonpb.IntersectionStatus_INTERSECTION_STATUS_OPTIMISING,
+	)
 	if err != nil {
-		return model.SimulationResponse{}, err
+		// NOTE: The job is failed rather than started, as an intersection that is not
+		// marked as optimising could be changed underneath it
+		_, jobErr := s.intrClient.UpdateOptimisationJob(
+			ctx,
+			job.Id,
+			intersectionpb.OptimisationJobStatus_OPTIMISATION_JOB_STATUS_FAILED,
+			false,
+			err.Error(),
+		)
+		if jobErr != nil {
+			logger.Warn("could not mark optimisation job as failed", "error", jobErr.Error())
+		}
+		return model.OptimisationJob{}, err
 	}
 
-	return model.SimulationResponse{
-		Results: util.RPCSimResultsToSimResults(simulationResults),
-		Output:  util.RPCSimOutputToSimOutput(simulationOutput),
-	}, nil
+	// NOTE: The job outlives the request, so it gets its own context which is only
+	// cancelled through CancelOptimisationJob
+	jobCtx, cancel := context.WithCancel(
+		middleware.SetLogger(context.Background(), logger.With("job_id", job.Id)),
+	)
+	s.trackJob(job.Id, cancel)
+	go s.runOptimisationJob(jobCtx, job, intersection)
+
+	return util.RPCOptimisationJobToOptimisationJob(job), nil
 }
 
-func (s *SimulationService) GetOptimisedData(
+func (s *SimulationService) GetOptimisationJob(
+	ctx context.Context,
+	jobID string,
+) (model.OptimisationJob, error) {
+	job, err := s.getOwnedOptimisationJob(ctx, jobID)
+	if err != nil {
+		return model.OptimisationJob{}, err
+	}
+	return util.RPCOptimisationJobToOptimisationJob(job), nil
+}
+
+func (s *SimulationService) GetOptimisationJobs(
 	ctx context.Context,
 	intersectionID string,
-) (model.SimulationResponse, error) {
+) (model.OptimisationJobs, error) {
 	logger := middleware.LoggerFromContext(ctx).With(
 		"service", "simulation",
 	)
 
 	userID, ok := middleware.GetUserID(ctx)
 	if !ok {
-		return model.SimulationResponse{}, errs.NewInternalError(
+		return model.OptimisationJobs{}, errs.NewInternalError(
 			"user ID missing inside of handler",
 			nil,
 			map[string]any{},
@@ -110,60 +240,611 @@ func (s *SimulationService) GetOptimisedData(
 	logger.Debug("calling user service to retrieve user's intersection IDs")
 	intersectionIDs, err := s.GetUserIntersectionIDs(ctx, userID)
 	if err != nil {
-		return model.SimulationResponse{}, err
+		return model.OptimisationJobs{}, err
 	}
 
 	if !slices.Contains(intersectionIDs, intersectionID) {
-		return model.SimulationResponse{}, errs.NewForbiddenError(
+		return model.OptimisationJobs{}, errs.NewForbiddenError(
 			"you do not have access to this intersection",
 			map[string]any{"intersectionID": intersectionID},
 		)
 	}
 
-	logger.Debug("calling intersection service to get simulation parameters")
-	intersection, err := s.intrClient.GetIntersection(ctx, intersectionID)
+	logger.Debug("calling intersection service to get optimisation jobs")
+	jobs, err := s.getOptimisationJobs(ctx, intersectionID, nil)
 	if err != nil {
-		return model.SimulationResponse{}, err
+		return model.OptimisationJobs{}, err
 	}
 
-	if intersection.BestParameters == nil {
-		return model.SimulationResponse{}, errs.NewNotFoundError(
-			"no optimised parameters found for this intersection",
-			map[string]any{"intersectionID": intersectionID},
+	result := model.OptimisationJobs{Jobs: make([]model.OptimisationJob, 0, len(jobs))}
+	for _, job := range jobs {
+		result.Jobs = append(result.Jobs, util.RPCOptimisationJobToOptimisationJob(job))
+	}
+	return result, nil
+}
+
+func (s *SimulationService) CancelOptimisationJob(
+	ctx context.Context,
+	jobID string,
+) (model.OptimisationJob, error) {
+	logger := middleware.LoggerFromContext(ctx).With(
+		"service", "simulation",
+	)
+
+	job, err := s.getOwnedOptimisationJob(ctx, jobID)
+	if err != nil {
+		return model.OptimisationJob{}, err
+	}
+
+	if isFinishedOptimisationJob(job.Status) {
+		logger.Debug("optimisation job has already finished, nothing to cancel")
+		return util.RPCOptimisationJobToOptimisationJob(job), nil
+	}
+
+	logger.Debug("calling intersection service to mark optimisation job as cancelled")
+	cancelled, err := s.intrClient.UpdateOptimisationJob(
+		ctx,
+		jobID,
+		intersectionpb.OptimisationJobStatus_OPTIMISATION_JOB_STATUS_CANCELLED,
+		false,
+		"cancelled by user",
+	)
+	if err != nil {
+		var svcErr *errs.ServiceError
+		if !errors.As(err, &svcErr) || svcErr.Code != errs.ErrConflict {
+			return model.OptimisationJob{}, err
+		}
+		// NOTE: The job finished while it was being cancelled, report how it ended instead
+		job, err = s.intrClient.GetOptimisationJob(ctx, jobID)
+		if err != nil {
+			return model.OptimisationJob{}, err
+		}
+		return util.RPCOptimisationJobToOptimisationJob(job), nil
+	}
+
+	s.cancelJob(jobID)
+	s.restoreIntersectionStatus(ctx, job)
+
+	return util.RPCOptimisationJobToOptimisationJob(cancelled), nil
+}
+
+// SubscribeOptimisationEvents follows the optimisation jobs of an intersection. The
+// returned function must be called once the caller stops reading events.
+func (s *SimulationService) SubscribeOptimisationEvents(
+	ctx context.Context,
+	intersectionID string,
+) (<-chan model.OptimisationEvent, func(), error) {
+	if _, err := s.checkIntersectionAccess(ctx, intersectionID); err != nil {
+		return nil, nil, err
+	}
+
+	events, unsubscribe := s.events.subscribe(intersectionID)
+	return events, unsubscribe, nil
+}
+
+/******************/
+/* Helper Methods */
+/******************/
+
+func (s *SimulationService) runOptimisationJob(
+	ctx context.Context,
+	job *intersectionpb.OptimisationJobResponse,
+	intersection *intersectionpb.IntersectionResponse,
+) {
+	logger := middleware.LoggerFromContext(ctx)
+	defer s.untrackJob(job.Id)
+
+	ctx, stopHeartbeat := context.WithCancelCause(ctx)
+	defer stopHeartbeat(nil)
+	go s.heartbeat(ctx, job.Id, stopHeartbeat)
+
+	run := model.Run{
+		IntersectionID: intersection.Id,
+		UserID:         job.UserId,
+		Type:           model.RunTypeOptimisation,
+		Parameters:     util.RPCOptiParamToOptiParam(intersection.DefaultParameters),
+		Outcome:        model.RunOutcomeFailed,
+		JobID:          job.Id,
+	}
+	started := time.Now()
+	defer func() {
+		if cause := context.Cause(ctx); errors.Is(cause, errLeaseLost) {
+			// NOTE: The intersection service already failed the job and its intersection
+			run.Outcome = model.RunOutcomeFailed
+			run.Error = cause.Error()
+		} else if ctx.Err() != nil {
+			run.Outcome = model.RunOutcomeCancelled
+		}
+		s.recordRun(ctx, run, started)
+		s.publishJobFinished(job, run)
+		s.notifyJobFinished(ctx, intersection, run)
+	}()
+
+	fail := func(err error) {
+		run.Error = err.Error()
+		s.failOptimisationJob(ctx, job.Id, intersection, err)
+	}
+
+	logger.Debug("calling intersection service to mark optimisation job as running")
+	running, err := s.intrClient.UpdateOptimisationJob(
+		ctx,
+		job.Id,
+		intersectionpb.OptimisationJobStatus_OPTIMISATION_JOB_STATUS_RUNNING,
+		false,
+		"",
+	)
+	if err != nil {
+		fail(err)
+		return
+	}
+	s.events.publish(intersection.Id, model.OptimisationEvent{
+		Type: model.OptimisationEventJob,
+		Data: util.RPCOptimisationJobToOptimisationJob(running),
+	})
+
+	logger.Debug("calling optimisation service to optimise intersection")
+	response, err := s.optimise(ctx, job.Id, intersection)
+	if err != nil {
+		fail(err)
+		return
+	}
+
+	params := util.RPCOptiParamToOptiParamOp(response)
+	// NOTE: The optimisation service does not send the density back
+	params.SimulationParameters.TrafficDensity = util.RPCTrafficDensityToTrafficDensity(
+		intersection.TrafficDensity,
+	)
+	run.Parameters = params
+
+	// NOTE: The intersection service only keeps the parameters as best if these results
+	// beat those of the current best parameters
+	logger.Debug("calling simulation service to evaluate optimised parameters")
+	results, err := s.evaluate(ctx, intersection.Id, params.SimulationParameters)
+	if err != nil {
+		fail(err)
+		return
+	}
+	run.Metrics = &results
+
+	logger.Debug("updating intersection with optimised parameters")
+	resp, err := s.intrClient.PutOptimisation(
+		ctx,
+		intersection.Id,
+		params,
+		util.SimResultsToRPCSimResults(results),
+		job.UserId,
+		job.Id,
+	)
+	if err != nil {
+		fail(err)
+		return
+	}
+	// NOTE: Putting the optimisation also marks the intersection as optimised
+	run.Outcome = model.RunOutcomeSucceeded
+	run.Improved = resp.Improved
+
+	logger.Debug("calling intersection service to mark optimisation job as succeeded")
+	_, err = s.intrClient.UpdateOptimisationJob(
+		ctx,
+		job.Id,
+		intersectionpb.OptimisationJobStatus_OPTIMISATION_JOB_STATUS_SUCCEEDED,
+		resp.Improved,
+		"",
+	)
+	if err != nil {
+		logger.Warn("could not mark optimisation job as succeeded", "error", err.Error())
+	}
+}
+
+// errLeaseLost stops a job whose lease the intersection service no longer renews, as it has
+// already been failed for running past it
+var errLeaseLost = errors.New("optimisation job lease expired")
+
+// heartbeat renews the lease of a job every OptimisationConfig.HeartbeatInterval until ctx
+// is done. Once the intersection service refuses to renew it, the job has been failed and
+// is stopped with errLeaseLost, so that it does not keep optimising for nothing.
+func (s *SimulationService) heartbeat(
+	ctx context.Context,
+	jobID string,
+	stop context.CancelCauseFunc,
+) {
+	if s.optimisation.HeartbeatInterval <= 0 {
+		return
+	}
+	logger := middleware.LoggerFromContext(ctx)
+
+	ticker := time.NewTicker(s.optimisation.HeartbeatInterval)
+	defer ticker.Stop()
+	for {
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+		}
+
+		_, err := s.intrClient.RenewOptimisationLease(ctx, jobID)
+		if err == nil {
+			continue
+		}
+		var svcErr *errs.ServiceError
+		if errors.As(err, &svcErr) && svcErr.Code == errs.ErrConflict {
+			logger.Warn("optimisation job lease could not be renewed, stopping job")
+			stop(errLeaseLost)
+			return
+		}
+		// NOTE: A missed renewal is retried on the next tick, which is still well within
+		// the lease
+		logger.Warn("could not renew optimisation job lease", "error", err.Error())
+	}
+}
+
+// optimise streams the optimisation of an intersection, publishing every progress event
+// to the intersection's subscribers, and returns the optimised parameters
+func (s *SimulationService) optimise(
+	ctx context.Context,
+	jobID string,
+	intersection *intersectionpb.IntersectionResponse,
+) (*commonpb.OptimisationParameters, error) {
+	stream, err := s.optiClient.StreamOptimisation(
+		ctx,
+		util.RPCOptiParamToOptiParam(intersection.DefaultParameters),
+	)
+	if err != nil {
+		return nil, err
+	}
+
+	for {
+		progress, err := stream.Recv()
+		if err == io.EOF {
+			return nil, errs.NewInternalError(
+				"optimisation finished without a result",
+				nil,
+				map[string]any{"jobID": jobID},
+			)
+		}
+		if err != nil {
+			return nil, util.GrpcErrorToErr(err)
+		}
+		if progress.Result != nil {
+			return progress.Result, nil
+		}
+		s.events.publish(intersection.Id, model.OptimisationEvent{
+			Type: model.OptimisationEventProgress,
+			Data: util.RPCOptimisationProgressToOptimisationProgress(jobID, progress),
+		})
+	}
+}
+
+// publishJobFinished tells the subscribers of an intersection how its job ended
+func (s *SimulationService) publishJobFinished(
+	job *intersectionpb.OptimisationJobResponse,
+	run model.Run,
+) {
+	finished := util.RPCOptimisationJobToOptimisationJob(job)
+	switch run.Outcome {
+	case model.RunOutcomeSucceeded:
+		finished.Status = intersectionpb.OptimisationJobStatus_OPTIMISATION_JOB_STATUS_SUCCEEDED.String()
+		finished.Improved = run.Improved
+	case model.RunOutcomeCancelled:
+		finished.Status = intersectionpb.OptimisationJobStatus_OPTIMISATION_JOB_STATUS_CANCELLED.String()
+	default:
+		finished.Status = intersectionpb.OptimisationJobStatus_OPTIMISATION_JOB_STATUS_FAILED.String()
+		finished.Error = run.Error
+	}
+	now := time.Now()
+	finished.FinishedAt = &now
+
+	s.events.publish(job.IntersectionId, model.OptimisationEvent{
+		Type: model.OptimisationEventJob,
+		Data: finished,
+	})
+}
+
+// notifyJobFinished tells the job's owner how their optimisation went, comparing its
+// average waiting time with that of the parameters it set out to beat. A cancelled job was
+// stopped by the user, so they are not told about it.
+func (s *SimulationService) notifyJobFinished(
+	ctx context.Context,
+	intersection *intersectionpb.IntersectionResponse,
+	run model.Run,
+) {
+	var notificationType, message string
+	switch run.Outcome {
+	case model.RunOutcomeSucceeded:
+		notificationType = model.NotificationTypeOptimisationCompleted
+		message = fmt.Sprintf("Optimisation of %s finished: ", intersection.Name)
+		baseline := util.RPCOptionalSimResultsToSimResults(intersection.BestMetrics)
+		if baseline == nil {
+			baseline = util.RPCOptionalSimResultsToSimResults(intersection.CurrentMetrics)
+		}
+		switch {
+		case !run.Improved:
+			message += "no improvement on the best parameters"
+		case run.Metrics == nil || baseline == nil || baseline.AverageWaitingTime <= 0:
+			message += "new best parameters found"
+		default:
+			message += "waiting time " + formatPercentChange(
+				baseline.AverageWaitingTime,
+				run.Metrics.AverageWaitingTime,
+			)
+		}
+	case model.RunOutcomeFailed:
+		notificationType = model.NotificationTypeOptimisationFailed
+		message = fmt.Sprintf("Optimisation of %s failed: %s", intersection.Name, run.Error)
+	default:
+		return
+	}
+
+	s.notifier.Notify(
+		context.WithoutCancel(ctx),
+		run.UserID,
+		notificationType,
+		intersection.Id,
+		message,
+	)
+}
+
+// formatPercentChange formats the change from before to after as a whole percentage, with
+// a minus sign rather than a hyphen for a decrease
+func formatPercentChange(before, after float64) string {
+	change := math.Round((after - before) / before * 100)
+	switch {
+	case change < 0:
+		return fmt.Sprintf("\u2212%.0f%%", -change)
+	case change > 0:
+		return fmt.Sprintf("+%.0f%%", change)
+	default:
+		return "0%"
+	}
+}
+
+func (s *SimulationService) failOptimisationJob(
+	ctx context.Context,
+	jobID string,
+	intersection *intersectionpb.IntersectionResponse,
+	cause error,
+) {
+	logger := middleware.LoggerFromContext(ctx)
+
+	if ctx.Err() != nil {
+		// NOTE: Cancelled jobs have already been recorded and their intersection restored,
+		// and jobs that lost their lease were failed by the intersection service
+		logger.Info("optimisation job was stopped", "cause", context.Cause(ctx).Error())
+		return
+	}
+
+	logger.Error("optimisation job failed", "error", cause.Error())
+
+	_, err := s.intrClient.UpdateOptimisationJob(
+		ctx,
+		jobID,
+		intersectionpb.OptimisationJobStatus_OPTIMISATION_JOB_STATUS_FAILED,
+		false,
+		cause.Error(),
+	)
+	if err != nil {
+		logger.Warn("could not mark optimisation job as failed", "error", err.Error())
+	}
+
+	_, err = s.intrClient.FailIntersection(ctx, intersection.Id, cause.Error())
+	if err != nil {
+		logger.Warn("Could not update intersection status to 'INTERSECTION_STATUS_FAILED'")
+	}
+}
+
+// restoreIntersectionStatus puts an intersection back into the status it had before
+// the given job started. Its parameters are untouched by an unfinished job, so that
+// status still describes it.
+func (s *SimulationService) restoreIntersectionStatus(
+	ctx context.Context,
+	job *intersectionpb.OptimisationJobResponse,
+) {
+	logger := middleware.LoggerFromContext(ctx)
+
+	status := job.PreviousStatus
+	switch status {
+	case commonpb.IntersectionStatus_INTERSECTION_STATUS_UNOPTIMISED,
+		commonpb.IntersectionStatus_INTERSECTION_STATUS_OPTIMISED,
+		commonpb.IntersectionStatus_INTERSECTION_STATUS_FAILED:
+	default:
+		status = commonpb.IntersectionStatus_INTERSECTION_STATUS_UNOPTIMISED
+	}
+
+	logger.Debug("calling intersection service to restore intersection status",
+		"status", status.String(),
+	)
+	_, err := s.intrClient.UpdateIntersectionStatus(ctx, job.IntersectionId, status)
+	if err != nil {
+		logger.Warn("could not restore intersection status",
+			"intersectionID", job.IntersectionId,
+			"error", err.Error(),
 		)
 	}
+}
+
+// simulate runs the simulation of an intersection with the given parameters and records
+// the run in the intersection's history. Simulations answered from the cache are only
+// views of an earlier run, so they are not recorded again.
+func (s *SimulationService) simulate(
+	ctx context.Context,
+	userID, intersectionID string,
+	params *commonpb.OptimisationParameters,
+	filter model.OutputFilter,
+) (model.SimulationResponse, error) {
+	logger := middleware.LoggerFromContext(ctx)
 
-	bestParams := util.RPCSimParamToSimParam(intersection.BestParameters.Parameters)
+	simParams := util.RPCSimParamToSimParam(params.Parameters)
+	run := model.Run{
+		IntersectionID: intersectionID,
+		UserID:         userID,
+		Type:           model.RunTypeSimulation,
+		Parameters:     util.RPCOptiParamToOptiParam(params),
+		Outcome:        model.RunOutcomeFailed,
+	}
+	started := time.Now()
+	simCtx, report := client.WithCacheReport(ctx)
+	defer func() {
+		if !report.Hit {
+			s.recordRun(ctx, run, started)
+		}
+	}()
 
-	logger.Debug("calling simulation service to get simulation results")
-	simulationResults, err := s.simClient.GetSimulationResults(ctx, intersection.Id, bestParams)
+	logger.Debug("calling simulation service to run simulation")
+	simulation, err := s.simClient.RunSimulation(simCtx, intersectionID, simParams)
 	if err != nil {
+		run.Error = err.Error()
 		return model.SimulationResponse{}, err
 	}
+	resp := simulationResponse(simulation, filter)
+	run.Metrics = &resp.Results
+	run.Outcome = model.RunOutcomeSucceeded
+
+	return resp, nil
+}
+
+// RunWhatIfSimulation simulates an intersection with ad-hoc parameters. Nothing about the
+// simulation is saved, so that timings can be tried out freely.
+func (s *SimulationService) RunWhatIfSimulation(
+	ctx context.Context,
+	intersectionID string,
+	params model.SimulationParameters,
+	filter model.OutputFilter,
+) (model.SimulationResponse, error) {
+	logger := middleware.LoggerFromContext(ctx).With(
+		"service", "simulation",
+	)
+
+	if _, err := s.checkIntersectionAccess(ctx, intersectionID); err != nil {
+		return model.SimulationResponse{}, err
+	}
+
+	if params.TrafficDensity == "" {
+		logger.Debug("calling intersection service to get traffic density")
+		intersection, err := s.intrClient.GetIntersection(ctx, intersectionID)
+		if err != nil {
+			return model.SimulationResponse{}, err
+		}
+		params.TrafficDensity = util.RPCTrafficDensityToTrafficDensity(
+			intersection.TrafficDensity,
+		)
+	}
 
-	logger.Debug("calling simulation service to get simulation output")
-	simulationOutput, err := s.simClient.GetSimulationOutput(ctx, intersection.Id, bestParams)
+	logger.Debug("calling simulation service to run what-if simulation")
+	simulation, err := s.simClient.RunWhatIfSimulation(ctx, intersectionID, params)
 	if err != nil {
 		return model.SimulationResponse{}, err
 	}
 
+	return simulationResponse(simulation, filter), nil
+}
+
+func simulationResponse(
+	simulation *simulationpb.SimulationResponse,
+	filter model.OutputFilter,
+) model.SimulationResponse {
+	output := simulation.Output
+	vehicles := util.RPCSimVehiclesToSimVehicles(filterVehicles(output.Vehicles, filter))
+	omitSpeeds(vehicles, filter)
 	return model.SimulationResponse{
-		Results: util.RPCSimResultsToSimResults(simulationResults),
-		Output:  util.RPCSimOutputToSimOutput(simulationOutput),
-	}, nil
+		Results: util.RPCSimResultsToSimResults(simulation.Results),
+		Output: model.SimulationOutput{
+			Intersection: util.RPCSimIntersectionToSimIntersection(output.Intersection),
+			Vehicles:     vehicles,
+		},
+	}
 }
 
-func (s *SimulationService) OptimiseIntersection(
+// getSimulationParameters returns the requesting user's ID with the default parameters of
+// their intersection, or its best parameters when optimised is set
+func (s *SimulationService) getSimulationParameters(
 	ctx context.Context,
 	intersectionID string,
-) (model.OptimisationResponse, error) {
+	optimised bool,
+) (string, *commonpb.OptimisationParameters, error) {
 	logger := middleware.LoggerFromContext(ctx).With(
 		"service", "simulation",
 	)
 
+	userID, err := s.checkIntersectionAccess(ctx, intersectionID)
+	if err != nil {
+		return "", nil, err
+	}
+
+	logger.Debug("calling intersection service to get simulation parameters")
+	intersection, err := s.intrClient.GetIntersection(ctx, intersectionID)
+	if err != nil {
+		return "", nil, err
+	}
+
+	if !optimised {
+		return userID, intersection.DefaultParameters, nil
+	}
+	if intersection.BestParameters == nil {
+		return "", nil, errs.NewNotFoundError(
+			"no optimised parameters found for this intersection",
+			map[string]any{"intersectionID": intersectionID},
+		)
+	}
+	return userID, intersection.BestParameters, nil
+}
+
+// streamOutput passes each chunk of a simulation's output to send as it arrives, so the
+// output is never held in memory whole. Chunks left without vehicles by the filter are
+// skipped, except for the first which carries the intersection.
+func (s *SimulationService) streamOutput(
+	ctx context.Context,
+	intersectionID string,
+	params *commonpb.OptimisationParameters,
+	filter model.OutputFilter,
+	send func(chunk model.SimulationOutputChunk) error,
+) error {
+	logger := middleware.LoggerFromContext(ctx)
+
+	// NOTE: Stops the simulation service streaming once send fails
+	ctx, cancel := context.WithCancel(ctx)
+	defer cancel()
+
+	logger.Debug("calling simulation service to stream simulation output")
+	stream, err := s.simClient.StreamSimulationOutput(
+		ctx,
+		intersectionID,
+		util.RPCSimParamToSimParam(params.Parameters),
+	)
+	if err != nil {
+		return err
+	}
+
+	for {
+		chunk, err := stream.Recv()
+		if err == io.EOF {
+			return nil
+		}
+		if err != nil {
+			return util.GrpcErrorToErr(err)
+		}
+		chunk.Vehicles = filterVehicles(chunk.Vehicles, filter)
+		output := util.RPCSimOutputChunkToSimOutputChunk(chunk)
+		omitSpeeds(output.Vehicles, filter)
+		if len(output.Vehicles) == 0 && output.Intersection == nil {
+			continue
+		}
+		if err := send(output); err != nil {
+			return err
+		}
+	}
+}
+
+// checkIntersectionAccess returns the ID of the requesting user once it is confirmed
+// that the intersection is in their intersection list
+func (s *SimulationService) checkIntersectionAccess(
+	ctx context.Context,
+	intersectionID string,
+) (string, error) {
+	logger := middleware.LoggerFromContext(ctx)
+
 	userID, ok := middleware.GetUserID(ctx)
 	if !ok {
-		return model.OptimisationResponse{}, errs.NewInternalError(
+		return "", errs.NewInternalError(
 			"user ID missing inside of handler",
 			nil,
 			map[string]any{},
@@ -173,84 +854,117 @@ func (s *SimulationService) OptimiseIntersection(
 	logger.Debug("calling user service to retrieve user's intersection IDs")
 	intersectionIDs, err := s.GetUserIntersectionIDs(ctx, userID)
 	if err != nil {
-		return model.OptimisationResponse{}, err
+		return "", err
 	}
 
 	if !slices.Contains(intersectionIDs, intersectionID) {
-		return model.OptimisationResponse{}, errs.NewForbiddenError(
+		return "", errs.NewForbiddenError(
 			"you do not have access to this intersection",
 			map[string]any{"intersectionID": intersectionID},
 		)
 	}
 
-	logger.Debug("calling intersection service to get intersection details")
-	intersection, err := s.intrClient.GetIntersection(ctx, intersectionID)
-	if err != nil {
-		return model.OptimisationResponse{}, err
-	}
+	return userID, nil
+}
 
-	logger.Debug(
-		"calling intersection service to change status to 'INTERSECTION_STATUS_OPTIMISING'",
-	)
-	_, err = s.intrClient.UpdateIntersectionStatus(
-		ctx,
-		intersection.Id,
-		intersection.Name,
-		util.RPCDetailsToDetails(intersection.Details),
-		commonpb.IntersectionStatus_INTERSECTION_STATUS_OPTIMISING,
+func (s *SimulationService) getOwnedOptimisationJob(
+	ctx context.Context,
+	jobID string,
+) (*intersectionpb.OptimisationJobResponse, error) {
+	logger := middleware.LoggerFromContext(ctx).With(
+		"service", "simulation",
 	)
+
+	userID, ok := middleware.GetUserID(ctx)
+	if !ok {
+		return nil, errs.NewInternalError(
+			"user ID missing inside of handler",
+			nil,
+			map[string]any{},
+		)
+	}
+
+	logger.Debug("calling intersection service to get optimisation job")
+	job, err := s.intrClient.GetOptimisationJob(ctx, jobID)
 	if err != nil {
-		logger.Warn("Could not update intersection status to 'INTERSECTION_STATUS_OPTIMISING'")
+		return nil, err
 	}
 
-	resultChan := make(chan model.OptimisationResponse, 1)
-	errChan := make(chan error, 1)
+	logger.Debug("calling user service to retrieve user's intersection IDs")
+	intersectionIDs, err := s.GetUserIntersectionIDs(ctx, userID)
+	if err != nil {
+		return nil, err
+	}
 
-	go func() {
-		ctx := context.Background()
-		logger.Debug("calling optimisation service to optimise intersection")
-		response, err := s.optiClient.RunOptimisation(
-			ctx,
-			util.RPCOptiParamToOptiParam(intersection.DefaultParameters),
+	if !slices.Contains(intersectionIDs, job.IntersectionId) {
+		return nil, errs.NewForbiddenError(
+			"you do not have access to this optimisation job",
+			map[string]any{"jobID": jobID},
 		)
-		if err != nil {
-			errChan <- err
-			return
-		}
+	}
 
-		logger.Debug("updating intersection with optimised parameters")
-		resp, err := s.intrClient.PutOptimisation(
-			ctx,
-			intersectionID,
-			util.RPCOptiParamToOptiParamOp(response),
-		)
-		if err != nil {
-			errChan <- err
-			return
+	return job, nil
+}
+
+func (s *SimulationService) getOptimisationJobs(
+	ctx context.Context,
+	intersectionID string,
+	statuses []intersectionpb.OptimisationJobStatus,
+) ([]*intersectionpb.OptimisationJobResponse, error) {
+	stream, err := s.intrClient.GetOptimisationJobs(ctx, intersectionID, statuses)
+	if err != nil {
+		return nil, err
+	}
+
+	jobs := []*intersectionpb.OptimisationJobResponse{}
+	for {
+		job, err := stream.Recv()
+		if err == io.EOF {
+			break
 		}
-		logger.Debug(
-			"calling intersection service to change status to 'INTERSECTION_STATUS_OPTIMISED'",
-		)
-		_, err = s.intrClient.UpdateIntersectionStatus(
-			ctx,
-			intersection.Id,
-			intersection.Name,
-			util.RPCDetailsToDetails(intersection.Details),
-			commonpb.IntersectionStatus_INTERSECTION_STATUS_OPTIMISED,
-		)
 		if err != nil {
-			logger.Warn("Could not update intersection status to 'INTERSECTION_STATUS_OPTIMISED'")
+			return nil, errs.NewInternalError(
+				"unable to retrieve optimisation jobs",
+				err,
+				map[string]any{},
+			)
 		}
-		resultChan <- model.OptimisationResponse{Improved: resp.Improved}
-	}()
+		jobs = append(jobs, job)
+	}
+	return jobs, nil
+}
+
+func isFinishedOptimisationJob(status intersectionpb.OptimisationJobStatus) bool {
+	switch status {
+	case intersectionpb.OptimisationJobStatus_OPTIMISATION_JOB_STATUS_SUCCEEDED,
+		intersectionpb.OptimisationJobStatus_OPTIMISATION_JOB_STATUS_FAILED,
+		intersectionpb.OptimisationJobStatus_OPTIMISATION_JOB_STATUS_CANCELLED:
+		return true
+	default:
+		return false
+	}
+}
+
+func (s *SimulationService) trackJob(jobID string, cancel context.CancelFunc) {
+	s.jobsMu.Lock()
+	defer s.jobsMu.Unlock()
+	s.jobs[jobID] = cancel
+}
 
-	select {
-	case result := <-resultChan:
-		return result, nil
-	case err := <-errChan:
-		return model.OptimisationResponse{}, err
-	case <-ctx.Done():
-		return model.OptimisationResponse{}, ctx.Err()
+func (s *SimulationService) untrackJob(jobID string) {
+	s.jobsMu.Lock()
+	defer s.jobsMu.Unlock()
+	if cancel, ok := s.jobs[jobID]; ok {
+		cancel()
+		delete(s.jobs, jobID)
+	}
+}
+
+func (s *SimulationService) cancelJob(jobID string) {
+	s.jobsMu.Lock()
+	defer s.jobsMu.Unlock()
+	if cancel, ok := s.jobs[jobID]; ok {
+		cancel()
 	}
 }
 
@@ -283,12 +997,85 @@ func (s *SimulationService) GetUserIntersectionIDs(
 
 // SimulationServiceInterface creates stub for testing
 type SimulationServiceInterface interface {
-	GetSimulationData(ctx context.Context, intersectionID string) (model.SimulationResponse, error)
-	GetOptimisedData(ctx context.Context, intersectionID string) (model.SimulationResponse, error)
-	OptimiseIntersection(
+	GetSimulationData(
+		ctx context.Context,
+		intersectionID string,
+		filter model.OutputFilter,
+	) (model.SimulationResponse, error)
+	GetOptimisedData(
+		ctx context.Context,
+		intersectionID string,
+		filter model.OutputFilter,
+	) (model.SimulationResponse, error)
+	StreamSimulationOutput(
+		ctx context.Context,
+		intersectionID string,
+		filter model.OutputFilter,
+		send func(chunk model.SimulationOutputChunk) error,
+	) error
+	StreamOptimisedOutput(
+		ctx context.Context,
+		intersectionID string,
+		filter model.OutputFilter,
+		send func(chunk model.SimulationOutputChunk) error,
+	) error
+	RunWhatIfSimulation(
+		ctx context.Context,
+		intersectionID string,
+		params model.SimulationParameters,
+		filter model.OutputFilter,
+	) (model.SimulationResponse, error)
+	ReplicateSimulation(
+		ctx context.Context,
+		intersectionID string,
+		replications int,
+	) (model.ReplicatedResults, error)
+	ReplicateOptimised(
+		ctx context.Context,
+		intersectionID string,
+		replications int,
+	) (model.ReplicatedResults, error)
+	CompareOptimisation(
+		ctx context.Context,
+		intersectionID string,
+		replications int,
+	) (model.Comparison, error)
+	CompareRuns(
+		ctx context.Context,
+		intersectionID, baselineRunID, candidateRunID string,
+	) (model.Comparison, error)
+	CompareParameters(
+		ctx context.Context,
+		intersectionID string,
+		baselineParams, candidateParams model.SimulationParameters,
+		replications int,
+	) (model.Comparison, error)
+	OptimiseIntersection(ctx context.Context, intersectionID string) (model.OptimisationJob, error)
+	GetOptimisationJob(ctx context.Context, jobID string) (model.OptimisationJob, error)
+	GetOptimisationJobs(
+		ctx context.Context,
+		intersectionID string,
+	) (model.OptimisationJobs, error)
+	CancelOptimisationJob(ctx context.Context, jobID string) (model.OptimisationJob, error)
+	GetRuns(ctx context.Context, intersectionID string, page, pageSize int) (model.Runs, error)
+	GetRun(ctx context.Context, intersectionID, runID string) (model.Run, error)
+	SubscribeOptimisationEvents(
+		ctx context.Context,
+		intersectionID string,
+	) (<-chan model.OptimisationEvent, func(), error)
+	StartSweep(
+		ctx context.Context,
+		intersectionID string,
+		ranges []model.SweepRange,
+	) (model.Sweep, error)
+	GetSweep(ctx context.Context, intersectionID, sweepID string) (model.Sweep, error)
+	GetSweeps(ctx context.Context, intersectionID string) (model.Sweeps, error)
+	RecoverSweeps(ctx context.Context) error
+	AnalyseSensitivity(
 		ctx context.Context,
 		intersectionID string,
-	) (model.OptimisationResponse, error)
+		req model.SensitivityRequest,
+	) (model.Sensitivity, error)
 }
 
 // NOTE: Asserts the SimulationService implements the SimulationServiceInterface
@@ -0,0 +1,356 @@
+package service
+
+import (
+	"context"
+	"errors"
+	"io"
+	"slices"
+	"time"
+
+	"github.com/COS301-SE-2025/Swift-Signals/api-gateway/internal/middleware"
+	"github.com/COS301-SE-2025/Swift-Signals/api-gateway/internal/model"
+	"github.com/COS301-SE-2025/Swift-Signals/api-gateway/internal/util"
+	intersectionpb "github.com/COS301-SE-2025/Swift-Signals/protos/gen/swiftsignals/intersection/v1"
+	errs "github.com/COS301-SE-2025/Swift-Signals/shared/error"
+	"golang.org/x/sync/errgroup"
+)
+
+// SweepConfig bounds parameter sweeps
+type SweepConfig struct {
+	// Concurrency is the number of points of a sweep simulated at the same time
+	Concurrency int
+	// CallTimeout is the deadline for simulating a single point. Points that run past it
+	// are recorded as failed and the sweep moves on.
+	CallTimeout time.Duration
+	// MaxPoints is the largest grid a sweep may cover
+	MaxPoints int
+}
+
+// StartSweep simulates an intersection over every combination of the given ranges, with
+// its default parameters for everything that is not swept. The sweep runs in the
+// background and every point is saved as soon as it has been simulated.
+func (s *SimulationService) StartSweep(
+	ctx context.Context,
+	intersectionID string,
+	ranges []model.SweepRange,
+) (model.Sweep, error) {
+	logger := middleware.LoggerFromContext(ctx).With(
+		"service", "simulation",
+	)
+
+	userID, params, err := s.getSimulationParameters(ctx, intersectionID, false)
+	if err != nil {
+		return model.Sweep{}, err
+	}
+
+	base := util.RPCSimParamToSimParam(params.Parameters)
+	points, err := s.sweepPoints(base, ranges)
+	if err != nil {
+		return model.Sweep{}, err
+	}
+
+	logger.Debug("calling intersection service to create sweep", "points", len(points))
+	sweep, err := s.intrClient.CreateSweep(ctx, intersectionID, userID, base, ranges, len(points))
+	if err != nil {
+		return model.Sweep{}, err
+	}
+
+	// NOTE: The sweep outlives the request, so it gets its own context
+	sweepCtx := middleware.SetLogger(context.Background(), logger.With("sweep_id", sweep.Id))
+	go s.runSweep(sweepCtx, sweep.Id, intersectionID, points)
+
+	return util.RPCSweepToSweep(sweep), nil
+}
+
+// GetSweep returns a sweep with the points simulated so far, ordered by their index
+func (s *SimulationService) GetSweep(
+	ctx context.Context,
+	intersectionID, sweepID string,
+) (model.Sweep, error) {
+	logger := middleware.LoggerFromContext(ctx).With(
+		"service", "simulation",
+	)
+
+	if _, err := s.checkIntersectionAccess(ctx, intersectionID); err != nil {
+		return model.Sweep{}, err
+	}
+
+	logger.Debug("calling intersection service to get sweep")
+	rpcSweep, err := s.intrClient.GetSweep(ctx, intersectionID, sweepID)
+	if err != nil {
+		return model.Sweep{}, err
+	}
+
+	sweep := util.RPCSweepToSweep(rpcSweep)
+	slices.SortFunc(sweep.Points, func(a, b model.SweepPoint) int {
+		return a.Index - b.Index
+	})
+	return sweep, nil
+}
+
+// GetSweeps returns the sweeps of an intersection without their points
+func (s *SimulationService) GetSweeps(
+	ctx context.Context,
+	intersectionID string,
+) (model.Sweeps, error) {
+	logger := middleware.LoggerFromContext(ctx).With(
+		"service", "simulation",
+	)
+
+	if _, err := s.checkIntersectionAccess(ctx, intersectionID); err != nil {
+		return model.Sweeps{}, err
+	}
+
+	logger.Debug("calling intersection service to get sweeps")
+	sweeps, err := s.getSweeps(ctx, intersectionID, nil)
+	if err != nil {
+		return model.Sweeps{}, err
+	}
+
+	result := model.Sweeps{Sweeps: make([]model.Sweep, 0, len(sweeps))}
+	for _, sweep := range sweeps {
+		result.Sweeps = append(result.Sweeps, util.RPCSweepToSweep(sweep))
+	}
+	return result, nil
+}
+
+// RecoverSweeps fails every sweep that was still pending or running when the gateway last
+// stopped, keeping the points it had already saved.
+// NOTE: This assumes a single gateway instance owns all sweeps
+func (s *SimulationService) RecoverSweeps(ctx context.Context) error {
+	logger := middleware.LoggerFromContext(ctx).With(
+		"service", "simulation",
+	)
+
+	logger.Debug("calling intersection service to find interrupted sweeps")
+	sweeps, err := s.getSweeps(ctx, "", []intersectionpb.SweepStatus{
+		intersectionpb.SweepStatus_SWEEP_STATUS_PENDING,
+		intersectionpb.SweepStatus_SWEEP_STATUS_RUNNING,
+	})
+	if err != nil {
+		return err
+	}
+
+	for _, sweep := range sweeps {
+		logger.Info("failing interrupted sweep", "sweep_id", sweep.Id)
+		_, err := s.intrClient.UpdateSweep(
+			ctx,
+			sweep.Id,
+			intersectionpb.SweepStatus_SWEEP_STATUS_FAILED,
+			"interrupted by a gateway restart",
+		)
+		if err != nil {
+			logger.Warn("could not fail interrupted sweep",
+				"sweep_id", sweep.Id,
+				"error", err.Error(),
+			)
+		}
+	}
+
+	return nil
+}
+
+/******************/
+/* Helper Methods */
+/******************/
+
+// runSweep simulates the points of a sweep at most SweepConfig.Concurrency at a time. Points
+// that fail are saved with their error, while failing to save a point fails the sweep.
+func (s *SimulationService) runSweep(
+	ctx context.Context,
+	sweepID, intersectionID string,
+	points []model.SimulationParameters,
+) {
+	logger := middleware.LoggerFromContext(ctx)
+
+	logger.Debug("calling intersection service to mark sweep as running")
+	_, err := s.intrClient.UpdateSweep(
+		ctx,
+		sweepID,
+		intersectionpb.SweepStatus_SWEEP_STATUS_RUNNING,
+		"",
+	)
+	if err != nil {
+		logger.Error("could not start sweep", "error", err.Error())
+		s.finishSweep(ctx, sweepID, err)
+		return
+	}
+
+	g, gctx := errgroup.WithContext(ctx)
+	g.SetLimit(max(s.sweep.Concurrency, 1))
+	for i, params := range points {
+		g.Go(func() error {
+			point := s.simulateSweepPoint(gctx, intersectionID, i, params)
+			if gctx.Err() != nil {
+				return gctx.Err()
+			}
+			_, err := s.intrClient.PutSweepPoint(gctx, sweepID, point)
+			return err
+		})
+	}
+
+	s.finishSweep(ctx, sweepID, g.Wait())
+}
+
+func (s *SimulationService) simulateSweepPoint(
+	ctx context.Context,
+	intersectionID string,
+	index int,
+	params model.SimulationParameters,
+) model.SweepPoint {
+	logger := middleware.LoggerFromContext(ctx)
+
+	if s.sweep.CallTimeout > 0 {
+		var cancel context.CancelFunc
+		ctx, cancel = context.WithTimeout(ctx, s.sweep.CallTimeout)
+		defer cancel()
+	}
+
+	point := model.SweepPoint{Index: index, Parameters: params}
+	started := time.Now()
+
+	logger.Debug("calling simulation service to simulate sweep point", "index", index)
+	simResults, err := s.simClient.GetSimulationResults(ctx, intersectionID, params)
+	point.DurationMs = time.Since(started).Milliseconds()
+	switch {
+	case err != nil && errors.Is(ctx.Err(), context.DeadlineExceeded):
+		point.Error = "simulation ran past its deadline of " + s.sweep.CallTimeout.String()
+	case err != nil:
+		point.Error = err.Error()
+	default:
+		results := util.RPCSimResultsToSimResults(simResults)
+		point.Results = &results
+	}
+	if point.Error != "" {
+		logger.Warn("sweep point failed", "index", index, "error", point.Error)
+	}
+	return point
+}
+
+func (s *SimulationService) finishSweep(ctx context.Context, sweepID string, cause error) {
+	logger := middleware.LoggerFromContext(ctx)
+
+	status := intersectionpb.SweepStatus_SWEEP_STATUS_SUCCEEDED
+	errMsg := ""
+	if cause != nil {
+		status = intersectionpb.SweepStatus_SWEEP_STATUS_FAILED
+		errMsg = cause.Error()
+	}
+
+	logger.Debug("calling intersection service to finish sweep", "status", status.String())
+	if _, err := s.intrClient.UpdateSweep(ctx, sweepID, status, errMsg); err != nil {
+		logger.Warn("could not finish sweep", "error", err.Error())
+	}
+}
+
+// sweepPoints expands the ranges into the parameters of every point of the grid, with the
+// last range changing fastest
+func (s *SimulationService) sweepPoints(
+	base model.SimulationParameters,
+	ranges []model.SweepRange,
+) ([]model.SimulationParameters, error) {
+	total := 1
+	seen := make(map[string]bool, len(ranges))
+	for _, r := range ranges {
+		if seen[r.Parameter] {
+			return nil, errs.NewValidationError(
+				"each parameter can only be swept once",
+				map[string]any{"parameter": r.Parameter},
+			)
+		}
+		seen[r.Parameter] = true
+		switch r.Parameter {
+		case model.SweepParameterGreen,
+			model.SweepParameterYellow,
+			model.SweepParameterRed,
+			model.SweepParameterSpeed,
+			model.SweepParameterSeed:
+		default:
+			return nil, errs.NewValidationError(
+				"parameter cannot be swept",
+				map[string]any{"parameter": r.Parameter},
+			)
+		}
+		if r.Step < 1 || r.Max < r.Min {
+			return nil, errs.NewValidationError(
+				"sweep ranges need a positive step and a max of at least their min",
+				map[string]any{"parameter": r.Parameter},
+			)
+		}
+		if r.Min < 1 || r.Max > model.SweepMax {
+			return nil, errs.NewValidationError(
+				"sweep range is outside what the simulator accepts",
+				map[string]any{"parameter": r.Parameter, "max": model.SweepMax},
+			)
+		}
+
+		total *= (r.Max-r.Min)/r.Step + 1
+		if total > s.sweep.MaxPoints {
+			return nil, errs.NewValidationError(
+				"sweep covers more points than the maximum",
+				map[string]any{"max": s.sweep.MaxPoints},
+			)
+		}
+	}
+
+	points := make([]model.SimulationParameters, 0, total)
+	var expand func(params model.SimulationParameters, depth int)
+	expand = func(params model.SimulationParameters, depth int) {
+		if depth == len(ranges) {
+			points = append(points, params)
+			return
+		}
+		r := ranges[depth]
+		// NOTE: Counting the steps rather than stepping the value cannot overflow past Max
+		for i := 0; i <= (r.Max-r.Min)/r.Step; i++ {
+			setSweepParameter(&params, r.Parameter, r.Min+i*r.Step)
+			expand(params, depth+1)
+		}
+	}
+	expand(base, 0)
+
+	return points, nil
+}
+
+func setSweepParameter(params *model.SimulationParameters, parameter string, value int) {
+	switch parameter {
+	case model.SweepParameterGreen:
+		params.Green = value
+	case model.SweepParameterYellow:
+		params.Yellow = value
+	case model.SweepParameterRed:
+		params.Red = value
+	case model.SweepParameterSpeed:
+		params.Speed = value
+	case model.SweepParameterSeed:
+		params.Seed = value
+	}
+}
+
+func (s *SimulationService) getSweeps(
+	ctx context.Context,
+	intersectionID string,
+	statuses []intersectionpb.SweepStatus,
+) ([]*intersectionpb.SweepResponse, error) {
+	stream, err := s.intrClient.GetSweeps(ctx, intersectionID, statuses)
+	if err != nil {
+		return nil, err
+	}
+
+	sweeps := []*intersectionpb.SweepResponse{}
+	for {
+		sweep, err := stream.Recv()
+		if err == io.EOF {
+			break
+		}
+		if err != nil {
+			return nil, errs.NewInternalError(
+				"unable to retrieve sweeps",
+				err,
+				map[string]any{},
+			)
+		}
+		sweeps = append(sweeps, sweep)
+	}
+	return sweeps, nil
+}
@@ -1,7 +1,11 @@
 package admin
 
 import (
+	"context"
+	"time"
+
 	mocks "github.com/COS301-SE-2025/Swift-Signals/api-gateway/internal/mocks/client"
+	"github.com/COS301-SE-2025/Swift-Signals/api-gateway/internal/repository"
 	"github.com/COS301-SE-2025/Swift-Signals/api-gateway/internal/service"
 	userpb "github.com/COS301-SE-2025/Swift-Signals/protos/gen/swiftsignals/user/v1"
 	"github.com/stretchr/testify/suite"
@@ -9,13 +13,34 @@ import (
 
 type TestSuite struct {
 	suite.Suite
-	client  *mocks.MockUserClientInterface
-	service service.AdminServiceInterface
+	client     *mocks.MockUserClientInterface
+	intrClient *mocks.MockIntersectionClientInterface
+	service    service.AdminServiceInterface
+
+	notifications *repository.MemoryNotificationRepository
 }
 
 func (suite *TestSuite) SetupTest() {
 	suite.client = new(mocks.MockUserClientInterface)
-	suite.service = service.NewAdminService(suite.client)
+	suite.intrClient = new(mocks.MockIntersectionClientInterface)
+	suite.notifications = repository.NewMemoryNotificationRepository()
+	suite.service = service.NewAdminService(
+		suite.client,
+		suite.intrClient,
+		service.NewOwnershipReconciler(suite.client, suite.intrClient, 10*time.Minute),
+		service.NewNotificationService(suite.notifications),
+	)
+}
+
+// expectUser expects the user being deleted to be looked up for the intersections they own
+func (suite *TestSuite) expectUser(
+	ctx context.Context,
+	userID string,
+	intersectionIDs ...string,
+) {
+	suite.client.On("GetUserByID", ctx, userID).
+		Return(createTestUser(userID, "Test User", "test@example.com", false, intersectionIDs), nil).
+		Once()
 }
 
 // createTestUser creates a test user protobuf object
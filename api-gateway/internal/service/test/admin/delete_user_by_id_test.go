@@ -8,6 +8,7 @@ func (suite *TestSuite) TestDeleteUserByID_Success() {
 	userID := "user-123"
 	ctx := createAdminContext()
 
+	suite.expectUser(ctx, userID)
 	suite.client.On("DeleteUser", ctx, userID).
 		Return(nil, nil)
 
@@ -50,6 +51,7 @@ func (suite *TestSuite) TestDeleteUserByID_UserNotFound() {
 	userID := "nonexistent-user"
 	ctx := createAdminContext()
 
+	suite.expectUser(ctx, userID)
 	suite.client.On("DeleteUser", ctx, userID).
 		Return(nil, errs.NewNotFoundError("user not found", map[string]any{"userID": userID}))
 
@@ -69,6 +71,7 @@ func (suite *TestSuite) TestDeleteUserByID_CannotDeleteSelf() {
 	userID := "admin-self"
 	ctx := createAdminContext()
 
+	suite.expectUser(ctx, userID)
 	suite.client.On("DeleteUser", ctx, userID).
 		Return(nil, errs.NewForbiddenError("cannot delete your own account", map[string]any{"userID": userID}))
 
@@ -88,6 +91,7 @@ func (suite *TestSuite) TestDeleteUserByID_InternalError() {
 	userID := "user-123"
 	ctx := createAdminContext()
 
+	suite.expectUser(ctx, userID)
 	suite.client.On("DeleteUser", ctx, userID).
 		Return(nil, errs.NewInternalError("database connection failed", nil, map[string]any{}))
 
@@ -107,6 +111,7 @@ func (suite *TestSuite) TestDeleteUserByID_EmptyUserID() {
 	userID := ""
 	ctx := createAdminContext()
 
+	suite.expectUser(ctx, userID)
 	suite.client.On("DeleteUser", ctx, userID).
 		Return(nil, errs.NewValidationError("user ID cannot be empty", map[string]any{"userID": userID}))
 
@@ -126,6 +131,7 @@ func (suite *TestSuite) TestDeleteUserByID_InvalidUserID() {
 	userID := "invalid-user-id-format"
 	ctx := createAdminContext()
 
+	suite.expectUser(ctx, userID)
 	suite.client.On("DeleteUser", ctx, userID).
 		Return(nil, errs.NewValidationError("invalid user ID format", map[string]any{"userID": userID}))
 
@@ -140,3 +146,83 @@ func (suite *TestSuite) TestDeleteUserByID_InvalidUserID() {
 
 	suite.client.AssertExpectations(suite.T())
 }
+
+func (suite *TestSuite) TestDeleteUserByID_TrashesIntersections() {
+	userID := "user-123"
+	ctx := createAdminContext()
+
+	suite.expectUser(ctx, userID, "intersection-1", "intersection-2")
+	suite.intrClient.On("DeleteIntersection", ctx, "intersection-1", 0).Return(nil, nil)
+	// NOTE: Already in the trash
+	suite.intrClient.On("DeleteIntersection", ctx, "intersection-2", 0).
+		Return(nil, errs.NewNotFoundError("intersection ID not found for deletion", map[string]any{}))
+	suite.client.On("DeleteUser", ctx, userID).Return(nil, nil)
+
+	err := suite.service.DeleteUserByID(ctx, userID)
+
+	suite.Require().NoError(err)
+	suite.client.AssertExpectations(suite.T())
+	suite.intrClient.AssertExpectations(suite.T())
+	suite.intrClient.AssertNotCalled(suite.T(), "RestoreIntersection", ctx, "intersection-1")
+}
+
+func (suite *TestSuite) TestDeleteUserByID_RestoresIntersectionsWhenUserNotDeleted() {
+	userID := "user-123"
+	ctx := createAdminContext()
+
+	suite.expectUser(ctx, userID, "intersection-1", "intersection-2")
+	suite.intrClient.On("DeleteIntersection", ctx, "intersection-1", 0).Return(nil, nil)
+	suite.intrClient.On("DeleteIntersection", ctx, "intersection-2", 0).Return(nil, nil)
+	suite.client.On("DeleteUser", ctx, userID).
+		Return(nil, errs.NewInternalError("database connection failed", nil, map[string]any{}))
+	suite.intrClient.On("RestoreIntersection", ctx, "intersection-1").Return(nil, nil)
+	suite.intrClient.On("RestoreIntersection", ctx, "intersection-2").Return(nil, nil)
+
+	err := suite.service.DeleteUserByID(ctx, userID)
+
+	suite.Require().Error(err)
+	svcError, ok := err.(*errs.ServiceError)
+	suite.True(ok)
+	suite.Equal(errs.ErrInternal, svcError.Code)
+	suite.Equal("database connection failed", svcError.Message)
+
+	suite.client.AssertExpectations(suite.T())
+	suite.intrClient.AssertExpectations(suite.T())
+}
+
+func (suite *TestSuite) TestDeleteUserByID_IntersectionDeleteError() {
+	userID := "user-123"
+	ctx := createAdminContext()
+
+	suite.expectUser(ctx, userID, "intersection-1", "intersection-2")
+	suite.intrClient.On("DeleteIntersection", ctx, "intersection-1", 0).Return(nil, nil)
+	suite.intrClient.On("DeleteIntersection", ctx, "intersection-2", 0).
+		Return(nil, errs.NewUnavailableError("intersection service unavailable", map[string]any{}))
+	suite.intrClient.On("RestoreIntersection", ctx, "intersection-1").Return(nil, nil)
+
+	err := suite.service.DeleteUserByID(ctx, userID)
+
+	suite.Require().Error(err)
+	svcError, ok := err.(*errs.ServiceError)
+	suite.True(ok)
+	suite.Equal(errs.ErrUnavailable, svcError.Code)
+
+	suite.intrClient.AssertExpectations(suite.T())
+	suite.client.AssertNotCalled(suite.T(), "DeleteUser", ctx, userID)
+}
+
+func (suite *TestSuite) TestDeleteUserByID_LookupError() {
+	userID := "nonexistent-user"
+	ctx := createAdminContext()
+
+	suite.client.On("GetUserByID", ctx, userID).
+		Return(nil, errs.NewNotFoundError("user not found", map[string]any{"userID": userID}))
+
+	err := suite.service.DeleteUserByID(ctx, userID)
+
+	suite.Require().Error(err)
+	svcError, ok := err.(*errs.ServiceError)
+	suite.True(ok)
+	suite.Equal(errs.ErrNotFound, svcError.Code)
+	suite.client.AssertNotCalled(suite.T(), "DeleteUser", ctx, userID)
+}
@@ -56,6 +56,7 @@ func (suite *TestSuite) TestCompleteUserManagementLifecycle() {
 	suite.False(updateResult.IsAdmin)
 
 	// Step 3: Delete the user
+	suite.expectUser(ctx, userID)
 	suite.client.On("DeleteUser", ctx, userID).
 		Return(nil, nil).
 		Once()
@@ -168,6 +169,7 @@ func (suite *TestSuite) TestErrorPropagationConsistency() {
 	suite.Equal("database connection failed", svcError.Message)
 
 	// Test DeleteUser error propagation
+	suite.expectUser(ctx, userID)
 	suite.client.On("DeleteUser", ctx, userID).
 		Return(nil, internalError).
 		Once()
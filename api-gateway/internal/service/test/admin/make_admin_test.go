@@ -0,0 +1,76 @@
+package admin
+
+import (
+	"github.com/COS301-SE-2025/Swift-Signals/api-gateway/internal/middleware"
+	"github.com/COS301-SE-2025/Swift-Signals/api-gateway/internal/model"
+	errs "github.com/COS301-SE-2025/Swift-Signals/shared/error"
+	"google.golang.org/protobuf/types/known/emptypb"
+)
+
+func (suite *TestSuite) TestMakeAdmin_Success() {
+	ctx := middleware.SetUserID(createAdminContext(), "admin-1")
+	suite.client.On("MakeAdmin", ctx, "user-123", "admin-1").Return(&emptypb.Empty{}, nil)
+
+	err := suite.service.MakeAdmin(ctx, "user-123")
+
+	suite.Require().NoError(err)
+	notifications, err := suite.notifications.GetNotifications(ctx, "user-123", 10)
+	suite.Require().NoError(err)
+	suite.Require().Len(notifications, 1)
+	suite.Equal(model.NotificationTypeAdminGranted, notifications[0].Type)
+	suite.Equal("You have been made an administrator", notifications[0].Message)
+	suite.client.AssertExpectations(suite.T())
+}
+
+func (suite *TestSuite) TestMakeAdmin_Forbidden_NonAdmin() {
+	ctx := middleware.SetUserID(createUserContext(), "user-456")
+
+	err := suite.service.MakeAdmin(ctx, "user-123")
+
+	suite.Require().Error(err)
+	svcError, ok := err.(*errs.ServiceError)
+	suite.Require().True(ok)
+	suite.Equal(errs.ErrForbidden, svcError.Code)
+	suite.client.AssertNotCalled(suite.T(), "MakeAdmin")
+}
+
+func (suite *TestSuite) TestMakeAdmin_UserNotFound() {
+	ctx := middleware.SetUserID(createAdminContext(), "admin-1")
+	suite.client.On("MakeAdmin", ctx, "missing", "admin-1").
+		Return(nil, errs.NewNotFoundError("user not found", map[string]any{}))
+
+	err := suite.service.MakeAdmin(ctx, "missing")
+
+	suite.Require().Error(err)
+	svcError, ok := err.(*errs.ServiceError)
+	suite.Require().True(ok)
+	suite.Equal(errs.ErrNotFound, svcError.Code)
+
+	unread, err := suite.notifications.CountUnread(ctx, "missing")
+	suite.Require().NoError(err)
+	suite.Zero(unread)
+}
+
+func (suite *TestSuite) TestRemoveAdmin_Success() {
+	ctx := middleware.SetUserID(createAdminContext(), "admin-1")
+	suite.client.On("RemoveAdmin", ctx, "admin-2", "admin-1").Return(&emptypb.Empty{}, nil)
+
+	err := suite.service.RemoveAdmin(ctx, "admin-2")
+
+	suite.Require().NoError(err)
+	notifications, err := suite.notifications.GetNotifications(ctx, "admin-2", 10)
+	suite.Require().NoError(err)
+	suite.Require().Len(notifications, 1)
+	suite.Equal(model.NotificationTypeAdminRevoked, notifications[0].Type)
+	suite.client.AssertExpectations(suite.T())
+}
+
+func (suite *TestSuite) TestRemoveAdmin_MissingUserID() {
+	err := suite.service.RemoveAdmin(createAdminContext(), "admin-2")
+
+	suite.Require().Error(err)
+	svcError, ok := err.(*errs.ServiceError)
+	suite.Require().True(ok)
+	suite.Equal(errs.ErrInternal, svcError.Code)
+	suite.client.AssertNotCalled(suite.T(), "RemoveAdmin")
+}
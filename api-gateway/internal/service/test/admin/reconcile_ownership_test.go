@@ -0,0 +1,175 @@
+package admin
+
+import (
+	"fmt"
+	"io"
+	"time"
+
+	grpcmocks "github.com/COS301-SE-2025/Swift-Signals/api-gateway/internal/mocks/grpc_client"
+	intersectionpb "github.com/COS301-SE-2025/Swift-Signals/protos/gen/swiftsignals/intersection/v1"
+	userpb "github.com/COS301-SE-2025/Swift-Signals/protos/gen/swiftsignals/user/v1"
+	errs "github.com/COS301-SE-2025/Swift-Signals/shared/error"
+	"github.com/stretchr/testify/mock"
+	"google.golang.org/protobuf/types/known/timestamppb"
+)
+
+func (suite *TestSuite) usersStream(users ...*userpb.UserResponse) any {
+	stream := suite.NewMockGetAllUsersStream()
+	for _, user := range users {
+		stream.On("Recv").Return(user, nil).Once()
+	}
+	stream.On("Recv").Return(nil, io.EOF).Once()
+	return stream
+}
+
+func (suite *TestSuite) intersectionsStream(
+	intersections ...*intersectionpb.IntersectionResponse,
+) any {
+	stream := grpcmocks.NewMockIntersectionService_GetAllIntersectionsClient[intersectionpb.IntersectionResponse](
+		suite.T(),
+	)
+	for _, intersection := range intersections {
+		stream.On("Recv").Return(intersection, nil).Once()
+	}
+	stream.On("Recv").Return(nil, io.EOF).Once()
+	return stream
+}
+
+func createdAgo(id string, age time.Duration) *intersectionpb.IntersectionResponse {
+	return &intersectionpb.IntersectionResponse{
+		Id:        id,
+		CreatedAt: timestamppb.New(time.Now().Add(-age)),
+	}
+}
+
+// expectOwnership sets up one user owning owned-1 and dangling-1, and the intersections
+// owned-1, orphan-1 (old enough to be an orphan), new-1 (too new to be) and, in the trash,
+// trashed-1
+func (suite *TestSuite) expectOwnership() {
+	suite.client.On("GetUsersAfter", mock.Anything, "", int32(100)).
+		Return(suite.usersStream(
+			createTestUser("user-1", "Test User", "test@example.com", false,
+				[]string{"owned-1", "dangling-1"}),
+			createTestUser("user-2", "Other User", "other@example.com", false, nil),
+		), nil)
+	trashed := createdAgo("trashed-1", time.Hour)
+	trashed.DeletedAt = timestamppb.Now()
+	suite.intrClient.On("ListIntersectionsAfter", mock.Anything, "", 100).
+		Return(suite.intersectionsStream(
+			createdAgo("new-1", time.Minute),
+			createdAgo("orphan-1", time.Hour),
+			createdAgo("owned-1", time.Hour),
+			trashed,
+		), nil)
+}
+
+func (suite *TestSuite) TestReconcileOwnership_Report() {
+	ctx := createAdminContext()
+	suite.expectOwnership()
+
+	report, err := suite.service.ReconcileOwnership(ctx, false)
+
+	suite.Require().NoError(err)
+	suite.Equal([]string{"orphan-1"}, report.OrphanedIntersections)
+	suite.Equal(map[string][]string{"user-1": {"dangling-1"}}, report.DanglingIntersectionIDs)
+	suite.False(report.Repaired)
+	suite.intrClient.AssertNotCalled(suite.T(), "DeleteIntersection", mock.Anything, mock.Anything, mock.Anything)
+	suite.client.AssertNotCalled(suite.T(), "RemoveIntersectionIDs",
+		mock.Anything, mock.Anything, mock.Anything)
+}
+
+func (suite *TestSuite) TestReconcileOwnership_Repair() {
+	ctx := createAdminContext()
+	suite.expectOwnership()
+	suite.intrClient.On("DeleteIntersection", ctx, "orphan-1", 0).Return(nil, nil)
+	suite.client.On("RemoveIntersectionIDs", ctx, "user-1", []string{"dangling-1"}).
+		Return(nil, nil)
+
+	report, err := suite.service.ReconcileOwnership(ctx, true)
+
+	suite.Require().NoError(err)
+	suite.True(report.Repaired)
+	suite.intrClient.AssertExpectations(suite.T())
+	suite.client.AssertExpectations(suite.T())
+}
+
+func (suite *TestSuite) TestReconcileOwnership_RepairError() {
+	ctx := createAdminContext()
+	suite.expectOwnership()
+	suite.intrClient.On("DeleteIntersection", ctx, "orphan-1", 0).
+		Return(nil, errs.NewUnavailableError("intersection service unavailable", map[string]any{}))
+	suite.client.On("RemoveIntersectionIDs", ctx, "user-1", []string{"dangling-1"}).
+		Return(nil, nil)
+
+	report, err := suite.service.ReconcileOwnership(ctx, true)
+
+	suite.Require().NoError(err)
+	suite.False(report.Repaired)
+	// NOTE: One failed repair does not stop the rest
+	suite.client.AssertExpectations(suite.T())
+}
+
+func (suite *TestSuite) TestReconcileOwnership_Pages() {
+	ctx := createAdminContext()
+
+	users := make([]*userpb.UserResponse, 100)
+	for i := range users {
+		users[i] = createTestUser(
+			fmt.Sprintf("user-%03d", i+1), "Test User", "test@example.com", false, nil,
+		)
+	}
+	suite.client.On("GetUsersAfter", mock.Anything, "", int32(100)).
+		Return(suite.usersStream(users...), nil)
+	// NOTE: The next page starts after the last user read, not at an offset
+	suite.client.On("GetUsersAfter", mock.Anything, "user-100", int32(100)).
+		Return(suite.usersStream(
+			createTestUser("user-101", "Last User", "last@example.com", false,
+				[]string{"owned-1"}),
+		), nil)
+	intersections := make([]*intersectionpb.IntersectionResponse, 100)
+	for i := range intersections {
+		intersections[i] = createdAgo(fmt.Sprintf("intersection-%03d", i+1), time.Hour)
+		intersections[i].DeletedAt = timestamppb.Now()
+	}
+	suite.intrClient.On("ListIntersectionsAfter", mock.Anything, "", 100).
+		Return(suite.intersectionsStream(intersections...), nil)
+	suite.intrClient.On("ListIntersectionsAfter", mock.Anything, "intersection-100", 100).
+		Return(suite.intersectionsStream(createdAgo("owned-1", time.Hour)), nil)
+
+	report, err := suite.service.ReconcileOwnership(ctx, false)
+
+	suite.Require().NoError(err)
+	suite.Empty(report.OrphanedIntersections)
+	suite.Empty(report.DanglingIntersectionIDs)
+	suite.client.AssertExpectations(suite.T())
+	suite.intrClient.AssertExpectations(suite.T())
+}
+
+func (suite *TestSuite) TestReconcileOwnership_UserServiceError() {
+	ctx := createAdminContext()
+
+	suite.client.On("GetUsersAfter", mock.Anything, "", int32(100)).
+		Return(nil, errs.NewUnavailableError("user service unavailable", map[string]any{}))
+
+	_, err := suite.service.ReconcileOwnership(ctx, true)
+
+	suite.Require().Error(err)
+	svcError, ok := err.(*errs.ServiceError)
+	suite.True(ok)
+	suite.Equal(errs.ErrUnavailable, svcError.Code)
+	suite.intrClient.AssertNotCalled(suite.T(), "ListIntersectionsAfter",
+		mock.Anything, mock.Anything, mock.Anything)
+}
+
+func (suite *TestSuite) TestReconcileOwnership_Forbidden() {
+	ctx := createUserContext()
+
+	_, err := suite.service.ReconcileOwnership(ctx, false)
+
+	suite.Require().Error(err)
+	svcError, ok := err.(*errs.ServiceError)
+	suite.True(ok)
+	suite.Equal(errs.ErrForbidden, svcError.Code)
+	suite.client.AssertNotCalled(suite.T(), "GetUsersAfter",
+		mock.Anything, mock.Anything, mock.Anything)
+}
@@ -32,6 +32,11 @@ func (suite *TestSuite) TestUpdateUserByID_Success() {
 	suite.False(result.IsAdmin)
 	suite.Equal([]string{"intersection-1", "intersection-2"}, result.IntersectionIDs)
 
+	notifications, err := suite.notifications.GetNotifications(ctx, userID, 10)
+	suite.Require().NoError(err)
+	suite.Require().Len(notifications, 1)
+	suite.Equal(model.NotificationTypeAccountUpdated, notifications[0].Type)
+
 	suite.client.AssertExpectations(suite.T())
 }
 
@@ -0,0 +1,41 @@
+package auth
+
+import (
+	"context"
+
+	"github.com/COS301-SE-2025/Swift-Signals/api-gateway/internal/model"
+	errs "github.com/COS301-SE-2025/Swift-Signals/shared/error"
+	"github.com/stretchr/testify/mock"
+	"google.golang.org/protobuf/types/known/emptypb"
+)
+
+func (suite *TestSuite) TestLogoutUser_Success() {
+	suite.client.Mock.On("LogoutUser", mock.Anything, "jwt.token.here", "refresh-token").
+		Return(&emptypb.Empty{}, nil)
+
+	ctx := context.Background()
+	result, err := suite.service.LogoutUser(
+		ctx,
+		"jwt.token.here",
+		model.LogoutRequest{RefreshToken: "refresh-token"},
+	)
+
+	suite.Require().NoError(err)
+	suite.Equal(model.LogoutResponse{Message: "Logout Successful"}, result)
+
+	suite.client.AssertExpectations(suite.T())
+}
+
+func (suite *TestSuite) TestLogoutUser_InvalidToken() {
+	suite.client.Mock.On("LogoutUser", mock.Anything, "invalid-token", "").
+		Return(nil, errs.NewUnauthorizedError("invalid token", nil))
+
+	ctx := context.Background()
+	_, err := suite.service.LogoutUser(ctx, "invalid-token", model.LogoutRequest{})
+
+	svcError, ok := err.(*errs.ServiceError)
+	suite.True(ok)
+	suite.Equal(errs.ErrUnauthorized, svcError.Code)
+
+	suite.client.AssertExpectations(suite.T())
+}
@@ -0,0 +1,59 @@
+package auth
+
+import (
+	"context"
+	"time"
+
+	"github.com/COS301-SE-2025/Swift-Signals/api-gateway/internal/model"
+	userpb "github.com/COS301-SE-2025/Swift-Signals/protos/gen/swiftsignals/user/v1"
+	errs "github.com/COS301-SE-2025/Swift-Signals/shared/error"
+	"github.com/stretchr/testify/mock"
+	"google.golang.org/protobuf/types/known/timestamppb"
+)
+
+func (suite *TestSuite) TestRefreshToken_Success() {
+	expiresAt := time.Date(2025, 6, 24, 15, 19, 5, 0, time.UTC)
+	refreshExpiresAt := time.Date(2025, 7, 1, 15, 4, 5, 0, time.UTC)
+
+	suite.client.Mock.On("RefreshToken", mock.Anything, "refresh-token").
+		Return(&userpb.LoginUserResponse{
+			Token:            "new.jwt.token",
+			ExpiresAt:        timestamppb.New(expiresAt),
+			RefreshToken:     "new-refresh-token",
+			RefreshExpiresAt: timestamppb.New(refreshExpiresAt),
+		}, nil)
+
+	ctx := context.Background()
+	result, err := suite.service.RefreshToken(
+		ctx,
+		model.RefreshRequest{RefreshToken: "refresh-token"},
+	)
+
+	suite.Require().NoError(err)
+	suite.Equal(model.LoginResponse{
+		Message:          "Refresh Successful",
+		Token:            "new.jwt.token",
+		ExpiresAt:        expiresAt,
+		RefreshToken:     "new-refresh-token",
+		RefreshExpiresAt: refreshExpiresAt,
+	}, result)
+
+	suite.client.AssertExpectations(suite.T())
+}
+
+func (suite *TestSuite) TestRefreshToken_Reused() {
+	suite.client.Mock.On("RefreshToken", mock.Anything, "refresh-token").
+		Return(nil, errs.NewUnauthorizedError("refresh token has already been used", nil))
+
+	ctx := context.Background()
+	_, err := suite.service.RefreshToken(
+		ctx,
+		model.RefreshRequest{RefreshToken: "refresh-token"},
+	)
+
+	svcError, ok := err.(*errs.ServiceError)
+	suite.True(ok)
+	suite.Equal(errs.ErrUnauthorized, svcError.Code)
+
+	suite.client.AssertExpectations(suite.T())
+}
@@ -0,0 +1,79 @@
+package auth
+
+import (
+	"context"
+
+	"github.com/COS301-SE-2025/Swift-Signals/api-gateway/internal/model"
+	errs "github.com/COS301-SE-2025/Swift-Signals/shared/error"
+	"github.com/stretchr/testify/mock"
+	"google.golang.org/protobuf/types/known/emptypb"
+)
+
+func (suite *TestSuite) TestResetPassword_Success() {
+	suite.client.Mock.On("ResetPassword", mock.Anything, "user@example.com").
+		Return(&emptypb.Empty{}, nil)
+
+	ctx := context.Background()
+	result, err := suite.service.ResetPassword(
+		ctx,
+		model.ResetPasswordRequest{Email: "user@example.com"},
+	)
+
+	suite.Require().NoError(err)
+	suite.Equal(
+		"If an account uses that email, a password reset link has been sent to it",
+		result.Message,
+	)
+
+	suite.client.AssertExpectations(suite.T())
+}
+
+func (suite *TestSuite) TestResetPassword_RateLimited() {
+	suite.client.Mock.On("ResetPassword", mock.Anything, "user@example.com").
+		Return(nil, errs.NewRateLimitedError("too many password reset requests, try again later", nil))
+
+	ctx := context.Background()
+	_, err := suite.service.ResetPassword(
+		ctx,
+		model.ResetPasswordRequest{Email: "user@example.com"},
+	)
+
+	svcError, ok := err.(*errs.ServiceError)
+	suite.True(ok)
+	suite.Equal(errs.ErrRateLimited, svcError.Code)
+
+	suite.client.AssertExpectations(suite.T())
+}
+
+func (suite *TestSuite) TestConfirmPasswordReset_Success() {
+	suite.client.Mock.On("ConfirmPasswordReset", mock.Anything, "reset-token", "newpass1234").
+		Return(&emptypb.Empty{}, nil)
+
+	ctx := context.Background()
+	result, err := suite.service.ConfirmPasswordReset(
+		ctx,
+		model.ConfirmResetPasswordRequest{Token: "reset-token", NewPassword: "newpass1234"},
+	)
+
+	suite.Require().NoError(err)
+	suite.Equal(model.ConfirmResetPasswordResponse{Message: "Password Reset Successful"}, result)
+
+	suite.client.AssertExpectations(suite.T())
+}
+
+func (suite *TestSuite) TestConfirmPasswordReset_InvalidToken() {
+	suite.client.Mock.On("ConfirmPasswordReset", mock.Anything, "used-token", "newpass1234").
+		Return(nil, errs.NewUnauthorizedError("invalid or expired reset token", nil))
+
+	ctx := context.Background()
+	_, err := suite.service.ConfirmPasswordReset(
+		ctx,
+		model.ConfirmResetPasswordRequest{Token: "used-token", NewPassword: "newpass1234"},
+	)
+
+	svcError, ok := err.(*errs.ServiceError)
+	suite.True(ok)
+	suite.Equal(errs.ErrUnauthorized, svcError.Code)
+
+	suite.client.AssertExpectations(suite.T())
+}
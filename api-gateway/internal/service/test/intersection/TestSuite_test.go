@@ -4,6 +4,7 @@ import (
 	"testing"
 	"time"
 
+	cachemocks "github.com/COS301-SE-2025/Swift-Signals/api-gateway/internal/mocks/cache"
 	mocks "github.com/COS301-SE-2025/Swift-Signals/api-gateway/internal/mocks/client"
 	grpcmocks "github.com/COS301-SE-2025/Swift-Signals/api-gateway/internal/mocks/grpc_client"
 	"github.com/COS301-SE-2025/Swift-Signals/api-gateway/internal/service"
@@ -18,18 +19,18 @@ type TestSuite struct {
 	suite.Suite
 	intrClient *mocks.MockIntersectionClientInterface
 	userClient *mocks.MockUserClientInterface
-	optiClient *mocks.MockOptimisationClientInterface
+	simCache   *cachemocks.MockSimulationCacheInterface
 	service    service.IntersectionServiceInterface
 }
 
 func (suite *TestSuite) SetupTest() {
 	suite.intrClient = new(mocks.MockIntersectionClientInterface)
 	suite.userClient = new(mocks.MockUserClientInterface)
-	suite.optiClient = new(mocks.MockOptimisationClientInterface)
+	suite.simCache = new(cachemocks.MockSimulationCacheInterface)
 	suite.service = service.NewIntersectionService(
 		suite.intrClient,
-		suite.optiClient,
 		suite.userClient,
+		suite.simCache,
 	)
 }
 
@@ -62,7 +62,7 @@ func (suite *TestSuite) TestCreateIntersection_Success() {
 		},
 	}
 
-	suite.intrClient.On("CreateIntersection", ctx, expectedIntersection).
+	suite.intrClient.On("CreateIntersection", ctx, expectedIntersection, userID).
 		Return(expectedCreateResponse, nil)
 
 	suite.userClient.On("AddIntersectionID", ctx, userID, "new-intersection-id").
@@ -125,7 +125,7 @@ func (suite *TestSuite) TestCreateIntersection_InvalidTrafficDensity() {
 		},
 	}
 
-	suite.intrClient.On("CreateIntersection", ctx, expectedIntersection).
+	suite.intrClient.On("CreateIntersection", ctx, expectedIntersection, userID).
 		Return(nil, errs.NewValidationError("invalid traffic density", map[string]any{}))
 
 	_, err := suite.service.CreateIntersection(ctx, userID, request)
@@ -188,7 +188,7 @@ func (suite *TestSuite) TestCreateIntersection_InvalidIntersectionType() {
 		},
 	}
 
-	suite.intrClient.On("CreateIntersection", ctx, expectedIntersection).
+	suite.intrClient.On("CreateIntersection", ctx, expectedIntersection, userID).
 		Return(nil, errs.NewValidationError("invalid intersection type", map[string]any{}))
 
 	_, err := suite.service.CreateIntersection(ctx, userID, request)
@@ -251,7 +251,7 @@ func (suite *TestSuite) TestCreateIntersection_IntersectionServiceError() {
 		},
 	}
 
-	suite.intrClient.On("CreateIntersection", ctx, expectedIntersection).
+	suite.intrClient.On("CreateIntersection", ctx, expectedIntersection, userID).
 		Return(nil, errs.NewInternalError("database connection failed", nil, map[string]any{}))
 
 	_, err := suite.service.CreateIntersection(ctx, userID, request)
@@ -318,11 +318,86 @@ func (suite *TestSuite) TestCreateIntersection_UserAssignmentError() {
 		},
 	}
 
-	suite.intrClient.On("CreateIntersection", ctx, expectedIntersection).
+	suite.intrClient.On("CreateIntersection", ctx, expectedIntersection, userID).
 		Return(expectedCreateResponse, nil)
 
 	suite.userClient.On("AddIntersectionID", ctx, userID, "new-intersection-id").
 		Return(nil, errs.NewNotFoundError("user not found", map[string]any{}))
+	// NOTE: The intersection no user owns is deleted again
+	suite.intrClient.On("DeleteIntersection", ctx, "new-intersection-id", 0).Return(nil, nil)
+
+	_, err := suite.service.CreateIntersection(ctx, userID, request)
+
+	suite.Require().Error(err)
+
+	svcError, ok := err.(*errs.ServiceError)
+	suite.True(ok)
+	suite.Equal(errs.ErrNotFound, svcError.Code)
+	suite.Equal("user not found", svcError.Message)
+
+	suite.intrClient.AssertExpectations(suite.T())
+	suite.userClient.AssertExpectations(suite.T())
+}
+
+func (suite *TestSuite) TestCreateIntersection_CompensationError() {
+	userID := "valid-user-id"
+
+	request := model.CreateIntersectionRequest{
+		Name: "New Intersection",
+		Details: struct {
+			Address  string `json:"address"  example:"Corner of Foo and Bar"`
+			City     string `json:"city"     example:"Pretoria"`
+			Province string `json:"province" example:"Gauteng"`
+		}{
+			Address:  "123 New Street",
+			City:     "Cape Town",
+			Province: "Western Cape",
+		},
+		TrafficDensity: "high",
+		DefaultParameters: model.SimulationParameters{
+			IntersectionType: "tjunction",
+			Green:            10,
+			Yellow:           3,
+			Red:              7,
+			Speed:            60,
+			Seed:             12345,
+		},
+	}
+
+	expectedCreateResponse := &intersectionpb.IntersectionResponse{
+		Id: "new-intersection-id",
+	}
+
+	logger := slog.Default()
+	ctx := middleware.SetLogger(context.Background(), logger)
+
+	expectedIntersection := model.Intersection{
+		Name: "New Intersection",
+		Details: model.Details{
+			Address:  "123 New Street",
+			City:     "Cape Town",
+			Province: "Western Cape",
+		},
+		TrafficDensity: "high",
+		DefaultParameters: model.OptimisationParameters{
+			SimulationParameters: model.SimulationParameters{
+				IntersectionType: "tjunction",
+				Green:            10,
+				Yellow:           3,
+				Red:              7,
+				Speed:            60,
+				Seed:             12345,
+			},
+		},
+	}
+
+	suite.intrClient.On("CreateIntersection", ctx, expectedIntersection, userID).
+		Return(expectedCreateResponse, nil)
+
+	suite.userClient.On("AddIntersectionID", ctx, userID, "new-intersection-id").
+		Return(nil, errs.NewNotFoundError("user not found", map[string]any{}))
+	suite.intrClient.On("DeleteIntersection", ctx, "new-intersection-id", 0).
+		Return(nil, errs.NewInternalError("database connection failed", nil, map[string]any{}))
 
 	_, err := suite.service.CreateIntersection(ctx, userID, request)
 
@@ -385,7 +460,7 @@ func (suite *TestSuite) TestCreateIntersection_EmptyName() {
 		},
 	}
 
-	suite.intrClient.On("CreateIntersection", ctx, expectedIntersection).
+	suite.intrClient.On("CreateIntersection", ctx, expectedIntersection, userID).
 		Return(nil, errs.NewValidationError("intersection name is required", map[string]any{}))
 
 	_, err := suite.service.CreateIntersection(ctx, userID, request)
@@ -448,7 +523,7 @@ func (suite *TestSuite) TestCreateIntersection_DuplicateName() {
 		},
 	}
 
-	suite.intrClient.On("CreateIntersection", ctx, expectedIntersection).
+	suite.intrClient.On("CreateIntersection", ctx, expectedIntersection, userID).
 		Return(nil, errs.NewAlreadyExistsError("intersection with this name already exists", map[string]any{}))
 
 	_, err := suite.service.CreateIntersection(ctx, userID, request)
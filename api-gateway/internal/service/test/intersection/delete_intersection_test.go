@@ -29,15 +29,16 @@ func (suite *TestSuite) TestDeleteIntersectionByID_Success() {
 	mockUserStream.On("Recv").Return(nil, io.EOF).Once()
 
 	suite.userClient.On("GetUserIntersectionIDs", ctx, userID).Return(mockUserStream, nil)
-	suite.userClient.On("RemoveIntersectionID", ctx, userID, intersectionID).Return(nil, nil)
-	suite.intrClient.On("DeleteIntersection", ctx, intersectionID).Return(nil, nil)
+	suite.intrClient.On("DeleteIntersection", ctx, intersectionID, 0).Return(nil, nil)
+	suite.simCache.On("InvalidateIntersection", ctx, intersectionID).Return()
 
-	err := suite.service.DeleteIntersectionByID(ctx, userID, intersectionID)
+	err := suite.service.DeleteIntersectionByID(ctx, userID, intersectionID, 0)
 
 	suite.Require().NoError(err)
 
 	suite.userClient.AssertExpectations(suite.T())
 	suite.intrClient.AssertExpectations(suite.T())
+	suite.simCache.AssertExpectations(suite.T())
 	mockUserStream.AssertExpectations(suite.T())
 }
 
@@ -61,7 +62,7 @@ func (suite *TestSuite) TestDeleteIntersectionByID_Forbidden() {
 
 	suite.userClient.On("GetUserIntersectionIDs", ctx, userID).Return(mockUserStream, nil)
 
-	err := suite.service.DeleteIntersectionByID(ctx, userID, intersectionID)
+	err := suite.service.DeleteIntersectionByID(ctx, userID, intersectionID, 0)
 
 	suite.Require().Error(err)
 
@@ -84,7 +85,7 @@ func (suite *TestSuite) TestDeleteIntersectionByID_UserServiceError() {
 	suite.userClient.On("GetUserIntersectionIDs", ctx, userID).
 		Return(nil, errs.NewNotFoundError("user not found", map[string]any{}))
 
-	err := suite.service.DeleteIntersectionByID(ctx, userID, intersectionID)
+	err := suite.service.DeleteIntersectionByID(ctx, userID, intersectionID, 0)
 
 	suite.Require().Error(err)
 
@@ -115,11 +116,10 @@ func (suite *TestSuite) TestDeleteIntersectionByID_IntersectionServiceError() {
 	mockUserStream.On("Recv").Return(nil, io.EOF).Once()
 
 	suite.userClient.On("GetUserIntersectionIDs", ctx, userID).Return(mockUserStream, nil)
-	suite.userClient.On("RemoveIntersectionID", ctx, userID, intersectionID).Return(nil, nil)
-	suite.intrClient.On("DeleteIntersection", ctx, intersectionID).
+	suite.intrClient.On("DeleteIntersection", ctx, intersectionID, 0).
 		Return(nil, errs.NewInternalError("database connection failed", nil, map[string]any{}))
 
-	err := suite.service.DeleteIntersectionByID(ctx, userID, intersectionID)
+	err := suite.service.DeleteIntersectionByID(ctx, userID, intersectionID, 0)
 
 	suite.Require().Error(err)
 
@@ -152,11 +152,10 @@ func (suite *TestSuite) TestDeleteIntersectionByID_IntersectionNotFound() {
 	mockUserStream.On("Recv").Return(nil, io.EOF).Once()
 
 	suite.userClient.On("GetUserIntersectionIDs", ctx, userID).Return(mockUserStream, nil)
-	suite.userClient.On("RemoveIntersectionID", ctx, userID, intersectionID).Return(nil, nil)
-	suite.intrClient.On("DeleteIntersection", ctx, intersectionID).
+	suite.intrClient.On("DeleteIntersection", ctx, intersectionID, 0).
 		Return(nil, errs.NewNotFoundError("intersection not found", map[string]any{}))
 
-	err := suite.service.DeleteIntersectionByID(ctx, userID, intersectionID)
+	err := suite.service.DeleteIntersectionByID(ctx, userID, intersectionID, 0)
 
 	suite.Require().Error(err)
 
@@ -170,40 +169,24 @@ func (suite *TestSuite) TestDeleteIntersectionByID_IntersectionNotFound() {
 	mockUserStream.AssertExpectations(suite.T())
 }
 
-func (suite *TestSuite) TestDeleteIntersectionByID_UnassignError() {
+func (suite *TestSuite) TestDeleteIntersectionByID_KeepsIntersectionAssigned() {
 	userID := "valid-user-id"
 	intersectionID := "intersection-123"
 
-	expectedIntersectionIDs := []string{"intersection-123", "intersection-456"}
-
 	logger := slog.Default()
 	ctx := middleware.SetLogger(context.Background(), logger)
 
-	// Mock user intersection IDs stream
-	mockUserStream := suite.NewMockUserIntersectionIDsStream()
-	for _, id := range expectedIntersectionIDs {
-		mockUserStream.On("Recv").
-			Return(&userpb.IntersectionIDResponse{IntersectionId: id}, nil).
-			Once()
-	}
-	mockUserStream.On("Recv").Return(nil, io.EOF).Once()
-
-	suite.userClient.On("GetUserIntersectionIDs", ctx, userID).Return(mockUserStream, nil)
-	suite.userClient.On("RemoveIntersectionID", ctx, userID, intersectionID).
-		Return(nil, errs.NewInternalError("failed to unassign intersection", nil, map[string]any{}))
+	suite.expectUserIntersections(ctx, userID, intersectionID)
+	suite.intrClient.On("DeleteIntersection", ctx, intersectionID, 0).Return(nil, nil)
+	suite.simCache.On("InvalidateIntersection", ctx, intersectionID).Return()
 
-	err := suite.service.DeleteIntersectionByID(ctx, userID, intersectionID)
+	err := suite.service.DeleteIntersectionByID(ctx, userID, intersectionID, 0)
 
-	suite.Require().Error(err)
-
-	svcError, ok := err.(*errs.ServiceError)
-	suite.True(ok)
-	suite.Equal(errs.ErrInternal, svcError.Code)
-	suite.Equal("failed to unassign intersection", svcError.Message)
+	suite.Require().NoError(err)
 
-	suite.userClient.AssertExpectations(suite.T())
+	// NOTE: Trashed intersections stay in the user's list so that they can be restored
+	suite.userClient.AssertNotCalled(suite.T(), "RemoveIntersectionID", ctx, userID, intersectionID)
 	suite.intrClient.AssertExpectations(suite.T())
-	mockUserStream.AssertExpectations(suite.T())
 }
 
 func (suite *TestSuite) TestDeleteIntersectionByID_EmptyUserID() {
@@ -216,7 +199,7 @@ func (suite *TestSuite) TestDeleteIntersectionByID_EmptyUserID() {
 	suite.userClient.On("GetUserIntersectionIDs", ctx, userID).
 		Return(nil, errs.NewValidationError("user ID cannot be empty", map[string]any{}))
 
-	err := suite.service.DeleteIntersectionByID(ctx, userID, intersectionID)
+	err := suite.service.DeleteIntersectionByID(ctx, userID, intersectionID, 0)
 
 	suite.Require().Error(err)
 
@@ -248,7 +231,7 @@ func (suite *TestSuite) TestDeleteIntersectionByID_EmptyIntersectionID() {
 
 	suite.userClient.On("GetUserIntersectionIDs", ctx, userID).Return(mockUserStream, nil)
 
-	err := suite.service.DeleteIntersectionByID(ctx, userID, intersectionID)
+	err := suite.service.DeleteIntersectionByID(ctx, userID, intersectionID, 0)
 
 	suite.Require().Error(err)
 
@@ -280,11 +263,10 @@ func (suite *TestSuite) TestDeleteIntersectionByID_ConflictError() {
 	mockUserStream.On("Recv").Return(nil, io.EOF).Once()
 
 	suite.userClient.On("GetUserIntersectionIDs", ctx, userID).Return(mockUserStream, nil)
-	suite.userClient.On("RemoveIntersectionID", ctx, userID, intersectionID).Return(nil, nil)
-	suite.intrClient.On("DeleteIntersection", ctx, intersectionID).
+	suite.intrClient.On("DeleteIntersection", ctx, intersectionID, 0).
 		Return(nil, errs.NewForbiddenError("intersection is currently being optimised and cannot be deleted", map[string]any{}))
 
-	err := suite.service.DeleteIntersectionByID(ctx, userID, intersectionID)
+	err := suite.service.DeleteIntersectionByID(ctx, userID, intersectionID, 0)
 
 	suite.Require().Error(err)
 
@@ -312,7 +294,7 @@ func (suite *TestSuite) TestDeleteIntersectionByID_StreamError() {
 
 	suite.userClient.On("GetUserIntersectionIDs", ctx, userID).Return(mockUserStream, nil)
 
-	err := suite.service.DeleteIntersectionByID(ctx, userID, intersectionID)
+	err := suite.service.DeleteIntersectionByID(ctx, userID, intersectionID, 0)
 
 	suite.Require().Error(err)
 
@@ -11,7 +11,6 @@ import (
 	intersectionpb "github.com/COS301-SE-2025/Swift-Signals/protos/gen/swiftsignals/intersection/v1"
 	userpb "github.com/COS301-SE-2025/Swift-Signals/protos/gen/swiftsignals/user/v1"
 	errs "github.com/COS301-SE-2025/Swift-Signals/shared/error"
-	"github.com/stretchr/testify/mock"
 )
 
 // TestIntegrationSuite tests the intersection service with integrated workflows
@@ -71,7 +70,7 @@ func (suite *TestIntegrationSuite) TestCompleteIntersectionLifecycle() {
 		},
 	}
 
-	suite.intrClient.On("CreateIntersection", ctx, expectedIntersection).
+	suite.intrClient.On("CreateIntersection", ctx, expectedIntersection, userID).
 		Return(&intersectionpb.IntersectionResponse{Id: createdIntersectionID}, nil)
 
 	suite.userClient.On("AddIntersectionID", ctx, userID, createdIntersectionID).
@@ -154,58 +153,22 @@ func (suite *TestIntegrationSuite) TestCompleteIntersectionLifecycle() {
 		Address:  "456 Updated Street",
 		City:     "Johannesburg",
 		Province: "Gauteng",
-	}).
+	}, "", (*model.OptimisationParameters)(nil), userID, 0).
 		Return(updatedIntersection, nil)
+	suite.simCache.On("InvalidateIntersection", ctx, createdIntersectionID).Return()
 
 	updateResult, err := suite.service.UpdateIntersectionByID(
 		ctx,
 		userID,
 		createdIntersectionID,
 		updateRequest,
+		0,
 	)
 	suite.Require().NoError(err)
 	suite.Equal("Updated Integration Intersection", updateResult.Name)
 	suite.Equal("456 Updated Street", updateResult.Details.Address)
 
-	// Step 4: Optimise intersection
-	mockUserStreamOptimise := suite.NewMockUserIntersectionIDsStream()
-	for _, id := range expectedIntersectionIDs {
-		mockUserStreamOptimise.On("Recv").
-			Return(&userpb.IntersectionIDResponse{IntersectionId: id}, nil).
-			Once()
-	}
-	mockUserStreamOptimise.On("Recv").Return(nil, io.EOF).Once()
-
-	suite.userClient.On("GetUserIntersectionIDs", ctx, userID).
-		Return(mockUserStreamOptimise, nil).
-		Once()
-
-	// Mock the intersection retrieval for optimisation
-	suite.intrClient.On("GetIntersection", ctx, createdIntersectionID).
-		Return(getIntersectionResponse, nil)
-
-	// Mock the optimisation service call
-	suite.optiClient.On("RunOptimisation", ctx, mock.AnythingOfType("model.OptimisationParameters")).
-		Return(&commonpb.OptimisationParameters{
-			OptimisationType: commonpb.OptimisationType_OPTIMISATION_TYPE_GRIDSEARCH,
-			Parameters: &commonpb.SimulationParameters{
-				IntersectionType: commonpb.IntersectionType_INTERSECTION_TYPE_TJUNCTION,
-				Green:            12,
-				Yellow:           3,
-				Red:              5,
-				Speed:            60,
-				Seed:             12345,
-			},
-		}, nil)
-
-	// Mock the put optimisation call
-	suite.intrClient.On("PutOptimisation", ctx, createdIntersectionID, mock.AnythingOfType("model.OptimisationParameters")).
-		Return(&intersectionpb.PutOptimisationResponse{Improved: true}, nil)
-
-	err = suite.service.OptimiseIntersectionByID(ctx, userID, createdIntersectionID)
-	suite.Require().NoError(err)
-
-	// Step 5: Delete intersection
+	// Step 4: Delete intersection
 	mockUserStreamDelete := suite.NewMockUserIntersectionIDsStream()
 	for _, id := range expectedIntersectionIDs {
 		mockUserStreamDelete.On("Recv").
@@ -217,19 +180,17 @@ func (suite *TestIntegrationSuite) TestCompleteIntersectionLifecycle() {
 	suite.userClient.On("GetUserIntersectionIDs", ctx, userID).
 		Return(mockUserStreamDelete, nil).
 		Once()
-	suite.userClient.On("RemoveIntersectionID", ctx, userID, createdIntersectionID).Return(nil, nil)
-	suite.intrClient.On("DeleteIntersection", ctx, createdIntersectionID).Return(nil, nil)
+	suite.intrClient.On("DeleteIntersection", ctx, createdIntersectionID, 0).Return(nil, nil)
 
-	err = suite.service.DeleteIntersectionByID(ctx, userID, createdIntersectionID)
+	err = suite.service.DeleteIntersectionByID(ctx, userID, createdIntersectionID, 0)
 	suite.Require().NoError(err)
 
 	// Assert all expectations
 	suite.intrClient.AssertExpectations(suite.T())
+	suite.simCache.AssertExpectations(suite.T())
 	suite.userClient.AssertExpectations(suite.T())
-	suite.optiClient.AssertExpectations(suite.T())
 	mockUserStreamGet.AssertExpectations(suite.T())
 	mockUserStreamUpdate.AssertExpectations(suite.T())
-	mockUserStreamOptimise.AssertExpectations(suite.T())
 	mockUserStreamDelete.AssertExpectations(suite.T())
 }
 
@@ -314,7 +275,7 @@ func (suite *TestIntegrationSuite) TestCascadingErrorScenarios() {
 	// Test 1: User service down affects all operations
 	suite.userClient.On("GetUserIntersectionIDs", ctx, userID).
 		Return(nil, errs.NewUnavailableError("user service is down", map[string]any{})).
-		Times(4) // Will be called for get, update, delete, and optimise
+		Times(3) // Will be called for get, update, and delete
 
 	// Test GetIntersectionByID with user service down
 	_, err := suite.service.GetIntersectionByID(ctx, userID, intersectionID)
@@ -325,21 +286,14 @@ func (suite *TestIntegrationSuite) TestCascadingErrorScenarios() {
 
 	// Test UpdateIntersectionByID with user service down
 	updateRequest := model.UpdateIntersectionRequest{Name: "Test"}
-	_, err = suite.service.UpdateIntersectionByID(ctx, userID, intersectionID, updateRequest)
+	_, err = suite.service.UpdateIntersectionByID(ctx, userID, intersectionID, updateRequest, 0)
 	suite.Require().Error(err)
 	svcError, ok = err.(*errs.ServiceError)
 	suite.True(ok)
 	suite.Equal(errs.ErrUnavailable, svcError.Code)
 
 	// Test DeleteIntersectionByID with user service down
-	err = suite.service.DeleteIntersectionByID(ctx, userID, intersectionID)
-	suite.Require().Error(err)
-	svcError, ok = err.(*errs.ServiceError)
-	suite.True(ok)
-	suite.Equal(errs.ErrUnavailable, svcError.Code)
-
-	// Test OptimiseIntersectionByID with user service down
-	err = suite.service.OptimiseIntersectionByID(ctx, userID, intersectionID)
+	err = suite.service.DeleteIntersectionByID(ctx, userID, intersectionID, 0)
 	suite.Require().Error(err)
 	svcError, ok = err.(*errs.ServiceError)
 	suite.True(ok)
@@ -358,8 +312,8 @@ func (suite *TestIntegrationSuite) TestPermissionConsistencyAcrossOperations() {
 	logger := slog.Default()
 	ctx := middleware.SetLogger(context.Background(), logger)
 
-	// Create 4 separate mock streams for each operation
-	for i := 0; i < 4; i++ {
+	// Create 3 separate mock streams for each operation
+	for i := 0; i < 3; i++ {
 		mockUserStream := suite.NewMockUserIntersectionIDsStream()
 		for _, id := range userIntersectionIDs {
 			mockUserStream.On("Recv").
@@ -381,21 +335,14 @@ func (suite *TestIntegrationSuite) TestPermissionConsistencyAcrossOperations() {
 
 	// Test UpdateIntersectionByID - should be forbidden
 	updateRequest := model.UpdateIntersectionRequest{Name: "Test"}
-	_, err = suite.service.UpdateIntersectionByID(ctx, userID, intersectionID, updateRequest)
+	_, err = suite.service.UpdateIntersectionByID(ctx, userID, intersectionID, updateRequest, 0)
 	suite.Require().Error(err)
 	svcError, ok = err.(*errs.ServiceError)
 	suite.True(ok)
 	suite.Equal(errs.ErrForbidden, svcError.Code)
 
 	// Test DeleteIntersectionByID - should be forbidden
-	err = suite.service.DeleteIntersectionByID(ctx, userID, intersectionID)
-	suite.Require().Error(err)
-	svcError, ok = err.(*errs.ServiceError)
-	suite.True(ok)
-	suite.Equal(errs.ErrForbidden, svcError.Code)
-
-	// Test OptimiseIntersectionByID - should be forbidden
-	err = suite.service.OptimiseIntersectionByID(ctx, userID, intersectionID)
+	err = suite.service.DeleteIntersectionByID(ctx, userID, intersectionID, 0)
 	suite.Require().Error(err)
 	svcError, ok = err.(*errs.ServiceError)
 	suite.True(ok)
@@ -498,7 +445,7 @@ func (suite *TestIntegrationSuite) TestCreateIntersectionWithDifferentTrafficDen
 			},
 		}
 
-		suite.intrClient.On("CreateIntersection", ctx, expectedIntersection).
+		suite.intrClient.On("CreateIntersection", ctx, expectedIntersection, userID).
 			Return(&intersectionpb.IntersectionResponse{Id: intersectionID}, nil).Once()
 
 		suite.userClient.On("AddIntersectionID", ctx, userID, intersectionID).
@@ -0,0 +1,220 @@
+package intersection
+
+import (
+	"context"
+	"io"
+	"log/slog"
+
+	"github.com/COS301-SE-2025/Swift-Signals/api-gateway/internal/middleware"
+	grpcmocks "github.com/COS301-SE-2025/Swift-Signals/api-gateway/internal/mocks/grpc_client"
+	"github.com/COS301-SE-2025/Swift-Signals/api-gateway/internal/model"
+	commonpb "github.com/COS301-SE-2025/Swift-Signals/protos/gen/swiftsignals/common/v1"
+	intersectionpb "github.com/COS301-SE-2025/Swift-Signals/protos/gen/swiftsignals/intersection/v1"
+	userpb "github.com/COS301-SE-2025/Swift-Signals/protos/gen/swiftsignals/user/v1"
+	errs "github.com/COS301-SE-2025/Swift-Signals/shared/error"
+	"google.golang.org/protobuf/types/known/timestamppb"
+)
+
+func (suite *TestSuite) expectUserIntersections(
+	ctx context.Context,
+	userID string,
+	intersectionIDs ...string,
+) {
+	mockUserStream := suite.NewMockUserIntersectionIDsStream()
+	for _, id := range intersectionIDs {
+		mockUserStream.On("Recv").
+			Return(&userpb.IntersectionIDResponse{IntersectionId: id}, nil).
+			Once()
+	}
+	mockUserStream.On("Recv").Return(nil, io.EOF).Once()
+
+	suite.userClient.On("GetUserIntersectionIDs", ctx, userID).Return(mockUserStream, nil)
+}
+
+func createTestParameterVersion(
+	intersectionID string,
+	version int32,
+	reason intersectionpb.ParameterChangeReason,
+) *intersectionpb.ParameterVersionResponse {
+	defaults := createTestIntersection(
+		intersectionID, "", "", "", "",
+		commonpb.IntersectionStatus_INTERSECTION_STATUS_UNOPTIMISED,
+		0,
+		commonpb.TrafficDensity_TRAFFIC_DENSITY_HIGH,
+	).DefaultParameters
+	return &intersectionpb.ParameterVersionResponse{
+		IntersectionId: intersectionID,
+		Version:        version,
+		UserId:         "valid-user-id",
+		Reason:         reason,
+		Changes: []*intersectionpb.ParameterChange{
+			{
+				Field:         intersectionpb.ParameterField_PARAMETER_FIELD_DEFAULT,
+				OldParameters: defaults,
+				NewParameters: defaults,
+			},
+		},
+		CreatedAt: timestamppb.Now(),
+	}
+}
+
+func (suite *TestSuite) TestGetParameterVersions_Success() {
+	userID := "valid-user-id"
+	intersectionID := "intersection-123"
+
+	ctx := middleware.SetLogger(context.Background(), slog.Default())
+
+	stream := grpcmocks.NewMockIntersectionService_GetParameterVersionsClient[intersectionpb.ParameterVersionResponse](
+		suite.T(),
+	)
+	stream.On("Recv").
+		Return(createTestParameterVersion(
+			intersectionID, 2, intersectionpb.ParameterChangeReason_PARAMETER_CHANGE_REASON_UPDATED,
+		), nil).
+		Once()
+	stream.On("Recv").
+		Return(&intersectionpb.ParameterVersionResponse{
+			IntersectionId: intersectionID,
+			Version:        1,
+			Reason:         intersectionpb.ParameterChangeReason_PARAMETER_CHANGE_REASON_CREATED,
+			CreatedAt:      timestamppb.Now(),
+		}, nil).
+		Once()
+	stream.On("Recv").Return(nil, io.EOF).Once()
+
+	suite.expectUserIntersections(ctx, userID, intersectionID)
+	suite.intrClient.On("GetParameterVersions", ctx, intersectionID, 2, 10).Return(stream, nil)
+
+	result, err := suite.service.GetParameterVersions(ctx, userID, intersectionID, 2, 10)
+
+	suite.Require().NoError(err)
+	suite.Equal(2, result.Page)
+	suite.Equal(10, result.PageSize)
+	suite.Require().Len(result.Versions, 2)
+	suite.Equal(2, result.Versions[0].Version)
+	suite.Equal(model.ParametersUpdated, result.Versions[0].Reason)
+	suite.Require().Len(result.Versions[0].Changes, 1)
+	suite.Equal(model.ParameterFieldDefault, result.Versions[0].Changes[0].Field)
+	suite.Require().NotNil(result.Versions[0].Changes[0].OldParameters)
+	suite.Equal(10, result.Versions[0].Changes[0].NewParameters.SimulationParameters.Green)
+	suite.Equal(model.ParametersCreated, result.Versions[1].Reason)
+	suite.NotNil(result.Versions[1].Changes)
+
+	suite.userClient.AssertExpectations(suite.T())
+	suite.intrClient.AssertExpectations(suite.T())
+}
+
+func (suite *TestSuite) TestGetParameterVersions_Forbidden() {
+	userID := "valid-user-id"
+	intersectionID := "intersection-999"
+
+	ctx := middleware.SetLogger(context.Background(), slog.Default())
+
+	suite.expectUserIntersections(ctx, userID, "intersection-123")
+
+	_, err := suite.service.GetParameterVersions(ctx, userID, intersectionID, 1, 20)
+
+	suite.Require().Error(err)
+	svcError, ok := err.(*errs.ServiceError)
+	suite.True(ok)
+	suite.Equal(errs.ErrForbidden, svcError.Code)
+	suite.intrClient.AssertNotCalled(
+		suite.T(), "GetParameterVersions",
+		ctx, intersectionID, 1, 20,
+	)
+}
+
+func (suite *TestSuite) TestGetParameterVersions_StreamError() {
+	userID := "valid-user-id"
+	intersectionID := "intersection-123"
+
+	ctx := middleware.SetLogger(context.Background(), slog.Default())
+
+	stream := grpcmocks.NewMockIntersectionService_GetParameterVersionsClient[intersectionpb.ParameterVersionResponse](
+		suite.T(),
+	)
+	stream.On("Recv").Return(nil, io.ErrUnexpectedEOF).Once()
+
+	suite.expectUserIntersections(ctx, userID, intersectionID)
+	suite.intrClient.On("GetParameterVersions", ctx, intersectionID, 1, 20).Return(stream, nil)
+
+	_, err := suite.service.GetParameterVersions(ctx, userID, intersectionID, 1, 20)
+
+	suite.Require().Error(err)
+}
+
+func (suite *TestSuite) TestRevertParameterVersion_Success() {
+	userID := "valid-user-id"
+	intersectionID := "intersection-123"
+
+	ctx := middleware.SetLogger(context.Background(), slog.Default())
+
+	reverted := createTestIntersection(
+		intersectionID,
+		"Test Intersection",
+		"123 Main Street",
+		"Pretoria",
+		"Gauteng",
+		commonpb.IntersectionStatus_INTERSECTION_STATUS_OPTIMISED,
+		3,
+		commonpb.TrafficDensity_TRAFFIC_DENSITY_HIGH,
+	)
+	reverted.ParameterVersion = 4
+
+	suite.expectUserIntersections(ctx, userID, intersectionID)
+	suite.intrClient.On("RevertParameterVersion", ctx, intersectionID, 2, userID, 0).
+		Return(reverted, nil)
+	suite.simCache.On("InvalidateIntersection", ctx, intersectionID).Return()
+
+	result, err := suite.service.RevertParameterVersion(ctx, userID, intersectionID, 2, 0)
+
+	suite.Require().NoError(err)
+	suite.Equal(intersectionID, result.ID)
+	suite.Equal(4, result.ParameterVersion)
+
+	suite.userClient.AssertExpectations(suite.T())
+	suite.intrClient.AssertExpectations(suite.T())
+	suite.simCache.AssertExpectations(suite.T())
+}
+
+func (suite *TestSuite) TestRevertParameterVersion_Forbidden() {
+	userID := "valid-user-id"
+	intersectionID := "intersection-999"
+
+	ctx := middleware.SetLogger(context.Background(), slog.Default())
+
+	suite.expectUserIntersections(ctx, userID, "intersection-123")
+
+	_, err := suite.service.RevertParameterVersion(ctx, userID, intersectionID, 2, 0)
+
+	suite.Require().Error(err)
+	svcError, ok := err.(*errs.ServiceError)
+	suite.True(ok)
+	suite.Equal(errs.ErrForbidden, svcError.Code)
+	suite.intrClient.AssertNotCalled(
+		suite.T(), "RevertParameterVersion",
+		ctx, intersectionID, 2, userID, 0,
+	)
+}
+
+func (suite *TestSuite) TestRevertParameterVersion_Conflict() {
+	userID := "valid-user-id"
+	intersectionID := "intersection-123"
+
+	ctx := middleware.SetLogger(context.Background(), slog.Default())
+
+	suite.expectUserIntersections(ctx, userID, intersectionID)
+	suite.intrClient.On("RevertParameterVersion", ctx, intersectionID, 2, userID, 0).
+		Return(nil, errs.NewConflictError(
+			"parameters cannot be reverted while the intersection is being optimised",
+			map[string]any{},
+		))
+
+	_, err := suite.service.RevertParameterVersion(ctx, userID, intersectionID, 2, 0)
+
+	suite.Require().Error(err)
+	svcError, ok := err.(*errs.ServiceError)
+	suite.True(ok)
+	suite.Equal(errs.ErrConflict, svcError.Code)
+	suite.simCache.AssertNotCalled(suite.T(), "InvalidateIntersection", ctx, intersectionID)
+}
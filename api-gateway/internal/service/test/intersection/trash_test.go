@@ -0,0 +1,130 @@
+package intersection
+
+import (
+	"context"
+	"io"
+	"log/slog"
+
+	"github.com/COS301-SE-2025/Swift-Signals/api-gateway/internal/middleware"
+	commonpb "github.com/COS301-SE-2025/Swift-Signals/protos/gen/swiftsignals/common/v1"
+	errs "github.com/COS301-SE-2025/Swift-Signals/shared/error"
+	"google.golang.org/protobuf/types/known/timestamppb"
+)
+
+func (suite *TestSuite) TestGetDeletedIntersections_Success() {
+	userID := "valid-user-id"
+
+	ctx := middleware.SetLogger(context.Background(), slog.Default())
+
+	trashed := createTestIntersection(
+		"intersection-456",
+		"Trashed Intersection",
+		"123 Main Street",
+		"Pretoria",
+		"Gauteng",
+		commonpb.IntersectionStatus_INTERSECTION_STATUS_OPTIMISED,
+		3,
+		commonpb.TrafficDensity_TRAFFIC_DENSITY_HIGH,
+	)
+	trashed.DeletedAt = timestamppb.Now()
+
+	stream := suite.NewMockIntersectionStream()
+	stream.On("Recv").Return(trashed, nil).Once()
+	stream.On("Recv").Return(nil, io.EOF).Once()
+
+	suite.expectUserIntersections(ctx, userID, "intersection-123", "intersection-456")
+	suite.intrClient.On("GetDeletedIntersections", ctx, "intersection-123,intersection-456").
+		Return(stream, nil)
+
+	result, err := suite.service.GetDeletedIntersections(ctx, userID)
+
+	suite.Require().NoError(err)
+	suite.Require().Len(result.Intersections, 1)
+	suite.Equal("intersection-456", result.Intersections[0].ID)
+	suite.NotNil(result.Intersections[0].DeletedAt)
+
+	suite.userClient.AssertExpectations(suite.T())
+	suite.intrClient.AssertExpectations(suite.T())
+}
+
+func (suite *TestSuite) TestGetDeletedIntersections_NoIntersections() {
+	userID := "valid-user-id"
+
+	ctx := middleware.SetLogger(context.Background(), slog.Default())
+
+	suite.expectUserIntersections(ctx, userID)
+
+	result, err := suite.service.GetDeletedIntersections(ctx, userID)
+
+	suite.Require().NoError(err)
+	suite.NotNil(result.Intersections)
+	suite.Empty(result.Intersections)
+	suite.intrClient.AssertNotCalled(suite.T(), "GetDeletedIntersections", ctx, "")
+}
+
+func (suite *TestSuite) TestRestoreIntersectionByID_Success() {
+	userID := "valid-user-id"
+	intersectionID := "intersection-123"
+
+	ctx := middleware.SetLogger(context.Background(), slog.Default())
+
+	restored := createTestIntersection(
+		intersectionID,
+		"Test Intersection",
+		"123 Main Street",
+		"Pretoria",
+		"Gauteng",
+		commonpb.IntersectionStatus_INTERSECTION_STATUS_OPTIMISED,
+		3,
+		commonpb.TrafficDensity_TRAFFIC_DENSITY_HIGH,
+	)
+	restored.Version = 7
+
+	suite.expectUserIntersections(ctx, userID, intersectionID)
+	suite.intrClient.On("RestoreIntersection", ctx, intersectionID).Return(restored, nil)
+
+	result, err := suite.service.RestoreIntersectionByID(ctx, userID, intersectionID)
+
+	suite.Require().NoError(err)
+	suite.Equal(intersectionID, result.ID)
+	suite.Equal(7, result.Version)
+	suite.Nil(result.DeletedAt)
+
+	suite.userClient.AssertExpectations(suite.T())
+	suite.intrClient.AssertExpectations(suite.T())
+}
+
+func (suite *TestSuite) TestRestoreIntersectionByID_Forbidden() {
+	userID := "valid-user-id"
+	intersectionID := "intersection-999"
+
+	ctx := middleware.SetLogger(context.Background(), slog.Default())
+
+	suite.expectUserIntersections(ctx, userID, "intersection-123")
+
+	_, err := suite.service.RestoreIntersectionByID(ctx, userID, intersectionID)
+
+	suite.Require().Error(err)
+	svcError, ok := err.(*errs.ServiceError)
+	suite.True(ok)
+	suite.Equal(errs.ErrForbidden, svcError.Code)
+	suite.intrClient.AssertNotCalled(suite.T(), "RestoreIntersection", ctx, intersectionID)
+}
+
+func (suite *TestSuite) TestRestoreIntersectionByID_NotInTrash() {
+	userID := "valid-user-id"
+	intersectionID := "intersection-123"
+
+	ctx := middleware.SetLogger(context.Background(), slog.Default())
+
+	suite.expectUserIntersections(ctx, userID, intersectionID)
+	suite.intrClient.On("RestoreIntersection", ctx, intersectionID).
+		Return(nil, errs.NewNotFoundError("intersection ID not found in trash", map[string]any{}))
+
+	_, err := suite.service.RestoreIntersectionByID(ctx, userID, intersectionID)
+
+	suite.Require().Error(err)
+	svcError, ok := err.(*errs.ServiceError)
+	suite.True(ok)
+	suite.Equal(errs.ErrNotFound, svcError.Code)
+}
@@ -59,10 +59,11 @@ func (suite *TestSuite) TestUpdateIntersectionByID_Success() {
 		Address:  "456 Updated Street",
 		City:     "Johannesburg",
 		Province: "Gauteng",
-	}).
+	}, "", (*model.OptimisationParameters)(nil), userID, 0).
 		Return(expectedUpdatedIntersection, nil)
+	suite.simCache.On("InvalidateIntersection", ctx, intersectionID).Return()
 
-	result, err := suite.service.UpdateIntersectionByID(ctx, userID, intersectionID, request)
+	result, err := suite.service.UpdateIntersectionByID(ctx, userID, intersectionID, request, 0)
 
 	suite.Require().NoError(err)
 	suite.Equal("intersection-123", result.ID)
@@ -73,6 +74,7 @@ func (suite *TestSuite) TestUpdateIntersectionByID_Success() {
 
 	suite.userClient.AssertExpectations(suite.T())
 	suite.intrClient.AssertExpectations(suite.T())
+	suite.simCache.AssertExpectations(suite.T())
 	mockUserStream.AssertExpectations(suite.T())
 }
 
@@ -109,7 +111,7 @@ func (suite *TestSuite) TestUpdateIntersectionByID_Forbidden() {
 
 	suite.userClient.On("GetUserIntersectionIDs", ctx, userID).Return(mockUserStream, nil)
 
-	_, err := suite.service.UpdateIntersectionByID(ctx, userID, intersectionID, request)
+	_, err := suite.service.UpdateIntersectionByID(ctx, userID, intersectionID, request, 0)
 
 	suite.Require().Error(err)
 
@@ -145,7 +147,7 @@ func (suite *TestSuite) TestUpdateIntersectionByID_UserServiceError() {
 	suite.userClient.On("GetUserIntersectionIDs", ctx, userID).
 		Return(nil, errs.NewNotFoundError("user not found", map[string]any{}))
 
-	_, err := suite.service.UpdateIntersectionByID(ctx, userID, intersectionID, request)
+	_, err := suite.service.UpdateIntersectionByID(ctx, userID, intersectionID, request, 0)
 
 	suite.Require().Error(err)
 
@@ -193,10 +195,10 @@ func (suite *TestSuite) TestUpdateIntersectionByID_IntersectionServiceError() {
 		Address:  "456 Updated Street",
 		City:     "Johannesburg",
 		Province: "Gauteng",
-	}).
+	}, "", (*model.OptimisationParameters)(nil), userID, 0).
 		Return(nil, errs.NewInternalError("database connection failed", nil, map[string]any{}))
 
-	_, err := suite.service.UpdateIntersectionByID(ctx, userID, intersectionID, request)
+	_, err := suite.service.UpdateIntersectionByID(ctx, userID, intersectionID, request, 0)
 
 	suite.Require().Error(err)
 
@@ -207,6 +209,7 @@ func (suite *TestSuite) TestUpdateIntersectionByID_IntersectionServiceError() {
 
 	suite.userClient.AssertExpectations(suite.T())
 	suite.intrClient.AssertExpectations(suite.T())
+	suite.simCache.AssertNotCalled(suite.T(), "InvalidateIntersection", ctx, intersectionID)
 	mockUserStream.AssertExpectations(suite.T())
 }
 
@@ -246,10 +249,10 @@ func (suite *TestSuite) TestUpdateIntersectionByID_IntersectionNotFound() {
 		Address:  "456 Updated Street",
 		City:     "Johannesburg",
 		Province: "Gauteng",
-	}).
+	}, "", (*model.OptimisationParameters)(nil), userID, 0).
 		Return(nil, errs.NewNotFoundError("intersection not found", map[string]any{}))
 
-	_, err := suite.service.UpdateIntersectionByID(ctx, userID, intersectionID, request)
+	_, err := suite.service.UpdateIntersectionByID(ctx, userID, intersectionID, request, 0)
 
 	suite.Require().Error(err)
 
@@ -268,7 +271,7 @@ func (suite *TestSuite) TestUpdateIntersectionByID_ValidationError() {
 	intersectionID := "intersection-123"
 
 	request := model.UpdateIntersectionRequest{
-		Name: "", // Empty name should cause validation error
+		Name: "X", // Too short a name should cause validation error
 		Details: struct {
 			Address  string `json:"address"  example:"Corner of Foo and Bar"`
 			City     string `json:"city"     example:"Pretoria"`
@@ -295,20 +298,20 @@ func (suite *TestSuite) TestUpdateIntersectionByID_ValidationError() {
 	mockUserStream.On("Recv").Return(nil, io.EOF).Once()
 
 	suite.userClient.On("GetUserIntersectionIDs", ctx, userID).Return(mockUserStream, nil)
-	suite.intrClient.On("UpdateIntersection", ctx, intersectionID, "", model.Details{
+	suite.intrClient.On("UpdateIntersection", ctx, intersectionID, "X", model.Details{
 		Address:  "456 Updated Street",
 		City:     "Johannesburg",
 		Province: "Gauteng",
-	}).Return(nil, errs.NewValidationError("intersection name cannot be empty", map[string]any{}))
+	}, "", (*model.OptimisationParameters)(nil), userID, 0).Return(nil, errs.NewValidationError("intersection name must be at least 2 characters", map[string]any{}))
 
-	_, err := suite.service.UpdateIntersectionByID(ctx, userID, intersectionID, request)
+	_, err := suite.service.UpdateIntersectionByID(ctx, userID, intersectionID, request, 0)
 
 	suite.Require().Error(err)
 
 	svcError, ok := err.(*errs.ServiceError)
 	suite.True(ok)
 	suite.Equal(errs.ErrValidation, svcError.Code)
-	suite.Equal("intersection name cannot be empty", svcError.Message)
+	suite.Equal("intersection name must be at least 2 characters", svcError.Message)
 
 	suite.userClient.AssertExpectations(suite.T())
 	suite.intrClient.AssertExpectations(suite.T())
@@ -338,7 +341,7 @@ func (suite *TestSuite) TestUpdateIntersectionByID_EmptyUserID() {
 	suite.userClient.On("GetUserIntersectionIDs", ctx, userID).
 		Return(nil, errs.NewValidationError("user ID cannot be empty", map[string]any{}))
 
-	_, err := suite.service.UpdateIntersectionByID(ctx, userID, intersectionID, request)
+	_, err := suite.service.UpdateIntersectionByID(ctx, userID, intersectionID, request, 0)
 
 	suite.Require().Error(err)
 
@@ -383,7 +386,7 @@ func (suite *TestSuite) TestUpdateIntersectionByID_EmptyIntersectionID() {
 
 	suite.userClient.On("GetUserIntersectionIDs", ctx, userID).Return(mockUserStream, nil)
 
-	_, err := suite.service.UpdateIntersectionByID(ctx, userID, intersectionID, request)
+	_, err := suite.service.UpdateIntersectionByID(ctx, userID, intersectionID, request, 0)
 
 	suite.Require().Error(err)
 
@@ -395,3 +398,240 @@ func (suite *TestSuite) TestUpdateIntersectionByID_EmptyIntersectionID() {
 	suite.userClient.AssertExpectations(suite.T())
 	mockUserStream.AssertExpectations(suite.T())
 }
+
+func (suite *TestSuite) TestUpdateIntersectionByID_Defaults() {
+	userID := "valid-user-id"
+	intersectionID := "intersection-123"
+
+	defaults := model.SimulationParameters{
+		IntersectionType: "traffic light",
+		Green:            20,
+		Yellow:           4,
+		Red:              9,
+		Speed:            50,
+		Seed:             777,
+	}
+	request := model.UpdateIntersectionRequest{
+		TrafficDensity:    "low",
+		DefaultParameters: &defaults,
+	}
+
+	current := createTestIntersection(
+		intersectionID,
+		"Current Intersection",
+		"123 Current Street",
+		"Pretoria",
+		"Gauteng",
+		commonpb.IntersectionStatus_INTERSECTION_STATUS_OPTIMISED,
+		5,
+		commonpb.TrafficDensity_TRAFFIC_DENSITY_HIGH,
+	)
+	updated := createTestIntersection(
+		intersectionID,
+		"Current Intersection",
+		"123 Current Street",
+		"Pretoria",
+		"Gauteng",
+		commonpb.IntersectionStatus_INTERSECTION_STATUS_OPTIMISED,
+		5,
+		commonpb.TrafficDensity_TRAFFIC_DENSITY_LOW,
+	)
+	current.Version = 7
+	updated.BestParametersStale = true
+
+	logger := slog.Default()
+	ctx := middleware.SetLogger(context.Background(), logger)
+
+	mockUserStream := suite.NewMockUserIntersectionIDsStream()
+	mockUserStream.On("Recv").
+		Return(&userpb.IntersectionIDResponse{IntersectionId: intersectionID}, nil).
+		Once()
+	mockUserStream.On("Recv").Return(nil, io.EOF).Once()
+
+	suite.userClient.On("GetUserIntersectionIDs", ctx, userID).Return(mockUserStream, nil)
+	suite.intrClient.On("GetIntersection", ctx, intersectionID).Return(current, nil)
+	// NOTE: Omitted fields keep their current values, as does the optimisation type
+	suite.intrClient.On("UpdateIntersection", ctx, intersectionID, "Current Intersection", model.Details{
+		Address:  "123 Current Street",
+		City:     "Pretoria",
+		Province: "Gauteng",
+	}, "low", &model.OptimisationParameters{
+		OptimisationType:     "OPTIMISATION_TYPE_GRIDSEARCH",
+		SimulationParameters: defaults,
+	}, userID, 7).
+		Return(updated, nil)
+	suite.simCache.On("InvalidateIntersection", ctx, intersectionID).Return()
+
+	result, err := suite.service.UpdateIntersectionByID(ctx, userID, intersectionID, request, 0)
+
+	suite.Require().NoError(err)
+	suite.Equal("TRAFFIC_DENSITY_LOW", result.TrafficDensity)
+	suite.True(result.BestParametersStale)
+
+	suite.intrClient.AssertExpectations(suite.T())
+	suite.simCache.AssertExpectations(suite.T())
+}
+
+func (suite *TestSuite) TestUpdateIntersectionByID_DefaultsConflict() {
+	userID := "valid-user-id"
+	intersectionID := "intersection-123"
+
+	request := model.UpdateIntersectionRequest{TrafficDensity: "medium"}
+
+	current := createTestIntersection(
+		intersectionID,
+		"Current Intersection",
+		"123 Current Street",
+		"Pretoria",
+		"Gauteng",
+		commonpb.IntersectionStatus_INTERSECTION_STATUS_OPTIMISING,
+		5,
+		commonpb.TrafficDensity_TRAFFIC_DENSITY_HIGH,
+	)
+	current.Version = 7
+
+	logger := slog.Default()
+	ctx := middleware.SetLogger(context.Background(), logger)
+
+	mockUserStream := suite.NewMockUserIntersectionIDsStream()
+	mockUserStream.On("Recv").
+		Return(&userpb.IntersectionIDResponse{IntersectionId: intersectionID}, nil).
+		Once()
+	mockUserStream.On("Recv").Return(nil, io.EOF).Once()
+
+	suite.userClient.On("GetUserIntersectionIDs", ctx, userID).Return(mockUserStream, nil)
+	suite.intrClient.On("GetIntersection", ctx, intersectionID).Return(current, nil)
+	suite.intrClient.On("UpdateIntersection", ctx, intersectionID, "Current Intersection", model.Details{
+		Address:  "123 Current Street",
+		City:     "Pretoria",
+		Province: "Gauteng",
+	}, "medium", (*model.OptimisationParameters)(nil), userID, 7).
+		Return(nil, errs.NewConflictError(
+			"defaults cannot change while the intersection is being optimised",
+			map[string]any{},
+		))
+
+	_, err := suite.service.UpdateIntersectionByID(ctx, userID, intersectionID, request, 0)
+
+	suite.Require().Error(err)
+	svcError, ok := err.(*errs.ServiceError)
+	suite.True(ok)
+	suite.Equal(errs.ErrConflict, svcError.Code)
+	suite.simCache.AssertNotCalled(suite.T(), "InvalidateIntersection", ctx, intersectionID)
+}
+
+func (suite *TestSuite) TestUpdateIntersectionByID_ExpectedVersion() {
+	userID := "valid-user-id"
+	intersectionID := "intersection-123"
+
+	request := model.UpdateIntersectionRequest{Name: "Updated Intersection"}
+	request.Details.Address = "456 Updated Street"
+	request.Details.City = "Johannesburg"
+	request.Details.Province = "Gauteng"
+
+	updated := createTestIntersection(
+		intersectionID,
+		"Updated Intersection",
+		"",
+		"",
+		"",
+		commonpb.IntersectionStatus_INTERSECTION_STATUS_UNOPTIMISED,
+		0,
+		commonpb.TrafficDensity_TRAFFIC_DENSITY_HIGH,
+	)
+	updated.Version = 4
+
+	ctx := middleware.SetLogger(context.Background(), slog.Default())
+
+	suite.expectUserIntersections(ctx, userID, intersectionID)
+	suite.intrClient.On("UpdateIntersection", ctx, intersectionID, "Updated Intersection", model.Details{
+		Address:  "456 Updated Street",
+		City:     "Johannesburg",
+		Province: "Gauteng",
+	},
+		"", (*model.OptimisationParameters)(nil), userID, 3).
+		Return(updated, nil)
+	suite.simCache.On("InvalidateIntersection", ctx, intersectionID).Return()
+
+	result, err := suite.service.UpdateIntersectionByID(ctx, userID, intersectionID, request, 3)
+
+	suite.Require().NoError(err)
+	suite.Equal(4, result.Version)
+	suite.intrClient.AssertExpectations(suite.T())
+}
+
+func (suite *TestSuite) TestUpdateIntersectionByID_StaleVersion() {
+	userID := "valid-user-id"
+	intersectionID := "intersection-123"
+
+	request := model.UpdateIntersectionRequest{Name: "Updated Intersection"}
+	request.Details.Address = "456 Updated Street"
+	request.Details.City = "Johannesburg"
+	request.Details.Province = "Gauteng"
+
+	ctx := middleware.SetLogger(context.Background(), slog.Default())
+
+	suite.expectUserIntersections(ctx, userID, intersectionID)
+	suite.intrClient.On("UpdateIntersection", ctx, intersectionID, "Updated Intersection", model.Details{
+		Address:  "456 Updated Street",
+		City:     "Johannesburg",
+		Province: "Gauteng",
+	},
+		"", (*model.OptimisationParameters)(nil), userID, 3).
+		Return(nil, errs.NewPreconditionError(
+			"intersection has been modified since it was read",
+			map[string]any{},
+		))
+
+	_, err := suite.service.UpdateIntersectionByID(ctx, userID, intersectionID, request, 3)
+
+	suite.Require().Error(err)
+	svcError, ok := err.(*errs.ServiceError)
+	suite.True(ok)
+	suite.Equal(errs.ErrPrecondition, svcError.Code)
+	suite.simCache.AssertNotCalled(suite.T(), "InvalidateIntersection", ctx, intersectionID)
+}
+
+func (suite *TestSuite) TestUpdateIntersectionByID_OmittedFieldsModifiedSinceRead() {
+	userID := "valid-user-id"
+	intersectionID := "intersection-123"
+
+	request := model.UpdateIntersectionRequest{TrafficDensity: "low"}
+
+	current := createTestIntersection(
+		intersectionID,
+		"Current Intersection",
+		"123 Current Street",
+		"Pretoria",
+		"Gauteng",
+		commonpb.IntersectionStatus_INTERSECTION_STATUS_UNOPTIMISED,
+		5,
+		commonpb.TrafficDensity_TRAFFIC_DENSITY_HIGH,
+	)
+	current.Version = 7
+
+	ctx := middleware.SetLogger(context.Background(), slog.Default())
+
+	suite.expectUserIntersections(ctx, userID, intersectionID)
+	suite.intrClient.On("GetIntersection", ctx, intersectionID).Return(current, nil)
+	// NOTE: Without If-Match the write is still conditional on the version whose name and
+	// details it carries, so it cannot undo a rename made in between
+	suite.intrClient.On("UpdateIntersection", ctx, intersectionID, "Current Intersection", model.Details{
+		Address:  "123 Current Street",
+		City:     "Pretoria",
+		Province: "Gauteng",
+	}, "low", (*model.OptimisationParameters)(nil), userID, 7).
+		Return(nil, errs.NewPreconditionError(
+			"intersection has been modified since it was read",
+			map[string]any{},
+		))
+
+	_, err := suite.service.UpdateIntersectionByID(ctx, userID, intersectionID, request, 0)
+
+	suite.Require().Error(err)
+	svcError, ok := err.(*errs.ServiceError)
+	suite.True(ok)
+	suite.Equal(errs.ErrPrecondition, svcError.Code)
+	suite.intrClient.AssertExpectations(suite.T())
+	suite.simCache.AssertNotCalled(suite.T(), "InvalidateIntersection", ctx, intersectionID)
+}
@@ -0,0 +1,56 @@
+package notification
+
+import (
+	"context"
+	"log/slog"
+	"os"
+	"testing"
+	"time"
+
+	"github.com/COS301-SE-2025/Swift-Signals/api-gateway/internal/middleware"
+	repomocks "github.com/COS301-SE-2025/Swift-Signals/api-gateway/internal/mocks/repository"
+	"github.com/COS301-SE-2025/Swift-Signals/api-gateway/internal/model"
+	"github.com/COS301-SE-2025/Swift-Signals/api-gateway/internal/repository"
+	"github.com/COS301-SE-2025/Swift-Signals/api-gateway/internal/service"
+	"github.com/stretchr/testify/suite"
+)
+
+type TestSuite struct {
+	suite.Suite
+	repo    *repomocks.MockNotificationRepositoryInterface
+	service service.NotificationServiceInterface
+	store   *repository.MemoryNotificationRepository
+	inbox   service.NotificationServiceInterface
+	ctx     context.Context
+}
+
+func (suite *TestSuite) SetupSuite() {
+	slog.SetDefault(slog.New(slog.NewTextHandler(os.NewFile(0, os.DevNull), nil)))
+}
+
+func (suite *TestSuite) SetupTest() {
+	suite.repo = new(repomocks.MockNotificationRepositoryInterface)
+	suite.service = service.NewNotificationService(suite.repo)
+
+	// NOTE: The inbox is tested against the in-memory repository, so that notifications
+	// can be sent, read and listed end to end
+	suite.store = repository.NewMemoryNotificationRepository()
+	suite.inbox = service.NewNotificationService(suite.store)
+
+	suite.ctx = middleware.SetLogger(context.Background(), slog.Default())
+}
+
+// addNotification saves a notification created the given time ago
+func (suite *TestSuite) addNotification(id, userID string, age time.Duration) {
+	suite.Require().NoError(suite.store.CreateNotification(suite.ctx, model.Notification{
+		ID:        id,
+		UserID:    userID,
+		Type:      model.NotificationTypeOptimisationCompleted,
+		Message:   "Optimisation of Main & 5th finished: waiting time −18%",
+		CreatedAt: time.Now().Add(-age),
+	}))
+}
+
+func TestService(t *testing.T) {
+	suite.Run(t, new(TestSuite))
+}
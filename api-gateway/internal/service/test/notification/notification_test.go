@@ -0,0 +1,150 @@
+package notification
+
+import (
+	"errors"
+	"time"
+
+	"github.com/COS301-SE-2025/Swift-Signals/api-gateway/internal/model"
+	errs "github.com/COS301-SE-2025/Swift-Signals/shared/error"
+	"github.com/stretchr/testify/mock"
+)
+
+func (suite *TestSuite) TestNotify_SavesNotification() {
+	suite.inbox.Notify(
+		suite.ctx,
+		"user-1",
+		model.NotificationTypeOptimisationCompleted,
+		"int-1",
+		"Optimisation of Main & 5th finished: waiting time −18%",
+	)
+
+	result, err := suite.inbox.GetNotifications(suite.ctx, "user-1", 10)
+
+	suite.Require().NoError(err)
+	suite.Equal(1, result.UnreadCount)
+	suite.Require().Len(result.Notifications, 1)
+	notification := result.Notifications[0]
+	suite.NotEmpty(notification.ID)
+	suite.Equal(model.NotificationTypeOptimisationCompleted, notification.Type)
+	suite.Equal("int-1", notification.IntersectionID)
+	suite.Equal("Optimisation of Main & 5th finished: waiting time −18%", notification.Message)
+	suite.False(notification.Read)
+	suite.Nil(notification.ReadAt)
+	suite.WithinDuration(time.Now(), notification.CreatedAt, time.Second)
+}
+
+func (suite *TestSuite) TestNotify_RepositoryError() {
+	suite.repo.On("CreateNotification", suite.ctx, mock.AnythingOfType("model.Notification")).
+		Return(errs.NewDatabaseError(
+			"failed to create notification",
+			errors.New("down"),
+			map[string]any{},
+		))
+
+	// NOTE: Best effort, so the failure is only logged
+	suite.NotPanics(func() {
+		suite.service.Notify(suite.ctx, "user-1", model.NotificationTypeAdminGranted, "", "hi")
+	})
+	suite.repo.AssertExpectations(suite.T())
+}
+
+func (suite *TestSuite) TestGetNotifications_NewestFirst() {
+	suite.addNotification("notification-1", "user-1", 3*time.Minute)
+	suite.addNotification("notification-2", "user-1", time.Minute)
+	suite.addNotification("notification-3", "user-1", 2*time.Minute)
+	suite.addNotification("notification-4", "user-2", 0)
+
+	result, err := suite.inbox.GetNotifications(suite.ctx, "user-1", 2)
+
+	suite.Require().NoError(err)
+	suite.Require().Len(result.Notifications, 2)
+	suite.Equal("notification-2", result.Notifications[0].ID)
+	suite.Equal("notification-3", result.Notifications[1].ID)
+	// NOTE: The unread count covers every notification, not only those returned
+	suite.Equal(3, result.UnreadCount)
+}
+
+func (suite *TestSuite) TestGetNotifications_RepositoryError() {
+	suite.repo.On("GetNotifications", suite.ctx, "user-1", 50).
+		Return(nil, errs.NewDatabaseError(
+			"failed to get notifications",
+			errors.New("down"),
+			map[string]any{},
+		))
+
+	_, err := suite.service.GetNotifications(suite.ctx, "user-1", 50)
+
+	suite.Require().Error(err)
+	svcError, ok := err.(*errs.ServiceError)
+	suite.Require().True(ok)
+	suite.Equal(errs.ErrDatabase, svcError.Code)
+}
+
+func (suite *TestSuite) TestMarkRead_Success() {
+	suite.addNotification("notification-1", "user-1", time.Minute)
+	suite.addNotification("notification-2", "user-1", 0)
+
+	suite.Require().NoError(suite.inbox.MarkRead(suite.ctx, "user-1", "notification-1"))
+
+	result, err := suite.inbox.GetNotifications(suite.ctx, "user-1", 10)
+	suite.Require().NoError(err)
+	suite.Equal(1, result.UnreadCount)
+	suite.False(result.Notifications[0].Read)
+	suite.True(result.Notifications[1].Read)
+	suite.NotNil(result.Notifications[1].ReadAt)
+}
+
+func (suite *TestSuite) TestMarkRead_KeepsFirstReadTime() {
+	suite.addNotification("notification-1", "user-1", 0)
+	first := time.Now().Add(-time.Hour)
+	suite.Require().NoError(suite.store.MarkRead(suite.ctx, "user-1", "notification-1", first))
+
+	suite.Require().NoError(suite.inbox.MarkRead(suite.ctx, "user-1", "notification-1"))
+
+	notifications, err := suite.store.GetNotifications(suite.ctx, "user-1", 10)
+	suite.Require().NoError(err)
+	suite.Require().NotNil(notifications[0].ReadAt)
+	suite.True(first.Equal(*notifications[0].ReadAt))
+}
+
+func (suite *TestSuite) TestMarkRead_OtherUsersNotification() {
+	suite.addNotification("notification-1", "user-2", 0)
+
+	err := suite.inbox.MarkRead(suite.ctx, "user-1", "notification-1")
+
+	suite.Require().Error(err)
+	svcError, ok := err.(*errs.ServiceError)
+	suite.Require().True(ok)
+	suite.Equal(errs.ErrNotFound, svcError.Code)
+
+	unread, err := suite.inbox.CountUnread(suite.ctx, "user-2")
+	suite.Require().NoError(err)
+	suite.Equal(1, unread)
+}
+
+func (suite *TestSuite) TestMarkAllRead_Success() {
+	suite.addNotification("notification-1", "user-1", 2*time.Minute)
+	suite.addNotification("notification-2", "user-1", time.Minute)
+	suite.addNotification("notification-3", "user-1", 0)
+	suite.addNotification("notification-4", "user-2", 0)
+	suite.Require().NoError(suite.inbox.MarkRead(suite.ctx, "user-1", "notification-2"))
+
+	result, err := suite.inbox.MarkAllRead(suite.ctx, "user-1")
+
+	suite.Require().NoError(err)
+	suite.Equal(2, result.Marked)
+
+	unread, err := suite.inbox.CountUnread(suite.ctx, "user-1")
+	suite.Require().NoError(err)
+	suite.Zero(unread)
+	unread, err = suite.inbox.CountUnread(suite.ctx, "user-2")
+	suite.Require().NoError(err)
+	suite.Equal(1, unread)
+}
+
+func (suite *TestSuite) TestMarkAllRead_NothingUnread() {
+	result, err := suite.inbox.MarkAllRead(suite.ctx, "user-1")
+
+	suite.Require().NoError(err)
+	suite.Zero(result.Marked)
+}
@@ -1,9 +1,11 @@
 package profile
 
 import (
+	"context"
 	"testing"
 
 	mocks "github.com/COS301-SE-2025/Swift-Signals/api-gateway/internal/mocks/client"
+	"github.com/COS301-SE-2025/Swift-Signals/api-gateway/internal/repository"
 	"github.com/COS301-SE-2025/Swift-Signals/api-gateway/internal/service"
 	userpb "github.com/COS301-SE-2025/Swift-Signals/protos/gen/swiftsignals/user/v1"
 	"github.com/stretchr/testify/suite"
@@ -11,13 +13,33 @@ import (
 
 type TestSuite struct {
 	suite.Suite
-	client  *mocks.MockUserClientInterface
-	service service.ProfileServiceInterface
+	client     *mocks.MockUserClientInterface
+	intrClient *mocks.MockIntersectionClientInterface
+	service    service.ProfileServiceInterface
+
+	notifications *repository.MemoryNotificationRepository
 }
 
 func (suite *TestSuite) SetupTest() {
 	suite.client = new(mocks.MockUserClientInterface)
-	suite.service = service.NewProfileService(suite.client)
+	suite.intrClient = new(mocks.MockIntersectionClientInterface)
+	suite.notifications = repository.NewMemoryNotificationRepository()
+	suite.service = service.NewProfileService(
+		suite.client,
+		suite.intrClient,
+		service.NewNotificationService(suite.notifications),
+	)
+}
+
+// expectUser expects the user being deleted to be looked up for the intersections they own
+func (suite *TestSuite) expectUser(
+	ctx context.Context,
+	userID string,
+	intersectionIDs ...string,
+) {
+	suite.client.On("GetUserByID", ctx, userID).
+		Return(createTestUser(userID, "Test User", "test@example.com", false, intersectionIDs), nil).
+		Once()
 }
 
 // createTestUser creates a test user protobuf object
@@ -6,6 +6,7 @@ import (
 
 	"github.com/COS301-SE-2025/Swift-Signals/api-gateway/internal/middleware"
 	errs "github.com/COS301-SE-2025/Swift-Signals/shared/error"
+	"github.com/stretchr/testify/mock"
 )
 
 func (suite *TestSuite) TestDeleteProfile_Success() {
@@ -15,6 +16,7 @@ func (suite *TestSuite) TestDeleteProfile_Success() {
 	ctx := middleware.SetLogger(context.Background(), logger)
 	ctx = middleware.SetUserID(ctx, userID)
 
+	suite.expectUser(ctx, userID)
 	suite.client.On("DeleteUser", ctx, userID).
 		Return(nil, nil)
 
@@ -49,6 +51,7 @@ func (suite *TestSuite) TestDeleteProfile_UserNotFound() {
 	ctx := middleware.SetLogger(context.Background(), logger)
 	ctx = middleware.SetUserID(ctx, userID)
 
+	suite.expectUser(ctx, userID)
 	suite.client.On("DeleteUser", ctx, userID).
 		Return(nil, errs.NewNotFoundError("user not found", map[string]any{"userID": userID}))
 
@@ -71,6 +74,7 @@ func (suite *TestSuite) TestDeleteProfile_InternalError() {
 	ctx := middleware.SetLogger(context.Background(), logger)
 	ctx = middleware.SetUserID(ctx, userID)
 
+	suite.expectUser(ctx, userID)
 	suite.client.On("DeleteUser", ctx, userID).
 		Return(nil, errs.NewInternalError("database connection failed", nil, map[string]any{}))
 
@@ -93,6 +97,7 @@ func (suite *TestSuite) TestDeleteProfile_UnauthorizedError() {
 	ctx := middleware.SetLogger(context.Background(), logger)
 	ctx = middleware.SetUserID(ctx, userID)
 
+	suite.expectUser(ctx, userID)
 	suite.client.On("DeleteUser", ctx, userID).
 		Return(nil, errs.NewUnauthorizedError("invalid token", map[string]any{}))
 
@@ -115,6 +120,7 @@ func (suite *TestSuite) TestDeleteProfile_ForbiddenError() {
 	ctx := middleware.SetLogger(context.Background(), logger)
 	ctx = middleware.SetUserID(ctx, userID)
 
+	suite.expectUser(ctx, userID)
 	suite.client.On("DeleteUser", ctx, userID).
 		Return(nil, errs.NewForbiddenError("cannot delete account with active intersections", map[string]any{"userID": userID}))
 
@@ -137,6 +143,7 @@ func (suite *TestSuite) TestDeleteProfile_ConflictError() {
 	ctx := middleware.SetLogger(context.Background(), logger)
 	ctx = middleware.SetUserID(ctx, userID)
 
+	suite.expectUser(ctx, userID)
 	suite.client.On("DeleteUser", ctx, userID).
 		Return(nil, errs.NewConflictError("user has pending operations", map[string]any{"userID": userID}))
 
@@ -159,6 +166,7 @@ func (suite *TestSuite) TestDeleteProfile_ServiceUnavailable() {
 	ctx := middleware.SetLogger(context.Background(), logger)
 	ctx = middleware.SetUserID(ctx, userID)
 
+	suite.expectUser(ctx, userID)
 	suite.client.On("DeleteUser", ctx, userID).
 		Return(nil, errs.NewUnavailableError("user service is temporarily unavailable", map[string]any{}))
 
@@ -181,6 +189,7 @@ func (suite *TestSuite) TestDeleteProfile_ValidationError() {
 	ctx := middleware.SetLogger(context.Background(), logger)
 	ctx = middleware.SetUserID(ctx, userID)
 
+	suite.expectUser(ctx, userID)
 	suite.client.On("DeleteUser", ctx, userID).
 		Return(nil, errs.NewValidationError("user ID cannot be empty", map[string]any{"userID": userID}))
 
@@ -203,6 +212,7 @@ func (suite *TestSuite) TestDeleteProfile_AdminUserDeletion() {
 	ctx := middleware.SetLogger(context.Background(), logger)
 	ctx = middleware.SetUserID(ctx, userID)
 
+	suite.expectUser(ctx, userID)
 	suite.client.On("DeleteUser", ctx, userID).
 		Return(nil, nil)
 
@@ -220,6 +230,7 @@ func (suite *TestSuite) TestDeleteProfile_UserWithIntersections() {
 	ctx := middleware.SetLogger(context.Background(), logger)
 	ctx = middleware.SetUserID(ctx, userID)
 
+	suite.expectUser(ctx, userID)
 	suite.client.On("DeleteUser", ctx, userID).
 		Return(nil, errs.NewForbiddenError("cannot delete user with assigned intersections", map[string]any{"userID": userID}))
 
@@ -234,3 +245,50 @@ func (suite *TestSuite) TestDeleteProfile_UserWithIntersections() {
 
 	suite.client.AssertExpectations(suite.T())
 }
+
+func (suite *TestSuite) TestDeleteProfile_TrashesIntersections() {
+	userID := "user-123"
+
+	logger := slog.Default()
+	ctx := middleware.SetLogger(context.Background(), logger)
+	ctx = middleware.SetUserID(ctx, userID)
+
+	suite.expectUser(ctx, userID, "intersection-1", "intersection-2")
+	suite.intrClient.On("DeleteIntersection", ctx, "intersection-1", 0).Return(nil, nil)
+	suite.intrClient.On("DeleteIntersection", ctx, "intersection-2", 0).Return(nil, nil)
+	suite.client.On("DeleteUser", ctx, userID).
+		Return(nil, nil)
+
+	err := suite.service.DeleteProfile(ctx, userID)
+
+	suite.Require().NoError(err)
+
+	suite.client.AssertExpectations(suite.T())
+	suite.intrClient.AssertExpectations(suite.T())
+	suite.intrClient.AssertNotCalled(suite.T(), "RestoreIntersection", mock.Anything, mock.Anything)
+}
+
+func (suite *TestSuite) TestDeleteProfile_RestoresIntersectionsWhenUserNotDeleted() {
+	userID := "user-123"
+
+	logger := slog.Default()
+	ctx := middleware.SetLogger(context.Background(), logger)
+	ctx = middleware.SetUserID(ctx, userID)
+
+	suite.expectUser(ctx, userID, "intersection-1")
+	suite.intrClient.On("DeleteIntersection", ctx, "intersection-1", 0).Return(nil, nil)
+	suite.client.On("DeleteUser", ctx, userID).
+		Return(nil, errs.NewUnavailableError("user service unavailable", map[string]any{}))
+	suite.intrClient.On("RestoreIntersection", ctx, "intersection-1").Return(nil, nil)
+
+	err := suite.service.DeleteProfile(ctx, userID)
+
+	suite.Require().Error(err)
+
+	svcError, ok := err.(*errs.ServiceError)
+	suite.True(ok)
+	suite.Equal(errs.ErrUnavailable, svcError.Code)
+
+	suite.client.AssertExpectations(suite.T())
+	suite.intrClient.AssertExpectations(suite.T())
+}
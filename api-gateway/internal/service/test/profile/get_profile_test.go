@@ -2,10 +2,14 @@ package profile
 
 import (
 	"context"
+	"errors"
 	"log/slog"
+	"time"
 
 	"github.com/COS301-SE-2025/Swift-Signals/api-gateway/internal/middleware"
+	repomocks "github.com/COS301-SE-2025/Swift-Signals/api-gateway/internal/mocks/repository"
 	"github.com/COS301-SE-2025/Swift-Signals/api-gateway/internal/model"
+	"github.com/COS301-SE-2025/Swift-Signals/api-gateway/internal/service"
 	errs "github.com/COS301-SE-2025/Swift-Signals/shared/error"
 )
 
@@ -35,10 +39,65 @@ func (suite *TestSuite) TestGetProfile_Success() {
 	suite.Equal("john@example.com", result.Email)
 	suite.False(result.IsAdmin)
 	suite.Equal([]string{"intersection-1", "intersection-2"}, result.IntersectionIDs)
+	suite.Require().NotNil(result.UnreadNotifications)
+	suite.Zero(*result.UnreadNotifications)
 
 	suite.client.AssertExpectations(suite.T())
 }
 
+func (suite *TestSuite) TestGetProfile_UnreadNotifications() {
+	userID := "user-123"
+	ctx := middleware.SetLogger(context.Background(), slog.Default())
+	ctx = middleware.SetUserID(ctx, userID)
+
+	for _, id := range []string{"notification-1", "notification-2", "notification-3"} {
+		suite.Require().NoError(suite.notifications.CreateNotification(ctx, model.Notification{
+			ID:        id,
+			UserID:    userID,
+			Message:   "Optimisation of Main & 5th finished: waiting time \u221218%",
+			CreatedAt: time.Now(),
+		}))
+	}
+	suite.Require().NoError(suite.notifications.MarkRead(ctx, userID, "notification-2", time.Now()))
+
+	suite.client.On("GetUserByID", ctx, userID).
+		Return(createTestUser(userID, "John Doe", "john@example.com", false, nil), nil)
+
+	result, err := suite.service.GetProfile(ctx, userID)
+
+	suite.Require().NoError(err)
+	suite.Require().NotNil(result.UnreadNotifications)
+	suite.Equal(2, *result.UnreadNotifications)
+}
+
+func (suite *TestSuite) TestGetProfile_InboxUnavailable() {
+	userID := "user-123"
+	ctx := middleware.SetLogger(context.Background(), slog.Default())
+	ctx = middleware.SetUserID(ctx, userID)
+
+	notifications := new(repomocks.MockNotificationRepositoryInterface)
+	notifications.On("CountUnread", ctx, userID).
+		Return(0, errors.New("notification store unavailable"))
+	profiles := service.NewProfileService(
+		suite.client,
+		suite.intrClient,
+		service.NewNotificationService(notifications),
+	)
+
+	suite.client.On("GetUserByID", ctx, userID).
+		Return(createTestUser(userID, "John Doe", "john@example.com", false, nil), nil)
+
+	result, err := profiles.GetProfile(ctx, userID)
+
+	// NOTE: The profile does not depend on the inbox, so it is returned without the count
+	suite.Require().NoError(err)
+	suite.Equal(userID, result.ID)
+	suite.Equal("John Doe", result.Username)
+	suite.Nil(result.UnreadNotifications)
+
+	notifications.AssertExpectations(suite.T())
+}
+
 func (suite *TestSuite) TestGetProfile_AdminUser() {
 	userID := "admin-456"
 
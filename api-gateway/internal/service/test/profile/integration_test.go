@@ -77,6 +77,7 @@ func (suite *TestSuite) TestCompleteProfileLifecycle() {
 	suite.Equal(updatedEmail, getUpdatedResult.Email)
 
 	// Step 4: Delete the profile
+	suite.expectUser(ctx, userID)
 	suite.client.On("DeleteUser", ctx, userID).
 		Return(nil, nil).
 		Once()
@@ -127,6 +128,7 @@ func (suite *TestSuite) TestProfileErrorPropagationConsistency() {
 	suite.Equal("database connection failed", svcError.Message)
 
 	// Test DeleteProfile error propagation
+	suite.expectUser(ctx, userID)
 	suite.client.On("DeleteUser", ctx, userID).
 		Return(nil, internalError).
 		Once()
@@ -336,6 +338,7 @@ func (suite *TestSuite) TestProfileDeletionScenarios() {
 	for _, tc := range testCases {
 		userCtx := middleware.SetUserID(ctx, tc.userID)
 
+		suite.expectUser(userCtx, tc.userID)
 		if tc.setupError != nil {
 			suite.client.On("DeleteUser", userCtx, tc.userID).
 				Return(nil, tc.setupError).
@@ -470,6 +473,7 @@ func (suite *TestSuite) TestProfileServiceResilience() {
 			suite.Require().Error(err, "Expected error for test case: %s", tc.name)
 
 		case "delete":
+			suite.expectUser(ctx, userID)
 			suite.client.On("DeleteUser", ctx, userID).
 				Return(nil, tc.error).
 				Once()
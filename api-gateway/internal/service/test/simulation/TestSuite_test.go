@@ -0,0 +1,225 @@
+package simulation
+
+import (
+	"context"
+	"io"
+	"log/slog"
+	"testing"
+	"time"
+
+	"github.com/COS301-SE-2025/Swift-Signals/api-gateway/internal/middleware"
+	mocks "github.com/COS301-SE-2025/Swift-Signals/api-gateway/internal/mocks/client"
+	grpcmocks "github.com/COS301-SE-2025/Swift-Signals/api-gateway/internal/mocks/grpc_client"
+	"github.com/COS301-SE-2025/Swift-Signals/api-gateway/internal/model"
+	"github.com/COS301-SE-2025/Swift-Signals/api-gateway/internal/repository"
+	"github.com/COS301-SE-2025/Swift-Signals/api-gateway/internal/service"
+	commonpb "github.com/COS301-SE-2025/Swift-Signals/protos/gen/swiftsignals/common/v1"
+	intersectionpb "github.com/COS301-SE-2025/Swift-Signals/protos/gen/swiftsignals/intersection/v1"
+	optimisationpb "github.com/COS301-SE-2025/Swift-Signals/protos/gen/swiftsignals/optimisation/v1"
+	simulationpb "github.com/COS301-SE-2025/Swift-Signals/protos/gen/swiftsignals/simulation/v1"
+	userpb "github.com/COS301-SE-2025/Swift-Signals/protos/gen/swiftsignals/user/v1"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/suite"
+	"google.golang.org/protobuf/types/known/timestamppb"
+)
+
+type TestSuite struct {
+	suite.Suite
+	intrClient *mocks.MockIntersectionClientInterface
+	optiClient *mocks.MockOptimisationClientInterface
+	userClient *mocks.MockUserClientInterface
+	simClient  *mocks.MockSimulationClientInterface
+	service    service.SimulationServiceInterface
+	ctx        context.Context
+
+	notifications *repository.MemoryNotificationRepository
+	notifier      service.NotificationServiceInterface
+}
+
+func (suite *TestSuite) SetupTest() {
+	suite.intrClient = new(mocks.MockIntersectionClientInterface)
+	suite.optiClient = new(mocks.MockOptimisationClientInterface)
+	suite.userClient = new(mocks.MockUserClientInterface)
+	suite.simClient = new(mocks.MockSimulationClientInterface)
+	suite.notifications = repository.NewMemoryNotificationRepository()
+	suite.notifier = service.NewNotificationService(suite.notifications)
+	suite.service = service.NewSimulationService(
+		suite.intrClient,
+		suite.optiClient,
+		suite.userClient,
+		suite.simClient,
+		service.ReplicationConfig{Concurrency: 2, MaxReplications: 10},
+		service.SweepConfig{Concurrency: 2, CallTimeout: time.Second, MaxPoints: 100},
+		service.OptimisationConfig{},
+		suite.notifier,
+	)
+	suite.ctx = middleware.SetUserID(
+		middleware.SetLogger(context.Background(), slog.Default()),
+		"test-user-id",
+	)
+}
+
+// expectUserIntersections mocks the user service returning the given intersection IDs
+func (suite *TestSuite) expectUserIntersections(ids ...string) {
+	stream := grpcmocks.NewMockUserService_GetUserIntersectionIDsClient[userpb.IntersectionIDResponse](
+		suite.T(),
+	)
+	for _, id := range ids {
+		stream.On("Recv").Return(&userpb.IntersectionIDResponse{IntersectionId: id}, nil).Once()
+	}
+	stream.On("Recv").Return(nil, io.EOF).Once()
+
+	suite.userClient.On("GetUserIntersectionIDs", suite.ctx, "test-user-id").
+		Return(stream, nil).
+		Once()
+}
+
+// expectOptimisationJobs mocks the intersection service streaming back the given jobs
+func (suite *TestSuite) expectOptimisationJobs(
+	intersectionID string,
+	statuses []intersectionpb.OptimisationJobStatus,
+	jobs ...*intersectionpb.OptimisationJobResponse,
+) {
+	stream := grpcmocks.NewMockIntersectionService_GetOptimisationJobsClient[intersectionpb.OptimisationJobResponse](
+		suite.T(),
+	)
+	for _, job := range jobs {
+		stream.On("Recv").Return(job, nil).Once()
+	}
+	stream.On("Recv").Return(nil, io.EOF).Once()
+
+	suite.intrClient.On("GetOptimisationJobs", suite.ctx, intersectionID, statuses).
+		Return(stream, nil).
+		Once()
+}
+
+// expectOptimisationStream mocks the optimisation service streaming back the given
+// progress events followed by a final event carrying the optimised parameters
+func (suite *TestSuite) expectOptimisationStream(
+	result *commonpb.OptimisationParameters,
+	progress ...*optimisationpb.OptimisationProgress,
+) {
+	stream := grpcmocks.NewMockOptimisationService_StreamOptimisationClient[optimisationpb.OptimisationProgress](
+		suite.T(),
+	)
+	for _, p := range progress {
+		stream.On("Recv").Return(p, nil).Once()
+	}
+	stream.On("Recv").Return(&optimisationpb.OptimisationProgress{Result: result}, nil).Once()
+
+	suite.optiClient.On("StreamOptimisation", mock.Anything, mock.Anything).
+		Return(stream, nil).
+		Once()
+}
+
+// expectOutputStream mocks the simulation service streaming back the given output chunks,
+// followed by err or the end of the stream when err is nil
+func (suite *TestSuite) expectOutputStream(
+	intersectionID string,
+	err error,
+	chunks ...*simulationpb.SimulationOutputChunk,
+) {
+	stream := grpcmocks.NewMockSimulationService_StreamSimulationOutputClient[simulationpb.SimulationOutputChunk](
+		suite.T(),
+	)
+	for _, chunk := range chunks {
+		stream.On("Recv").Return(chunk, nil).Once()
+	}
+	if err == nil {
+		err = io.EOF
+	}
+	stream.On("Recv").Return(nil, err).Once()
+
+	suite.simClient.On("StreamSimulationOutput", mock.Anything, intersectionID, mock.Anything).
+		Return(stream, nil).
+		Once()
+}
+
+// expectRun mocks the intersection service recording a run and returns a channel that
+// receives the recorded run
+func (suite *TestSuite) expectRun() <-chan model.Run {
+	recorded := make(chan model.Run, 1)
+	suite.intrClient.On("CreateRun", mock.Anything, mock.AnythingOfType("model.Run")).
+		Run(func(args mock.Arguments) { recorded <- args.Get(1).(model.Run) }).
+		Return(&intersectionpb.RunResponse{}, nil).
+		Once()
+	return recorded
+}
+
+// waitForRun waits for a run expected by expectRun to be recorded
+func (suite *TestSuite) waitForRun(recorded <-chan model.Run) model.Run {
+	select {
+	case run := <-recorded:
+		return run
+	case <-time.After(jobWaitTimeout):
+		suite.FailNow("run was not recorded")
+		return model.Run{}
+	}
+}
+
+// waitForNotification waits for the user to be sent a notification, which happens after the
+// job's run is recorded
+func (suite *TestSuite) waitForNotification(userID string) model.Notification {
+	var notifications []model.Notification
+	suite.Require().Eventually(func() bool {
+		var err error
+		notifications, err = suite.notifications.GetNotifications(suite.ctx, userID, 10)
+		suite.Require().NoError(err)
+		return len(notifications) > 0
+	}, jobWaitTimeout, time.Millisecond, "notification was not sent")
+	suite.Require().Len(notifications, 1)
+	return notifications[0]
+}
+
+func createTestIntersection(
+	id string,
+	status commonpb.IntersectionStatus,
+) *intersectionpb.IntersectionResponse {
+	now := time.Now()
+	params := &commonpb.OptimisationParameters{
+		OptimisationType: commonpb.OptimisationType_OPTIMISATION_TYPE_GRIDSEARCH,
+		Parameters: &commonpb.SimulationParameters{
+			IntersectionType: commonpb.IntersectionType_INTERSECTION_TYPE_TJUNCTION,
+			Green:            10,
+			Yellow:           3,
+			Red:              7,
+			Speed:            60,
+			Seed:             12345,
+		},
+	}
+	return &intersectionpb.IntersectionResponse{
+		Id:   id,
+		Name: "Test Intersection",
+		Details: &intersectionpb.IntersectionDetails{
+			Address:  "123 Test St",
+			City:     "Pretoria",
+			Province: "Gauteng",
+		},
+		CreatedAt:         timestamppb.New(now),
+		LastRunAt:         timestamppb.New(now),
+		Status:            status,
+		TrafficDensity:    commonpb.TrafficDensity_TRAFFIC_DENSITY_HIGH,
+		DefaultParameters: params,
+		BestParameters:    params,
+		CurrentParameters: params,
+	}
+}
+
+func createTestJob(
+	id, intersectionID string,
+	status intersectionpb.OptimisationJobStatus,
+	previousStatus commonpb.IntersectionStatus,
+) *intersectionpb.OptimisationJobResponse {
+	return &intersectionpb.OptimisationJobResponse{
+		Id:             id,
+		IntersectionId: intersectionID,
+		UserId:         "test-user-id",
+		Status:         status,
+		PreviousStatus: previousStatus,
+		CreatedAt:      timestamppb.Now(),
+	}
+}
+
+func TestService(t *testing.T) {
+	suite.Run(t, new(TestSuite))
+}
@@ -0,0 +1,197 @@
+package simulation
+
+import (
+	"context"
+	"errors"
+	"time"
+
+	grpcmocks "github.com/COS301-SE-2025/Swift-Signals/api-gateway/internal/mocks/grpc_client"
+	"github.com/COS301-SE-2025/Swift-Signals/api-gateway/internal/model"
+	commonpb "github.com/COS301-SE-2025/Swift-Signals/protos/gen/swiftsignals/common/v1"
+	intersectionpb "github.com/COS301-SE-2025/Swift-Signals/protos/gen/swiftsignals/intersection/v1"
+	optimisationpb "github.com/COS301-SE-2025/Swift-Signals/protos/gen/swiftsignals/optimisation/v1"
+	errs "github.com/COS301-SE-2025/Swift-Signals/shared/error"
+	"github.com/stretchr/testify/mock"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+func (suite *TestSuite) TestCancelOptimisationJob_RestoresPreviousStatus() {
+	intersectionID := "intersection-123"
+	intersection := createTestIntersection(
+		intersectionID,
+		commonpb.IntersectionStatus_INTERSECTION_STATUS_OPTIMISING,
+	)
+	job := createTestJob(
+		"job-1",
+		intersectionID,
+		intersectionpb.OptimisationJobStatus_OPTIMISATION_JOB_STATUS_RUNNING,
+		commonpb.IntersectionStatus_INTERSECTION_STATUS_OPTIMISED,
+	)
+	cancelled := createTestJob(
+		"job-1",
+		intersectionID,
+		intersectionpb.OptimisationJobStatus_OPTIMISATION_JOB_STATUS_CANCELLED,
+		commonpb.IntersectionStatus_INTERSECTION_STATUS_OPTIMISED,
+	)
+
+	suite.intrClient.On("GetOptimisationJob", suite.ctx, "job-1").Return(job, nil)
+	suite.expectUserIntersections(intersectionID)
+	suite.intrClient.On("UpdateOptimisationJob", suite.ctx, "job-1",
+		intersectionpb.OptimisationJobStatus_OPTIMISATION_JOB_STATUS_CANCELLED, false,
+		"cancelled by user").
+		Return(cancelled, nil)
+	suite.intrClient.On("UpdateIntersectionStatus", suite.ctx, intersectionID,
+		commonpb.IntersectionStatus_INTERSECTION_STATUS_OPTIMISED).
+		Return(intersection, nil)
+
+	result, err := suite.service.CancelOptimisationJob(suite.ctx, "job-1")
+
+	suite.Require().NoError(err)
+	suite.Equal("OPTIMISATION_JOB_STATUS_CANCELLED", result.Status)
+
+	suite.intrClient.AssertExpectations(suite.T())
+	// NOTE: Only the status is written, so the intersection need not be read
+	suite.intrClient.AssertNotCalled(suite.T(), "GetIntersection", mock.Anything, mock.Anything)
+}
+
+func (suite *TestSuite) TestCancelOptimisationJob_AlreadyFinished() {
+	job := createTestJob(
+		"job-1",
+		"intersection-123",
+		intersectionpb.OptimisationJobStatus_OPTIMISATION_JOB_STATUS_SUCCEEDED,
+		commonpb.IntersectionStatus_INTERSECTION_STATUS_UNOPTIMISED,
+	)
+
+	suite.intrClient.On("GetOptimisationJob", suite.ctx, "job-1").Return(job, nil)
+	suite.expectUserIntersections("intersection-123")
+
+	result, err := suite.service.CancelOptimisationJob(suite.ctx, "job-1")
+
+	suite.Require().NoError(err)
+	suite.Equal("OPTIMISATION_JOB_STATUS_SUCCEEDED", result.Status)
+
+	suite.intrClient.AssertNotCalled(suite.T(), "UpdateOptimisationJob",
+		mock.Anything, mock.Anything, mock.Anything, mock.Anything, mock.Anything)
+	suite.intrClient.AssertNotCalled(suite.T(), "UpdateIntersectionStatus",
+		mock.Anything, mock.Anything, mock.Anything)
+}
+
+func (suite *TestSuite) TestCancelOptimisationJob_Forbidden() {
+	job := createTestJob(
+		"job-1",
+		"intersection-123",
+		intersectionpb.OptimisationJobStatus_OPTIMISATION_JOB_STATUS_RUNNING,
+		commonpb.IntersectionStatus_INTERSECTION_STATUS_UNOPTIMISED,
+	)
+
+	suite.intrClient.On("GetOptimisationJob", suite.ctx, "job-1").Return(job, nil)
+	suite.expectUserIntersections("intersection-456")
+
+	_, err := suite.service.CancelOptimisationJob(suite.ctx, "job-1")
+
+	suite.Require().Error(err)
+	var svcErr *errs.ServiceError
+	suite.Require().True(errors.As(err, &svcErr))
+	suite.Equal(errs.ErrForbidden, svcErr.Code)
+
+	suite.intrClient.AssertNotCalled(suite.T(), "UpdateOptimisationJob",
+		mock.Anything, mock.Anything, mock.Anything, mock.Anything, mock.Anything)
+}
+
+func (suite *TestSuite) TestCancelOptimisationJob_StopsRunningOptimisation() {
+	intersectionID := "intersection-123"
+	intersection := createTestIntersection(
+		intersectionID,
+		commonpb.IntersectionStatus_INTERSECTION_STATUS_UNOPTIMISED,
+	)
+	pending := createTestJob(
+		"job-1",
+		intersectionID,
+		intersectionpb.OptimisationJobStatus_OPTIMISATION_JOB_STATUS_PENDING,
+		commonpb.IntersectionStatus_INTERSECTION_STATUS_UNOPTIMISED,
+	)
+	running := createTestJob(
+		"job-1",
+		intersectionID,
+		intersectionpb.OptimisationJobStatus_OPTIMISATION_JOB_STATUS_RUNNING,
+		commonpb.IntersectionStatus_INTERSECTION_STATUS_UNOPTIMISED,
+	)
+	cancelled := createTestJob(
+		"job-1",
+		intersectionID,
+		intersectionpb.OptimisationJobStatus_OPTIMISATION_JOB_STATUS_CANCELLED,
+		commonpb.IntersectionStatus_INTERSECTION_STATUS_UNOPTIMISED,
+	)
+
+	// Start the job
+	suite.expectUserIntersections(intersectionID)
+	suite.intrClient.On("GetIntersection", suite.ctx, intersectionID).Return(intersection, nil)
+	suite.intrClient.On("CreateOptimisationJob", suite.ctx, intersectionID, "test-user-id",
+		commonpb.IntersectionStatus_INTERSECTION_STATUS_UNOPTIMISED).
+		Return(pending, nil)
+	suite.intrClient.On("UpdateIntersectionStatus", suite.ctx, intersectionID,
+		commonpb.IntersectionStatus_INTERSECTION_STATUS_OPTIMISING).
+		Return(intersection, nil).
+		Once()
+	suite.intrClient.On("UpdateOptimisationJob", mock.Anything, "job-1",
+		intersectionpb.OptimisationJobStatus_OPTIMISATION_JOB_STATUS_RUNNING, false, "").
+		Return(running, nil)
+
+	recorded := suite.expectRun()
+	started := make(chan struct{})
+	stopped := make(chan struct{})
+	stream := grpcmocks.NewMockOptimisationService_StreamOptimisationClient[optimisationpb.OptimisationProgress](
+		suite.T(),
+	)
+	var streamCtx context.Context
+	suite.optiClient.On("StreamOptimisation", mock.Anything, mock.Anything).
+		Run(func(args mock.Arguments) { streamCtx = args.Get(0).(context.Context) }).
+		Return(stream, nil)
+	stream.On("Recv").
+		Run(func(args mock.Arguments) {
+			close(started)
+			<-streamCtx.Done()
+			close(stopped)
+		}).
+		Return(nil, status.Error(codes.Canceled, "context canceled"))
+
+	_, err := suite.service.OptimiseIntersection(suite.ctx, intersectionID)
+	suite.Require().NoError(err)
+
+	select {
+	case <-started:
+	case <-time.After(jobWaitTimeout):
+		suite.FailNow("optimisation job did not start")
+	}
+
+	// Cancel it while the optimiser is running
+	suite.intrClient.On("GetOptimisationJob", suite.ctx, "job-1").Return(running, nil)
+	suite.expectUserIntersections(intersectionID)
+	suite.intrClient.On("UpdateOptimisationJob", suite.ctx, "job-1",
+		intersectionpb.OptimisationJobStatus_OPTIMISATION_JOB_STATUS_CANCELLED, false,
+		"cancelled by user").
+		Return(cancelled, nil)
+	suite.intrClient.On("UpdateIntersectionStatus", suite.ctx, intersectionID,
+		commonpb.IntersectionStatus_INTERSECTION_STATUS_UNOPTIMISED).
+		Return(intersection, nil)
+
+	result, err := suite.service.CancelOptimisationJob(suite.ctx, "job-1")
+	suite.Require().NoError(err)
+	suite.Equal("OPTIMISATION_JOB_STATUS_CANCELLED", result.Status)
+
+	select {
+	case <-stopped:
+	case <-time.After(jobWaitTimeout):
+		suite.FailNow("optimisation was not cancelled")
+	}
+	run := suite.waitForRun(recorded)
+	suite.Equal(model.RunOutcomeCancelled, run.Outcome)
+
+	suite.intrClient.AssertExpectations(suite.T())
+	suite.intrClient.AssertNotCalled(suite.T(), "PutOptimisation",
+		mock.Anything, mock.Anything, mock.Anything, mock.Anything, mock.Anything, mock.Anything)
+	suite.intrClient.AssertNotCalled(suite.T(), "UpdateOptimisationJob", mock.Anything, "job-1",
+		intersectionpb.OptimisationJobStatus_OPTIMISATION_JOB_STATUS_FAILED,
+		mock.Anything, mock.Anything)
+}
@@ -0,0 +1,237 @@
+package simulation
+
+import (
+	"errors"
+
+	"github.com/COS301-SE-2025/Swift-Signals/api-gateway/internal/model"
+	commonpb "github.com/COS301-SE-2025/Swift-Signals/protos/gen/swiftsignals/common/v1"
+	simulationpb "github.com/COS301-SE-2025/Swift-Signals/protos/gen/swiftsignals/simulation/v1"
+	errs "github.com/COS301-SE-2025/Swift-Signals/shared/error"
+	"github.com/stretchr/testify/mock"
+)
+
+// expectSimulation mocks the simulation service returning results for the given green time
+func (suite *TestSuite) expectSimulation(
+	intersectionID string,
+	green int,
+	results *simulationpb.SimulationResultsResponse,
+) {
+	suite.simClient.On(
+		"GetSimulationResults",
+		mock.Anything,
+		intersectionID,
+		mock.MatchedBy(func(params model.SimulationParameters) bool {
+			return params.Green == green
+		}),
+	).Return(results, nil).Once()
+}
+
+func findMetric(comparison model.Comparison, name string) model.MetricComparison {
+	for _, metric := range comparison.Metrics {
+		if metric.Metric == name {
+			return metric
+		}
+	}
+	return model.MetricComparison{}
+}
+
+func (suite *TestSuite) TestCompareOptimisation_Success() {
+	intersectionID := "intersection-123"
+	intersection := createTestIntersection(
+		intersectionID,
+		commonpb.IntersectionStatus_INTERSECTION_STATUS_OPTIMISED,
+	)
+	intersection.BestParameters = &commonpb.OptimisationParameters{
+		Parameters: &commonpb.SimulationParameters{
+			IntersectionType: commonpb.IntersectionType_INTERSECTION_TYPE_TJUNCTION,
+			Green:            20,
+		},
+	}
+
+	suite.expectUserIntersections(intersectionID)
+	suite.intrClient.On("GetIntersection", suite.ctx, intersectionID).Return(intersection, nil)
+	suite.expectSimulation(intersectionID, 10, &simulationpb.SimulationResultsResponse{
+		AverageWaitingTime: 40,
+		AverageSpeed:       10,
+		GeneratedVehicles:  100,
+		NearCollisions:     0,
+	})
+	suite.expectSimulation(intersectionID, 20, &simulationpb.SimulationResultsResponse{
+		AverageWaitingTime: 30,
+		AverageSpeed:       8,
+		GeneratedVehicles:  100,
+		NearCollisions:     2,
+	})
+
+	result, err := suite.service.CompareOptimisation(suite.ctx, intersectionID, 1)
+
+	suite.Require().NoError(err)
+	suite.Equal(intersectionID, result.IntersectionID)
+	suite.Equal(model.ComparisonSourceDefault, result.Baseline.Source)
+	suite.Equal(10, result.Baseline.Parameters.Green)
+	suite.Equal(model.ComparisonSourceOptimised, result.Candidate.Source)
+	suite.Equal(20, result.Candidate.Parameters.Green)
+
+	waiting := findMetric(result, "average_waiting_time")
+	suite.Equal(model.MetricDirectionLower, waiting.Direction)
+	suite.Equal(40.0, waiting.Baseline)
+	suite.Equal(30.0, waiting.Candidate)
+	suite.Equal(-10.0, waiting.Delta)
+	suite.Require().NotNil(waiting.DeltaPercent)
+	suite.Equal(-25.0, *waiting.DeltaPercent)
+	suite.True(waiting.Improved)
+
+	speed := findMetric(result, "average_speed")
+	suite.Equal(model.MetricDirectionHigher, speed.Direction)
+	suite.False(speed.Improved)
+
+	generated := findMetric(result, "generated_vehicles")
+	suite.Equal(model.MetricDirectionNone, generated.Direction)
+	suite.False(generated.Improved)
+
+	// NOTE: A change from zero has no percentage
+	collisions := findMetric(result, "near_collisions")
+	suite.Equal(2.0, collisions.Delta)
+	suite.Nil(collisions.DeltaPercent)
+	suite.False(collisions.Improved)
+
+	suite.simClient.AssertNumberOfCalls(suite.T(), "GetSimulationResults", 2)
+}
+
+func (suite *TestSuite) TestCompareOptimisation_NotOptimised() {
+	intersectionID := "intersection-123"
+	intersection := createTestIntersection(
+		intersectionID,
+		commonpb.IntersectionStatus_INTERSECTION_STATUS_UNOPTIMISED,
+	)
+	intersection.BestParameters = nil
+
+	suite.expectUserIntersections(intersectionID)
+	suite.intrClient.On("GetIntersection", suite.ctx, intersectionID).Return(intersection, nil)
+
+	_, err := suite.service.CompareOptimisation(suite.ctx, intersectionID, 1)
+
+	suite.Require().Error(err)
+	var svcErr *errs.ServiceError
+	suite.Require().True(errors.As(err, &svcErr))
+	suite.Equal(errs.ErrNotFound, svcErr.Code)
+	suite.simClient.AssertNotCalled(suite.T(), "GetSimulationResults",
+		mock.Anything, mock.Anything, mock.Anything)
+}
+
+func (suite *TestSuite) TestCompareOptimisation_SimulationError() {
+	intersectionID := "intersection-123"
+	intersection := createTestIntersection(
+		intersectionID,
+		commonpb.IntersectionStatus_INTERSECTION_STATUS_OPTIMISED,
+	)
+
+	suite.expectUserIntersections(intersectionID)
+	suite.intrClient.On("GetIntersection", suite.ctx, intersectionID).Return(intersection, nil)
+	suite.simClient.On("GetSimulationResults", mock.Anything, intersectionID, mock.Anything).
+		Return(nil, errs.NewUnavailableError("simulation service unavailable", map[string]any{}))
+
+	_, err := suite.service.CompareOptimisation(suite.ctx, intersectionID, 1)
+
+	suite.Require().Error(err)
+	var svcErr *errs.ServiceError
+	suite.Require().True(errors.As(err, &svcErr))
+	suite.Equal(errs.ErrUnavailable, svcErr.Code)
+}
+
+func (suite *TestSuite) TestCompareRuns_Success() {
+	intersectionID := "intersection-123"
+	baseline := createTestRun("run-1", intersectionID)
+	candidate := createTestRun("run-2", intersectionID)
+	candidate.Metrics = &simulationpb.SimulationResultsResponse{AverageWaitingTime: 21}
+
+	suite.expectUserIntersections(intersectionID)
+	suite.intrClient.On("GetRun", suite.ctx, intersectionID, "run-1").Return(baseline, nil)
+	suite.intrClient.On("GetRun", suite.ctx, intersectionID, "run-2").Return(candidate, nil)
+
+	result, err := suite.service.CompareRuns(suite.ctx, intersectionID, "run-1", "run-2")
+
+	suite.Require().NoError(err)
+	suite.Equal(model.ComparisonSourceRun, result.Baseline.Source)
+	suite.Equal("run-1", result.Baseline.RunID)
+	suite.Equal("run-2", result.Candidate.RunID)
+
+	waiting := findMetric(result, "average_waiting_time")
+	suite.Equal(-21.0, waiting.Delta)
+	suite.Require().NotNil(waiting.DeltaPercent)
+	suite.Equal(-50.0, *waiting.DeltaPercent)
+	suite.True(waiting.Improved)
+
+	// NOTE: Stored runs are compared as they are, without simulating again
+	suite.simClient.AssertNotCalled(suite.T(), "GetSimulationResults",
+		mock.Anything, mock.Anything, mock.Anything)
+}
+
+func (suite *TestSuite) TestCompareRuns_RunWithoutMetrics() {
+	intersectionID := "intersection-123"
+	failed := createTestRun("run-2", intersectionID)
+	failed.Metrics = nil
+
+	suite.expectUserIntersections(intersectionID)
+	suite.intrClient.On("GetRun", suite.ctx, intersectionID, "run-1").
+		Return(createTestRun("run-1", intersectionID), nil)
+	suite.intrClient.On("GetRun", suite.ctx, intersectionID, "run-2").Return(failed, nil)
+
+	_, err := suite.service.CompareRuns(suite.ctx, intersectionID, "run-1", "run-2")
+
+	suite.Require().Error(err)
+	var svcErr *errs.ServiceError
+	suite.Require().True(errors.As(err, &svcErr))
+	suite.Equal(errs.ErrValidation, svcErr.Code)
+}
+
+func (suite *TestSuite) TestCompareParameters_Success() {
+	intersectionID := "intersection-123"
+	baseline := createWhatIfParameters()
+	candidate := createWhatIfParameters()
+	candidate.Green = 35
+
+	suite.expectUserIntersections(intersectionID)
+	suite.expectSimulation(intersectionID, 25, &simulationpb.SimulationResultsResponse{
+		TotalVehicles: 80,
+	})
+	suite.expectSimulation(intersectionID, 35, &simulationpb.SimulationResultsResponse{
+		TotalVehicles: 100,
+	})
+
+	result, err := suite.service.CompareParameters(
+		suite.ctx,
+		intersectionID,
+		baseline,
+		candidate,
+		1,
+	)
+
+	suite.Require().NoError(err)
+	suite.Equal(model.ComparisonSourceParameters, result.Baseline.Source)
+	suite.Equal(baseline, result.Baseline.Parameters)
+	suite.Equal(candidate, result.Candidate.Parameters)
+
+	vehicles := findMetric(result, "total_vehicles")
+	suite.Equal(20.0, vehicles.Delta)
+	suite.Require().NotNil(vehicles.DeltaPercent)
+	suite.Equal(25.0, *vehicles.DeltaPercent)
+	suite.True(vehicles.Improved)
+}
+
+func (suite *TestSuite) TestCompareParameters_Forbidden() {
+	suite.expectUserIntersections("intersection-456")
+
+	_, err := suite.service.CompareParameters(
+		suite.ctx,
+		"intersection-123",
+		createWhatIfParameters(),
+		createWhatIfParameters(),
+		1,
+	)
+
+	suite.Require().Error(err)
+	var svcErr *errs.ServiceError
+	suite.Require().True(errors.As(err, &svcErr))
+	suite.Equal(errs.ErrForbidden, svcErr.Code)
+}
@@ -0,0 +1,91 @@
+package simulation
+
+import (
+	"errors"
+
+	commonpb "github.com/COS301-SE-2025/Swift-Signals/protos/gen/swiftsignals/common/v1"
+	intersectionpb "github.com/COS301-SE-2025/Swift-Signals/protos/gen/swiftsignals/intersection/v1"
+	errs "github.com/COS301-SE-2025/Swift-Signals/shared/error"
+)
+
+func (suite *TestSuite) TestGetOptimisationJob_Success() {
+	job := createTestJob(
+		"job-1",
+		"intersection-123",
+		intersectionpb.OptimisationJobStatus_OPTIMISATION_JOB_STATUS_SUCCEEDED,
+		commonpb.IntersectionStatus_INTERSECTION_STATUS_UNOPTIMISED,
+	)
+	job.Improved = true
+
+	suite.intrClient.On("GetOptimisationJob", suite.ctx, "job-1").Return(job, nil)
+	suite.expectUserIntersections("intersection-123")
+
+	result, err := suite.service.GetOptimisationJob(suite.ctx, "job-1")
+
+	suite.Require().NoError(err)
+	suite.Equal("job-1", result.ID)
+	suite.Equal("OPTIMISATION_JOB_STATUS_SUCCEEDED", result.Status)
+	suite.True(result.Improved)
+	suite.Nil(result.StartedAt)
+
+	suite.intrClient.AssertExpectations(suite.T())
+	suite.userClient.AssertExpectations(suite.T())
+}
+
+func (suite *TestSuite) TestGetOptimisationJob_Forbidden() {
+	job := createTestJob(
+		"job-1",
+		"intersection-123",
+		intersectionpb.OptimisationJobStatus_OPTIMISATION_JOB_STATUS_RUNNING,
+		commonpb.IntersectionStatus_INTERSECTION_STATUS_UNOPTIMISED,
+	)
+
+	suite.intrClient.On("GetOptimisationJob", suite.ctx, "job-1").Return(job, nil)
+	suite.expectUserIntersections("intersection-456")
+
+	_, err := suite.service.GetOptimisationJob(suite.ctx, "job-1")
+
+	suite.Require().Error(err)
+	var svcErr *errs.ServiceError
+	suite.Require().True(errors.As(err, &svcErr))
+	suite.Equal(errs.ErrForbidden, svcErr.Code)
+}
+
+func (suite *TestSuite) TestGetOptimisationJobs_Success() {
+	intersectionID := "intersection-123"
+	running := createTestJob(
+		"job-2",
+		intersectionID,
+		intersectionpb.OptimisationJobStatus_OPTIMISATION_JOB_STATUS_RUNNING,
+		commonpb.IntersectionStatus_INTERSECTION_STATUS_OPTIMISED,
+	)
+	cancelled := createTestJob(
+		"job-1",
+		intersectionID,
+		intersectionpb.OptimisationJobStatus_OPTIMISATION_JOB_STATUS_CANCELLED,
+		commonpb.IntersectionStatus_INTERSECTION_STATUS_UNOPTIMISED,
+	)
+
+	suite.expectUserIntersections(intersectionID)
+	suite.expectOptimisationJobs(intersectionID, nil, running, cancelled)
+
+	result, err := suite.service.GetOptimisationJobs(suite.ctx, intersectionID)
+
+	suite.Require().NoError(err)
+	suite.Require().Len(result.Jobs, 2)
+	suite.Equal("job-2", result.Jobs[0].ID)
+	suite.Equal("OPTIMISATION_JOB_STATUS_CANCELLED", result.Jobs[1].Status)
+
+	suite.intrClient.AssertExpectations(suite.T())
+}
+
+func (suite *TestSuite) TestGetOptimisationJobs_Forbidden() {
+	suite.expectUserIntersections("intersection-456")
+
+	_, err := suite.service.GetOptimisationJobs(suite.ctx, "intersection-123")
+
+	suite.Require().Error(err)
+	var svcErr *errs.ServiceError
+	suite.Require().True(errors.As(err, &svcErr))
+	suite.Equal(errs.ErrForbidden, svcErr.Code)
+}
@@ -0,0 +1,213 @@
+package simulation
+
+import (
+	"errors"
+	"time"
+
+	grpcmocks "github.com/COS301-SE-2025/Swift-Signals/api-gateway/internal/mocks/grpc_client"
+	"github.com/COS301-SE-2025/Swift-Signals/api-gateway/internal/model"
+	commonpb "github.com/COS301-SE-2025/Swift-Signals/protos/gen/swiftsignals/common/v1"
+	intersectionpb "github.com/COS301-SE-2025/Swift-Signals/protos/gen/swiftsignals/intersection/v1"
+	optimisationpb "github.com/COS301-SE-2025/Swift-Signals/protos/gen/swiftsignals/optimisation/v1"
+	simulationpb "github.com/COS301-SE-2025/Swift-Signals/protos/gen/swiftsignals/simulation/v1"
+	errs "github.com/COS301-SE-2025/Swift-Signals/shared/error"
+	"github.com/stretchr/testify/mock"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+// nextEvent waits for the next optimisation event on a subscription
+func (suite *TestSuite) nextEvent(events <-chan model.OptimisationEvent) model.OptimisationEvent {
+	select {
+	case event := <-events:
+		return event
+	case <-time.After(jobWaitTimeout):
+		suite.FailNow("no optimisation event received")
+		return model.OptimisationEvent{}
+	}
+}
+
+// expectOptimisationJobStart mocks the intersection service calls made while starting
+// an optimisation job
+func (suite *TestSuite) expectOptimisationJobStart(
+	intersection *intersectionpb.IntersectionResponse,
+	job *intersectionpb.OptimisationJobResponse,
+) {
+	suite.expectUserIntersections(intersection.Id)
+	suite.intrClient.On("GetIntersection", suite.ctx, intersection.Id).Return(intersection, nil)
+	suite.intrClient.On("CreateOptimisationJob", suite.ctx, intersection.Id, "test-user-id",
+		intersection.Status).
+		Return(job, nil)
+	suite.intrClient.On("UpdateIntersectionStatus", suite.ctx, intersection.Id,
+		commonpb.IntersectionStatus_INTERSECTION_STATUS_OPTIMISING).
+		Return(intersection, nil)
+	suite.intrClient.On("UpdateOptimisationJob", mock.Anything, job.Id,
+		intersectionpb.OptimisationJobStatus_OPTIMISATION_JOB_STATUS_RUNNING, false, "").
+		Return(job, nil)
+}
+
+func (suite *TestSuite) TestSubscribeOptimisationEvents_ReceivesProgress() {
+	intersectionID := "intersection-123"
+	intersection := createTestIntersection(
+		intersectionID,
+		commonpb.IntersectionStatus_INTERSECTION_STATUS_UNOPTIMISED,
+	)
+	job := createTestJob(
+		"job-1",
+		intersectionID,
+		intersectionpb.OptimisationJobStatus_OPTIMISATION_JOB_STATUS_PENDING,
+		commonpb.IntersectionStatus_INTERSECTION_STATUS_UNOPTIMISED,
+	)
+	best := &commonpb.SimulationParameters{Green: 20, Yellow: 4, Red: 30, Speed: 60, Seed: 1408}
+	metrics := &simulationpb.SimulationResultsResponse{AverageWaitingTime: 12}
+
+	suite.expectUserIntersections(intersectionID)
+	events, unsubscribe, err := suite.service.SubscribeOptimisationEvents(
+		suite.ctx,
+		intersectionID,
+	)
+	suite.Require().NoError(err)
+	defer unsubscribe()
+
+	suite.expectOptimisationJobStart(intersection, job)
+	suite.expectOptimisationStream(
+		&commonpb.OptimisationParameters{Parameters: best},
+		&optimisationpb.OptimisationProgress{
+			Generation:       1,
+			TotalGenerations: 30,
+			BestFitness:      1532.7,
+			BestParameters:   best,
+			Candidates: []*optimisationpb.OptimisationCandidate{
+				{Parameters: best, Fitness: 1532.7},
+				{Parameters: intersection.DefaultParameters.Parameters, Fitness: 1780.2},
+			},
+		},
+	)
+	suite.simClient.On("GetSimulationResults", mock.Anything, intersectionID, mock.Anything).
+		Return(metrics, nil)
+	suite.intrClient.On("PutOptimisation", mock.Anything, intersectionID, mock.Anything, metrics,
+		"test-user-id", job.Id).
+		Return(&intersectionpb.PutOptimisationResponse{Improved: true}, nil)
+	suite.intrClient.On("UpdateOptimisationJob", mock.Anything, "job-1",
+		intersectionpb.OptimisationJobStatus_OPTIMISATION_JOB_STATUS_SUCCEEDED, true, "").
+		Return(job, nil)
+	recorded := suite.expectRun()
+
+	_, err = suite.service.OptimiseIntersection(suite.ctx, intersectionID)
+	suite.Require().NoError(err)
+
+	event := suite.nextEvent(events)
+	suite.Equal(model.OptimisationEventJob, event.Type)
+
+	event = suite.nextEvent(events)
+	suite.Require().Equal(model.OptimisationEventProgress, event.Type)
+	progress, ok := event.Data.(model.OptimisationProgress)
+	suite.Require().True(ok)
+	suite.Equal("job-1", progress.JobID)
+	suite.Equal(1, progress.Generation)
+	suite.Equal(30, progress.TotalGenerations)
+	suite.InDelta(1532.7, progress.BestFitness, 0.001)
+	suite.Require().NotNil(progress.BestParameters)
+	suite.Equal(20, progress.BestParameters.Green)
+	suite.Require().Len(progress.Candidates, 2)
+	suite.InDelta(1780.2, progress.Candidates[1].Fitness, 0.001)
+
+	event = suite.nextEvent(events)
+	suite.Require().Equal(model.OptimisationEventJob, event.Type)
+	finished, ok := event.Data.(model.OptimisationJob)
+	suite.Require().True(ok)
+	suite.Equal("OPTIMISATION_JOB_STATUS_SUCCEEDED", finished.Status)
+	suite.True(finished.Improved)
+	suite.NotNil(finished.FinishedAt)
+
+	suite.waitForRun(recorded)
+}
+
+func (suite *TestSuite) TestSubscribeOptimisationEvents_ReplaysLatestProgress() {
+	intersectionID := "intersection-123"
+	intersection := createTestIntersection(
+		intersectionID,
+		commonpb.IntersectionStatus_INTERSECTION_STATUS_UNOPTIMISED,
+	)
+	job := createTestJob(
+		"job-1",
+		intersectionID,
+		intersectionpb.OptimisationJobStatus_OPTIMISATION_JOB_STATUS_PENDING,
+		commonpb.IntersectionStatus_INTERSECTION_STATUS_UNOPTIMISED,
+	)
+
+	// Keep the job between generations until the test has subscribed
+	stream := grpcmocks.NewMockOptimisationService_StreamOptimisationClient[optimisationpb.OptimisationProgress](
+		suite.T(),
+	)
+	generated := make(chan struct{})
+	release := make(chan struct{})
+	stream.On("Recv").
+		Return(&optimisationpb.OptimisationProgress{Generation: 3, TotalGenerations: 30}, nil).
+		Once()
+	stream.On("Recv").
+		Run(func(args mock.Arguments) {
+			close(generated)
+			<-release
+		}).
+		Return(nil, status.Error(codes.Internal, "optimisation failed")).
+		Once()
+	suite.optiClient.On("StreamOptimisation", mock.Anything, mock.Anything).Return(stream, nil)
+
+	suite.expectOptimisationJobStart(intersection, job)
+	suite.intrClient.On("UpdateOptimisationJob", mock.Anything, "job-1",
+		intersectionpb.OptimisationJobStatus_OPTIMISATION_JOB_STATUS_FAILED, false, mock.Anything).
+		Return(job, nil)
+	suite.intrClient.On("FailIntersection", mock.Anything, intersectionID, mock.Anything).
+		Return(intersection, nil)
+	recorded := suite.expectRun()
+
+	_, err := suite.service.OptimiseIntersection(suite.ctx, intersectionID)
+	suite.Require().NoError(err)
+
+	select {
+	case <-generated:
+	case <-time.After(jobWaitTimeout):
+		suite.FailNow("optimisation did not progress")
+	}
+
+	suite.expectUserIntersections(intersectionID)
+	events, unsubscribe, err := suite.service.SubscribeOptimisationEvents(
+		suite.ctx,
+		intersectionID,
+	)
+	suite.Require().NoError(err)
+	defer unsubscribe()
+
+	event := suite.nextEvent(events)
+	suite.Require().Equal(model.OptimisationEventProgress, event.Type)
+	suite.Equal(3, event.Data.(model.OptimisationProgress).Generation)
+
+	close(release)
+
+	event = suite.nextEvent(events)
+	suite.Require().Equal(model.OptimisationEventJob, event.Type)
+	finished := event.Data.(model.OptimisationJob)
+	suite.Equal("OPTIMISATION_JOB_STATUS_FAILED", finished.Status)
+	suite.NotEmpty(finished.Error)
+
+	run := suite.waitForRun(recorded)
+	suite.Equal(model.RunOutcomeFailed, run.Outcome)
+}
+
+func (suite *TestSuite) TestSubscribeOptimisationEvents_Forbidden() {
+	suite.expectUserIntersections("other-intersection")
+
+	events, unsubscribe, err := suite.service.SubscribeOptimisationEvents(
+		suite.ctx,
+		"intersection-123",
+	)
+
+	suite.Require().Error(err)
+	suite.Nil(events)
+	suite.Nil(unsubscribe)
+
+	var svcErr *errs.ServiceError
+	suite.Require().True(errors.As(err, &svcErr))
+	suite.Equal(errs.ErrForbidden, svcErr.Code)
+}
@@ -0,0 +1,146 @@
+package simulation
+
+import (
+	"context"
+	"time"
+
+	grpcmocks "github.com/COS301-SE-2025/Swift-Signals/api-gateway/internal/mocks/grpc_client"
+	"github.com/COS301-SE-2025/Swift-Signals/api-gateway/internal/model"
+	"github.com/COS301-SE-2025/Swift-Signals/api-gateway/internal/service"
+	commonpb "github.com/COS301-SE-2025/Swift-Signals/protos/gen/swiftsignals/common/v1"
+	intersectionpb "github.com/COS301-SE-2025/Swift-Signals/protos/gen/swiftsignals/intersection/v1"
+	optimisationpb "github.com/COS301-SE-2025/Swift-Signals/protos/gen/swiftsignals/optimisation/v1"
+	errs "github.com/COS301-SE-2025/Swift-Signals/shared/error"
+	"github.com/stretchr/testify/mock"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+// expectBlockingOptimisation mocks an optimiser that keeps running until its context is
+// cancelled
+func (suite *TestSuite) expectBlockingOptimisation() {
+	stream := grpcmocks.NewMockOptimisationService_StreamOptimisationClient[optimisationpb.OptimisationProgress](
+		suite.T(),
+	)
+	var streamCtx context.Context
+	suite.optiClient.On("StreamOptimisation", mock.Anything, mock.Anything).
+		Run(func(args mock.Arguments) { streamCtx = args.Get(0).(context.Context) }).
+		Return(stream, nil)
+	stream.On("Recv").
+		Run(func(args mock.Arguments) { <-streamCtx.Done() }).
+		Return(nil, status.Error(codes.Canceled, "context canceled"))
+}
+
+func (suite *TestSuite) useHeartbeatInterval(interval time.Duration) {
+	suite.service = service.NewSimulationService(
+		suite.intrClient,
+		suite.optiClient,
+		suite.userClient,
+		suite.simClient,
+		service.ReplicationConfig{Concurrency: 2, MaxReplications: 10},
+		service.SweepConfig{},
+		service.OptimisationConfig{HeartbeatInterval: interval},
+		suite.notifier,
+	)
+}
+
+func (suite *TestSuite) TestOptimiseIntersection_RenewsLease() {
+	suite.useHeartbeatInterval(5 * time.Millisecond)
+
+	intersectionID := "intersection-123"
+	intersection := createTestIntersection(
+		intersectionID,
+		commonpb.IntersectionStatus_INTERSECTION_STATUS_UNOPTIMISED,
+	)
+	job := createTestJob(
+		"job-1",
+		intersectionID,
+		intersectionpb.OptimisationJobStatus_OPTIMISATION_JOB_STATUS_RUNNING,
+		commonpb.IntersectionStatus_INTERSECTION_STATUS_UNOPTIMISED,
+	)
+
+	suite.expectOptimisationJobStart(intersection, job)
+	recorded := suite.expectRun()
+	renewed := make(chan struct{}, 10)
+	suite.intrClient.On("RenewOptimisationLease", mock.Anything, "job-1").
+		Run(func(args mock.Arguments) {
+			select {
+			case renewed <- struct{}{}:
+			default:
+			}
+		}).
+		Return(job, nil)
+	suite.expectBlockingOptimisation()
+
+	_, err := suite.service.OptimiseIntersection(suite.ctx, intersectionID)
+	suite.Require().NoError(err)
+
+	for range 3 {
+		select {
+		case <-renewed:
+		case <-time.After(jobWaitTimeout):
+			suite.FailNow("optimisation job did not renew its lease")
+		}
+	}
+
+	// Cancel the job so that it stops renewing
+	suite.intrClient.On("GetOptimisationJob", suite.ctx, "job-1").Return(job, nil)
+	suite.expectUserIntersections(intersectionID)
+	suite.intrClient.On("UpdateOptimisationJob", suite.ctx, "job-1",
+		intersectionpb.OptimisationJobStatus_OPTIMISATION_JOB_STATUS_CANCELLED, false,
+		"cancelled by user").
+		Return(job, nil)
+	suite.intrClient.On("UpdateIntersectionStatus", suite.ctx, intersectionID,
+		commonpb.IntersectionStatus_INTERSECTION_STATUS_UNOPTIMISED).
+		Return(intersection, nil)
+
+	_, err = suite.service.CancelOptimisationJob(suite.ctx, "job-1")
+	suite.Require().NoError(err)
+
+	run := suite.waitForRun(recorded)
+	suite.Equal(model.RunOutcomeCancelled, run.Outcome)
+}
+
+func (suite *TestSuite) TestOptimiseIntersection_LeaseLost() {
+	suite.useHeartbeatInterval(5 * time.Millisecond)
+
+	intersectionID := "intersection-123"
+	intersection := createTestIntersection(
+		intersectionID,
+		commonpb.IntersectionStatus_INTERSECTION_STATUS_UNOPTIMISED,
+	)
+	job := createTestJob(
+		"job-1",
+		intersectionID,
+		intersectionpb.OptimisationJobStatus_OPTIMISATION_JOB_STATUS_RUNNING,
+		commonpb.IntersectionStatus_INTERSECTION_STATUS_UNOPTIMISED,
+	)
+
+	suite.expectOptimisationJobStart(intersection, job)
+	recorded := suite.expectRun()
+	// NOTE: The intersection service already failed the job as stale
+	suite.intrClient.On("RenewOptimisationLease", mock.Anything, "job-1").
+		Return(nil, errs.NewConflictError("optimisation job already finished", map[string]any{})).
+		Once()
+	suite.expectBlockingOptimisation()
+
+	_, err := suite.service.OptimiseIntersection(suite.ctx, intersectionID)
+	suite.Require().NoError(err)
+
+	run := suite.waitForRun(recorded)
+	suite.Equal(model.RunOutcomeFailed, run.Outcome)
+	suite.Contains(run.Error, "lease expired")
+
+	notification := suite.waitForNotification("test-user-id")
+	suite.Equal(model.NotificationTypeOptimisationFailed, notification.Type)
+
+	// The job and its intersection were failed by the intersection service, and the worker
+	// leaves them as they are
+	suite.intrClient.AssertNotCalled(suite.T(), "PutOptimisation",
+		mock.Anything, mock.Anything, mock.Anything, mock.Anything, mock.Anything, mock.Anything)
+	suite.intrClient.AssertNotCalled(suite.T(), "UpdateOptimisationJob", mock.Anything, "job-1",
+		intersectionpb.OptimisationJobStatus_OPTIMISATION_JOB_STATUS_FAILED,
+		mock.Anything, mock.Anything)
+	suite.intrClient.AssertNotCalled(suite.T(), "FailIntersection",
+		mock.Anything, mock.Anything, mock.Anything)
+}
@@ -0,0 +1,342 @@
+package simulation
+
+import (
+	"errors"
+	"time"
+
+	"github.com/COS301-SE-2025/Swift-Signals/api-gateway/internal/model"
+	commonpb "github.com/COS301-SE-2025/Swift-Signals/protos/gen/swiftsignals/common/v1"
+	intersectionpb "github.com/COS301-SE-2025/Swift-Signals/protos/gen/swiftsignals/intersection/v1"
+	simulationpb "github.com/COS301-SE-2025/Swift-Signals/protos/gen/swiftsignals/simulation/v1"
+	errs "github.com/COS301-SE-2025/Swift-Signals/shared/error"
+	"github.com/stretchr/testify/mock"
+)
+
+const jobWaitTimeout = 2 * time.Second
+
+func (suite *TestSuite) TestOptimiseIntersection_Success() {
+	intersectionID := "intersection-123"
+	intersection := createTestIntersection(
+		intersectionID,
+		commonpb.IntersectionStatus_INTERSECTION_STATUS_UNOPTIMISED,
+	)
+	intersection.Name = "Main & 5th"
+	intersection.CurrentMetrics = &simulationpb.SimulationResultsResponse{AverageWaitingTime: 51.2}
+	job := createTestJob(
+		"job-1",
+		intersectionID,
+		intersectionpb.OptimisationJobStatus_OPTIMISATION_JOB_STATUS_PENDING,
+		commonpb.IntersectionStatus_INTERSECTION_STATUS_UNOPTIMISED,
+	)
+	optimisedParams := &commonpb.OptimisationParameters{
+		OptimisationType: commonpb.OptimisationType_OPTIMISATION_TYPE_GRIDSEARCH,
+		Parameters: &commonpb.SimulationParameters{
+			IntersectionType: commonpb.IntersectionType_INTERSECTION_TYPE_TJUNCTION,
+			Green:            14,
+			Yellow:           3,
+			Red:              5,
+			Speed:            60,
+			Seed:             12345,
+		},
+	}
+	metrics := &simulationpb.SimulationResultsResponse{AverageWaitingTime: 42}
+
+	suite.expectUserIntersections(intersectionID)
+	suite.intrClient.On("GetIntersection", suite.ctx, intersectionID).Return(intersection, nil)
+	suite.intrClient.On("CreateOptimisationJob", suite.ctx, intersectionID, "test-user-id",
+		commonpb.IntersectionStatus_INTERSECTION_STATUS_UNOPTIMISED).
+		Return(job, nil)
+	suite.intrClient.On("UpdateIntersectionStatus", suite.ctx, intersectionID,
+		commonpb.IntersectionStatus_INTERSECTION_STATUS_OPTIMISING).
+		Return(intersection, nil)
+
+	recorded := suite.expectRun()
+	suite.intrClient.On("UpdateOptimisationJob", mock.Anything, "job-1",
+		intersectionpb.OptimisationJobStatus_OPTIMISATION_JOB_STATUS_RUNNING, false, "").
+		Return(job, nil)
+	suite.expectOptimisationStream(optimisedParams)
+	suite.simClient.On("GetSimulationResults", mock.Anything, intersectionID,
+		mock.MatchedBy(func(params model.SimulationParameters) bool {
+			return params.Green == 14 && params.Red == 5
+		})).
+		Return(metrics, nil)
+	suite.intrClient.On("PutOptimisation", mock.Anything, intersectionID, mock.Anything, metrics,
+		"test-user-id", "job-1").
+		Return(&intersectionpb.PutOptimisationResponse{Improved: true}, nil)
+	suite.intrClient.On("UpdateOptimisationJob", mock.Anything, "job-1",
+		intersectionpb.OptimisationJobStatus_OPTIMISATION_JOB_STATUS_SUCCEEDED, true, "").
+		Return(job, nil)
+
+	result, err := suite.service.OptimiseIntersection(suite.ctx, intersectionID)
+
+	suite.Require().NoError(err)
+	suite.Equal("job-1", result.ID)
+	suite.Equal(intersectionID, result.IntersectionID)
+	suite.Equal("OPTIMISATION_JOB_STATUS_PENDING", result.Status)
+
+	run := suite.waitForRun(recorded)
+	suite.Equal(model.RunTypeOptimisation, run.Type)
+	suite.Equal(model.RunOutcomeSucceeded, run.Outcome)
+	suite.Equal("job-1", run.JobID)
+	suite.Equal("test-user-id", run.UserID)
+	suite.Require().NotNil(run.Metrics)
+	suite.True(run.Improved)
+	suite.Equal(14, run.Parameters.SimulationParameters.Green)
+	suite.InDelta(42, run.Metrics.AverageWaitingTime, 0.001)
+
+	notification := suite.waitForNotification("test-user-id")
+	suite.Equal(model.NotificationTypeOptimisationCompleted, notification.Type)
+	suite.Equal("Optimisation of Main & 5th finished: waiting time \u221218%", notification.Message)
+	suite.Equal(intersectionID, notification.IntersectionID)
+	suite.False(notification.Read)
+
+	suite.intrClient.AssertExpectations(suite.T())
+	// NOTE: Putting the optimisation marks the intersection as optimised in the same write
+	suite.intrClient.AssertNotCalled(suite.T(), "UpdateIntersectionStatus", mock.Anything,
+		intersectionID, commonpb.IntersectionStatus_INTERSECTION_STATUS_OPTIMISED)
+	suite.optiClient.AssertExpectations(suite.T())
+	suite.simClient.AssertExpectations(suite.T())
+	suite.userClient.AssertExpectations(suite.T())
+}
+
+func (suite *TestSuite) TestOptimiseIntersection_NotImproved() {
+	intersectionID := "intersection-123"
+	intersection := createTestIntersection(
+		intersectionID,
+		commonpb.IntersectionStatus_INTERSECTION_STATUS_OPTIMISED,
+	)
+	job := createTestJob(
+		"job-1",
+		intersectionID,
+		intersectionpb.OptimisationJobStatus_OPTIMISATION_JOB_STATUS_PENDING,
+		commonpb.IntersectionStatus_INTERSECTION_STATUS_OPTIMISED,
+	)
+	metrics := &simulationpb.SimulationResultsResponse{AverageWaitingTime: 90}
+
+	suite.expectUserIntersections(intersectionID)
+	suite.intrClient.On("GetIntersection", suite.ctx, intersectionID).Return(intersection, nil)
+	suite.intrClient.On("CreateOptimisationJob", suite.ctx, intersectionID, "test-user-id",
+		commonpb.IntersectionStatus_INTERSECTION_STATUS_OPTIMISED).
+		Return(job, nil)
+	suite.intrClient.On("UpdateIntersectionStatus", suite.ctx, intersectionID,
+		commonpb.IntersectionStatus_INTERSECTION_STATUS_OPTIMISING).
+		Return(intersection, nil)
+
+	recorded := suite.expectRun()
+	suite.intrClient.On("UpdateOptimisationJob", mock.Anything, "job-1",
+		intersectionpb.OptimisationJobStatus_OPTIMISATION_JOB_STATUS_RUNNING, false, "").
+		Return(job, nil)
+	suite.expectOptimisationStream(intersection.DefaultParameters)
+	suite.simClient.On("GetSimulationResults", mock.Anything, intersectionID, mock.Anything).
+		Return(metrics, nil)
+	suite.intrClient.On("PutOptimisation", mock.Anything, intersectionID, mock.Anything, metrics,
+		"test-user-id", "job-1").
+		Return(&intersectionpb.PutOptimisationResponse{Improved: false}, nil)
+	suite.intrClient.On("UpdateOptimisationJob", mock.Anything, "job-1",
+		intersectionpb.OptimisationJobStatus_OPTIMISATION_JOB_STATUS_SUCCEEDED, false, "").
+		Return(job, nil)
+
+	_, err := suite.service.OptimiseIntersection(suite.ctx, intersectionID)
+	suite.Require().NoError(err)
+
+	run := suite.waitForRun(recorded)
+	suite.Equal(model.RunOutcomeSucceeded, run.Outcome)
+	suite.False(run.Improved)
+
+	notification := suite.waitForNotification("test-user-id")
+	suite.Equal(
+		"Optimisation of Test Intersection finished: no improvement on the best parameters",
+		notification.Message,
+	)
+
+	suite.intrClient.AssertExpectations(suite.T())
+	suite.simClient.AssertExpectations(suite.T())
+}
+
+func (suite *TestSuite) TestOptimiseIntersection_SimulationFailure() {
+	intersectionID := "intersection-123"
+	intersection := createTestIntersection(
+		intersectionID,
+		commonpb.IntersectionStatus_INTERSECTION_STATUS_UNOPTIMISED,
+	)
+	job := createTestJob(
+		"job-1",
+		intersectionID,
+		intersectionpb.OptimisationJobStatus_OPTIMISATION_JOB_STATUS_PENDING,
+		commonpb.IntersectionStatus_INTERSECTION_STATUS_UNOPTIMISED,
+	)
+	simulationErr := errs.NewUnavailableError("simulation service unavailable", map[string]any{})
+
+	suite.expectUserIntersections(intersectionID)
+	suite.intrClient.On("GetIntersection", suite.ctx, intersectionID).Return(intersection, nil)
+	suite.intrClient.On("CreateOptimisationJob", suite.ctx, intersectionID, "test-user-id",
+		commonpb.IntersectionStatus_INTERSECTION_STATUS_UNOPTIMISED).
+		Return(job, nil)
+	suite.intrClient.On("UpdateIntersectionStatus", suite.ctx, intersectionID,
+		commonpb.IntersectionStatus_INTERSECTION_STATUS_OPTIMISING).
+		Return(intersection, nil)
+
+	recorded := suite.expectRun()
+	suite.intrClient.On("UpdateOptimisationJob", mock.Anything, "job-1",
+		intersectionpb.OptimisationJobStatus_OPTIMISATION_JOB_STATUS_RUNNING, false, "").
+		Return(job, nil)
+	suite.expectOptimisationStream(intersection.DefaultParameters)
+	suite.simClient.On("GetSimulationResults", mock.Anything, intersectionID, mock.Anything).
+		Return(nil, simulationErr)
+	suite.intrClient.On("UpdateOptimisationJob", mock.Anything, "job-1",
+		intersectionpb.OptimisationJobStatus_OPTIMISATION_JOB_STATUS_FAILED, false,
+		simulationErr.Error()).
+		Return(job, nil)
+	suite.intrClient.On("FailIntersection", mock.Anything, intersectionID, simulationErr.Error()).
+		Return(intersection, nil)
+
+	_, err := suite.service.OptimiseIntersection(suite.ctx, intersectionID)
+	suite.Require().NoError(err)
+
+	run := suite.waitForRun(recorded)
+	suite.Equal(model.RunOutcomeFailed, run.Outcome)
+	suite.NotEmpty(run.Error)
+
+	notification := suite.waitForNotification("test-user-id")
+	suite.Equal(model.NotificationTypeOptimisationFailed, notification.Type)
+	suite.Equal(
+		"Optimisation of Test Intersection failed: "+simulationErr.Error(),
+		notification.Message,
+	)
+
+	suite.intrClient.AssertExpectations(suite.T())
+	suite.intrClient.AssertNotCalled(suite.T(), "PutOptimisation",
+		mock.Anything, mock.Anything, mock.Anything, mock.Anything, mock.Anything, mock.Anything)
+}
+
+func (suite *TestSuite) TestOptimiseIntersection_OptimiserFailure() {
+	intersectionID := "intersection-123"
+	intersection := createTestIntersection(
+		intersectionID,
+		commonpb.IntersectionStatus_INTERSECTION_STATUS_OPTIMISED,
+	)
+	job := createTestJob(
+		"job-1",
+		intersectionID,
+		intersectionpb.OptimisationJobStatus_OPTIMISATION_JOB_STATUS_PENDING,
+		commonpb.IntersectionStatus_INTERSECTION_STATUS_OPTIMISED,
+	)
+	optimiserErr := errs.NewInternalError("optimiser crashed", nil, map[string]any{})
+
+	suite.expectUserIntersections(intersectionID)
+	suite.intrClient.On("GetIntersection", suite.ctx, intersectionID).Return(intersection, nil)
+	suite.intrClient.On("CreateOptimisationJob", suite.ctx, intersectionID, "test-user-id",
+		commonpb.IntersectionStatus_INTERSECTION_STATUS_OPTIMISED).
+		Return(job, nil)
+	suite.intrClient.On("UpdateIntersectionStatus", suite.ctx, intersectionID,
+		commonpb.IntersectionStatus_INTERSECTION_STATUS_OPTIMISING).
+		Return(intersection, nil)
+
+	recorded := suite.expectRun()
+	suite.intrClient.On("UpdateOptimisationJob", mock.Anything, "job-1",
+		intersectionpb.OptimisationJobStatus_OPTIMISATION_JOB_STATUS_RUNNING, false, "").
+		Return(job, nil)
+	suite.optiClient.On("StreamOptimisation", mock.Anything, mock.Anything).
+		Return(nil, optimiserErr)
+	suite.intrClient.On("UpdateOptimisationJob", mock.Anything, "job-1",
+		intersectionpb.OptimisationJobStatus_OPTIMISATION_JOB_STATUS_FAILED, false,
+		optimiserErr.Error()).
+		Return(job, nil)
+	suite.intrClient.On("FailIntersection", mock.Anything, intersectionID, optimiserErr.Error()).
+		Return(intersection, nil)
+
+	_, err := suite.service.OptimiseIntersection(suite.ctx, intersectionID)
+	suite.Require().NoError(err)
+
+	run := suite.waitForRun(recorded)
+	suite.Equal(model.RunOutcomeFailed, run.Outcome)
+	suite.NotEmpty(run.Error)
+
+	suite.intrClient.AssertExpectations(suite.T())
+	suite.optiClient.AssertExpectations(suite.T())
+	suite.intrClient.AssertNotCalled(suite.T(), "PutOptimisation",
+		mock.Anything, mock.Anything, mock.Anything, mock.Anything, mock.Anything, mock.Anything)
+}
+
+func (suite *TestSuite) TestOptimiseIntersection_JobAlreadyActive() {
+	intersectionID := "intersection-123"
+	intersection := createTestIntersection(
+		intersectionID,
+		commonpb.IntersectionStatus_INTERSECTION_STATUS_OPTIMISING,
+	)
+	conflict := errs.NewAlreadyExistsError(
+		"an optimisation job is already active for this intersection",
+		map[string]any{},
+	)
+
+	suite.expectUserIntersections(intersectionID)
+	suite.intrClient.On("GetIntersection", suite.ctx, intersectionID).Return(intersection, nil)
+	suite.intrClient.On("CreateOptimisationJob", suite.ctx, intersectionID, "test-user-id",
+		commonpb.IntersectionStatus_INTERSECTION_STATUS_OPTIMISING).
+		Return(nil, conflict)
+
+	_, err := suite.service.OptimiseIntersection(suite.ctx, intersectionID)
+
+	suite.Require().Error(err)
+	var svcErr *errs.ServiceError
+	suite.Require().True(errors.As(err, &svcErr))
+	suite.Equal(errs.ErrAlreadyExists, svcErr.Code)
+
+	suite.intrClient.AssertNotCalled(suite.T(), "UpdateIntersectionStatus",
+		mock.Anything, mock.Anything, mock.Anything)
+	suite.optiClient.AssertNotCalled(suite.T(), "StreamOptimisation", mock.Anything, mock.Anything)
+}
+
+func (suite *TestSuite) TestOptimiseIntersection_StatusUpdateFailure() {
+	intersectionID := "intersection-123"
+	intersection := createTestIntersection(
+		intersectionID,
+		commonpb.IntersectionStatus_INTERSECTION_STATUS_UNOPTIMISED,
+	)
+	job := createTestJob(
+		"job-1",
+		intersectionID,
+		intersectionpb.OptimisationJobStatus_OPTIMISATION_JOB_STATUS_PENDING,
+		commonpb.IntersectionStatus_INTERSECTION_STATUS_UNOPTIMISED,
+	)
+	unavailable := errs.NewUnavailableError("intersection service unavailable", map[string]any{})
+
+	suite.expectUserIntersections(intersectionID)
+	suite.intrClient.On("GetIntersection", suite.ctx, intersectionID).Return(intersection, nil)
+	suite.intrClient.On("CreateOptimisationJob", suite.ctx, intersectionID, "test-user-id",
+		commonpb.IntersectionStatus_INTERSECTION_STATUS_UNOPTIMISED).
+		Return(job, nil)
+	suite.intrClient.On("UpdateIntersectionStatus", suite.ctx, intersectionID,
+		commonpb.IntersectionStatus_INTERSECTION_STATUS_OPTIMISING).
+		Return(nil, unavailable)
+	suite.intrClient.On("UpdateOptimisationJob", suite.ctx, "job-1",
+		intersectionpb.OptimisationJobStatus_OPTIMISATION_JOB_STATUS_FAILED, false,
+		unavailable.Error()).
+		Return(job, nil)
+
+	_, err := suite.service.OptimiseIntersection(suite.ctx, intersectionID)
+
+	suite.Require().Error(err)
+	var svcErr *errs.ServiceError
+	suite.Require().True(errors.As(err, &svcErr))
+	suite.Equal(errs.ErrUnavailable, svcErr.Code)
+
+	suite.intrClient.AssertExpectations(suite.T())
+	suite.intrClient.AssertNotCalled(suite.T(), "UpdateOptimisationJob", mock.Anything, "job-1",
+		intersectionpb.OptimisationJobStatus_OPTIMISATION_JOB_STATUS_RUNNING, false, "")
+	suite.optiClient.AssertNotCalled(suite.T(), "StreamOptimisation", mock.Anything, mock.Anything)
+}
+
+func (suite *TestSuite) TestOptimiseIntersection_Forbidden() {
+	suite.expectUserIntersections("intersection-456")
+
+	_, err := suite.service.OptimiseIntersection(suite.ctx, "intersection-123")
+
+	suite.Require().Error(err)
+	var svcErr *errs.ServiceError
+	suite.Require().True(errors.As(err, &svcErr))
+	suite.Equal(errs.ErrForbidden, svcErr.Code)
+
+	suite.intrClient.AssertNotCalled(suite.T(), "CreateOptimisationJob",
+		mock.Anything, mock.Anything, mock.Anything, mock.Anything)
+}
@@ -0,0 +1,171 @@
+package simulation
+
+import (
+	"github.com/COS301-SE-2025/Swift-Signals/api-gateway/internal/model"
+	commonpb "github.com/COS301-SE-2025/Swift-Signals/protos/gen/swiftsignals/common/v1"
+	simulationpb "github.com/COS301-SE-2025/Swift-Signals/protos/gen/swiftsignals/simulation/v1"
+	"github.com/stretchr/testify/mock"
+)
+
+func createTestVehicles() []*simulationpb.Vehicle {
+	positions := func() []*simulationpb.Position {
+		positions := []*simulationpb.Position{}
+		for t := range 10 {
+			positions = append(positions, &simulationpb.Position{
+				Time:  int32(t),
+				X:     float32(t),
+				Y:     float32(t),
+				Speed: 10,
+			})
+		}
+		return positions
+	}
+	return []*simulationpb.Vehicle{
+		{Id: "vehicle-1", Positions: positions()},
+		{Id: "vehicle-2", Positions: positions()},
+		{Id: "vehicle-3", Positions: positions()[8:]},
+	}
+}
+
+// simulateWithFilter runs GetSimulationData over createTestVehicles with the given filter
+func (suite *TestSuite) simulateWithFilter(filter model.OutputFilter) []model.SimulationVehicle {
+	intersectionID := "intersection-123"
+	intersection := createTestIntersection(
+		intersectionID,
+		commonpb.IntersectionStatus_INTERSECTION_STATUS_UNOPTIMISED,
+	)
+
+	suite.expectUserIntersections(intersectionID)
+	suite.intrClient.On("GetIntersection", suite.ctx, intersectionID).Return(intersection, nil)
+	suite.simClient.On("RunSimulation", mock.Anything, intersectionID, mock.Anything).
+		Return(&simulationpb.SimulationResponse{
+			Results: &simulationpb.SimulationResultsResponse{},
+			Output: &simulationpb.SimulationOutputResponse{
+				Intersection: &simulationpb.Intersection{},
+				Vehicles:     createTestVehicles(),
+			},
+		}, nil)
+	recorded := suite.expectRun()
+
+	result, err := suite.service.GetSimulationData(suite.ctx, intersectionID, filter)
+	suite.Require().NoError(err)
+	suite.waitForRun(recorded)
+
+	return result.Output.Vehicles
+}
+
+func (suite *TestSuite) TestGetSimulationData_NoFilter() {
+	vehicles := suite.simulateWithFilter(model.OutputFilter{})
+
+	suite.Require().Len(vehicles, 3)
+	suite.Len(vehicles[0].Positions, 10)
+	suite.Require().NotNil(vehicles[0].Positions[0].Speed)
+	suite.Equal(10.0, *vehicles[0].Positions[0].Speed)
+}
+
+func (suite *TestSuite) TestGetSimulationData_FilterTimeWindow() {
+	from, to := 2, 5
+	vehicles := suite.simulateWithFilter(model.OutputFilter{From: &from, To: &to})
+
+	// NOTE: vehicle-3 has no positions inside the window and is dropped
+	suite.Require().Len(vehicles, 2)
+	for _, vehicle := range vehicles {
+		suite.Require().Len(vehicle.Positions, 4)
+		suite.Equal(2, vehicle.Positions[0].Time)
+		suite.Equal(5, vehicle.Positions[3].Time)
+	}
+}
+
+func (suite *TestSuite) TestGetSimulationData_FilterVehicles() {
+	vehicles := suite.simulateWithFilter(model.OutputFilter{
+		VehicleIDs: []string{"vehicle-2", "vehicle-3", "vehicle-unknown"},
+	})
+
+	suite.Require().Len(vehicles, 2)
+	suite.Equal("vehicle-2", vehicles[0].ID)
+	suite.Equal("vehicle-3", vehicles[1].ID)
+}
+
+func (suite *TestSuite) TestGetSimulationData_FilterSampling() {
+	vehicles := suite.simulateWithFilter(model.OutputFilter{
+		VehicleIDs:  []string{"vehicle-1"},
+		SampleEvery: 3,
+	})
+
+	suite.Require().Len(vehicles, 1)
+	times := []int{}
+	for _, position := range vehicles[0].Positions {
+		times = append(times, position.Time)
+	}
+	suite.Equal([]int{0, 3, 6, 9}, times)
+}
+
+func (suite *TestSuite) TestGetSimulationData_FilterOmitSpeed() {
+	vehicles := suite.simulateWithFilter(model.OutputFilter{OmitSpeed: true})
+
+	suite.Require().Len(vehicles, 3)
+	for _, vehicle := range vehicles {
+		for _, position := range vehicle.Positions {
+			suite.Nil(position.Speed)
+		}
+	}
+}
+
+func (suite *TestSuite) TestStreamSimulationOutput_FilterKeepsIntersectionChunk() {
+	intersectionID := "intersection-123"
+	intersection := createTestIntersection(
+		intersectionID,
+		commonpb.IntersectionStatus_INTERSECTION_STATUS_UNOPTIMISED,
+	)
+
+	suite.expectUserIntersections(intersectionID)
+	suite.intrClient.On("GetIntersection", suite.ctx, intersectionID).Return(intersection, nil)
+	suite.expectOutputStream(intersectionID, nil, createTestOutputChunks()...)
+
+	chunks := []model.SimulationOutputChunk{}
+	err := suite.service.StreamSimulationOutput(
+		suite.ctx,
+		intersectionID,
+		model.OutputFilter{VehicleIDs: []string{"vehicle-2"}},
+		func(chunk model.SimulationOutputChunk) error {
+			chunks = append(chunks, chunk)
+			return nil
+		},
+	)
+
+	suite.Require().NoError(err)
+	suite.Require().Len(chunks, 2)
+	// NOTE: The first chunk is kept for its intersection even though no vehicles are left
+	suite.NotNil(chunks[0].Intersection)
+	suite.Empty(chunks[0].Vehicles)
+	suite.Require().Len(chunks[1].Vehicles, 1)
+	suite.Equal("vehicle-2", chunks[1].Vehicles[0].ID)
+}
+
+func (suite *TestSuite) TestStreamSimulationOutput_FilterSkipsEmptyChunks() {
+	intersectionID := "intersection-123"
+	intersection := createTestIntersection(
+		intersectionID,
+		commonpb.IntersectionStatus_INTERSECTION_STATUS_UNOPTIMISED,
+	)
+
+	suite.expectUserIntersections(intersectionID)
+	suite.intrClient.On("GetIntersection", suite.ctx, intersectionID).Return(intersection, nil)
+	suite.expectOutputStream(intersectionID, nil, createTestOutputChunks()...)
+
+	chunks := []model.SimulationOutputChunk{}
+	err := suite.service.StreamSimulationOutput(
+		suite.ctx,
+		intersectionID,
+		model.OutputFilter{VehicleIDs: []string{"vehicle-1"}},
+		func(chunk model.SimulationOutputChunk) error {
+			chunks = append(chunks, chunk)
+			return nil
+		},
+	)
+
+	suite.Require().NoError(err)
+	suite.Require().Len(chunks, 1)
+	suite.Require().Len(chunks[0].Vehicles, 1)
+	suite.Equal("vehicle-1", chunks[0].Vehicles[0].ID)
+}
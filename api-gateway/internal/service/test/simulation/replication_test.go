@@ -0,0 +1,296 @@
+package simulation
+
+import (
+	"context"
+	"errors"
+	"sync/atomic"
+	"time"
+
+	"github.com/COS301-SE-2025/Swift-Signals/api-gateway/internal/model"
+	"github.com/COS301-SE-2025/Swift-Signals/api-gateway/internal/service"
+	commonpb "github.com/COS301-SE-2025/Swift-Signals/protos/gen/swiftsignals/common/v1"
+	intersectionpb "github.com/COS301-SE-2025/Swift-Signals/protos/gen/swiftsignals/intersection/v1"
+	simulationpb "github.com/COS301-SE-2025/Swift-Signals/protos/gen/swiftsignals/simulation/v1"
+	errs "github.com/COS301-SE-2025/Swift-Signals/shared/error"
+	"github.com/stretchr/testify/mock"
+)
+
+// expectReplications mocks the simulation service returning the average waiting time
+// given for each seed
+func (suite *TestSuite) expectReplications(intersectionID string, waitingBySeed map[int]float32) {
+	suite.simClient.On("GetSimulationResults", mock.Anything, intersectionID, mock.Anything).
+		Return(
+			func(
+				_ context.Context,
+				_ string,
+				params model.SimulationParameters,
+			) *simulationpb.SimulationResultsResponse {
+				return &simulationpb.SimulationResultsResponse{
+					AverageWaitingTime: waitingBySeed[params.Seed],
+					TotalVehicles:      int64(params.Green),
+				}
+			},
+			nil,
+		)
+}
+
+func (suite *TestSuite) TestReplicateSimulation_Summarises() {
+	intersectionID := "intersection-123"
+	intersection := createTestIntersection(
+		intersectionID,
+		commonpb.IntersectionStatus_INTERSECTION_STATUS_UNOPTIMISED,
+	)
+
+	suite.expectUserIntersections(intersectionID)
+	suite.intrClient.On("GetIntersection", suite.ctx, intersectionID).Return(intersection, nil)
+	suite.expectReplications(intersectionID, map[int]float32{12345: 10, 12346: 20, 12347: 30})
+
+	result, err := suite.service.ReplicateSimulation(suite.ctx, intersectionID, 3)
+
+	suite.Require().NoError(err)
+	suite.Equal(3, result.Replications)
+	suite.Equal([]int{12345, 12346, 12347}, result.Seeds)
+	suite.Len(result.Metrics, 10)
+
+	waiting := result.Metrics[model.MetricAverageWaitingTime]
+	suite.InDelta(20, waiting.Mean, 0.001)
+	suite.InDelta(10, waiting.StdDev, 0.001)
+	suite.InDelta(10, waiting.Min, 0.001)
+	suite.InDelta(30, waiting.Max, 0.001)
+	// NOTE: t(0.975, 2) = 4.303, so the margin is 4.303 * 10 / sqrt(3)
+	suite.InDelta(20-24.843, waiting.CILower, 0.001)
+	suite.InDelta(20+24.843, waiting.CIUpper, 0.001)
+
+	vehicles := result.Metrics[model.MetricTotalVehicles]
+	suite.InDelta(10, vehicles.Mean, 0.001)
+	suite.InDelta(0, vehicles.StdDev, 0.001)
+	suite.InDelta(10, vehicles.CILower, 0.001)
+
+	// NOTE: Replications are views of the intersection, not runs of their own
+	suite.intrClient.AssertNotCalled(suite.T(), "CreateRun", mock.Anything, mock.Anything)
+}
+
+func (suite *TestSuite) TestReplicateSimulation_BoundedConcurrency() {
+	intersectionID := "intersection-123"
+	intersection := createTestIntersection(
+		intersectionID,
+		commonpb.IntersectionStatus_INTERSECTION_STATUS_UNOPTIMISED,
+	)
+
+	var running, peak atomic.Int32
+	suite.expectUserIntersections(intersectionID)
+	suite.intrClient.On("GetIntersection", suite.ctx, intersectionID).Return(intersection, nil)
+	suite.simClient.On("GetSimulationResults", mock.Anything, intersectionID, mock.Anything).
+		Run(func(args mock.Arguments) {
+			current := running.Add(1)
+			for {
+				previous := peak.Load()
+				if current <= previous || peak.CompareAndSwap(previous, current) {
+					break
+				}
+			}
+			time.Sleep(10 * time.Millisecond)
+			running.Add(-1)
+		}).
+		Return(&simulationpb.SimulationResultsResponse{}, nil)
+
+	_, err := suite.service.ReplicateSimulation(suite.ctx, intersectionID, 8)
+
+	suite.Require().NoError(err)
+	suite.simClient.AssertNumberOfCalls(suite.T(), "GetSimulationResults", 8)
+	suite.LessOrEqual(peak.Load(), int32(2))
+}
+
+func (suite *TestSuite) TestReplicateSimulation_InvalidReplications() {
+	for _, replications := range []int{0, 1, 11} {
+		_, err := suite.service.ReplicateSimulation(suite.ctx, "intersection-123", replications)
+
+		suite.Require().Error(err)
+		var svcErr *errs.ServiceError
+		suite.Require().True(errors.As(err, &svcErr))
+		suite.Equal(errs.ErrValidation, svcErr.Code)
+	}
+	suite.userClient.AssertNotCalled(suite.T(), "GetUserIntersectionIDs",
+		mock.Anything, mock.Anything)
+	suite.simClient.AssertNotCalled(suite.T(), "GetSimulationResults",
+		mock.Anything, mock.Anything, mock.Anything)
+}
+
+func (suite *TestSuite) TestReplicateSimulation_ReplicationFails() {
+	intersectionID := "intersection-123"
+	intersection := createTestIntersection(
+		intersectionID,
+		commonpb.IntersectionStatus_INTERSECTION_STATUS_UNOPTIMISED,
+	)
+
+	suite.expectUserIntersections(intersectionID)
+	suite.intrClient.On("GetIntersection", suite.ctx, intersectionID).Return(intersection, nil)
+	suite.simClient.On("GetSimulationResults", mock.Anything, intersectionID, mock.Anything).
+		Return(nil, errs.NewUnavailableError("simulation service unavailable", map[string]any{}))
+
+	_, err := suite.service.ReplicateSimulation(suite.ctx, intersectionID, 4)
+
+	suite.Require().Error(err)
+	var svcErr *errs.ServiceError
+	suite.Require().True(errors.As(err, &svcErr))
+	suite.Equal(errs.ErrUnavailable, svcErr.Code)
+}
+
+func (suite *TestSuite) TestReplicateOptimised_UsesBestParameters() {
+	intersectionID := "intersection-123"
+	intersection := createTestIntersection(
+		intersectionID,
+		commonpb.IntersectionStatus_INTERSECTION_STATUS_OPTIMISED,
+	)
+	intersection.BestParameters = &commonpb.OptimisationParameters{
+		Parameters: &commonpb.SimulationParameters{
+			IntersectionType: commonpb.IntersectionType_INTERSECTION_TYPE_TJUNCTION,
+			Green:            33,
+			Seed:             100,
+		},
+	}
+
+	suite.expectUserIntersections(intersectionID)
+	suite.intrClient.On("GetIntersection", suite.ctx, intersectionID).Return(intersection, nil)
+	suite.expectReplications(intersectionID, map[int]float32{100: 5, 101: 7})
+
+	result, err := suite.service.ReplicateOptimised(suite.ctx, intersectionID, 2)
+
+	suite.Require().NoError(err)
+	suite.Equal([]int{100, 101}, result.Seeds)
+	suite.InDelta(33, result.Metrics[model.MetricTotalVehicles].Mean, 0.001)
+	suite.InDelta(6, result.Metrics[model.MetricAverageWaitingTime].Mean, 0.001)
+}
+
+func (suite *TestSuite) TestCompareParameters_Replicated() {
+	intersectionID := "intersection-123"
+	baseline := createWhatIfParameters()
+	baseline.Seed = 100
+	candidate := createWhatIfParameters()
+	candidate.Seed = 200
+	candidate.Green = 35
+
+	suite.expectUserIntersections(intersectionID)
+	suite.expectReplications(intersectionID, map[int]float32{
+		100: 40, 101: 41, 102: 39,
+		200: 30, 201: 31, 202: 29,
+	})
+
+	result, err := suite.service.CompareParameters(
+		suite.ctx,
+		intersectionID,
+		baseline,
+		candidate,
+		3,
+	)
+
+	suite.Require().NoError(err)
+	suite.Require().NotNil(result.Baseline.Replications)
+	suite.Require().NotNil(result.Candidate.Replications)
+	suite.InDelta(40, result.Baseline.Results.AverageWaitingTime, 0.001)
+
+	waiting := findMetric(result, model.MetricAverageWaitingTime)
+	suite.InDelta(-10, waiting.Delta, 0.001)
+	suite.True(waiting.Improved)
+
+	// NOTE: Every replication of both sides yields the same speed, so nothing changed
+	speed := findMetric(result, model.MetricAverageSpeed)
+	suite.False(speed.Improved)
+	suite.simClient.AssertNumberOfCalls(suite.T(), "GetSimulationResults", 6)
+}
+
+func (suite *TestSuite) TestCompareParameters_ReplicatedOverlapNotImproved() {
+	intersectionID := "intersection-123"
+	baseline := createWhatIfParameters()
+	baseline.Seed = 100
+	candidate := createWhatIfParameters()
+	candidate.Seed = 200
+
+	suite.expectUserIntersections(intersectionID)
+	suite.expectReplications(intersectionID, map[int]float32{
+		100: 40, 101: 20, 102: 60,
+		200: 30, 201: 50, 202: 25,
+	})
+
+	result, err := suite.service.CompareParameters(
+		suite.ctx,
+		intersectionID,
+		baseline,
+		candidate,
+		3,
+	)
+
+	suite.Require().NoError(err)
+	waiting := findMetric(result, model.MetricAverageWaitingTime)
+	suite.Less(waiting.Delta, 0.0)
+	// NOTE: The candidate is lower on average, but well within the spread of the seeds
+	suite.False(waiting.Improved)
+}
+
+func (suite *TestSuite) TestOptimiseIntersection_ReplicatedEvaluation() {
+	suite.service = service.NewSimulationService(
+		suite.intrClient,
+		suite.optiClient,
+		suite.userClient,
+		suite.simClient,
+		service.ReplicationConfig{Concurrency: 2, MaxReplications: 10, OptimisationReplications: 3},
+		service.SweepConfig{},
+		service.OptimisationConfig{},
+		suite.notifier,
+	)
+
+	intersectionID := "intersection-123"
+	intersection := createTestIntersection(
+		intersectionID,
+		commonpb.IntersectionStatus_INTERSECTION_STATUS_UNOPTIMISED,
+	)
+	job := createTestJob(
+		"job-1",
+		intersectionID,
+		intersectionpb.OptimisationJobStatus_OPTIMISATION_JOB_STATUS_PENDING,
+		commonpb.IntersectionStatus_INTERSECTION_STATUS_UNOPTIMISED,
+	)
+	optimisedParams := &commonpb.OptimisationParameters{
+		OptimisationType: commonpb.OptimisationType_OPTIMISATION_TYPE_GRIDSEARCH,
+		Parameters: &commonpb.SimulationParameters{
+			IntersectionType: commonpb.IntersectionType_INTERSECTION_TYPE_TJUNCTION,
+			Green:            14,
+			Yellow:           3,
+			Red:              5,
+			Speed:            60,
+			Seed:             500,
+		},
+	}
+
+	suite.expectUserIntersections(intersectionID)
+	suite.intrClient.On("GetIntersection", suite.ctx, intersectionID).Return(intersection, nil)
+	suite.intrClient.On("CreateOptimisationJob", suite.ctx, intersectionID, "test-user-id",
+		commonpb.IntersectionStatus_INTERSECTION_STATUS_UNOPTIMISED).
+		Return(job, nil)
+	suite.intrClient.On("UpdateIntersectionStatus", mock.Anything, intersectionID,
+		mock.Anything).
+		Return(intersection, nil)
+	suite.intrClient.On("UpdateOptimisationJob", mock.Anything, "job-1",
+		mock.Anything, mock.Anything, "").
+		Return(job, nil)
+	recorded := suite.expectRun()
+	suite.expectOptimisationStream(optimisedParams)
+	suite.expectReplications(intersectionID, map[int]float32{500: 30, 501: 40, 502: 50})
+	suite.intrClient.On("PutOptimisation", mock.Anything, intersectionID, mock.Anything,
+		mock.MatchedBy(func(metrics *simulationpb.SimulationResultsResponse) bool {
+			return metrics.AverageWaitingTime == 40 && metrics.TotalVehicles == 14
+		}), "test-user-id", "job-1").
+		Return(&intersectionpb.PutOptimisationResponse{Improved: true}, nil)
+
+	_, err := suite.service.OptimiseIntersection(suite.ctx, intersectionID)
+	suite.Require().NoError(err)
+
+	// NOTE: The optimised parameters are judged on the mean over all their seeds
+	run := suite.waitForRun(recorded)
+	suite.Equal(model.RunOutcomeSucceeded, run.Outcome)
+	suite.Require().NotNil(run.Metrics)
+	suite.InDelta(40, run.Metrics.AverageWaitingTime, 0.001)
+	suite.simClient.AssertNumberOfCalls(suite.T(), "GetSimulationResults", 3)
+	suite.intrClient.AssertCalled(suite.T(), "PutOptimisation",
+		mock.Anything, intersectionID, mock.Anything, mock.Anything, "test-user-id", "job-1")
+}
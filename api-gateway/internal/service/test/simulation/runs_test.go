@@ -0,0 +1,242 @@
+package simulation
+
+import (
+	"errors"
+	"io"
+
+	"github.com/COS301-SE-2025/Swift-Signals/api-gateway/internal/cache"
+	"github.com/COS301-SE-2025/Swift-Signals/api-gateway/internal/client"
+	grpcmocks "github.com/COS301-SE-2025/Swift-Signals/api-gateway/internal/mocks/grpc_client"
+	"github.com/COS301-SE-2025/Swift-Signals/api-gateway/internal/model"
+	"github.com/COS301-SE-2025/Swift-Signals/api-gateway/internal/service"
+	commonpb "github.com/COS301-SE-2025/Swift-Signals/protos/gen/swiftsignals/common/v1"
+	intersectionpb "github.com/COS301-SE-2025/Swift-Signals/protos/gen/swiftsignals/intersection/v1"
+	simulationpb "github.com/COS301-SE-2025/Swift-Signals/protos/gen/swiftsignals/simulation/v1"
+	errs "github.com/COS301-SE-2025/Swift-Signals/shared/error"
+	"github.com/stretchr/testify/mock"
+	"google.golang.org/protobuf/types/known/durationpb"
+	"google.golang.org/protobuf/types/known/timestamppb"
+)
+
+func createTestRun(id, intersectionID string) *intersectionpb.RunResponse {
+	return &intersectionpb.RunResponse{
+		Id:             id,
+		IntersectionId: intersectionID,
+		UserId:         "test-user-id",
+		Type:           intersectionpb.RunType_RUN_TYPE_OPTIMISATION,
+		Parameters:     createTestIntersection(intersectionID, 0).DefaultParameters,
+		Metrics:        &simulationpb.SimulationResultsResponse{AverageWaitingTime: 42},
+		Duration:       durationpb.New(90_000_000_000),
+		Outcome:        intersectionpb.RunOutcome_RUN_OUTCOME_SUCCEEDED,
+		Improved:       true,
+		JobId:          "job-1",
+		CreatedAt:      timestamppb.Now(),
+	}
+}
+
+func (suite *TestSuite) TestGetRuns_Success() {
+	intersectionID := "intersection-123"
+
+	stream := grpcmocks.NewMockIntersectionService_GetRunsClient[intersectionpb.RunResponse](
+		suite.T(),
+	)
+	stream.On("Recv").Return(createTestRun("run-2", intersectionID), nil).Once()
+	stream.On("Recv").Return(createTestRun("run-1", intersectionID), nil).Once()
+	stream.On("Recv").Return(nil, io.EOF).Once()
+
+	suite.expectUserIntersections(intersectionID)
+	suite.intrClient.On("GetRuns", suite.ctx, intersectionID, 2, 10).Return(stream, nil)
+
+	result, err := suite.service.GetRuns(suite.ctx, intersectionID, 2, 10)
+
+	suite.Require().NoError(err)
+	suite.Equal(2, result.Page)
+	suite.Equal(10, result.PageSize)
+	suite.Require().Len(result.Runs, 2)
+	suite.Equal("run-2", result.Runs[0].ID)
+	suite.Equal(model.RunTypeOptimisation, result.Runs[0].Type)
+	suite.Equal(model.RunOutcomeSucceeded, result.Runs[0].Outcome)
+	suite.Equal(int64(90_000), result.Runs[0].DurationMs)
+	suite.Require().NotNil(result.Runs[0].Metrics)
+	suite.InDelta(42, result.Runs[0].Metrics.AverageWaitingTime, 0.001)
+
+	suite.intrClient.AssertExpectations(suite.T())
+	suite.userClient.AssertExpectations(suite.T())
+}
+
+func (suite *TestSuite) TestGetRuns_Empty() {
+	intersectionID := "intersection-123"
+
+	stream := grpcmocks.NewMockIntersectionService_GetRunsClient[intersectionpb.RunResponse](
+		suite.T(),
+	)
+	stream.On("Recv").Return(nil, io.EOF).Once()
+
+	suite.expectUserIntersections(intersectionID)
+	suite.intrClient.On("GetRuns", suite.ctx, intersectionID, 1, 20).Return(stream, nil)
+
+	result, err := suite.service.GetRuns(suite.ctx, intersectionID, 1, 20)
+
+	suite.Require().NoError(err)
+	suite.NotNil(result.Runs)
+	suite.Empty(result.Runs)
+}
+
+func (suite *TestSuite) TestGetRuns_Forbidden() {
+	suite.expectUserIntersections("other-intersection")
+
+	_, err := suite.service.GetRuns(suite.ctx, "intersection-123", 1, 20)
+
+	suite.Require().Error(err)
+	var svcErr *errs.ServiceError
+	suite.Require().True(errors.As(err, &svcErr))
+	suite.Equal(errs.ErrForbidden, svcErr.Code)
+
+	suite.intrClient.AssertNotCalled(suite.T(), "GetRuns",
+		mock.Anything, mock.Anything, mock.Anything, mock.Anything)
+}
+
+func (suite *TestSuite) TestGetRun_Success() {
+	intersectionID := "intersection-123"
+
+	suite.expectUserIntersections(intersectionID)
+	suite.intrClient.On("GetRun", suite.ctx, intersectionID, "run-1").
+		Return(createTestRun("run-1", intersectionID), nil)
+
+	result, err := suite.service.GetRun(suite.ctx, intersectionID, "run-1")
+
+	suite.Require().NoError(err)
+	suite.Equal("run-1", result.ID)
+	suite.Equal("job-1", result.JobID)
+	suite.True(result.Improved)
+}
+
+func (suite *TestSuite) TestGetRun_NotFound() {
+	intersectionID := "intersection-123"
+
+	suite.expectUserIntersections(intersectionID)
+	suite.intrClient.On("GetRun", suite.ctx, intersectionID, "missing-run").
+		Return(nil, errs.NewNotFoundError("run not found", map[string]any{}))
+
+	_, err := suite.service.GetRun(suite.ctx, intersectionID, "missing-run")
+
+	suite.Require().Error(err)
+	var svcErr *errs.ServiceError
+	suite.Require().True(errors.As(err, &svcErr))
+	suite.Equal(errs.ErrNotFound, svcErr.Code)
+}
+
+func (suite *TestSuite) TestGetSimulationData_RecordsRun() {
+	intersectionID := "intersection-123"
+	intersection := createTestIntersection(
+		intersectionID,
+		commonpb.IntersectionStatus_INTERSECTION_STATUS_UNOPTIMISED,
+	)
+	results := &simulationpb.SimulationResultsResponse{AverageWaitingTime: 30, TotalVehicles: 12}
+
+	suite.expectUserIntersections(intersectionID)
+	suite.intrClient.On("GetIntersection", suite.ctx, intersectionID).Return(intersection, nil)
+	suite.simClient.On("RunSimulation", mock.Anything, intersectionID, mock.Anything).
+		Return(&simulationpb.SimulationResponse{
+			Results: results,
+			Output:  &simulationpb.SimulationOutputResponse{Intersection: &simulationpb.Intersection{}},
+		}, nil)
+	recorded := suite.expectRun()
+
+	_, err := suite.service.GetSimulationData(suite.ctx, intersectionID, model.OutputFilter{})
+	suite.Require().NoError(err)
+	suite.simClient.AssertNumberOfCalls(suite.T(), "RunSimulation", 1)
+
+	run := suite.waitForRun(recorded)
+	suite.Equal(model.RunTypeSimulation, run.Type)
+	suite.Equal(model.RunOutcomeSucceeded, run.Outcome)
+	suite.Equal("test-user-id", run.UserID)
+	suite.Equal(10, run.Parameters.SimulationParameters.Green)
+	suite.Require().NotNil(run.Metrics)
+	suite.Equal(12, run.Metrics.TotalVehicles)
+}
+
+func (suite *TestSuite) TestGetSimulationData_CacheHitNotRecorded() {
+	intersectionID := "intersection-123"
+	intersection := createTestIntersection(
+		intersectionID,
+		commonpb.IntersectionStatus_INTERSECTION_STATUS_UNOPTIMISED,
+	)
+	suite.service = service.NewSimulationService(
+		suite.intrClient,
+		suite.optiClient,
+		suite.userClient,
+		client.NewCachedSimulationClient(suite.simClient, cache.NewSimulationCache(1<<20, nil)),
+		service.ReplicationConfig{Concurrency: 2, MaxReplications: 10},
+		service.SweepConfig{},
+		service.OptimisationConfig{},
+		suite.notifier,
+	)
+
+	suite.expectUserIntersections(intersectionID)
+	suite.expectUserIntersections(intersectionID)
+	suite.intrClient.On("GetIntersection", suite.ctx, intersectionID).Return(intersection, nil)
+	suite.simClient.On("RunSimulation", mock.Anything, intersectionID, mock.Anything).
+		Return(&simulationpb.SimulationResponse{
+			Results: &simulationpb.SimulationResultsResponse{},
+			Output:  &simulationpb.SimulationOutputResponse{Intersection: &simulationpb.Intersection{}},
+		}, nil).
+		Once()
+	recorded := suite.expectRun()
+
+	// NOTE: Reloading the page is answered from the cache and never reaches the simulator
+	for range 2 {
+		_, err := suite.service.GetSimulationData(suite.ctx, intersectionID, model.OutputFilter{})
+		suite.Require().NoError(err)
+	}
+
+	suite.waitForRun(recorded)
+	suite.simClient.AssertExpectations(suite.T())
+	suite.intrClient.AssertNumberOfCalls(suite.T(), "CreateRun", 1)
+}
+
+func (suite *TestSuite) TestGetSimulationData_RecordsFailedRun() {
+	intersectionID := "intersection-123"
+	intersection := createTestIntersection(
+		intersectionID,
+		commonpb.IntersectionStatus_INTERSECTION_STATUS_UNOPTIMISED,
+	)
+	simulationErr := errs.NewUnavailableError("simulation service unavailable", map[string]any{})
+
+	suite.expectUserIntersections(intersectionID)
+	suite.intrClient.On("GetIntersection", suite.ctx, intersectionID).Return(intersection, nil)
+	suite.simClient.On("RunSimulation", mock.Anything, intersectionID, mock.Anything).
+		Return(nil, simulationErr)
+	recorded := suite.expectRun()
+
+	_, err := suite.service.GetSimulationData(suite.ctx, intersectionID, model.OutputFilter{})
+	suite.Require().Error(err)
+
+	run := suite.waitForRun(recorded)
+	suite.Equal(model.RunOutcomeFailed, run.Outcome)
+	suite.Equal(simulationErr.Error(), run.Error)
+	suite.Nil(run.Metrics)
+}
+
+func (suite *TestSuite) TestGetSimulationData_RecordingFailureIgnored() {
+	intersectionID := "intersection-123"
+	intersection := createTestIntersection(
+		intersectionID,
+		commonpb.IntersectionStatus_INTERSECTION_STATUS_UNOPTIMISED,
+	)
+
+	suite.expectUserIntersections(intersectionID)
+	suite.intrClient.On("GetIntersection", suite.ctx, intersectionID).Return(intersection, nil)
+	suite.simClient.On("RunSimulation", mock.Anything, intersectionID, mock.Anything).
+		Return(&simulationpb.SimulationResponse{
+			Results: &simulationpb.SimulationResultsResponse{},
+			Output:  &simulationpb.SimulationOutputResponse{Intersection: &simulationpb.Intersection{}},
+		}, nil)
+	suite.intrClient.On("CreateRun", mock.Anything, mock.Anything).
+		Return(nil, errs.NewUnavailableError("intersection service unavailable", map[string]any{}))
+
+	_, err := suite.service.GetSimulationData(suite.ctx, intersectionID, model.OutputFilter{})
+
+	suite.Require().NoError(err)
+	suite.intrClient.AssertExpectations(suite.T())
+}
@@ -0,0 +1,216 @@
+package simulation
+
+import (
+	"context"
+	"errors"
+
+	"github.com/COS301-SE-2025/Swift-Signals/api-gateway/internal/model"
+	commonpb "github.com/COS301-SE-2025/Swift-Signals/protos/gen/swiftsignals/common/v1"
+	simulationpb "github.com/COS301-SE-2025/Swift-Signals/protos/gen/swiftsignals/simulation/v1"
+	errs "github.com/COS301-SE-2025/Swift-Signals/shared/error"
+	"github.com/stretchr/testify/mock"
+)
+
+// expectSensitivity mocks the simulation service with results that follow waiting for the
+// green duration, and a vehicle count equal to it
+func (suite *TestSuite) expectSensitivity(intersectionID string, waiting func(green int) float32) {
+	suite.simClient.On("GetSimulationResults", mock.Anything, intersectionID, mock.Anything).
+		Return(
+			func(
+				_ context.Context,
+				_ string,
+				params model.SimulationParameters,
+			) *simulationpb.SimulationResultsResponse {
+				return &simulationpb.SimulationResultsResponse{
+					AverageWaitingTime: waiting(params.Green),
+					TotalVehicles:      int64(params.Green),
+					GeneratedVehicles:  100,
+				}
+			},
+			nil,
+		)
+}
+
+func findCurve(sensitivity model.Sensitivity, metric string) model.SensitivityCurve {
+	for _, curve := range sensitivity.Curves {
+		if curve.Metric == metric {
+			return curve
+		}
+	}
+	return model.SensitivityCurve{}
+}
+
+func (suite *TestSuite) TestAnalyseSensitivity_Success() {
+	intersectionID := "intersection-123"
+	intersection := createTestIntersection(
+		intersectionID,
+		commonpb.IntersectionStatus_INTERSECTION_STATUS_UNOPTIMISED,
+	)
+
+	suite.expectUserIntersections(intersectionID)
+	suite.intrClient.On("GetIntersection", suite.ctx, intersectionID).Return(intersection, nil)
+	suite.expectSensitivity(intersectionID, func(green int) float32 {
+		return float32((green-20)*(green-20) + 10)
+	})
+
+	result, err := suite.service.AnalyseSensitivity(suite.ctx, intersectionID,
+		model.SensitivityRequest{Parameter: model.SweepParameterGreen, Min: 10, Max: 30, Steps: 5})
+
+	suite.Require().NoError(err)
+	suite.Equal([]int{10, 15, 20, 25, 30}, result.ParameterValues)
+	suite.Equal(7, result.BaseParameters.Red)
+	suite.Len(result.Curves, 10)
+	suite.simClient.AssertNumberOfCalls(suite.T(), "GetSimulationResults", 5)
+
+	waiting := findCurve(result, model.MetricAverageWaitingTime)
+	suite.Equal([]float64{110, 35, 10, 35, 110}, waiting.Values)
+	suite.Require().NotNil(waiting.OptimalValue)
+	suite.Equal(20, *waiting.OptimalValue)
+	suite.False(waiting.OptimumAtBoundary)
+	// NOTE: The curve is flat at its minimum and symmetric across the range
+	suite.Require().NotNil(waiting.Elasticities[2])
+	suite.InDelta(0, *waiting.Elasticities[2], 0.001)
+	suite.Require().NotNil(waiting.Elasticity)
+	suite.InDelta(0, *waiting.Elasticity, 0.001)
+	suite.Require().NotNil(waiting.Elasticities[0])
+	suite.Less(*waiting.Elasticities[0], 0.0)
+
+	// A metric proportional to the parameter has an elasticity of one everywhere
+	vehicles := findCurve(result, model.MetricTotalVehicles)
+	for _, e := range vehicles.Elasticities {
+		suite.Require().NotNil(e)
+		suite.InDelta(1, *e, 0.001)
+	}
+	suite.Equal(30, *vehicles.OptimalValue)
+	suite.True(vehicles.OptimumAtBoundary)
+
+	suite.Nil(findCurve(result, model.MetricGeneratedVehicles).OptimalValue)
+	speed := findCurve(result, model.MetricAverageSpeed)
+	suite.Nil(speed.Elasticity)
+	suite.Nil(speed.Elasticities[0])
+}
+
+func (suite *TestSuite) TestAnalyseSensitivity_Replicated() {
+	intersectionID := "intersection-123"
+	intersection := createTestIntersection(
+		intersectionID,
+		commonpb.IntersectionStatus_INTERSECTION_STATUS_UNOPTIMISED,
+	)
+
+	suite.expectUserIntersections(intersectionID)
+	suite.intrClient.On("GetIntersection", suite.ctx, intersectionID).Return(intersection, nil)
+	suite.simClient.On("GetSimulationResults", mock.Anything, intersectionID, mock.Anything).
+		Return(
+			func(
+				_ context.Context,
+				_ string,
+				params model.SimulationParameters,
+			) *simulationpb.SimulationResultsResponse {
+				return &simulationpb.SimulationResultsResponse{
+					AverageWaitingTime: float32(params.Green + 2*(params.Seed-12345)),
+				}
+			},
+			nil,
+		)
+
+	result, err := suite.service.AnalyseSensitivity(suite.ctx, intersectionID,
+		model.SensitivityRequest{
+			Parameter:    model.SweepParameterGreen,
+			Min:          10,
+			Max:          30,
+			Steps:        3,
+			Replications: 3,
+		})
+
+	suite.Require().NoError(err)
+	suite.Equal(3, result.Replications)
+	suite.simClient.AssertNumberOfCalls(suite.T(), "GetSimulationResults", 9)
+	// NOTE: Each value is the mean over seeds 12345 to 12347
+	waiting := findCurve(result, model.MetricAverageWaitingTime)
+	suite.Equal([]float64{12, 22, 32}, waiting.Values)
+}
+
+func (suite *TestSuite) TestAnalyseSensitivity_NarrowRange() {
+	intersectionID := "intersection-123"
+	intersection := createTestIntersection(
+		intersectionID,
+		commonpb.IntersectionStatus_INTERSECTION_STATUS_UNOPTIMISED,
+	)
+
+	suite.expectUserIntersections(intersectionID)
+	suite.intrClient.On("GetIntersection", suite.ctx, intersectionID).Return(intersection, nil)
+	suite.expectSensitivity(intersectionID, func(green int) float32 { return float32(green) })
+
+	result, err := suite.service.AnalyseSensitivity(suite.ctx, intersectionID,
+		model.SensitivityRequest{Parameter: model.SweepParameterGreen, Min: 1, Max: 3, Steps: 10})
+
+	suite.Require().NoError(err)
+	suite.Equal([]int{1, 2, 3}, result.ParameterValues)
+	suite.simClient.AssertNumberOfCalls(suite.T(), "GetSimulationResults", 3)
+}
+
+func (suite *TestSuite) TestAnalyseSensitivity_VariesOnlyParameter() {
+	intersectionID := "intersection-123"
+	intersection := createTestIntersection(
+		intersectionID,
+		commonpb.IntersectionStatus_INTERSECTION_STATUS_UNOPTIMISED,
+	)
+
+	suite.expectUserIntersections(intersectionID)
+	suite.intrClient.On("GetIntersection", suite.ctx, intersectionID).Return(intersection, nil)
+	suite.simClient.On("GetSimulationResults", mock.Anything, intersectionID,
+		mock.MatchedBy(func(params model.SimulationParameters) bool {
+			return params.Green == 10 && params.Yellow == 3 && params.Seed == 12345 &&
+				(params.Red == 4 || params.Red == 8)
+		})).
+		Return(&simulationpb.SimulationResultsResponse{AverageWaitingTime: 20}, nil)
+
+	result, err := suite.service.AnalyseSensitivity(suite.ctx, intersectionID,
+		model.SensitivityRequest{Parameter: model.SweepParameterRed, Min: 4, Max: 8, Steps: 2})
+
+	suite.Require().NoError(err)
+	suite.Equal(model.SweepParameterRed, result.Parameter)
+	suite.Equal([]int{4, 8}, result.ParameterValues)
+}
+
+func (suite *TestSuite) TestAnalyseSensitivity_InvalidRequest() {
+	for _, req := range []model.SensitivityRequest{
+		{Parameter: model.SweepParameterSeed, Min: 1, Max: 10, Steps: 5},
+		{Parameter: model.SweepParameterGreen, Min: 10, Max: 10, Steps: 5},
+		{Parameter: model.SweepParameterGreen, Min: 0, Max: 10, Steps: 5},
+		{Parameter: model.SweepParameterGreen, Min: 1, Max: 10, Steps: 1},
+		{Parameter: model.SweepParameterGreen, Min: 1, Max: 10, Steps: 5, Replications: 11},
+	} {
+		_, err := suite.service.AnalyseSensitivity(suite.ctx, "intersection-123", req)
+
+		suite.Require().Error(err)
+		var svcErr *errs.ServiceError
+		suite.Require().True(errors.As(err, &svcErr))
+		suite.Equal(errs.ErrValidation, svcErr.Code)
+	}
+	suite.userClient.AssertNotCalled(suite.T(), "GetUserIntersectionIDs",
+		mock.Anything, mock.Anything)
+	suite.simClient.AssertNotCalled(suite.T(), "GetSimulationResults",
+		mock.Anything, mock.Anything, mock.Anything)
+}
+
+func (suite *TestSuite) TestAnalyseSensitivity_SimulationFails() {
+	intersectionID := "intersection-123"
+	intersection := createTestIntersection(
+		intersectionID,
+		commonpb.IntersectionStatus_INTERSECTION_STATUS_UNOPTIMISED,
+	)
+
+	suite.expectUserIntersections(intersectionID)
+	suite.intrClient.On("GetIntersection", suite.ctx, intersectionID).Return(intersection, nil)
+	suite.simClient.On("GetSimulationResults", mock.Anything, intersectionID, mock.Anything).
+		Return(nil, errs.NewUnavailableError("simulation service unavailable", map[string]any{}))
+
+	_, err := suite.service.AnalyseSensitivity(suite.ctx, intersectionID,
+		model.SensitivityRequest{Parameter: model.SweepParameterSpeed, Min: 40, Max: 80, Steps: 3})
+
+	suite.Require().Error(err)
+	var svcErr *errs.ServiceError
+	suite.Require().True(errors.As(err, &svcErr))
+	suite.Equal(errs.ErrUnavailable, svcErr.Code)
+}
@@ -0,0 +1,213 @@
+package simulation
+
+import (
+	"context"
+	"errors"
+
+	grpcmocks "github.com/COS301-SE-2025/Swift-Signals/api-gateway/internal/mocks/grpc_client"
+	"github.com/COS301-SE-2025/Swift-Signals/api-gateway/internal/model"
+	commonpb "github.com/COS301-SE-2025/Swift-Signals/protos/gen/swiftsignals/common/v1"
+	simulationpb "github.com/COS301-SE-2025/Swift-Signals/protos/gen/swiftsignals/simulation/v1"
+	errs "github.com/COS301-SE-2025/Swift-Signals/shared/error"
+	"github.com/stretchr/testify/mock"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+func createTestOutputChunks() []*simulationpb.SimulationOutputChunk {
+	return []*simulationpb.SimulationOutputChunk{
+		{
+			Intersection: &simulationpb.Intersection{
+				Nodes: []*simulationpb.Node{{Id: "node-1", X: 1, Y: 2}},
+			},
+			Vehicles: []*simulationpb.Vehicle{
+				{Id: "vehicle-1", Positions: []*simulationpb.Position{{Time: 0, X: 1, Y: 2}}},
+			},
+		},
+		{
+			Vehicles: []*simulationpb.Vehicle{
+				{Id: "vehicle-2", Positions: []*simulationpb.Position{{Time: 1, X: 3, Y: 4}}},
+			},
+		},
+	}
+}
+
+func (suite *TestSuite) TestStreamSimulationOutput_Success() {
+	intersectionID := "intersection-123"
+	intersection := createTestIntersection(
+		intersectionID,
+		commonpb.IntersectionStatus_INTERSECTION_STATUS_UNOPTIMISED,
+	)
+	intersection.DefaultParameters.Parameters.Green = 21
+
+	suite.expectUserIntersections(intersectionID)
+	suite.intrClient.On("GetIntersection", suite.ctx, intersectionID).Return(intersection, nil)
+	suite.expectOutputStream(intersectionID, nil, createTestOutputChunks()...)
+
+	chunks := []model.SimulationOutputChunk{}
+	err := suite.service.StreamSimulationOutput(
+		suite.ctx,
+		intersectionID,
+		model.OutputFilter{},
+		func(chunk model.SimulationOutputChunk) error {
+			chunks = append(chunks, chunk)
+			return nil
+		},
+	)
+
+	suite.Require().NoError(err)
+	suite.Require().Len(chunks, 2)
+	suite.Require().NotNil(chunks[0].Intersection)
+	suite.Equal("node-1", chunks[0].Intersection.Nodes[0].ID)
+	suite.Equal("vehicle-1", chunks[0].Vehicles[0].ID)
+	suite.Nil(chunks[1].Intersection)
+	suite.Equal("vehicle-2", chunks[1].Vehicles[0].ID)
+
+	suite.simClient.AssertCalled(suite.T(), "StreamSimulationOutput", mock.Anything, intersectionID,
+		mock.MatchedBy(func(params model.SimulationParameters) bool {
+			return params.Green == 21
+		}))
+	// NOTE: Streamed output is a view of a simulation, not a run of its own
+	suite.intrClient.AssertNotCalled(suite.T(), "CreateRun", mock.Anything, mock.Anything)
+}
+
+func (suite *TestSuite) TestStreamOptimisedOutput_UsesBestParameters() {
+	intersectionID := "intersection-123"
+	intersection := createTestIntersection(
+		intersectionID,
+		commonpb.IntersectionStatus_INTERSECTION_STATUS_OPTIMISED,
+	)
+	intersection.BestParameters = &commonpb.OptimisationParameters{
+		Parameters: &commonpb.SimulationParameters{
+			IntersectionType: commonpb.IntersectionType_INTERSECTION_TYPE_TJUNCTION,
+			Green:            33,
+		},
+	}
+
+	suite.expectUserIntersections(intersectionID)
+	suite.intrClient.On("GetIntersection", suite.ctx, intersectionID).Return(intersection, nil)
+	suite.expectOutputStream(intersectionID, nil, createTestOutputChunks()...)
+
+	err := suite.service.StreamOptimisedOutput(
+		suite.ctx,
+		intersectionID,
+		model.OutputFilter{},
+		func(chunk model.SimulationOutputChunk) error { return nil },
+	)
+
+	suite.Require().NoError(err)
+	suite.simClient.AssertCalled(suite.T(), "StreamSimulationOutput", mock.Anything, intersectionID,
+		mock.MatchedBy(func(params model.SimulationParameters) bool {
+			return params.Green == 33
+		}))
+}
+
+func (suite *TestSuite) TestStreamOptimisedOutput_NotOptimised() {
+	intersectionID := "intersection-123"
+	intersection := createTestIntersection(
+		intersectionID,
+		commonpb.IntersectionStatus_INTERSECTION_STATUS_UNOPTIMISED,
+	)
+	intersection.BestParameters = nil
+
+	suite.expectUserIntersections(intersectionID)
+	suite.intrClient.On("GetIntersection", suite.ctx, intersectionID).Return(intersection, nil)
+
+	err := suite.service.StreamOptimisedOutput(
+		suite.ctx,
+		intersectionID,
+		model.OutputFilter{},
+		func(chunk model.SimulationOutputChunk) error { return nil },
+	)
+
+	suite.Require().Error(err)
+	svcError, ok := err.(*errs.ServiceError)
+	suite.True(ok)
+	suite.Equal(errs.ErrNotFound, svcError.Code)
+	suite.simClient.AssertNotCalled(suite.T(), "StreamSimulationOutput",
+		mock.Anything, mock.Anything, mock.Anything)
+}
+
+func (suite *TestSuite) TestStreamSimulationOutput_Forbidden() {
+	suite.expectUserIntersections("intersection-456")
+
+	err := suite.service.StreamSimulationOutput(
+		suite.ctx,
+		"intersection-123",
+		model.OutputFilter{},
+		func(chunk model.SimulationOutputChunk) error { return nil },
+	)
+
+	suite.Require().Error(err)
+	svcError, ok := err.(*errs.ServiceError)
+	suite.True(ok)
+	suite.Equal(errs.ErrForbidden, svcError.Code)
+	suite.intrClient.AssertNotCalled(suite.T(), "GetIntersection", mock.Anything, mock.Anything)
+}
+
+func (suite *TestSuite) TestStreamSimulationOutput_StreamError() {
+	intersectionID := "intersection-123"
+	intersection := createTestIntersection(
+		intersectionID,
+		commonpb.IntersectionStatus_INTERSECTION_STATUS_UNOPTIMISED,
+	)
+
+	suite.expectUserIntersections(intersectionID)
+	suite.intrClient.On("GetIntersection", suite.ctx, intersectionID).Return(intersection, nil)
+	suite.expectOutputStream(
+		intersectionID,
+		status.Error(codes.Internal, "simulation failed"),
+		createTestOutputChunks()[0],
+	)
+
+	sent := 0
+	err := suite.service.StreamSimulationOutput(
+		suite.ctx,
+		intersectionID,
+		model.OutputFilter{},
+		func(chunk model.SimulationOutputChunk) error {
+			sent++
+			return nil
+		},
+	)
+
+	suite.Require().Error(err)
+	svcError, ok := err.(*errs.ServiceError)
+	suite.True(ok)
+	suite.Equal(errs.ErrInternal, svcError.Code)
+	suite.Equal(1, sent)
+}
+
+func (suite *TestSuite) TestStreamSimulationOutput_SendErrorStopsStream() {
+	intersectionID := "intersection-123"
+	intersection := createTestIntersection(
+		intersectionID,
+		commonpb.IntersectionStatus_INTERSECTION_STATUS_UNOPTIMISED,
+	)
+	sendErr := errors.New("client disconnected")
+
+	suite.expectUserIntersections(intersectionID)
+	suite.intrClient.On("GetIntersection", suite.ctx, intersectionID).Return(intersection, nil)
+	stream := grpcmocks.NewMockSimulationService_StreamSimulationOutputClient[simulationpb.SimulationOutputChunk](
+		suite.T(),
+	)
+	stream.On("Recv").Return(createTestOutputChunks()[0], nil).Once()
+
+	var streamCtx context.Context
+	suite.simClient.On("StreamSimulationOutput", mock.Anything, intersectionID, mock.Anything).
+		Run(func(args mock.Arguments) { streamCtx = args.Get(0).(context.Context) }).
+		Return(stream, nil).
+		Once()
+
+	err := suite.service.StreamSimulationOutput(
+		suite.ctx,
+		intersectionID,
+		model.OutputFilter{},
+		func(chunk model.SimulationOutputChunk) error { return sendErr },
+	)
+
+	suite.ErrorIs(err, sendErr)
+	// NOTE: The stream is cancelled rather than read to the end once the client is gone
+	suite.Require().NotNil(streamCtx)
+	suite.ErrorIs(streamCtx.Err(), context.Canceled)
+}
@@ -0,0 +1,312 @@
+package simulation
+
+import (
+	"context"
+	"errors"
+	"io"
+	"math"
+	"strings"
+	"time"
+
+	grpcmocks "github.com/COS301-SE-2025/Swift-Signals/api-gateway/internal/mocks/grpc_client"
+	"github.com/COS301-SE-2025/Swift-Signals/api-gateway/internal/model"
+	"github.com/COS301-SE-2025/Swift-Signals/api-gateway/internal/service"
+	commonpb "github.com/COS301-SE-2025/Swift-Signals/protos/gen/swiftsignals/common/v1"
+	intersectionpb "github.com/COS301-SE-2025/Swift-Signals/protos/gen/swiftsignals/intersection/v1"
+	simulationpb "github.com/COS301-SE-2025/Swift-Signals/protos/gen/swiftsignals/simulation/v1"
+	errs "github.com/COS301-SE-2025/Swift-Signals/shared/error"
+	"github.com/stretchr/testify/mock"
+	"google.golang.org/protobuf/types/known/emptypb"
+	"google.golang.org/protobuf/types/known/timestamppb"
+)
+
+func createTestSweep(
+	intersectionID string,
+	status intersectionpb.SweepStatus,
+) *intersectionpb.SweepResponse {
+	return &intersectionpb.SweepResponse{
+		Id:             "sweep-1",
+		IntersectionId: intersectionID,
+		UserId:         "test-user-id",
+		Status:         status,
+		BaseParameters: &commonpb.SimulationParameters{
+			IntersectionType: commonpb.IntersectionType_INTERSECTION_TYPE_TJUNCTION,
+			Green:            10,
+			Yellow:           3,
+			Red:              7,
+			Speed:            60,
+			Seed:             12345,
+		},
+		TotalPoints: 1,
+		CreatedAt:   timestamppb.Now(),
+	}
+}
+
+// expectSweep mocks the intersection service creating and starting a sweep and returns
+// channels that receive every saved point and the status the sweep finished with
+func (suite *TestSuite) expectSweep(
+	intersectionID string,
+	totalPoints int,
+) (<-chan model.SweepPoint, <-chan string) {
+	sweep := createTestSweep(intersectionID, intersectionpb.SweepStatus_SWEEP_STATUS_PENDING)
+	sweep.TotalPoints = int32(totalPoints)
+	points := make(chan model.SweepPoint, totalPoints)
+	finished := make(chan string, 1)
+
+	suite.intrClient.On("CreateSweep", suite.ctx, intersectionID, "test-user-id",
+		mock.AnythingOfType("model.SimulationParameters"), mock.Anything, totalPoints).
+		Return(sweep, nil).
+		Once()
+	suite.intrClient.On("UpdateSweep", mock.Anything, "sweep-1",
+		intersectionpb.SweepStatus_SWEEP_STATUS_RUNNING, "").
+		Return(sweep, nil).
+		Once()
+	suite.intrClient.On("PutSweepPoint", mock.Anything, "sweep-1",
+		mock.AnythingOfType("model.SweepPoint")).
+		Run(func(args mock.Arguments) { points <- args.Get(2).(model.SweepPoint) }).
+		Return(&emptypb.Empty{}, nil)
+	for _, status := range []intersectionpb.SweepStatus{
+		intersectionpb.SweepStatus_SWEEP_STATUS_SUCCEEDED,
+		intersectionpb.SweepStatus_SWEEP_STATUS_FAILED,
+	} {
+		suite.intrClient.On("UpdateSweep", mock.Anything, "sweep-1", status, mock.Anything).
+			Run(func(args mock.Arguments) { finished <- args.Get(3).(string) }).
+			Return(sweep, nil).
+			Maybe()
+	}
+
+	return points, finished
+}
+
+// waitForSweep waits for a sweep expected by expectSweep to finish and returns its error
+func (suite *TestSuite) waitForSweep(finished <-chan string) string {
+	select {
+	case errMsg := <-finished:
+		return errMsg
+	case <-time.After(jobWaitTimeout):
+		suite.FailNow("sweep did not finish")
+		return ""
+	}
+}
+
+func (suite *TestSuite) TestStartSweep_SimulatesEveryPoint() {
+	intersectionID := "intersection-123"
+	intersection := createTestIntersection(
+		intersectionID,
+		commonpb.IntersectionStatus_INTERSECTION_STATUS_UNOPTIMISED,
+	)
+	ranges := []model.SweepRange{
+		{Parameter: model.SweepParameterGreen, Min: 10, Max: 20, Step: 5},
+		{Parameter: model.SweepParameterRed, Min: 5, Max: 10, Step: 5},
+	}
+
+	suite.expectUserIntersections(intersectionID)
+	suite.intrClient.On("GetIntersection", suite.ctx, intersectionID).Return(intersection, nil)
+	points, finished := suite.expectSweep(intersectionID, 6)
+	suite.simClient.On("GetSimulationResults", mock.Anything, intersectionID,
+		mock.MatchedBy(func(params model.SimulationParameters) bool {
+			return params.Green == 20 && params.Red == 10
+		})).
+		Return(nil, errs.NewUnavailableError("simulation service unavailable", map[string]any{}))
+	suite.simClient.On("GetSimulationResults", mock.Anything, intersectionID, mock.Anything).
+		Return(&simulationpb.SimulationResultsResponse{AverageWaitingTime: 30}, nil)
+
+	result, err := suite.service.StartSweep(suite.ctx, intersectionID, ranges)
+
+	suite.Require().NoError(err)
+	suite.Equal("sweep-1", result.ID)
+	suite.Equal(model.SweepStatusPending, result.Status)
+	suite.Empty(suite.waitForSweep(finished))
+
+	saved := make(map[int]model.SweepPoint)
+	for range 6 {
+		point := <-points
+		saved[point.Index] = point
+	}
+	suite.Len(saved, 6)
+	// NOTE: The last range changes fastest, everything not swept keeps its default
+	suite.Equal(10, saved[1].Parameters.Green)
+	suite.Equal(10, saved[1].Parameters.Red)
+	suite.Equal(15, saved[2].Parameters.Green)
+	suite.Equal(5, saved[2].Parameters.Red)
+	suite.Equal(3, saved[2].Parameters.Yellow)
+	suite.Require().NotNil(saved[0].Results)
+	suite.InDelta(30, saved[0].Results.AverageWaitingTime, 0.001)
+	suite.Nil(saved[5].Results)
+	suite.Contains(saved[5].Error, "simulation service unavailable")
+}
+
+func (suite *TestSuite) TestStartSweep_PointPastDeadline() {
+	suite.service = service.NewSimulationService(
+		suite.intrClient,
+		suite.optiClient,
+		suite.userClient,
+		suite.simClient,
+		service.ReplicationConfig{},
+		service.SweepConfig{Concurrency: 1, CallTimeout: 10 * time.Millisecond, MaxPoints: 10},
+		service.OptimisationConfig{},
+		suite.notifier,
+	)
+
+	intersectionID := "intersection-123"
+	intersection := createTestIntersection(
+		intersectionID,
+		commonpb.IntersectionStatus_INTERSECTION_STATUS_UNOPTIMISED,
+	)
+
+	suite.expectUserIntersections(intersectionID)
+	suite.intrClient.On("GetIntersection", suite.ctx, intersectionID).Return(intersection, nil)
+	points, finished := suite.expectSweep(intersectionID, 1)
+	suite.simClient.On("GetSimulationResults", mock.Anything, intersectionID, mock.Anything).
+		Run(func(args mock.Arguments) { <-args.Get(0).(context.Context).Done() }).
+		Return(nil, errs.NewUnavailableError("simulation cancelled", map[string]any{}))
+
+	_, err := suite.service.StartSweep(suite.ctx, intersectionID, []model.SweepRange{
+		{Parameter: model.SweepParameterSpeed, Min: 40, Max: 40, Step: 1},
+	})
+
+	suite.Require().NoError(err)
+	// NOTE: A point past its deadline fails on its own, not the sweep
+	suite.Empty(suite.waitForSweep(finished))
+	point := <-points
+	suite.Nil(point.Results)
+	suite.True(strings.HasPrefix(point.Error, "simulation ran past its deadline"))
+}
+
+func (suite *TestSuite) TestStartSweep_SavingPointFails() {
+	intersectionID := "intersection-123"
+	intersection := createTestIntersection(
+		intersectionID,
+		commonpb.IntersectionStatus_INTERSECTION_STATUS_UNOPTIMISED,
+	)
+	sweep := createTestSweep(intersectionID, intersectionpb.SweepStatus_SWEEP_STATUS_PENDING)
+	finished := make(chan string, 1)
+
+	suite.expectUserIntersections(intersectionID)
+	suite.intrClient.On("GetIntersection", suite.ctx, intersectionID).Return(intersection, nil)
+	suite.intrClient.On("CreateSweep", suite.ctx, intersectionID, "test-user-id",
+		mock.Anything, mock.Anything, 1).
+		Return(sweep, nil)
+	suite.intrClient.On("UpdateSweep", mock.Anything, "sweep-1",
+		intersectionpb.SweepStatus_SWEEP_STATUS_RUNNING, "").
+		Return(sweep, nil)
+	suite.simClient.On("GetSimulationResults", mock.Anything, intersectionID, mock.Anything).
+		Return(&simulationpb.SimulationResultsResponse{}, nil)
+	suite.intrClient.On("PutSweepPoint", mock.Anything, "sweep-1", mock.Anything).
+		Return(nil, errs.NewInternalError("database unavailable", nil, map[string]any{}))
+	suite.intrClient.On("UpdateSweep", mock.Anything, "sweep-1",
+		intersectionpb.SweepStatus_SWEEP_STATUS_FAILED, mock.Anything).
+		Run(func(args mock.Arguments) { finished <- args.Get(3).(string) }).
+		Return(sweep, nil)
+
+	_, err := suite.service.StartSweep(suite.ctx, intersectionID, []model.SweepRange{
+		{Parameter: model.SweepParameterSeed, Min: 1, Max: 1, Step: 1},
+	})
+
+	suite.Require().NoError(err)
+	suite.Contains(suite.waitForSweep(finished), "database unavailable")
+}
+
+func (suite *TestSuite) TestStartSweep_InvalidRanges() {
+	intersectionID := "intersection-123"
+	intersection := createTestIntersection(
+		intersectionID,
+		commonpb.IntersectionStatus_INTERSECTION_STATUS_UNOPTIMISED,
+	)
+
+	for name, ranges := range map[string][]model.SweepRange{
+		"too many points": {
+			{Parameter: model.SweepParameterGreen, Min: 1, Max: 20, Step: 1},
+			{Parameter: model.SweepParameterRed, Min: 1, Max: 10, Step: 1},
+		},
+		"duplicate parameter": {
+			{Parameter: model.SweepParameterGreen, Min: 10, Max: 20, Step: 5},
+			{Parameter: model.SweepParameterGreen, Min: 30, Max: 40, Step: 5},
+		},
+		"unknown parameter": {
+			{Parameter: "intersection_type", Min: 1, Max: 2, Step: 1},
+		},
+		"max below min": {
+			{Parameter: model.SweepParameterRed, Min: 20, Max: 10, Step: 5},
+		},
+		"past the simulator's range": {
+			{Parameter: model.SweepParameterSeed, Min: math.MaxInt, Max: math.MaxInt, Step: 1},
+		},
+	} {
+		suite.Run(name, func() {
+			suite.expectUserIntersections(intersectionID)
+			suite.intrClient.On("GetIntersection", suite.ctx, intersectionID).
+				Return(intersection, nil).
+				Once()
+
+			_, err := suite.service.StartSweep(suite.ctx, intersectionID, ranges)
+
+			suite.Require().Error(err)
+			var svcErr *errs.ServiceError
+			suite.Require().True(errors.As(err, &svcErr))
+			suite.Equal(errs.ErrValidation, svcErr.Code)
+		})
+	}
+	suite.intrClient.AssertNotCalled(suite.T(), "CreateSweep",
+		mock.Anything, mock.Anything, mock.Anything, mock.Anything, mock.Anything, mock.Anything)
+}
+
+func (suite *TestSuite) TestGetSweep_OrdersPoints() {
+	intersectionID := "intersection-123"
+
+	sweep := createTestSweep(intersectionID, intersectionpb.SweepStatus_SWEEP_STATUS_RUNNING)
+	sweep.TotalPoints = 4
+	sweep.CompletedPoints = 2
+	sweep.Points = []*intersectionpb.SweepPoint{
+		{Index: 3, Parameters: &commonpb.SimulationParameters{Green: 25}},
+		{Index: 1, Parameters: &commonpb.SimulationParameters{Green: 15}},
+	}
+
+	suite.expectUserIntersections(intersectionID)
+	suite.intrClient.On("GetSweep", suite.ctx, intersectionID, "sweep-1").Return(sweep, nil)
+
+	result, err := suite.service.GetSweep(suite.ctx, intersectionID, "sweep-1")
+
+	suite.Require().NoError(err)
+	suite.Equal(model.SweepStatusRunning, result.Status)
+	suite.InDelta(0.5, result.Progress, 0.001)
+	suite.Require().Len(result.Points, 2)
+	suite.Equal(1, result.Points[0].Index)
+	suite.Equal(3, result.Points[1].Index)
+}
+
+func (suite *TestSuite) TestGetSweep_Forbidden() {
+	suite.expectUserIntersections("other-intersection")
+
+	_, err := suite.service.GetSweep(suite.ctx, "intersection-123", "sweep-1")
+
+	suite.Require().Error(err)
+	var svcErr *errs.ServiceError
+	suite.Require().True(errors.As(err, &svcErr))
+	suite.Equal(errs.ErrForbidden, svcErr.Code)
+	suite.intrClient.AssertNotCalled(suite.T(), "GetSweep",
+		mock.Anything, mock.Anything, mock.Anything)
+}
+
+func (suite *TestSuite) TestRecoverSweeps_FailsInterruptedSweeps() {
+	statuses := []intersectionpb.SweepStatus{
+		intersectionpb.SweepStatus_SWEEP_STATUS_PENDING,
+		intersectionpb.SweepStatus_SWEEP_STATUS_RUNNING,
+	}
+	sweep := createTestSweep("intersection-123", intersectionpb.SweepStatus_SWEEP_STATUS_RUNNING)
+
+	stream := grpcmocks.NewMockIntersectionService_GetSweepsClient[intersectionpb.SweepResponse](
+		suite.T(),
+	)
+	stream.On("Recv").Return(sweep, nil).Once()
+	stream.On("Recv").Return(nil, io.EOF).Once()
+	suite.intrClient.On("GetSweeps", suite.ctx, "", statuses).Return(stream, nil)
+	suite.intrClient.On("UpdateSweep", suite.ctx, "sweep-1",
+		intersectionpb.SweepStatus_SWEEP_STATUS_FAILED, "interrupted by a gateway restart").
+		Return(sweep, nil)
+
+	err := suite.service.RecoverSweeps(suite.ctx)
+
+	suite.Require().NoError(err)
+	suite.intrClient.AssertExpectations(suite.T())
+}
@@ -0,0 +1,129 @@
+package simulation
+
+import (
+	"errors"
+
+	"github.com/COS301-SE-2025/Swift-Signals/api-gateway/internal/model"
+	commonpb "github.com/COS301-SE-2025/Swift-Signals/protos/gen/swiftsignals/common/v1"
+	simulationpb "github.com/COS301-SE-2025/Swift-Signals/protos/gen/swiftsignals/simulation/v1"
+	errs "github.com/COS301-SE-2025/Swift-Signals/shared/error"
+	"github.com/stretchr/testify/mock"
+)
+
+func createWhatIfParameters() model.SimulationParameters {
+	return model.SimulationParameters{
+		IntersectionType: "INTERSECTION_TYPE_TRAFFICLIGHT",
+		Green:            25,
+		Yellow:           4,
+		Red:              12,
+		Speed:            50,
+		Seed:             98765,
+		TrafficDensity:   "low",
+	}
+}
+
+func (suite *TestSuite) TestRunWhatIfSimulation_Success() {
+	intersectionID := "intersection-123"
+	params := createWhatIfParameters()
+
+	suite.expectUserIntersections(intersectionID)
+	suite.simClient.On("RunWhatIfSimulation", suite.ctx, intersectionID, params).
+		Return(&simulationpb.SimulationResponse{
+			Results: &simulationpb.SimulationResultsResponse{
+				AverageWaitingTime: 18,
+				TotalVehicles:      40,
+			},
+			Output: &simulationpb.SimulationOutputResponse{
+				Intersection: &simulationpb.Intersection{},
+				Vehicles:     createTestVehicles(),
+			},
+		}, nil)
+
+	result, err := suite.service.RunWhatIfSimulation(
+		suite.ctx,
+		intersectionID,
+		params,
+		model.OutputFilter{VehicleIDs: []string{"vehicle-1"}},
+	)
+
+	suite.Require().NoError(err)
+	suite.Equal(40, result.Results.TotalVehicles)
+	suite.Equal(18.0, result.Results.AverageWaitingTime)
+	suite.Require().Len(result.Output.Vehicles, 1)
+	suite.Equal("vehicle-1", result.Output.Vehicles[0].ID)
+
+	// NOTE: With a density given, what-if simulations are neither read from nor saved to
+	// the intersection
+	suite.intrClient.AssertNotCalled(suite.T(), "GetIntersection", mock.Anything, mock.Anything)
+	suite.intrClient.AssertNotCalled(suite.T(), "CreateRun", mock.Anything, mock.Anything)
+}
+
+func (suite *TestSuite) TestRunWhatIfSimulation_IntersectionDensity() {
+	intersectionID := "intersection-123"
+	intersection := createTestIntersection(
+		intersectionID,
+		commonpb.IntersectionStatus_INTERSECTION_STATUS_UNOPTIMISED,
+	)
+	params := createWhatIfParameters()
+	params.TrafficDensity = ""
+
+	suite.expectUserIntersections(intersectionID)
+	suite.intrClient.On("GetIntersection", suite.ctx, intersectionID).Return(intersection, nil)
+	suite.simClient.On("RunWhatIfSimulation", suite.ctx, intersectionID,
+		mock.MatchedBy(func(params model.SimulationParameters) bool {
+			return params.TrafficDensity == "high" && params.Green == 25
+		})).
+		Return(&simulationpb.SimulationResponse{
+			Results: &simulationpb.SimulationResultsResponse{},
+			Output:  &simulationpb.SimulationOutputResponse{Intersection: &simulationpb.Intersection{}},
+		}, nil)
+
+	_, err := suite.service.RunWhatIfSimulation(
+		suite.ctx,
+		intersectionID,
+		params,
+		model.OutputFilter{},
+	)
+
+	suite.Require().NoError(err)
+	suite.simClient.AssertExpectations(suite.T())
+	suite.intrClient.AssertNotCalled(suite.T(), "CreateRun", mock.Anything, mock.Anything)
+}
+
+func (suite *TestSuite) TestRunWhatIfSimulation_Forbidden() {
+	suite.expectUserIntersections("intersection-456")
+
+	_, err := suite.service.RunWhatIfSimulation(
+		suite.ctx,
+		"intersection-123",
+		createWhatIfParameters(),
+		model.OutputFilter{},
+	)
+
+	suite.Require().Error(err)
+	var svcErr *errs.ServiceError
+	suite.Require().True(errors.As(err, &svcErr))
+	suite.Equal(errs.ErrForbidden, svcErr.Code)
+	suite.simClient.AssertNotCalled(suite.T(), "RunWhatIfSimulation",
+		mock.Anything, mock.Anything, mock.Anything)
+}
+
+func (suite *TestSuite) TestRunWhatIfSimulation_SimulationError() {
+	intersectionID := "intersection-123"
+
+	suite.expectUserIntersections(intersectionID)
+	suite.simClient.On("RunWhatIfSimulation", suite.ctx, intersectionID, mock.Anything).
+		Return(nil, errs.NewValidationError("invalid simulation parameters", map[string]any{}))
+
+	_, err := suite.service.RunWhatIfSimulation(
+		suite.ctx,
+		intersectionID,
+		createWhatIfParameters(),
+		model.OutputFilter{},
+	)
+
+	suite.Require().Error(err)
+	var svcErr *errs.ServiceError
+	suite.Require().True(errors.As(err, &svcErr))
+	suite.Equal(errs.ErrValidation, svcErr.Code)
+}
@@ -0,0 +1,126 @@
+package webhook
+
+import (
+	"context"
+	"io"
+	"log/slog"
+	"net"
+	"testing"
+	"time"
+
+	"github.com/COS301-SE-2025/Swift-Signals/api-gateway/internal/middleware"
+	mocks "github.com/COS301-SE-2025/Swift-Signals/api-gateway/internal/mocks/client"
+	grpcmocks "github.com/COS301-SE-2025/Swift-Signals/api-gateway/internal/mocks/grpc_client"
+	repomocks "github.com/COS301-SE-2025/Swift-Signals/api-gateway/internal/mocks/repository"
+	"github.com/COS301-SE-2025/Swift-Signals/api-gateway/internal/model"
+	"github.com/COS301-SE-2025/Swift-Signals/api-gateway/internal/repository"
+	"github.com/COS301-SE-2025/Swift-Signals/api-gateway/internal/service"
+	userpb "github.com/COS301-SE-2025/Swift-Signals/protos/gen/swiftsignals/user/v1"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/suite"
+)
+
+type TestSuite struct {
+	suite.Suite
+	repo       *repomocks.MockWebhookRepositoryInterface
+	service    service.WebhookServiceInterface
+	store      *repository.MemoryWebhookRepository
+	userClient *mocks.MockUserClientInterface
+	dispatcher *service.WebhookDispatcher
+	ctx        context.Context
+}
+
+func (suite *TestSuite) SetupSuite() {
+	// NOTE: Not os.NewFile(0, os.DevNull), whose finalizer closes file descriptor 0 and
+	// with it any webhook receiver's listener that has since been given it
+	slog.SetDefault(slog.New(slog.NewTextHandler(io.Discard, nil)))
+}
+
+func (suite *TestSuite) SetupTest() {
+	suite.repo = new(repomocks.MockWebhookRepositoryInterface)
+	suite.service = service.NewWebhookService(suite.repo, service.WebhookAddresses{
+		Resolver: staticResolver{
+			"ops.example.com":      {"93.184.215.14"},
+			"internal.example.com": {"93.184.215.14", "10.0.0.7"},
+		},
+	})
+
+	// NOTE: The dispatcher is tested against the in-memory repository, so that its
+	// delivery log can be read back
+	suite.store = repository.NewMemoryWebhookRepository()
+	suite.userClient = new(mocks.MockUserClientInterface)
+	suite.dispatcher = service.NewWebhookDispatcher(
+		suite.store,
+		suite.userClient,
+		service.WebhookConfig{
+			MaxAttempts:    3,
+			InitialBackoff: time.Millisecond,
+			Timeout:        time.Second,
+			// NOTE: The receivers under test listen on loopback
+			Addresses: service.WebhookAddresses{AllowPrivate: true},
+		},
+	)
+
+	suite.ctx = middleware.SetLogger(context.Background(), slog.Default())
+}
+
+// addWebhook saves a webhook for the dispatcher to deliver to
+func (suite *TestSuite) addWebhook(id, userID, url string, eventTypes ...string) model.Webhook {
+	webhook := model.Webhook{
+		ID:        id,
+		UserID:    userID,
+		URL:       url,
+		Events:    eventTypes,
+		Secret:    "whsec_" + id,
+		CreatedAt: time.Now(),
+	}
+	suite.Require().NoError(suite.store.CreateWebhook(suite.ctx, webhook))
+	return webhook
+}
+
+// deliverAll makes every attempt at the queued deliveries, as though each backoff had passed
+func (suite *TestSuite) deliverAll(dispatcher *service.WebhookDispatcher) {
+	now := time.Now()
+	for range 10 {
+		attempted, err := dispatcher.DeliverDue(suite.ctx, now)
+		suite.Require().NoError(err)
+		if attempted == 0 {
+			return
+		}
+		now = now.Add(time.Hour)
+	}
+}
+
+// expectUserIntersections mocks the user service returning the given intersection IDs
+func (suite *TestSuite) expectUserIntersections(userID string, ids ...string) {
+	stream := grpcmocks.NewMockUserService_GetUserIntersectionIDsClient[userpb.IntersectionIDResponse](
+		suite.T(),
+	)
+	for _, id := range ids {
+		stream.On("Recv").Return(&userpb.IntersectionIDResponse{IntersectionId: id}, nil).Once()
+	}
+	stream.On("Recv").Return(nil, io.EOF).Once()
+
+	suite.userClient.On("GetUserIntersectionIDs", mock.Anything, userID).
+		Return(stream, nil).
+		Once()
+}
+
+// staticResolver resolves the hosts it knows without a DNS server
+type staticResolver map[string][]string
+
+func (r staticResolver) LookupIPAddr(_ context.Context, host string) ([]net.IPAddr, error) {
+	ips, ok := r[host]
+	if !ok {
+		return nil, &net.DNSError{Err: "no such host", Name: host, IsNotFound: true}
+	}
+	addrs := make([]net.IPAddr, 0, len(ips))
+	for _, ip := range ips {
+		addrs = append(addrs, net.IPAddr{IP: net.ParseIP(ip)})
+	}
+	return addrs, nil
+}
+
+func TestService(t *testing.T) {
+	suite.Run(t, new(TestSuite))
+}
@@ -0,0 +1,398 @@
+package webhook
+
+import (
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"sync"
+	"sync/atomic"
+	"time"
+
+	"github.com/COS301-SE-2025/Swift-Signals/api-gateway/internal/service"
+	errs "github.com/COS301-SE-2025/Swift-Signals/shared/error"
+	"github.com/COS301-SE-2025/Swift-Signals/shared/events"
+	"github.com/stretchr/testify/mock"
+)
+
+type receivedRequest struct {
+	header http.Header
+	body   []byte
+}
+
+// startReceiver serves webhook deliveries, answering them with the given status codes in
+// turn and with the last one after that
+func (suite *TestSuite) startReceiver(
+	statuses ...int,
+) (*httptest.Server, func() []receivedRequest) {
+	var mu sync.Mutex
+	received := []receivedRequest{}
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		body, _ := io.ReadAll(r.Body)
+		mu.Lock()
+		received = append(received, receivedRequest{header: r.Header.Clone(), body: body})
+		status := statuses[min(len(received), len(statuses))-1]
+		mu.Unlock()
+		w.WriteHeader(status)
+	}))
+	suite.T().Cleanup(server.Close)
+
+	return server, func() []receivedRequest {
+		mu.Lock()
+		defer mu.Unlock()
+		return received
+	}
+}
+
+func (suite *TestSuite) newEvent(eventType events.Type, intersectionID string) events.Event {
+	event, err := events.NewEvent(eventType, "job-1", events.OptimisationPayload{
+		JobID:          "job-1",
+		IntersectionID: intersectionID,
+		UserID:         "user-1",
+		Improved:       true,
+	})
+	suite.Require().NoError(err)
+	return event
+}
+
+func (suite *TestSuite) TestDispatch_DeliversSignedEvent() {
+	server, received := suite.startReceiver(http.StatusOK)
+	webhook := suite.addWebhook("hook-1", "user-1", server.URL, "optimisation.completed")
+	suite.expectUserIntersections("user-1", "int-1")
+	event := suite.newEvent(events.OptimisationCompleted, "int-1")
+
+	suite.Require().NoError(suite.dispatcher.HandleEvent(suite.ctx, event))
+	suite.deliverAll(suite.dispatcher)
+
+	requests := received()
+	suite.Require().Len(requests, 1)
+	req := requests[0]
+	suite.Equal(
+		service.SignWebhookPayload(webhook.Secret, req.body),
+		req.header.Get(service.WebhookSignatureHeader),
+	)
+	suite.Equal("optimisation.completed", req.header.Get(service.WebhookEventHeader))
+	suite.Equal(event.ID, req.header.Get(service.WebhookEventIDHeader))
+	suite.Equal("1", req.header.Get(service.WebhookAttemptHeader))
+	suite.Equal("application/json", req.header.Get("Content-Type"))
+	suite.Contains(string(req.body), `"intersection_id":"int-1"`)
+
+	deliveries, err := suite.store.GetDeliveries(suite.ctx, "hook-1", 10)
+	suite.Require().NoError(err)
+	suite.Require().Len(deliveries, 1)
+	suite.True(deliveries[0].Success)
+	suite.Equal(http.StatusOK, deliveries[0].StatusCode)
+	suite.Equal(event.ID, deliveries[0].EventID)
+	suite.Equal(1, deliveries[0].Attempt)
+}
+
+func (suite *TestSuite) TestSignWebhookPayload_DependsOnSecretAndBody() {
+	body := []byte(`{"id":"event-1"}`)
+
+	signature := service.SignWebhookPayload("whsec_a", body)
+
+	suite.Regexp(`^sha256=[0-9a-f]{64}$`, signature)
+	suite.Equal(signature, service.SignWebhookPayload("whsec_a", body))
+	suite.NotEqual(signature, service.SignWebhookPayload("whsec_b", body))
+	suite.NotEqual(signature, service.SignWebhookPayload("whsec_a", []byte(`{"id":"event-2"}`)))
+}
+
+func (suite *TestSuite) TestDispatch_RetriesServerErrors() {
+	server, received := suite.startReceiver(
+		http.StatusServiceUnavailable,
+		http.StatusTooManyRequests,
+		http.StatusNoContent,
+	)
+	suite.addWebhook("hook-1", "user-1", server.URL, "optimisation.failed")
+	suite.expectUserIntersections("user-1", "int-1")
+
+	err := suite.dispatcher.HandleEvent(
+		suite.ctx,
+		suite.newEvent(events.OptimisationFailed, "int-1"),
+	)
+	suite.Require().NoError(err)
+	suite.deliverAll(suite.dispatcher)
+
+	requests := received()
+	suite.Require().Len(requests, 3)
+	suite.Equal("3", requests[2].header.Get(service.WebhookAttemptHeader))
+
+	deliveries, err := suite.store.GetDeliveries(suite.ctx, "hook-1", 10)
+	suite.Require().NoError(err)
+	suite.Require().Len(deliveries, 3)
+	suite.True(deliveries[0].Success)
+	suite.Equal(3, deliveries[0].Attempt)
+	suite.Equal(http.StatusTooManyRequests, deliveries[1].StatusCode)
+	suite.False(deliveries[1].Success)
+	suite.Equal(http.StatusServiceUnavailable, deliveries[2].StatusCode)
+	suite.NotEmpty(deliveries[2].Error)
+}
+
+func (suite *TestSuite) TestDispatch_GivesUpAfterMaxAttempts() {
+	server, received := suite.startReceiver(http.StatusInternalServerError)
+	suite.addWebhook("hook-1", "user-1", server.URL, "optimisation.completed")
+	suite.expectUserIntersections("user-1", "int-1")
+
+	err := suite.dispatcher.HandleEvent(
+		suite.ctx,
+		suite.newEvent(events.OptimisationCompleted, "int-1"),
+	)
+	suite.Require().NoError(err)
+	suite.deliverAll(suite.dispatcher)
+
+	suite.Len(received(), 3)
+	deliveries, err := suite.store.GetDeliveries(suite.ctx, "hook-1", 10)
+	suite.Require().NoError(err)
+	suite.Len(deliveries, 3)
+	for _, delivery := range deliveries {
+		suite.False(delivery.Success)
+	}
+}
+
+func (suite *TestSuite) TestDispatch_DoesNotRetryClientErrors() {
+	server, received := suite.startReceiver(http.StatusGone)
+	suite.addWebhook("hook-1", "user-1", server.URL, "optimisation.completed")
+	suite.expectUserIntersections("user-1", "int-1")
+
+	err := suite.dispatcher.HandleEvent(
+		suite.ctx,
+		suite.newEvent(events.OptimisationCompleted, "int-1"),
+	)
+	suite.Require().NoError(err)
+	suite.deliverAll(suite.dispatcher)
+
+	suite.Len(received(), 1)
+	deliveries, err := suite.store.GetDeliveries(suite.ctx, "hook-1", 10)
+	suite.Require().NoError(err)
+	suite.Require().Len(deliveries, 1)
+	suite.Equal(http.StatusGone, deliveries[0].StatusCode)
+}
+
+func (suite *TestSuite) TestDispatch_RetriesUnreachableWebhook() {
+	server, _ := suite.startReceiver(http.StatusOK)
+	url := server.URL
+	server.Close()
+	suite.addWebhook("hook-1", "user-1", url, "optimisation.completed")
+	suite.expectUserIntersections("user-1", "int-1")
+
+	err := suite.dispatcher.HandleEvent(
+		suite.ctx,
+		suite.newEvent(events.OptimisationCompleted, "int-1"),
+	)
+	suite.Require().NoError(err)
+	suite.deliverAll(suite.dispatcher)
+
+	deliveries, err := suite.store.GetDeliveries(suite.ctx, "hook-1", 10)
+	suite.Require().NoError(err)
+	suite.Require().Len(deliveries, 3)
+	suite.Zero(deliveries[0].StatusCode)
+	suite.NotEmpty(deliveries[0].Error)
+}
+
+func (suite *TestSuite) TestDispatch_OnlyOwnersWebhooks() {
+	var calls atomic.Int32
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		calls.Add(1)
+	}))
+	suite.T().Cleanup(server.Close)
+	suite.addWebhook("hook-1", "user-1", server.URL, "intersection.deleted")
+	suite.addWebhook("hook-2", "user-2", server.URL, "intersection.deleted")
+	suite.addWebhook("hook-3", "user-2", server.URL, "intersection.deleted")
+	suite.expectUserIntersections("user-1", "int-1")
+	suite.expectUserIntersections("user-2", "int-2")
+
+	event, err := events.NewEvent(
+		events.IntersectionDeleted,
+		"int-1",
+		events.IntersectionDeletedPayload{IntersectionID: "int-1"},
+	)
+	suite.Require().NoError(err)
+
+	suite.Require().NoError(suite.dispatcher.HandleEvent(suite.ctx, event))
+	suite.deliverAll(suite.dispatcher)
+
+	suite.Equal(int32(1), calls.Load())
+	// NOTE: Each owner's intersections are looked up once per event
+	suite.userClient.AssertNumberOfCalls(suite.T(), "GetUserIntersectionIDs", 2)
+}
+
+func (suite *TestSuite) TestDispatch_SkipsDeletedOwner() {
+	server, received := suite.startReceiver(http.StatusOK)
+	suite.addWebhook("hook-1", "user-1", server.URL, "optimisation.completed")
+	suite.userClient.On("GetUserIntersectionIDs", mock.Anything, "user-1").
+		Return(nil, errs.NewNotFoundError("user not found", map[string]any{}))
+
+	err := suite.dispatcher.HandleEvent(
+		suite.ctx,
+		suite.newEvent(events.OptimisationCompleted, "int-1"),
+	)
+	suite.Require().NoError(err)
+	suite.deliverAll(suite.dispatcher)
+
+	suite.Empty(received())
+}
+
+func (suite *TestSuite) TestDispatch_UnsubscribedEvent() {
+	server, received := suite.startReceiver(http.StatusOK)
+	suite.addWebhook("hook-1", "user-1", server.URL, "optimisation.failed")
+
+	err := suite.dispatcher.HandleEvent(
+		suite.ctx,
+		suite.newEvent(events.OptimisationCompleted, "int-1"),
+	)
+	suite.Require().NoError(err)
+	suite.deliverAll(suite.dispatcher)
+
+	suite.Empty(received())
+	suite.userClient.AssertNotCalled(suite.T(), "GetUserIntersectionIDs", mock.Anything, mock.Anything)
+}
+
+func (suite *TestSuite) TestDispatch_ViaReceiver() {
+	server, received := suite.startReceiver(http.StatusOK)
+	suite.addWebhook("hook-1", "user-1", server.URL, "intersection.status_changed")
+	suite.expectUserIntersections("user-1", "int-1")
+
+	// NOTE: Events reach the dispatcher relayed from another service's outbox
+	receiver := httptest.NewServer(events.NewReceiver(suite.dispatcher.HandleEvent, "relay-secret"))
+	suite.T().Cleanup(receiver.Close)
+	broker := events.NewHTTPBroker([]string{receiver.URL}, "relay-secret", time.Second)
+
+	event, err := events.NewEvent(
+		events.IntersectionStatusChanged,
+		"int-1",
+		events.IntersectionStatusChangedPayload{
+			IntersectionID: "int-1",
+			Status:         "INTERSECTION_STATUS_OPTIMISED",
+		},
+	)
+	suite.Require().NoError(err)
+
+	suite.Require().NoError(broker.Publish(suite.ctx, event))
+	suite.deliverAll(suite.dispatcher)
+
+	requests := received()
+	suite.Require().Len(requests, 1)
+	suite.Contains(string(requests[0].body), `"status":"INTERSECTION_STATUS_OPTIMISED"`)
+}
+
+func (suite *TestSuite) TestDispatch_QueuesRelayedEventOnce() {
+	server, received := suite.startReceiver(http.StatusOK)
+	suite.addWebhook("hook-1", "user-1", server.URL, "optimisation.completed")
+	suite.expectUserIntersections("user-1", "int-1")
+	suite.expectUserIntersections("user-1", "int-1")
+	event := suite.newEvent(events.OptimisationCompleted, "int-1")
+
+	// NOTE: An outbox relays an event again when it cannot tell it was received
+	suite.Require().NoError(suite.dispatcher.HandleEvent(suite.ctx, event))
+	suite.Require().NoError(suite.dispatcher.HandleEvent(suite.ctx, event))
+	suite.deliverAll(suite.dispatcher)
+
+	suite.Len(received(), 1)
+}
+
+func (suite *TestSuite) TestDispatch_RefusesEventWhenOwnerUnknown() {
+	server, received := suite.startReceiver(http.StatusOK)
+	suite.addWebhook("hook-1", "user-1", server.URL, "optimisation.completed")
+	suite.userClient.On("GetUserIntersectionIDs", mock.Anything, "user-1").
+		Return(nil, errs.NewUnavailableError("user service unavailable", map[string]any{}))
+
+	err := suite.dispatcher.HandleEvent(
+		suite.ctx,
+		suite.newEvent(events.OptimisationCompleted, "int-1"),
+	)
+
+	// NOTE: Refused so that the event is relayed again, rather than dropped
+	suite.Require().Error(err)
+	suite.deliverAll(suite.dispatcher)
+	suite.Empty(received())
+}
+
+func (suite *TestSuite) TestDispatch_WaitsForBackoff() {
+	server, received := suite.startReceiver(http.StatusServiceUnavailable, http.StatusOK)
+	suite.addWebhook("hook-1", "user-1", server.URL, "optimisation.completed")
+	suite.expectUserIntersections("user-1", "int-1")
+	dispatcher := service.NewWebhookDispatcher(suite.store, suite.userClient, service.WebhookConfig{
+		MaxAttempts:    3,
+		InitialBackoff: time.Minute,
+		Timeout:        time.Second,
+		Addresses:      service.WebhookAddresses{AllowPrivate: true},
+	})
+
+	err := dispatcher.HandleEvent(suite.ctx, suite.newEvent(events.OptimisationCompleted, "int-1"))
+	suite.Require().NoError(err)
+
+	now := time.Now()
+	attempted, err := dispatcher.DeliverDue(suite.ctx, now)
+	suite.Require().NoError(err)
+	suite.Equal(1, attempted)
+
+	attempted, err = dispatcher.DeliverDue(suite.ctx, now.Add(30*time.Second))
+	suite.Require().NoError(err)
+	suite.Zero(attempted)
+
+	attempted, err = dispatcher.DeliverDue(suite.ctx, now.Add(time.Minute))
+	suite.Require().NoError(err)
+	suite.Equal(1, attempted)
+	suite.Len(received(), 2)
+}
+
+func (suite *TestSuite) TestDispatch_DropsDeliveriesOfDeletedWebhook() {
+	server, received := suite.startReceiver(http.StatusOK)
+	suite.addWebhook("hook-1", "user-1", server.URL, "optimisation.completed")
+	suite.expectUserIntersections("user-1", "int-1")
+
+	err := suite.dispatcher.HandleEvent(
+		suite.ctx,
+		suite.newEvent(events.OptimisationCompleted, "int-1"),
+	)
+	suite.Require().NoError(err)
+	suite.Require().NoError(suite.store.DeleteWebhook(suite.ctx, "user-1", "hook-1"))
+	suite.deliverAll(suite.dispatcher)
+
+	suite.Empty(received())
+}
+
+func (suite *TestSuite) TestDispatch_RefusesPrivateAddress() {
+	server, received := suite.startReceiver(http.StatusOK)
+	suite.addWebhook("hook-1", "user-1", server.URL, "optimisation.completed")
+	suite.expectUserIntersections("user-1", "int-1")
+	// NOTE: A webhook whose host resolved to a public address when it was created may
+	// resolve to a private one by the time it is delivered to
+	dispatcher := service.NewWebhookDispatcher(suite.store, suite.userClient, service.WebhookConfig{
+		MaxAttempts:    1,
+		InitialBackoff: time.Millisecond,
+		Timeout:        time.Second,
+	})
+
+	err := dispatcher.HandleEvent(suite.ctx, suite.newEvent(events.OptimisationCompleted, "int-1"))
+	suite.Require().NoError(err)
+	suite.deliverAll(dispatcher)
+
+	suite.Empty(received())
+	deliveries, err := suite.store.GetDeliveries(suite.ctx, "hook-1", 10)
+	suite.Require().NoError(err)
+	suite.Require().Len(deliveries, 1)
+	suite.False(deliveries[0].Success)
+	suite.Contains(deliveries[0].Error, "not public")
+}
+
+func (suite *TestSuite) TestDispatch_DoesNotFollowRedirects() {
+	target, received := suite.startReceiver(http.StatusOK)
+	redirect := httptest.NewServer(http.RedirectHandler(target.URL, http.StatusFound))
+	suite.T().Cleanup(redirect.Close)
+	suite.addWebhook("hook-1", "user-1", redirect.URL, "optimisation.completed")
+	suite.expectUserIntersections("user-1", "int-1")
+
+	err := suite.dispatcher.HandleEvent(
+		suite.ctx,
+		suite.newEvent(events.OptimisationCompleted, "int-1"),
+	)
+	suite.Require().NoError(err)
+	suite.deliverAll(suite.dispatcher)
+
+	suite.Empty(received())
+	deliveries, err := suite.store.GetDeliveries(suite.ctx, "hook-1", 10)
+	suite.Require().NoError(err)
+	suite.Require().Len(deliveries, 1)
+	suite.Equal(http.StatusFound, deliveries[0].StatusCode)
+	suite.False(deliveries[0].Success)
+}
@@ -0,0 +1,163 @@
+package webhook
+
+import (
+	"errors"
+	"strings"
+
+	"github.com/COS301-SE-2025/Swift-Signals/api-gateway/internal/model"
+	errs "github.com/COS301-SE-2025/Swift-Signals/shared/error"
+	"github.com/stretchr/testify/mock"
+)
+
+func (suite *TestSuite) TestCreateWebhook_Success() {
+	req := model.CreateWebhookRequest{
+		URL:    "https://ops.example.com/hook",
+		Events: []string{"optimisation.completed", "optimisation.failed", "optimisation.completed"},
+	}
+
+	suite.repo.On("GetWebhooks", suite.ctx, "user-1").Return([]model.Webhook{}, nil)
+	suite.repo.On("CreateWebhook", suite.ctx, mock.MatchedBy(func(w model.Webhook) bool {
+		return w.UserID == "user-1" && w.URL == req.URL && w.ID != "" &&
+			strings.HasPrefix(w.Secret, "whsec_")
+	})).Return(nil)
+
+	webhook, err := suite.service.CreateWebhook(suite.ctx, "user-1", req)
+
+	suite.Require().NoError(err)
+	suite.NotEmpty(webhook.ID)
+	suite.Equal(req.URL, webhook.URL)
+	suite.Equal([]string{"optimisation.completed", "optimisation.failed"}, webhook.Events)
+	suite.True(strings.HasPrefix(webhook.Secret, "whsec_"))
+	suite.False(webhook.CreatedAt.IsZero())
+	suite.repo.AssertExpectations(suite.T())
+}
+
+func (suite *TestSuite) TestCreateWebhook_UniqueSecrets() {
+	req := model.CreateWebhookRequest{
+		URL:    "https://ops.example.com/hook",
+		Events: []string{"intersection.deleted"},
+	}
+
+	suite.repo.On("GetWebhooks", suite.ctx, "user-1").Return([]model.Webhook{}, nil)
+	suite.repo.On("CreateWebhook", suite.ctx, mock.Anything).Return(nil)
+
+	first, err := suite.service.CreateWebhook(suite.ctx, "user-1", req)
+	suite.Require().NoError(err)
+	second, err := suite.service.CreateWebhook(suite.ctx, "user-1", req)
+	suite.Require().NoError(err)
+
+	suite.NotEqual(first.ID, second.ID)
+	suite.NotEqual(first.Secret, second.Secret)
+}
+
+func (suite *TestSuite) TestCreateWebhook_LimitReached() {
+	existing := make([]model.Webhook, 10)
+	suite.repo.On("GetWebhooks", suite.ctx, "user-1").Return(existing, nil)
+
+	_, err := suite.service.CreateWebhook(suite.ctx, "user-1", model.CreateWebhookRequest{
+		URL:    "https://ops.example.com/hook",
+		Events: []string{"optimisation.completed"},
+	})
+
+	suite.Require().Error(err)
+	var svcErr *errs.ServiceError
+	suite.Require().True(errors.As(err, &svcErr))
+	suite.Equal(errs.ErrValidation, svcErr.Code)
+	suite.repo.AssertNotCalled(suite.T(), "CreateWebhook", mock.Anything, mock.Anything)
+}
+
+func (suite *TestSuite) TestCreateWebhook_RepositoryError() {
+	suite.repo.On("GetWebhooks", suite.ctx, "user-1").Return([]model.Webhook{}, nil)
+	suite.repo.On("CreateWebhook", suite.ctx, mock.Anything).
+		Return(errs.NewDatabaseError("failed to create webhook", nil, map[string]any{}))
+
+	_, err := suite.service.CreateWebhook(suite.ctx, "user-1", model.CreateWebhookRequest{
+		URL:    "https://ops.example.com/hook",
+		Events: []string{"optimisation.completed"},
+	})
+
+	suite.Require().Error(err)
+	suite.Contains(err.Error(), "failed to create webhook")
+}
+
+func (suite *TestSuite) TestGetWebhooks_HidesSecrets() {
+	suite.repo.On("GetWebhooks", suite.ctx, "user-1").Return([]model.Webhook{
+		{ID: "hook-1", UserID: "user-1", URL: "https://a.example.com", Secret: "whsec_a"},
+		{ID: "hook-2", UserID: "user-1", URL: "https://b.example.com", Secret: "whsec_b"},
+	}, nil)
+
+	result, err := suite.service.GetWebhooks(suite.ctx, "user-1")
+
+	suite.Require().NoError(err)
+	suite.Require().Len(result.Webhooks, 2)
+	for _, webhook := range result.Webhooks {
+		suite.Empty(webhook.Secret)
+	}
+}
+
+func (suite *TestSuite) TestDeleteWebhook_NotFound() {
+	suite.repo.On("DeleteWebhook", suite.ctx, "user-1", "hook-1").
+		Return(errs.NewNotFoundError("webhook not found", map[string]any{}))
+
+	err := suite.service.DeleteWebhook(suite.ctx, "user-1", "hook-1")
+
+	suite.Require().Error(err)
+	suite.Contains(err.Error(), "webhook not found")
+}
+
+func (suite *TestSuite) TestGetWebhookDeliveries_Success() {
+	deliveries := []model.WebhookDelivery{
+		{ID: "delivery-2", WebhookID: "hook-1", Attempt: 2, Success: true},
+		{ID: "delivery-1", WebhookID: "hook-1", Attempt: 1, StatusCode: 503},
+	}
+	suite.repo.On("GetWebhook", suite.ctx, "user-1", "hook-1").
+		Return(&model.Webhook{ID: "hook-1", UserID: "user-1"}, nil)
+	suite.repo.On("GetDeliveries", suite.ctx, "hook-1", 20).Return(deliveries, nil)
+
+	result, err := suite.service.GetWebhookDeliveries(suite.ctx, "user-1", "hook-1", 20)
+
+	suite.Require().NoError(err)
+	suite.Equal(deliveries, result.Deliveries)
+	suite.repo.AssertExpectations(suite.T())
+}
+
+func (suite *TestSuite) TestGetWebhookDeliveries_NotOwner() {
+	suite.repo.On("GetWebhook", suite.ctx, "user-2", "hook-1").
+		Return(nil, errs.NewNotFoundError("webhook not found", map[string]any{}))
+
+	_, err := suite.service.GetWebhookDeliveries(suite.ctx, "user-2", "hook-1", 20)
+
+	suite.Require().Error(err)
+	suite.Contains(err.Error(), "webhook not found")
+	suite.repo.AssertNotCalled(
+		suite.T(),
+		"GetDeliveries",
+		mock.Anything,
+		mock.Anything,
+		mock.Anything,
+	)
+}
+
+func (suite *TestSuite) TestCreateWebhook_RefusedURLs() {
+	for _, url := range []string{
+		"http://ops.example.com/hook",
+		"https://127.0.0.1/hook",
+		"https://169.254.169.254/latest/meta-data",
+		"https://[::1]/hook",
+		"https://[::ffff:10.0.0.1]/hook",
+		"https://100.100.100.200/hook",
+		"https://internal.example.com/hook",
+		"https://unknown.example.com/hook",
+	} {
+		_, err := suite.service.CreateWebhook(suite.ctx, "user-1", model.CreateWebhookRequest{
+			URL:    url,
+			Events: []string{"optimisation.completed"},
+		})
+
+		suite.Require().Error(err, url)
+		var svcErr *errs.ServiceError
+		suite.Require().True(errors.As(err, &svcErr), url)
+		suite.Equal(errs.ErrValidation, svcErr.Code, url)
+	}
+	suite.repo.AssertNotCalled(suite.T(), "CreateWebhook", mock.Anything, mock.Anything)
+}
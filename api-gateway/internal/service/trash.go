@@ -0,0 +1,88 @@
+package service
+
+import (
+	"context"
+	"io"
+	"slices"
+	"strings"
+
+	"github.com/COS301-SE-2025/Swift-Signals/api-gateway/internal/middleware"
+	"github.com/COS301-SE-2025/Swift-Signals/api-gateway/internal/model"
+	"github.com/COS301-SE-2025/Swift-Signals/api-gateway/internal/util"
+	errs "github.com/COS301-SE-2025/Swift-Signals/shared/error"
+)
+
+// GetDeletedIntersections returns the user's intersections that are in the trash
+func (s *IntersectionService) GetDeletedIntersections(
+	ctx context.Context,
+	userID string,
+) (model.Intersections, error) {
+	logger := middleware.LoggerFromContext(ctx).With(
+		"service", "intersection",
+	)
+
+	logger.Debug("calling user service to retrieve user's intersection list")
+	idList, err := s.GetUserIntersectionIDs(ctx, userID)
+	if err != nil {
+		return model.Intersections{}, err
+	}
+	if len(idList) == 0 {
+		return model.Intersections{Intersections: []model.Intersection{}}, nil
+	}
+
+	logger.Debug("starting grpc stream")
+	stream, err := s.intrClient.GetDeletedIntersections(ctx, strings.Join(idList, ","))
+	if err != nil {
+		return model.Intersections{}, err
+	}
+
+	intersections := []model.Intersection{}
+	for {
+		rpcIntersection, err := stream.Recv()
+		if err == io.EOF {
+			break
+		}
+		if err != nil {
+			return model.Intersections{}, errs.NewInternalError(
+				"unable to get deleted intersections",
+				err,
+				map[string]any{},
+			)
+		}
+		intersections = append(intersections, util.RPCIntersectionToIntersection(rpcIntersection))
+	}
+
+	return model.Intersections{Intersections: intersections}, nil
+}
+
+// RestoreIntersectionByID takes one of the user's intersections back out of the trash
+func (s *IntersectionService) RestoreIntersectionByID(
+	ctx context.Context,
+	userID string,
+	intersectionID string,
+) (model.Intersection, error) {
+	logger := middleware.LoggerFromContext(ctx).With(
+		"service", "intersection",
+	)
+
+	logger.Debug("calling user service to retrieve user's intersection list")
+	ids, err := s.GetUserIntersectionIDs(ctx, userID)
+	if err != nil {
+		return model.Intersection{}, err
+	}
+
+	if !slices.Contains(ids, intersectionID) {
+		return model.Intersection{}, errs.NewForbiddenError(
+			"intersection not in user's intersection list",
+			map[string]any{},
+		)
+	}
+
+	logger.Debug("calling intersection client to restore intersection")
+	pbResp, err := s.intrClient.RestoreIntersection(ctx, intersectionID)
+	if err != nil {
+		return model.Intersection{}, err
+	}
+
+	return util.RPCIntersectionToIntersection(pbResp), nil
+}
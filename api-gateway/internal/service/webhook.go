@@ -0,0 +1,173 @@
+package service
+
+import (
+	"context"
+	"crypto/rand"
+	"encoding/hex"
+	"time"
+
+	"github.com/COS301-SE-2025/Swift-Signals/api-gateway/internal/middleware"
+	"github.com/COS301-SE-2025/Swift-Signals/api-gateway/internal/model"
+	"github.com/COS301-SE-2025/Swift-Signals/api-gateway/internal/repository"
+	errs "github.com/COS301-SE-2025/Swift-Signals/shared/error"
+	"github.com/google/uuid"
+)
+
+// maxWebhooksPerUser keeps a user from fanning each of their events out to many URLs
+const maxWebhooksPerUser = 10
+
+type WebhookService struct {
+	repo      repository.WebhookRepositoryInterface
+	addresses WebhookAddresses
+}
+
+func NewWebhookService(
+	repo repository.WebhookRepositoryInterface,
+	addresses WebhookAddresses,
+) WebhookServiceInterface {
+	return &WebhookService{
+		repo:      repo,
+		addresses: addresses,
+	}
+}
+
+// CreateWebhook subscribes a URL to the given types of event on the user's intersections.
+// The returned secret signs every delivery and cannot be retrieved again.
+func (s *WebhookService) CreateWebhook(
+	ctx context.Context,
+	userID string,
+	req model.CreateWebhookRequest,
+) (model.Webhook, error) {
+	logger := middleware.LoggerFromContext(ctx).With(
+		"service", "webhook",
+	)
+
+	logger.Debug("checking webhook URL")
+	if err := s.addresses.CheckURL(ctx, req.URL); err != nil {
+		return model.Webhook{}, err
+	}
+
+	logger.Debug("retrieving user's webhooks")
+	existing, err := s.repo.GetWebhooks(ctx, userID)
+	if err != nil {
+		return model.Webhook{}, err
+	}
+	if len(existing) >= maxWebhooksPerUser {
+		return model.Webhook{}, errs.NewValidationError(
+			"webhook limit reached",
+			map[string]any{"max_webhooks": maxWebhooksPerUser},
+		)
+	}
+
+	secret, err := newWebhookSecret()
+	if err != nil {
+		return model.Webhook{}, errs.NewInternalError(
+			"failed to generate webhook secret",
+			err,
+			map[string]any{},
+		)
+	}
+
+	webhook := model.Webhook{
+		ID:        uuid.NewString(),
+		UserID:    userID,
+		URL:       req.URL,
+		Events:    uniqueEvents(req.Events),
+		Secret:    secret,
+		CreatedAt: time.Now().UTC().Truncate(time.Millisecond),
+	}
+
+	logger.Debug("saving webhook")
+	if err := s.repo.CreateWebhook(ctx, webhook); err != nil {
+		return model.Webhook{}, err
+	}
+	return webhook, nil
+}
+
+func (s *WebhookService) GetWebhooks(ctx context.Context, userID string) (model.Webhooks, error) {
+	logger := middleware.LoggerFromContext(ctx).With(
+		"service", "webhook",
+	)
+
+	logger.Debug("retrieving user's webhooks")
+	webhooks, err := s.repo.GetWebhooks(ctx, userID)
+	if err != nil {
+		return model.Webhooks{}, err
+	}
+	for i := range webhooks {
+		webhooks[i].Secret = ""
+	}
+	return model.Webhooks{Webhooks: webhooks}, nil
+}
+
+func (s *WebhookService) DeleteWebhook(ctx context.Context, userID, id string) error {
+	logger := middleware.LoggerFromContext(ctx).With(
+		"service", "webhook",
+	)
+
+	logger.Debug("deleting webhook")
+	return s.repo.DeleteWebhook(ctx, userID, id)
+}
+
+// GetWebhookDeliveries returns the most recent attempts at delivering events to one of the
+// user's webhooks, newest first
+func (s *WebhookService) GetWebhookDeliveries(
+	ctx context.Context,
+	userID, id string,
+	limit int,
+) (model.WebhookDeliveries, error) {
+	logger := middleware.LoggerFromContext(ctx).With(
+		"service", "webhook",
+	)
+
+	logger.Debug("checking webhook belongs to user")
+	if _, err := s.repo.GetWebhook(ctx, userID, id); err != nil {
+		return model.WebhookDeliveries{}, err
+	}
+
+	logger.Debug("retrieving webhook deliveries")
+	deliveries, err := s.repo.GetDeliveries(ctx, id, limit)
+	if err != nil {
+		return model.WebhookDeliveries{}, err
+	}
+	return model.WebhookDeliveries{Deliveries: deliveries}, nil
+}
+
+func newWebhookSecret() (string, error) {
+	secret := make([]byte, 32)
+	if _, err := rand.Read(secret); err != nil {
+		return "", err
+	}
+	return "whsec_" + hex.EncodeToString(secret), nil
+}
+
+func uniqueEvents(eventTypes []string) []string {
+	seen := map[string]bool{}
+	unique := []string{}
+	for _, eventType := range eventTypes {
+		if !seen[eventType] {
+			seen[eventType] = true
+			unique = append(unique, eventType)
+		}
+	}
+	return unique
+}
+
+// WebhookServiceInterface creates stub for testing
+type WebhookServiceInterface interface {
+	CreateWebhook(
+		ctx context.Context,
+		userID string,
+		req model.CreateWebhookRequest,
+	) (model.Webhook, error)
+	GetWebhooks(ctx context.Context, userID string) (model.Webhooks, error)
+	DeleteWebhook(ctx context.Context, userID, id string) error
+	GetWebhookDeliveries(
+		ctx context.Context,
+		userID, id string,
+		limit int,
+	) (model.WebhookDeliveries, error)
+}
+
+// NOTE: Asserts Interface Implementation
+var _ WebhookServiceInterface = (*WebhookService)(nil)
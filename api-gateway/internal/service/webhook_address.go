@@ -0,0 +1,144 @@
+package service
+
+import (
+	"context"
+	"fmt"
+	"net"
+	"net/http"
+	"net/netip"
+	"net/url"
+	"syscall"
+	"time"
+
+	errs "github.com/COS301-SE-2025/Swift-Signals/shared/error"
+)
+
+// Resolver looks up the addresses of a webhook's host, as *net.Resolver does
+type Resolver interface {
+	LookupIPAddr(ctx context.Context, host string) ([]net.IPAddr, error)
+}
+
+// WebhookAddresses decides where webhooks may be delivered. Only https URLs on public
+// addresses are allowed, so that a webhook cannot be aimed at the gateway's own network or
+// a cloud metadata endpoint.
+type WebhookAddresses struct {
+	// AllowPrivate lets webhooks use plain http and reach loopback and private addresses,
+	// for development and tests only
+	AllowPrivate bool
+	// Resolver looks up webhook hosts, net.DefaultResolver when nil
+	Resolver Resolver
+}
+
+// NOTE: Ranges that are not loopback, private or link-local but still never lead to a
+// public webhook receiver
+var reservedPrefixes = []netip.Prefix{
+	netip.MustParsePrefix("0.0.0.0/8"),
+	netip.MustParsePrefix("100.64.0.0/10"), // Carrier-grade NAT, and some cloud metadata endpoints
+	netip.MustParsePrefix("192.0.0.0/24"),
+	netip.MustParsePrefix("198.18.0.0/15"),
+	netip.MustParsePrefix("240.0.0.0/4"),
+	netip.MustParsePrefix("64:ff9b::/96"), // NAT64, which can reach any IPv4 address
+	netip.MustParsePrefix("2002::/16"),    // 6to4, which embeds an IPv4 address
+}
+
+// IsPublic reports whether a webhook may be delivered to the address
+func (a WebhookAddresses) IsPublic(ip netip.Addr) bool {
+	ip = ip.Unmap()
+	if !ip.IsValid() || !ip.IsGlobalUnicast() || ip.IsPrivate() {
+		return false
+	}
+	for _, prefix := range reservedPrefixes {
+		if prefix.Contains(ip) {
+			return false
+		}
+	}
+	return true
+}
+
+// CheckURL refuses a webhook URL that is not https or whose host resolves to an address
+// webhooks may not be delivered to
+func (a WebhookAddresses) CheckURL(ctx context.Context, rawURL string) error {
+	u, err := url.Parse(rawURL)
+	if err != nil || u.Hostname() == "" {
+		return errs.NewValidationError("invalid webhook URL", map[string]any{"url": rawURL})
+	}
+	if u.Scheme != "https" && !(a.AllowPrivate && u.Scheme == "http") {
+		return errs.NewValidationError("webhook URL must use https", map[string]any{"url": rawURL})
+	}
+	if a.AllowPrivate {
+		return nil
+	}
+
+	host := u.Hostname()
+	var ips []netip.Addr
+	if ip, err := netip.ParseAddr(host); err == nil {
+		ips = []netip.Addr{ip}
+	} else {
+		resolver := a.Resolver
+		if resolver == nil {
+			resolver = net.DefaultResolver
+		}
+		addrs, err := resolver.LookupIPAddr(ctx, host)
+		if err != nil || len(addrs) == 0 {
+			return errs.NewValidationError(
+				"webhook host could not be resolved",
+				map[string]any{"host": host},
+			)
+		}
+		for _, addr := range addrs {
+			ip, _ := netip.AddrFromSlice(addr.IP)
+			ips = append(ips, ip)
+		}
+	}
+
+	// NOTE: Every address must be public, as the one dialled may be any of them
+	for _, ip := range ips {
+		if !a.IsPublic(ip) {
+			return errs.NewValidationError(
+				"webhook URL must not resolve to a private address",
+				map[string]any{"host": host},
+			)
+		}
+	}
+	return nil
+}
+
+// control refuses connections to addresses webhooks may not be delivered to. It runs once
+// the host has been resolved, so a host that resolves differently than when the webhook was
+// created is still caught.
+func (a WebhookAddresses) control(network, address string, _ syscall.RawConn) error {
+	if a.AllowPrivate {
+		return nil
+	}
+	addrPort, err := netip.ParseAddrPort(address)
+	if err != nil {
+		return fmt.Errorf("webhook address %q: %w", address, err)
+	}
+	if !a.IsPublic(addrPort.Addr()) {
+		return fmt.Errorf("webhook address %s is not public", addrPort.Addr())
+	}
+	return nil
+}
+
+// httpClient delivers webhooks only to allowed addresses. Redirects are not followed, as
+// they could lead anywhere, and the environment's proxy is not used, as the address checked
+// would then be the proxy's.
+func (a WebhookAddresses) httpClient(timeout time.Duration) *http.Client {
+	dialer := &net.Dialer{
+		Timeout: timeout,
+		Control: a.control,
+	}
+	return &http.Client{
+		Timeout: timeout,
+		Transport: &http.Transport{
+			DialContext:         dialer.DialContext,
+			ForceAttemptHTTP2:   true,
+			TLSHandshakeTimeout: timeout,
+			MaxIdleConns:        100,
+			IdleConnTimeout:     90 * time.Second,
+		},
+		CheckRedirect: func(*http.Request, []*http.Request) error {
+			return http.ErrUseLastResponse
+		},
+	}
+}
@@ -0,0 +1,330 @@
+package service
+
+import (
+	"bytes"
+	"context"
+	"crypto/hmac"
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"io"
+	"net/http"
+	"slices"
+	"strconv"
+	"sync/atomic"
+	"time"
+
+	"github.com/COS301-SE-2025/Swift-Signals/api-gateway/internal/client"
+	"github.com/COS301-SE-2025/Swift-Signals/api-gateway/internal/middleware"
+	"github.com/COS301-SE-2025/Swift-Signals/api-gateway/internal/model"
+	"github.com/COS301-SE-2025/Swift-Signals/api-gateway/internal/repository"
+	"github.com/COS301-SE-2025/Swift-Signals/api-gateway/internal/util"
+	"github.com/COS301-SE-2025/Swift-Signals/shared/events"
+	"github.com/google/uuid"
+	"golang.org/x/sync/errgroup"
+)
+
+// Headers sent with every webhook delivery
+const (
+	WebhookEventHeader     = "X-Swift-Signals-Event"
+	WebhookEventIDHeader   = "X-Swift-Signals-Event-ID"
+	WebhookAttemptHeader   = "X-Swift-Signals-Attempt"
+	WebhookSignatureHeader = "X-Swift-Signals-Signature"
+)
+
+type WebhookConfig struct {
+	// MaxAttempts is the number of times an event is sent to a webhook before giving up
+	MaxAttempts int
+	// InitialBackoff is the wait before the first retry, doubling before each one after
+	InitialBackoff time.Duration
+	// Timeout is the deadline for a webhook to respond to a single attempt
+	Timeout time.Duration
+	// Concurrency is the number of deliveries attempted at a time, so that a slow webhook
+	// does not hold up the rest
+	Concurrency int
+	// Addresses are where webhooks may be delivered, checked again as each is dialled
+	Addresses WebhookAddresses
+}
+
+// WebhookDispatcher delivers events on users' intersections to the webhooks they have
+// subscribed to them. Each delivery is queued in the repository and retried from there with
+// exponential backoff, so that a gateway that restarts carries on with it, and every
+// attempt is recorded in the delivery log.
+type WebhookDispatcher struct {
+	repo       repository.WebhookRepositoryInterface
+	userClient client.UserClientInterface
+	httpClient *http.Client
+	config     WebhookConfig
+	wake       chan struct{}
+}
+
+func NewWebhookDispatcher(
+	repo repository.WebhookRepositoryInterface,
+	uc client.UserClientInterface,
+	config WebhookConfig,
+) *WebhookDispatcher {
+	return &WebhookDispatcher{
+		repo:       repo,
+		userClient: uc,
+		httpClient: config.Addresses.httpClient(config.Timeout),
+		config:     config,
+		wake:       make(chan struct{}, 1),
+	}
+}
+
+// SignWebhookPayload returns the signature header value of a delivery's body, so that a
+// receiver holding the webhook's secret can check the delivery came from the gateway
+func SignWebhookPayload(secret string, body []byte) string {
+	mac := hmac.New(sha256.New, []byte(secret))
+	mac.Write(body)
+	return "sha256=" + hex.EncodeToString(mac.Sum(nil))
+}
+
+// HandleEvent queues an event for delivery to the webhooks subscribed to it whose owners
+// own its intersection. It is an events.Handler, and fails when the event could not be
+// queued so that it is relayed again.
+func (d *WebhookDispatcher) HandleEvent(ctx context.Context, event events.Event) error {
+	logger := middleware.LoggerFromContext(ctx).With(
+		"service", "webhook",
+		"event_id", event.ID,
+		"event_type", event.Type,
+	)
+
+	var payload struct {
+		IntersectionID string `json:"intersection_id"`
+	}
+	if err := event.Decode(&payload); err != nil {
+		return err
+	}
+	// NOTE: Only events on an intersection can be subscribed to
+	if payload.IntersectionID == "" {
+		return nil
+	}
+
+	webhooks, err := d.repo.GetWebhooksForEvent(ctx, string(event.Type))
+	if err != nil {
+		return err
+	}
+
+	// NOTE: Ownership is checked now rather than when delivering, as deleting a user
+	// deletes their intersections first and the user straight after
+	owners := map[string]bool{}
+	queued := 0
+	for _, webhook := range webhooks {
+		owns, checked := owners[webhook.UserID]
+		if !checked {
+			owns, err = d.ownsIntersection(ctx, webhook.UserID, payload.IntersectionID)
+			if err != nil {
+				return err
+			}
+			owners[webhook.UserID] = owns
+		}
+		if !owns {
+			continue
+		}
+
+		err := d.repo.AddPendingDelivery(ctx, model.PendingDelivery{
+			ID:            webhook.ID + ":" + event.ID,
+			WebhookID:     webhook.ID,
+			UserID:        webhook.UserID,
+			Event:         event,
+			NextAttemptAt: time.Now(),
+		})
+		if err != nil {
+			return err
+		}
+		queued++
+	}
+
+	if queued > 0 {
+		logger.Debug("queued webhook deliveries", "count", queued)
+		select {
+		case d.wake <- struct{}{}:
+		default:
+		}
+	}
+	return nil
+}
+
+func (d *WebhookDispatcher) ownsIntersection(
+	ctx context.Context,
+	userID, intersectionID string,
+) (bool, error) {
+	// NOTE: The webhooks of deleted users are not delivered to
+	stream, err := d.userClient.GetUserIntersectionIDs(ctx, userID)
+	if err != nil {
+		if isNotFound(err) {
+			return false, nil
+		}
+		return false, err
+	}
+
+	intersectionIDs := []string{}
+	for {
+		intID, err := stream.Recv()
+		if err == io.EOF {
+			break
+		}
+		if err != nil {
+			svcErr := util.GrpcErrorToErr(err)
+			if isNotFound(svcErr) {
+				return false, nil
+			}
+			return false, svcErr
+		}
+		intersectionIDs = append(intersectionIDs, intID.IntersectionId)
+	}
+	return slices.Contains(intersectionIDs, intersectionID), nil
+}
+
+// DeliverDue makes an attempt at every queued delivery due by now, returning how many it
+// made. Deliveries that fail and are worth retrying are queued again after a backoff.
+func (d *WebhookDispatcher) DeliverDue(ctx context.Context, now time.Time) (int, error) {
+	var attempted atomic.Int64
+	g, gctx := errgroup.WithContext(ctx)
+	for range max(d.config.Concurrency, 1) {
+		g.Go(func() error {
+			for {
+				// NOTE: Claimed for longer than an attempt can take, so that another
+				// gateway does not make the same attempt meanwhile
+				pending, err := d.repo.ClaimPendingDelivery(
+					gctx,
+					now,
+					2*d.config.Timeout+time.Minute,
+				)
+				if err != nil || pending == nil {
+					return err
+				}
+				if err := d.deliver(gctx, pending, now); err != nil {
+					return err
+				}
+				attempted.Add(1)
+			}
+		})
+	}
+	err := g.Wait()
+	return int(attempted.Load()), err
+}
+
+// deliver makes the next attempt at a queued delivery, dropping it once the webhook accepts
+// it, rejects it outright or the attempts run out
+func (d *WebhookDispatcher) deliver(
+	ctx context.Context,
+	pending *model.PendingDelivery,
+	now time.Time,
+) error {
+	logger := middleware.LoggerFromContext(ctx).With(
+		"service", "webhook",
+		"webhook_id", pending.WebhookID,
+		"event_id", pending.Event.ID,
+	)
+
+	webhook, err := d.repo.GetWebhook(ctx, pending.UserID, pending.WebhookID)
+	if err != nil {
+		// NOTE: The webhook was deleted after the event was queued
+		if isNotFound(err) {
+			return d.repo.DeletePendingDelivery(ctx, pending.ID)
+		}
+		return err
+	}
+
+	attempt := pending.Attempts + 1
+	delivery, retry := d.attempt(ctx, *webhook, pending.Event, attempt)
+	if err := d.repo.AddDelivery(ctx, delivery); err != nil {
+		logger.Error("could not record webhook delivery", "error", err.Error())
+	}
+
+	if delivery.Success {
+		return d.repo.DeletePendingDelivery(ctx, pending.ID)
+	}
+	if !retry || attempt >= d.config.MaxAttempts {
+		logger.Warn("webhook delivery failed",
+			"attempts", attempt,
+			"status_code", delivery.StatusCode,
+			"error", delivery.Error,
+		)
+		return d.repo.DeletePendingDelivery(ctx, pending.ID)
+	}
+
+	backoff := d.config.InitialBackoff << (attempt - 1)
+	return d.repo.ReschedulePendingDelivery(ctx, pending.ID, attempt, now.Add(backoff))
+}
+
+// attempt makes a single delivery, reporting whether a failure is worth retrying
+func (d *WebhookDispatcher) attempt(
+	ctx context.Context,
+	webhook model.Webhook,
+	event events.Event,
+	attempt int,
+) (model.WebhookDelivery, bool) {
+	delivery := model.WebhookDelivery{
+		ID:          uuid.NewString(),
+		WebhookID:   webhook.ID,
+		EventID:     event.ID,
+		EventType:   string(event.Type),
+		Attempt:     attempt,
+		AttemptedAt: time.Now().UTC().Truncate(time.Millisecond),
+	}
+
+	body, err := json.Marshal(event)
+	if err != nil {
+		delivery.Error = err.Error()
+		return delivery, false
+	}
+	req, err := http.NewRequestWithContext(
+		ctx,
+		http.MethodPost,
+		webhook.URL,
+		bytes.NewReader(body),
+	)
+	if err != nil {
+		delivery.Error = err.Error()
+		return delivery, false
+	}
+	req.Header.Set("Content-Type", "application/json")
+	req.Header.Set(WebhookEventHeader, string(event.Type))
+	req.Header.Set(WebhookEventIDHeader, event.ID)
+	req.Header.Set(WebhookAttemptHeader, strconv.Itoa(attempt))
+	req.Header.Set(WebhookSignatureHeader, SignWebhookPayload(webhook.Secret, body))
+
+	start := time.Now()
+	resp, err := d.httpClient.Do(req)
+	delivery.DurationMs = time.Since(start).Milliseconds()
+	if err != nil {
+		delivery.Error = err.Error()
+		return delivery, true
+	}
+	defer resp.Body.Close()
+	// NOTE: Drained so the connection can be reused
+	_, _ = io.Copy(io.Discard, io.LimitReader(resp.Body, 64<<10))
+
+	delivery.StatusCode = resp.StatusCode
+	delivery.Success = resp.StatusCode >= 200 && resp.StatusCode < 300
+	if !delivery.Success {
+		delivery.Error = resp.Status
+	}
+	return delivery, resp.StatusCode == http.StatusRequestTimeout ||
+		resp.StatusCode == http.StatusTooManyRequests ||
+		resp.StatusCode >= 500
+}
+
+// RunWebhookDispatcher delivers queued webhook deliveries as soon as they are queued and
+// retries them on every interval until ctx is cancelled
+func RunWebhookDispatcher(ctx context.Context, d *WebhookDispatcher, interval time.Duration) {
+	logger := middleware.LoggerFromContext(ctx).With("component", "webhook-dispatcher")
+
+	ticker := time.NewTicker(interval)
+	defer ticker.Stop()
+	for {
+		if _, err := d.DeliverDue(ctx, time.Now()); err != nil {
+			logger.Error("failed to deliver webhooks", "error", err.Error())
+		}
+
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+		case <-d.wake:
+		}
+	}
+}
@@ -1,28 +1,40 @@
 package util
 
 import (
+	"time"
+
 	"github.com/COS301-SE-2025/Swift-Signals/api-gateway/internal/model"
 	commonpb "github.com/COS301-SE-2025/Swift-Signals/protos/gen/swiftsignals/common/v1"
 	intersectionpb "github.com/COS301-SE-2025/Swift-Signals/protos/gen/swiftsignals/intersection/v1"
+	optimisationpb "github.com/COS301-SE-2025/Swift-Signals/protos/gen/swiftsignals/optimisation/v1"
 	simulationpb "github.com/COS301-SE-2025/Swift-Signals/protos/gen/swiftsignals/simulation/v1"
 	errs "github.com/COS301-SE-2025/Swift-Signals/shared/error"
 	"google.golang.org/grpc/codes"
 	"google.golang.org/grpc/status"
+	"google.golang.org/protobuf/types/known/timestamppb"
 )
 
 func RPCIntersectionToIntersection(rpc *intersectionpb.IntersectionResponse) model.Intersection {
 	return model.Intersection{
-		ID:                rpc.Id,
-		Name:              rpc.Name,
-		Details:           RPCDetailsToDetails(rpc.Details),
-		CreatedAt:         rpc.CreatedAt.AsTime(),
-		LastRunAt:         rpc.LastRunAt.AsTime(),
-		Status:            rpc.Status.String(),
-		RunCount:          int(rpc.RunCount),
-		TrafficDensity:    rpc.TrafficDensity.String(),
-		DefaultParameters: RPCOptiParamToOptiParam(rpc.DefaultParameters),
-		BestParameters:    RPCOptiParamToOptiParam(rpc.BestParameters),
-		CurrentParameters: RPCOptiParamToOptiParam(rpc.CurrentParameters),
+		ID:                  rpc.Id,
+		Name:                rpc.Name,
+		Details:             RPCDetailsToDetails(rpc.Details),
+		CreatedAt:           rpc.CreatedAt.AsTime(),
+		LastRunAt:           rpc.LastRunAt.AsTime(),
+		Status:              rpc.Status.String(),
+		RunCount:            int(rpc.RunCount),
+		TrafficDensity:      rpc.TrafficDensity.String(),
+		DefaultParameters:   RPCOptiParamToOptiParam(rpc.DefaultParameters),
+		BestParameters:      RPCOptiParamToOptiParam(rpc.BestParameters),
+		BestParametersStale: rpc.BestParametersStale,
+		CurrentParameters:   RPCOptiParamToOptiParam(rpc.CurrentParameters),
+		BestMetrics:         RPCOptionalSimResultsToSimResults(rpc.BestMetrics),
+		CurrentMetrics:      RPCOptionalSimResultsToSimResults(rpc.CurrentMetrics),
+		FailureReason:       rpc.FailureReason,
+		FailedAt:            RPCOptionalTimestampToTime(rpc.FailedAt),
+		ParameterVersion:    int(rpc.ParameterVersion),
+		Version:             int(rpc.Version),
+		DeletedAt:           RPCOptionalTimestampToTime(rpc.DeletedAt),
 	}
 }
 
@@ -60,6 +72,7 @@ func RPCSimParamToSimParam(rpc *commonpb.SimulationParameters) model.SimulationP
 		Green:            int(rpc.Green),
 		Speed:            int(rpc.Speed),
 		Seed:             int(rpc.Seed),
+		TrafficDensity:   RPCTrafficDensityToTrafficDensity(rpc.TrafficDensity),
 	}
 }
 
@@ -71,6 +84,22 @@ func RPCSimParamToSimParamOp(rpc *commonpb.SimulationParameters) model.Simulatio
 		Green:            int(rpc.Green),
 		Speed:            int(rpc.Speed),
 		Seed:             int(rpc.Seed),
+		TrafficDensity:   RPCTrafficDensityToTrafficDensity(rpc.TrafficDensity),
+	}
+}
+
+// RPCTrafficDensityToTrafficDensity names a density the way requests give it, leaving it
+// empty when unspecified
+func RPCTrafficDensityToTrafficDensity(rpc commonpb.TrafficDensity) string {
+	switch rpc {
+	case commonpb.TrafficDensity_TRAFFIC_DENSITY_LOW:
+		return "low"
+	case commonpb.TrafficDensity_TRAFFIC_DENSITY_MEDIUM:
+		return "medium"
+	case commonpb.TrafficDensity_TRAFFIC_DENSITY_HIGH:
+		return "high"
+	default:
+		return ""
 	}
 }
 
@@ -91,6 +120,35 @@ func RPCSimResultsToSimResults(
 	}
 }
 
+func SimResultsToRPCSimResults(
+	results model.SimulationResults,
+) *simulationpb.SimulationResultsResponse {
+	return &simulationpb.SimulationResultsResponse{
+		TotalVehicles:      int64(results.TotalVehicles),
+		AverageTravelTime:  float32(results.AverageTravelTime),
+		TotalTravelTime:    float32(results.TotalTravelTime),
+		AverageSpeed:       float32(results.AverageSpeed),
+		AverageWaitingTime: float32(results.AverageWaitingTime),
+		TotalWaitingTime:   float32(results.TotalWaitingTime),
+		GeneratedVehicles:  int64(results.GeneratedVehicles),
+		EmergencyBrakes:    int64(results.EmergencyBrakes),
+		EmergencyStops:     int64(results.EmergencyStops),
+		NearCollisions:     int64(results.NearCollisions),
+	}
+}
+
+// RPCOptionalSimResultsToSimResults converts results that an intersection may not have
+// yet, such as those of its best parameters before it is first optimised
+func RPCOptionalSimResultsToSimResults(
+	rpc *simulationpb.SimulationResultsResponse,
+) *model.SimulationResults {
+	if rpc == nil {
+		return nil
+	}
+	results := RPCSimResultsToSimResults(rpc)
+	return &results
+}
+
 func RPCSimOutputToSimOutput(rpc *simulationpb.SimulationOutputResponse) model.SimulationOutput {
 	return model.SimulationOutput{
 		Intersection: RPCSimIntersectionToSimIntersection(rpc.Intersection),
@@ -98,6 +156,19 @@ func RPCSimOutputToSimOutput(rpc *simulationpb.SimulationOutputResponse) model.S
 	}
 }
 
+func RPCSimOutputChunkToSimOutputChunk(
+	rpc *simulationpb.SimulationOutputChunk,
+) model.SimulationOutputChunk {
+	chunk := model.SimulationOutputChunk{
+		Vehicles: RPCSimVehiclesToSimVehicles(rpc.Vehicles),
+	}
+	if rpc.Intersection != nil {
+		intersection := RPCSimIntersectionToSimIntersection(rpc.Intersection)
+		chunk.Intersection = &intersection
+	}
+	return chunk
+}
+
 func RPCSimIntersectionToSimIntersection(
 	rpc *simulationpb.Intersection,
 ) model.SimulationIntersection {
@@ -176,11 +247,12 @@ func RPCSimVehiclesToSimVehicles(rpc []*simulationpb.Vehicle) []model.Simulation
 	for i, v := range rpc {
 		positions := make([]model.Position, len(v.Positions))
 		for j, p := range v.Positions {
+			speed := float64(p.Speed)
 			positions[j] = model.Position{
 				Time:  int(p.Time),
 				X:     float64(p.X),
 				Y:     float64(p.Y),
-				Speed: float64(p.Speed),
+				Speed: &speed,
 			}
 		}
 		vehicles[i] = model.SimulationVehicle{
@@ -191,6 +263,136 @@ func RPCSimVehiclesToSimVehicles(rpc []*simulationpb.Vehicle) []model.Simulation
 	return vehicles
 }
 
+func RPCOptimisationJobToOptimisationJob(
+	rpc *intersectionpb.OptimisationJobResponse,
+) model.OptimisationJob {
+	return model.OptimisationJob{
+		ID:             rpc.Id,
+		IntersectionID: rpc.IntersectionId,
+		Status:         rpc.Status.String(),
+		Improved:       rpc.Improved,
+		Error:          rpc.Error,
+		CreatedAt:      rpc.CreatedAt.AsTime(),
+		StartedAt:      RPCOptionalTimestampToTime(rpc.StartedAt),
+		FinishedAt:     RPCOptionalTimestampToTime(rpc.FinishedAt),
+	}
+}
+
+func RPCOptimisationProgressToOptimisationProgress(
+	jobID string,
+	rpc *optimisationpb.OptimisationProgress,
+) model.OptimisationProgress {
+	progress := model.OptimisationProgress{
+		JobID:            jobID,
+		Generation:       int(rpc.Generation),
+		TotalGenerations: int(rpc.TotalGenerations),
+		BestFitness:      rpc.BestFitness,
+		Candidates:       make([]model.OptimisationCandidate, len(rpc.Candidates)),
+	}
+	if rpc.BestParameters != nil {
+		best := RPCSimParamToSimParam(rpc.BestParameters)
+		progress.BestParameters = &best
+	}
+	for i, c := range rpc.Candidates {
+		progress.Candidates[i] = model.OptimisationCandidate{
+			Parameters: RPCSimParamToSimParam(c.Parameters),
+			Fitness:    c.Fitness,
+		}
+	}
+	return progress
+}
+
+func RPCRunToRun(rpc *intersectionpb.RunResponse) model.Run {
+	return model.Run{
+		ID:             rpc.Id,
+		IntersectionID: rpc.IntersectionId,
+		UserID:         rpc.UserId,
+		Type:           rpc.Type.String(),
+		Parameters:     RPCOptiParamToOptiParam(rpc.Parameters),
+		Metrics:        RPCOptionalSimResultsToSimResults(rpc.Metrics),
+		DurationMs:     rpc.Duration.AsDuration().Milliseconds(),
+		Outcome:        rpc.Outcome.String(),
+		Error:          rpc.Error,
+		Improved:       rpc.Improved,
+		JobID:          rpc.JobId,
+		CreatedAt:      rpc.CreatedAt.AsTime(),
+	}
+}
+
+func RPCParameterVersionToParameterVersion(
+	rpc *intersectionpb.ParameterVersionResponse,
+) model.ParameterVersion {
+	version := model.ParameterVersion{
+		IntersectionID:  rpc.IntersectionId,
+		Version:         int(rpc.Version),
+		UserID:          rpc.UserId,
+		Reason:          rpc.Reason.String(),
+		RevertedVersion: int(rpc.RevertedVersion),
+		Changes:         make([]model.ParameterChange, len(rpc.Changes)),
+		CreatedAt:       rpc.CreatedAt.AsTime(),
+	}
+	for i, c := range rpc.Changes {
+		version.Changes[i] = model.ParameterChange{
+			Field:         c.Field.String(),
+			NewParameters: RPCOptiParamToOptiParam(c.NewParameters),
+			Metrics:       RPCOptionalSimResultsToSimResults(c.Metrics),
+		}
+		if c.OldParameters != nil {
+			old := RPCOptiParamToOptiParam(c.OldParameters)
+			version.Changes[i].OldParameters = &old
+		}
+	}
+	return version
+}
+
+// RPCSweepToSweep works out the progress of a sweep from its point counts, since listed
+// sweeps come without their points
+func RPCSweepToSweep(rpc *intersectionpb.SweepResponse) model.Sweep {
+	sweep := model.Sweep{
+		ID:              rpc.Id,
+		IntersectionID:  rpc.IntersectionId,
+		Status:          rpc.Status.String(),
+		BaseParameters:  RPCSimParamToSimParam(rpc.BaseParameters),
+		Ranges:          make([]model.SweepRange, len(rpc.Ranges)),
+		TotalPoints:     int(rpc.TotalPoints),
+		CompletedPoints: int(rpc.CompletedPoints),
+		FailedPoints:    int(rpc.FailedPoints),
+		Error:           rpc.Error,
+		CreatedAt:       rpc.CreatedAt.AsTime(),
+		StartedAt:       RPCOptionalTimestampToTime(rpc.StartedAt),
+		FinishedAt:      RPCOptionalTimestampToTime(rpc.FinishedAt),
+	}
+	if sweep.TotalPoints > 0 {
+		sweep.Progress = float64(sweep.CompletedPoints) / float64(sweep.TotalPoints)
+	}
+	for i, r := range rpc.Ranges {
+		sweep.Ranges[i] = model.SweepRange{
+			Parameter: r.Parameter,
+			Min:       int(r.Min),
+			Max:       int(r.Max),
+			Step:      int(r.Step),
+		}
+	}
+	for _, p := range rpc.Points {
+		sweep.Points = append(sweep.Points, model.SweepPoint{
+			Index:      int(p.Index),
+			Parameters: RPCSimParamToSimParam(p.Parameters),
+			Results:    RPCOptionalSimResultsToSimResults(p.Metrics),
+			Error:      p.Error,
+			DurationMs: p.Duration.AsDuration().Milliseconds(),
+		})
+	}
+	return sweep
+}
+
+func RPCOptionalTimestampToTime(rpc *timestamppb.Timestamp) *time.Time {
+	if rpc == nil {
+		return nil
+	}
+	t := rpc.AsTime()
+	return &t
+}
+
 func GrpcErrorToErr(err error) *errs.ServiceError {
 	switch status.Code(err) {
 	case codes.InvalidArgument:
@@ -203,6 +405,12 @@ func GrpcErrorToErr(err error) *errs.ServiceError {
 		return errs.NewUnauthorizedError(err.Error(), map[string]any{})
 	case codes.PermissionDenied:
 		return errs.NewForbiddenError(err.Error(), map[string]any{})
+	case codes.FailedPrecondition:
+		return errs.NewConflictError(err.Error(), map[string]any{})
+	case codes.Aborted:
+		return errs.NewPreconditionError(err.Error(), map[string]any{})
+	case codes.ResourceExhausted:
+		return errs.NewRateLimitedError(err.Error(), map[string]any{})
 	default:
 		return errs.NewInternalError(err.Error(), err, map[string]any{})
 	}
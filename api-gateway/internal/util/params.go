@@ -5,6 +5,8 @@ import (
 	"net/http"
 	"strconv"
 	"strings"
+
+	errs "github.com/COS301-SE-2025/Swift-Signals/shared/error"
 )
 
 func GetToken(r *http.Request) (string, error) {
@@ -30,3 +32,31 @@ func GetID(r *http.Request) (int, error) {
 	idStr := r.PathValue("id")
 	return strconv.Atoi(idStr)
 }
+
+// GetIfMatch returns the version named by the If-Match header, or 0 when the header is
+// missing or "*". Any other tag cannot match a version the gateway hands out, so it fails
+// the precondition.
+func GetIfMatch(r *http.Request) (int, error) {
+	header := strings.TrimSpace(r.Header.Get("If-Match"))
+	if header == "" || header == "*" {
+		return 0, nil
+	}
+
+	if len(header) > 2 && header[0] == '"' && header[len(header)-1] == '"' {
+		if version, err := strconv.Atoi(header[1 : len(header)-1]); err == nil && version > 0 {
+			return version, nil
+		}
+	}
+	return 0, errs.NewPreconditionError(
+		"If-Match does not match the current version of the resource",
+		map[string]any{"If-Match": header},
+	)
+}
+
+// SetETag sends a version of a resource as its entity tag. Resources written before they
+// were versioned have no tag.
+func SetETag(w http.ResponseWriter, version int) {
+	if version > 0 {
+		w.Header().Set("ETag", `"`+strconv.Itoa(version)+`"`)
+	}
+}
@@ -1,8 +1,10 @@
 package util
 
 import (
+	"encoding/csv"
 	"encoding/json"
 	"errors"
+	"fmt"
 	"log"
 	"log/slog"
 	"net/http"
@@ -28,12 +30,44 @@ func SendJSONResponse(w http.ResponseWriter, statusCode int, data any) {
 	}
 }
 
+// SendEvent writes a single Server-Sent Event with a JSON payload. Callers flush it.
+func SendEvent(w http.ResponseWriter, event string, data any) error {
+	payload, err := json.Marshal(data)
+	if err != nil {
+		return err
+	}
+	_, err = fmt.Fprintf(w, "event: %s\ndata: %s\n\n", event, payload)
+	return err
+}
+
+// SendJSONLine writes data as a single line of newline delimited JSON. Callers flush it.
+func SendJSONLine(w http.ResponseWriter, data any) error {
+	return json.NewEncoder(w).Encode(data)
+}
+
+// SendCSVResponse writes records as a CSV attachment with the given file name
+func SendCSVResponse(w http.ResponseWriter, statusCode int, filename string, records [][]string) {
+	w.Header().Set("Content-Type", "text/csv")
+	w.Header().Set("Content-Disposition", fmt.Sprintf("attachment; filename=%q", filename))
+	w.WriteHeader(statusCode)
+	if err := csv.NewWriter(w).WriteAll(records); err != nil {
+		log.Printf("Error encoding CSV response: %v", err)
+	}
+}
+
 func SendErrorResponse(w http.ResponseWriter, err error) {
 	if err == nil {
 		logger := slog.Default()
 		logger.Warn("returning nil error to client")
 	}
 
+	errResp := ErrorToErrorResponse(err)
+	SendJSONResponse(w, errResp.Code, errResp)
+}
+
+// ErrorToErrorResponse maps an error to the response sent to clients, hiding the
+// details of unexpected errors
+func ErrorToErrorResponse(err error) model.ErrorResponse {
 	errResp := model.ErrorResponse{
 		Code:    http.StatusInternalServerError,
 		Message: "something went wrong",
@@ -47,17 +81,21 @@ func SendErrorResponse(w http.ResponseWriter, err error) {
 			errResp.Code = http.StatusBadRequest
 		case errs.ErrNotFound:
 			errResp.Code = http.StatusNotFound
-		case errs.ErrAlreadyExists:
+		case errs.ErrAlreadyExists, errs.ErrConflict:
 			errResp.Code = http.StatusConflict
 		case errs.ErrUnauthorized:
 			errResp.Code = http.StatusUnauthorized
 		case errs.ErrForbidden:
 			errResp.Code = http.StatusForbidden
+		case errs.ErrPrecondition:
+			errResp.Code = http.StatusPreconditionFailed
+		case errs.ErrRateLimited:
+			errResp.Code = http.StatusTooManyRequests
 		default:
 			errResp.Code = http.StatusInternalServerError
 			errResp.Message = "something went wrong"
 		}
 	}
 
-	SendJSONResponse(w, errResp.Code, errResp)
+	return errResp
 }
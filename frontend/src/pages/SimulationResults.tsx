@@ -55,6 +55,42 @@ interface ApiIntersection {
   status?: string; // Added status property
   // Add other fields from the full intersection object if needed
 }
+
+// Background optimisation job started by POST /intersections/{id}/optimise
+interface ApiOptimisationJob {
+  id: string;
+  intersection_id: string;
+  status: string;
+  improved: boolean;
+  error?: string;
+}
+
+const OPTIMISATION_JOB_POLL_INTERVAL_MS = 5000;
+
+// Polls an optimisation job until it is no longer pending or running
+const waitForOptimisationJob = async (
+  jobId: string,
+  authToken: string,
+): Promise<ApiOptimisationJob> => {
+  for (;;) {
+    const res = await fetch(`${API_BASE_URL}/optimisation-jobs/${jobId}`, {
+      headers: { Authorization: `Bearer ${authToken}` },
+    });
+    if (!res.ok) {
+      throw new Error(`Failed to fetch optimization job: ${res.statusText}`);
+    }
+    const job: ApiOptimisationJob = await res.json();
+    if (
+      job.status !== "OPTIMISATION_JOB_STATUS_PENDING" &&
+      job.status !== "OPTIMISATION_JOB_STATUS_RUNNING"
+    ) {
+      return job;
+    }
+    await new Promise((resolve) =>
+      setTimeout(resolve, OPTIMISATION_JOB_POLL_INTERVAL_MS),
+    );
+  }
+};
 // #endregion
 
 // #region Loading Component
@@ -413,6 +449,8 @@ const SimulationResults: React.FC = () => {
           throw new Error("Authentication failed. Please log in again.");
         } else if (optResponse.status === 404) {
           throw new Error("Intersection not found for optimization.");
+        } else if (optResponse.status === 409) {
+          throw new Error("An optimization is already running.");
         } else {
           throw new Error(
             `Failed to run optimization: ${optResponse.statusText}`,
@@ -420,8 +458,16 @@ const SimulationResults: React.FC = () => {
         }
       }
 
-      const optResult = await optResponse.json();
+      const optJob: ApiOptimisationJob = await optResponse.json();
+      setOptimizationStatus("Optimization running...");
+
+      const optResult = await waitForOptimisationJob(optJob.id, authToken);
       console.log("Optimization result:", optResult);
+      if (optResult.status !== "OPTIMISATION_JOB_STATUS_SUCCEEDED") {
+        throw new Error(
+          optResult.error || "The optimization job did not complete.",
+        );
+      }
 
       setOptimizationStatus(
         "Optimization completed successfully! Fetching optimized data...",
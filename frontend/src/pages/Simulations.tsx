@@ -1992,6 +1992,7 @@ const Simulations: React.FC = () => {
           return "Optimising"; // Frontend display string
         case "unoptimised": // Assuming backend still sends "unoptimised" for unoptimized
           return "Unoptimised"; // Frontend display string
+        case "INTERSECTION_STATUS_FAILED":
         case "Failed":
           return "Failed";
         default:
@@ -5,11 +5,14 @@ import (
 	"log"
 	"net"
 	"os"
+	"strings"
+	"time"
 
 	"github.com/COS301-SE-2025/Swift-Signals/intersection-service/internal/db"
 	"github.com/COS301-SE-2025/Swift-Signals/intersection-service/internal/handler"
 	"github.com/COS301-SE-2025/Swift-Signals/intersection-service/internal/service"
 	intersectionpb "github.com/COS301-SE-2025/Swift-Signals/protos/gen/swiftsignals/intersection/v1"
+	"github.com/COS301-SE-2025/Swift-Signals/shared/events"
 	"go.mongodb.org/mongo-driver/mongo"
 	"go.mongodb.org/mongo-driver/mongo/options"
 	"google.golang.org/grpc"
@@ -24,10 +27,47 @@ func main() {
 	}
 
 	collection := client.Database("IntersectionService").Collection("Intersections")
+	if err := db.CreateJobIndexes(context.TODO(), collection); err != nil {
+		log.Fatalf("Failed to create optimisation job indexes: %v", err)
+	}
 	repo := db.NewMongoIntersectionRepo(collection)
-	svc := service.NewIntersectionService(repo)
+	objective, err := service.ParseObjective(os.Getenv("OPTIMISATION_OBJECTIVE"))
+	if err != nil {
+		log.Fatalf("Invalid OPTIMISATION_OBJECTIVE: %v", err)
+	}
+	svc := service.NewIntersectionService(repo, objective)
 	h := handler.NewIntersectionHandler(svc)
 
+	// NOTE: Features that react to intersections and optimisations subscribe here, while other
+	// processes receive the events at their endpoints
+	broker := newBroker()
+	broker.Subscribe(events.AllEvents, func(ctx context.Context, event events.Event) error {
+		log.Printf("Published %s event for %s", event.Type, event.AggregateID)
+		return nil
+	})
+	go events.RunRelay(
+		context.Background(),
+		events.NewRelay(repo, broker, 100),
+		durationFromEnv("OUTBOX_RELAY_INTERVAL", time.Second),
+	)
+
+	// NOTE: Running jobs renew their lease every OPTI_HEARTBEAT_SEC of the gateway (60s by
+	// default), so the lease only has to outlast a few missed heartbeats before a job whose
+	// gateway went away is failed and its intersection freed
+	go service.RunOptimisationReconciler(
+		context.Background(),
+		svc,
+		durationFromEnv("OPTIMISATION_LEASE", 5*time.Minute),
+		durationFromEnv("OPTIMISATION_RECONCILE_INTERVAL", time.Minute),
+	)
+
+	go service.RunTrashPurger(
+		context.Background(),
+		svc,
+		durationFromEnv("TRASH_RETENTION", 30*24*time.Hour),
+		durationFromEnv("TRASH_PURGE_INTERVAL", time.Hour),
+	)
+
 	lis, err := net.Listen("tcp", ":"+os.Getenv("APP_PORT"))
 	if err != nil {
 		log.Fatalf("Failed to listen: %v", err)
@@ -43,3 +83,33 @@ func main() {
 		log.Fatalf("Failed to serve gRPC: %v", err)
 	}
 }
+
+// durationFromEnv reads a duration such as "90m" from the environment, falling back to def
+func durationFromEnv(key string, def time.Duration) time.Duration {
+	value := os.Getenv(key)
+	if value == "" {
+		return def
+	}
+	d, err := time.ParseDuration(value)
+	if err != nil || d <= 0 {
+		log.Printf("Invalid %s %q, using %s", key, value, def)
+		return def
+	}
+	return d
+}
+
+// newBroker relays events to the comma separated endpoints in EVENT_ENDPOINTS, which check
+// them against EVENT_SECRET
+func newBroker() *events.HTTPBroker {
+	var endpoints []string
+	for endpoint := range strings.SplitSeq(os.Getenv("EVENT_ENDPOINTS"), ",") {
+		if endpoint = strings.TrimSpace(endpoint); endpoint != "" {
+			endpoints = append(endpoints, endpoint)
+		}
+	}
+	secret := os.Getenv("EVENT_SECRET")
+	if len(endpoints) > 0 && secret == "" {
+		log.Fatalf("EVENT_SECRET is required to relay events to EVENT_ENDPOINTS")
+	}
+	return events.NewHTTPBroker(endpoints, secret, durationFromEnv("EVENT_TIMEOUT", 10*time.Second))
+}
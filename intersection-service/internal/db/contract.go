@@ -2,20 +2,29 @@ package db
 
 import (
 	"context"
+	"time"
 
 	"github.com/COS301-SE-2025/Swift-Signals/intersection-service/internal/model"
+	"github.com/COS301-SE-2025/Swift-Signals/shared/events"
 )
 
 type IntersectionRepository interface {
 	CreateIntersection(
 		ctx context.Context,
 		intersection *model.Intersection,
+		version *model.ParameterVersion,
 	) (*model.Intersection, error)
 	GetIntersectionByID(ctx context.Context, id string) (*model.Intersection, error)
 	GetAllIntersections(
 		ctx context.Context,
 		limit, offset int,
 		filter string,
+		deleted bool,
+	) ([]*model.Intersection, error)
+	ListIntersectionsAfter(
+		ctx context.Context,
+		afterID string,
+		limit int,
 	) ([]*model.Intersection, error)
 	UpdateIntersection(
 		ctx context.Context,
@@ -23,16 +32,86 @@ type IntersectionRepository interface {
 		name string,
 		details model.IntersectionDetails,
 		status model.IntersectionStatus,
+		failureReason string,
+		density model.TrafficDensity,
+		defaultParams *model.OptimisationParameters,
+		version *model.ParameterVersion,
+		expectedVersion int,
+	) (*model.Intersection, error)
+	UpdateIntersectionStatus(
+		ctx context.Context,
+		id string,
+		status model.IntersectionStatus,
+		failureReason string,
 	) (*model.Intersection, error)
-	DeleteIntersection(ctx context.Context, id string) error
+	DeleteIntersection(ctx context.Context, id string, expectedVersion int) error
+	RestoreIntersection(ctx context.Context, id string) (*model.Intersection, error)
+	PurgeDeletedIntersections(ctx context.Context, deadline time.Time) ([]string, error)
 	UpdateCurrentParams(
 		ctx context.Context,
 		id string,
 		params model.OptimisationParameters,
+		metrics *model.SimulationResults,
+		best bool,
+		expectedStatus model.IntersectionStatus,
+		version *model.ParameterVersion,
 	) error
-	UpdateBestParams(
+	FailStaleOptimisations(
+		ctx context.Context,
+		deadline time.Time,
+		reason string,
+	) ([]string, error)
+	CreateOptimisationJob(
+		ctx context.Context,
+		job *model.OptimisationJob,
+	) (*model.OptimisationJob, error)
+	GetOptimisationJobByID(ctx context.Context, id string) (*model.OptimisationJob, error)
+	GetOptimisationJobs(
+		ctx context.Context,
+		intersectionID string,
+		statuses []model.OptimisationJobStatus,
+	) ([]*model.OptimisationJob, error)
+	UpdateOptimisationJob(
+		ctx context.Context,
+		job *model.OptimisationJob,
+	) (*model.OptimisationJob, error)
+	RenewOptimisationLease(
 		ctx context.Context,
 		id string,
-		params model.OptimisationParameters,
-	) error
+		now time.Time,
+	) (*model.OptimisationJob, error)
+	CreateRun(ctx context.Context, run *model.Run) (*model.Run, error)
+	GetRunByID(ctx context.Context, intersectionID, id string) (*model.Run, error)
+	GetRuns(
+		ctx context.Context,
+		intersectionID string,
+		limit, offset int,
+	) ([]*model.Run, error)
+	CreateSweep(ctx context.Context, sweep *model.Sweep) (*model.Sweep, error)
+	GetSweepByID(ctx context.Context, intersectionID, id string) (*model.Sweep, error)
+	GetSweeps(
+		ctx context.Context,
+		intersectionID string,
+		statuses []model.SweepStatus,
+	) ([]*model.Sweep, error)
+	AddSweepPoint(ctx context.Context, id string, point model.SweepPoint) error
+	UpdateSweep(ctx context.Context, sweep *model.Sweep) (*model.Sweep, error)
+	GetParameterVersion(
+		ctx context.Context,
+		intersectionID string,
+		version int,
+	) (*model.ParameterVersion, error)
+	GetParameterVersions(
+		ctx context.Context,
+		intersectionID string,
+		limit, offset int,
+	) ([]*model.ParameterVersion, error)
+	RevertParameters(
+		ctx context.Context,
+		id string,
+		version *model.ParameterVersion,
+		expectedVersion int,
+	) (*model.Intersection, error)
+	PendingEvents(ctx context.Context, limit int) ([]events.Event, error)
+	MarkEventsPublished(ctx context.Context, ids []string) error
 }
@@ -0,0 +1,251 @@
+package db
+
+import (
+	"context"
+	"time"
+
+	"github.com/COS301-SE-2025/Swift-Signals/intersection-service/internal/model"
+	"github.com/COS301-SE-2025/Swift-Signals/intersection-service/internal/util"
+	errs "github.com/COS301-SE-2025/Swift-Signals/shared/error"
+	"github.com/COS301-SE-2025/Swift-Signals/shared/events"
+	"go.mongodb.org/mongo-driver/bson"
+	"go.mongodb.org/mongo-driver/mongo"
+	"go.mongodb.org/mongo-driver/mongo/options"
+)
+
+// CreateJobIndexes prepares the indexes of the optimisation jobs kept alongside the given
+// intersections collection, so it needs a reachable database. At most one job per
+// intersection may be pending or running, which the index enforces however many requests
+// to start one arrive at once.
+func CreateJobIndexes(ctx context.Context, collection *mongo.Collection) error {
+	jobs := collection.Database().Collection("OptimisationJobs")
+	_, err := jobs.Indexes().CreateMany(ctx, []mongo.IndexModel{
+		{Keys: bson.D{{Key: "id", Value: 1}}, Options: options.Index().SetUnique(true)},
+		{
+			Keys: bson.D{{Key: "intersectionid", Value: 1}},
+			Options: options.Index().
+				SetUnique(true).
+				SetPartialFilterExpression(bson.M{
+					"status": bson.M{"$in": []model.OptimisationJobStatus{
+						model.JobPending,
+						model.JobRunning,
+					}},
+				}),
+		},
+	})
+	return err
+}
+
+func (r *MongoIntersectionRepo) CreateOptimisationJob(
+	ctx context.Context,
+	job *model.OptimisationJob,
+) (*model.OptimisationJob, error) {
+	logger := util.LoggerFromContext(ctx)
+	logger.Debug("inserting optimisation job")
+
+	_, err := r.jobs.InsertOne(ctx, job)
+	if mongo.IsDuplicateKeyError(err) {
+		return nil, errs.NewAlreadyExistsError(
+			"an optimisation job is already active for this intersection",
+			map[string]any{"intersection ID": job.IntersectionID},
+		)
+	}
+	if err != nil {
+		return nil, errs.NewDatabaseError(
+			"failed to insert optimisation job into collection",
+			err,
+			map[string]any{"job ID": job.ID, "intersection ID": job.IntersectionID},
+		)
+	}
+
+	return job, nil
+}
+
+func (r *MongoIntersectionRepo) GetOptimisationJobByID(
+	ctx context.Context,
+	id string,
+) (*model.OptimisationJob, error) {
+	logger := util.LoggerFromContext(ctx)
+	logger.Debug("finding optimisation job by ID")
+
+	var job model.OptimisationJob
+
+	err := r.jobs.FindOne(ctx, bson.M{"id": id}).Decode(&job)
+	if err != nil {
+		if err == mongo.ErrNoDocuments {
+			return nil, errs.NewNotFoundError(
+				"optimisation job ID not found in collection",
+				map[string]any{"job ID": id},
+			)
+		}
+		return nil, errs.NewDatabaseError(
+			"failed to find optimisation job",
+			err,
+			map[string]any{"job ID": id},
+		)
+	}
+
+	return &job, nil
+}
+
+func (r *MongoIntersectionRepo) GetOptimisationJobs(
+	ctx context.Context,
+	intersectionID string,
+	statuses []model.OptimisationJobStatus,
+) ([]*model.OptimisationJob, error) {
+	logger := util.LoggerFromContext(ctx)
+	logger.Debug("fetching optimisation jobs")
+
+	query := bson.M{}
+	if intersectionID != "" {
+		query["intersectionid"] = intersectionID
+	}
+	if len(statuses) > 0 {
+		query["status"] = bson.M{"$in": statuses}
+	}
+
+	opts := options.Find().SetSort(bson.D{{Key: "createdat", Value: -1}})
+
+	cursor, err := r.jobs.Find(ctx, query, opts)
+	if err != nil {
+		return nil, errs.NewDatabaseError(
+			"failed to find optimisation jobs",
+			err,
+			map[string]any{"intersection ID": intersectionID, "statuses": statuses},
+		)
+	}
+	defer func() {
+		if err := cursor.Close(ctx); err != nil {
+			logger.Warn("failed to close cursor:", "error", err)
+		}
+	}()
+
+	var jobs []*model.OptimisationJob
+	if err = cursor.All(ctx, &jobs); err != nil {
+		return nil, errs.NewDatabaseError(
+			"failed to decode optimisation jobs",
+			err,
+			map[string]any{"intersection ID": intersectionID, "statuses": statuses},
+		)
+	}
+
+	return jobs, nil
+}
+
+// UpdateOptimisationJob only applies to jobs that have not yet finished, so a job
+// that was cancelled cannot later be overwritten by the worker that was running it
+func (r *MongoIntersectionRepo) UpdateOptimisationJob(
+	ctx context.Context,
+	job *model.OptimisationJob,
+) (*model.OptimisationJob, error) {
+	logger := util.LoggerFromContext(ctx)
+	logger.Debug("updating optimisation job")
+
+	filter := bson.M{
+		"id":     job.ID,
+		"status": bson.M{"$in": []model.OptimisationJobStatus{model.JobPending, model.JobRunning}},
+	}
+	update := bson.M{
+		"$set": bson.M{
+			"status":     job.Status,
+			"improved":   job.Improved,
+			"error":      job.Error,
+			"startedat":  job.StartedAt,
+			"finishedat": job.FinishedAt,
+		},
+	}
+	if eventType, ok := jobEventTypes[job.Status]; ok {
+		event, err := newJobEvent(eventType, job)
+		if err != nil {
+			return nil, err
+		}
+		update["$push"] = bson.M{"outbox": event}
+	}
+
+	opts := options.FindOneAndUpdate().SetReturnDocument(options.After)
+	var updatedJob model.OptimisationJob
+
+	err := r.jobs.FindOneAndUpdate(ctx, filter, update, opts).Decode(&updatedJob)
+	if err != nil {
+		if err == mongo.ErrNoDocuments {
+			return nil, errs.NewConflictError(
+				"optimisation job not found or already finished",
+				map[string]any{"job ID": job.ID},
+			)
+		}
+		return nil, errs.NewDatabaseError(
+			"failed to update optimisation job",
+			err,
+			map[string]any{"job ID": job.ID},
+		)
+	}
+
+	return &updatedJob, nil
+}
+
+// jobEventTypes are the events announcing that a job has moved to a status
+var jobEventTypes = map[model.OptimisationJobStatus]events.Type{
+	model.JobRunning:   events.OptimisationStarted,
+	model.JobSucceeded: events.OptimisationCompleted,
+	model.JobFailed:    events.OptimisationFailed,
+}
+
+func newJobEvent(eventType events.Type, job *model.OptimisationJob) (events.Event, error) {
+	return newEvent(eventType, job.ID, events.OptimisationPayload{
+		JobID:          job.ID,
+		IntersectionID: job.IntersectionID,
+		UserID:         job.UserID,
+		Improved:       job.Improved,
+		Error:          job.Error,
+	})
+}
+
+// RenewOptimisationLease records that the worker running a job is still alive, which keeps
+// its intersection from being failed as a stale optimisation. Jobs that have finished,
+// e.g. because their lease already expired, cannot be renewed.
+func (r *MongoIntersectionRepo) RenewOptimisationLease(
+	ctx context.Context,
+	id string,
+	now time.Time,
+) (*model.OptimisationJob, error) {
+	logger := util.LoggerFromContext(ctx)
+	logger.Debug("renewing optimisation job lease")
+
+	filter := bson.M{
+		"id":     id,
+		"status": bson.M{"$in": []model.OptimisationJobStatus{model.JobPending, model.JobRunning}},
+	}
+	update := bson.M{"$set": bson.M{"heartbeatat": now}}
+
+	opts := options.FindOneAndUpdate().SetReturnDocument(options.After)
+	var job model.OptimisationJob
+
+	err := r.jobs.FindOneAndUpdate(ctx, filter, update, opts).Decode(&job)
+	if err != nil {
+		if err == mongo.ErrNoDocuments {
+			return nil, errs.NewConflictError(
+				"optimisation job not found or already finished",
+				map[string]any{"job ID": id},
+			)
+		}
+		return nil, errs.NewDatabaseError(
+			"failed to renew optimisation job lease",
+			err,
+			map[string]any{"job ID": id},
+		)
+	}
+
+	_, err = r.collection.UpdateOne(ctx,
+		bson.M{"id": job.IntersectionID, "status": model.Optimising},
+		bson.M{"$set": bson.M{"leaserenewedat": now}},
+	)
+	if err != nil {
+		return nil, errs.NewDatabaseError(
+			"failed to renew optimisation lease of intersection",
+			err,
+			map[string]any{"job ID": id, "intersection ID": job.IntersectionID},
+		)
+	}
+
+	return &job, nil
+}
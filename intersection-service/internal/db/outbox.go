@@ -0,0 +1,145 @@
+package db
+
+import (
+	"context"
+	"slices"
+
+	"github.com/COS301-SE-2025/Swift-Signals/intersection-service/internal/model"
+	"github.com/COS301-SE-2025/Swift-Signals/intersection-service/internal/util"
+	errs "github.com/COS301-SE-2025/Swift-Signals/shared/error"
+	"github.com/COS301-SE-2025/Swift-Signals/shared/events"
+	"go.mongodb.org/mongo-driver/bson"
+	"go.mongodb.org/mongo-driver/mongo"
+)
+
+// NOTE: MongoDB only writes a single document atomically without a replica set, so each
+// document carries its own outbox of events waiting to be relayed, written in the same
+// update as the change they describe
+
+type intersectionDocument struct {
+	model.Intersection `bson:",inline"`
+	Outbox             []events.Event           `bson:"outbox"`
+	PendingVersions    []model.ParameterVersion `bson:"pendingversions,omitempty"`
+}
+
+type optimisationJobDocument struct {
+	model.OptimisationJob `bson:",inline"`
+	Outbox                []events.Event `bson:"outbox"`
+}
+
+// newEvent creates an event for a document's outbox
+func newEvent(eventType events.Type, aggregateID string, payload any) (events.Event, error) {
+	event, err := events.NewEvent(eventType, aggregateID, payload)
+	if err != nil {
+		return events.Event{}, errs.NewInternalError(
+			"failed to create event",
+			err,
+			map[string]any{"event type": eventType, "aggregate ID": aggregateID},
+		)
+	}
+	return event, nil
+}
+
+// withEvents adds events to the outbox of the document an update writes
+func withEvents(update bson.M, outbox ...events.Event) {
+	if len(outbox) > 0 {
+		operatorFields(update, "$push")["outbox"] = bson.M{"$each": outbox}
+	}
+}
+
+// newStatusEvent creates the event of an intersection moving to a status
+func newStatusEvent(
+	id string,
+	status model.IntersectionStatus,
+	failureReason string,
+) (events.Event, error) {
+	return newEvent(events.IntersectionStatusChanged, id, events.IntersectionStatusChangedPayload{
+		IntersectionID: id,
+		Status:         string(status),
+		FailureReason:  failureReason,
+	})
+}
+
+// outboxCollections are the collections whose documents carry an outbox
+func (r *MongoIntersectionRepo) outboxCollections() []*mongo.Collection {
+	return []*mongo.Collection{r.collection, r.jobs}
+}
+
+// PendingEvents gathers the oldest events waiting in the outboxes of intersections and
+// optimisation jobs, in the order they occurred across every document
+func (r *MongoIntersectionRepo) PendingEvents(
+	ctx context.Context,
+	limit int,
+) ([]events.Event, error) {
+	logger := util.LoggerFromContext(ctx)
+	logger.Debug("finding pending events")
+
+	// NOTE: Events are sorted and limited one by one rather than by document, so that a
+	// document with many events cannot have a later one relayed before an earlier event of
+	// another document. Events of one document keep their outbox order.
+	pipeline := mongo.Pipeline{
+		{{Key: "$match", Value: bson.M{"outbox.0": bson.M{"$exists": true}}}},
+		{{Key: "$unwind", Value: bson.M{"path": "$outbox", "includeArrayIndex": "position"}}},
+		{{Key: "$sort", Value: bson.D{
+			{Key: "outbox.occurredat", Value: 1},
+			{Key: "_id", Value: 1},
+			{Key: "position", Value: 1},
+		}}},
+		{{Key: "$limit", Value: limit}},
+		{{Key: "$replaceRoot", Value: bson.M{"newRoot": "$outbox"}}},
+	}
+
+	var pending []events.Event
+	for _, collection := range r.outboxCollections() {
+		cursor, err := collection.Aggregate(ctx, pipeline)
+		if err != nil {
+			return nil, errs.NewDatabaseError(
+				"failed to find pending events",
+				err,
+				map[string]any{"collection": collection.Name()},
+			)
+		}
+
+		var outbox []events.Event
+		err = cursor.All(ctx, &outbox)
+		if closeErr := cursor.Close(ctx); closeErr != nil {
+			logger.Warn("failed to close cursor:", "error", closeErr)
+		}
+		if err != nil {
+			return nil, errs.NewDatabaseError(
+				"failed to decode pending events",
+				err,
+				map[string]any{"collection": collection.Name()},
+			)
+		}
+		pending = append(pending, outbox...)
+	}
+
+	slices.SortStableFunc(pending, func(a, b events.Event) int {
+		return a.OccurredAt.Compare(b.OccurredAt)
+	})
+	if len(pending) > limit {
+		pending = pending[:limit]
+	}
+	return pending, nil
+}
+
+// MarkEventsPublished removes published events from the outboxes they were waiting in
+func (r *MongoIntersectionRepo) MarkEventsPublished(ctx context.Context, ids []string) error {
+	logger := util.LoggerFromContext(ctx)
+	logger.Debug("removing published events", "count", len(ids))
+
+	filter := bson.M{"outbox.id": bson.M{"$in": ids}}
+	update := bson.M{"$pull": bson.M{"outbox": bson.M{"id": bson.M{"$in": ids}}}}
+
+	for _, collection := range r.outboxCollections() {
+		if _, err := collection.UpdateMany(ctx, filter, update); err != nil {
+			return errs.NewDatabaseError(
+				"failed to remove published events",
+				err,
+				map[string]any{"collection": collection.Name(), "event IDs": ids},
+			)
+		}
+	}
+	return nil
+}
@@ -0,0 +1,292 @@
+package db
+
+import (
+	"context"
+	"time"
+
+	"github.com/COS301-SE-2025/Swift-Signals/intersection-service/internal/model"
+	"github.com/COS301-SE-2025/Swift-Signals/intersection-service/internal/util"
+	errs "github.com/COS301-SE-2025/Swift-Signals/shared/error"
+	"github.com/COS301-SE-2025/Swift-Signals/shared/events"
+	"go.mongodb.org/mongo-driver/bson"
+	"go.mongodb.org/mongo-driver/mongo"
+	"go.mongodb.org/mongo-driver/mongo/options"
+)
+
+// NOTE: A parameter version is pushed onto its intersection in the same update as the
+// change it records, and moved into its own collection afterwards. parameterversion
+// counts every version, pending or not, so the pending ones are always the latest and
+// their numbers follow from it.
+
+// withParameterVersion adds the version to the update of the change it records
+func withParameterVersion(update bson.M, version *model.ParameterVersion) {
+	if version == nil {
+		return
+	}
+	version.CreatedAt = time.Now()
+	operatorFields(update, "$inc")["parameterversion"] = 1
+	operatorFields(update, "$push")["pendingversions"] = version
+}
+
+// operatorFields returns the fields of an update operator, adding the operator if the
+// update does not have it yet
+func operatorFields(update bson.M, operator string) bson.M {
+	fields, ok := update[operator].(bson.M)
+	if !ok {
+		fields = bson.M{}
+		update[operator] = fields
+	}
+	return fields
+}
+
+// moveParameterVersions numbers the pending versions of an intersection and inserts them
+// into the parameter versions collection. Inserting is idempotent, so versions left
+// pending by a move that failed part way are moved by the next one.
+func (r *MongoIntersectionRepo) moveParameterVersions(
+	ctx context.Context,
+	intersectionID string,
+) error {
+	logger := util.LoggerFromContext(ctx)
+
+	var document struct {
+		ParameterVersion int                      `bson:"parameterversion"`
+		PendingVersions  []model.ParameterVersion `bson:"pendingversions"`
+	}
+	filter := bson.M{"id": intersectionID, "pendingversions.0": bson.M{"$exists": true}}
+	opts := options.FindOne().SetProjection(bson.M{"parameterversion": 1, "pendingversions": 1})
+	err := r.collection.FindOne(ctx, filter, opts).Decode(&document)
+	if err != nil {
+		if err == mongo.ErrNoDocuments {
+			return nil
+		}
+		return errs.NewDatabaseError(
+			"failed to find pending parameter versions",
+			err,
+			map[string]any{"intersection ID": intersectionID},
+		)
+	}
+
+	logger.Debug("moving parameter versions", "count", len(document.PendingVersions))
+	first := document.ParameterVersion - len(document.PendingVersions) + 1
+	for i, version := range document.PendingVersions {
+		version.Version = first + i
+		_, err = r.parameterVersions.ReplaceOne(
+			ctx,
+			bson.M{"intersectionid": intersectionID, "version": version.Version},
+			version,
+			options.Replace().SetUpsert(true),
+		)
+		if err != nil {
+			return errs.NewDatabaseError(
+				"failed to insert parameter version into collection",
+				err,
+				map[string]any{"intersection ID": intersectionID, "version": version.Version},
+			)
+		}
+	}
+
+	// NOTE: Versions pushed in the meantime bump the counter, so they are left pending
+	_, err = r.collection.UpdateOne(
+		ctx,
+		bson.M{"id": intersectionID, "parameterversion": document.ParameterVersion},
+		bson.M{"$unset": bson.M{"pendingversions": ""}},
+	)
+	if err != nil {
+		return errs.NewDatabaseError(
+			"failed to clear pending parameter versions",
+			err,
+			map[string]any{"intersection ID": intersectionID},
+		)
+	}
+	return nil
+}
+
+// afterParameterVersion moves the version just written. The version is already recorded
+// with its change, so failing to move it only delays it until the next read or write.
+func (r *MongoIntersectionRepo) afterParameterVersion(
+	ctx context.Context,
+	intersectionID string,
+	version *model.ParameterVersion,
+) {
+	if version == nil {
+		return
+	}
+	if err := r.moveParameterVersions(ctx, intersectionID); err != nil {
+		util.LoggerFromContext(ctx).Warn("could not move parameter versions",
+			"intersection_id", intersectionID,
+			"error", err.Error(),
+		)
+	}
+}
+
+func (r *MongoIntersectionRepo) GetParameterVersion(
+	ctx context.Context,
+	intersectionID string,
+	version int,
+) (*model.ParameterVersion, error) {
+	logger := util.LoggerFromContext(ctx)
+	logger.Debug("finding parameter version")
+
+	if err := r.moveParameterVersions(ctx, intersectionID); err != nil {
+		return nil, err
+	}
+
+	var parameterVersion model.ParameterVersion
+
+	filter := bson.M{"intersectionid": intersectionID, "version": version}
+	err := r.parameterVersions.FindOne(ctx, filter).Decode(&parameterVersion)
+	if err != nil {
+		if err == mongo.ErrNoDocuments {
+			return nil, errs.NewNotFoundError(
+				"parameter version not found for intersection",
+				map[string]any{"intersection ID": intersectionID, "version": version},
+			)
+		}
+		return nil, errs.NewDatabaseError(
+			"failed to find parameter version",
+			err,
+			map[string]any{"intersection ID": intersectionID, "version": version},
+		)
+	}
+
+	return &parameterVersion, nil
+}
+
+func (r *MongoIntersectionRepo) GetParameterVersions(
+	ctx context.Context,
+	intersectionID string,
+	limit, offset int,
+) ([]*model.ParameterVersion, error) {
+	logger := util.LoggerFromContext(ctx)
+	logger.Debug("fetching parameter versions")
+
+	if err := r.moveParameterVersions(ctx, intersectionID); err != nil {
+		return nil, err
+	}
+
+	opts := options.Find().
+		SetSort(bson.D{{Key: "version", Value: -1}}).
+		SetSkip(int64(offset)).
+		SetLimit(int64(limit))
+
+	cursor, err := r.parameterVersions.Find(
+		ctx,
+		bson.M{"intersectionid": intersectionID},
+		opts,
+	)
+	if err != nil {
+		return nil, errs.NewDatabaseError(
+			"failed to find parameter versions",
+			err,
+			map[string]any{"intersection ID": intersectionID},
+		)
+	}
+	defer func() {
+		if err := cursor.Close(ctx); err != nil {
+			logger.Warn("failed to close cursor:", "error", err)
+		}
+	}()
+
+	var versions []*model.ParameterVersion
+	if err = cursor.All(ctx, &versions); err != nil {
+		return nil, errs.NewDatabaseError(
+			"failed to decode parameter versions",
+			err,
+			map[string]any{"intersection ID": intersectionID},
+		)
+	}
+
+	return versions, nil
+}
+
+// RevertParameters sets the fields of the version's changes to their new parameters and
+// metrics and records the version, unless the intersection is being optimised or has been
+// written since the expected version was read. Best parameters are stale when the
+// defaults change without them.
+func (r *MongoIntersectionRepo) RevertParameters(
+	ctx context.Context,
+	id string,
+	version *model.ParameterVersion,
+	expectedVersion int,
+) (*model.Intersection, error) {
+	logger := util.LoggerFromContext(ctx)
+	logger.Debug("reverting parameters")
+
+	fields := bson.M{}
+	for _, change := range version.Changes {
+		switch change.Field {
+		case model.ParameterFieldDefault:
+			fields["defaultparameters"] = change.NewParameters
+		case model.ParameterFieldCurrent:
+			fields["currentparameters"] = change.NewParameters
+			fields["currentmetrics"] = change.Metrics
+		case model.ParameterFieldBest:
+			fields["bestparameters"] = change.NewParameters
+			fields["bestmetrics"] = change.Metrics
+		}
+	}
+	_, defaults := fields["defaultparameters"]
+	_, best := fields["bestparameters"]
+	if defaults || best {
+		fields["bestparametersstale"] = defaults && !best
+	}
+
+	event, err := newEvent(
+		events.ParametersUpdated,
+		id,
+		events.ParametersUpdatedPayload{IntersectionID: id, Reason: "revert"},
+	)
+	if err != nil {
+		return nil, err
+	}
+
+	// NOTE: The version's old parameters are those that were read, so the revert only goes
+	// ahead while they are still there
+	filter := bson.M{
+		"id":      id,
+		"version": expectedVersion,
+		"status":  bson.M{"$ne": model.Optimising},
+	}
+	update := bson.M{
+		"$set":  fields,
+		"$inc":  bson.M{"version": 1},
+		"$push": bson.M{"outbox": event},
+	}
+	withParameterVersion(update, version)
+	opts := options.FindOneAndUpdate().SetReturnDocument(options.After)
+
+	var intersection model.Intersection
+	err = r.collection.FindOneAndUpdate(ctx, filter, update, opts).Decode(&intersection)
+	if err == nil {
+		r.afterParameterVersion(ctx, id, version)
+		return &intersection, nil
+	}
+	if err != mongo.ErrNoDocuments {
+		return nil, errs.NewDatabaseError(
+			"failed to revert intersection parameters",
+			err,
+			map[string]any{"intersection ID": id},
+		)
+	}
+
+	// NOTE: Nothing matched, because the intersection does not exist, is being optimised or
+	// has been written since it was read
+	current, err := r.GetIntersectionByID(ctx, id)
+	if err != nil {
+		return nil, err
+	}
+	if current.Status == model.Optimising {
+		return nil, errs.NewConflictError(
+			"parameters cannot be reverted while the intersection is being optimised",
+			map[string]any{"intersection ID": id},
+		)
+	}
+	return nil, errs.NewPreconditionError(
+		"intersection has been modified since it was read",
+		map[string]any{
+			"intersection ID":  id,
+			"expected version": expectedVersion,
+			"version":          current.Version,
+		},
+	)
+}
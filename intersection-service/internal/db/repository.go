@@ -8,6 +8,7 @@ import (
 	"github.com/COS301-SE-2025/Swift-Signals/intersection-service/internal/model"
 	"github.com/COS301-SE-2025/Swift-Signals/intersection-service/internal/util"
 	errs "github.com/COS301-SE-2025/Swift-Signals/shared/error"
+	"github.com/COS301-SE-2025/Swift-Signals/shared/events"
 	"go.mongodb.org/mongo-driver/bson"
 	"go.mongodb.org/mongo-driver/mongo"
 	"go.mongodb.org/mongo-driver/mongo/options"
@@ -15,21 +16,59 @@ import (
 
 type MongoIntersectionRepo struct {
 	collection *mongo.Collection
+	jobs       *mongo.Collection
+	runs       *mongo.Collection
+	sweeps     *mongo.Collection
+
+	parameterVersions *mongo.Collection
 }
 
+// NewMongoIntersectionRepo stores intersections in the given collection and keeps
+// the supporting collections (e.g. optimisation jobs) alongside it in the same database
 func NewMongoIntersectionRepo(collection *mongo.Collection) IntersectionRepository {
-	return &MongoIntersectionRepo{collection: collection}
+	return &MongoIntersectionRepo{
+		collection: collection,
+		jobs:       collection.Database().Collection("OptimisationJobs"),
+		runs:       collection.Database().Collection("Runs"),
+		sweeps:     collection.Database().Collection("Sweeps"),
+
+		parameterVersions: collection.Database().Collection("ParameterVersions"),
+	}
 }
 
+// CreateIntersection inserts the intersection along with its first parameter version
 func (r *MongoIntersectionRepo) CreateIntersection(
 	ctx context.Context,
 	intersection *model.Intersection,
+	version *model.ParameterVersion,
 ) (*model.Intersection, error) {
 	logger := util.LoggerFromContext(ctx)
 
+	event, err := newEvent(
+		events.IntersectionCreated,
+		intersection.ID,
+		events.IntersectionCreatedPayload{
+			IntersectionID: intersection.ID,
+			Name:           intersection.Name,
+		},
+	)
+	if err != nil {
+		return nil, err
+	}
+
 	logger.Debug("inserting intersection")
 
-	_, err := r.collection.InsertOne(ctx, intersection)
+	document := intersectionDocument{
+		Intersection: *intersection,
+		Outbox:       []events.Event{event},
+	}
+	if version != nil {
+		version.CreatedAt = time.Now()
+		document.ParameterVersion = 1
+		document.PendingVersions = []model.ParameterVersion{*version}
+	}
+
+	_, err = r.collection.InsertOne(ctx, document)
 	if err != nil {
 		return nil, errs.NewDatabaseError(
 			"failed to insert intersection into collection",
@@ -37,8 +76,9 @@ func (r *MongoIntersectionRepo) CreateIntersection(
 			map[string]any{"intersection ID:": intersection.ID},
 		)
 	}
+	r.afterParameterVersion(ctx, intersection.ID, version)
 
-	return intersection, nil
+	return &document.Intersection, nil
 }
 
 func (r *MongoIntersectionRepo) GetIntersectionByID(
@@ -51,7 +91,7 @@ func (r *MongoIntersectionRepo) GetIntersectionByID(
 
 	var intersection model.Intersection
 
-	filter := bson.M{"id": id}
+	filter := bson.M{"id": id, "deletedat": nil}
 
 	err := r.collection.FindOne(ctx, filter).Decode(&intersection)
 	if err != nil {
@@ -71,8 +111,11 @@ func (r *MongoIntersectionRepo) GetIntersectionByID(
 	return &intersection, nil
 }
 
+// GetAllIntersections finds the intersections with the given comma-separated IDs, or all
+// of them without a filter. Only intersections in the trash are found if deleted is set,
+// and only those outside it otherwise.
 func (r *MongoIntersectionRepo) GetAllIntersections(
-	ctx context.Context, limit, offset int, filter string,
+	ctx context.Context, limit, offset int, filter string, deleted bool,
 ) ([]*model.Intersection, error) {
 	logger := util.LoggerFromContext(ctx)
 	logger.Debug("fetching all intersections")
@@ -96,6 +139,13 @@ func (r *MongoIntersectionRepo) GetAllIntersections(
 	} else {
 		query = bson.M{}
 	}
+	if deleted {
+		// NOTE: Intersections being purged are no longer in the trash to be restored
+		query["deletedat"] = bson.M{"$ne": nil}
+		query["purgingat"] = nil
+	} else {
+		query["deletedat"] = nil
+	}
 
 	opts := options.Find()
 	if limit > 0 {
@@ -131,38 +181,151 @@ func (r *MongoIntersectionRepo) GetAllIntersections(
 	return intersections, nil
 }
 
+// ListIntersectionsAfter finds up to limit intersections, in the trash or not, whose ID sorts
+// after afterID, in order of ID
+func (r *MongoIntersectionRepo) ListIntersectionsAfter(
+	ctx context.Context, afterID string, limit int,
+) ([]*model.Intersection, error) {
+	logger := util.LoggerFromContext(ctx)
+	logger.Debug("fetching intersections after ID", "afterID", afterID)
+
+	opts := options.Find().SetSort(bson.D{{Key: "id", Value: 1}}).SetLimit(int64(limit))
+	cursor, err := r.collection.Find(ctx, bson.M{"id": bson.M{"$gt": afterID}}, opts)
+	if err != nil {
+		return nil, errs.NewDatabaseError(
+			"failed to find intersections",
+			err,
+			map[string]any{"afterID": afterID, "limit": limit},
+		)
+	}
+	defer func() {
+		if err := cursor.Close(ctx); err != nil {
+			logger.Warn("failed to close cursor:", "error", err)
+		}
+	}()
+
+	var intersections []*model.Intersection
+	if err = cursor.All(ctx, &intersections); err != nil {
+		return nil, errs.NewDatabaseError(
+			"failed to decode intersections",
+			err,
+			map[string]any{"afterID": afterID, "limit": limit},
+		)
+	}
+
+	return intersections, nil
+}
+
 func (r *MongoIntersectionRepo) UpdateIntersection(
 	ctx context.Context,
 	id string,
 	name string,
 	details model.IntersectionDetails,
 	status model.IntersectionStatus,
+	failureReason string,
+	density model.TrafficDensity,
+	defaultParams *model.OptimisationParameters,
+	version *model.ParameterVersion,
+	expectedVersion int,
 ) (*model.Intersection, error) {
 	logger := util.LoggerFromContext(ctx)
 	logger.Debug("updating intersection")
 
-	filter := bson.M{"id": id}
-	update := bson.M{
-		"$set": bson.M{
-			"name":    name,
-			"details": details,
-			"status":  status,
-		},
+	filter := bson.M{"id": id, "deletedat": nil}
+	if expectedVersion > 0 {
+		filter["version"] = expectedVersion
+	}
+	fields := bson.M{
+		"name":    name,
+		"details": details,
 	}
 
-	if status == model.IntersectionStatus("INTERSECTION_STATUS_UNSPECIFIED") {
-		update = bson.M{
-			"$set": bson.M{
-				"name":    name,
-				"details": details,
-			},
+	setStatus(fields, status, failureReason)
+
+	// NOTE: Best parameters found under other defaults are kept, but flagged until an
+	// optimisation replaces them
+	if density != "" {
+		fields["trafficdensity"] = density
+		fields["bestparametersstale"] = true
+	}
+	if defaultParams != nil {
+		fields["defaultparameters"] = *defaultParams
+		fields["bestparametersstale"] = true
+	}
+
+	update := bson.M{"$set": fields, "$inc": bson.M{"version": 1}}
+	var outbox []events.Event
+	if status != model.Unspecified {
+		event, err := newStatusEvent(id, status, failureReason)
+		if err != nil {
+			return nil, err
+		}
+		outbox = append(outbox, event)
+	}
+	defaultsChanged := density != "" || defaultParams != nil
+	if defaultsChanged {
+		// NOTE: Checked in the update itself, so that an optimisation starting after the
+		// service looked cannot have its defaults changed underneath it
+		filter["status"] = bson.M{"$ne": model.Optimising}
+
+		event, err := newEvent(
+			events.ParametersUpdated,
+			id,
+			events.ParametersUpdatedPayload{IntersectionID: id, Reason: "defaults"},
+		)
+		if err != nil {
+			return nil, err
 		}
+		outbox = append(outbox, event)
 	}
+	withEvents(update, outbox...)
+	withParameterVersion(update, version)
 
 	opts := options.FindOneAndUpdate().SetReturnDocument(options.After)
 	var updatedIntersection model.Intersection
 
 	err := r.collection.FindOneAndUpdate(ctx, filter, update, opts).Decode(&updatedIntersection)
+	if err != nil {
+		if err == mongo.ErrNoDocuments {
+			return nil, r.updateMissed(ctx, id, expectedVersion, defaultsChanged)
+		}
+		return nil, errs.NewDatabaseError(
+			"failed to update intersection",
+			err,
+			map[string]any{"intersection ID": id},
+		)
+	}
+	r.afterParameterVersion(ctx, id, version)
+
+	return &updatedIntersection, nil
+}
+
+// UpdateIntersectionStatus changes only the status of an intersection (and the reason it
+// failed), so that it cannot revert edits made since its caller last read it
+func (r *MongoIntersectionRepo) UpdateIntersectionStatus(
+	ctx context.Context,
+	id string,
+	status model.IntersectionStatus,
+	failureReason string,
+) (*model.Intersection, error) {
+	logger := util.LoggerFromContext(ctx)
+	logger.Debug("updating intersection status")
+
+	fields := bson.M{}
+	setStatus(fields, status, failureReason)
+
+	event, err := newStatusEvent(id, status, failureReason)
+	if err != nil {
+		return nil, err
+	}
+	update := bson.M{"$set": fields, "$inc": bson.M{"version": 1}}
+	withEvents(update, event)
+
+	filter := bson.M{"id": id, "deletedat": nil}
+	opts := options.FindOneAndUpdate().SetReturnDocument(options.After)
+	var updatedIntersection model.Intersection
+
+	err = r.collection.FindOneAndUpdate(ctx, filter, update, opts).Decode(&updatedIntersection)
 	if err != nil {
 		if err == mongo.ErrNoDocuments {
 			return nil, errs.NewNotFoundError(
@@ -171,7 +334,7 @@ func (r *MongoIntersectionRepo) UpdateIntersection(
 			)
 		}
 		return nil, errs.NewDatabaseError(
-			"failed to update intersection",
+			"failed to update intersection status",
 			err,
 			map[string]any{"intersection ID": id},
 		)
@@ -180,12 +343,91 @@ func (r *MongoIntersectionRepo) UpdateIntersection(
 	return &updatedIntersection, nil
 }
 
-func (r *MongoIntersectionRepo) DeleteIntersection(ctx context.Context, id string) error {
+// setStatus adds the fields that record a change to the given status to an update
+func setStatus(fields bson.M, status model.IntersectionStatus, failureReason string) {
+	switch status {
+	case model.Unspecified:
+	case model.Optimising:
+		fields["status"] = status
+		fields["optimisingsince"] = time.Now()
+	case model.Failed:
+		fields["status"] = status
+		// NOTE: Without a reason the previous failure (if any) is left as is, e.g. when a
+		// cancelled job restores an intersection that had already failed
+		if failureReason != "" {
+			fields["failurereason"] = failureReason
+			fields["failedat"] = time.Now()
+		}
+	default:
+		fields["status"] = status
+	}
+}
+
+// updateMissed explains why a conditional update matched nothing: either the intersection
+// does not exist, it has been written since the expected version was read or, when its
+// defaults were changed, it is being optimised
+func (r *MongoIntersectionRepo) updateMissed(
+	ctx context.Context,
+	id string,
+	expectedVersion int,
+	defaultsChanged bool,
+) error {
+	if expectedVersion <= 0 && !defaultsChanged {
+		return errs.NewNotFoundError(
+			"intersection ID not found for update",
+			map[string]any{"intersection ID": id},
+		)
+	}
+
+	current, err := r.GetIntersectionByID(ctx, id)
+	if err != nil {
+		return err
+	}
+	if expectedVersion <= 0 || current.Version == expectedVersion {
+		return errs.NewConflictError(
+			"defaults cannot change while the intersection is being optimised",
+			map[string]any{"intersection ID": id},
+		)
+	}
+	return errs.NewPreconditionError(
+		"intersection has been modified since it was read",
+		map[string]any{
+			"intersection ID":  id,
+			"expected version": expectedVersion,
+			"version":          current.Version,
+		},
+	)
+}
+
+// DeleteIntersection moves an intersection to the trash, from which it can be restored
+// until it is purged, unless it has been written since the expected version was read
+func (r *MongoIntersectionRepo) DeleteIntersection(
+	ctx context.Context,
+	id string,
+	expectedVersion int,
+) error {
 	logger := util.LoggerFromContext(ctx)
-	logger.Debug("deleting intersection")
+	logger.Debug("moving intersection to trash")
+
+	event, err := newEvent(
+		events.IntersectionDeleted,
+		id,
+		events.IntersectionDeletedPayload{IntersectionID: id},
+	)
+	if err != nil {
+		return err
+	}
 
-	filter := bson.M{"id": id}
-	result, err := r.collection.DeleteOne(ctx, filter)
+	filter := bson.M{"id": id, "deletedat": nil}
+	if expectedVersion > 0 {
+		filter["version"] = expectedVersion
+	}
+	update := bson.M{
+		"$set":  bson.M{"deletedat": time.Now()},
+		"$inc":  bson.M{"version": 1},
+		"$push": bson.M{"outbox": event},
+	}
+	result, err := r.collection.UpdateOne(ctx, filter, update)
 	if err != nil {
 		return errs.NewDatabaseError(
 			"failed to delete intersection",
@@ -194,7 +436,21 @@ func (r *MongoIntersectionRepo) DeleteIntersection(ctx context.Context, id strin
 		)
 	}
 
-	if result.DeletedCount == 0 {
+	if result.MatchedCount == 0 && expectedVersion > 0 {
+		current, err := r.GetIntersectionByID(ctx, id)
+		if err != nil {
+			return err
+		}
+		return errs.NewPreconditionError(
+			"intersection has been modified since it was read",
+			map[string]any{
+				"intersection ID":  id,
+				"expected version": expectedVersion,
+				"version":          current.Version,
+			},
+		)
+	}
+	if result.MatchedCount == 0 {
 		return errs.NewNotFoundError(
 			"intersection ID not found for deletion",
 			map[string]any{"intersection ID": id},
@@ -204,25 +460,217 @@ func (r *MongoIntersectionRepo) DeleteIntersection(ctx context.Context, id strin
 	return nil
 }
 
+// RestoreIntersection takes an intersection back out of the trash
+func (r *MongoIntersectionRepo) RestoreIntersection(
+	ctx context.Context,
+	id string,
+) (*model.Intersection, error) {
+	logger := util.LoggerFromContext(ctx)
+	logger.Debug("restoring intersection from trash")
+
+	filter := bson.M{"id": id, "deletedat": bson.M{"$ne": nil}, "purgingat": nil}
+	update := bson.M{
+		"$unset": bson.M{"deletedat": ""},
+		"$inc":   bson.M{"version": 1},
+	}
+
+	opts := options.FindOneAndUpdate().SetReturnDocument(options.After)
+	var restored model.Intersection
+
+	err := r.collection.FindOneAndUpdate(ctx, filter, update, opts).Decode(&restored)
+	if err != nil {
+		if err == mongo.ErrNoDocuments {
+			return nil, errs.NewNotFoundError(
+				"intersection ID not found in trash",
+				map[string]any{"intersection ID": id},
+			)
+		}
+		return nil, errs.NewDatabaseError(
+			"failed to restore intersection",
+			err,
+			map[string]any{"intersection ID": id},
+		)
+	}
+
+	return &restored, nil
+}
+
+// PurgeDeletedIntersections permanently removes every intersection that was moved to the
+// trash before the deadline, along with its jobs, runs, sweeps and parameter versions.
+// It returns the IDs of the intersections that were removed.
+func (r *MongoIntersectionRepo) PurgeDeletedIntersections(
+	ctx context.Context,
+	deadline time.Time,
+) ([]string, error) {
+	logger := util.LoggerFromContext(ctx)
+	logger.Debug("finding intersections due to be purged")
+
+	due := bson.M{"deletedat": bson.M{"$ne": nil, "$lt": deadline}, "purgingat": nil}
+	ids, err := r.findIDs(ctx, due)
+	if err != nil {
+		return nil, err
+	}
+
+	// NOTE: Claimed intersections can no longer be restored, so nothing is purged from
+	// under one that was restored after it was found. Each carries the purged event in
+	// its outbox, so that other services can forget it.
+	logger.Debug("claiming intersections to purge", "count", len(ids))
+	for _, id := range ids {
+		event, err := newEvent(
+			events.IntersectionPurged,
+			id,
+			events.IntersectionPurgedPayload{IntersectionID: id},
+		)
+		if err != nil {
+			return nil, err
+		}
+
+		filter := bson.M{"id": id, "deletedat": due["deletedat"], "purgingat": nil}
+		update := bson.M{
+			"$set":  bson.M{"purgingat": time.Now()},
+			"$push": bson.M{"outbox": event},
+		}
+		if _, err := r.collection.UpdateOne(ctx, filter, update); err != nil {
+			return nil, errs.NewDatabaseError(
+				"failed to claim deleted intersection",
+				err,
+				map[string]any{"intersection ID": id},
+			)
+		}
+	}
+
+	// NOTE: Intersections claimed by an earlier purge that was interrupted are finished too
+	claimed, err := r.findIDs(ctx, bson.M{"purgingat": bson.M{"$ne": nil}})
+	if err != nil {
+		return nil, err
+	}
+	if len(claimed) == 0 {
+		return nil, nil
+	}
+
+	logger.Debug("purging documents of claimed intersections", "count", len(claimed))
+	for _, collection := range []*mongo.Collection{
+		r.jobs, r.runs, r.sweeps, r.parameterVersions,
+	} {
+		_, err = collection.DeleteMany(ctx, bson.M{"intersectionid": bson.M{"$in": claimed}})
+		if err != nil {
+			return nil, errs.NewDatabaseError(
+				"failed to purge documents of deleted intersections",
+				err,
+				map[string]any{"collection": collection.Name(), "intersection IDs": claimed},
+			)
+		}
+	}
+
+	// NOTE: An intersection is only removed once its purged event has been relayed, as the
+	// event waits in its outbox. The rest are removed by a later purge.
+	relayed := bson.M{"purgingat": bson.M{"$ne": nil}, "outbox.0": bson.M{"$exists": false}}
+	purged, err := r.findIDs(ctx, relayed)
+	if err != nil {
+		return nil, err
+	}
+	if len(purged) == 0 {
+		return nil, nil
+	}
+
+	relayed["id"] = bson.M{"$in": purged}
+	if _, err = r.collection.DeleteMany(ctx, relayed); err != nil {
+		return nil, errs.NewDatabaseError(
+			"failed to purge deleted intersections",
+			err,
+			map[string]any{"intersection IDs": purged},
+		)
+	}
+
+	return purged, nil
+}
+
+// findIDs returns the IDs of the intersections matching the filter
+func (r *MongoIntersectionRepo) findIDs(ctx context.Context, filter bson.M) ([]string, error) {
+	logger := util.LoggerFromContext(ctx)
+
+	cursor, err := r.collection.Find(ctx, filter, options.Find().SetProjection(bson.M{"id": 1}))
+	if err != nil {
+		return nil, errs.NewDatabaseError(
+			"failed to find intersections",
+			err,
+			map[string]any{"filter": filter},
+		)
+	}
+	defer func() {
+		if err := cursor.Close(ctx); err != nil {
+			logger.Warn("failed to close cursor:", "error", err)
+		}
+	}()
+
+	var documents []struct {
+		ID string `bson:"id"`
+	}
+	if err = cursor.All(ctx, &documents); err != nil {
+		return nil, errs.NewDatabaseError(
+			"failed to decode intersections",
+			err,
+			map[string]any{"filter": filter},
+		)
+	}
+
+	ids := make([]string, 0, len(documents))
+	for _, document := range documents {
+		ids = append(ids, document.ID)
+	}
+	return ids, nil
+}
+
+// UpdateCurrentParams records the parameters an optimisation found as the current ones
+// and, when they are better, as the best ones too, along with the version of the change
 func (r *MongoIntersectionRepo) UpdateCurrentParams(
 	ctx context.Context,
 	id string,
 	params model.OptimisationParameters,
+	metrics *model.SimulationResults,
+	best bool,
+	expectedStatus model.IntersectionStatus,
+	version *model.ParameterVersion,
 ) error {
 	logger := util.LoggerFromContext(ctx)
-	logger.Debug("updating current parameters")
+	logger.Debug("updating current parameters", "best", best)
+
+	event, err := newEvent(
+		events.ParametersUpdated,
+		id,
+		events.ParametersUpdatedPayload{IntersectionID: id, Reason: "optimisation"},
+	)
+	if err != nil {
+		return err
+	}
 
-	filter := bson.M{"id": id}
+	// NOTE: An optimisation job only writes while the intersection is still optimising, so
+	// that it cannot overwrite an intersection that was failed when its lease expired, and
+	// never once it is in the trash, where it may already be being purged
+	filter := bson.M{"id": id, "deletedat": nil}
+	if expectedStatus != "" {
+		filter["status"] = expectedStatus
+	}
+	fields := bson.M{
+		"currentparameters": params,
+		"currentmetrics":    metrics,
+		"lastrunat":         time.Now(),
+		"status":            model.Optimised,
+	}
+	if best {
+		fields["bestparameters"] = params
+		fields["bestmetrics"] = metrics
+		fields["bestparametersstale"] = false
+	}
 	update := bson.M{
-		"$set": bson.M{
-			"current_parameters": params,
-			"last_run_at":        time.Now(),
-			"status":             model.Optimised,
-		},
+		"$set": fields,
 		"$inc": bson.M{
-			"run_count": 1,
+			"runcount": 1,
+			"version":  1,
 		},
+		"$push": bson.M{"outbox": event},
 	}
+	withParameterVersion(update, version)
 
 	result, err := r.collection.UpdateOne(ctx, filter, update)
 	if err != nil {
@@ -234,50 +682,209 @@ func (r *MongoIntersectionRepo) UpdateCurrentParams(
 	}
 
 	if result.MatchedCount == 0 {
+		if expectedStatus != "" {
+			// NOTE: Found only if the intersection is out of the trash, so that one trashed
+			// during the optimisation is reported as gone rather than as failed
+			if _, err := r.GetIntersectionByID(ctx, id); err != nil {
+				return err
+			}
+			return errs.NewConflictError(
+				"intersection is no longer in the expected status",
+				map[string]any{"intersection ID": id, "expected status": expectedStatus},
+			)
+		}
 		return errs.NewNotFoundError(
 			"intersection ID not found for optimisation update",
 			map[string]any{"intersection ID": id},
 		)
 	}
+	r.afterParameterVersion(ctx, id, version)
 
 	return nil
 }
 
-func (r *MongoIntersectionRepo) UpdateBestParams(
+// FailStaleOptimisations fails every optimisation job still active whose lease was not
+// renewed since the deadline, and then moves every intersection that has been optimising
+// since before the deadline, without its lease being renewed since, to the failed status.
+// It returns the IDs of the intersections that were failed.
+// NOTE: Jobs are reaped on their own lease, before and regardless of their intersection,
+// so that a job left behind by a crash cannot keep its intersection from ever being
+// optimised again
+func (r *MongoIntersectionRepo) FailStaleOptimisations(
 	ctx context.Context,
-	id string,
-	params model.OptimisationParameters,
-) error {
+	deadline time.Time,
+	reason string,
+) ([]string, error) {
 	logger := util.LoggerFromContext(ctx)
-	logger.Debug("updating best parameters")
+	now := time.Now()
 
-	filter := bson.M{"id": id}
-	update := bson.M{
-		"$set": bson.M{
-			"bestparameters": params,
-			"lastrunat":      time.Now(),
-			"status":         model.Optimised,
+	if err := r.failStaleJobs(ctx, deadline, reason, now); err != nil {
+		return nil, err
+	}
+
+	logger.Debug("finding intersections stuck optimising")
+
+	// NOTE: Intersections that started optimising before optimisingsince was recorded
+	// have no such field and are treated as stale
+	filter := bson.M{
+		"status": model.Optimising,
+		"$and": bson.A{
+			bson.M{"$or": bson.A{
+				bson.M{"optimisingsince": bson.M{"$lt": deadline}},
+				bson.M{"optimisingsince": bson.M{"$exists": false}},
+			}},
+			bson.M{"$or": bson.A{
+				bson.M{"leaserenewedat": bson.M{"$lt": deadline}},
+				bson.M{"leaserenewedat": bson.M{"$exists": false}},
+			}},
 		},
-		"$inc": bson.M{
-			"runcount": 1,
+	}
+
+	cursor, err := r.collection.Find(ctx, filter, options.Find().SetProjection(bson.M{"id": 1}))
+	if err != nil {
+		return nil, errs.NewDatabaseError(
+			"failed to find stale optimisations",
+			err,
+			map[string]any{"deadline": deadline},
+		)
+	}
+	defer func() {
+		if err := cursor.Close(ctx); err != nil {
+			logger.Warn("failed to close cursor:", "error", err)
+		}
+	}()
+
+	var stale []struct {
+		ID string `bson:"id"`
+	}
+	if err = cursor.All(ctx, &stale); err != nil {
+		return nil, errs.NewDatabaseError(
+			"failed to decode stale optimisations",
+			err,
+			map[string]any{"deadline": deadline},
+		)
+	}
+	if len(stale) == 0 {
+		return nil, nil
+	}
+
+	// NOTE: Each intersection is failed under the same filter it was found with, so one
+	// whose lease was renewed in the meantime keeps optimising
+	logger.Debug("failing stale optimisations", "count", len(stale))
+	var ids []string
+	for _, s := range stale {
+		event, err := newStatusEvent(s.ID, model.Failed, reason)
+		if err != nil {
+			return nil, err
+		}
+
+		result, err := r.collection.UpdateOne(ctx,
+			bson.M{"id": s.ID, "status": model.Optimising, "$and": filter["$and"]},
+			bson.M{
+				"$set": bson.M{
+					"status":        model.Failed,
+					"failurereason": reason,
+					"failedat":      now,
+				},
+				"$inc":  bson.M{"version": 1},
+				"$push": bson.M{"outbox": event},
+			},
+		)
+		if err != nil {
+			return nil, errs.NewDatabaseError(
+				"failed to fail stale optimisations",
+				err,
+				map[string]any{"intersection ID": s.ID},
+			)
+		}
+		if result.ModifiedCount > 0 {
+			ids = append(ids, s.ID)
+		}
+	}
+	return ids, nil
+}
+
+// failStaleJobs fails every optimisation job still active that was created before the
+// deadline without its lease being renewed since, one at a time so that each job's outbox
+// gets its own event
+func (r *MongoIntersectionRepo) failStaleJobs(
+	ctx context.Context,
+	deadline time.Time,
+	reason string,
+	now time.Time,
+) error {
+	logger := util.LoggerFromContext(ctx)
+	logger.Debug("finding optimisation jobs whose lease expired")
+
+	// NOTE: Jobs that were never renewed have no heartbeat, so they are stale once they
+	// were created before the deadline
+	filter := bson.M{
+		"status":    bson.M{"$in": []model.OptimisationJobStatus{model.JobPending, model.JobRunning}},
+		"createdat": bson.M{"$lt": deadline},
+		"$or": bson.A{
+			bson.M{"heartbeatat": bson.M{"$lt": deadline}},
+			bson.M{"heartbeatat": bson.M{"$exists": false}},
 		},
 	}
 
-	result, err := r.collection.UpdateOne(ctx, filter, update)
+	cursor, err := r.jobs.Find(ctx, filter)
 	if err != nil {
 		return errs.NewDatabaseError(
-			"failed to update intersection best parameters",
+			"failed to find stale optimisation jobs",
 			err,
-			map[string]any{"intersection ID": id},
+			map[string]any{"deadline": deadline},
 		)
 	}
+	defer func() {
+		if err := cursor.Close(ctx); err != nil {
+			logger.Warn("failed to close cursor:", "error", err)
+		}
+	}()
 
-	if result.MatchedCount == 0 {
-		return errs.NewNotFoundError(
-			"intersection ID not found for optimisation update",
-			map[string]any{"intersection ID": id},
+	var stale []*model.OptimisationJob
+	if err = cursor.All(ctx, &stale); err != nil {
+		return errs.NewDatabaseError(
+			"failed to decode stale optimisation jobs",
+			err,
+			map[string]any{"deadline": deadline},
 		)
 	}
 
+	// NOTE: Each job is failed under the same filter it was found with, so one whose lease
+	// was renewed in the meantime keeps running
+	logger.Debug("failing stale optimisation jobs", "count", len(stale))
+	for _, job := range stale {
+		job.Status = model.JobFailed
+		job.Error = reason
+		job.FinishedAt = now
+		event, err := newJobEvent(events.OptimisationFailed, job)
+		if err != nil {
+			return err
+		}
+
+		_, err = r.jobs.UpdateOne(ctx,
+			bson.M{
+				"id":        job.ID,
+				"status":    filter["status"],
+				"createdat": filter["createdat"],
+				"$or":       filter["$or"],
+			},
+			bson.M{
+				"$set": bson.M{
+					"status":     job.Status,
+					"error":      job.Error,
+					"finishedat": job.FinishedAt,
+				},
+				"$push": bson.M{"outbox": event},
+			},
+		)
+		if err != nil {
+			return errs.NewDatabaseError(
+				"failed to fail stale optimisation jobs",
+				err,
+				map[string]any{"job ID": job.ID},
+			)
+		}
+	}
 	return nil
 }
@@ -0,0 +1,98 @@
+package db
+
+import (
+	"context"
+
+	"github.com/COS301-SE-2025/Swift-Signals/intersection-service/internal/model"
+	"github.com/COS301-SE-2025/Swift-Signals/intersection-service/internal/util"
+	errs "github.com/COS301-SE-2025/Swift-Signals/shared/error"
+	"go.mongodb.org/mongo-driver/bson"
+	"go.mongodb.org/mongo-driver/mongo"
+	"go.mongodb.org/mongo-driver/mongo/options"
+)
+
+func (r *MongoIntersectionRepo) CreateRun(
+	ctx context.Context,
+	run *model.Run,
+) (*model.Run, error) {
+	logger := util.LoggerFromContext(ctx)
+	logger.Debug("inserting run")
+
+	_, err := r.runs.InsertOne(ctx, run)
+	if err != nil {
+		return nil, errs.NewDatabaseError(
+			"failed to insert run into collection",
+			err,
+			map[string]any{"run ID": run.ID, "intersection ID": run.IntersectionID},
+		)
+	}
+
+	return run, nil
+}
+
+func (r *MongoIntersectionRepo) GetRunByID(
+	ctx context.Context,
+	intersectionID, id string,
+) (*model.Run, error) {
+	logger := util.LoggerFromContext(ctx)
+	logger.Debug("finding run by ID")
+
+	var run model.Run
+
+	filter := bson.M{"id": id, "intersectionid": intersectionID}
+	err := r.runs.FindOne(ctx, filter).Decode(&run)
+	if err != nil {
+		if err == mongo.ErrNoDocuments {
+			return nil, errs.NewNotFoundError(
+				"run ID not found for intersection",
+				map[string]any{"run ID": id, "intersection ID": intersectionID},
+			)
+		}
+		return nil, errs.NewDatabaseError(
+			"failed to find run",
+			err,
+			map[string]any{"run ID": id, "intersection ID": intersectionID},
+		)
+	}
+
+	return &run, nil
+}
+
+func (r *MongoIntersectionRepo) GetRuns(
+	ctx context.Context,
+	intersectionID string,
+	limit, offset int,
+) ([]*model.Run, error) {
+	logger := util.LoggerFromContext(ctx)
+	logger.Debug("fetching runs")
+
+	opts := options.Find().
+		SetSort(bson.D{{Key: "createdat", Value: -1}, {Key: "id", Value: 1}}).
+		SetSkip(int64(offset)).
+		SetLimit(int64(limit))
+
+	cursor, err := r.runs.Find(ctx, bson.M{"intersectionid": intersectionID}, opts)
+	if err != nil {
+		return nil, errs.NewDatabaseError(
+			"failed to find runs",
+			err,
+			map[string]any{"intersection ID": intersectionID},
+		)
+	}
+	defer func() {
+		if err := cursor.Close(ctx); err != nil {
+			logger.Warn("failed to close cursor:", "error", err)
+		}
+	}()
+
+	var runs []*model.Run
+	if err = cursor.All(ctx, &runs); err != nil {
+		return nil, errs.NewDatabaseError(
+			"failed to decode runs",
+			err,
+			map[string]any{"intersection ID": intersectionID},
+		)
+	}
+
+	return runs, nil
+}
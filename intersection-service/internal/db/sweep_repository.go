@@ -0,0 +1,194 @@
+package db
+
+import (
+	"context"
+
+	"github.com/COS301-SE-2025/Swift-Signals/intersection-service/internal/model"
+	"github.com/COS301-SE-2025/Swift-Signals/intersection-service/internal/util"
+	errs "github.com/COS301-SE-2025/Swift-Signals/shared/error"
+	"go.mongodb.org/mongo-driver/bson"
+	"go.mongodb.org/mongo-driver/mongo"
+	"go.mongodb.org/mongo-driver/mongo/options"
+)
+
+func (r *MongoIntersectionRepo) CreateSweep(
+	ctx context.Context,
+	sweep *model.Sweep,
+) (*model.Sweep, error) {
+	logger := util.LoggerFromContext(ctx)
+	logger.Debug("inserting sweep")
+
+	_, err := r.sweeps.InsertOne(ctx, sweep)
+	if err != nil {
+		return nil, errs.NewDatabaseError(
+			"failed to insert sweep into collection",
+			err,
+			map[string]any{"sweep ID": sweep.ID, "intersection ID": sweep.IntersectionID},
+		)
+	}
+
+	return sweep, nil
+}
+
+// GetSweepByID finds a sweep of any intersection when no intersection ID is given
+func (r *MongoIntersectionRepo) GetSweepByID(
+	ctx context.Context,
+	intersectionID, id string,
+) (*model.Sweep, error) {
+	logger := util.LoggerFromContext(ctx)
+	logger.Debug("finding sweep by ID")
+
+	var sweep model.Sweep
+
+	filter := bson.M{"id": id}
+	if intersectionID != "" {
+		filter["intersectionid"] = intersectionID
+	}
+	err := r.sweeps.FindOne(ctx, filter).Decode(&sweep)
+	if err != nil {
+		if err == mongo.ErrNoDocuments {
+			return nil, errs.NewNotFoundError(
+				"sweep ID not found for intersection",
+				map[string]any{"sweep ID": id, "intersection ID": intersectionID},
+			)
+		}
+		return nil, errs.NewDatabaseError(
+			"failed to find sweep",
+			err,
+			map[string]any{"sweep ID": id, "intersection ID": intersectionID},
+		)
+	}
+
+	return &sweep, nil
+}
+
+// GetSweeps leaves out the points of the sweeps, which are only needed one sweep at a time
+func (r *MongoIntersectionRepo) GetSweeps(
+	ctx context.Context,
+	intersectionID string,
+	statuses []model.SweepStatus,
+) ([]*model.Sweep, error) {
+	logger := util.LoggerFromContext(ctx)
+	logger.Debug("fetching sweeps")
+
+	query := bson.M{}
+	if intersectionID != "" {
+		query["intersectionid"] = intersectionID
+	}
+	if len(statuses) > 0 {
+		query["status"] = bson.M{"$in": statuses}
+	}
+
+	opts := options.Find().
+		SetSort(bson.D{{Key: "createdat", Value: -1}}).
+		SetProjection(bson.M{"points": 0})
+
+	cursor, err := r.sweeps.Find(ctx, query, opts)
+	if err != nil {
+		return nil, errs.NewDatabaseError(
+			"failed to find sweeps",
+			err,
+			map[string]any{"intersection ID": intersectionID, "statuses": statuses},
+		)
+	}
+	defer func() {
+		if err := cursor.Close(ctx); err != nil {
+			logger.Warn("failed to close cursor:", "error", err)
+		}
+	}()
+
+	var sweeps []*model.Sweep
+	if err = cursor.All(ctx, &sweeps); err != nil {
+		return nil, errs.NewDatabaseError(
+			"failed to decode sweeps",
+			err,
+			map[string]any{"intersection ID": intersectionID, "statuses": statuses},
+		)
+	}
+
+	return sweeps, nil
+}
+
+// AddSweepPoint only applies to running sweeps that do not have the point yet, so a
+// point cannot be added twice or after the sweep has finished
+func (r *MongoIntersectionRepo) AddSweepPoint(
+	ctx context.Context,
+	id string,
+	point model.SweepPoint,
+) error {
+	logger := util.LoggerFromContext(ctx)
+	logger.Debug("adding sweep point")
+
+	filter := bson.M{
+		"id":           id,
+		"status":       model.SweepRunning,
+		"points.index": bson.M{"$ne": point.Index},
+	}
+	failed := 0
+	if point.Error != "" {
+		failed = 1
+	}
+	update := bson.M{
+		"$push": bson.M{"points": point},
+		"$inc":  bson.M{"completedpoints": 1, "failedpoints": failed},
+	}
+
+	result, err := r.sweeps.UpdateOne(ctx, filter, update)
+	if err != nil {
+		return errs.NewDatabaseError(
+			"failed to add sweep point",
+			err,
+			map[string]any{"sweep ID": id, "index": point.Index},
+		)
+	}
+	if result.MatchedCount == 0 {
+		return errs.NewConflictError(
+			"sweep not found, not running or already has this point",
+			map[string]any{"sweep ID": id, "index": point.Index},
+		)
+	}
+
+	return nil
+}
+
+// UpdateSweep only applies to sweeps that have not yet finished
+func (r *MongoIntersectionRepo) UpdateSweep(
+	ctx context.Context,
+	sweep *model.Sweep,
+) (*model.Sweep, error) {
+	logger := util.LoggerFromContext(ctx)
+	logger.Debug("updating sweep")
+
+	filter := bson.M{
+		"id":     sweep.ID,
+		"status": bson.M{"$in": []model.SweepStatus{model.SweepPending, model.SweepRunning}},
+	}
+	update := bson.M{
+		"$set": bson.M{
+			"status":     sweep.Status,
+			"error":      sweep.Error,
+			"startedat":  sweep.StartedAt,
+			"finishedat": sweep.FinishedAt,
+		},
+	}
+
+	opts := options.FindOneAndUpdate().SetReturnDocument(options.After)
+	var updatedSweep model.Sweep
+
+	err := r.sweeps.FindOneAndUpdate(ctx, filter, update, opts).Decode(&updatedSweep)
+	if err != nil {
+		if err == mongo.ErrNoDocuments {
+			return nil, errs.NewConflictError(
+				"sweep not found or already finished",
+				map[string]any{"sweep ID": sweep.ID},
+			)
+		}
+		return nil, errs.NewDatabaseError(
+			"failed to update sweep",
+			err,
+			map[string]any{"sweep ID": sweep.ID},
+		)
+	}
+
+	return &updatedSweep, nil
+}
@@ -1,5 +0,0 @@
-package test
-
-func (suite *TestSuite) TestUpdateBestParams_Success() {
-	// TODO: Add test case implementation
-}
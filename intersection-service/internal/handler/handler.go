@@ -40,6 +40,7 @@ func (h *Handler) CreateIntersection(
 		intersectionDetails,
 		trafficDensity,
 		optimisationParams,
+		req.GetUserId(),
 	)
 	if err != nil {
 		logger.Error("failed to create intersection",
@@ -82,12 +83,23 @@ func (h *Handler) GetAllIntersections(
 	logger := util.LoggerFromContext(ctx)
 	logger.Info("processing GetAllIntersections request")
 
-	intersections, err := h.service.GetAllIntersections(
-		ctx,
-		int(req.GetPage()),
-		int(req.GetPageSize()),
-		req.GetFilter(),
-	)
+	var intersections []*model.Intersection
+	var err error
+	if req.GetById() {
+		intersections, err = h.service.GetIntersectionsAfter(
+			ctx,
+			req.GetAfterId(),
+			int(req.GetPageSize()),
+		)
+	} else {
+		intersections, err = h.service.GetAllIntersections(
+			ctx,
+			int(req.GetPage()),
+			int(req.GetPageSize()),
+			req.GetFilter(),
+			req.GetDeleted(),
+		)
+	}
 	if err != nil {
 		logger.Error("failed to find all intersections",
 			"error", err.Error(),
@@ -126,16 +138,50 @@ func (h *Handler) UpdateIntersection(
 	logger := util.LoggerFromContext(ctx)
 	logger.Info("processing UpdateIntersection request")
 
+	if isStatusChange(req) {
+		intersection, err := h.service.UpdateIntersectionStatus(
+			ctx,
+			req.GetId(),
+			model.IntersectionStatus(req.GetStatus().String()),
+			req.GetFailureReason(),
+		)
+		if err != nil {
+			logger.Error("failed to update intersection status",
+				"error", err.Error(),
+			)
+			return nil, errs.HandleServiceError(err)
+		}
+
+		logger.Info("UpdateIntersection successful")
+		return h.mapToIntersection(intersection), nil
+	}
+
 	intersectionDetails := h.mapIntersectionDetails(req.GetDetails())
 
 	intersectionStatus := model.IntersectionStatus(req.Status.String())
 
+	// NOTE: Unspecified density and unset default parameters are left unchanged
+	var trafficDensity model.TrafficDensity
+	if req.GetTrafficDensity() != commonpb.TrafficDensity_TRAFFIC_DENSITY_UNSPECIFIED {
+		trafficDensity = model.TrafficDensity(req.GetTrafficDensity().String())
+	}
+	var defaultParams *model.OptimisationParameters
+	if req.GetDefaultParameters() != nil {
+		params := h.mapOptimisationParameters(req.GetDefaultParameters())
+		defaultParams = &params
+	}
+
 	intersection, err := h.service.UpdateIntersection(
 		ctx,
 		req.GetId(),
 		req.GetName(),
 		intersectionDetails,
 		intersectionStatus,
+		req.GetFailureReason(),
+		trafficDensity,
+		defaultParams,
+		req.GetUserId(),
+		int(req.GetExpectedVersion()),
 	)
 	if err != nil {
 		logger.Error("failed to update intersection",
@@ -150,12 +196,12 @@ func (h *Handler) UpdateIntersection(
 
 func (h *Handler) DeleteIntersection(
 	ctx context.Context,
-	req *intersectionpb.IntersectionIDRequest,
+	req *intersectionpb.DeleteIntersectionRequest,
 ) (*emptypb.Empty, error) {
 	logger := util.LoggerFromContext(ctx)
 	logger.Info("processing DeleteIntersection request")
 
-	err := h.service.DeleteIntersection(ctx, req.GetId())
+	err := h.service.DeleteIntersection(ctx, req.GetId(), int(req.GetExpectedVersion()))
 	if err != nil {
 		logger.Error("failed to delete intersection",
 			"error", err.Error(),
@@ -167,6 +213,25 @@ func (h *Handler) DeleteIntersection(
 	return &emptypb.Empty{}, nil
 }
 
+func (h *Handler) RestoreIntersection(
+	ctx context.Context,
+	req *intersectionpb.IntersectionIDRequest,
+) (*intersectionpb.IntersectionResponse, error) {
+	logger := util.LoggerFromContext(ctx)
+	logger.Info("processing RestoreIntersection request")
+
+	intersection, err := h.service.RestoreIntersection(ctx, req.GetId())
+	if err != nil {
+		logger.Error("failed to restore intersection",
+			"error", err.Error(),
+		)
+		return nil, errs.HandleServiceError(err)
+	}
+
+	logger.Info("RestoreIntersection successful")
+	return h.mapToIntersection(intersection), nil
+}
+
 func (h *Handler) PutOptimisation(
 	ctx context.Context,
 	req *intersectionpb.PutOptimisationRequest,
@@ -175,11 +240,15 @@ func (h *Handler) PutOptimisation(
 	logger.Info("processing PutOptimisation request")
 
 	optimisationParams := h.mapOptimisationParameters(req.GetParameters())
+	metrics := h.mapSimulationResults(req.GetMetrics())
 
 	optimisationResponse, err := h.service.PutOptimisation(
 		ctx,
 		req.GetId(),
 		optimisationParams,
+		metrics,
+		req.GetUserId(),
+		req.GetJobId(),
 	)
 	if err != nil {
 		logger.Error("failed to update intersection optimisation params",
@@ -191,3 +260,416 @@ func (h *Handler) PutOptimisation(
 	logger.Info("PutOptimisation successful")
 	return &intersectionpb.PutOptimisationResponse{Improved: optimisationResponse}, nil
 }
+
+func (h *Handler) CreateOptimisationJob(
+	ctx context.Context,
+	req *intersectionpb.CreateOptimisationJobRequest,
+) (*intersectionpb.OptimisationJobResponse, error) {
+	logger := util.LoggerFromContext(ctx)
+	logger.Info("processing CreateOptimisationJob request")
+
+	previousStatus := model.IntersectionStatus(req.GetPreviousStatus().String())
+
+	job, err := h.service.CreateOptimisationJob(
+		ctx,
+		req.GetIntersectionId(),
+		req.GetUserId(),
+		previousStatus,
+	)
+	if err != nil {
+		logger.Error("failed to create optimisation job",
+			"error", err.Error(),
+		)
+		return nil, errs.HandleServiceError(err)
+	}
+
+	logger.Info("CreateOptimisationJob successful")
+	return h.mapToOptimisationJob(job), nil
+}
+
+func (h *Handler) GetOptimisationJob(
+	ctx context.Context,
+	req *intersectionpb.OptimisationJobIDRequest,
+) (*intersectionpb.OptimisationJobResponse, error) {
+	logger := util.LoggerFromContext(ctx)
+	logger.Info("processing GetOptimisationJob request")
+
+	job, err := h.service.GetOptimisationJob(ctx, req.GetId())
+	if err != nil {
+		logger.Error("failed to find optimisation job",
+			"error", err.Error(),
+		)
+		return nil, errs.HandleServiceError(err)
+	}
+
+	logger.Info("GetOptimisationJob successful")
+	return h.mapToOptimisationJob(job), nil
+}
+
+func (h *Handler) GetOptimisationJobs(
+	req *intersectionpb.GetOptimisationJobsRequest,
+	stream intersectionpb.IntersectionService_GetOptimisationJobsServer,
+) error {
+	ctx := stream.Context()
+	logger := util.LoggerFromContext(ctx)
+	logger.Info("processing GetOptimisationJobs request")
+
+	statuses := make([]model.OptimisationJobStatus, 0, len(req.GetStatuses()))
+	for _, status := range req.GetStatuses() {
+		statuses = append(statuses, model.OptimisationJobStatus(status.String()))
+	}
+
+	jobs, err := h.service.GetOptimisationJobs(ctx, req.GetIntersectionId(), statuses)
+	if err != nil {
+		logger.Error("failed to find optimisation jobs",
+			"error", err.Error(),
+		)
+		return errs.HandleServiceError(err)
+	}
+
+	for _, job := range jobs {
+		select {
+		case <-ctx.Done():
+			return ctx.Err()
+		default:
+		}
+
+		response := h.mapToOptimisationJob(job)
+		if response == nil {
+			continue
+		}
+
+		if err := stream.Send(response); err != nil {
+			logger.Error("failed to send optimisation job",
+				"error", err.Error(),
+			)
+			return errs.HandleServiceError(err)
+		}
+	}
+
+	logger.Info("GetOptimisationJobs successful")
+	return nil
+}
+
+func (h *Handler) UpdateOptimisationJob(
+	ctx context.Context,
+	req *intersectionpb.UpdateOptimisationJobRequest,
+) (*intersectionpb.OptimisationJobResponse, error) {
+	logger := util.LoggerFromContext(ctx)
+	logger.Info("processing UpdateOptimisationJob request")
+
+	job, err := h.service.UpdateOptimisationJob(
+		ctx,
+		req.GetId(),
+		model.OptimisationJobStatus(req.GetStatus().String()),
+		req.GetImproved(),
+		req.GetError(),
+	)
+	if err != nil {
+		logger.Error("failed to update optimisation job",
+			"error", err.Error(),
+		)
+		return nil, errs.HandleServiceError(err)
+	}
+
+	logger.Info("UpdateOptimisationJob successful")
+	return h.mapToOptimisationJob(job), nil
+}
+
+func (h *Handler) RenewOptimisationLease(
+	ctx context.Context,
+	req *intersectionpb.OptimisationJobIDRequest,
+) (*intersectionpb.OptimisationJobResponse, error) {
+	logger := util.LoggerFromContext(ctx)
+	logger.Info("processing RenewOptimisationLease request")
+
+	job, err := h.service.RenewOptimisationLease(ctx, req.GetId())
+	if err != nil {
+		logger.Error("failed to renew optimisation lease",
+			"error", err.Error(),
+		)
+		return nil, errs.HandleServiceError(err)
+	}
+
+	logger.Info("RenewOptimisationLease successful")
+	return h.mapToOptimisationJob(job), nil
+}
+
+func (h *Handler) CreateRun(
+	ctx context.Context,
+	req *intersectionpb.CreateRunRequest,
+) (*intersectionpb.RunResponse, error) {
+	logger := util.LoggerFromContext(ctx)
+	logger.Info("processing CreateRun request")
+
+	run, err := h.service.CreateRun(ctx, h.mapRun(req))
+	if err != nil {
+		logger.Error("failed to create run",
+			"error", err.Error(),
+		)
+		return nil, errs.HandleServiceError(err)
+	}
+
+	logger.Info("CreateRun successful")
+	return h.mapToRun(run), nil
+}
+
+func (h *Handler) GetRun(
+	ctx context.Context,
+	req *intersectionpb.RunIDRequest,
+) (*intersectionpb.RunResponse, error) {
+	logger := util.LoggerFromContext(ctx)
+	logger.Info("processing GetRun request")
+
+	run, err := h.service.GetRun(ctx, req.GetIntersectionId(), req.GetId())
+	if err != nil {
+		logger.Error("failed to find run",
+			"error", err.Error(),
+		)
+		return nil, errs.HandleServiceError(err)
+	}
+
+	logger.Info("GetRun successful")
+	return h.mapToRun(run), nil
+}
+
+func (h *Handler) GetRuns(
+	req *intersectionpb.GetRunsRequest,
+	stream intersectionpb.IntersectionService_GetRunsServer,
+) error {
+	ctx := stream.Context()
+	logger := util.LoggerFromContext(ctx)
+	logger.Info("processing GetRuns request")
+
+	runs, err := h.service.GetRuns(
+		ctx,
+		req.GetIntersectionId(),
+		int(req.GetPage()),
+		int(req.GetPageSize()),
+	)
+	if err != nil {
+		logger.Error("failed to find runs",
+			"error", err.Error(),
+		)
+		return errs.HandleServiceError(err)
+	}
+
+	for _, run := range runs {
+		select {
+		case <-ctx.Done():
+			return ctx.Err()
+		default:
+		}
+
+		response := h.mapToRun(run)
+		if response == nil {
+			continue
+		}
+
+		if err := stream.Send(response); err != nil {
+			logger.Error("failed to send run",
+				"error", err.Error(),
+			)
+			return errs.HandleServiceError(err)
+		}
+	}
+
+	logger.Info("GetRuns successful")
+	return nil
+}
+
+func (h *Handler) CreateSweep(
+	ctx context.Context,
+	req *intersectionpb.CreateSweepRequest,
+) (*intersectionpb.SweepResponse, error) {
+	logger := util.LoggerFromContext(ctx)
+	logger.Info("processing CreateSweep request")
+
+	sweep, err := h.service.CreateSweep(ctx, h.mapSweep(req))
+	if err != nil {
+		logger.Error("failed to create sweep",
+			"error", err.Error(),
+		)
+		return nil, errs.HandleServiceError(err)
+	}
+
+	logger.Info("CreateSweep successful")
+	return h.mapToSweep(sweep), nil
+}
+
+func (h *Handler) GetSweep(
+	ctx context.Context,
+	req *intersectionpb.SweepIDRequest,
+) (*intersectionpb.SweepResponse, error) {
+	logger := util.LoggerFromContext(ctx)
+	logger.Info("processing GetSweep request")
+
+	sweep, err := h.service.GetSweep(ctx, req.GetIntersectionId(), req.GetId())
+	if err != nil {
+		logger.Error("failed to find sweep",
+			"error", err.Error(),
+		)
+		return nil, errs.HandleServiceError(err)
+	}
+
+	logger.Info("GetSweep successful")
+	return h.mapToSweep(sweep), nil
+}
+
+func (h *Handler) GetSweeps(
+	req *intersectionpb.GetSweepsRequest,
+	stream intersectionpb.IntersectionService_GetSweepsServer,
+) error {
+	ctx := stream.Context()
+	logger := util.LoggerFromContext(ctx)
+	logger.Info("processing GetSweeps request")
+
+	statuses := make([]model.SweepStatus, 0, len(req.GetStatuses()))
+	for _, status := range req.GetStatuses() {
+		statuses = append(statuses, model.SweepStatus(status.String()))
+	}
+
+	sweeps, err := h.service.GetSweeps(ctx, req.GetIntersectionId(), statuses)
+	if err != nil {
+		logger.Error("failed to find sweeps",
+			"error", err.Error(),
+		)
+		return errs.HandleServiceError(err)
+	}
+
+	for _, sweep := range sweeps {
+		select {
+		case <-ctx.Done():
+			return ctx.Err()
+		default:
+		}
+
+		response := h.mapToSweep(sweep)
+		if response == nil {
+			continue
+		}
+
+		if err := stream.Send(response); err != nil {
+			logger.Error("failed to send sweep",
+				"error", err.Error(),
+			)
+			return errs.HandleServiceError(err)
+		}
+	}
+
+	logger.Info("GetSweeps successful")
+	return nil
+}
+
+func (h *Handler) PutSweepPoint(
+	ctx context.Context,
+	req *intersectionpb.PutSweepPointRequest,
+) (*emptypb.Empty, error) {
+	logger := util.LoggerFromContext(ctx)
+	logger.Info("processing PutSweepPoint request")
+
+	err := h.service.PutSweepPoint(ctx, req.GetId(), h.mapSweepPoint(req.GetPoint()))
+	if err != nil {
+		logger.Error("failed to add sweep point",
+			"error", err.Error(),
+		)
+		return nil, errs.HandleServiceError(err)
+	}
+
+	logger.Info("PutSweepPoint successful")
+	return &emptypb.Empty{}, nil
+}
+
+func (h *Handler) UpdateSweep(
+	ctx context.Context,
+	req *intersectionpb.UpdateSweepRequest,
+) (*intersectionpb.SweepResponse, error) {
+	logger := util.LoggerFromContext(ctx)
+	logger.Info("processing UpdateSweep request")
+
+	sweep, err := h.service.UpdateSweep(
+		ctx,
+		req.GetId(),
+		model.SweepStatus(req.GetStatus().String()),
+		req.GetError(),
+	)
+	if err != nil {
+		logger.Error("failed to update sweep",
+			"error", err.Error(),
+		)
+		return nil, errs.HandleServiceError(err)
+	}
+
+	logger.Info("UpdateSweep successful")
+	return h.mapToSweep(sweep), nil
+}
+
+func (h *Handler) GetParameterVersions(
+	req *intersectionpb.GetParameterVersionsRequest,
+	stream intersectionpb.IntersectionService_GetParameterVersionsServer,
+) error {
+	ctx := stream.Context()
+	logger := util.LoggerFromContext(ctx)
+	logger.Info("processing GetParameterVersions request")
+
+	versions, err := h.service.GetParameterVersions(
+		ctx,
+		req.GetIntersectionId(),
+		int(req.GetPage()),
+		int(req.GetPageSize()),
+	)
+	if err != nil {
+		logger.Error("failed to find parameter versions",
+			"error", err.Error(),
+		)
+		return errs.HandleServiceError(err)
+	}
+
+	for _, version := range versions {
+		select {
+		case <-ctx.Done():
+			return ctx.Err()
+		default:
+		}
+
+		response := h.mapToParameterVersion(version)
+		if response == nil {
+			continue
+		}
+
+		if err := stream.Send(response); err != nil {
+			logger.Error("failed to send parameter version",
+				"error", err.Error(),
+			)
+			return errs.HandleServiceError(err)
+		}
+	}
+
+	logger.Info("GetParameterVersions successful")
+	return nil
+}
+
+func (h *Handler) RevertParameterVersion(
+	ctx context.Context,
+	req *intersectionpb.RevertParameterVersionRequest,
+) (*intersectionpb.IntersectionResponse, error) {
+	logger := util.LoggerFromContext(ctx)
+	logger.Info("processing RevertParameterVersion request")
+
+	intersection, err := h.service.RevertParameterVersion(
+		ctx,
+		req.GetIntersectionId(),
+		int(req.GetVersion()),
+		req.GetUserId(),
+		int(req.GetExpectedVersion()),
+	)
+	if err != nil {
+		logger.Error("failed to revert parameter version",
+			"error", err.Error(),
+		)
+		return nil, errs.HandleServiceError(err)
+	}
+
+	logger.Info("RevertParameterVersion successful")
+	return h.mapToIntersection(intersection), nil
+}
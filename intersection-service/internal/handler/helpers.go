@@ -1,9 +1,13 @@
 package handler
 
 import (
+	"time"
+
 	"github.com/COS301-SE-2025/Swift-Signals/intersection-service/internal/model"
 	commonpb "github.com/COS301-SE-2025/Swift-Signals/protos/gen/swiftsignals/common/v1"
 	intersectionpb "github.com/COS301-SE-2025/Swift-Signals/protos/gen/swiftsignals/intersection/v1"
+	simulationpb "github.com/COS301-SE-2025/Swift-Signals/protos/gen/swiftsignals/simulation/v1"
+	"google.golang.org/protobuf/types/known/durationpb"
 	"google.golang.org/protobuf/types/known/timestamppb"
 )
 
@@ -59,6 +63,87 @@ func (h *Handler) mapOptimisationParameters(
 	}
 }
 
+func (h *Handler) mapSimulationResults(
+	pbResults *simulationpb.SimulationResultsResponse,
+) *model.SimulationResults {
+	if pbResults == nil {
+		return nil
+	}
+
+	return &model.SimulationResults{
+		TotalVehicles:      int(pbResults.GetTotalVehicles()),
+		AverageTravelTime:  float64(pbResults.GetAverageTravelTime()),
+		TotalTravelTime:    float64(pbResults.GetTotalTravelTime()),
+		AverageSpeed:       float64(pbResults.GetAverageSpeed()),
+		AverageWaitingTime: float64(pbResults.GetAverageWaitingTime()),
+		TotalWaitingTime:   float64(pbResults.GetTotalWaitingTime()),
+		GeneratedVehicles:  int(pbResults.GetGeneratedVehicles()),
+		EmergencyBrakes:    int(pbResults.GetEmergencyBrakes()),
+		EmergencyStops:     int(pbResults.GetEmergencyStops()),
+		NearCollisions:     int(pbResults.GetNearCollisions()),
+	}
+}
+
+func (h *Handler) mapRun(pbRun *intersectionpb.CreateRunRequest) *model.Run {
+	return &model.Run{
+		IntersectionID: pbRun.GetIntersectionId(),
+		UserID:         pbRun.GetUserId(),
+		Type:           model.RunType(pbRun.GetType().String()),
+		Parameters:     h.mapOptimisationParameters(pbRun.GetParameters()),
+		Metrics:        h.mapSimulationResults(pbRun.GetMetrics()),
+		Duration:       pbRun.GetDuration().AsDuration(),
+		Outcome:        model.RunOutcome(pbRun.GetOutcome().String()),
+		Error:          pbRun.GetError(),
+		Improved:       pbRun.GetImproved(),
+		JobID:          pbRun.GetJobId(),
+	}
+}
+
+func (h *Handler) mapSweep(pbSweep *intersectionpb.CreateSweepRequest) *model.Sweep {
+	ranges := make([]model.SweepRange, 0, len(pbSweep.GetRanges()))
+	for _, pbRange := range pbSweep.GetRanges() {
+		ranges = append(ranges, model.SweepRange{
+			Parameter: pbRange.GetParameter(),
+			Min:       int(pbRange.GetMin()),
+			Max:       int(pbRange.GetMax()),
+			Step:      int(pbRange.GetStep()),
+		})
+	}
+
+	return &model.Sweep{
+		IntersectionID: pbSweep.GetIntersectionId(),
+		UserID:         pbSweep.GetUserId(),
+		BaseParameters: h.mapSimulationParameters(pbSweep.GetBaseParameters()),
+		Ranges:         ranges,
+		TotalPoints:    int(pbSweep.GetTotalPoints()),
+	}
+}
+
+func (h *Handler) mapSweepPoint(pbPoint *intersectionpb.SweepPoint) model.SweepPoint {
+	if pbPoint == nil {
+		return model.SweepPoint{}
+	}
+
+	return model.SweepPoint{
+		Index:      int(pbPoint.GetIndex()),
+		Parameters: h.mapSimulationParameters(pbPoint.GetParameters()),
+		Metrics:    h.mapSimulationResults(pbPoint.GetMetrics()),
+		Error:      pbPoint.GetError(),
+		Duration:   pbPoint.GetDuration().AsDuration(),
+	}
+}
+
+// isStatusChange reports whether an update carries nothing but a new status, in which
+// case the name, details and defaults are left as they are
+func isStatusChange(req *intersectionpb.UpdateIntersectionRequest) bool {
+	return req.GetStatus() != commonpb.IntersectionStatus_INTERSECTION_STATUS_UNSPECIFIED &&
+		req.GetName() == "" &&
+		req.GetDetails() == nil &&
+		req.GetTrafficDensity() == commonpb.TrafficDensity_TRAFFIC_DENSITY_UNSPECIFIED &&
+		req.GetDefaultParameters() == nil &&
+		req.GetExpectedVersion() == 0
+}
+
 // =============================================================================
 // MAPPING HELPERS - MODEL TO PROTOBUF
 // =============================================================================
@@ -101,6 +186,27 @@ func (h *Handler) mapToProtoOptimisationParameters(
 	}
 }
 
+func (h *Handler) mapToProtoSimulationResults(
+	results *model.SimulationResults,
+) *simulationpb.SimulationResultsResponse {
+	if results == nil {
+		return nil
+	}
+
+	return &simulationpb.SimulationResultsResponse{
+		TotalVehicles:      int64(results.TotalVehicles),
+		AverageTravelTime:  float32(results.AverageTravelTime),
+		TotalTravelTime:    float32(results.TotalTravelTime),
+		AverageSpeed:       float32(results.AverageSpeed),
+		AverageWaitingTime: float32(results.AverageWaitingTime),
+		TotalWaitingTime:   float32(results.TotalWaitingTime),
+		GeneratedVehicles:  int64(results.GeneratedVehicles),
+		EmergencyBrakes:    int64(results.EmergencyBrakes),
+		EmergencyStops:     int64(results.EmergencyStops),
+		NearCollisions:     int64(results.NearCollisions),
+	}
+}
+
 func (h *Handler) mapToIntersection(
 	intersection *model.Intersection,
 ) *intersectionpb.IntersectionResponse {
@@ -108,7 +214,7 @@ func (h *Handler) mapToIntersection(
 		return nil
 	}
 
-	return &intersectionpb.IntersectionResponse{
+	resp := &intersectionpb.IntersectionResponse{
 		Id:        intersection.ID,
 		Name:      intersection.Name,
 		Details:   h.mapToProtoIntersectionDetails(intersection.Details),
@@ -119,8 +225,160 @@ func (h *Handler) mapToIntersection(
 		RunCount: int32(intersection.RunCount),
 		TrafficDensity: commonpb.TrafficDensity(
 			commonpb.TrafficDensity_value[string(intersection.TrafficDensity)]),
-		DefaultParameters: h.mapToProtoOptimisationParameters(intersection.DefaultParameters),
-		BestParameters:    h.mapToProtoOptimisationParameters(intersection.BestParameters),
-		CurrentParameters: h.mapToProtoOptimisationParameters(intersection.CurrentParameters),
+		DefaultParameters:   h.mapToProtoOptimisationParameters(intersection.DefaultParameters),
+		BestParameters:      h.mapToProtoOptimisationParameters(intersection.BestParameters),
+		CurrentParameters:   h.mapToProtoOptimisationParameters(intersection.CurrentParameters),
+		BestMetrics:         h.mapToProtoSimulationResults(intersection.BestMetrics),
+		CurrentMetrics:      h.mapToProtoSimulationResults(intersection.CurrentMetrics),
+		FailureReason:       intersection.FailureReason,
+		FailedAt:            h.mapToOptionalTimestamp(intersection.FailedAt),
+		BestParametersStale: intersection.BestParametersStale,
+		ParameterVersion:    int32(intersection.ParameterVersion),
+		Version:             int32(intersection.Version),
+	}
+	// NOTE: Parameters carry the intersection's density, so that simulating them uses the
+	// traffic they are meant for
+	for _, params := range []*commonpb.OptimisationParameters{
+		resp.DefaultParameters,
+		resp.BestParameters,
+		resp.CurrentParameters,
+	} {
+		params.Parameters.TrafficDensity = resp.TrafficDensity
+	}
+	if intersection.DeletedAt != nil {
+		resp.DeletedAt = timestamppb.New(*intersection.DeletedAt)
+	}
+	return resp
+}
+
+func (h *Handler) mapToOptionalTimestamp(t time.Time) *timestamppb.Timestamp {
+	if t.IsZero() {
+		return nil
+	}
+	return timestamppb.New(t)
+}
+
+func (h *Handler) mapToOptimisationJob(
+	job *model.OptimisationJob,
+) *intersectionpb.OptimisationJobResponse {
+	if job == nil {
+		return nil
+	}
+
+	return &intersectionpb.OptimisationJobResponse{
+		Id:             job.ID,
+		IntersectionId: job.IntersectionID,
+		UserId:         job.UserID,
+		Status: intersectionpb.OptimisationJobStatus(
+			intersectionpb.OptimisationJobStatus_value[string(job.Status)]),
+		PreviousStatus: commonpb.IntersectionStatus(
+			commonpb.IntersectionStatus_value[string(job.PreviousStatus)]),
+		Improved:    job.Improved,
+		Error:       job.Error,
+		CreatedAt:   timestamppb.New(job.CreatedAt),
+		StartedAt:   h.mapToOptionalTimestamp(job.StartedAt),
+		FinishedAt:  h.mapToOptionalTimestamp(job.FinishedAt),
+		HeartbeatAt: h.mapToOptionalTimestamp(job.HeartbeatAt),
+	}
+}
+
+func (h *Handler) mapToRun(run *model.Run) *intersectionpb.RunResponse {
+	if run == nil {
+		return nil
+	}
+
+	return &intersectionpb.RunResponse{
+		Id:             run.ID,
+		IntersectionId: run.IntersectionID,
+		UserId:         run.UserID,
+		Type:           intersectionpb.RunType(intersectionpb.RunType_value[string(run.Type)]),
+		Parameters:     h.mapToProtoOptimisationParameters(run.Parameters),
+		Metrics:        h.mapToProtoSimulationResults(run.Metrics),
+		Duration:       durationpb.New(run.Duration),
+		Outcome: intersectionpb.RunOutcome(
+			intersectionpb.RunOutcome_value[string(run.Outcome)]),
+		Error:     run.Error,
+		Improved:  run.Improved,
+		JobId:     run.JobID,
+		CreatedAt: timestamppb.New(run.CreatedAt),
+	}
+}
+
+func (h *Handler) mapToSweep(sweep *model.Sweep) *intersectionpb.SweepResponse {
+	if sweep == nil {
+		return nil
+	}
+
+	ranges := make([]*intersectionpb.SweepRange, 0, len(sweep.Ranges))
+	for _, sweepRange := range sweep.Ranges {
+		ranges = append(ranges, &intersectionpb.SweepRange{
+			Parameter: sweepRange.Parameter,
+			Min:       int32(sweepRange.Min),
+			Max:       int32(sweepRange.Max),
+			Step:      int32(sweepRange.Step),
+		})
+	}
+
+	points := make([]*intersectionpb.SweepPoint, 0, len(sweep.Points))
+	for _, point := range sweep.Points {
+		points = append(points, &intersectionpb.SweepPoint{
+			Index:      int32(point.Index),
+			Parameters: h.mapToProtoSimulationParameters(point.Parameters),
+			Metrics:    h.mapToProtoSimulationResults(point.Metrics),
+			Error:      point.Error,
+			Duration:   durationpb.New(point.Duration),
+		})
+	}
+
+	return &intersectionpb.SweepResponse{
+		Id:             sweep.ID,
+		IntersectionId: sweep.IntersectionID,
+		UserId:         sweep.UserID,
+		Status: intersectionpb.SweepStatus(
+			intersectionpb.SweepStatus_value[string(sweep.Status)]),
+		BaseParameters:  h.mapToProtoSimulationParameters(sweep.BaseParameters),
+		Ranges:          ranges,
+		TotalPoints:     int32(sweep.TotalPoints),
+		CompletedPoints: int32(sweep.CompletedPoints),
+		FailedPoints:    int32(sweep.FailedPoints),
+		Points:          points,
+		Error:           sweep.Error,
+		CreatedAt:       timestamppb.New(sweep.CreatedAt),
+		StartedAt:       h.mapToOptionalTimestamp(sweep.StartedAt),
+		FinishedAt:      h.mapToOptionalTimestamp(sweep.FinishedAt),
+	}
+}
+
+func (h *Handler) mapToParameterVersion(
+	version *model.ParameterVersion,
+) *intersectionpb.ParameterVersionResponse {
+	if version == nil {
+		return nil
+	}
+
+	changes := make([]*intersectionpb.ParameterChange, 0, len(version.Changes))
+	for _, change := range version.Changes {
+		var oldParameters *commonpb.OptimisationParameters
+		if change.OldParameters != nil {
+			oldParameters = h.mapToProtoOptimisationParameters(*change.OldParameters)
+		}
+		changes = append(changes, &intersectionpb.ParameterChange{
+			Field: intersectionpb.ParameterField(
+				intersectionpb.ParameterField_value[string(change.Field)]),
+			OldParameters: oldParameters,
+			NewParameters: h.mapToProtoOptimisationParameters(change.NewParameters),
+			Metrics:       h.mapToProtoSimulationResults(change.Metrics),
+		})
+	}
+
+	return &intersectionpb.ParameterVersionResponse{
+		IntersectionId: version.IntersectionID,
+		Version:        int32(version.Version),
+		UserId:         version.UserID,
+		Reason: intersectionpb.ParameterChangeReason(
+			intersectionpb.ParameterChangeReason_value[string(version.Reason)]),
+		RevertedVersion: int32(version.RevertedVersion),
+		Changes:         changes,
+		CreatedAt:       timestamppb.New(version.CreatedAt),
 	}
 }
@@ -0,0 +1,36 @@
+package model
+
+import (
+	"time"
+)
+
+type OptimisationJob struct {
+	ID             string                `json:"id"`
+	IntersectionID string                `json:"intersection_id"`
+	UserID         string                `json:"user_id"`
+	Status         OptimisationJobStatus `json:"status"`
+	PreviousStatus IntersectionStatus    `json:"previous_status"`
+	Improved       bool                  `json:"improved"`
+	Error          string                `json:"error"`
+	CreatedAt      time.Time             `json:"created_at"`
+	StartedAt      time.Time             `json:"started_at"`
+	FinishedAt     time.Time             `json:"finished_at"`
+	// HeartbeatAt is when the worker running the job last renewed its lease
+	HeartbeatAt time.Time `json:"heartbeat_at"`
+}
+
+type OptimisationJobStatus string
+
+const (
+	JobUnspecified OptimisationJobStatus = "OPTIMISATION_JOB_STATUS_UNSPECIFIED"
+	JobPending     OptimisationJobStatus = "OPTIMISATION_JOB_STATUS_PENDING"
+	JobRunning     OptimisationJobStatus = "OPTIMISATION_JOB_STATUS_RUNNING"
+	JobSucceeded   OptimisationJobStatus = "OPTIMISATION_JOB_STATUS_SUCCEEDED"
+	JobFailed      OptimisationJobStatus = "OPTIMISATION_JOB_STATUS_FAILED"
+	JobCancelled   OptimisationJobStatus = "OPTIMISATION_JOB_STATUS_CANCELLED"
+)
+
+// IsTerminal reports whether a job in this status can no longer change
+func (s OptimisationJobStatus) IsTerminal() bool {
+	return s == JobSucceeded || s == JobFailed || s == JobCancelled
+}
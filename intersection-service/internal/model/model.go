@@ -15,7 +15,24 @@ type Intersection struct {
 	TrafficDensity    TrafficDensity         `json:"traffic_density"`
 	DefaultParameters OptimisationParameters `json:"default_parameters"`
 	BestParameters    OptimisationParameters `json:"best_parameters"`
-	CurrentParameters OptimisationParameters `json:"current_parameters"`
+	// BestParametersStale is set when the defaults change after BestParameters were found
+	BestParametersStale bool                   `json:"best_parameters_stale"`
+	CurrentParameters   OptimisationParameters `json:"current_parameters"`
+	BestMetrics         *SimulationResults     `json:"best_metrics"`
+	CurrentMetrics      *SimulationResults     `json:"current_metrics"`
+	OptimisingSince     time.Time              `json:"optimising_since"`
+	FailureReason       string                 `json:"failure_reason"`
+	FailedAt            time.Time              `json:"failed_at"`
+	// LeaseRenewedAt is when the job optimising the intersection last reported that it is
+	// still running
+	LeaseRenewedAt time.Time `json:"lease_renewed_at"`
+	// ParameterVersion is the number of the latest ParameterVersion of the intersection
+	ParameterVersion int `json:"parameter_version"`
+	// Version counts the writes made to the intersection, so that updates can be made
+	// conditional on it being unchanged since it was read
+	Version int `json:"version"`
+	// DeletedAt is set while the intersection is in the trash
+	DeletedAt *time.Time `json:"deleted_at"`
 }
 
 type IntersectionDetails struct {
@@ -64,6 +81,19 @@ type SimulationParameters struct {
 	Seed             int              `json:"seed"`
 }
 
+type SimulationResults struct {
+	TotalVehicles      int     `json:"total_vehicles"`
+	AverageTravelTime  float64 `json:"average_travel_time"`
+	TotalTravelTime    float64 `json:"total_travel_time"`
+	AverageSpeed       float64 `json:"average_speed"`
+	AverageWaitingTime float64 `json:"average_waiting_time"`
+	TotalWaitingTime   float64 `json:"total_waiting_time"`
+	GeneratedVehicles  int     `json:"generated_vehicles"`
+	EmergencyBrakes    int     `json:"emergency_brakes"`
+	EmergencyStops     int     `json:"emergency_stops"`
+	NearCollisions     int     `json:"near_collisions"`
+}
+
 type IntersectionType string
 
 const (
@@ -0,0 +1,45 @@
+package model
+
+import (
+	"time"
+)
+
+// ParameterVersion is an immutable record of one change to an intersection's default,
+// current or best parameters. Versions are numbered per intersection from 1, in the order
+// the changes were made.
+type ParameterVersion struct {
+	IntersectionID  string                `json:"intersection_id"`
+	Version         int                   `json:"version"`
+	UserID          string                `json:"user_id"`
+	Reason          ParameterChangeReason `json:"reason"`
+	RevertedVersion int                   `json:"reverted_version"`
+	Changes         []ParameterChange     `json:"changes"`
+	CreatedAt       time.Time             `json:"created_at"`
+}
+
+// ParameterChange is the change of a single field within a version. Metrics are those
+// that came with the new current or best parameters, and are kept so that a revert can
+// restore both.
+type ParameterChange struct {
+	Field         ParameterField          `json:"field"`
+	OldParameters *OptimisationParameters `json:"old_parameters"`
+	NewParameters OptimisationParameters  `json:"new_parameters"`
+	Metrics       *SimulationResults      `json:"metrics"`
+}
+
+type ParameterField string
+
+const (
+	ParameterFieldDefault ParameterField = "PARAMETER_FIELD_DEFAULT"
+	ParameterFieldCurrent ParameterField = "PARAMETER_FIELD_CURRENT"
+	ParameterFieldBest    ParameterField = "PARAMETER_FIELD_BEST"
+)
+
+type ParameterChangeReason string
+
+const (
+	ParametersCreated   ParameterChangeReason = "PARAMETER_CHANGE_REASON_CREATED"
+	ParametersUpdated   ParameterChangeReason = "PARAMETER_CHANGE_REASON_UPDATED"
+	ParametersOptimised ParameterChangeReason = "PARAMETER_CHANGE_REASON_OPTIMISED"
+	ParametersReverted  ParameterChangeReason = "PARAMETER_CHANGE_REASON_REVERTED"
+)
@@ -0,0 +1,35 @@
+package model
+
+import (
+	"time"
+)
+
+type Run struct {
+	ID             string                 `json:"id"`
+	IntersectionID string                 `json:"intersection_id"`
+	UserID         string                 `json:"user_id"`
+	Type           RunType                `json:"type"`
+	Parameters     OptimisationParameters `json:"parameters"`
+	Metrics        *SimulationResults     `json:"metrics"`
+	Duration       time.Duration          `json:"duration"`
+	Outcome        RunOutcome             `json:"outcome"`
+	Error          string                 `json:"error"`
+	Improved       bool                   `json:"improved"`
+	JobID          string                 `json:"job_id"`
+	CreatedAt      time.Time              `json:"created_at"`
+}
+
+type RunType string
+
+const (
+	RunSimulation   RunType = "RUN_TYPE_SIMULATION"
+	RunOptimisation RunType = "RUN_TYPE_OPTIMISATION"
+)
+
+type RunOutcome string
+
+const (
+	RunSucceeded RunOutcome = "RUN_OUTCOME_SUCCEEDED"
+	RunFailed    RunOutcome = "RUN_OUTCOME_FAILED"
+	RunCancelled RunOutcome = "RUN_OUTCOME_CANCELLED"
+)
@@ -0,0 +1,57 @@
+package model
+
+import (
+	"time"
+)
+
+// Sweep simulates an intersection over a grid of parameters. Every point of the grid is
+// the base parameters with the swept parameters set to one combination of their ranges.
+// The point counts are kept alongside the points so that sweeps can be listed without them.
+type Sweep struct {
+	ID              string               `json:"id"`
+	IntersectionID  string               `json:"intersection_id"`
+	UserID          string               `json:"user_id"`
+	Status          SweepStatus          `json:"status"`
+	BaseParameters  SimulationParameters `json:"base_parameters"`
+	Ranges          []SweepRange         `json:"ranges"`
+	TotalPoints     int                  `json:"total_points"`
+	CompletedPoints int                  `json:"completed_points"`
+	FailedPoints    int                  `json:"failed_points"`
+	Points          []SweepPoint         `json:"points"`
+	Error           string               `json:"error"`
+	CreatedAt       time.Time            `json:"created_at"`
+	StartedAt       time.Time            `json:"started_at"`
+	FinishedAt      time.Time            `json:"finished_at"`
+}
+
+type SweepRange struct {
+	Parameter string `validate:"required,oneof=green yellow red speed seed" json:"parameter"`
+	Min       int    `validate:"min=1"                                     json:"min"`
+	Max       int    `validate:"gtefield=Min"                              json:"max"`
+	Step      int    `validate:"gt=0"                                      json:"step"`
+}
+
+// SweepPoint is one simulated point of a sweep. Points whose simulation failed have an
+// error instead of metrics.
+type SweepPoint struct {
+	Index      int                  `json:"index"`
+	Parameters SimulationParameters `json:"parameters"`
+	Metrics    *SimulationResults   `json:"metrics"`
+	Error      string               `json:"error"`
+	Duration   time.Duration        `json:"duration"`
+}
+
+type SweepStatus string
+
+const (
+	SweepUnspecified SweepStatus = "SWEEP_STATUS_UNSPECIFIED"
+	SweepPending     SweepStatus = "SWEEP_STATUS_PENDING"
+	SweepRunning     SweepStatus = "SWEEP_STATUS_RUNNING"
+	SweepSucceeded   SweepStatus = "SWEEP_STATUS_SUCCEEDED"
+	SweepFailed      SweepStatus = "SWEEP_STATUS_FAILED"
+)
+
+// IsTerminal reports whether a sweep in this status can no longer change
+func (s SweepStatus) IsTerminal() bool {
+	return s == SweepSucceeded || s == SweepFailed
+}
@@ -2,6 +2,7 @@ package service
 
 import (
 	"context"
+	"time"
 
 	"github.com/COS301-SE-2025/Swift-Signals/intersection-service/internal/model"
 )
@@ -13,12 +14,19 @@ type IntersectionService interface {
 		details model.IntersectionDetails,
 		density model.TrafficDensity,
 		defaultParams model.OptimisationParameters,
+		userID string,
 	) (*model.Intersection, error)
 	GetIntersection(ctx context.Context, id string) (*model.Intersection, error)
 	GetAllIntersections(
 		ctx context.Context,
 		page, pageSize int,
 		filter string,
+		deleted bool,
+	) ([]*model.Intersection, error)
+	GetIntersectionsAfter(
+		ctx context.Context,
+		afterID string,
+		pageSize int,
 	) ([]*model.Intersection, error)
 	UpdateIntersection(
 		ctx context.Context,
@@ -26,13 +34,83 @@ type IntersectionService interface {
 		name string,
 		details model.IntersectionDetails,
 		status model.IntersectionStatus,
+		failureReason string,
+		density model.TrafficDensity,
+		defaultParams *model.OptimisationParameters,
+		userID string,
+		expectedVersion int,
 	) (*model.Intersection, error)
-	DeleteIntersection(ctx context.Context, id string) error
+	UpdateIntersectionStatus(
+		ctx context.Context,
+		id string,
+		status model.IntersectionStatus,
+		failureReason string,
+	) (*model.Intersection, error)
+	DeleteIntersection(ctx context.Context, id string, expectedVersion int) error
+	RestoreIntersection(ctx context.Context, id string) (*model.Intersection, error)
+	PurgeDeletedIntersections(ctx context.Context, retention time.Duration) ([]string, error)
 	PutOptimisation(
 		ctx context.Context,
 		id string,
 		params model.OptimisationParameters,
+		metrics *model.SimulationResults,
+		userID string,
+		jobID string,
 	) (bool, error)
+	ReconcileStaleOptimisations(ctx context.Context, lease time.Duration) ([]string, error)
+	CreateOptimisationJob(
+		ctx context.Context,
+		intersectionID string,
+		userID string,
+		previousStatus model.IntersectionStatus,
+	) (*model.OptimisationJob, error)
+	GetOptimisationJob(ctx context.Context, id string) (*model.OptimisationJob, error)
+	GetOptimisationJobs(
+		ctx context.Context,
+		intersectionID string,
+		statuses []model.OptimisationJobStatus,
+	) ([]*model.OptimisationJob, error)
+	UpdateOptimisationJob(
+		ctx context.Context,
+		id string,
+		status model.OptimisationJobStatus,
+		improved bool,
+		errMsg string,
+	) (*model.OptimisationJob, error)
+	RenewOptimisationLease(ctx context.Context, id string) (*model.OptimisationJob, error)
+	CreateRun(ctx context.Context, run *model.Run) (*model.Run, error)
+	GetRun(ctx context.Context, intersectionID, id string) (*model.Run, error)
+	GetRuns(
+		ctx context.Context,
+		intersectionID string,
+		page, pageSize int,
+	) ([]*model.Run, error)
+	CreateSweep(ctx context.Context, sweep *model.Sweep) (*model.Sweep, error)
+	GetSweep(ctx context.Context, intersectionID, id string) (*model.Sweep, error)
+	GetSweeps(
+		ctx context.Context,
+		intersectionID string,
+		statuses []model.SweepStatus,
+	) ([]*model.Sweep, error)
+	PutSweepPoint(ctx context.Context, id string, point model.SweepPoint) error
+	UpdateSweep(
+		ctx context.Context,
+		id string,
+		status model.SweepStatus,
+		errMsg string,
+	) (*model.Sweep, error)
+	GetParameterVersions(
+		ctx context.Context,
+		intersectionID string,
+		page, pageSize int,
+	) ([]*model.ParameterVersion, error)
+	RevertParameterVersion(
+		ctx context.Context,
+		intersectionID string,
+		version int,
+		userID string,
+		expectedVersion int,
+	) (*model.Intersection, error)
 }
 
 type CreateIntersectionRequest struct {
@@ -52,17 +130,147 @@ type GetAllIntersectionsRequest struct {
 	Filter   string `validate:"max=255"       json:"filter"`
 }
 
+type GetIntersectionsAfterRequest struct {
+	AfterID  string `validate:"omitempty,uuid4" json:"after_id"`
+	PageSize int    `validate:"min=1,max=100"   json:"page_size"`
+}
+
 type UpdateIntersectionRequest struct {
-	ID      string                    `validate:"required,uuid4"         json:"id"`
-	Name    string                    `validate:"required,min=2,max=100" json:"name"`
-	Details model.IntersectionDetails `validate:"required"               json:"details"`
+	ID            string                    `validate:"required,uuid4"         json:"id"`
+	Name          string                    `validate:"required,min=2,max=100" json:"name"`
+	Details       model.IntersectionDetails `validate:"required"               json:"details"`
+	FailureReason string                    `validate:"max=1024"               json:"failure_reason"`
+	Density       model.TrafficDensity      `validate:"omitempty,oneof=TRAFFIC_DENSITY_LOW TRAFFIC_DENSITY_MEDIUM TRAFFIC_DENSITY_HIGH" json:"density"`
+	DefaultParams *DefaultParametersRequest `validate:"omitempty"                                                                       json:"default_params"`
+	// ExpectedVersion makes the update conditional on the intersection's version, unless 0
+	ExpectedVersion int `validate:"min=0" json:"expected_version"`
+}
+
+type UpdateIntersectionStatusRequest struct {
+	ID            string                   `validate:"required,uuid4" json:"id"`
+	Status        model.IntersectionStatus `validate:"required,oneof=INTERSECTION_STATUS_UNOPTIMISED INTERSECTION_STATUS_OPTIMISING INTERSECTION_STATUS_OPTIMISED INTERSECTION_STATUS_FAILED" json:"status"`
+	FailureReason string                   `validate:"max=1024"       json:"failure_reason"`
+}
+
+// DefaultParametersRequest validates new default parameters, whose enums the handler maps
+// from their names
+type DefaultParametersRequest struct {
+	OptimisationType model.OptimisationType `validate:"required,excludes=UNSPECIFIED" json:"optimisation_type"`
+	IntersectionType model.IntersectionType `validate:"required,excludes=UNSPECIFIED" json:"intersection_type"`
+	Green            int                    `validate:"min=1"                         json:"green"`
+	Yellow           int                    `validate:"min=1"                         json:"yellow"`
+	Red              int                    `validate:"min=1"                         json:"red"`
+	Speed            int                    `validate:"min=1"                         json:"speed"`
 }
 
 type DeleteIntersectionRequest struct {
 	ID string `validate:"required,uuid4" json:"id"`
+	// ExpectedVersion makes the deletion conditional on the intersection's version, unless 0
+	ExpectedVersion int `validate:"min=0" json:"expected_version"`
+}
+
+type RestoreIntersectionRequest struct {
+	ID string `validate:"required,uuid4" json:"id"`
+}
+
+type PurgeDeletedIntersectionsRequest struct {
+	Retention time.Duration `validate:"gt=0" json:"retention"`
 }
 
 type PutOptimisationRequest struct {
-	ID     string                       `validate:"required,uuid4" json:"id"`
-	Params model.OptimisationParameters `validate:"required"       json:"params"`
+	ID      string                       `validate:"required,uuid4"  json:"id"`
+	Params  model.OptimisationParameters `validate:"required"        json:"params"`
+	Metrics *model.SimulationResults     `validate:"required"        json:"metrics"`
+	JobID   string                       `validate:"omitempty,uuid4" json:"job_id"`
+}
+
+type ReconcileStaleOptimisationsRequest struct {
+	Lease time.Duration `validate:"gt=0" json:"lease"`
+}
+
+type CreateOptimisationJobRequest struct {
+	IntersectionID string `validate:"required,uuid4" json:"intersection_id"`
+	UserID         string `validate:"required"       json:"user_id"`
+}
+
+type GetOptimisationJobRequest struct {
+	ID string `validate:"required,uuid4" json:"id"`
+}
+
+type GetOptimisationJobsRequest struct {
+	IntersectionID string `validate:"omitempty,uuid4" json:"intersection_id"`
+}
+
+type UpdateOptimisationJobRequest struct {
+	ID     string                      `validate:"required,uuid4"                                                                                                                        json:"id"`
+	Status model.OptimisationJobStatus `validate:"required,oneof=OPTIMISATION_JOB_STATUS_RUNNING OPTIMISATION_JOB_STATUS_SUCCEEDED OPTIMISATION_JOB_STATUS_FAILED OPTIMISATION_JOB_STATUS_CANCELLED" json:"status"`
+	Error  string                      `validate:"max=1024"                                                                                                                              json:"error"`
+}
+
+type RenewOptimisationLeaseRequest struct {
+	ID string `validate:"required,uuid4" json:"id"`
+}
+
+type CreateRunRequest struct {
+	IntersectionID string           `validate:"required,uuid4"                                                                json:"intersection_id"`
+	UserID         string           `validate:"required"                                                                      json:"user_id"`
+	Type           model.RunType    `validate:"required,oneof=RUN_TYPE_SIMULATION RUN_TYPE_OPTIMISATION"                      json:"type"`
+	Outcome        model.RunOutcome `validate:"required,oneof=RUN_OUTCOME_SUCCEEDED RUN_OUTCOME_FAILED RUN_OUTCOME_CANCELLED" json:"outcome"`
+	Duration       time.Duration    `validate:"gte=0"                                                                         json:"duration"`
+	Error          string           `validate:"max=1024"                                                                      json:"error"`
+	JobID          string           `validate:"omitempty,uuid4"                                                               json:"job_id"`
+}
+
+type GetRunRequest struct {
+	IntersectionID string `validate:"required,uuid4" json:"intersection_id"`
+	ID             string `validate:"required,uuid4" json:"id"`
+}
+
+type GetRunsRequest struct {
+	IntersectionID string `validate:"required,uuid4" json:"intersection_id"`
+	Page           int    `validate:"min=1"          json:"page"`
+	PageSize       int    `validate:"min=1,max=100"  json:"page_size"`
+}
+
+type CreateSweepRequest struct {
+	IntersectionID string             `validate:"required,uuid4"      json:"intersection_id"`
+	UserID         string             `validate:"required"            json:"user_id"`
+	Ranges         []model.SweepRange `validate:"required,min=1,dive" json:"ranges"`
+	TotalPoints    int                `validate:"min=1"               json:"total_points"`
+}
+
+type GetSweepRequest struct {
+	IntersectionID string `validate:"required,uuid4" json:"intersection_id"`
+	ID             string `validate:"required,uuid4" json:"id"`
+}
+
+type GetSweepsRequest struct {
+	IntersectionID string `validate:"omitempty,uuid4" json:"intersection_id"`
+}
+
+type PutSweepPointRequest struct {
+	ID       string        `validate:"required,uuid4" json:"id"`
+	Index    int           `validate:"gte=0"          json:"index"`
+	Duration time.Duration `validate:"gte=0"          json:"duration"`
+	Error    string        `validate:"max=1024"       json:"error"`
+}
+
+type UpdateSweepRequest struct {
+	ID     string            `validate:"required,uuid4"                                                                  json:"id"`
+	Status model.SweepStatus `validate:"required,oneof=SWEEP_STATUS_RUNNING SWEEP_STATUS_SUCCEEDED SWEEP_STATUS_FAILED" json:"status"`
+	Error  string            `validate:"max=1024"                                                                        json:"error"`
+}
+
+type GetParameterVersionsRequest struct {
+	IntersectionID string `validate:"required,uuid4" json:"intersection_id"`
+	Page           int    `validate:"min=1"          json:"page"`
+	PageSize       int    `validate:"min=1,max=100"  json:"page_size"`
+}
+
+type RevertParameterVersionRequest struct {
+	IntersectionID string `validate:"required,uuid4" json:"intersection_id"`
+	Version        int    `validate:"min=1"          json:"version"`
+	UserID         string `validate:"required"       json:"user_id"`
+	// ExpectedVersion makes the revert conditional on the intersection's version, unless 0
+	ExpectedVersion int `validate:"min=0" json:"expected_version"`
 }
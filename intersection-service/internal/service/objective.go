@@ -0,0 +1,69 @@
+package service
+
+import (
+	"fmt"
+
+	"github.com/COS301-SE-2025/Swift-Signals/intersection-service/internal/model"
+)
+
+// Objective is the simulation metric that optimised parameters are judged on.
+// Lower scores are better for every objective.
+type Objective string
+
+const (
+	ObjectiveWaitingTime Objective = "waiting_time"
+	ObjectiveTravelTime  Objective = "travel_time"
+	ObjectiveSafety      Objective = "safety"
+)
+
+// Weights of the events counted towards the safety score, so that one near collision
+// counts as much as five emergency brakes
+const (
+	emergencyBrakeWeight = 1.0
+	emergencyStopWeight  = 2.0
+	nearCollisionWeight  = 5.0
+)
+
+// ParseObjective returns the objective with the given name, defaulting to average
+// waiting time when name is empty
+func ParseObjective(name string) (Objective, error) {
+	switch objective := Objective(name); objective {
+	case "":
+		return ObjectiveWaitingTime, nil
+	case ObjectiveWaitingTime, ObjectiveTravelTime, ObjectiveSafety:
+		return objective, nil
+	default:
+		return "", fmt.Errorf(
+			"unknown objective %q, expected one of %s, %s or %s",
+			name, ObjectiveWaitingTime, ObjectiveTravelTime, ObjectiveSafety,
+		)
+	}
+}
+
+// Score reduces the simulation results to the single value this objective minimises
+func (o Objective) Score(results model.SimulationResults) float64 {
+	switch o {
+	case ObjectiveTravelTime:
+		return results.AverageTravelTime
+	case ObjectiveSafety:
+		score := emergencyBrakeWeight*float64(results.EmergencyBrakes) +
+			emergencyStopWeight*float64(results.EmergencyStops) +
+			nearCollisionWeight*float64(results.NearCollisions)
+		// NOTE: Scored per vehicle so that runs with different traffic stay comparable
+		if results.TotalVehicles > 0 {
+			score /= float64(results.TotalVehicles)
+		}
+		return score
+	default:
+		return results.AverageWaitingTime
+	}
+}
+
+// Improves reports whether the candidate results beat the best results so far.
+// Any candidate improves on an intersection without best results.
+func (o Objective) Improves(candidate model.SimulationResults, best *model.SimulationResults) bool {
+	if best == nil {
+		return true
+	}
+	return o.Score(candidate) < o.Score(*best)
+}
@@ -0,0 +1,278 @@
+package service
+
+import (
+	"context"
+	"errors"
+	"strings"
+	"time"
+
+	"github.com/COS301-SE-2025/Swift-Signals/intersection-service/internal/model"
+	"github.com/COS301-SE-2025/Swift-Signals/intersection-service/internal/util"
+	errs "github.com/COS301-SE-2025/Swift-Signals/shared/error"
+	"github.com/google/uuid"
+)
+
+func (s *Service) CreateOptimisationJob(
+	ctx context.Context,
+	intersectionID string,
+	userID string,
+	previousStatus model.IntersectionStatus,
+) (*model.OptimisationJob, error) {
+	logger := util.LoggerFromContext(ctx)
+
+	logger.Debug("validating input")
+	req := CreateOptimisationJobRequest{
+		IntersectionID: strings.TrimSpace(intersectionID),
+		UserID:         strings.TrimSpace(userID),
+	}
+	if err := s.validator.Struct(req); err != nil {
+		return nil, handleValidationError(err)
+	}
+
+	logger.Debug("checking that intersection exists")
+	_, err := s.repo.GetIntersectionByID(ctx, req.IntersectionID)
+	if err != nil {
+		var svcErr *errs.ServiceError
+		if errors.As(err, &svcErr) {
+			return nil, err
+		}
+		return nil, errs.NewInternalError("failed to find intersection", err, map[string]any{})
+	}
+
+	// NOTE: Checking first names the active job, while the repository still refuses one
+	// that starts between the check and the insert
+	logger.Debug("checking for active optimisation jobs")
+	activeJobs, err := s.repo.GetOptimisationJobs(
+		ctx,
+		req.IntersectionID,
+		[]model.OptimisationJobStatus{model.JobPending, model.JobRunning},
+	)
+	if err != nil {
+		var svcErr *errs.ServiceError
+		if errors.As(err, &svcErr) {
+			return nil, err
+		}
+		return nil, errs.NewInternalError(
+			"failed to find active optimisation jobs",
+			err,
+			map[string]any{},
+		)
+	}
+	if len(activeJobs) > 0 {
+		return nil, errs.NewAlreadyExistsError(
+			"an optimisation job is already active for this intersection",
+			map[string]any{"intersection ID": req.IntersectionID, "job ID": activeJobs[0].ID},
+		)
+	}
+
+	logger.Debug("creating optimisation job")
+	job := &model.OptimisationJob{
+		ID:             uuid.New().String(),
+		IntersectionID: req.IntersectionID,
+		UserID:         req.UserID,
+		Status:         model.JobPending,
+		PreviousStatus: previousStatus,
+		CreatedAt:      time.Now(),
+	}
+
+	createdJob, err := s.repo.CreateOptimisationJob(ctx, job)
+	if err != nil {
+		var svcErr *errs.ServiceError
+		if errors.As(err, &svcErr) {
+			return nil, err
+		}
+		return nil, errs.NewInternalError(
+			"failed to create optimisation job",
+			err,
+			map[string]any{},
+		)
+	}
+
+	return createdJob, nil
+}
+
+func (s *Service) GetOptimisationJob(
+	ctx context.Context,
+	id string,
+) (*model.OptimisationJob, error) {
+	logger := util.LoggerFromContext(ctx)
+
+	logger.Debug("validating input")
+	req := GetOptimisationJobRequest{
+		ID: strings.TrimSpace(id),
+	}
+	if err := s.validator.Struct(req); err != nil {
+		return nil, handleValidationError(err)
+	}
+
+	logger.Debug("finding optimisation job")
+	job, err := s.repo.GetOptimisationJobByID(ctx, req.ID)
+	if err != nil {
+		var svcErr *errs.ServiceError
+		if errors.As(err, &svcErr) {
+			return nil, err
+		}
+		return nil, errs.NewInternalError("failed to find optimisation job", err, map[string]any{})
+	}
+	return job, nil
+}
+
+func (s *Service) GetOptimisationJobs(
+	ctx context.Context,
+	intersectionID string,
+	statuses []model.OptimisationJobStatus,
+) ([]*model.OptimisationJob, error) {
+	logger := util.LoggerFromContext(ctx)
+
+	logger.Debug("validating input")
+	req := GetOptimisationJobsRequest{
+		IntersectionID: strings.TrimSpace(intersectionID),
+	}
+	if err := s.validator.Struct(req); err != nil {
+		return nil, handleValidationError(err)
+	}
+
+	logger.Debug("finding optimisation jobs")
+	jobs, err := s.repo.GetOptimisationJobs(ctx, req.IntersectionID, statuses)
+	if err != nil {
+		var svcErr *errs.ServiceError
+		if errors.As(err, &svcErr) {
+			return nil, err
+		}
+		return nil, errs.NewInternalError(
+			"failed to find optimisation jobs",
+			err,
+			map[string]any{},
+		)
+	}
+	return jobs, nil
+}
+
+func (s *Service) UpdateOptimisationJob(
+	ctx context.Context,
+	id string,
+	status model.OptimisationJobStatus,
+	improved bool,
+	errMsg string,
+) (*model.OptimisationJob, error) {
+	logger := util.LoggerFromContext(ctx)
+
+	logger.Debug("validating input")
+	req := UpdateOptimisationJobRequest{
+		ID:     strings.TrimSpace(id),
+		Status: status,
+		Error:  strings.TrimSpace(errMsg),
+	}
+	if err := s.validator.Struct(req); err != nil {
+		return nil, handleValidationError(err)
+	}
+
+	logger.Debug("finding optimisation job")
+	job, err := s.repo.GetOptimisationJobByID(ctx, req.ID)
+	if err != nil {
+		var svcErr *errs.ServiceError
+		if errors.As(err, &svcErr) {
+			return nil, err
+		}
+		return nil, errs.NewInternalError("failed to find optimisation job", err, map[string]any{})
+	}
+
+	if job.Status.IsTerminal() {
+		return nil, errs.NewConflictError(
+			"optimisation job has already finished",
+			map[string]any{"job ID": job.ID, "status": job.Status},
+		)
+	}
+
+	now := time.Now()
+	job.Status = req.Status
+	job.Improved = improved
+	job.Error = req.Error
+	if req.Status == model.JobRunning {
+		job.StartedAt = now
+	}
+	if req.Status.IsTerminal() {
+		job.FinishedAt = now
+	}
+
+	logger.Debug("updating optimisation job")
+	updatedJob, err := s.repo.UpdateOptimisationJob(ctx, job)
+	if err != nil {
+		var svcErr *errs.ServiceError
+		if errors.As(err, &svcErr) {
+			return nil, err
+		}
+		return nil, errs.NewInternalError(
+			"failed to update optimisation job",
+			err,
+			map[string]any{},
+		)
+	}
+	return updatedJob, nil
+}
+
+// RenewOptimisationLease keeps a job's intersection from being failed as a stale
+// optimisation for another lease. It fails with a conflict once the job has finished, which
+// tells its worker to stop.
+func (s *Service) RenewOptimisationLease(
+	ctx context.Context,
+	id string,
+) (*model.OptimisationJob, error) {
+	logger := util.LoggerFromContext(ctx)
+
+	logger.Debug("validating input")
+	req := RenewOptimisationLeaseRequest{
+		ID: strings.TrimSpace(id),
+	}
+	if err := s.validator.Struct(req); err != nil {
+		return nil, handleValidationError(err)
+	}
+
+	logger.Debug("renewing optimisation lease")
+	job, err := s.repo.RenewOptimisationLease(ctx, req.ID, time.Now())
+	if err != nil {
+		var svcErr *errs.ServiceError
+		if errors.As(err, &svcErr) {
+			return nil, err
+		}
+		return nil, errs.NewInternalError(
+			"failed to renew optimisation lease",
+			err,
+			map[string]any{},
+		)
+	}
+	return job, nil
+}
+
+// checkActiveOptimisationJob fails unless the job is optimising the intersection and has
+// not yet finished
+func (s *Service) checkActiveOptimisationJob(
+	ctx context.Context,
+	jobID, intersectionID string,
+) error {
+	logger := util.LoggerFromContext(ctx)
+
+	logger.Debug("checking that optimisation job is active")
+	job, err := s.repo.GetOptimisationJobByID(ctx, jobID)
+	if err != nil {
+		var svcErr *errs.ServiceError
+		if errors.As(err, &svcErr) {
+			return err
+		}
+		return errs.NewInternalError("failed to find optimisation job", err, map[string]any{})
+	}
+
+	if job.IntersectionID != intersectionID {
+		return errs.NewValidationError(
+			"optimisation job belongs to another intersection",
+			map[string]any{"job ID": job.ID, "intersection ID": intersectionID},
+		)
+	}
+	if job.Status.IsTerminal() {
+		return errs.NewConflictError(
+			"optimisation job has already finished",
+			map[string]any{"job ID": job.ID, "status": job.Status},
+		)
+	}
+	return nil
+}
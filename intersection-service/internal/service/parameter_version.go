@@ -0,0 +1,170 @@
+package service
+
+import (
+	"context"
+	"errors"
+	"strings"
+
+	"github.com/COS301-SE-2025/Swift-Signals/intersection-service/internal/model"
+	"github.com/COS301-SE-2025/Swift-Signals/intersection-service/internal/util"
+	errs "github.com/COS301-SE-2025/Swift-Signals/shared/error"
+)
+
+// GetParameterVersions returns a page of the parameter versions of an intersection,
+// newest first
+func (s *Service) GetParameterVersions(
+	ctx context.Context,
+	intersectionID string,
+	page, pageSize int,
+) ([]*model.ParameterVersion, error) {
+	logger := util.LoggerFromContext(ctx)
+
+	logger.Debug("validating input")
+	req := GetParameterVersionsRequest{
+		IntersectionID: strings.TrimSpace(intersectionID),
+		Page:           page,
+		PageSize:       pageSize,
+	}
+	if err := s.validator.Struct(req); err != nil {
+		return nil, handleValidationError(err)
+	}
+
+	logger.Debug("checking that intersection exists")
+	_, err := s.repo.GetIntersectionByID(ctx, req.IntersectionID)
+	if err != nil {
+		var svcErr *errs.ServiceError
+		if errors.As(err, &svcErr) {
+			return nil, err
+		}
+		return nil, errs.NewInternalError("failed to find intersection", err, map[string]any{})
+	}
+
+	logger.Debug("finding parameter versions")
+	offset := (page - 1) * pageSize
+	versions, err := s.repo.GetParameterVersions(ctx, req.IntersectionID, pageSize, offset)
+	if err != nil {
+		var svcErr *errs.ServiceError
+		if errors.As(err, &svcErr) {
+			return nil, err
+		}
+		return nil, errs.NewInternalError(
+			"failed to find parameter versions",
+			err,
+			map[string]any{},
+		)
+	}
+	return versions, nil
+}
+
+// RevertParameterVersion restores the parameters (and metrics) that the given version set,
+// recording the revert as a new version. Fields that already hold them are left out, and
+// reverting to the current state changes nothing. An expected version other than 0 makes
+// the revert conditional on the intersection still being at it.
+func (s *Service) RevertParameterVersion(
+	ctx context.Context,
+	intersectionID string,
+	version int,
+	userID string,
+	expectedVersion int,
+) (*model.Intersection, error) {
+	logger := util.LoggerFromContext(ctx)
+
+	logger.Debug("validating input")
+	req := RevertParameterVersionRequest{
+		IntersectionID:  strings.TrimSpace(intersectionID),
+		Version:         version,
+		UserID:          strings.TrimSpace(userID),
+		ExpectedVersion: expectedVersion,
+	}
+	if err := s.validator.Struct(req); err != nil {
+		return nil, handleValidationError(err)
+	}
+
+	logger.Debug("finding parameter version")
+	target, err := s.repo.GetParameterVersion(ctx, req.IntersectionID, req.Version)
+	if err != nil {
+		var svcErr *errs.ServiceError
+		if errors.As(err, &svcErr) {
+			return nil, err
+		}
+		return nil, errs.NewInternalError(
+			"failed to find parameter version",
+			err,
+			map[string]any{},
+		)
+	}
+
+	logger.Debug("finding current parameters")
+	intersection, err := s.repo.GetIntersectionByID(ctx, req.IntersectionID)
+	if err != nil {
+		var svcErr *errs.ServiceError
+		if errors.As(err, &svcErr) {
+			return nil, err
+		}
+		return nil, errs.NewInternalError("failed to find intersection", err, map[string]any{})
+	}
+	if req.ExpectedVersion > 0 && intersection.Version != req.ExpectedVersion {
+		return nil, errs.NewPreconditionError(
+			"intersection has been modified since it was read",
+			map[string]any{
+				"intersection ID":  req.IntersectionID,
+				"expected version": req.ExpectedVersion,
+				"version":          intersection.Version,
+			},
+		)
+	}
+
+	var changes []model.ParameterChange
+	for _, change := range target.Changes {
+		old := parametersOf(intersection, change.Field)
+		if old == nil || *old == change.NewParameters {
+			continue
+		}
+		changes = append(changes, model.ParameterChange{
+			Field:         change.Field,
+			OldParameters: old,
+			NewParameters: change.NewParameters,
+			Metrics:       change.Metrics,
+		})
+	}
+	if len(changes) == 0 {
+		logger.Debug("parameters already match version", "version", req.Version)
+		return intersection, nil
+	}
+
+	logger.Debug("reverting parameters", "version", req.Version)
+	reverted, err := s.repo.RevertParameters(ctx, req.IntersectionID, &model.ParameterVersion{
+		IntersectionID:  req.IntersectionID,
+		UserID:          req.UserID,
+		Reason:          model.ParametersReverted,
+		RevertedVersion: req.Version,
+		Changes:         changes,
+	}, intersection.Version)
+	if err != nil {
+		var svcErr *errs.ServiceError
+		if errors.As(err, &svcErr) {
+			return nil, err
+		}
+		return nil, errs.NewInternalError("failed to revert parameters", err, map[string]any{})
+	}
+	return reverted, nil
+}
+
+// parametersOf returns a copy of the given parameters of an intersection
+func parametersOf(
+	intersection *model.Intersection,
+	field model.ParameterField,
+) *model.OptimisationParameters {
+	var params model.OptimisationParameters
+	switch field {
+	case model.ParameterFieldDefault:
+		params = intersection.DefaultParameters
+	case model.ParameterFieldCurrent:
+		params = intersection.CurrentParameters
+	case model.ParameterFieldBest:
+		params = intersection.BestParameters
+	default:
+		return nil
+	}
+	return &params
+}
@@ -0,0 +1,87 @@
+package service
+
+import (
+	"context"
+	"errors"
+	"time"
+
+	"github.com/COS301-SE-2025/Swift-Signals/intersection-service/internal/util"
+	errs "github.com/COS301-SE-2025/Swift-Signals/shared/error"
+)
+
+const staleOptimisationReason = "optimisation did not finish before its lease expired"
+
+// ReconcileStaleOptimisations fails every optimisation job and every intersection that has
+// gone without its lease being renewed for longer than the lease, on the assumption that
+// whoever was optimising it has gone away
+func (s *Service) ReconcileStaleOptimisations(
+	ctx context.Context,
+	lease time.Duration,
+) ([]string, error) {
+	logger := util.LoggerFromContext(ctx)
+
+	logger.Debug("validating input")
+	req := ReconcileStaleOptimisationsRequest{
+		Lease: lease,
+	}
+	if err := s.validator.Struct(req); err != nil {
+		return nil, handleValidationError(err)
+	}
+
+	logger.Debug("failing stale optimisations")
+	ids, err := s.repo.FailStaleOptimisations(
+		ctx,
+		time.Now().Add(-req.Lease),
+		staleOptimisationReason,
+	)
+	if err != nil {
+		var svcErr *errs.ServiceError
+		if errors.As(err, &svcErr) {
+			return nil, err
+		}
+		return nil, errs.NewInternalError(
+			"failed to reconcile stale optimisations",
+			err,
+			map[string]any{},
+		)
+	}
+	return ids, nil
+}
+
+// RunOptimisationReconciler reconciles stale optimisations once straight away and then
+// on every interval until ctx is cancelled
+func RunOptimisationReconciler(
+	ctx context.Context,
+	svc IntersectionService,
+	lease, interval time.Duration,
+) {
+	logger := util.LoggerFromContext(ctx).With("component", "optimisation-reconciler")
+
+	reconcile := func() {
+		ids, err := svc.ReconcileStaleOptimisations(ctx, lease)
+		if err != nil {
+			logger.Error("failed to reconcile stale optimisations",
+				"error", err.Error(),
+			)
+			return
+		}
+		if len(ids) > 0 {
+			logger.Warn("failed stale optimisations",
+				"intersection_ids", ids,
+			)
+		}
+	}
+
+	reconcile()
+
+	ticker := time.NewTicker(interval)
+	defer ticker.Stop()
+	for {
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+			reconcile()
+		}
+	}
+}
@@ -0,0 +1,132 @@
+package service
+
+import (
+	"context"
+	"errors"
+	"strings"
+	"time"
+
+	"github.com/COS301-SE-2025/Swift-Signals/intersection-service/internal/model"
+	"github.com/COS301-SE-2025/Swift-Signals/intersection-service/internal/util"
+	errs "github.com/COS301-SE-2025/Swift-Signals/shared/error"
+	"github.com/google/uuid"
+)
+
+// CreateRun records a finished simulation or optimisation run of an intersection.
+// The ID and creation time of the given run are assigned here.
+func (s *Service) CreateRun(ctx context.Context, run *model.Run) (*model.Run, error) {
+	logger := util.LoggerFromContext(ctx)
+
+	logger.Debug("validating input")
+	if run == nil {
+		return nil, errs.NewValidationError("run is required", map[string]any{})
+	}
+	run.IntersectionID = strings.TrimSpace(run.IntersectionID)
+	run.UserID = strings.TrimSpace(run.UserID)
+	req := CreateRunRequest{
+		IntersectionID: run.IntersectionID,
+		UserID:         run.UserID,
+		Type:           run.Type,
+		Outcome:        run.Outcome,
+		Duration:       run.Duration,
+		Error:          run.Error,
+		JobID:          strings.TrimSpace(run.JobID),
+	}
+	if err := s.validator.Struct(req); err != nil {
+		return nil, handleValidationError(err)
+	}
+
+	logger.Debug("checking that intersection exists")
+	_, err := s.repo.GetIntersectionByID(ctx, run.IntersectionID)
+	if err != nil {
+		var svcErr *errs.ServiceError
+		if errors.As(err, &svcErr) {
+			return nil, err
+		}
+		return nil, errs.NewInternalError("failed to find intersection", err, map[string]any{})
+	}
+
+	logger.Debug("creating run")
+	run.ID = uuid.New().String()
+	run.JobID = req.JobID
+	run.CreatedAt = time.Now()
+
+	createdRun, err := s.repo.CreateRun(ctx, run)
+	if err != nil {
+		var svcErr *errs.ServiceError
+		if errors.As(err, &svcErr) {
+			return nil, err
+		}
+		return nil, errs.NewInternalError("failed to create run", err, map[string]any{})
+	}
+
+	return createdRun, nil
+}
+
+func (s *Service) GetRun(
+	ctx context.Context,
+	intersectionID, id string,
+) (*model.Run, error) {
+	logger := util.LoggerFromContext(ctx)
+
+	logger.Debug("validating input")
+	req := GetRunRequest{
+		IntersectionID: strings.TrimSpace(intersectionID),
+		ID:             strings.TrimSpace(id),
+	}
+	if err := s.validator.Struct(req); err != nil {
+		return nil, handleValidationError(err)
+	}
+
+	logger.Debug("finding run")
+	run, err := s.repo.GetRunByID(ctx, req.IntersectionID, req.ID)
+	if err != nil {
+		var svcErr *errs.ServiceError
+		if errors.As(err, &svcErr) {
+			return nil, err
+		}
+		return nil, errs.NewInternalError("failed to find run", err, map[string]any{})
+	}
+	return run, nil
+}
+
+// GetRuns returns a page of the runs of an intersection, newest first
+func (s *Service) GetRuns(
+	ctx context.Context,
+	intersectionID string,
+	page, pageSize int,
+) ([]*model.Run, error) {
+	logger := util.LoggerFromContext(ctx)
+
+	logger.Debug("validating input")
+	req := GetRunsRequest{
+		IntersectionID: strings.TrimSpace(intersectionID),
+		Page:           page,
+		PageSize:       pageSize,
+	}
+	if err := s.validator.Struct(req); err != nil {
+		return nil, handleValidationError(err)
+	}
+
+	logger.Debug("checking that intersection exists")
+	_, err := s.repo.GetIntersectionByID(ctx, req.IntersectionID)
+	if err != nil {
+		var svcErr *errs.ServiceError
+		if errors.As(err, &svcErr) {
+			return nil, err
+		}
+		return nil, errs.NewInternalError("failed to find intersection", err, map[string]any{})
+	}
+
+	logger.Debug("finding runs")
+	offset := (page - 1) * pageSize
+	runs, err := s.repo.GetRuns(ctx, req.IntersectionID, pageSize, offset)
+	if err != nil {
+		var svcErr *errs.ServiceError
+		if errors.As(err, &svcErr) {
+			return nil, err
+		}
+		return nil, errs.NewInternalError("failed to find runs", err, map[string]any{})
+	}
+	return runs, nil
+}
@@ -17,12 +17,14 @@ import (
 type Service struct {
 	repo      db.IntersectionRepository
 	validator *validator.Validate
+	objective Objective
 }
 
-func NewIntersectionService(r db.IntersectionRepository) IntersectionService {
+func NewIntersectionService(r db.IntersectionRepository, objective Objective) IntersectionService {
 	return &Service{
 		repo:      r,
 		validator: validator.New(),
+		objective: objective,
 	}
 }
 
@@ -32,6 +34,7 @@ func (s *Service) CreateIntersection(
 	details model.IntersectionDetails,
 	density model.TrafficDensity,
 	defaultParams model.OptimisationParameters,
+	userID string,
 ) (*model.Intersection, error) {
 	logger := util.LoggerFromContext(ctx)
 
@@ -65,9 +68,19 @@ func (s *Service) CreateIntersection(
 		DefaultParameters: defaultParams,
 		BestParameters:    defaultParams,
 		CurrentParameters: defaultParams,
+		Version:           1,
 	}
 
-	createdIntersection, err := s.repo.CreateIntersection(ctx, intersection)
+	createdIntersection, err := s.repo.CreateIntersection(ctx, intersection, &model.ParameterVersion{
+		IntersectionID: id,
+		UserID:         strings.TrimSpace(userID),
+		Reason:         model.ParametersCreated,
+		Changes: []model.ParameterChange{
+			{Field: model.ParameterFieldDefault, NewParameters: defaultParams},
+			{Field: model.ParameterFieldCurrent, NewParameters: defaultParams},
+			{Field: model.ParameterFieldBest, NewParameters: defaultParams},
+		},
+	})
 	if err != nil {
 		var svcErr *errs.ServiceError
 		if errors.As(err, &svcErr) {
@@ -102,10 +115,13 @@ func (s *Service) GetIntersection(ctx context.Context, id string) (*model.Inters
 	return intersection, nil
 }
 
+// GetAllIntersections returns a page of the intersections matching the filter, from the
+// trash if deleted is set
 func (s *Service) GetAllIntersections(
 	ctx context.Context,
 	page, pageSize int,
 	filter string,
+	deleted bool,
 ) ([]*model.Intersection, error) {
 	logger := util.LoggerFromContext(ctx)
 
@@ -122,7 +138,7 @@ func (s *Service) GetAllIntersections(
 	logger.Debug("finding all intersections")
 	offset := (page - 1) * pageSize
 	limit := pageSize
-	intersections, err := s.repo.GetAllIntersections(ctx, limit, offset, filter)
+	intersections, err := s.repo.GetAllIntersections(ctx, limit, offset, filter, deleted)
 	if err != nil {
 		var svcErr *errs.ServiceError
 		if errors.As(err, &svcErr) {
@@ -133,27 +149,125 @@ func (s *Service) GetAllIntersections(
 	return intersections, nil
 }
 
+// GetIntersectionsAfter returns a page of the intersections, in the trash or not, whose ID
+// sorts after afterID. Unlike a page number, the position cannot shift as intersections are
+// trashed, restored or purged between pages.
+func (s *Service) GetIntersectionsAfter(
+	ctx context.Context,
+	afterID string,
+	pageSize int,
+) ([]*model.Intersection, error) {
+	logger := util.LoggerFromContext(ctx)
+
+	logger.Debug("validating input")
+	req := GetIntersectionsAfterRequest{
+		AfterID:  strings.TrimSpace(afterID),
+		PageSize: pageSize,
+	}
+	if err := s.validator.Struct(req); err != nil {
+		return nil, handleValidationError(err)
+	}
+
+	logger.Debug("finding intersections after ID", "afterID", req.AfterID, "pageSize", pageSize)
+	intersections, err := s.repo.ListIntersectionsAfter(ctx, req.AfterID, req.PageSize)
+	if err != nil {
+		var svcErr *errs.ServiceError
+		if errors.As(err, &svcErr) {
+			return nil, err
+		}
+		return nil, errs.NewInternalError(
+			"failed to find intersections",
+			err,
+			map[string]any{"afterID": req.AfterID, "pageSize": pageSize},
+		)
+	}
+	return intersections, nil
+}
+
 func (s *Service) UpdateIntersection(
 	ctx context.Context,
 	id string,
 	name string,
 	details model.IntersectionDetails,
 	status model.IntersectionStatus,
+	failureReason string,
+	density model.TrafficDensity,
+	defaultParams *model.OptimisationParameters,
+	userID string,
+	expectedVersion int,
 ) (*model.Intersection, error) {
 	logger := util.LoggerFromContext(ctx)
 
 	logger.Debug("validating input")
 	req := UpdateIntersectionRequest{
-		ID:      strings.TrimSpace(id),
-		Name:    strings.TrimSpace(name),
-		Details: details,
+		ID:              strings.TrimSpace(id),
+		Name:            strings.TrimSpace(name),
+		Details:         details,
+		FailureReason:   strings.TrimSpace(failureReason),
+		Density:         density,
+		ExpectedVersion: expectedVersion,
+	}
+	if defaultParams != nil {
+		req.DefaultParams = &DefaultParametersRequest{
+			OptimisationType: defaultParams.OptimisationType,
+			IntersectionType: defaultParams.Parameters.IntersectionType,
+			Green:            defaultParams.Parameters.Green,
+			Yellow:           defaultParams.Parameters.Yellow,
+			Red:              defaultParams.Parameters.Red,
+			Speed:            defaultParams.Parameters.Speed,
+		}
 	}
 	if err := s.validator.Struct(req); err != nil {
 		return nil, handleValidationError(err)
 	}
 
+	var version *model.ParameterVersion
+	if density != "" || defaultParams != nil {
+		var current *model.Intersection
+		var err error
+		density, defaultParams, current, err = s.changedDefaults(
+			ctx,
+			req.ID,
+			status,
+			density,
+			defaultParams,
+		)
+		if err != nil {
+			return nil, err
+		}
+
+		if defaultParams != nil {
+			version = &model.ParameterVersion{
+				IntersectionID: req.ID,
+				UserID:         strings.TrimSpace(userID),
+				Reason:         model.ParametersUpdated,
+				Changes: []model.ParameterChange{{
+					Field:         model.ParameterFieldDefault,
+					OldParameters: &current.DefaultParameters,
+					NewParameters: *defaultParams,
+				}},
+			}
+			// NOTE: The version records the defaults that were read, so the update only goes
+			// ahead while they are still there
+			if req.ExpectedVersion == 0 {
+				req.ExpectedVersion = current.Version
+			}
+		}
+	}
+
 	logger.Debug("updating intersection")
-	intersection, err := s.repo.UpdateIntersection(ctx, id, name, details, status)
+	intersection, err := s.repo.UpdateIntersection(
+		ctx,
+		id,
+		name,
+		details,
+		status,
+		req.FailureReason,
+		density,
+		defaultParams,
+		version,
+		req.ExpectedVersion,
+	)
 	if err != nil {
 		var svcErr *errs.ServiceError
 		if errors.As(err, &svcErr) {
@@ -164,19 +278,107 @@ func (s *Service) UpdateIntersection(
 	return intersection, nil
 }
 
-func (s *Service) DeleteIntersection(ctx context.Context, id string) error {
+// UpdateIntersectionStatus changes only the status of an intersection, leaving its name,
+// details and defaults as they are now rather than as its caller last read them
+func (s *Service) UpdateIntersectionStatus(
+	ctx context.Context,
+	id string,
+	status model.IntersectionStatus,
+	failureReason string,
+) (*model.Intersection, error) {
+	logger := util.LoggerFromContext(ctx)
+
+	logger.Debug("validating input")
+	req := UpdateIntersectionStatusRequest{
+		ID:            strings.TrimSpace(id),
+		Status:        status,
+		FailureReason: strings.TrimSpace(failureReason),
+	}
+	if err := s.validator.Struct(req); err != nil {
+		return nil, handleValidationError(err)
+	}
+
+	logger.Debug("updating intersection status")
+	intersection, err := s.repo.UpdateIntersectionStatus(
+		ctx,
+		req.ID,
+		req.Status,
+		req.FailureReason,
+	)
+	if err != nil {
+		var svcErr *errs.ServiceError
+		if errors.As(err, &svcErr) {
+			return nil, err
+		}
+		return nil, errs.NewInternalError(
+			"failed to update intersection status",
+			err,
+			map[string]any{},
+		)
+	}
+	return intersection, nil
+}
+
+// changedDefaults drops the traffic density and default parameters that match the
+// intersection's current ones, so that only real changes flag its best parameters as stale.
+// The intersection they were compared with is returned alongside. Defaults cannot change
+// while the intersection is being optimised.
+func (s *Service) changedDefaults(
+	ctx context.Context,
+	id string,
+	status model.IntersectionStatus,
+	density model.TrafficDensity,
+	defaultParams *model.OptimisationParameters,
+) (model.TrafficDensity, *model.OptimisationParameters, *model.Intersection, error) {
+	logger := util.LoggerFromContext(ctx)
+
+	logger.Debug("finding current defaults")
+	intersection, err := s.repo.GetIntersectionByID(ctx, id)
+	if err != nil {
+		var svcErr *errs.ServiceError
+		if errors.As(err, &svcErr) {
+			return "", nil, nil, err
+		}
+		return "", nil, nil, errs.NewInternalError(
+			"failed to find intersection",
+			err,
+			map[string]any{},
+		)
+	}
+	if density == intersection.TrafficDensity {
+		density = ""
+	}
+	if defaultParams != nil && *defaultParams == intersection.DefaultParameters {
+		defaultParams = nil
+	}
+	if density == "" && defaultParams == nil {
+		return "", nil, intersection, nil
+	}
+
+	if intersection.Status == model.Optimising || status == model.Optimising {
+		return "", nil, nil, errs.NewConflictError(
+			"defaults cannot change while the intersection is being optimised",
+			map[string]any{"intersection ID": id},
+		)
+	}
+	return density, defaultParams, intersection, nil
+}
+
+// DeleteIntersection moves an intersection to the trash
+func (s *Service) DeleteIntersection(ctx context.Context, id string, expectedVersion int) error {
 	logger := util.LoggerFromContext(ctx)
 
 	logger.Debug("validating input")
 	req := DeleteIntersectionRequest{
-		ID: strings.TrimSpace(id),
+		ID:              strings.TrimSpace(id),
+		ExpectedVersion: expectedVersion,
 	}
 	if err := s.validator.Struct(req); err != nil {
 		return handleValidationError(err)
 	}
 
 	logger.Debug("deleting intersection")
-	err := s.repo.DeleteIntersection(ctx, id)
+	err := s.repo.DeleteIntersection(ctx, id, req.ExpectedVersion)
 	if err != nil {
 		var svcErr *errs.ServiceError
 		if errors.As(err, &svcErr) {
@@ -191,20 +393,36 @@ func (s *Service) PutOptimisation(
 	ctx context.Context,
 	id string,
 	params model.OptimisationParameters,
+	metrics *model.SimulationResults,
+	userID string,
+	jobID string,
 ) (bool, error) {
 	logger := util.LoggerFromContext(ctx)
 
 	logger.Debug("validating input")
 	req := PutOptimisationRequest{
-		ID:     strings.TrimSpace(id),
-		Params: params,
+		ID:      strings.TrimSpace(id),
+		Params:  params,
+		Metrics: metrics,
+		JobID:   strings.TrimSpace(jobID),
 	}
 	if err := s.validator.Struct(req); err != nil {
 		return false, handleValidationError(err)
 	}
 
-	logger.Debug("finding existing current params")
-	_, err := s.repo.GetIntersectionByID(ctx, id)
+	// NOTE: Parameters found by a job are only kept while the job is active and its
+	// intersection still optimising, so a worker that outlived its lease cannot overwrite
+	// the failure the reconciler recorded
+	var expectedStatus model.IntersectionStatus
+	if req.JobID != "" {
+		if err := s.checkActiveOptimisationJob(ctx, req.JobID, req.ID); err != nil {
+			return false, err
+		}
+		expectedStatus = model.Optimising
+	}
+
+	logger.Debug("finding existing best params")
+	intersection, err := s.repo.GetIntersectionByID(ctx, id)
 	if err != nil {
 		var svcErr *errs.ServiceError
 		if errors.As(err, &svcErr) {
@@ -217,27 +435,42 @@ func (s *Service) PutOptimisation(
 		)
 	}
 
-	logger.Debug("evaluating whether current params are better then best params")
-	// TODO: Implement logic to decide whether current params are better than current
-	// NOTE: For now always updating best params to current's
-	better := true
+	logger.Debug("evaluating whether current params are better than best params",
+		"objective", s.objective,
+	)
+	// NOTE: Stale best parameters were found under other defaults, so anything replaces them
+	better := intersection.BestParametersStale ||
+		s.objective.Improves(*metrics, intersection.BestMetrics)
 
-	logger.Debug("updating best params")
-	err = s.repo.UpdateBestParams(ctx, id, params)
-	if err != nil {
-		var svcErr *errs.ServiceError
-		if errors.As(err, &svcErr) {
-			return false, err
+	var changes []model.ParameterChange
+	if params != intersection.CurrentParameters {
+		changes = append(changes, model.ParameterChange{
+			Field:         model.ParameterFieldCurrent,
+			OldParameters: &intersection.CurrentParameters,
+			NewParameters: params,
+			Metrics:       metrics,
+		})
+	}
+	if better && params != intersection.BestParameters {
+		changes = append(changes, model.ParameterChange{
+			Field:         model.ParameterFieldBest,
+			OldParameters: &intersection.BestParameters,
+			NewParameters: params,
+			Metrics:       metrics,
+		})
+	}
+	var version *model.ParameterVersion
+	if len(changes) > 0 {
+		version = &model.ParameterVersion{
+			IntersectionID: req.ID,
+			UserID:         strings.TrimSpace(userID),
+			Reason:         model.ParametersOptimised,
+			Changes:        changes,
 		}
-		return false, errs.NewInternalError(
-			"failed to update best params for intersection",
-			err,
-			map[string]any{},
-		)
 	}
 
-	logger.Debug("updating current params")
-	err = s.repo.UpdateCurrentParams(ctx, id, params)
+	logger.Debug("updating current params", "better", better)
+	err = s.repo.UpdateCurrentParams(ctx, id, params, metrics, better, expectedStatus, version)
 	if err != nil {
 		var svcErr *errs.ServiceError
 		if errors.As(err, &svcErr) {
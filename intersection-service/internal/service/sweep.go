@@ -0,0 +1,212 @@
+package service
+
+import (
+	"context"
+	"errors"
+	"strings"
+	"time"
+
+	"github.com/COS301-SE-2025/Swift-Signals/intersection-service/internal/model"
+	"github.com/COS301-SE-2025/Swift-Signals/intersection-service/internal/util"
+	errs "github.com/COS301-SE-2025/Swift-Signals/shared/error"
+	"github.com/google/uuid"
+)
+
+// CreateSweep records a pending sweep of an intersection. The grid itself is expanded and
+// simulated by the caller, which adds every point through PutSweepPoint.
+func (s *Service) CreateSweep(ctx context.Context, sweep *model.Sweep) (*model.Sweep, error) {
+	logger := util.LoggerFromContext(ctx)
+
+	logger.Debug("validating input")
+	if sweep == nil {
+		return nil, errs.NewValidationError("sweep is required", map[string]any{})
+	}
+	sweep.IntersectionID = strings.TrimSpace(sweep.IntersectionID)
+	sweep.UserID = strings.TrimSpace(sweep.UserID)
+	req := CreateSweepRequest{
+		IntersectionID: sweep.IntersectionID,
+		UserID:         sweep.UserID,
+		Ranges:         sweep.Ranges,
+		TotalPoints:    sweep.TotalPoints,
+	}
+	if err := s.validator.Struct(req); err != nil {
+		return nil, handleValidationError(err)
+	}
+
+	logger.Debug("checking that intersection exists")
+	_, err := s.repo.GetIntersectionByID(ctx, sweep.IntersectionID)
+	if err != nil {
+		var svcErr *errs.ServiceError
+		if errors.As(err, &svcErr) {
+			return nil, err
+		}
+		return nil, errs.NewInternalError("failed to find intersection", err, map[string]any{})
+	}
+
+	logger.Debug("creating sweep")
+	sweep.ID = uuid.New().String()
+	sweep.Status = model.SweepPending
+	sweep.Points = []model.SweepPoint{}
+	sweep.CompletedPoints = 0
+	sweep.FailedPoints = 0
+	sweep.Error = ""
+	sweep.CreatedAt = time.Now()
+	sweep.StartedAt = time.Time{}
+	sweep.FinishedAt = time.Time{}
+
+	createdSweep, err := s.repo.CreateSweep(ctx, sweep)
+	if err != nil {
+		var svcErr *errs.ServiceError
+		if errors.As(err, &svcErr) {
+			return nil, err
+		}
+		return nil, errs.NewInternalError("failed to create sweep", err, map[string]any{})
+	}
+
+	return createdSweep, nil
+}
+
+func (s *Service) GetSweep(
+	ctx context.Context,
+	intersectionID, id string,
+) (*model.Sweep, error) {
+	logger := util.LoggerFromContext(ctx)
+
+	logger.Debug("validating input")
+	req := GetSweepRequest{
+		IntersectionID: strings.TrimSpace(intersectionID),
+		ID:             strings.TrimSpace(id),
+	}
+	if err := s.validator.Struct(req); err != nil {
+		return nil, handleValidationError(err)
+	}
+
+	logger.Debug("finding sweep")
+	sweep, err := s.repo.GetSweepByID(ctx, req.IntersectionID, req.ID)
+	if err != nil {
+		var svcErr *errs.ServiceError
+		if errors.As(err, &svcErr) {
+			return nil, err
+		}
+		return nil, errs.NewInternalError("failed to find sweep", err, map[string]any{})
+	}
+	return sweep, nil
+}
+
+// GetSweeps returns the sweeps of an intersection, or of every intersection when none is
+// given, newest first and without their points
+func (s *Service) GetSweeps(
+	ctx context.Context,
+	intersectionID string,
+	statuses []model.SweepStatus,
+) ([]*model.Sweep, error) {
+	logger := util.LoggerFromContext(ctx)
+
+	logger.Debug("validating input")
+	req := GetSweepsRequest{
+		IntersectionID: strings.TrimSpace(intersectionID),
+	}
+	if err := s.validator.Struct(req); err != nil {
+		return nil, handleValidationError(err)
+	}
+
+	logger.Debug("finding sweeps")
+	sweeps, err := s.repo.GetSweeps(ctx, req.IntersectionID, statuses)
+	if err != nil {
+		var svcErr *errs.ServiceError
+		if errors.As(err, &svcErr) {
+			return nil, err
+		}
+		return nil, errs.NewInternalError("failed to find sweeps", err, map[string]any{})
+	}
+	return sweeps, nil
+}
+
+// PutSweepPoint stores the outcome of one point of a running sweep
+func (s *Service) PutSweepPoint(ctx context.Context, id string, point model.SweepPoint) error {
+	logger := util.LoggerFromContext(ctx)
+
+	logger.Debug("validating input")
+	req := PutSweepPointRequest{
+		ID:       strings.TrimSpace(id),
+		Index:    point.Index,
+		Duration: point.Duration,
+		Error:    point.Error,
+	}
+	if err := s.validator.Struct(req); err != nil {
+		return handleValidationError(err)
+	}
+	if point.Metrics == nil && point.Error == "" {
+		return errs.NewValidationError(
+			"sweep point needs either metrics or an error",
+			map[string]any{"index": point.Index},
+		)
+	}
+
+	logger.Debug("adding sweep point")
+	if err := s.repo.AddSweepPoint(ctx, req.ID, point); err != nil {
+		var svcErr *errs.ServiceError
+		if errors.As(err, &svcErr) {
+			return err
+		}
+		return errs.NewInternalError("failed to add sweep point", err, map[string]any{})
+	}
+	return nil
+}
+
+func (s *Service) UpdateSweep(
+	ctx context.Context,
+	id string,
+	status model.SweepStatus,
+	errMsg string,
+) (*model.Sweep, error) {
+	logger := util.LoggerFromContext(ctx)
+
+	logger.Debug("validating input")
+	req := UpdateSweepRequest{
+		ID:     strings.TrimSpace(id),
+		Status: status,
+		Error:  strings.TrimSpace(errMsg),
+	}
+	if err := s.validator.Struct(req); err != nil {
+		return nil, handleValidationError(err)
+	}
+
+	logger.Debug("finding sweep")
+	sweep, err := s.repo.GetSweepByID(ctx, "", req.ID)
+	if err != nil {
+		var svcErr *errs.ServiceError
+		if errors.As(err, &svcErr) {
+			return nil, err
+		}
+		return nil, errs.NewInternalError("failed to find sweep", err, map[string]any{})
+	}
+
+	if sweep.Status.IsTerminal() {
+		return nil, errs.NewConflictError(
+			"sweep has already finished",
+			map[string]any{"sweep ID": sweep.ID, "status": sweep.Status},
+		)
+	}
+
+	now := time.Now()
+	sweep.Status = req.Status
+	sweep.Error = req.Error
+	if req.Status == model.SweepRunning {
+		sweep.StartedAt = now
+	}
+	if req.Status.IsTerminal() {
+		sweep.FinishedAt = now
+	}
+
+	logger.Debug("updating sweep")
+	updatedSweep, err := s.repo.UpdateSweep(ctx, sweep)
+	if err != nil {
+		var svcErr *errs.ServiceError
+		if errors.As(err, &svcErr) {
+			return nil, err
+		}
+		return nil, errs.NewInternalError("failed to update sweep", err, map[string]any{})
+	}
+	return updatedSweep, nil
+}
@@ -16,7 +16,7 @@ type TestSuite struct {
 
 func (suite *TestSuite) SetupTest() {
 	suite.repo = new(mocks.MockIntersectionRepository)
-	suite.service = service.NewIntersectionService(suite.repo)
+	suite.service = service.NewIntersectionService(suite.repo, service.ObjectiveWaitingTime)
 }
 
 func TestService(t *testing.T) {
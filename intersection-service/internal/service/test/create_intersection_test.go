@@ -1,5 +1,41 @@
 package test
 
+import (
+	"context"
+
+	"github.com/COS301-SE-2025/Swift-Signals/intersection-service/internal/model"
+	"github.com/stretchr/testify/mock"
+)
+
 func (suite *TestSuite) TestCreateIntersection_Success() {
-	// TODO: add test case implementation
+	ctx := context.Background()
+	defaults := createTestDefaults(10)
+
+	suite.repo.On("CreateIntersection", ctx,
+		mock.MatchedBy(func(i *model.Intersection) bool {
+			return i.ID != "" && i.BestParameters == defaults && i.CurrentParameters == defaults
+		}),
+		mock.MatchedBy(func(version *model.ParameterVersion) bool {
+			return version.Reason == model.ParametersCreated &&
+				version.UserID == testUserID &&
+				len(version.Changes) == 3
+		})).
+		Return(func(_ context.Context, i *model.Intersection, _ *model.ParameterVersion) *model.Intersection {
+			created := *i
+			created.ParameterVersion = 1
+			return &created
+		}, nil)
+
+	created, err := suite.service.CreateIntersection(
+		ctx,
+		"Test Intersection",
+		model.IntersectionDetails{City: "Pretoria"},
+		model.TrafficHigh,
+		defaults,
+		testUserID,
+	)
+
+	suite.Require().NoError(err)
+	suite.Equal(1, created.ParameterVersion)
+	suite.repo.AssertExpectations(suite.T())
 }
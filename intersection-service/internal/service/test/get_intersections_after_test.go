@@ -0,0 +1,76 @@
+package test
+
+import (
+	"context"
+	"errors"
+	"time"
+
+	"github.com/COS301-SE-2025/Swift-Signals/intersection-service/internal/model"
+	errs "github.com/COS301-SE-2025/Swift-Signals/shared/error"
+	"github.com/stretchr/testify/mock"
+)
+
+func (suite *TestSuite) TestGetIntersectionsAfter_Success() {
+	ctx := context.Background()
+	deletedAt := time.Now()
+	page := []*model.Intersection{
+		createTestIntersection(model.Optimised),
+		{ID: "660e8400-e29b-41d4-a716-446655440000", DeletedAt: &deletedAt},
+	}
+
+	suite.repo.On("ListIntersectionsAfter", ctx, testIntersectionID, 100).Return(page, nil)
+
+	got, err := suite.service.GetIntersectionsAfter(ctx, " "+testIntersectionID+" ", 100)
+
+	suite.Require().NoError(err)
+	suite.Equal(page, got)
+	suite.repo.AssertExpectations(suite.T())
+}
+
+func (suite *TestSuite) TestGetIntersectionsAfter_FirstPage() {
+	ctx := context.Background()
+
+	suite.repo.On("ListIntersectionsAfter", ctx, "", 100).Return([]*model.Intersection{}, nil)
+
+	got, err := suite.service.GetIntersectionsAfter(ctx, "", 100)
+
+	suite.Require().NoError(err)
+	suite.Empty(got)
+	suite.repo.AssertExpectations(suite.T())
+}
+
+func (suite *TestSuite) TestGetIntersectionsAfter_InvalidInput() {
+	ctx := context.Background()
+
+	for _, tc := range []struct {
+		afterID  string
+		pageSize int
+	}{
+		{"not-a-uuid", 100},
+		{testIntersectionID, 0},
+		{testIntersectionID, 101},
+	} {
+		_, err := suite.service.GetIntersectionsAfter(ctx, tc.afterID, tc.pageSize)
+
+		suite.Require().Error(err)
+		var svcErr *errs.ServiceError
+		suite.Require().True(errors.As(err, &svcErr))
+		suite.Equal(errs.ErrValidation, svcErr.Code)
+	}
+	suite.repo.AssertNotCalled(suite.T(), "ListIntersectionsAfter",
+		mock.Anything, mock.Anything, mock.Anything)
+}
+
+func (suite *TestSuite) TestGetIntersectionsAfter_RepositoryFailure() {
+	ctx := context.Background()
+
+	suite.repo.On("ListIntersectionsAfter", ctx, testIntersectionID, 100).
+		Return(nil, errors.New("database error"))
+
+	_, err := suite.service.GetIntersectionsAfter(ctx, testIntersectionID, 100)
+
+	suite.Require().Error(err)
+	var svcErr *errs.ServiceError
+	suite.Require().True(errors.As(err, &svcErr))
+	suite.Equal(errs.ErrInternal, svcErr.Code)
+}
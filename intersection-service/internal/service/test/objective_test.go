@@ -0,0 +1,43 @@
+package test
+
+import (
+	"github.com/COS301-SE-2025/Swift-Signals/intersection-service/internal/model"
+	"github.com/COS301-SE-2025/Swift-Signals/intersection-service/internal/service"
+)
+
+func (suite *TestSuite) TestParseObjective() {
+	objective, err := service.ParseObjective("")
+	suite.Require().NoError(err)
+	suite.Equal(service.ObjectiveWaitingTime, objective)
+
+	objective, err = service.ParseObjective("safety")
+	suite.Require().NoError(err)
+	suite.Equal(service.ObjectiveSafety, objective)
+
+	_, err = service.ParseObjective("throughput")
+	suite.Require().Error(err)
+}
+
+func (suite *TestSuite) TestObjective_Score() {
+	results := model.SimulationResults{
+		TotalVehicles:      10,
+		AverageTravelTime:  120,
+		AverageWaitingTime: 40,
+		EmergencyBrakes:    4,
+		EmergencyStops:     3,
+		NearCollisions:     2,
+	}
+
+	suite.Equal(40.0, service.ObjectiveWaitingTime.Score(results))
+	suite.Equal(120.0, service.ObjectiveTravelTime.Score(results))
+	suite.InDelta(2.0, service.ObjectiveSafety.Score(results), 1e-9)
+}
+
+func (suite *TestSuite) TestObjective_Improves() {
+	best := &model.SimulationResults{AverageTravelTime: 100, NearCollisions: 1, TotalVehicles: 10}
+	candidate := model.SimulationResults{AverageTravelTime: 90, NearCollisions: 2, TotalVehicles: 10}
+
+	suite.True(service.ObjectiveTravelTime.Improves(candidate, best))
+	suite.False(service.ObjectiveSafety.Improves(candidate, best))
+	suite.True(service.ObjectiveSafety.Improves(candidate, nil))
+}
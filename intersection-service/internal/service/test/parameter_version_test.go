@@ -0,0 +1,226 @@
+package test
+
+import (
+	"context"
+
+	"github.com/COS301-SE-2025/Swift-Signals/intersection-service/internal/model"
+	errs "github.com/COS301-SE-2025/Swift-Signals/shared/error"
+	"github.com/stretchr/testify/mock"
+)
+
+func (suite *TestSuite) TestGetParameterVersions_Pagination() {
+	ctx := context.Background()
+	versions := []*model.ParameterVersion{{Version: 4}, {Version: 3}}
+
+	suite.repo.On("GetIntersectionByID", ctx, testIntersectionID).
+		Return(&model.Intersection{ID: testIntersectionID}, nil)
+	suite.repo.On("GetParameterVersions", ctx, testIntersectionID, 2, 2).Return(versions, nil)
+
+	got, err := suite.service.GetParameterVersions(ctx, testIntersectionID, 2, 2)
+
+	suite.Require().NoError(err)
+	suite.Equal(versions, got)
+	suite.repo.AssertExpectations(suite.T())
+}
+
+func (suite *TestSuite) TestGetParameterVersions_InvalidPage() {
+	_, err := suite.service.GetParameterVersions(context.Background(), testIntersectionID, 0, 20)
+
+	suite.Require().Error(err)
+	svcErr, ok := err.(*errs.ServiceError)
+	suite.Require().True(ok)
+	suite.Equal(errs.ErrValidation, svcErr.Code)
+	suite.repo.AssertNotCalled(suite.T(), "GetParameterVersions",
+		mock.Anything, mock.Anything, mock.Anything, mock.Anything)
+}
+
+func (suite *TestSuite) TestRevertParameterVersion_Success() {
+	ctx := context.Background()
+	current := createTestIntersection(model.Optimised)
+	current.CurrentParameters = createTestDefaults(14)
+	oldMetrics := &model.SimulationResults{AverageWaitingTime: 50}
+
+	// NOTE: Version 2 changed the defaults and current parameters, the latter of which are
+	// unchanged since
+	suite.repo.On("GetParameterVersion", ctx, testIntersectionID, 2).
+		Return(&model.ParameterVersion{
+			IntersectionID: testIntersectionID,
+			Version:        2,
+			Changes: []model.ParameterChange{
+				{Field: model.ParameterFieldDefault, NewParameters: createTestDefaults(8)},
+				{
+					Field:         model.ParameterFieldCurrent,
+					NewParameters: createTestDefaults(14),
+					Metrics:       oldMetrics,
+				},
+			},
+		}, nil)
+	suite.repo.On("GetIntersectionByID", ctx, testIntersectionID).Return(current, nil)
+
+	reverted := createTestIntersection(model.Optimised)
+	reverted.DefaultParameters = createTestDefaults(8)
+	reverted.BestParametersStale = true
+	reverted.ParameterVersion = 5
+	suite.repo.On("RevertParameters", ctx, testIntersectionID,
+		mock.MatchedBy(func(version *model.ParameterVersion) bool {
+			changes := version.Changes
+			return version.Reason == model.ParametersReverted &&
+				version.RevertedVersion == 2 &&
+				version.UserID == testUserID &&
+				len(changes) == 1 &&
+				changes[0].Field == model.ParameterFieldDefault &&
+				*changes[0].OldParameters == createTestDefaults(10) &&
+				changes[0].NewParameters == createTestDefaults(8)
+		}), current.Version).
+		Return(reverted, nil)
+
+	result, err := suite.service.RevertParameterVersion(ctx, testIntersectionID, 2, testUserID, 0)
+
+	suite.Require().NoError(err)
+	suite.Equal(createTestDefaults(8), result.DefaultParameters)
+	suite.True(result.BestParametersStale)
+	suite.Equal(5, result.ParameterVersion)
+	suite.repo.AssertExpectations(suite.T())
+}
+
+func (suite *TestSuite) TestRevertParameterVersion_AlreadyCurrent() {
+	ctx := context.Background()
+	current := createTestIntersection(model.Optimised)
+
+	suite.repo.On("GetParameterVersion", ctx, testIntersectionID, 1).
+		Return(&model.ParameterVersion{
+			IntersectionID: testIntersectionID,
+			Version:        1,
+			Changes: []model.ParameterChange{
+				{Field: model.ParameterFieldDefault, NewParameters: createTestDefaults(10)},
+			},
+		}, nil)
+	suite.repo.On("GetIntersectionByID", ctx, testIntersectionID).Return(current, nil)
+
+	result, err := suite.service.RevertParameterVersion(ctx, testIntersectionID, 1, testUserID, 0)
+
+	suite.Require().NoError(err)
+	suite.Equal(current, result)
+	suite.repo.AssertNotCalled(suite.T(), "RevertParameters",
+		mock.Anything, mock.Anything, mock.Anything, mock.Anything)
+}
+
+func (suite *TestSuite) TestRevertParameterVersion_VersionNotFound() {
+	ctx := context.Background()
+
+	suite.repo.On("GetParameterVersion", ctx, testIntersectionID, 9).
+		Return(nil, errs.NewNotFoundError("parameter version not found", map[string]any{}))
+
+	_, err := suite.service.RevertParameterVersion(ctx, testIntersectionID, 9, testUserID, 0)
+
+	suite.Require().Error(err)
+	svcErr, ok := err.(*errs.ServiceError)
+	suite.Require().True(ok)
+	suite.Equal(errs.ErrNotFound, svcErr.Code)
+}
+
+func (suite *TestSuite) TestRevertParameterVersion_WhileOptimising() {
+	ctx := context.Background()
+
+	suite.repo.On("GetParameterVersion", ctx, testIntersectionID, 1).
+		Return(&model.ParameterVersion{
+			IntersectionID: testIntersectionID,
+			Version:        1,
+			Changes: []model.ParameterChange{
+				{Field: model.ParameterFieldBest, NewParameters: createTestDefaults(10)},
+			},
+		}, nil)
+	suite.repo.On("GetIntersectionByID", ctx, testIntersectionID).
+		Return(createTestIntersection(model.Optimising), nil)
+	suite.repo.On("RevertParameters", ctx, testIntersectionID, mock.Anything, mock.Anything).
+		Return(nil, errs.NewConflictError(
+			"parameters cannot be reverted while the intersection is being optimised",
+			map[string]any{},
+		))
+
+	_, err := suite.service.RevertParameterVersion(ctx, testIntersectionID, 1, testUserID, 0)
+
+	suite.Require().Error(err)
+	svcErr, ok := err.(*errs.ServiceError)
+	suite.Require().True(ok)
+	suite.Equal(errs.ErrConflict, svcErr.Code)
+}
+
+func (suite *TestSuite) TestRevertParameterVersion_ModifiedSinceRead() {
+	ctx := context.Background()
+	current := createTestIntersection(model.Optimised)
+	current.Version = 7
+
+	suite.repo.On("GetParameterVersion", ctx, testIntersectionID, 1).
+		Return(&model.ParameterVersion{
+			IntersectionID: testIntersectionID,
+			Version:        1,
+			Changes: []model.ParameterChange{
+				{Field: model.ParameterFieldBest, NewParameters: createTestDefaults(10)},
+			},
+		}, nil)
+	suite.repo.On("GetIntersectionByID", ctx, testIntersectionID).Return(current, nil)
+	suite.repo.On("RevertParameters", ctx, testIntersectionID, mock.Anything, 7).
+		Return(nil, errs.NewPreconditionError(
+			"intersection has been modified since it was read",
+			map[string]any{},
+		))
+
+	_, err := suite.service.RevertParameterVersion(ctx, testIntersectionID, 1, testUserID, 0)
+
+	suite.Require().Error(err)
+	svcErr, ok := err.(*errs.ServiceError)
+	suite.Require().True(ok)
+	suite.Equal(errs.ErrPrecondition, svcErr.Code)
+	suite.repo.AssertExpectations(suite.T())
+}
+
+func (suite *TestSuite) TestRevertParameterVersion_NotExpectedVersion() {
+	ctx := context.Background()
+	current := createTestIntersection(model.Optimised)
+	current.Version = 7
+
+	suite.repo.On("GetParameterVersion", ctx, testIntersectionID, 1).
+		Return(&model.ParameterVersion{
+			IntersectionID: testIntersectionID,
+			Version:        1,
+			Changes: []model.ParameterChange{
+				{Field: model.ParameterFieldBest, NewParameters: createTestDefaults(10)},
+			},
+		}, nil)
+	suite.repo.On("GetIntersectionByID", ctx, testIntersectionID).Return(current, nil)
+
+	_, err := suite.service.RevertParameterVersion(ctx, testIntersectionID, 1, testUserID, 6)
+
+	suite.Require().Error(err)
+	svcErr, ok := err.(*errs.ServiceError)
+	suite.Require().True(ok)
+	suite.Equal(errs.ErrPrecondition, svcErr.Code)
+	suite.repo.AssertNotCalled(suite.T(), "RevertParameters",
+		mock.Anything, mock.Anything, mock.Anything, mock.Anything)
+}
+
+func (suite *TestSuite) TestRevertParameterVersion_InvalidInput() {
+	for name, input := range map[string]struct {
+		version int
+		userID  string
+	}{
+		"zero version": {version: 0, userID: testUserID},
+		"missing user": {version: 1, userID: " "},
+	} {
+		_, err := suite.service.RevertParameterVersion(
+			context.Background(),
+			testIntersectionID,
+			input.version,
+			input.userID,
+			0,
+		)
+
+		suite.Require().Error(err, name)
+		svcErr, ok := err.(*errs.ServiceError)
+		suite.Require().True(ok, name)
+		suite.Equal(errs.ErrValidation, svcErr.Code, name)
+	}
+	suite.repo.AssertNotCalled(suite.T(), "GetParameterVersion",
+		mock.Anything, mock.Anything, mock.Anything)
+}
@@ -1,5 +1,338 @@
 package test
 
+import (
+	"context"
+
+	"github.com/COS301-SE-2025/Swift-Signals/intersection-service/internal/model"
+	errs "github.com/COS301-SE-2025/Swift-Signals/shared/error"
+	"github.com/stretchr/testify/mock"
+)
+
+const (
+	testIntersectionID = "5b3e8c1e-7d4f-4a8b-9c2d-1e6f3a9b7c5d"
+	testUserID         = "test-user-id"
+	testJobID          = "9d6f2b4a-3c1e-4f8a-b7d5-2e9c6a1f8b3d"
+)
+
+// anyStatus is the status expected of an intersection written to without a job
+const anyStatus = model.IntersectionStatus("")
+
+// parameterVersion matches a version of the given reason that changes exactly the given
+// fields
+func parameterVersion(reason model.ParameterChangeReason, fields ...model.ParameterField) any {
+	return mock.MatchedBy(func(version *model.ParameterVersion) bool {
+		if version.Reason != reason || version.UserID != testUserID ||
+			version.IntersectionID != testIntersectionID ||
+			len(version.Changes) != len(fields) {
+			return false
+		}
+		for i, change := range version.Changes {
+			if change.Field != fields[i] {
+				return false
+			}
+		}
+		return true
+	})
+}
+
 func (suite *TestSuite) TestPutOptimisation_Success() {
-	// TODO: add test case implementation
+	ctx := context.Background()
+	params := model.OptimisationParameters{OptimisationType: model.OptGridSearch}
+	metrics := &model.SimulationResults{AverageWaitingTime: 30}
+
+	suite.repo.On("GetIntersectionByID", ctx, testIntersectionID).
+		Return(&model.Intersection{
+			ID:          testIntersectionID,
+			BestMetrics: &model.SimulationResults{AverageWaitingTime: 45},
+		}, nil)
+	suite.repo.On("UpdateCurrentParams", ctx, testIntersectionID, params, metrics, true, anyStatus,
+		parameterVersion(model.ParametersOptimised,
+			model.ParameterFieldCurrent, model.ParameterFieldBest)).
+		Return(nil)
+
+	improved, err := suite.service.PutOptimisation(
+		ctx,
+		testIntersectionID,
+		params,
+		metrics,
+		testUserID,
+		"",
+	)
+
+	suite.Require().NoError(err)
+	suite.True(improved)
+	suite.repo.AssertExpectations(suite.T())
+}
+
+func (suite *TestSuite) TestPutOptimisation_NoBestMetrics() {
+	ctx := context.Background()
+	params := model.OptimisationParameters{OptimisationType: model.OptGridSearch}
+	metrics := &model.SimulationResults{AverageWaitingTime: 90}
+
+	suite.repo.On("GetIntersectionByID", ctx, testIntersectionID).
+		Return(&model.Intersection{ID: testIntersectionID}, nil)
+	suite.repo.On("UpdateCurrentParams", ctx, testIntersectionID, params, metrics, true, anyStatus,
+		parameterVersion(model.ParametersOptimised,
+			model.ParameterFieldCurrent, model.ParameterFieldBest)).
+		Return(nil)
+
+	improved, err := suite.service.PutOptimisation(
+		ctx,
+		testIntersectionID,
+		params,
+		metrics,
+		testUserID,
+		"",
+	)
+
+	suite.Require().NoError(err)
+	suite.True(improved)
+	suite.repo.AssertExpectations(suite.T())
+}
+
+func (suite *TestSuite) TestPutOptimisation_NotImproved() {
+	ctx := context.Background()
+	params := model.OptimisationParameters{OptimisationType: model.OptGridSearch}
+	metrics := &model.SimulationResults{AverageWaitingTime: 45}
+
+	suite.repo.On("GetIntersectionByID", ctx, testIntersectionID).
+		Return(&model.Intersection{
+			ID:          testIntersectionID,
+			BestMetrics: &model.SimulationResults{AverageWaitingTime: 45},
+		}, nil)
+	suite.repo.On("UpdateCurrentParams", ctx, testIntersectionID, params, metrics, false, anyStatus,
+		parameterVersion(model.ParametersOptimised, model.ParameterFieldCurrent)).
+		Return(nil)
+
+	improved, err := suite.service.PutOptimisation(
+		ctx,
+		testIntersectionID,
+		params,
+		metrics,
+		testUserID,
+		"",
+	)
+
+	suite.Require().NoError(err)
+	suite.False(improved)
+	suite.repo.AssertExpectations(suite.T())
+}
+
+func (suite *TestSuite) TestPutOptimisation_StaleBestParameters() {
+	ctx := context.Background()
+	params := model.OptimisationParameters{OptimisationType: model.OptGridSearch}
+	metrics := &model.SimulationResults{AverageWaitingTime: 60}
+
+	// NOTE: Worse than the stale best, which was found under other defaults
+	suite.repo.On("GetIntersectionByID", ctx, testIntersectionID).
+		Return(&model.Intersection{
+			ID:                  testIntersectionID,
+			BestMetrics:         &model.SimulationResults{AverageWaitingTime: 45},
+			BestParametersStale: true,
+		}, nil)
+	suite.repo.On("UpdateCurrentParams", ctx, testIntersectionID, params, metrics, true, anyStatus,
+		parameterVersion(model.ParametersOptimised,
+			model.ParameterFieldCurrent, model.ParameterFieldBest)).
+		Return(nil)
+
+	improved, err := suite.service.PutOptimisation(
+		ctx,
+		testIntersectionID,
+		params,
+		metrics,
+		testUserID,
+		"",
+	)
+
+	suite.Require().NoError(err)
+	suite.True(improved)
+	suite.repo.AssertExpectations(suite.T())
+}
+
+func (suite *TestSuite) TestPutOptimisation_MissingMetrics() {
+	improved, err := suite.service.PutOptimisation(
+		context.Background(),
+		testIntersectionID,
+		model.OptimisationParameters{},
+		nil,
+		testUserID,
+		"",
+	)
+
+	suite.Require().Error(err)
+	suite.False(improved)
+	svcErr, ok := err.(*errs.ServiceError)
+	suite.Require().True(ok)
+	suite.Equal(errs.ErrValidation, svcErr.Code)
+	suite.repo.AssertNotCalled(suite.T(), "GetIntersectionByID", mock.Anything, mock.Anything)
+}
+
+func (suite *TestSuite) TestPutOptimisation_NotFound() {
+	ctx := context.Background()
+
+	suite.repo.On("GetIntersectionByID", ctx, testIntersectionID).
+		Return(nil, errs.NewNotFoundError("intersection not found", map[string]any{}))
+
+	_, err := suite.service.PutOptimisation(
+		ctx,
+		testIntersectionID,
+		model.OptimisationParameters{},
+		&model.SimulationResults{},
+		testUserID,
+		"",
+	)
+
+	suite.Require().Error(err)
+	svcErr, ok := err.(*errs.ServiceError)
+	suite.Require().True(ok)
+	suite.Equal(errs.ErrNotFound, svcErr.Code)
+	suite.repo.AssertNotCalled(suite.T(), "UpdateCurrentParams", mock.Anything, mock.Anything,
+		mock.Anything, mock.Anything, mock.Anything, mock.Anything, mock.Anything)
+}
+
+func (suite *TestSuite) TestPutOptimisation_UnchangedParameters() {
+	ctx := context.Background()
+	params := model.OptimisationParameters{OptimisationType: model.OptGridSearch}
+	metrics := &model.SimulationResults{AverageWaitingTime: 30}
+
+	suite.repo.On("GetIntersectionByID", ctx, testIntersectionID).
+		Return(&model.Intersection{
+			ID:                testIntersectionID,
+			CurrentParameters: params,
+			BestParameters:    params,
+			BestMetrics:       &model.SimulationResults{AverageWaitingTime: 45},
+		}, nil)
+	suite.repo.On("UpdateCurrentParams", ctx, testIntersectionID, params, metrics, true, anyStatus,
+		(*model.ParameterVersion)(nil)).
+		Return(nil)
+
+	_, err := suite.service.PutOptimisation(
+		ctx,
+		testIntersectionID,
+		params,
+		metrics,
+		testUserID,
+		"",
+	)
+
+	suite.Require().NoError(err)
+	suite.repo.AssertExpectations(suite.T())
+}
+
+func (suite *TestSuite) TestPutOptimisation_ActiveJob() {
+	ctx := context.Background()
+	params := model.OptimisationParameters{OptimisationType: model.OptGridSearch}
+	metrics := &model.SimulationResults{AverageWaitingTime: 30}
+
+	suite.repo.On("GetOptimisationJobByID", ctx, testJobID).
+		Return(&model.OptimisationJob{
+			ID:             testJobID,
+			IntersectionID: testIntersectionID,
+			Status:         model.JobRunning,
+		}, nil)
+	suite.repo.On("GetIntersectionByID", ctx, testIntersectionID).
+		Return(&model.Intersection{ID: testIntersectionID, Status: model.Optimising}, nil)
+	suite.repo.On("UpdateCurrentParams", ctx, testIntersectionID, params, metrics, true,
+		model.Optimising, parameterVersion(model.ParametersOptimised,
+			model.ParameterFieldCurrent, model.ParameterFieldBest)).
+		Return(nil)
+
+	improved, err := suite.service.PutOptimisation(
+		ctx,
+		testIntersectionID,
+		params,
+		metrics,
+		testUserID,
+		testJobID,
+	)
+
+	suite.Require().NoError(err)
+	suite.True(improved)
+	suite.repo.AssertExpectations(suite.T())
+}
+
+func (suite *TestSuite) TestPutOptimisation_FinishedJob() {
+	ctx := context.Background()
+
+	// NOTE: The reconciler failed the job when its lease expired
+	suite.repo.On("GetOptimisationJobByID", ctx, testJobID).
+		Return(&model.OptimisationJob{
+			ID:             testJobID,
+			IntersectionID: testIntersectionID,
+			Status:         model.JobFailed,
+		}, nil)
+
+	_, err := suite.service.PutOptimisation(
+		ctx,
+		testIntersectionID,
+		model.OptimisationParameters{},
+		&model.SimulationResults{},
+		testUserID,
+		testJobID,
+	)
+
+	suite.Require().Error(err)
+	svcErr, ok := err.(*errs.ServiceError)
+	suite.Require().True(ok)
+	suite.Equal(errs.ErrConflict, svcErr.Code)
+	suite.repo.AssertNotCalled(suite.T(), "UpdateCurrentParams", mock.Anything, mock.Anything,
+		mock.Anything, mock.Anything, mock.Anything, mock.Anything, mock.Anything)
+}
+
+func (suite *TestSuite) TestPutOptimisation_JobOfOtherIntersection() {
+	ctx := context.Background()
+
+	suite.repo.On("GetOptimisationJobByID", ctx, testJobID).
+		Return(&model.OptimisationJob{
+			ID:             testJobID,
+			IntersectionID: "other-intersection",
+			Status:         model.JobRunning,
+		}, nil)
+
+	_, err := suite.service.PutOptimisation(
+		ctx,
+		testIntersectionID,
+		model.OptimisationParameters{},
+		&model.SimulationResults{},
+		testUserID,
+		testJobID,
+	)
+
+	suite.Require().Error(err)
+	svcErr, ok := err.(*errs.ServiceError)
+	suite.Require().True(ok)
+	suite.Equal(errs.ErrValidation, svcErr.Code)
+	suite.repo.AssertNotCalled(suite.T(), "GetIntersectionByID", mock.Anything, mock.Anything)
+}
+
+func (suite *TestSuite) TestPutOptimisation_IntersectionNoLongerOptimising() {
+	ctx := context.Background()
+	params := model.OptimisationParameters{OptimisationType: model.OptGridSearch}
+	metrics := &model.SimulationResults{AverageWaitingTime: 90}
+
+	suite.repo.On("GetOptimisationJobByID", ctx, testJobID).
+		Return(&model.OptimisationJob{
+			ID:             testJobID,
+			IntersectionID: testIntersectionID,
+			Status:         model.JobRunning,
+		}, nil)
+	suite.repo.On("GetIntersectionByID", ctx, testIntersectionID).
+		Return(&model.Intersection{ID: testIntersectionID}, nil)
+	suite.repo.On("UpdateCurrentParams", ctx, testIntersectionID, params, metrics, true,
+		model.Optimising, mock.Anything).
+		Return(errs.NewConflictError("intersection no longer optimising", map[string]any{}))
+
+	_, err := suite.service.PutOptimisation(
+		ctx,
+		testIntersectionID,
+		params,
+		metrics,
+		testUserID,
+		testJobID,
+	)
+
+	suite.Require().Error(err)
+	svcErr, ok := err.(*errs.ServiceError)
+	suite.Require().True(ok)
+	suite.Equal(errs.ErrConflict, svcErr.Code)
 }
@@ -0,0 +1,95 @@
+package test
+
+import (
+	"context"
+	"time"
+
+	"github.com/COS301-SE-2025/Swift-Signals/intersection-service/internal/model"
+	errs "github.com/COS301-SE-2025/Swift-Signals/shared/error"
+	"github.com/stretchr/testify/mock"
+)
+
+func (suite *TestSuite) TestReconcileStaleOptimisations_Success() {
+	ctx := context.Background()
+	lease := 2 * time.Hour
+
+	suite.repo.On("FailStaleOptimisations", ctx,
+		mock.MatchedBy(func(deadline time.Time) bool {
+			age := time.Since(deadline)
+			return age >= lease && age < lease+time.Minute
+		}),
+		mock.AnythingOfType("string"),
+	).Return([]string{"stuck-intersection"}, nil)
+
+	ids, err := suite.service.ReconcileStaleOptimisations(ctx, lease)
+
+	suite.Require().NoError(err)
+	suite.Equal([]string{"stuck-intersection"}, ids)
+	suite.repo.AssertExpectations(suite.T())
+}
+
+func (suite *TestSuite) TestReconcileStaleOptimisations_InvalidLease() {
+	_, err := suite.service.ReconcileStaleOptimisations(context.Background(), 0)
+
+	suite.Require().Error(err)
+	svcErr, ok := err.(*errs.ServiceError)
+	suite.Require().True(ok)
+	suite.Equal(errs.ErrValidation, svcErr.Code)
+	suite.repo.AssertNotCalled(suite.T(), "FailStaleOptimisations",
+		mock.Anything, mock.Anything, mock.Anything)
+}
+
+func (suite *TestSuite) TestReconcileStaleOptimisations_DatabaseError() {
+	ctx := context.Background()
+
+	suite.repo.On("FailStaleOptimisations", ctx, mock.Anything, mock.Anything).
+		Return(nil, errs.NewDatabaseError("connection lost", nil, map[string]any{}))
+
+	_, err := suite.service.ReconcileStaleOptimisations(ctx, time.Hour)
+
+	suite.Require().Error(err)
+	svcErr, ok := err.(*errs.ServiceError)
+	suite.Require().True(ok)
+	suite.Equal(errs.ErrDatabase, svcErr.Code)
+}
+
+func (suite *TestSuite) TestRenewOptimisationLease_Success() {
+	ctx := context.Background()
+
+	suite.repo.On("RenewOptimisationLease", ctx, testJobID,
+		mock.MatchedBy(func(now time.Time) bool {
+			return time.Since(now) < time.Minute
+		}),
+	).Return(&model.OptimisationJob{ID: testJobID, Status: model.JobRunning}, nil)
+
+	job, err := suite.service.RenewOptimisationLease(ctx, testJobID)
+
+	suite.Require().NoError(err)
+	suite.Equal(testJobID, job.ID)
+	suite.repo.AssertExpectations(suite.T())
+}
+
+func (suite *TestSuite) TestRenewOptimisationLease_FinishedJob() {
+	ctx := context.Background()
+
+	suite.repo.On("RenewOptimisationLease", ctx, testJobID, mock.Anything).
+		Return(nil, errs.NewConflictError("optimisation job already finished", map[string]any{}))
+
+	_, err := suite.service.RenewOptimisationLease(ctx, testJobID)
+
+	suite.Require().Error(err)
+	svcErr, ok := err.(*errs.ServiceError)
+	suite.Require().True(ok)
+	suite.Equal(errs.ErrConflict, svcErr.Code)
+}
+
+func (suite *TestSuite) TestRenewOptimisationLease_InvalidID() {
+	_, err := suite.service.RenewOptimisationLease(context.Background(), "not-a-uuid")
+
+	suite.Require().Error(err)
+	svcErr, ok := err.(*errs.ServiceError)
+	suite.Require().True(ok)
+	suite.Equal(errs.ErrValidation, svcErr.Code)
+	suite.repo.AssertNotCalled(suite.T(), "RenewOptimisationLease",
+		mock.Anything, mock.Anything, mock.Anything)
+}
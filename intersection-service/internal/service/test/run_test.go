@@ -0,0 +1,73 @@
+package test
+
+import (
+	"context"
+	"time"
+
+	"github.com/COS301-SE-2025/Swift-Signals/intersection-service/internal/model"
+	errs "github.com/COS301-SE-2025/Swift-Signals/shared/error"
+	"github.com/stretchr/testify/mock"
+)
+
+func (suite *TestSuite) TestCreateRun_Success() {
+	ctx := context.Background()
+	run := &model.Run{
+		IntersectionID: testIntersectionID,
+		UserID:         "test-user-id",
+		Type:           model.RunSimulation,
+		Metrics:        &model.SimulationResults{AverageWaitingTime: 30},
+		Duration:       2 * time.Second,
+		Outcome:        model.RunSucceeded,
+	}
+
+	suite.repo.On("GetIntersectionByID", ctx, testIntersectionID).
+		Return(&model.Intersection{ID: testIntersectionID}, nil)
+	suite.repo.On("CreateRun", ctx, mock.MatchedBy(func(r *model.Run) bool {
+		return r.ID != "" && !r.CreatedAt.IsZero() && r.IntersectionID == testIntersectionID
+	})).Return(run, nil)
+
+	created, err := suite.service.CreateRun(ctx, run)
+
+	suite.Require().NoError(err)
+	suite.Equal(testIntersectionID, created.IntersectionID)
+	suite.repo.AssertExpectations(suite.T())
+}
+
+func (suite *TestSuite) TestCreateRun_InvalidOutcome() {
+	_, err := suite.service.CreateRun(context.Background(), &model.Run{
+		IntersectionID: testIntersectionID,
+		UserID:         "test-user-id",
+		Type:           model.RunSimulation,
+		Outcome:        "RUN_OUTCOME_UNSPECIFIED",
+	})
+
+	suite.Require().Error(err)
+	svcErr, ok := err.(*errs.ServiceError)
+	suite.Require().True(ok)
+	suite.Equal(errs.ErrValidation, svcErr.Code)
+	suite.repo.AssertNotCalled(suite.T(), "CreateRun", mock.Anything, mock.Anything)
+}
+
+func (suite *TestSuite) TestGetRuns_Pagination() {
+	ctx := context.Background()
+	runs := []*model.Run{{ID: "run-3"}, {ID: "run-4"}}
+
+	suite.repo.On("GetIntersectionByID", ctx, testIntersectionID).
+		Return(&model.Intersection{ID: testIntersectionID}, nil)
+	suite.repo.On("GetRuns", ctx, testIntersectionID, 2, 2).Return(runs, nil)
+
+	got, err := suite.service.GetRuns(ctx, testIntersectionID, 2, 2)
+
+	suite.Require().NoError(err)
+	suite.Equal(runs, got)
+	suite.repo.AssertExpectations(suite.T())
+}
+
+func (suite *TestSuite) TestGetRuns_InvalidPageSize() {
+	_, err := suite.service.GetRuns(context.Background(), testIntersectionID, 1, 500)
+
+	suite.Require().Error(err)
+	svcErr, ok := err.(*errs.ServiceError)
+	suite.Require().True(ok)
+	suite.Equal(errs.ErrValidation, svcErr.Code)
+}
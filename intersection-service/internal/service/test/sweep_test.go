@@ -0,0 +1,136 @@
+package test
+
+import (
+	"context"
+	"time"
+
+	"github.com/COS301-SE-2025/Swift-Signals/intersection-service/internal/model"
+	errs "github.com/COS301-SE-2025/Swift-Signals/shared/error"
+	"github.com/stretchr/testify/mock"
+)
+
+const testSweepID = "7c9e6679-7425-40de-944b-e07fc1f90ae7"
+
+func createTestSweepRanges() []model.SweepRange {
+	return []model.SweepRange{
+		{Parameter: "green", Min: 10, Max: 60, Step: 5},
+		{Parameter: "red", Min: 5, Max: 30, Step: 5},
+	}
+}
+
+func (suite *TestSuite) TestCreateSweep_Success() {
+	ctx := context.Background()
+	sweep := &model.Sweep{
+		IntersectionID: testIntersectionID,
+		UserID:         "test-user-id",
+		BaseParameters: model.SimulationParameters{Green: 10, Yellow: 3, Red: 5, Speed: 60},
+		Ranges:         createTestSweepRanges(),
+		TotalPoints:    66,
+	}
+
+	suite.repo.On("GetIntersectionByID", ctx, testIntersectionID).
+		Return(&model.Intersection{ID: testIntersectionID}, nil)
+	suite.repo.On("CreateSweep", ctx, mock.MatchedBy(func(s *model.Sweep) bool {
+		return s.ID != "" && !s.CreatedAt.IsZero() && s.Status == model.SweepPending &&
+			len(s.Points) == 0
+	})).Return(sweep, nil)
+
+	created, err := suite.service.CreateSweep(ctx, sweep)
+
+	suite.Require().NoError(err)
+	suite.Equal(model.SweepPending, created.Status)
+	suite.repo.AssertExpectations(suite.T())
+}
+
+func (suite *TestSuite) TestCreateSweep_InvalidRange() {
+	ranges := createTestSweepRanges()
+	ranges[1].Max = 1
+
+	_, err := suite.service.CreateSweep(context.Background(), &model.Sweep{
+		IntersectionID: testIntersectionID,
+		UserID:         "test-user-id",
+		Ranges:         ranges,
+		TotalPoints:    11,
+	})
+
+	suite.Require().Error(err)
+	svcErr, ok := err.(*errs.ServiceError)
+	suite.Require().True(ok)
+	suite.Equal(errs.ErrValidation, svcErr.Code)
+	suite.repo.AssertNotCalled(suite.T(), "CreateSweep", mock.Anything, mock.Anything)
+}
+
+func (suite *TestSuite) TestCreateSweep_UnknownParameter() {
+	_, err := suite.service.CreateSweep(context.Background(), &model.Sweep{
+		IntersectionID: testIntersectionID,
+		UserID:         "test-user-id",
+		Ranges:         []model.SweepRange{{Parameter: "density", Min: 1, Max: 3, Step: 1}},
+		TotalPoints:    3,
+	})
+
+	suite.Require().Error(err)
+	svcErr, ok := err.(*errs.ServiceError)
+	suite.Require().True(ok)
+	suite.Equal(errs.ErrValidation, svcErr.Code)
+}
+
+func (suite *TestSuite) TestPutSweepPoint_Success() {
+	ctx := context.Background()
+	point := model.SweepPoint{
+		Index:      3,
+		Parameters: model.SimulationParameters{Green: 25, Red: 5},
+		Metrics:    &model.SimulationResults{AverageWaitingTime: 12},
+		Duration:   time.Second,
+	}
+
+	suite.repo.On("AddSweepPoint", ctx, testSweepID, point).Return(nil)
+
+	err := suite.service.PutSweepPoint(ctx, testSweepID, point)
+
+	suite.Require().NoError(err)
+	suite.repo.AssertExpectations(suite.T())
+}
+
+func (suite *TestSuite) TestPutSweepPoint_NoOutcome() {
+	err := suite.service.PutSweepPoint(context.Background(), testSweepID, model.SweepPoint{
+		Index: 0,
+	})
+
+	suite.Require().Error(err)
+	svcErr, ok := err.(*errs.ServiceError)
+	suite.Require().True(ok)
+	suite.Equal(errs.ErrValidation, svcErr.Code)
+	suite.repo.AssertNotCalled(suite.T(), "AddSweepPoint",
+		mock.Anything, mock.Anything, mock.Anything)
+}
+
+func (suite *TestSuite) TestUpdateSweep_Finished() {
+	ctx := context.Background()
+
+	suite.repo.On("GetSweepByID", ctx, "", testSweepID).
+		Return(&model.Sweep{ID: testSweepID, Status: model.SweepSucceeded}, nil)
+
+	_, err := suite.service.UpdateSweep(ctx, testSweepID, model.SweepFailed, "too late")
+
+	suite.Require().Error(err)
+	svcErr, ok := err.(*errs.ServiceError)
+	suite.Require().True(ok)
+	suite.Equal(errs.ErrConflict, svcErr.Code)
+	suite.repo.AssertNotCalled(suite.T(), "UpdateSweep", mock.Anything, mock.Anything)
+}
+
+func (suite *TestSuite) TestUpdateSweep_Running() {
+	ctx := context.Background()
+
+	suite.repo.On("GetSweepByID", ctx, "", testSweepID).
+		Return(&model.Sweep{ID: testSweepID, Status: model.SweepPending}, nil)
+	suite.repo.On("UpdateSweep", ctx, mock.MatchedBy(func(s *model.Sweep) bool {
+		return s.Status == model.SweepRunning && !s.StartedAt.IsZero() && s.FinishedAt.IsZero()
+	})).Return(&model.Sweep{ID: testSweepID, Status: model.SweepRunning}, nil)
+
+	updated, err := suite.service.UpdateSweep(ctx, testSweepID, model.SweepRunning, "")
+
+	suite.Require().NoError(err)
+	suite.Equal(model.SweepRunning, updated.Status)
+	suite.repo.AssertExpectations(suite.T())
+}
@@ -0,0 +1,104 @@
+package test
+
+import (
+	"context"
+	"time"
+
+	"github.com/COS301-SE-2025/Swift-Signals/intersection-service/internal/model"
+	errs "github.com/COS301-SE-2025/Swift-Signals/shared/error"
+	"github.com/stretchr/testify/mock"
+)
+
+func (suite *TestSuite) TestGetAllIntersections_Trash() {
+	ctx := context.Background()
+	deletedAt := time.Now()
+	trashed := []*model.Intersection{{ID: testIntersectionID, DeletedAt: &deletedAt}}
+
+	suite.repo.On("GetAllIntersections", ctx, 20, 20, testIntersectionID, true).
+		Return(trashed, nil)
+
+	got, err := suite.service.GetAllIntersections(ctx, 2, 20, testIntersectionID, true)
+
+	suite.Require().NoError(err)
+	suite.Equal(trashed, got)
+	suite.repo.AssertExpectations(suite.T())
+}
+
+func (suite *TestSuite) TestRestoreIntersection_Success() {
+	ctx := context.Background()
+	restored := createTestIntersection(model.Optimised)
+
+	suite.repo.On("RestoreIntersection", ctx, testIntersectionID).Return(restored, nil)
+
+	got, err := suite.service.RestoreIntersection(ctx, " "+testIntersectionID+" ")
+
+	suite.Require().NoError(err)
+	suite.Equal(restored, got)
+	suite.repo.AssertExpectations(suite.T())
+}
+
+func (suite *TestSuite) TestRestoreIntersection_NotInTrash() {
+	ctx := context.Background()
+
+	suite.repo.On("RestoreIntersection", ctx, testIntersectionID).
+		Return(nil, errs.NewNotFoundError("intersection ID not found in trash", map[string]any{}))
+
+	_, err := suite.service.RestoreIntersection(ctx, testIntersectionID)
+
+	suite.Require().Error(err)
+	svcErr, ok := err.(*errs.ServiceError)
+	suite.Require().True(ok)
+	suite.Equal(errs.ErrNotFound, svcErr.Code)
+}
+
+func (suite *TestSuite) TestRestoreIntersection_InvalidID() {
+	_, err := suite.service.RestoreIntersection(context.Background(), "not-a-uuid")
+
+	suite.Require().Error(err)
+	svcErr, ok := err.(*errs.ServiceError)
+	suite.Require().True(ok)
+	suite.Equal(errs.ErrValidation, svcErr.Code)
+	suite.repo.AssertNotCalled(suite.T(), "RestoreIntersection", mock.Anything, mock.Anything)
+}
+
+func (suite *TestSuite) TestPurgeDeletedIntersections_Success() {
+	ctx := context.Background()
+	retention := 30 * 24 * time.Hour
+
+	suite.repo.On("PurgeDeletedIntersections", ctx,
+		mock.MatchedBy(func(deadline time.Time) bool {
+			age := time.Since(deadline)
+			return age >= retention && age < retention+time.Minute
+		}),
+	).Return([]string{"expired-intersection"}, nil)
+
+	ids, err := suite.service.PurgeDeletedIntersections(ctx, retention)
+
+	suite.Require().NoError(err)
+	suite.Equal([]string{"expired-intersection"}, ids)
+	suite.repo.AssertExpectations(suite.T())
+}
+
+func (suite *TestSuite) TestPurgeDeletedIntersections_InvalidRetention() {
+	_, err := suite.service.PurgeDeletedIntersections(context.Background(), 0)
+
+	suite.Require().Error(err)
+	svcErr, ok := err.(*errs.ServiceError)
+	suite.Require().True(ok)
+	suite.Equal(errs.ErrValidation, svcErr.Code)
+	suite.repo.AssertNotCalled(suite.T(), "PurgeDeletedIntersections", mock.Anything, mock.Anything)
+}
+
+func (suite *TestSuite) TestPurgeDeletedIntersections_DatabaseError() {
+	ctx := context.Background()
+
+	suite.repo.On("PurgeDeletedIntersections", ctx, mock.Anything).
+		Return(nil, errs.NewDatabaseError("connection lost", nil, map[string]any{}))
+
+	_, err := suite.service.PurgeDeletedIntersections(ctx, time.Hour)
+
+	suite.Require().Error(err)
+	svcErr, ok := err.(*errs.ServiceError)
+	suite.Require().True(ok)
+	suite.Equal(errs.ErrDatabase, svcErr.Code)
+}
@@ -1,5 +1,230 @@
 package test
 
+import (
+	"context"
+	"errors"
+
+	"github.com/COS301-SE-2025/Swift-Signals/intersection-service/internal/model"
+	errs "github.com/COS301-SE-2025/Swift-Signals/shared/error"
+	"github.com/stretchr/testify/mock"
+)
+
+func createTestDefaults(green int) model.OptimisationParameters {
+	return model.OptimisationParameters{
+		OptimisationType: model.OptGridSearch,
+		Parameters: model.SimulationParameters{
+			IntersectionType: model.IntersectionTJunction,
+			Green:            green,
+			Yellow:           3,
+			Red:              7,
+			Speed:            60,
+			Seed:             12345,
+		},
+	}
+}
+
+func createTestIntersection(status model.IntersectionStatus) *model.Intersection {
+	return &model.Intersection{
+		ID:                testIntersectionID,
+		Name:              "Test Intersection",
+		Status:            status,
+		TrafficDensity:    model.TrafficHigh,
+		DefaultParameters: createTestDefaults(10),
+		BestParameters:    createTestDefaults(14),
+	}
+}
+
 func (suite *TestSuite) TestUpdateIntersection_Success() {
-	// TODO: add test case implementation
+	ctx := context.Background()
+	details := model.IntersectionDetails{City: "Pretoria"}
+	updated := createTestIntersection(model.Unoptimised)
+
+	suite.repo.On("UpdateIntersection", ctx, testIntersectionID, "New Name", details,
+		model.Unspecified, "", model.TrafficDensity(""), (*model.OptimisationParameters)(nil), (*model.ParameterVersion)(nil), 0).
+		Return(updated, nil)
+
+	result, err := suite.service.UpdateIntersection(ctx, testIntersectionID, "New Name", details,
+		model.Unspecified, "", "", nil, testUserID, 0)
+
+	suite.Require().NoError(err)
+	suite.Equal(updated, result)
+	// NOTE: Only changing defaults needs the current intersection
+	suite.repo.AssertNotCalled(suite.T(), "GetIntersectionByID", mock.Anything, mock.Anything)
+}
+
+func (suite *TestSuite) TestUpdateIntersection_ChangesDefaults() {
+	ctx := context.Background()
+	defaults := createTestDefaults(20)
+	updated := createTestIntersection(model.Optimised)
+	updated.DefaultParameters = defaults
+	updated.TrafficDensity = model.TrafficLow
+	updated.BestParametersStale = true
+	updated.ParameterVersion = 2
+	current := createTestIntersection(model.Optimised)
+	current.Version = 6
+
+	// NOTE: Without an expected version, the update is conditional on the one that was read
+	suite.repo.On("GetIntersectionByID", ctx, testIntersectionID).Return(current, nil)
+	suite.repo.On("UpdateIntersection", ctx, testIntersectionID, "Test Intersection",
+		mock.Anything, model.Unspecified, "", model.TrafficLow, &defaults,
+		parameterVersion(model.ParametersUpdated, model.ParameterFieldDefault), 6).
+		Return(updated, nil)
+
+	result, err := suite.service.UpdateIntersection(ctx, testIntersectionID, "Test Intersection",
+		model.IntersectionDetails{}, model.Unspecified, "", model.TrafficLow, &defaults, testUserID, 0)
+
+	suite.Require().NoError(err)
+	suite.True(result.BestParametersStale)
+	suite.Equal(2, result.ParameterVersion)
+	suite.repo.AssertExpectations(suite.T())
+}
+
+func (suite *TestSuite) TestUpdateIntersection_UnchangedDefaults() {
+	ctx := context.Background()
+	defaults := createTestDefaults(10)
+	current := createTestIntersection(model.Optimised)
+
+	suite.repo.On("GetIntersectionByID", ctx, testIntersectionID).Return(current, nil)
+	suite.repo.On("UpdateIntersection", ctx, testIntersectionID, "Test Intersection",
+		mock.Anything, model.Unspecified, "", model.TrafficDensity(""),
+		(*model.OptimisationParameters)(nil), (*model.ParameterVersion)(nil), 0).
+		Return(current, nil)
+
+	_, err := suite.service.UpdateIntersection(ctx, testIntersectionID, "Test Intersection",
+		model.IntersectionDetails{}, model.Unspecified, "", model.TrafficHigh, &defaults, testUserID, 0)
+
+	suite.Require().NoError(err)
+	suite.repo.AssertExpectations(suite.T())
+}
+
+func (suite *TestSuite) TestUpdateIntersection_InvalidDefaults() {
+	ctx := context.Background()
+	invalidGreen := createTestDefaults(0)
+	unspecifiedType := createTestDefaults(10)
+	unspecifiedType.Parameters.IntersectionType = "INTERSECTION_TYPE_UNSPECIFIED"
+
+	for name, update := range map[string]struct {
+		density  model.TrafficDensity
+		defaults *model.OptimisationParameters
+	}{
+		"unknown density":           {density: "TRAFFIC_DENSITY_EXTREME"},
+		"green below one":           {defaults: &invalidGreen},
+		"unspecified intersection":  {defaults: &unspecifiedType},
+		"density with bad defaults": {density: model.TrafficLow, defaults: &invalidGreen},
+	} {
+		_, err := suite.service.UpdateIntersection(ctx, testIntersectionID, "Test Intersection",
+			model.IntersectionDetails{}, model.Unspecified, "", update.density, update.defaults,
+			testUserID, 0)
+
+		suite.Require().Error(err, name)
+		var svcErr *errs.ServiceError
+		suite.Require().True(errors.As(err, &svcErr), name)
+		suite.Equal(errs.ErrValidation, svcErr.Code, name)
+	}
+	suite.repo.AssertNotCalled(suite.T(), "UpdateIntersection", mock.Anything, mock.Anything,
+		mock.Anything, mock.Anything, mock.Anything, mock.Anything, mock.Anything, mock.Anything,
+		mock.Anything, mock.Anything)
+}
+
+func (suite *TestSuite) TestUpdateIntersection_DefaultsWhileOptimising() {
+	ctx := context.Background()
+	defaults := createTestDefaults(20)
+
+	suite.repo.On("GetIntersectionByID", ctx, testIntersectionID).
+		Return(createTestIntersection(model.Optimising), nil)
+
+	_, err := suite.service.UpdateIntersection(ctx, testIntersectionID, "Test Intersection",
+		model.IntersectionDetails{}, model.Unspecified, "", "", &defaults, testUserID, 0)
+
+	suite.Require().Error(err)
+	var svcErr *errs.ServiceError
+	suite.Require().True(errors.As(err, &svcErr))
+	suite.Equal(errs.ErrConflict, svcErr.Code)
+	suite.repo.AssertNotCalled(suite.T(), "UpdateIntersection", mock.Anything, mock.Anything,
+		mock.Anything, mock.Anything, mock.Anything, mock.Anything, mock.Anything, mock.Anything,
+		mock.Anything, mock.Anything)
+}
+
+func (suite *TestSuite) TestUpdateIntersection_ExpectedVersion() {
+	ctx := context.Background()
+	details := model.IntersectionDetails{City: "Pretoria"}
+	updated := createTestIntersection(model.Unoptimised)
+	updated.Version = 4
+
+	suite.repo.On("UpdateIntersection", ctx, testIntersectionID, "New Name", details,
+		model.Unspecified, "", model.TrafficDensity(""), (*model.OptimisationParameters)(nil), (*model.ParameterVersion)(nil), 3).
+		Return(updated, nil)
+
+	result, err := suite.service.UpdateIntersection(ctx, testIntersectionID, "New Name", details,
+		model.Unspecified, "", "", nil, testUserID, 3)
+
+	suite.Require().NoError(err)
+	suite.Equal(4, result.Version)
+	suite.repo.AssertExpectations(suite.T())
+}
+
+func (suite *TestSuite) TestUpdateIntersection_StaleVersion() {
+	ctx := context.Background()
+	details := model.IntersectionDetails{City: "Pretoria"}
+
+	suite.repo.On("UpdateIntersection", ctx, testIntersectionID, "New Name", details,
+		model.Unspecified, "", model.TrafficDensity(""), (*model.OptimisationParameters)(nil), (*model.ParameterVersion)(nil), 3).
+		Return(nil, errs.NewPreconditionError(
+			"intersection has been modified since it was read",
+			map[string]any{},
+		))
+
+	_, err := suite.service.UpdateIntersection(ctx, testIntersectionID, "New Name", details,
+		model.Unspecified, "", "", nil, testUserID, 3)
+
+	suite.Require().Error(err)
+	var svcErr *errs.ServiceError
+	suite.Require().True(errors.As(err, &svcErr))
+	suite.Equal(errs.ErrPrecondition, svcErr.Code)
+}
+
+func (suite *TestSuite) TestUpdateIntersection_InvalidExpectedVersion() {
+	ctx := context.Background()
+
+	_, err := suite.service.UpdateIntersection(ctx, testIntersectionID, "New Name",
+		model.IntersectionDetails{}, model.Unspecified, "", "", nil, testUserID, -1)
+
+	suite.Require().Error(err)
+	var svcErr *errs.ServiceError
+	suite.Require().True(errors.As(err, &svcErr))
+	suite.Equal(errs.ErrValidation, svcErr.Code)
+	suite.repo.AssertNotCalled(suite.T(), "UpdateIntersection", mock.Anything, mock.Anything,
+		mock.Anything, mock.Anything, mock.Anything, mock.Anything, mock.Anything, mock.Anything,
+		mock.Anything, mock.Anything)
+}
+
+func (suite *TestSuite) TestUpdateIntersectionStatus_Success() {
+	ctx := context.Background()
+	updated := createTestIntersection(model.Failed)
+
+	suite.repo.On("UpdateIntersectionStatus", ctx, testIntersectionID, model.Failed,
+		"simulation timed out").
+		Return(updated, nil)
+
+	result, err := suite.service.UpdateIntersectionStatus(ctx, testIntersectionID, model.Failed,
+		" simulation timed out ")
+
+	suite.Require().NoError(err)
+	suite.Equal(updated, result)
+	suite.repo.AssertNotCalled(suite.T(), "UpdateIntersection", mock.Anything, mock.Anything,
+		mock.Anything, mock.Anything, mock.Anything, mock.Anything, mock.Anything, mock.Anything,
+		mock.Anything, mock.Anything)
+}
+
+func (suite *TestSuite) TestUpdateIntersectionStatus_InvalidStatus() {
+	ctx := context.Background()
+
+	_, err := suite.service.UpdateIntersectionStatus(ctx, testIntersectionID, model.Unspecified, "")
+
+	suite.Require().Error(err)
+	var svcErr *errs.ServiceError
+	suite.Require().True(errors.As(err, &svcErr))
+	suite.Equal(errs.ErrValidation, svcErr.Code)
+	suite.repo.AssertNotCalled(suite.T(), "UpdateIntersectionStatus", mock.Anything,
+		mock.Anything, mock.Anything, mock.Anything)
 }
@@ -0,0 +1,106 @@
+package service
+
+import (
+	"context"
+	"errors"
+	"strings"
+	"time"
+
+	"github.com/COS301-SE-2025/Swift-Signals/intersection-service/internal/model"
+	"github.com/COS301-SE-2025/Swift-Signals/intersection-service/internal/util"
+	errs "github.com/COS301-SE-2025/Swift-Signals/shared/error"
+)
+
+// RestoreIntersection takes an intersection back out of the trash
+func (s *Service) RestoreIntersection(ctx context.Context, id string) (*model.Intersection, error) {
+	logger := util.LoggerFromContext(ctx)
+
+	logger.Debug("validating input")
+	req := RestoreIntersectionRequest{
+		ID: strings.TrimSpace(id),
+	}
+	if err := s.validator.Struct(req); err != nil {
+		return nil, handleValidationError(err)
+	}
+
+	logger.Debug("restoring intersection")
+	intersection, err := s.repo.RestoreIntersection(ctx, req.ID)
+	if err != nil {
+		var svcErr *errs.ServiceError
+		if errors.As(err, &svcErr) {
+			return nil, err
+		}
+		return nil, errs.NewInternalError("failed to restore intersection", err, map[string]any{})
+	}
+	return intersection, nil
+}
+
+// PurgeDeletedIntersections permanently removes every intersection that has been in the
+// trash for longer than the retention period
+func (s *Service) PurgeDeletedIntersections(
+	ctx context.Context,
+	retention time.Duration,
+) ([]string, error) {
+	logger := util.LoggerFromContext(ctx)
+
+	logger.Debug("validating input")
+	req := PurgeDeletedIntersectionsRequest{
+		Retention: retention,
+	}
+	if err := s.validator.Struct(req); err != nil {
+		return nil, handleValidationError(err)
+	}
+
+	logger.Debug("purging deleted intersections")
+	ids, err := s.repo.PurgeDeletedIntersections(ctx, time.Now().Add(-req.Retention))
+	if err != nil {
+		var svcErr *errs.ServiceError
+		if errors.As(err, &svcErr) {
+			return nil, err
+		}
+		return nil, errs.NewInternalError(
+			"failed to purge deleted intersections",
+			err,
+			map[string]any{},
+		)
+	}
+	return ids, nil
+}
+
+// RunTrashPurger purges deleted intersections once straight away and then on every
+// interval until ctx is cancelled
+func RunTrashPurger(
+	ctx context.Context,
+	svc IntersectionService,
+	retention, interval time.Duration,
+) {
+	logger := util.LoggerFromContext(ctx).With("component", "trash-purger")
+
+	purge := func() {
+		ids, err := svc.PurgeDeletedIntersections(ctx, retention)
+		if err != nil {
+			logger.Error("failed to purge deleted intersections",
+				"error", err.Error(),
+			)
+			return
+		}
+		if len(ids) > 0 {
+			logger.Info("purged deleted intersections",
+				"intersection_ids", ids,
+			)
+		}
+	}
+
+	purge()
+
+	ticker := time.NewTicker(interval)
+	defer ticker.Stop()
+	for {
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+			purge()
+		}
+	}
+}
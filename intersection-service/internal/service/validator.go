@@ -38,6 +38,8 @@ func getValidationErrorMessage(fe validator.FieldError) string {
 		return fe.Field() + " must be at most " + fe.Param() + " characters long"
 	case "uuid4":
 		return fe.Field() + " must be a valid UUID"
+	case "oneof":
+		return fe.Field() + " must be one of " + fe.Param()
 	default:
 		return fe.Field() + " is invalid"
 	}
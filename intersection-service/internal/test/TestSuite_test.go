@@ -51,7 +51,7 @@ func (suite *IntegrationTestSuite) SetupSuite() {
 
 	collection := client.Database("IntersectionService").Collection("Intersections")
 	suite.repo = db.NewMongoIntersectionRepo(collection)
-	suite.service = service.NewIntersectionService(suite.repo)
+	suite.service = service.NewIntersectionService(suite.repo, service.ObjectiveWaitingTime)
 	suite.handler = handler.NewIntersectionHandler(suite.service)
 
 	suite.bufListener = bufconn.Listen(1024 * 1024)
@@ -76,9 +76,17 @@ func (suite *IntegrationTestSuite) SetupSuite() {
 }
 
 func (suite *IntegrationTestSuite) SetupTest() {
-	err := suite.mongoClient.Database("IntersectionService").
-		Collection("Intersections").
-		Drop(suite.ctx)
+	for _, name := range []string{"Intersections", "OptimisationJobs", "Runs", "Sweeps"} {
+		err := suite.mongoClient.Database("IntersectionService").
+			Collection(name).
+			Drop(suite.ctx)
+		suite.Require().NoError(err)
+	}
+
+	err := db.CreateJobIndexes(
+		suite.ctx,
+		suite.mongoClient.Database("IntersectionService").Collection("Intersections"),
+	)
 	suite.Require().NoError(err)
 }
 
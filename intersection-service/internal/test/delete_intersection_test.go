@@ -5,6 +5,8 @@ import (
 	"time"
 
 	intersectionpb "github.com/COS301-SE-2025/Swift-Signals/protos/gen/swiftsignals/intersection/v1"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
 )
 
 func (suite *IntegrationTestSuite) TestDeleteIntersection() {
@@ -20,7 +22,7 @@ func (suite *IntegrationTestSuite) TestDeleteIntersection() {
 		suite.Require().Error(err)
 	}
 
-	req := &intersectionpb.IntersectionIDRequest{
+	req := &intersectionpb.DeleteIntersectionRequest{
 		Id: intersection.GetId(),
 	}
 
@@ -41,10 +43,38 @@ func (suite *IntegrationTestSuite) TestDeleteIntersection_Failure() {
 	_, err := suite.client.CreateIntersection(ctx, createReq)
 	suite.Require().NoError(err)
 
-	req := &intersectionpb.IntersectionIDRequest{}
+	req := &intersectionpb.DeleteIntersectionRequest{}
 
 	resp, err := suite.client.DeleteIntersection(ctx, req)
 
 	suite.Require().Error(err)
 	suite.Require().Nil(resp)
 }
+
+func (suite *IntegrationTestSuite) TestDeleteIntersection_NotExpectedVersion() {
+	ctx, cancel := context.WithTimeout(suite.ctx, 30*time.Second)
+	defer cancel()
+
+	intersection, err := suite.client.CreateIntersection(ctx, &intersectionpb.CreateIntersectionRequest{
+		Name: "Test Intersection",
+	})
+	suite.Require().NoError(err)
+
+	_, err = suite.client.DeleteIntersection(ctx, &intersectionpb.DeleteIntersectionRequest{
+		Id:              intersection.GetId(),
+		ExpectedVersion: intersection.GetVersion() + 1,
+	})
+	suite.Require().Error(err)
+	suite.Equal(codes.Aborted, status.Code(err))
+
+	_, err = suite.client.GetIntersection(ctx, &intersectionpb.IntersectionIDRequest{
+		Id: intersection.GetId(),
+	})
+	suite.Require().NoError(err)
+
+	_, err = suite.client.DeleteIntersection(ctx, &intersectionpb.DeleteIntersectionRequest{
+		Id:              intersection.GetId(),
+		ExpectedVersion: intersection.GetVersion(),
+	})
+	suite.Require().NoError(err)
+}
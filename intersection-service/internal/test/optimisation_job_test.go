@@ -0,0 +1,177 @@
+package test
+
+import (
+	"context"
+	"io"
+	"sync"
+	"time"
+
+	commonpb "github.com/COS301-SE-2025/Swift-Signals/protos/gen/swiftsignals/common/v1"
+	intersectionpb "github.com/COS301-SE-2025/Swift-Signals/protos/gen/swiftsignals/intersection/v1"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+func (suite *IntegrationTestSuite) createJobIntersection(ctx context.Context) string {
+	createReq := &intersectionpb.CreateIntersectionRequest{
+		Name:           "Test Intersection",
+		TrafficDensity: commonpb.TrafficDensity_TRAFFIC_DENSITY_HIGH,
+	}
+
+	intersection, err := suite.client.CreateIntersection(ctx, createReq)
+	suite.Require().NoError(err)
+	return intersection.GetId()
+}
+
+func (suite *IntegrationTestSuite) TestOptimisationJobLifecycle() {
+	ctx, cancel := context.WithTimeout(suite.ctx, 30*time.Second)
+	defer cancel()
+
+	intersectionID := suite.createJobIntersection(ctx)
+
+	job, err := suite.client.CreateOptimisationJob(ctx, &intersectionpb.CreateOptimisationJobRequest{
+		IntersectionId: intersectionID,
+		UserId:         "test-user-id",
+		PreviousStatus: commonpb.IntersectionStatus_INTERSECTION_STATUS_UNOPTIMISED,
+	})
+	suite.Require().NoError(err)
+	suite.Equal(intersectionpb.OptimisationJobStatus_OPTIMISATION_JOB_STATUS_PENDING, job.GetStatus())
+	suite.Nil(job.GetStartedAt())
+
+	running, err := suite.client.UpdateOptimisationJob(ctx, &intersectionpb.UpdateOptimisationJobRequest{
+		Id:     job.GetId(),
+		Status: intersectionpb.OptimisationJobStatus_OPTIMISATION_JOB_STATUS_RUNNING,
+	})
+	suite.Require().NoError(err)
+	suite.NotNil(running.GetStartedAt())
+
+	done, err := suite.client.UpdateOptimisationJob(ctx, &intersectionpb.UpdateOptimisationJobRequest{
+		Id:       job.GetId(),
+		Status:   intersectionpb.OptimisationJobStatus_OPTIMISATION_JOB_STATUS_SUCCEEDED,
+		Improved: true,
+	})
+	suite.Require().NoError(err)
+	suite.True(done.GetImproved())
+	suite.NotNil(done.GetFinishedAt())
+
+	fetched, err := suite.client.GetOptimisationJob(ctx, &intersectionpb.OptimisationJobIDRequest{
+		Id: job.GetId(),
+	})
+	suite.Require().NoError(err)
+	suite.Equal(
+		intersectionpb.OptimisationJobStatus_OPTIMISATION_JOB_STATUS_SUCCEEDED,
+		fetched.GetStatus(),
+	)
+	suite.Equal(
+		commonpb.IntersectionStatus_INTERSECTION_STATUS_UNOPTIMISED,
+		fetched.GetPreviousStatus(),
+	)
+
+	_, err = suite.client.UpdateOptimisationJob(ctx, &intersectionpb.UpdateOptimisationJobRequest{
+		Id:     job.GetId(),
+		Status: intersectionpb.OptimisationJobStatus_OPTIMISATION_JOB_STATUS_CANCELLED,
+	})
+	suite.Require().Error(err)
+	suite.Equal(codes.FailedPrecondition, status.Code(err))
+}
+
+func (suite *IntegrationTestSuite) TestCreateOptimisationJob_AlreadyActive() {
+	ctx, cancel := context.WithTimeout(suite.ctx, 30*time.Second)
+	defer cancel()
+
+	intersectionID := suite.createJobIntersection(ctx)
+
+	req := &intersectionpb.CreateOptimisationJobRequest{
+		IntersectionId: intersectionID,
+		UserId:         "test-user-id",
+	}
+
+	_, err := suite.client.CreateOptimisationJob(ctx, req)
+	suite.Require().NoError(err)
+
+	resp, err := suite.client.CreateOptimisationJob(ctx, req)
+	suite.Require().Error(err)
+	suite.Nil(resp)
+	suite.Equal(codes.AlreadyExists, status.Code(err))
+}
+
+func (suite *IntegrationTestSuite) TestCreateOptimisationJob_ConcurrentRequests() {
+	ctx, cancel := context.WithTimeout(suite.ctx, 30*time.Second)
+	defer cancel()
+
+	intersectionID := suite.createJobIntersection(ctx)
+
+	req := &intersectionpb.CreateOptimisationJobRequest{
+		IntersectionId: intersectionID,
+		UserId:         "test-user-id",
+	}
+
+	// NOTE: Requests that all find no active job must still start only one between them
+	const requests = 8
+	errs := make(chan error, requests)
+	var wg sync.WaitGroup
+	for range requests {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			_, err := suite.client.CreateOptimisationJob(ctx, req)
+			errs <- err
+		}()
+	}
+	wg.Wait()
+	close(errs)
+
+	created := 0
+	for err := range errs {
+		if err == nil {
+			created++
+			continue
+		}
+		suite.Equal(codes.AlreadyExists, status.Code(err))
+	}
+	suite.Equal(1, created)
+}
+
+func (suite *IntegrationTestSuite) TestGetOptimisationJobs() {
+	ctx, cancel := context.WithTimeout(suite.ctx, 30*time.Second)
+	defer cancel()
+
+	intersectionID := suite.createJobIntersection(ctx)
+
+	job, err := suite.client.CreateOptimisationJob(ctx, &intersectionpb.CreateOptimisationJobRequest{
+		IntersectionId: intersectionID,
+		UserId:         "test-user-id",
+	})
+	suite.Require().NoError(err)
+
+	stream, err := suite.client.GetOptimisationJobs(ctx, &intersectionpb.GetOptimisationJobsRequest{
+		Statuses: []intersectionpb.OptimisationJobStatus{
+			intersectionpb.OptimisationJobStatus_OPTIMISATION_JOB_STATUS_PENDING,
+		},
+	})
+	suite.Require().NoError(err)
+
+	var ids []string
+	for {
+		resp, err := stream.Recv()
+		if err == io.EOF {
+			break
+		}
+		suite.Require().NoError(err)
+		ids = append(ids, resp.GetId())
+	}
+	suite.Equal([]string{job.GetId()}, ids)
+}
+
+func (suite *IntegrationTestSuite) TestGetOptimisationJob_NotFound() {
+	ctx, cancel := context.WithTimeout(suite.ctx, 30*time.Second)
+	defer cancel()
+
+	resp, err := suite.client.GetOptimisationJob(ctx, &intersectionpb.OptimisationJobIDRequest{
+		Id: "2c0d5e6a-5d57-4d8e-9a0f-6b3f3f0f2b11",
+	})
+
+	suite.Require().Error(err)
+	suite.Nil(resp)
+	suite.Equal(codes.NotFound, status.Code(err))
+}
@@ -0,0 +1,93 @@
+package test
+
+import (
+	"context"
+	"time"
+
+	commonpb "github.com/COS301-SE-2025/Swift-Signals/protos/gen/swiftsignals/common/v1"
+	intersectionpb "github.com/COS301-SE-2025/Swift-Signals/protos/gen/swiftsignals/intersection/v1"
+	"github.com/COS301-SE-2025/Swift-Signals/shared/events"
+)
+
+func (suite *IntegrationTestSuite) pendingEventTypes(ctx context.Context) []events.Type {
+	pending, err := suite.repo.PendingEvents(ctx, 100)
+	suite.Require().NoError(err)
+
+	types := make([]events.Type, 0, len(pending))
+	for _, event := range pending {
+		types = append(types, event.Type)
+	}
+	return types
+}
+
+func (suite *IntegrationTestSuite) TestOutbox_RecordsEventsWithChanges() {
+	ctx, cancel := context.WithTimeout(suite.ctx, 30*time.Second)
+	defer cancel()
+
+	intersectionID := suite.createJobIntersection(ctx)
+
+	_, err := suite.client.UpdateIntersection(ctx, &intersectionpb.UpdateIntersectionRequest{
+		Id:             intersectionID,
+		Name:           "Renamed Intersection",
+		TrafficDensity: commonpb.TrafficDensity_TRAFFIC_DENSITY_LOW,
+	})
+	suite.Require().NoError(err)
+
+	job, err := suite.client.CreateOptimisationJob(ctx, &intersectionpb.CreateOptimisationJobRequest{
+		IntersectionId: intersectionID,
+		UserId:         "test-user-id",
+		PreviousStatus: commonpb.IntersectionStatus_INTERSECTION_STATUS_UNOPTIMISED,
+	})
+	suite.Require().NoError(err)
+
+	for _, status := range []intersectionpb.OptimisationJobStatus{
+		intersectionpb.OptimisationJobStatus_OPTIMISATION_JOB_STATUS_RUNNING,
+		intersectionpb.OptimisationJobStatus_OPTIMISATION_JOB_STATUS_SUCCEEDED,
+	} {
+		_, err = suite.client.UpdateOptimisationJob(ctx, &intersectionpb.UpdateOptimisationJobRequest{
+			Id:     job.GetId(),
+			Status: status,
+		})
+		suite.Require().NoError(err)
+	}
+
+	suite.Equal([]events.Type{
+		events.IntersectionCreated,
+		events.ParametersUpdated,
+		events.OptimisationStarted,
+		events.OptimisationCompleted,
+	}, suite.pendingEventTypes(ctx))
+}
+
+func (suite *IntegrationTestSuite) TestOutbox_RelayPublishesAndClears() {
+	ctx, cancel := context.WithTimeout(suite.ctx, 30*time.Second)
+	defer cancel()
+
+	intersectionID := suite.createJobIntersection(ctx)
+
+	broker := events.NewInProcessBroker()
+	var published []events.Event
+	broker.Subscribe(events.IntersectionCreated, func(ctx context.Context, event events.Event) error {
+		published = append(published, event)
+		return nil
+	})
+
+	count, err := events.NewRelay(suite.repo, broker, 10).RelayPending(ctx)
+	suite.Require().NoError(err)
+	suite.Equal(1, count)
+
+	suite.Require().Len(published, 1)
+	suite.Equal(intersectionID, published[0].AggregateID)
+	var payload events.IntersectionCreatedPayload
+	suite.Require().NoError(published[0].Decode(&payload))
+	suite.Equal("Test Intersection", payload.Name)
+
+	suite.Empty(suite.pendingEventTypes(ctx))
+
+	// NOTE: The outbox is not part of the intersection as the service sees it
+	intersection, err := suite.client.GetIntersection(ctx, &intersectionpb.IntersectionIDRequest{
+		Id: intersectionID,
+	})
+	suite.Require().NoError(err)
+	suite.Equal("Test Intersection", intersection.GetName())
+}
@@ -0,0 +1,106 @@
+package test
+
+import (
+	"context"
+	"io"
+	"time"
+
+	commonpb "github.com/COS301-SE-2025/Swift-Signals/protos/gen/swiftsignals/common/v1"
+	intersectionpb "github.com/COS301-SE-2025/Swift-Signals/protos/gen/swiftsignals/intersection/v1"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+func (suite *IntegrationTestSuite) TestRevertParameterVersion() {
+	ctx, cancel := context.WithTimeout(suite.ctx, 30*time.Second)
+	defer cancel()
+
+	intersection, err := suite.client.CreateIntersection(ctx, &intersectionpb.CreateIntersectionRequest{
+		Name:           "Test Intersection",
+		TrafficDensity: commonpb.TrafficDensity_TRAFFIC_DENSITY_HIGH,
+		DefaultParameters: &commonpb.OptimisationParameters{
+			OptimisationType: commonpb.OptimisationType_OPTIMISATION_TYPE_GRIDSEARCH,
+			Parameters: &commonpb.SimulationParameters{
+				IntersectionType: commonpb.IntersectionType_INTERSECTION_TYPE_TJUNCTION,
+				Green:            10,
+				Yellow:           3,
+				Red:              7,
+				Speed:            60,
+			},
+		},
+		UserId: "test-user-id",
+	})
+	suite.Require().NoError(err)
+	suite.Equal(int32(1), intersection.GetParameterVersion())
+
+	updated, err := suite.client.UpdateIntersection(ctx, &intersectionpb.UpdateIntersectionRequest{
+		Id:   intersection.GetId(),
+		Name: "Test Intersection",
+		DefaultParameters: &commonpb.OptimisationParameters{
+			OptimisationType: commonpb.OptimisationType_OPTIMISATION_TYPE_GRIDSEARCH,
+			Parameters: &commonpb.SimulationParameters{
+				IntersectionType: commonpb.IntersectionType_INTERSECTION_TYPE_TJUNCTION,
+				Green:            20,
+				Yellow:           3,
+				Red:              7,
+				Speed:            60,
+			},
+		},
+		UserId: "test-user-id",
+	})
+	suite.Require().NoError(err)
+	suite.Equal(int32(2), updated.GetParameterVersion())
+
+	reverted, err := suite.client.RevertParameterVersion(ctx, &intersectionpb.RevertParameterVersionRequest{
+		IntersectionId: intersection.GetId(),
+		Version:        1,
+		UserId:         "test-user-id",
+	})
+	suite.Require().NoError(err)
+	suite.Equal(int32(3), reverted.GetParameterVersion())
+	suite.Equal(int32(10), reverted.GetDefaultParameters().GetParameters().GetGreen())
+
+	stream, err := suite.client.GetParameterVersions(ctx, &intersectionpb.GetParameterVersionsRequest{
+		IntersectionId: intersection.GetId(),
+		Page:           1,
+		PageSize:       10,
+	})
+	suite.Require().NoError(err)
+
+	var versions []*intersectionpb.ParameterVersionResponse
+	for {
+		version, err := stream.Recv()
+		if err == io.EOF {
+			break
+		}
+		suite.Require().NoError(err)
+		versions = append(versions, version)
+	}
+
+	suite.Require().Len(versions, 3)
+	suite.Equal(int32(3), versions[0].GetVersion())
+	suite.Equal(intersectionpb.ParameterChangeReason_PARAMETER_CHANGE_REASON_REVERTED, versions[0].GetReason())
+	suite.Equal(int32(1), versions[0].GetRevertedVersion())
+	suite.Equal(intersectionpb.ParameterChangeReason_PARAMETER_CHANGE_REASON_UPDATED, versions[1].GetReason())
+	suite.Equal(intersectionpb.ParameterChangeReason_PARAMETER_CHANGE_REASON_CREATED, versions[2].GetReason())
+}
+
+func (suite *IntegrationTestSuite) TestRevertParameterVersion_VersionNotFound() {
+	ctx, cancel := context.WithTimeout(suite.ctx, 30*time.Second)
+	defer cancel()
+
+	intersection, err := suite.client.CreateIntersection(ctx, &intersectionpb.CreateIntersectionRequest{
+		Name:           "Test Intersection",
+		TrafficDensity: commonpb.TrafficDensity_TRAFFIC_DENSITY_LOW,
+	})
+	suite.Require().NoError(err)
+
+	_, err = suite.client.RevertParameterVersion(ctx, &intersectionpb.RevertParameterVersionRequest{
+		IntersectionId: intersection.GetId(),
+		Version:        7,
+		UserId:         "test-user-id",
+	})
+
+	suite.Require().Error(err)
+	suite.Equal(codes.NotFound, status.Code(err))
+}
@@ -6,6 +6,7 @@ import (
 
 	commonpb "github.com/COS301-SE-2025/Swift-Signals/protos/gen/swiftsignals/common/v1"
 	intersectionpb "github.com/COS301-SE-2025/Swift-Signals/protos/gen/swiftsignals/intersection/v1"
+	simulationpb "github.com/COS301-SE-2025/Swift-Signals/protos/gen/swiftsignals/simulation/v1"
 )
 
 func (suite *IntegrationTestSuite) TestPutOptimisation() {
@@ -21,13 +22,77 @@ func (suite *IntegrationTestSuite) TestPutOptimisation() {
 	suite.Require().NoError(err)
 
 	req := &intersectionpb.PutOptimisationRequest{
-		Id: intersection.GetId(),
+		Id:      intersection.GetId(),
+		Metrics: &simulationpb.SimulationResultsResponse{AverageWaitingTime: 30},
 	}
 
 	resp, err := suite.client.PutOptimisation(ctx, req)
 
 	suite.Require().NoError(err)
 	suite.Require().NotNil(resp)
+	suite.True(resp.GetImproved())
+}
+
+func (suite *IntegrationTestSuite) TestPutOptimisation_OnlyReplacesBestWhenImproved() {
+	createReq := &intersectionpb.CreateIntersectionRequest{
+		Name:           "Test Intersection",
+		TrafficDensity: commonpb.TrafficDensity_TRAFFIC_DENSITY_HIGH,
+	}
+
+	ctx, cancel := context.WithTimeout(suite.ctx, 30*time.Second)
+	defer cancel()
+
+	intersection, err := suite.client.CreateIntersection(ctx, createReq)
+	suite.Require().NoError(err)
+
+	put := func(green int32, waitingTime float32) bool {
+		resp, err := suite.client.PutOptimisation(ctx, &intersectionpb.PutOptimisationRequest{
+			Id: intersection.GetId(),
+			Parameters: &commonpb.OptimisationParameters{
+				OptimisationType: commonpb.OptimisationType_OPTIMISATION_TYPE_GRIDSEARCH,
+				Parameters:       &commonpb.SimulationParameters{Green: green},
+			},
+			Metrics: &simulationpb.SimulationResultsResponse{AverageWaitingTime: waitingTime},
+		})
+		suite.Require().NoError(err)
+		return resp.GetImproved()
+	}
+
+	suite.True(put(10, 30))
+	suite.False(put(20, 45))
+	suite.True(put(30, 15))
+	suite.False(put(40, 15))
+
+	resp, err := suite.client.GetIntersection(ctx, &intersectionpb.IntersectionIDRequest{
+		Id: intersection.GetId(),
+	})
+	suite.Require().NoError(err)
+	suite.Equal(int32(30), resp.GetBestParameters().GetParameters().GetGreen())
+	suite.Equal(float32(15), resp.GetBestMetrics().GetAverageWaitingTime())
+	suite.Equal(int32(40), resp.GetCurrentParameters().GetParameters().GetGreen())
+	suite.Equal(float32(15), resp.GetCurrentMetrics().GetAverageWaitingTime())
+	suite.Equal(int32(4), resp.GetRunCount())
+	suite.Equal(commonpb.IntersectionStatus_INTERSECTION_STATUS_OPTIMISED, resp.GetStatus())
+}
+
+func (suite *IntegrationTestSuite) TestPutOptimisation_MissingMetrics() {
+	createReq := &intersectionpb.CreateIntersectionRequest{
+		Name:           "Test Intersection",
+		TrafficDensity: commonpb.TrafficDensity_TRAFFIC_DENSITY_HIGH,
+	}
+
+	ctx, cancel := context.WithTimeout(suite.ctx, 30*time.Second)
+	defer cancel()
+
+	intersection, err := suite.client.CreateIntersection(ctx, createReq)
+	suite.Require().NoError(err)
+
+	resp, err := suite.client.PutOptimisation(ctx, &intersectionpb.PutOptimisationRequest{
+		Id: intersection.GetId(),
+	})
+
+	suite.Require().Error(err)
+	suite.Require().Nil(resp)
 }
 
 func (suite *IntegrationTestSuite) TestPutOptimisation_Failure() {
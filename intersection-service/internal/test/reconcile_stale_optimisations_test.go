@@ -0,0 +1,226 @@
+package test
+
+import (
+	"context"
+	"time"
+
+	commonpb "github.com/COS301-SE-2025/Swift-Signals/protos/gen/swiftsignals/common/v1"
+	intersectionpb "github.com/COS301-SE-2025/Swift-Signals/protos/gen/swiftsignals/intersection/v1"
+	simulationpb "github.com/COS301-SE-2025/Swift-Signals/protos/gen/swiftsignals/simulation/v1"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+func (suite *IntegrationTestSuite) TestUpdateIntersection_FailedWithReason() {
+	ctx, cancel := context.WithTimeout(suite.ctx, 30*time.Second)
+	defer cancel()
+
+	intersection, err := suite.client.CreateIntersection(ctx, &intersectionpb.CreateIntersectionRequest{
+		Name:           "Test Intersection",
+		TrafficDensity: commonpb.TrafficDensity_TRAFFIC_DENSITY_HIGH,
+	})
+	suite.Require().NoError(err)
+
+	resp, err := suite.client.UpdateIntersection(ctx, &intersectionpb.UpdateIntersectionRequest{
+		Id:            intersection.GetId(),
+		Name:          intersection.GetName(),
+		Status:        commonpb.IntersectionStatus_INTERSECTION_STATUS_FAILED,
+		FailureReason: "optimiser crashed",
+	})
+
+	suite.Require().NoError(err)
+	suite.Equal(commonpb.IntersectionStatus_INTERSECTION_STATUS_FAILED, resp.GetStatus())
+	suite.Equal("optimiser crashed", resp.GetFailureReason())
+	suite.NotNil(resp.GetFailedAt())
+}
+
+func (suite *IntegrationTestSuite) TestReconcileStaleOptimisations() {
+	ctx, cancel := context.WithTimeout(suite.ctx, 30*time.Second)
+	defer cancel()
+
+	stuck, err := suite.client.CreateIntersection(ctx, &intersectionpb.CreateIntersectionRequest{
+		Name:           "Stuck Intersection",
+		TrafficDensity: commonpb.TrafficDensity_TRAFFIC_DENSITY_HIGH,
+	})
+	suite.Require().NoError(err)
+
+	idle, err := suite.client.CreateIntersection(ctx, &intersectionpb.CreateIntersectionRequest{
+		Name:           "Idle Intersection",
+		TrafficDensity: commonpb.TrafficDensity_TRAFFIC_DENSITY_LOW,
+	})
+	suite.Require().NoError(err)
+
+	_, err = suite.client.UpdateIntersection(ctx, &intersectionpb.UpdateIntersectionRequest{
+		Id:     stuck.GetId(),
+		Name:   stuck.GetName(),
+		Status: commonpb.IntersectionStatus_INTERSECTION_STATUS_OPTIMISING,
+	})
+	suite.Require().NoError(err)
+
+	job, err := suite.client.CreateOptimisationJob(ctx, &intersectionpb.CreateOptimisationJobRequest{
+		IntersectionId: stuck.GetId(),
+		UserId:         "test-user-id",
+	})
+	suite.Require().NoError(err)
+
+	// A lease long enough to cover the run leaves the intersection alone
+	ids, err := suite.service.ReconcileStaleOptimisations(ctx, time.Hour)
+	suite.Require().NoError(err)
+	suite.Empty(ids)
+
+	time.Sleep(10 * time.Millisecond)
+	ids, err = suite.service.ReconcileStaleOptimisations(ctx, time.Millisecond)
+	suite.Require().NoError(err)
+	suite.Equal([]string{stuck.GetId()}, ids)
+
+	failed, err := suite.client.GetIntersection(ctx, &intersectionpb.IntersectionIDRequest{
+		Id: stuck.GetId(),
+	})
+	suite.Require().NoError(err)
+	suite.Equal(commonpb.IntersectionStatus_INTERSECTION_STATUS_FAILED, failed.GetStatus())
+	suite.NotEmpty(failed.GetFailureReason())
+	suite.NotNil(failed.GetFailedAt())
+
+	untouched, err := suite.client.GetIntersection(ctx, &intersectionpb.IntersectionIDRequest{
+		Id: idle.GetId(),
+	})
+	suite.Require().NoError(err)
+	suite.Equal(commonpb.IntersectionStatus_INTERSECTION_STATUS_UNOPTIMISED, untouched.GetStatus())
+
+	failedJob, err := suite.client.GetOptimisationJob(ctx, &intersectionpb.OptimisationJobIDRequest{
+		Id: job.GetId(),
+	})
+	suite.Require().NoError(err)
+	suite.Equal(
+		intersectionpb.OptimisationJobStatus_OPTIMISATION_JOB_STATUS_FAILED,
+		failedJob.GetStatus(),
+	)
+}
+
+func (suite *IntegrationTestSuite) TestReconcileStaleOptimisations_JobNeverStarted() {
+	ctx, cancel := context.WithTimeout(suite.ctx, 30*time.Second)
+	defer cancel()
+
+	intersectionID := suite.createJobIntersection(ctx)
+	req := &intersectionpb.CreateOptimisationJobRequest{
+		IntersectionId: intersectionID,
+		UserId:         "test-user-id",
+	}
+
+	// NOTE: The gateway went away before it moved the intersection to optimising, so only
+	// the job's own lease can free the intersection
+	job, err := suite.client.CreateOptimisationJob(ctx, req)
+	suite.Require().NoError(err)
+
+	time.Sleep(10 * time.Millisecond)
+	ids, err := suite.service.ReconcileStaleOptimisations(ctx, time.Millisecond)
+	suite.Require().NoError(err)
+	suite.Empty(ids)
+
+	failedJob, err := suite.client.GetOptimisationJob(ctx, &intersectionpb.OptimisationJobIDRequest{
+		Id: job.GetId(),
+	})
+	suite.Require().NoError(err)
+	suite.Equal(
+		intersectionpb.OptimisationJobStatus_OPTIMISATION_JOB_STATUS_FAILED,
+		failedJob.GetStatus(),
+	)
+
+	_, err = suite.client.CreateOptimisationJob(ctx, req)
+	suite.Require().NoError(err)
+}
+
+func (suite *IntegrationTestSuite) TestReconcileStaleOptimisations_RenewedLease() {
+	ctx, cancel := context.WithTimeout(suite.ctx, 30*time.Second)
+	defer cancel()
+
+	intersection, err := suite.client.CreateIntersection(ctx, &intersectionpb.CreateIntersectionRequest{
+		Name:           "Long Running Intersection",
+		TrafficDensity: commonpb.TrafficDensity_TRAFFIC_DENSITY_HIGH,
+	})
+	suite.Require().NoError(err)
+
+	job, err := suite.client.CreateOptimisationJob(ctx, &intersectionpb.CreateOptimisationJobRequest{
+		IntersectionId: intersection.GetId(),
+		UserId:         "test-user-id",
+	})
+	suite.Require().NoError(err)
+
+	_, err = suite.client.UpdateIntersection(ctx, &intersectionpb.UpdateIntersectionRequest{
+		Id:     intersection.GetId(),
+		Name:   intersection.GetName(),
+		Status: commonpb.IntersectionStatus_INTERSECTION_STATUS_OPTIMISING,
+	})
+	suite.Require().NoError(err)
+
+	// NOTE: The intersection has been optimising for longer than the lease, but its job
+	// renewed the lease since
+	time.Sleep(20 * time.Millisecond)
+	renewed, err := suite.client.RenewOptimisationLease(ctx, &intersectionpb.OptimisationJobIDRequest{
+		Id: job.GetId(),
+	})
+	suite.Require().NoError(err)
+	suite.NotNil(renewed.GetHeartbeatAt())
+
+	ids, err := suite.service.ReconcileStaleOptimisations(ctx, 10*time.Millisecond)
+	suite.Require().NoError(err)
+	suite.Empty(ids)
+
+	time.Sleep(20 * time.Millisecond)
+	ids, err = suite.service.ReconcileStaleOptimisations(ctx, 10*time.Millisecond)
+	suite.Require().NoError(err)
+	suite.Equal([]string{intersection.GetId()}, ids)
+
+	_, err = suite.client.RenewOptimisationLease(ctx, &intersectionpb.OptimisationJobIDRequest{
+		Id: job.GetId(),
+	})
+	suite.Require().Error(err)
+	suite.Equal(codes.FailedPrecondition, status.Code(err))
+}
+
+func (suite *IntegrationTestSuite) TestReconcileStaleOptimisations_RejectsLateResults() {
+	ctx, cancel := context.WithTimeout(suite.ctx, 30*time.Second)
+	defer cancel()
+
+	intersection, err := suite.client.CreateIntersection(ctx, &intersectionpb.CreateIntersectionRequest{
+		Name:           "Abandoned Intersection",
+		TrafficDensity: commonpb.TrafficDensity_TRAFFIC_DENSITY_HIGH,
+	})
+	suite.Require().NoError(err)
+
+	job, err := suite.client.CreateOptimisationJob(ctx, &intersectionpb.CreateOptimisationJobRequest{
+		IntersectionId: intersection.GetId(),
+		UserId:         "test-user-id",
+	})
+	suite.Require().NoError(err)
+
+	_, err = suite.client.UpdateIntersection(ctx, &intersectionpb.UpdateIntersectionRequest{
+		Id:     intersection.GetId(),
+		Name:   intersection.GetName(),
+		Status: commonpb.IntersectionStatus_INTERSECTION_STATUS_OPTIMISING,
+	})
+	suite.Require().NoError(err)
+
+	time.Sleep(10 * time.Millisecond)
+	ids, err := suite.service.ReconcileStaleOptimisations(ctx, time.Millisecond)
+	suite.Require().NoError(err)
+	suite.Equal([]string{intersection.GetId()}, ids)
+
+	// The worker that ran the job only finishes after its lease expired
+	_, err = suite.client.PutOptimisation(ctx, &intersectionpb.PutOptimisationRequest{
+		Id:         intersection.GetId(),
+		Parameters: &commonpb.OptimisationParameters{},
+		Metrics:    &simulationpb.SimulationResultsResponse{AverageWaitingTime: 10},
+		UserId:     "test-user-id",
+		JobId:      job.GetId(),
+	})
+	suite.Require().Error(err)
+	suite.Equal(codes.FailedPrecondition, status.Code(err))
+
+	failed, err := suite.client.GetIntersection(ctx, &intersectionpb.IntersectionIDRequest{
+		Id: intersection.GetId(),
+	})
+	suite.Require().NoError(err)
+	suite.Equal(commonpb.IntersectionStatus_INTERSECTION_STATUS_FAILED, failed.GetStatus())
+	suite.Nil(failed.GetCurrentMetrics())
+}
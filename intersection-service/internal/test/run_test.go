@@ -0,0 +1,156 @@
+package test
+
+import (
+	"context"
+	"time"
+
+	commonpb "github.com/COS301-SE-2025/Swift-Signals/protos/gen/swiftsignals/common/v1"
+	intersectionpb "github.com/COS301-SE-2025/Swift-Signals/protos/gen/swiftsignals/intersection/v1"
+	simulationpb "github.com/COS301-SE-2025/Swift-Signals/protos/gen/swiftsignals/simulation/v1"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+	"google.golang.org/protobuf/types/known/durationpb"
+)
+
+func (suite *IntegrationTestSuite) TestCreateRun() {
+	ctx, cancel := context.WithTimeout(suite.ctx, 30*time.Second)
+	defer cancel()
+
+	intersection, err := suite.client.CreateIntersection(ctx, &intersectionpb.CreateIntersectionRequest{
+		Name:           "Test Intersection",
+		TrafficDensity: commonpb.TrafficDensity_TRAFFIC_DENSITY_HIGH,
+	})
+	suite.Require().NoError(err)
+
+	resp, err := suite.client.CreateRun(ctx, &intersectionpb.CreateRunRequest{
+		IntersectionId: intersection.GetId(),
+		UserId:         "test-user-id",
+		Type:           intersectionpb.RunType_RUN_TYPE_OPTIMISATION,
+		Parameters: &commonpb.OptimisationParameters{
+			OptimisationType: commonpb.OptimisationType_OPTIMISATION_TYPE_GRIDSEARCH,
+			Parameters:       &commonpb.SimulationParameters{Green: 12},
+		},
+		Metrics:  &simulationpb.SimulationResultsResponse{AverageWaitingTime: 30},
+		Duration: durationpb.New(90 * time.Second),
+		Outcome:  intersectionpb.RunOutcome_RUN_OUTCOME_SUCCEEDED,
+		Improved: true,
+	})
+
+	suite.Require().NoError(err)
+	suite.NotEmpty(resp.GetId())
+	suite.Equal(intersection.GetId(), resp.GetIntersectionId())
+	suite.Equal("test-user-id", resp.GetUserId())
+	suite.Equal(intersectionpb.RunType_RUN_TYPE_OPTIMISATION, resp.GetType())
+	suite.Equal(
+		commonpb.OptimisationType_OPTIMISATION_TYPE_GRIDSEARCH,
+		resp.GetParameters().GetOptimisationType(),
+	)
+	suite.Equal(float32(30), resp.GetMetrics().GetAverageWaitingTime())
+	suite.Equal(90*time.Second, resp.GetDuration().AsDuration())
+	suite.Equal(intersectionpb.RunOutcome_RUN_OUTCOME_SUCCEEDED, resp.GetOutcome())
+	suite.True(resp.GetImproved())
+
+	got, err := suite.client.GetRun(ctx, &intersectionpb.RunIDRequest{
+		IntersectionId: intersection.GetId(),
+		Id:             resp.GetId(),
+	})
+	suite.Require().NoError(err)
+	suite.Equal(resp.GetId(), got.GetId())
+	suite.Equal(int32(12), got.GetParameters().GetParameters().GetGreen())
+}
+
+func (suite *IntegrationTestSuite) TestCreateRun_IntersectionNotFound() {
+	ctx, cancel := context.WithTimeout(suite.ctx, 30*time.Second)
+	defer cancel()
+
+	_, err := suite.client.CreateRun(ctx, &intersectionpb.CreateRunRequest{
+		IntersectionId: "5b3e8c1e-7d4f-4a8b-9c2d-1e6f3a9b7c5d",
+		UserId:         "test-user-id",
+		Type:           intersectionpb.RunType_RUN_TYPE_SIMULATION,
+		Outcome:        intersectionpb.RunOutcome_RUN_OUTCOME_SUCCEEDED,
+	})
+
+	suite.Require().Error(err)
+	suite.Equal(codes.NotFound, status.Code(err))
+}
+
+func (suite *IntegrationTestSuite) TestGetRun_OtherIntersection() {
+	ctx, cancel := context.WithTimeout(suite.ctx, 30*time.Second)
+	defer cancel()
+
+	first, err := suite.client.CreateIntersection(ctx, &intersectionpb.CreateIntersectionRequest{
+		Name:           "First Intersection",
+		TrafficDensity: commonpb.TrafficDensity_TRAFFIC_DENSITY_HIGH,
+	})
+	suite.Require().NoError(err)
+
+	second, err := suite.client.CreateIntersection(ctx, &intersectionpb.CreateIntersectionRequest{
+		Name:           "Second Intersection",
+		TrafficDensity: commonpb.TrafficDensity_TRAFFIC_DENSITY_LOW,
+	})
+	suite.Require().NoError(err)
+
+	run, err := suite.client.CreateRun(ctx, &intersectionpb.CreateRunRequest{
+		IntersectionId: first.GetId(),
+		UserId:         "test-user-id",
+		Type:           intersectionpb.RunType_RUN_TYPE_SIMULATION,
+		Outcome:        intersectionpb.RunOutcome_RUN_OUTCOME_SUCCEEDED,
+	})
+	suite.Require().NoError(err)
+
+	_, err = suite.client.GetRun(ctx, &intersectionpb.RunIDRequest{
+		IntersectionId: second.GetId(),
+		Id:             run.GetId(),
+	})
+
+	suite.Require().Error(err)
+	suite.Equal(codes.NotFound, status.Code(err))
+}
+
+func (suite *IntegrationTestSuite) TestGetRuns_Paginated() {
+	ctx, cancel := context.WithTimeout(suite.ctx, 30*time.Second)
+	defer cancel()
+
+	intersection, err := suite.client.CreateIntersection(ctx, &intersectionpb.CreateIntersectionRequest{
+		Name:           "Test Intersection",
+		TrafficDensity: commonpb.TrafficDensity_TRAFFIC_DENSITY_HIGH,
+	})
+	suite.Require().NoError(err)
+
+	var ids []string
+	for range 3 {
+		run, err := suite.client.CreateRun(ctx, &intersectionpb.CreateRunRequest{
+			IntersectionId: intersection.GetId(),
+			UserId:         "test-user-id",
+			Type:           intersectionpb.RunType_RUN_TYPE_SIMULATION,
+			Outcome:        intersectionpb.RunOutcome_RUN_OUTCOME_SUCCEEDED,
+		})
+		suite.Require().NoError(err)
+		ids = append(ids, run.GetId())
+		time.Sleep(5 * time.Millisecond)
+	}
+
+	getPage := func(page int32) []string {
+		stream, err := suite.client.GetRuns(ctx, &intersectionpb.GetRunsRequest{
+			IntersectionId: intersection.GetId(),
+			Page:           page,
+			PageSize:       2,
+		})
+		suite.Require().NoError(err)
+
+		var got []string
+		for {
+			run, err := stream.Recv()
+			if err != nil {
+				break
+			}
+			got = append(got, run.GetId())
+		}
+		return got
+	}
+
+	// Newest first
+	suite.Equal([]string{ids[2], ids[1]}, getPage(1))
+	suite.Equal([]string{ids[0]}, getPage(2))
+	suite.Empty(getPage(3))
+}
@@ -0,0 +1,143 @@
+package test
+
+import (
+	"context"
+	"io"
+	"time"
+
+	commonpb "github.com/COS301-SE-2025/Swift-Signals/protos/gen/swiftsignals/common/v1"
+	intersectionpb "github.com/COS301-SE-2025/Swift-Signals/protos/gen/swiftsignals/intersection/v1"
+	simulationpb "github.com/COS301-SE-2025/Swift-Signals/protos/gen/swiftsignals/simulation/v1"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+	"google.golang.org/protobuf/types/known/durationpb"
+)
+
+func (suite *IntegrationTestSuite) TestSweep_Lifecycle() {
+	ctx, cancel := context.WithTimeout(suite.ctx, 30*time.Second)
+	defer cancel()
+
+	intersection, err := suite.client.CreateIntersection(ctx, &intersectionpb.CreateIntersectionRequest{
+		Name:           "Test Intersection",
+		TrafficDensity: commonpb.TrafficDensity_TRAFFIC_DENSITY_HIGH,
+	})
+	suite.Require().NoError(err)
+
+	sweep, err := suite.client.CreateSweep(ctx, &intersectionpb.CreateSweepRequest{
+		IntersectionId: intersection.GetId(),
+		UserId:         "test-user-id",
+		BaseParameters: &commonpb.SimulationParameters{Green: 10, Yellow: 3, Red: 5, Speed: 60},
+		Ranges: []*intersectionpb.SweepRange{
+			{Parameter: "green", Min: 10, Max: 20, Step: 10},
+		},
+		TotalPoints: 2,
+	})
+	suite.Require().NoError(err)
+	suite.NotEmpty(sweep.GetId())
+	suite.Equal(intersectionpb.SweepStatus_SWEEP_STATUS_PENDING, sweep.GetStatus())
+
+	// NOTE: Points can only be added to running sweeps
+	point := &intersectionpb.SweepPoint{
+		Index:      1,
+		Parameters: &commonpb.SimulationParameters{Green: 20, Yellow: 3, Red: 5, Speed: 60},
+		Metrics:    &simulationpb.SimulationResultsResponse{AverageWaitingTime: 25},
+		Duration:   durationpb.New(2 * time.Second),
+	}
+	_, err = suite.client.PutSweepPoint(ctx, &intersectionpb.PutSweepPointRequest{
+		Id:    sweep.GetId(),
+		Point: point,
+	})
+	suite.Require().Error(err)
+	suite.Equal(codes.FailedPrecondition, status.Code(err))
+
+	_, err = suite.client.UpdateSweep(ctx, &intersectionpb.UpdateSweepRequest{
+		Id:     sweep.GetId(),
+		Status: intersectionpb.SweepStatus_SWEEP_STATUS_RUNNING,
+	})
+	suite.Require().NoError(err)
+
+	_, err = suite.client.PutSweepPoint(ctx, &intersectionpb.PutSweepPointRequest{
+		Id:    sweep.GetId(),
+		Point: point,
+	})
+	suite.Require().NoError(err)
+
+	// NOTE: The same point cannot be added twice
+	_, err = suite.client.PutSweepPoint(ctx, &intersectionpb.PutSweepPointRequest{
+		Id:    sweep.GetId(),
+		Point: point,
+	})
+	suite.Require().Error(err)
+
+	got, err := suite.client.GetSweep(ctx, &intersectionpb.SweepIDRequest{
+		IntersectionId: intersection.GetId(),
+		Id:             sweep.GetId(),
+	})
+	suite.Require().NoError(err)
+	suite.Equal(intersectionpb.SweepStatus_SWEEP_STATUS_RUNNING, got.GetStatus())
+	suite.NotNil(got.GetStartedAt())
+	suite.Require().Len(got.GetPoints(), 1)
+	suite.Equal(int32(1), got.GetPoints()[0].GetIndex())
+	suite.Equal(float32(25), got.GetPoints()[0].GetMetrics().GetAverageWaitingTime())
+
+	finished, err := suite.client.UpdateSweep(ctx, &intersectionpb.UpdateSweepRequest{
+		Id:     sweep.GetId(),
+		Status: intersectionpb.SweepStatus_SWEEP_STATUS_SUCCEEDED,
+	})
+	suite.Require().NoError(err)
+	suite.NotNil(finished.GetFinishedAt())
+
+	stream, err := suite.client.GetSweeps(ctx, &intersectionpb.GetSweepsRequest{
+		IntersectionId: intersection.GetId(),
+		Statuses:       []intersectionpb.SweepStatus{intersectionpb.SweepStatus_SWEEP_STATUS_SUCCEEDED},
+	})
+	suite.Require().NoError(err)
+	var sweeps []*intersectionpb.SweepResponse
+	for {
+		resp, err := stream.Recv()
+		if err == io.EOF {
+			break
+		}
+		suite.Require().NoError(err)
+		sweeps = append(sweeps, resp)
+	}
+	suite.Require().Len(sweeps, 1)
+	suite.Empty(sweeps[0].GetPoints())
+	suite.Equal(int32(1), sweeps[0].GetCompletedPoints())
+	suite.Equal(int32(0), sweeps[0].GetFailedPoints())
+}
+
+func (suite *IntegrationTestSuite) TestGetSweep_OtherIntersection() {
+	ctx, cancel := context.WithTimeout(suite.ctx, 30*time.Second)
+	defer cancel()
+
+	first, err := suite.client.CreateIntersection(ctx, &intersectionpb.CreateIntersectionRequest{
+		Name:           "First Intersection",
+		TrafficDensity: commonpb.TrafficDensity_TRAFFIC_DENSITY_HIGH,
+	})
+	suite.Require().NoError(err)
+
+	second, err := suite.client.CreateIntersection(ctx, &intersectionpb.CreateIntersectionRequest{
+		Name:           "Second Intersection",
+		TrafficDensity: commonpb.TrafficDensity_TRAFFIC_DENSITY_LOW,
+	})
+	suite.Require().NoError(err)
+
+	sweep, err := suite.client.CreateSweep(ctx, &intersectionpb.CreateSweepRequest{
+		IntersectionId: first.GetId(),
+		UserId:         "test-user-id",
+		Ranges: []*intersectionpb.SweepRange{
+			{Parameter: "red", Min: 5, Max: 30, Step: 5},
+		},
+		TotalPoints: 6,
+	})
+	suite.Require().NoError(err)
+
+	_, err = suite.client.GetSweep(ctx, &intersectionpb.SweepIDRequest{
+		IntersectionId: second.GetId(),
+		Id:             sweep.GetId(),
+	})
+
+	suite.Require().Error(err)
+	suite.Equal(codes.NotFound, status.Code(err))
+}
@@ -0,0 +1,91 @@
+package test
+
+import (
+	"context"
+	"io"
+	"time"
+
+	commonpb "github.com/COS301-SE-2025/Swift-Signals/protos/gen/swiftsignals/common/v1"
+	intersectionpb "github.com/COS301-SE-2025/Swift-Signals/protos/gen/swiftsignals/intersection/v1"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+func (suite *IntegrationTestSuite) TestDeleteIntersection_MovesToTrash() {
+	ctx, cancel := context.WithTimeout(suite.ctx, 30*time.Second)
+	defer cancel()
+
+	intersection, err := suite.client.CreateIntersection(ctx, &intersectionpb.CreateIntersectionRequest{
+		Name:           "Test Intersection",
+		TrafficDensity: commonpb.TrafficDensity_TRAFFIC_DENSITY_HIGH,
+	})
+	suite.Require().NoError(err)
+
+	_, err = suite.client.DeleteIntersection(ctx, &intersectionpb.DeleteIntersectionRequest{
+		Id: intersection.GetId(),
+	})
+	suite.Require().NoError(err)
+
+	_, err = suite.client.GetIntersection(ctx, &intersectionpb.IntersectionIDRequest{
+		Id: intersection.GetId(),
+	})
+	suite.Require().Error(err)
+	suite.Equal(codes.NotFound, status.Code(err))
+
+	suite.Empty(suite.listIntersections(ctx, intersection.GetId(), false))
+	trashed := suite.listIntersections(ctx, intersection.GetId(), true)
+	suite.Require().Len(trashed, 1)
+	suite.NotNil(trashed[0].GetDeletedAt())
+
+	restored, err := suite.client.RestoreIntersection(ctx, &intersectionpb.IntersectionIDRequest{
+		Id: intersection.GetId(),
+	})
+	suite.Require().NoError(err)
+	suite.Nil(restored.GetDeletedAt())
+
+	suite.Len(suite.listIntersections(ctx, intersection.GetId(), false), 1)
+	suite.Empty(suite.listIntersections(ctx, intersection.GetId(), true))
+}
+
+func (suite *IntegrationTestSuite) TestRestoreIntersection_NotInTrash() {
+	ctx, cancel := context.WithTimeout(suite.ctx, 30*time.Second)
+	defer cancel()
+
+	intersection, err := suite.client.CreateIntersection(ctx, &intersectionpb.CreateIntersectionRequest{
+		Name:           "Test Intersection",
+		TrafficDensity: commonpb.TrafficDensity_TRAFFIC_DENSITY_LOW,
+	})
+	suite.Require().NoError(err)
+
+	_, err = suite.client.RestoreIntersection(ctx, &intersectionpb.IntersectionIDRequest{
+		Id: intersection.GetId(),
+	})
+
+	suite.Require().Error(err)
+	suite.Equal(codes.NotFound, status.Code(err))
+}
+
+func (suite *IntegrationTestSuite) listIntersections(
+	ctx context.Context,
+	id string,
+	deleted bool,
+) []*intersectionpb.IntersectionResponse {
+	stream, err := suite.client.GetAllIntersections(ctx, &intersectionpb.GetAllIntersectionsRequest{
+		Page:     1,
+		PageSize: 10,
+		Filter:   id,
+		Deleted:  deleted,
+	})
+	suite.Require().NoError(err)
+
+	var intersections []*intersectionpb.IntersectionResponse
+	for {
+		intersection, err := stream.Recv()
+		if err == io.EOF {
+			break
+		}
+		suite.Require().NoError(err)
+		intersections = append(intersections, intersection)
+	}
+	return intersections
+}
@@ -6,6 +6,8 @@ import (
 
 	commonpb "github.com/COS301-SE-2025/Swift-Signals/protos/gen/swiftsignals/common/v1"
 	intersectionpb "github.com/COS301-SE-2025/Swift-Signals/protos/gen/swiftsignals/intersection/v1"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
 )
 
 func (suite *IntegrationTestSuite) TestUpdateIntersection() {
@@ -31,6 +33,75 @@ func (suite *IntegrationTestSuite) TestUpdateIntersection() {
 	suite.Require().NotNil(resp)
 }
 
+func (suite *IntegrationTestSuite) TestUpdateIntersection_ExpectedVersion() {
+	createReq := &intersectionpb.CreateIntersectionRequest{
+		Name:           "Test Intersection",
+		TrafficDensity: commonpb.TrafficDensity_TRAFFIC_DENSITY_HIGH,
+	}
+
+	ctx, cancel := context.WithTimeout(suite.ctx, 30*time.Second)
+	defer cancel()
+
+	intersection, err := suite.client.CreateIntersection(ctx, createReq)
+	suite.Require().NoError(err)
+	suite.Equal(int32(1), intersection.GetVersion())
+
+	resp, err := suite.client.UpdateIntersection(ctx, &intersectionpb.UpdateIntersectionRequest{
+		Id:              intersection.GetId(),
+		Name:            "First Edit",
+		ExpectedVersion: intersection.GetVersion(),
+	})
+	suite.Require().NoError(err)
+	suite.Equal(int32(2), resp.GetVersion())
+
+	// NOTE: A second edit made against the version both editors read must not overwrite
+	// the first
+	_, err = suite.client.UpdateIntersection(ctx, &intersectionpb.UpdateIntersectionRequest{
+		Id:              intersection.GetId(),
+		Name:            "Second Edit",
+		ExpectedVersion: intersection.GetVersion(),
+	})
+	suite.Require().Error(err)
+	suite.Equal(codes.Aborted, status.Code(err))
+
+	got, err := suite.client.GetIntersection(ctx, &intersectionpb.IntersectionIDRequest{
+		Id: intersection.GetId(),
+	})
+	suite.Require().NoError(err)
+	suite.Equal("First Edit", got.GetName())
+	suite.Equal(int32(2), got.GetVersion())
+}
+
+func (suite *IntegrationTestSuite) TestUpdateIntersection_StatusKeepsRename() {
+	createReq := &intersectionpb.CreateIntersectionRequest{
+		Name:           "Test Intersection",
+		TrafficDensity: commonpb.TrafficDensity_TRAFFIC_DENSITY_HIGH,
+	}
+
+	ctx, cancel := context.WithTimeout(suite.ctx, 30*time.Second)
+	defer cancel()
+
+	intersection, err := suite.client.CreateIntersection(ctx, createReq)
+	suite.Require().NoError(err)
+
+	_, err = suite.client.UpdateIntersection(ctx, &intersectionpb.UpdateIntersectionRequest{
+		Id:   intersection.GetId(),
+		Name: "Renamed",
+	})
+	suite.Require().NoError(err)
+
+	// NOTE: A job that read the intersection before the rename only sends its new status
+	resp, err := suite.client.UpdateIntersection(ctx, &intersectionpb.UpdateIntersectionRequest{
+		Id:            intersection.GetId(),
+		Status:        commonpb.IntersectionStatus_INTERSECTION_STATUS_FAILED,
+		FailureReason: "simulation timed out",
+	})
+	suite.Require().NoError(err)
+	suite.Equal("Renamed", resp.GetName())
+	suite.Equal(commonpb.IntersectionStatus_INTERSECTION_STATUS_FAILED, resp.GetStatus())
+	suite.Equal("simulation timed out", resp.GetFailureReason())
+}
+
 func (suite *IntegrationTestSuite) TestUpdateIntersection_Failure_Min() {
 	createReq := &intersectionpb.CreateIntersectionRequest{
 		Name:           "Test Intersection",
@@ -47,7 +47,7 @@ def custom_mutate(individual, indpb=0.2, min_speed=40):
 toolbox.register("mutate", custom_mutate)
 
 
-def run_ga(pop, hof, ngen, cxpb, mutpb, evaluate_func, label="GA"):
+def run_ga(pop, hof, ngen, cxpb, mutpb, evaluate_func, label="GA", on_generation=None):
     """
     The main genetic algorithm loop.
 
@@ -59,6 +59,9 @@ def run_ga(pop, hof, ngen, cxpb, mutpb, evaluate_func, label="GA"):
         mutpb: The probability of mutation.
         evaluate_func: The function to use for evaluating fitness.
         label: A label for the progress bar.
+        on_generation: Optional callback called after every generation with the generation
+            number, the number of generations, the best individual so far and the
+            individuals evaluated during that generation.
     """
     toolbox.register("evaluate", evaluate_func)
 
@@ -98,3 +101,6 @@ def run_ga(pop, hof, ngen, cxpb, mutpb, evaluate_func, label="GA"):
         hof.update(pop)
         record = stats.compile(pop)
         logbook.record(gen=gen, nevals=len(invalid_individuals), **record)
+
+        if on_generation is not None:
+            on_generation(gen, ngen, hof[0], invalid_individuals)
@@ -9,12 +9,16 @@ from ga.evaluation import evaluate_balanced
 from ga.simulation_client import run_simulation
 
 
-def main(traffic_density: int = 2) -> dict:
+def main(traffic_density: int = 2, on_generation=None) -> dict:
     """Main function to run the genetic algorithm for optimising traffic light parameters.
     This function initialises the genetic algorithm, runs it in two phases (minimising waiting time and safety hazards),
     and saves the best parameters found to a JSON file. It also runs a final simulation with the best parameters
     and prints the results.
 
+    Args:
+        traffic_density (int): The traffic density to simulate.
+        on_generation (callable): Optional callback passed on to run_ga to report progress.
+
     Returns:
         dict: A dictionary containing the best parameters found during the optimisation process.
     """
@@ -38,6 +42,7 @@ def main(traffic_density: int = 2) -> dict:
         mutpb,
         lambda ind: evaluate_balanced(ind, traffic_density=traffic_density),
         label="BalancedGA",
+        on_generation=on_generation,
     )
     end_ga = time.time()
 
@@ -39,6 +39,7 @@ def run_simulation(individual_params: list, traffic_density: int = 2) -> dict |
         red=red,
         speed=speed,
         seed=seed,
+        traffic_density=traffic_density,
     )
     request = SimulationRequest(
         intersection_id="TrafficLight",
@@ -1,6 +1,8 @@
 from concurrent import futures
 import os
 import logging
+import queue
+import threading
 from pprint import pformat
 
 import grpc
@@ -34,6 +36,37 @@ def pretty_log(name, obj, max_len=1000):
     logger.debug("%s:\n%s", name, text)
 
 
+class OptimisationCancelled(Exception):
+    """Raised inside the GA to stop it once the client has gone away."""
+
+
+def to_simulation_parameters(individual):
+    """Convert a GA individual [green, yellow, red, speed, seed] to SimulationParameters."""
+    return sim_pb.SimulationParameters(
+        intersection_type=types_pb.IntersectionType.INTERSECTION_TYPE_TRAFFICLIGHT,
+        green=individual[0],
+        yellow=individual[1],
+        red=individual[2],
+        speed=individual[3],
+        seed=individual[4],
+    )
+
+
+def to_optimisation_parameters(result):
+    """Convert the best parameters returned by the GA to OptimisationParameters."""
+    return sim_pb.OptimisationParameters(
+        optimisation_type=types_pb.OptimisationType.OPTIMISATION_TYPE_GENETIC_EVALUATION,
+        parameters=sim_pb.SimulationParameters(
+            intersection_type=types_pb.IntersectionType.INTERSECTION_TYPE_TRAFFICLIGHT,
+            green=result["Green"],
+            yellow=result["Yellow"],
+            red=result["Red"],
+            speed=result["Speed"],
+            seed=result["Seed"],
+        ),
+    )
+
+
 class OptimisationServicer(pb_grpc.OptimisationServiceServicer):
     def RunOptimisation(self, request: sim_pb.OptimisationParameters, context):
         logger.info(
@@ -46,22 +79,73 @@ class OptimisationServicer(pb_grpc.OptimisationServiceServicer):
         result = run_optimisation(traffic_density)
         logger.info("Optimisation completed successfully.")
 
-        # Convert the result to a dictionary
-        response = sim_pb.OptimisationParameters(
-            optimisation_type=types_pb.OptimisationType.OPTIMISATION_TYPE_GENETIC_EVALUATION,
-            parameters=sim_pb.SimulationParameters(
-                intersection_type=types_pb.IntersectionType.INTERSECTION_TYPE_TRAFFICLIGHT,
-                green=result["Green"],
-                yellow=result["Yellow"],
-                red=result["Red"],
-                speed=result["Speed"],
-                seed=result["Seed"],
-            ),
-        )
+        response = to_optimisation_parameters(result)
         pretty_log("Response Parameters", response.parameters)
 
         return response
 
+    def StreamOptimisation(self, request: sim_pb.OptimisationParameters, context):
+        logger.info(
+            f"Received StreamOptimisation request with intersection_type: {request.parameters.intersection_type}",
+        )
+        pretty_log("Request Parameters", request.parameters)
+
+        # The GA runs in its own thread and hands its events to this one through a queue
+        events = queue.Queue()
+        cancelled = threading.Event()
+        context.add_callback(cancelled.set)
+
+        def on_generation(gen, ngen, best, evaluated):
+            if cancelled.is_set():
+                raise OptimisationCancelled()
+            events.put(
+                pb.OptimisationProgress(
+                    generation=gen,
+                    total_generations=ngen,
+                    best_fitness=best.fitness.values[0],
+                    best_parameters=to_simulation_parameters(best),
+                    candidates=[
+                        pb.OptimisationCandidate(
+                            parameters=to_simulation_parameters(ind),
+                            fitness=ind.fitness.values[0],
+                        )
+                        for ind in evaluated
+                    ],
+                )
+            )
+
+        def optimise():
+            try:
+                result = run_optimisation(
+                    request.parameters.traffic_density,
+                    on_generation=on_generation,
+                )
+                events.put(
+                    pb.OptimisationProgress(
+                        best_fitness=result["Fitness"],
+                        result=to_optimisation_parameters(result),
+                    )
+                )
+            except OptimisationCancelled:
+                logger.info("Optimisation cancelled by the client.")
+            except Exception as e:
+                logger.exception("Optimisation failed.")
+                events.put(e)
+            finally:
+                events.put(None)
+
+        threading.Thread(target=optimise, daemon=True).start()
+
+        while True:
+            event = events.get()
+            if event is None:
+                break
+            if isinstance(event, Exception):
+                context.abort(grpc.StatusCode.INTERNAL, f"optimisation failed: {event}")
+            yield event
+
+        logger.info("Optimisation stream completed.")
+
 
 def serve():
     server = grpc.server(futures.ThreadPoolExecutor(max_workers=10))
@@ -40,7 +40,9 @@ const (
 	ErrUnauthorized  ErrorCode = "UNAUTHORIZED"
 	ErrForbidden     ErrorCode = "FORBIDDEN"
 	ErrConflict      ErrorCode = "CONFLICT_ERROR"
+	ErrPrecondition  ErrorCode = "PRECONDITION_FAILED"
 	ErrUnavailable   ErrorCode = "UNAVAILABLE_ERROR"
+	ErrRateLimited   ErrorCode = "RATE_LIMITED"
 	ErrDatabase      ErrorCode = "DB_ERROR"
 	ErrInternal      ErrorCode = "INTERNAL_ERROR"
 	ErrExternal      ErrorCode = "EXTERNAL_ERROR"
@@ -130,6 +132,16 @@ func NewConflictError(message string, context map[string]any) *ServiceError {
 	}
 }
 
+// NewPreconditionError creates a new precondition error, for writes made against a stale
+// version of a resource
+func NewPreconditionError(message string, context map[string]any) *ServiceError {
+	return &ServiceError{
+		Code:    ErrPrecondition,
+		Message: message,
+		Context: context,
+	}
+}
+
 // NewUnavailableError creates a new unavailable error
 func NewUnavailableError(message string, context map[string]any) *ServiceError {
 	return &ServiceError{
@@ -139,6 +151,15 @@ func NewUnavailableError(message string, context map[string]any) *ServiceError {
 	}
 }
 
+// NewRateLimitedError creates a new rate limited error
+func NewRateLimitedError(message string, context map[string]any) *ServiceError {
+	return &ServiceError{
+		Code:    ErrRateLimited,
+		Message: message,
+		Context: context,
+	}
+}
+
 // HandleServiceError converts service errors to appropriate gRPC status errors
 func HandleServiceError(err error) error {
 	if err == nil {
@@ -160,8 +181,12 @@ func HandleServiceError(err error) error {
 			return status.Error(codes.PermissionDenied, svcErr.Message)
 		case ErrConflict:
 			return status.Error(codes.FailedPrecondition, svcErr.Message)
+		case ErrPrecondition:
+			return status.Error(codes.Aborted, svcErr.Message)
 		case ErrUnavailable:
 			return status.Error(codes.Unavailable, svcErr.Message)
+		case ErrRateLimited:
+			return status.Error(codes.ResourceExhausted, svcErr.Message)
 		case ErrDatabase, ErrInternal, ErrExternal:
 			return status.Error(codes.Internal, "internal server error")
 		}
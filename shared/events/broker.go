@@ -0,0 +1,90 @@
+package events
+
+import (
+	"context"
+	"log/slog"
+	"slices"
+	"sync"
+)
+
+// AllEvents subscribes a handler to every type of event
+const AllEvents Type = "*"
+
+type Handler func(ctx context.Context, event Event) error
+
+// Broker delivers published events to their subscribers. Delivery is at least once, so
+// handlers should use the event ID to ignore events they have already seen.
+type Broker interface {
+	Publish(ctx context.Context, event Event) error
+	Subscribe(eventType Type, handler Handler) (unsubscribe func())
+}
+
+type subscription struct {
+	eventType Type
+	handler   Handler
+}
+
+// InProcessBroker delivers events to handlers in the same process, one after another in
+// the order they subscribed. It suits running a service on its own, e.g. locally.
+type InProcessBroker struct {
+	mu            sync.RWMutex
+	subscriptions map[int]subscription
+	nextID        int
+}
+
+func NewInProcessBroker() *InProcessBroker {
+	return &InProcessBroker{subscriptions: map[int]subscription{}}
+}
+
+// Publish hands the event to every subscribed handler. A handler that fails is logged
+// rather than failing the publish, since the event has been delivered to the rest and
+// retrying it would deliver it to them again.
+func (b *InProcessBroker) Publish(ctx context.Context, event Event) error {
+	if err := ctx.Err(); err != nil {
+		return err
+	}
+
+	b.mu.RLock()
+	ids := make([]int, 0, len(b.subscriptions))
+	for id, sub := range b.subscriptions {
+		if sub.eventType == event.Type || sub.eventType == AllEvents {
+			ids = append(ids, id)
+		}
+	}
+	handlers := make([]Handler, 0, len(ids))
+	slices.Sort(ids)
+	for _, id := range ids {
+		handlers = append(handlers, b.subscriptions[id].handler)
+	}
+	b.mu.RUnlock()
+
+	for _, handler := range handlers {
+		if err := handler(ctx, event); err != nil {
+			slog.Default().Error("event handler failed",
+				"event_id", event.ID,
+				"event_type", event.Type,
+				"error", err.Error(),
+			)
+		}
+	}
+	return nil
+}
+
+// Subscribe registers a handler for one type of event, or for all of them with AllEvents
+func (b *InProcessBroker) Subscribe(eventType Type, handler Handler) func() {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+
+	id := b.nextID
+	b.nextID++
+	b.subscriptions[id] = subscription{eventType: eventType, handler: handler}
+
+	return func() {
+		b.mu.Lock()
+		defer b.mu.Unlock()
+		delete(b.subscriptions, id)
+	}
+}
+
+// NOTE: Asserts Interface Implementation
+var _ Broker = (*InProcessBroker)(nil)
@@ -0,0 +1,106 @@
+package events
+
+import (
+	"crypto/rand"
+	"encoding/json"
+	"fmt"
+	"time"
+)
+
+type Type string
+
+const (
+	IntersectionCreated       Type = "intersection.created"
+	IntersectionStatusChanged Type = "intersection.status_changed"
+	IntersectionDeleted       Type = "intersection.deleted"
+	IntersectionPurged        Type = "intersection.purged"
+	ParametersUpdated         Type = "intersection.parameters_updated"
+	OptimisationStarted       Type = "optimisation.started"
+	OptimisationCompleted     Type = "optimisation.completed"
+	OptimisationFailed        Type = "optimisation.failed"
+	UserRegistered            Type = "user.registered"
+	UserDeleted               Type = "user.deleted"
+)
+
+// Event is something that happened to an aggregate (a user, intersection or optimisation
+// job), recorded alongside the change itself and published once it has been committed
+type Event struct {
+	ID          string          `json:"id"`
+	Type        Type            `json:"type"`
+	AggregateID string          `json:"aggregate_id"`
+	Payload     json.RawMessage `json:"payload"`
+	OccurredAt  time.Time       `json:"occurred_at"`
+}
+
+// NewEvent creates an event with a fresh ID and the payload encoded as JSON
+func NewEvent(eventType Type, aggregateID string, payload any) (Event, error) {
+	data, err := json.Marshal(payload)
+	if err != nil {
+		return Event{}, fmt.Errorf("failed to encode %s payload: %w", eventType, err)
+	}
+
+	return Event{
+		ID:          rand.Text(),
+		Type:        eventType,
+		AggregateID: aggregateID,
+		Payload:     data,
+		// NOTE: Truncated so that the time survives a round trip through either database
+		OccurredAt: time.Now().UTC().Truncate(time.Millisecond),
+	}, nil
+}
+
+// Decode decodes the payload into v, which should be the payload type for the event's type
+func (e Event) Decode(v any) error {
+	if err := json.Unmarshal(e.Payload, v); err != nil {
+		return fmt.Errorf("failed to decode %s payload: %w", e.Type, err)
+	}
+	return nil
+}
+
+type UserRegisteredPayload struct {
+	UserID  string `json:"user_id"`
+	Name    string `json:"name"`
+	Email   string `json:"email"`
+	IsAdmin bool   `json:"is_admin"`
+}
+
+type UserDeletedPayload struct {
+	UserID string `json:"user_id"`
+}
+
+type IntersectionCreatedPayload struct {
+	IntersectionID string `json:"intersection_id"`
+	Name           string `json:"name"`
+}
+
+type IntersectionStatusChangedPayload struct {
+	IntersectionID string `json:"intersection_id"`
+	Status         string `json:"status"`
+	FailureReason  string `json:"failure_reason,omitempty"`
+}
+
+type IntersectionDeletedPayload struct {
+	IntersectionID string `json:"intersection_id"`
+}
+
+// IntersectionPurgedPayload is the payload of an intersection removed from the trash for
+// good, after which it cannot be restored
+type IntersectionPurgedPayload struct {
+	IntersectionID string `json:"intersection_id"`
+}
+
+type ParametersUpdatedPayload struct {
+	IntersectionID string `json:"intersection_id"`
+	// Reason is what changed the parameters, e.g. "defaults", "optimisation" or "revert"
+	Reason string `json:"reason"`
+}
+
+// OptimisationPayload is the payload of the optimisation started, completed and failed
+// events
+type OptimisationPayload struct {
+	JobID          string `json:"job_id"`
+	IntersectionID string `json:"intersection_id"`
+	UserID         string `json:"user_id"`
+	Improved       bool   `json:"improved"`
+	Error          string `json:"error,omitempty"`
+}
@@ -0,0 +1,189 @@
+package events
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestNewEvent_RoundTrip(t *testing.T) {
+	event, err := NewEvent(UserDeleted, "user-1", UserDeletedPayload{UserID: "user-1"})
+	assert.NoError(t, err)
+	assert.NotEmpty(t, event.ID)
+	assert.Equal(t, UserDeleted, event.Type)
+	assert.Equal(t, "user-1", event.AggregateID)
+	assert.False(t, event.OccurredAt.IsZero())
+
+	var payload UserDeletedPayload
+	assert.NoError(t, event.Decode(&payload))
+	assert.Equal(t, "user-1", payload.UserID)
+}
+
+func TestNewEvent_UniqueIDs(t *testing.T) {
+	first, err := NewEvent(UserDeleted, "user-1", UserDeletedPayload{})
+	assert.NoError(t, err)
+	second, err := NewEvent(UserDeleted, "user-1", UserDeletedPayload{})
+	assert.NoError(t, err)
+
+	assert.NotEqual(t, first.ID, second.ID)
+}
+
+func TestNewEvent_UnencodablePayload(t *testing.T) {
+	_, err := NewEvent(UserDeleted, "user-1", make(chan int))
+	assert.Error(t, err)
+}
+
+func TestInProcessBroker_DeliversBySubscription(t *testing.T) {
+	broker := NewInProcessBroker()
+
+	var got []string
+	broker.Subscribe(UserDeleted, func(ctx context.Context, event Event) error {
+		got = append(got, "deleted:"+event.AggregateID)
+		return nil
+	})
+	broker.Subscribe(AllEvents, func(ctx context.Context, event Event) error {
+		got = append(got, "all:"+event.AggregateID)
+		return nil
+	})
+
+	deleted, _ := NewEvent(UserDeleted, "user-1", UserDeletedPayload{})
+	registered, _ := NewEvent(UserRegistered, "user-2", UserRegisteredPayload{})
+
+	assert.NoError(t, broker.Publish(context.Background(), deleted))
+	assert.NoError(t, broker.Publish(context.Background(), registered))
+
+	assert.Equal(t, []string{"deleted:user-1", "all:user-1", "all:user-2"}, got)
+}
+
+func TestInProcessBroker_Unsubscribe(t *testing.T) {
+	broker := NewInProcessBroker()
+
+	calls := 0
+	unsubscribe := broker.Subscribe(AllEvents, func(ctx context.Context, event Event) error {
+		calls++
+		return nil
+	})
+
+	event, _ := NewEvent(UserDeleted, "user-1", UserDeletedPayload{})
+	assert.NoError(t, broker.Publish(context.Background(), event))
+	unsubscribe()
+	assert.NoError(t, broker.Publish(context.Background(), event))
+
+	assert.Equal(t, 1, calls)
+}
+
+func TestInProcessBroker_FailingHandlerDoesNotStopOthers(t *testing.T) {
+	broker := NewInProcessBroker()
+
+	delivered := false
+	broker.Subscribe(AllEvents, func(ctx context.Context, event Event) error {
+		return errors.New("handler failed")
+	})
+	broker.Subscribe(AllEvents, func(ctx context.Context, event Event) error {
+		delivered = true
+		return nil
+	})
+
+	event, _ := NewEvent(UserDeleted, "user-1", UserDeletedPayload{})
+	assert.NoError(t, broker.Publish(context.Background(), event))
+	assert.True(t, delivered)
+}
+
+func TestInProcessBroker_CancelledContext(t *testing.T) {
+	broker := NewInProcessBroker()
+	ctx, cancel := context.WithCancel(context.Background())
+	cancel()
+
+	event, _ := NewEvent(UserDeleted, "user-1", UserDeletedPayload{})
+	assert.ErrorIs(t, broker.Publish(ctx, event), context.Canceled)
+}
+
+type fakeOutbox struct {
+	pending []Event
+	marked  []string
+	markErr error
+}
+
+func (o *fakeOutbox) PendingEvents(ctx context.Context, limit int) ([]Event, error) {
+	return o.pending[:min(limit, len(o.pending))], nil
+}
+
+func (o *fakeOutbox) MarkEventsPublished(ctx context.Context, ids []string) error {
+	if o.markErr != nil {
+		return o.markErr
+	}
+	o.marked = append(o.marked, ids...)
+	return nil
+}
+
+type fakeBroker struct {
+	published []string
+	failOn    string
+}
+
+func (b *fakeBroker) Publish(ctx context.Context, event Event) error {
+	if event.ID == b.failOn {
+		return errors.New("broker unavailable")
+	}
+	b.published = append(b.published, event.ID)
+	return nil
+}
+
+func (b *fakeBroker) Subscribe(eventType Type, handler Handler) func() {
+	return func() {}
+}
+
+func pendingEvents(ids ...string) []Event {
+	events := make([]Event, 0, len(ids))
+	for _, id := range ids {
+		events = append(events, Event{ID: id, Type: UserDeleted})
+	}
+	return events
+}
+
+func TestRelayPending_PublishesBatchInOrder(t *testing.T) {
+	outbox := &fakeOutbox{pending: pendingEvents("1", "2", "3")}
+	broker := &fakeBroker{}
+
+	count, err := NewRelay(outbox, broker, 2).RelayPending(context.Background())
+
+	assert.NoError(t, err)
+	assert.Equal(t, 2, count)
+	assert.Equal(t, []string{"1", "2"}, broker.published)
+	assert.Equal(t, []string{"1", "2"}, outbox.marked)
+}
+
+func TestRelayPending_StopsAtFirstFailure(t *testing.T) {
+	outbox := &fakeOutbox{pending: pendingEvents("1", "2", "3")}
+	broker := &fakeBroker{failOn: "2"}
+
+	count, err := NewRelay(outbox, broker, 10).RelayPending(context.Background())
+
+	assert.Error(t, err)
+	assert.Equal(t, 1, count)
+	assert.Equal(t, []string{"1"}, broker.published)
+	assert.Equal(t, []string{"1"}, outbox.marked)
+}
+
+func TestRelayPending_MarkError(t *testing.T) {
+	outbox := &fakeOutbox{pending: pendingEvents("1"), markErr: errors.New("database down")}
+	broker := &fakeBroker{}
+
+	count, err := NewRelay(outbox, broker, 10).RelayPending(context.Background())
+
+	assert.Error(t, err)
+	assert.Equal(t, 0, count)
+}
+
+func TestRelayPending_NothingPending(t *testing.T) {
+	outbox := &fakeOutbox{}
+	broker := &fakeBroker{}
+
+	count, err := NewRelay(outbox, broker, 10).RelayPending(context.Background())
+
+	assert.NoError(t, err)
+	assert.Equal(t, 0, count)
+	assert.Empty(t, outbox.marked)
+}
@@ -0,0 +1,173 @@
+package events
+
+import (
+	"bytes"
+	"context"
+	"crypto/hmac"
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"fmt"
+	"io"
+	"log/slog"
+	"net/http"
+	"strconv"
+	"time"
+)
+
+// Headers sent with every event relayed over HTTP
+const (
+	TimestampHeader = "X-Swift-Signals-Timestamp"
+	SignatureHeader = "X-Swift-Signals-Signature"
+)
+
+// NOTE: Relayed events signed longer ago than this are refused, so that a captured request
+// cannot be replayed later
+const signatureTolerance = 5 * time.Minute
+
+// maxEventSize is the largest request body a Receiver reads
+const maxEventSize = 1 << 20
+
+// Sign returns the signature of an event's body relayed at the given Unix time, which
+// only a holder of the shared secret can produce
+func Sign(secret []byte, timestamp string, body []byte) string {
+	mac := hmac.New(sha256.New, secret)
+	mac.Write([]byte(timestamp))
+	mac.Write([]byte("."))
+	mac.Write(body)
+	return "sha256=" + hex.EncodeToString(mac.Sum(nil))
+}
+
+// HTTPBroker relays events to other processes by posting them, signed, to their Receivers,
+// and delivers them to the handlers subscribed in this process after. An event is only
+// published once every endpoint has accepted it, so an endpoint that is down holds the
+// outbox up until it is back, and the others may receive the event again meanwhile.
+type HTTPBroker struct {
+	*InProcessBroker
+	endpoints []string
+	secret    []byte
+	client    *http.Client
+}
+
+// NewHTTPBroker relays to the given endpoints, giving each timeout to accept an event.
+// Without endpoints it only delivers to the handlers in this process.
+func NewHTTPBroker(endpoints []string, secret string, timeout time.Duration) *HTTPBroker {
+	return &HTTPBroker{
+		InProcessBroker: NewInProcessBroker(),
+		endpoints:       endpoints,
+		secret:          []byte(secret),
+		client:          &http.Client{Timeout: timeout},
+	}
+}
+
+func (b *HTTPBroker) Publish(ctx context.Context, event Event) error {
+	if len(b.endpoints) > 0 {
+		body, err := json.Marshal(event)
+		if err != nil {
+			return fmt.Errorf("failed to encode %s event: %w", event.Type, err)
+		}
+		for _, endpoint := range b.endpoints {
+			if err := b.post(ctx, endpoint, body); err != nil {
+				return fmt.Errorf("failed to relay %s event to %s: %w", event.Type, endpoint, err)
+			}
+		}
+	}
+	return b.InProcessBroker.Publish(ctx, event)
+}
+
+func (b *HTTPBroker) post(ctx context.Context, endpoint string, body []byte) error {
+	req, err := http.NewRequestWithContext(ctx, http.MethodPost, endpoint, bytes.NewReader(body))
+	if err != nil {
+		return err
+	}
+	timestamp := strconv.FormatInt(time.Now().Unix(), 10)
+	req.Header.Set("Content-Type", "application/json")
+	req.Header.Set(TimestampHeader, timestamp)
+	req.Header.Set(SignatureHeader, Sign(b.secret, timestamp, body))
+
+	resp, err := b.client.Do(req)
+	if err != nil {
+		return err
+	}
+	defer resp.Body.Close()
+	// NOTE: Drained so the connection can be reused
+	_, _ = io.Copy(io.Discard, io.LimitReader(resp.Body, 64<<10))
+
+	if resp.StatusCode < 200 || resp.StatusCode >= 300 {
+		return fmt.Errorf("endpoint responded %s", resp.Status)
+	}
+	return nil
+}
+
+// Receiver is the HTTP endpoint another process's HTTPBroker relays its events to. Each
+// event is handed to the handler, and refused if the handler fails so that the sender
+// relays it again.
+type Receiver struct {
+	handler Handler
+	secret  []byte
+	now     func() time.Time
+}
+
+func NewReceiver(handler Handler, secret string) *Receiver {
+	return &Receiver{
+		handler: handler,
+		secret:  []byte(secret),
+		now:     time.Now,
+	}
+}
+
+func (rc *Receiver) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	if r.Method != http.MethodPost {
+		w.Header().Set("Allow", http.MethodPost)
+		http.Error(w, "method not allowed", http.StatusMethodNotAllowed)
+		return
+	}
+
+	body, err := io.ReadAll(http.MaxBytesReader(w, r.Body, maxEventSize))
+	if err != nil {
+		http.Error(w, "could not read event", http.StatusBadRequest)
+		return
+	}
+
+	timestamp := r.Header.Get(TimestampHeader)
+	if !rc.validSignature(timestamp, r.Header.Get(SignatureHeader), body) {
+		http.Error(w, "invalid signature", http.StatusUnauthorized)
+		return
+	}
+
+	var event Event
+	if err := json.Unmarshal(body, &event); err != nil || event.ID == "" || event.Type == "" {
+		http.Error(w, "invalid event", http.StatusBadRequest)
+		return
+	}
+
+	if err := rc.handler(r.Context(), event); err != nil {
+		slog.Default().Error("relayed event handler failed",
+			"event_id", event.ID,
+			"event_type", event.Type,
+			"error", err.Error(),
+		)
+		http.Error(w, "event not handled", http.StatusInternalServerError)
+		return
+	}
+	w.WriteHeader(http.StatusNoContent)
+}
+
+// validSignature refuses everything without a secret, as anyone could sign with an empty one
+func (rc *Receiver) validSignature(timestamp, signature string, body []byte) bool {
+	if len(rc.secret) == 0 {
+		return false
+	}
+	seconds, err := strconv.ParseInt(timestamp, 10, 64)
+	if err != nil {
+		return false
+	}
+	age := rc.now().Sub(time.Unix(seconds, 0))
+	if age > signatureTolerance || age < -signatureTolerance {
+		return false
+	}
+	return hmac.Equal([]byte(signature), []byte(Sign(rc.secret, timestamp, body)))
+}
+
+// NOTE: Asserts Interface Implementation
+var _ Broker = (*HTTPBroker)(nil)
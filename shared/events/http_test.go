@@ -0,0 +1,131 @@
+package events
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"strconv"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+const testSecret = "relay-secret"
+
+func TestHTTPBroker_RelaysToReceiver(t *testing.T) {
+	var received []Event
+	server := httptest.NewServer(NewReceiver(func(ctx context.Context, event Event) error {
+		received = append(received, event)
+		return nil
+	}, testSecret))
+	defer server.Close()
+
+	broker := NewHTTPBroker([]string{server.URL}, testSecret, time.Second)
+	local := 0
+	broker.Subscribe(AllEvents, func(ctx context.Context, event Event) error {
+		local++
+		return nil
+	})
+
+	event, _ := NewEvent(UserDeleted, "user-1", UserDeletedPayload{UserID: "user-1"})
+	assert.NoError(t, broker.Publish(context.Background(), event))
+
+	assert.Len(t, received, 1)
+	assert.Equal(t, event.ID, received[0].ID)
+	assert.True(t, event.OccurredAt.Equal(received[0].OccurredAt))
+	var payload UserDeletedPayload
+	assert.NoError(t, received[0].Decode(&payload))
+	assert.Equal(t, "user-1", payload.UserID)
+	assert.Equal(t, 1, local)
+}
+
+func TestHTTPBroker_FailsWhenEndpointRefuses(t *testing.T) {
+	server := httptest.NewServer(NewReceiver(func(ctx context.Context, event Event) error {
+		return errors.New("database down")
+	}, testSecret))
+	defer server.Close()
+
+	broker := NewHTTPBroker([]string{server.URL}, testSecret, time.Second)
+	local := 0
+	broker.Subscribe(AllEvents, func(ctx context.Context, event Event) error {
+		local++
+		return nil
+	})
+
+	// NOTE: The relay keeps the event in the outbox and publishes it again later
+	event, _ := NewEvent(UserDeleted, "user-1", UserDeletedPayload{})
+	assert.Error(t, broker.Publish(context.Background(), event))
+	assert.Equal(t, 0, local)
+}
+
+func TestHTTPBroker_WrongSecret(t *testing.T) {
+	server := httptest.NewServer(NewReceiver(func(ctx context.Context, event Event) error {
+		t.Fatal("event with a forged signature was handled")
+		return nil
+	}, testSecret))
+	defer server.Close()
+
+	event, _ := NewEvent(UserDeleted, "user-1", UserDeletedPayload{})
+	broker := NewHTTPBroker([]string{server.URL}, "other-secret", time.Second)
+	assert.Error(t, broker.Publish(context.Background(), event))
+}
+
+func TestReceiver_RefusesBadRequests(t *testing.T) {
+	handled := false
+	receiver := NewReceiver(func(ctx context.Context, event Event) error {
+		handled = true
+		return nil
+	}, testSecret)
+	now := time.Now()
+	receiver.now = func() time.Time { return now }
+
+	body := `{"id":"1","type":"user.deleted","aggregate_id":"user-1","payload":{}}`
+	signed := func(at time.Time, body string) *http.Request {
+		timestamp := strconv.FormatInt(at.Unix(), 10)
+		req := httptest.NewRequest(http.MethodPost, "/events", strings.NewReader(body))
+		req.Header.Set(TimestampHeader, timestamp)
+		req.Header.Set(SignatureHeader, Sign([]byte(testSecret), timestamp, []byte(body)))
+		return req
+	}
+	unsigned := httptest.NewRequest(http.MethodPost, "/events", strings.NewReader(body))
+
+	for name, test := range map[string]struct {
+		req  *http.Request
+		want int
+	}{
+		"unsigned":  {req: unsigned, want: http.StatusUnauthorized},
+		"replayed":  {req: signed(now.Add(-10*time.Minute), body), want: http.StatusUnauthorized},
+		"not event": {req: signed(now, `{"id":""}`), want: http.StatusBadRequest},
+		"wrong method": {
+			req:  httptest.NewRequest(http.MethodGet, "/events", nil),
+			want: http.StatusMethodNotAllowed,
+		},
+	} {
+		rec := httptest.NewRecorder()
+		receiver.ServeHTTP(rec, test.req)
+		assert.Equal(t, test.want, rec.Code, name)
+	}
+	assert.False(t, handled)
+
+	rec := httptest.NewRecorder()
+	receiver.ServeHTTP(rec, signed(now, body))
+	assert.Equal(t, http.StatusNoContent, rec.Code)
+	assert.True(t, handled)
+}
+
+func TestReceiver_RefusesWithoutSecret(t *testing.T) {
+	receiver := NewReceiver(func(ctx context.Context, event Event) error { return nil }, "")
+
+	body := `{"id":"1","type":"user.deleted"}`
+	timestamp := strconv.FormatInt(time.Now().Unix(), 10)
+	req := httptest.NewRequest(http.MethodPost, "/events", strings.NewReader(body))
+	req.Header.Set(TimestampHeader, timestamp)
+	req.Header.Set(SignatureHeader, Sign(nil, timestamp, []byte(body)))
+
+	rec := httptest.NewRecorder()
+	receiver.ServeHTTP(rec, req)
+	assert.Equal(t, http.StatusUnauthorized, rec.Code)
+}
@@ -0,0 +1,88 @@
+package events
+
+import (
+	"context"
+	"log/slog"
+	"time"
+)
+
+// OutboxStore is the outbox a repository writes events to in the same write as the change
+// they describe
+type OutboxStore interface {
+	// PendingEvents returns up to limit events that have not been published, oldest first
+	PendingEvents(ctx context.Context, limit int) ([]Event, error)
+	// MarkEventsPublished removes the events with the given IDs from the outbox
+	MarkEventsPublished(ctx context.Context, ids []string) error
+}
+
+// Relay moves events from an outbox to a broker
+type Relay struct {
+	store     OutboxStore
+	broker    Broker
+	batchSize int
+}
+
+func NewRelay(store OutboxStore, broker Broker, batchSize int) *Relay {
+	return &Relay{
+		store:     store,
+		broker:    broker,
+		batchSize: batchSize,
+	}
+}
+
+// RelayPending publishes one batch of pending events in order, stopping at the first that
+// fails so that none overtakes another. It returns how many were published.
+func (r *Relay) RelayPending(ctx context.Context) (int, error) {
+	pending, err := r.store.PendingEvents(ctx, r.batchSize)
+	if err != nil {
+		return 0, err
+	}
+
+	published := make([]string, 0, len(pending))
+	var publishErr error
+	for _, event := range pending {
+		if publishErr = r.broker.Publish(ctx, event); publishErr != nil {
+			break
+		}
+		published = append(published, event.ID)
+	}
+
+	// NOTE: If marking fails the events are published again on the next pass, which
+	// at least once delivery allows for
+	if len(published) > 0 {
+		if err := r.store.MarkEventsPublished(ctx, published); err != nil {
+			return 0, err
+		}
+	}
+	return len(published), publishErr
+}
+
+// RunRelay relays pending events on every interval until ctx is cancelled, going round
+// again straight away while there are full batches waiting
+func RunRelay(ctx context.Context, r *Relay, interval time.Duration) {
+	logger := slog.Default().With("component", "outbox-relay")
+
+	ticker := time.NewTicker(interval)
+	defer ticker.Stop()
+	for {
+		for {
+			count, err := r.RelayPending(ctx)
+			if err != nil {
+				logger.Error("failed to relay events",
+					"published", count,
+					"error", err.Error(),
+				)
+				break
+			}
+			if count < r.batchSize {
+				break
+			}
+		}
+
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+		}
+	}
+}
@@ -1,6 +1,7 @@
 package jwt
 
 import (
+	"crypto/rand"
 	"errors"
 	"fmt"
 	"time"
@@ -20,6 +21,8 @@ func Init(key []byte) {
 	secretKey = key
 }
 
+// GenerateToken signs a token for the user with a unique ID, its jti claim, by which it can
+// be revoked before it expires
 func GenerateToken(userID, role string, expiryDuration time.Duration) (string, error) {
 	expirationTime := time.Now().Add(expiryDuration)
 
@@ -27,6 +30,7 @@ func GenerateToken(userID, role string, expiryDuration time.Duration) (string, e
 		UserID: userID,
 		Role:   role,
 		RegisteredClaims: jwt.RegisteredClaims{
+			ID:        rand.Text(),
 			ExpiresAt: jwt.NewNumericDate(expirationTime),
 			IssuedAt:  jwt.NewNumericDate(time.Now()),
 			NotBefore: jwt.NewNumericDate(time.Now()),
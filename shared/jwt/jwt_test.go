@@ -25,6 +25,22 @@ func TestGenerateAndParseToken_Success(t *testing.T) {
 	assert.Equal(t, userID, claims.UserID)
 	assert.Equal(t, role, claims.Role)
 	assert.Equal(t, "swift-signals", claims.Issuer)
+	assert.NotEmpty(t, claims.ID)
+}
+
+func TestGenerateToken_UniqueIDs(t *testing.T) {
+	Init([]byte("my-secret-key"))
+
+	first, err := GenerateToken("12345", "regular", time.Minute)
+	assert.NoError(t, err)
+	second, err := GenerateToken("12345", "regular", time.Minute)
+	assert.NoError(t, err)
+
+	firstClaims, err := ParseToken(first)
+	assert.NoError(t, err)
+	secondClaims, err := ParseToken(second)
+	assert.NoError(t, err)
+	assert.NotEqual(t, firstClaims.ID, secondClaims.ID)
 }
 
 func TestParseToken_InvalidToken(t *testing.T) {
@@ -48,6 +48,62 @@ def pretty_log(name, obj, max_len=1000):
     logger.debug("%s:\n%s", name, text)
 
 
+# Number of vehicles sent per chunk of streamed output
+OUTPUT_CHUNK_VEHICLES = int(os.environ.get("OUTPUT_CHUNK_VEHICLES", "100"))
+
+# SimLoad numbers densities low, medium and high from 0, where the proto starts at 1 and
+# leaves 0 unspecified, which is simulated as medium
+TRAFFIC_DENSITIES = {1: 0, 2: 1, 3: 2}
+
+PARAMETER_KEYS = {
+    "green": "Green",
+    "yellow": "Yellow",
+    "red": "Red",
+    "speed": "Speed",
+    "seed": "Seed",
+}
+
+
+def to_request_dict(request: pb.SimulationRequest):
+    """Convert a simulation request to the dict SimLoad expects."""
+    req_dict = {
+        "intersection": MessageToDict(
+            request, preserving_proto_field_name=True, use_integers_for_enums=True
+        )
+    }
+    density = TRAFFIC_DENSITIES.get(request.simulation_parameters.traffic_density, 1)
+    req_dict["intersection"]["traffic density"] = density
+    req_dict["intersection"]["Traffic Density"] = density
+    params = req_dict["intersection"]["simulation_parameters"]
+    for key, sim_key in PARAMETER_KEYS.items():
+        params[sim_key] = params[key]
+    return req_dict
+
+
+def parse_results(sim_output):
+    """Return the results message of a simulation, or None if it has none."""
+    if not sim_output or sim_output[0] is None:
+        return None
+    results = sim_output[0]["intersection"]["results"]
+    pretty_log("Parsed results", results)
+
+    msg_results = pb.SimulationResultsResponse()
+    ParseDict(results, msg_results)
+    return msg_results
+
+
+def parse_output(sim_output):
+    """Return the output message of a simulation, or None if it has none."""
+    if not sim_output or len(sim_output) < 2:
+        return None
+    output = sim_output[1]
+    pretty_log("Parsed output", output)
+
+    msg_output = pb.SimulationOutputResponse()
+    ParseDict(output, msg_output)
+    return msg_output
+
+
 class SimulationServicer(pb_grpc.SimulationServiceServicer):
     def GetSimulationResults(self, request: pb.SimulationRequest, context):
         logger.info(
@@ -55,48 +111,21 @@ class SimulationServicer(pb_grpc.SimulationServiceServicer):
             extra={"intersection_id": request.intersection_id},
         )
 
-        req_dict = {
-            "intersection": MessageToDict(
-                request, preserving_proto_field_name=True, use_integers_for_enums=True
-            )
-        }
-        req_dict["intersection"]["traffic density"] = 1
-        req_dict["intersection"]["Traffic Density"] = 1
-        req_dict["intersection"]["simulation_parameters"]["Green"] = req_dict[
-            "intersection"
-        ]["simulation_parameters"]["green"]
-        req_dict["intersection"]["simulation_parameters"]["Yellow"] = req_dict[
-            "intersection"
-        ]["simulation_parameters"]["yellow"]
-        req_dict["intersection"]["simulation_parameters"]["Red"] = req_dict[
-            "intersection"
-        ]["simulation_parameters"]["red"]
-        req_dict["intersection"]["simulation_parameters"]["Speed"] = req_dict[
-            "intersection"
-        ]["simulation_parameters"]["speed"]
-        req_dict["intersection"]["simulation_parameters"]["Seed"] = req_dict[
-            "intersection"
-        ]["simulation_parameters"]["seed"]
-
+        req_dict = to_request_dict(request)
         pretty_log("Request dict", req_dict)
 
         try:
             sim_output = SimLoad.main(req_dict)
             pretty_log("Raw simulation output", sim_output)
 
-            if not sim_output or sim_output[0] is None:
+            msg_results = parse_results(sim_output)
+            if msg_results is None:
                 msg = f"Simulation returned no results for intersection_id={request.intersection_id}"
                 logger.error(msg)
                 context.set_code(grpc.StatusCode.INTERNAL)
                 context.set_details(msg)
                 return pb.SimulationResultsResponse()
 
-            results = sim_output[0]["intersection"]["results"]
-            pretty_log("Parsed results", results)
-
-            msg_results = pb.SimulationResultsResponse()
-            ParseDict(results, msg_results)
-
             logger.info(
                 "Returning simulation results",
                 extra={"intersection_id": request.intersection_id},
@@ -115,51 +144,26 @@ class SimulationServicer(pb_grpc.SimulationServiceServicer):
             extra={"intersection_id": request.intersection_id},
         )
 
-        req_dict = {
-            "intersection": MessageToDict(
-                request, preserving_proto_field_name=True, use_integers_for_enums=True
-            )
-        }
+        req_dict = to_request_dict(request)
         pretty_log("Request dict", req_dict)
-        req_dict["intersection"]["traffic density"] = 1
-        req_dict["intersection"]["simulation_parameters"]["Green"] = req_dict[
-            "intersection"
-        ]["simulation_parameters"]["green"]
-        req_dict["intersection"]["simulation_parameters"]["Yellow"] = req_dict[
-            "intersection"
-        ]["simulation_parameters"]["yellow"]
-        req_dict["intersection"]["simulation_parameters"]["Red"] = req_dict[
-            "intersection"
-        ]["simulation_parameters"]["red"]
-        req_dict["intersection"]["simulation_parameters"]["Speed"] = req_dict[
-            "intersection"
-        ]["simulation_parameters"]["speed"]
-        req_dict["intersection"]["simulation_parameters"]["Seed"] = req_dict[
-            "intersection"
-        ]["simulation_parameters"]["seed"]
 
         try:
             sim_output = SimLoad.main(req_dict)
             pretty_log("Raw simulation output", sim_output)
 
-            if not sim_output or len(sim_output) < 2:
+            msg_output = parse_output(sim_output)
+            if msg_output is None:
                 msg = f"Simulation returned no output for intersection_id={request.intersection_id}"
                 logger.error(msg)
                 context.set_code(grpc.StatusCode.INTERNAL)
                 context.set_details(msg)
                 return pb.SimulationOutputResponse()
 
-            results = sim_output[1]
-            pretty_log("Parsed output", results)
-
-            msg_results = pb.SimulationOutputResponse()
-            ParseDict(results, msg_results)
-
             logger.info(
                 "Returning simulation output",
                 extra={"intersection_id": request.intersection_id},
             )
-            return msg_results
+            return msg_output
 
         except Exception as e:
             logger.exception("Error while processing GetSimulationOutput")
@@ -167,6 +171,85 @@ class SimulationServicer(pb_grpc.SimulationServiceServicer):
             context.set_details(str(e))
             return pb.SimulationOutputResponse()
 
+    def RunSimulation(self, request, context):
+        logger.info(
+            "Received RunSimulation request",
+            extra={"intersection_id": request.intersection_id},
+        )
+
+        req_dict = to_request_dict(request)
+        pretty_log("Request dict", req_dict)
+
+        try:
+            sim_output = SimLoad.main(req_dict)
+            pretty_log("Raw simulation output", sim_output)
+
+            msg_results = parse_results(sim_output)
+            msg_output = parse_output(sim_output)
+            if msg_results is None or msg_output is None:
+                msg = f"Simulation returned no results or output for intersection_id={request.intersection_id}"
+                logger.error(msg)
+                context.set_code(grpc.StatusCode.INTERNAL)
+                context.set_details(msg)
+                return pb.SimulationResponse()
+
+            logger.info(
+                "Returning simulation results and output",
+                extra={"intersection_id": request.intersection_id},
+            )
+            return pb.SimulationResponse(results=msg_results, output=msg_output)
+
+        except Exception as e:
+            logger.exception("Error while processing RunSimulation")
+            context.set_code(grpc.StatusCode.INTERNAL)
+            context.set_details(str(e))
+            return pb.SimulationResponse()
+
+
+    def StreamSimulationOutput(self, request, context):
+        logger.info(
+            "Received StreamSimulationOutput request",
+            extra={"intersection_id": request.intersection_id},
+        )
+
+        req_dict = to_request_dict(request)
+        pretty_log("Request dict", req_dict)
+
+        try:
+            sim_output = SimLoad.main(req_dict)
+        except Exception as e:
+            logger.exception("Error while processing StreamSimulationOutput")
+            context.abort(grpc.StatusCode.INTERNAL, str(e))
+
+        msg_output = parse_output(sim_output)
+        if msg_output is None:
+            msg = f"Simulation returned no output for intersection_id={request.intersection_id}"
+            logger.error(msg)
+            context.abort(grpc.StatusCode.INTERNAL, msg)
+
+        vehicles = msg_output.vehicles
+        chunks = 0
+        for start in range(0, max(len(vehicles), 1), OUTPUT_CHUNK_VEHICLES):
+            if not context.is_active():
+                logger.info(
+                    "Client cancelled output stream",
+                    extra={"intersection_id": request.intersection_id},
+                )
+                return
+            chunk = pb.SimulationOutputChunk(
+                vehicles=vehicles[start : start + OUTPUT_CHUNK_VEHICLES]
+            )
+            if start == 0:
+                chunk.intersection.CopyFrom(msg_output.intersection)
+            chunks += 1
+            yield chunk
+
+        logger.info(
+            "Streamed simulation output in %d chunks",
+            chunks,
+            extra={"intersection_id": request.intersection_id},
+        )
+
 
 def serve():
     server = grpc.server(futures.ThreadPoolExecutor(max_workers=1))
@@ -1,16 +1,22 @@
 package main
 
 import (
+	"context"
 	"database/sql"
 	"fmt"
 	"log"
 	"net"
+	"net/http"
 	"os"
+	"strings"
+	"time"
 
 	userpb "github.com/COS301-SE-2025/Swift-Signals/protos/gen/swiftsignals/user/v1"
+	"github.com/COS301-SE-2025/Swift-Signals/shared/events"
 	"github.com/COS301-SE-2025/Swift-Signals/shared/jwt"
 	"github.com/COS301-SE-2025/Swift-Signals/user-service/internal/db"
 	"github.com/COS301-SE-2025/Swift-Signals/user-service/internal/handler"
+	"github.com/COS301-SE-2025/Swift-Signals/user-service/internal/mailer"
 	"github.com/COS301-SE-2025/Swift-Signals/user-service/internal/service"
 	"google.golang.org/grpc"
 	"google.golang.org/grpc/reflection" // for development using grpcurl
@@ -42,7 +48,30 @@ func main() {
 
 	repo := db.NewPostgresUserRepo(dbConn)
 
-	svc := service.NewUserService(repo)
+	// NOTE: Features that react to users being registered or deleted subscribe here, while other
+	// processes receive the events at their endpoints
+	broker := newBroker()
+	broker.Subscribe(events.AllEvents, func(ctx context.Context, event events.Event) error {
+		log.Printf("Published %s event for %s", event.Type, event.AggregateID)
+		return nil
+	})
+	go events.RunRelay(
+		context.Background(),
+		events.NewRelay(repo, broker, 100),
+		durationFromEnv("OUTBOX_RELAY_INTERVAL", time.Second),
+	)
+
+	svc := service.NewUserService(
+		repo,
+		newMailer(),
+		envOrDefault("PASSWORD_RESET_URL", "http://localhost:5173/reset-password"),
+	)
+	go service.RunTokenCleanup(
+		context.Background(),
+		svc,
+		durationFromEnv("TOKEN_CLEANUP_INTERVAL", time.Hour),
+	)
+	serveEvents(svc)
 	handler := handler.NewUserHandler(svc)
 
 	lis, err := net.Listen("tcp", ":"+os.Getenv("APP_PORT"))
@@ -61,3 +90,94 @@ func main() {
 		log.Printf("gRPC server exited with error: %v", err)
 	}
 }
+
+// newMailer picks how emails are sent from MAILER, which is one of "smtp", "file" or
+// "stdout". Without a mail server, stdout is the default so reset links show up in the logs.
+func newMailer() mailer.Mailer {
+	from := envOrDefault("MAIL_FROM", "Swift Signals <no-reply@swiftsignals.local>")
+
+	switch kind := envOrDefault("MAILER", "stdout"); kind {
+	case "smtp":
+		return mailer.NewSMTPMailer(
+			os.Getenv("SMTP_HOST"),
+			envOrDefault("SMTP_PORT", "587"),
+			os.Getenv("SMTP_USERNAME"),
+			os.Getenv("SMTP_PASSWORD"),
+			from,
+		)
+	case "file":
+		m, err := mailer.NewFileMailer(envOrDefault("MAIL_DIR", "mail"), from)
+		if err != nil {
+			log.Fatalf("Failed to create mail directory: %v", err)
+		}
+		return m
+	case "stdout":
+		return mailer.NewStdoutMailer(os.Stdout, from)
+	default:
+		log.Fatalf("Unknown MAILER %q", kind)
+		return nil
+	}
+}
+
+// envOrDefault reads a setting from the environment, falling back to def when it is unset
+func envOrDefault(key, def string) string {
+	if value := os.Getenv(key); value != "" {
+		return value
+	}
+	return def
+}
+
+// durationFromEnv reads a duration such as "90m" from the environment, falling back to def
+func durationFromEnv(key string, def time.Duration) time.Duration {
+	value := os.Getenv(key)
+	if value == "" {
+		return def
+	}
+	d, err := time.ParseDuration(value)
+	if err != nil || d <= 0 {
+		log.Printf("Invalid %s %q, using %s", key, value, def)
+		return def
+	}
+	return d
+}
+
+// newBroker relays events to the comma separated endpoints in EVENT_ENDPOINTS, which check
+// them against EVENT_SECRET
+func newBroker() *events.HTTPBroker {
+	var endpoints []string
+	for endpoint := range strings.SplitSeq(os.Getenv("EVENT_ENDPOINTS"), ",") {
+		if endpoint = strings.TrimSpace(endpoint); endpoint != "" {
+			endpoints = append(endpoints, endpoint)
+		}
+	}
+	secret := os.Getenv("EVENT_SECRET")
+	if len(endpoints) > 0 && secret == "" {
+		log.Fatalf("EVENT_SECRET is required to relay events to EVENT_ENDPOINTS")
+	}
+	return events.NewHTTPBroker(endpoints, secret, durationFromEnv("EVENT_TIMEOUT", 10*time.Second))
+}
+
+// serveEvents receives the events other services relay to this one on EVENTS_PORT, checking
+// them against EVENT_SECRET. Without a secret nothing could be accepted, so nothing is served.
+func serveEvents(svc service.UserService) {
+	secret := os.Getenv("EVENT_SECRET")
+	if secret == "" {
+		log.Println("EVENT_SECRET is not set, not receiving events from other services")
+		return
+	}
+
+	mux := http.NewServeMux()
+	mux.Handle("POST /events", events.NewReceiver(svc.HandleEvent, secret))
+	server := &http.Server{
+		Addr:              ":" + envOrDefault("EVENTS_PORT", "8081"),
+		Handler:           mux,
+		ReadHeaderTimeout: 10 * time.Second,
+	}
+
+	go func() {
+		log.Println("Events receiver running on " + server.Addr)
+		if err := server.ListenAndServe(); err != nil {
+			log.Fatalf("Events receiver exited with error: %v", err)
+		}
+	}()
+}
@@ -2,7 +2,9 @@ package db
 
 import (
 	"context"
+	"time"
 
+	"github.com/COS301-SE-2025/Swift-Signals/shared/events"
 	"github.com/COS301-SE-2025/Swift-Signals/user-service/internal/model"
 )
 
@@ -13,7 +15,25 @@ type UserRepository interface {
 	UpdateUser(ctx context.Context, user *model.User) (*model.User, error)
 	DeleteUser(ctx context.Context, id string) error
 	ListUsers(ctx context.Context, limit, offset int) ([]*model.User, error)
+	ListUsersAfter(ctx context.Context, afterID string, limit int) ([]*model.User, error)
 	AddIntersectionID(ctx context.Context, userID string, intID string) error
 	GetIntersectionsByUserID(ctx context.Context, userID string) ([]string, error)
+	DeleteIntersectionID(ctx context.Context, intID string) (int64, error)
 	AdminExists(ctx context.Context) (bool, error)
+	PendingEvents(ctx context.Context, limit int) ([]events.Event, error)
+	MarkEventsPublished(ctx context.Context, ids []string) error
+	RevokeToken(ctx context.Context, tokenID, userID string, expiresAt time.Time) error
+	IsTokenRevoked(ctx context.Context, tokenID, userID string, issuedAt time.Time) (bool, error)
+	DeleteExpiredTokens(ctx context.Context, before time.Time) (int64, error)
+	CreateRefreshToken(ctx context.Context, token *model.RefreshToken) error
+	ClaimRefreshToken(
+		ctx context.Context,
+		tokenHash string,
+		at time.Time,
+	) (*model.RefreshToken, error)
+	DeleteRefreshTokenFamily(ctx context.Context, familyID string) error
+	DeleteExpiredRefreshTokens(ctx context.Context, before time.Time) (int64, error)
+	CreatePasswordResetToken(ctx context.Context, token *model.PasswordResetToken) error
+	ResetPassword(ctx context.Context, tokenHash, password string, at time.Time) (string, error)
+	DeleteExpiredPasswordResetTokens(ctx context.Context, before time.Time) (int64, error)
 }
@@ -0,0 +1,132 @@
+package db
+
+import (
+	"context"
+	"database/sql"
+
+	errs "github.com/COS301-SE-2025/Swift-Signals/shared/error"
+	"github.com/COS301-SE-2025/Swift-Signals/shared/events"
+	"github.com/COS301-SE-2025/Swift-Signals/user-service/internal/util"
+	"github.com/lib/pq"
+)
+
+// withTx runs fn in a transaction, which is committed if fn succeeds and rolled back if not
+func (r *PostgresUserRepo) withTx(
+	ctx context.Context,
+	errCtx ErrorContext,
+	fn func(tx *sql.Tx) error,
+) error {
+	logger := util.LoggerFromContext(ctx)
+
+	tx, err := r.db.BeginTx(ctx, nil)
+	if err != nil {
+		return HandleDatabaseError(err, errCtx)
+	}
+
+	if err := fn(tx); err != nil {
+		if rbErr := tx.Rollback(); rbErr != nil {
+			logger.Warn("Failed to roll back transaction", "Error", rbErr)
+		}
+		return err
+	}
+
+	if err := tx.Commit(); err != nil {
+		return HandleDatabaseError(err, errCtx)
+	}
+	return nil
+}
+
+// insertEvent adds an event to the outbox as part of the transaction making the change
+func insertEvent(
+	ctx context.Context,
+	tx *sql.Tx,
+	eventType events.Type,
+	aggregateID string,
+	payload any,
+) error {
+	logger := util.LoggerFromContext(ctx)
+
+	event, err := events.NewEvent(eventType, aggregateID, payload)
+	if err != nil {
+		return errs.NewInternalError(
+			"failed to create event",
+			err,
+			map[string]any{"eventType": eventType},
+		)
+	}
+
+	logger.Debug("Inserting into outbox_events table", "eventType", eventType)
+	query := `INSERT INTO outbox_events (id, type, aggregate_id, payload, occurred_at)
+	          VALUES ($1, $2, $3, $4, $5)`
+	_, err = tx.ExecContext(
+		ctx,
+		query,
+		event.ID,
+		event.Type,
+		event.AggregateID,
+		string(event.Payload),
+		event.OccurredAt,
+	)
+	if err != nil {
+		return HandleDatabaseError(err, ErrorContext{Operation: OpCreate, Table: "outbox_events"})
+	}
+	return nil
+}
+
+func (r *PostgresUserRepo) PendingEvents(ctx context.Context, limit int) ([]events.Event, error) {
+	logger := util.LoggerFromContext(ctx)
+
+	logger.Debug("Selecting pending events from outbox_events table")
+	query := `SELECT id, type, aggregate_id, payload, occurred_at
+	          FROM outbox_events
+	          ORDER BY occurred_at, id LIMIT $1`
+	rows, err := r.db.QueryContext(ctx, query, limit)
+	if err != nil {
+		return nil, HandleDatabaseError(err, ErrorContext{Operation: OpRead, Table: "outbox_events"})
+	}
+	defer func() {
+		if err := rows.Close(); err != nil {
+			logger.Warn("Failed to close rows", "Error", err)
+		}
+	}()
+
+	var pending []events.Event
+	for rows.Next() {
+		var event events.Event
+		var payload []byte
+		err := rows.Scan(
+			&event.ID,
+			&event.Type,
+			&event.AggregateID,
+			&payload,
+			&event.OccurredAt,
+		)
+		if err != nil {
+			return nil, HandleDatabaseError(
+				err,
+				ErrorContext{Operation: OpRead, Table: "outbox_events"},
+			)
+		}
+		event.Payload = payload
+		pending = append(pending, event)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, HandleDatabaseError(err, ErrorContext{Operation: OpRead, Table: "outbox_events"})
+	}
+	return pending, nil
+}
+
+// MarkEventsPublished deletes published events, since the outbox only holds those still
+// waiting to be relayed
+func (r *PostgresUserRepo) MarkEventsPublished(ctx context.Context, ids []string) error {
+	logger := util.LoggerFromContext(ctx)
+
+	logger.Debug("Deleting published events from outbox_events table", "count", len(ids))
+	query := `DELETE FROM outbox_events
+	          WHERE id = ANY($1)`
+	_, err := r.db.ExecContext(ctx, query, pq.Array(ids))
+	if err != nil {
+		return HandleDatabaseError(err, ErrorContext{Operation: OpDelete, Table: "outbox_events"})
+	}
+	return nil
+}
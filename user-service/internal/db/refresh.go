@@ -0,0 +1,110 @@
+package db
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"time"
+
+	"github.com/COS301-SE-2025/Swift-Signals/user-service/internal/model"
+	"github.com/COS301-SE-2025/Swift-Signals/user-service/internal/util"
+)
+
+func (r *PostgresUserRepo) CreateRefreshToken(ctx context.Context, token *model.RefreshToken) error {
+	logger := util.LoggerFromContext(ctx)
+
+	logger.Debug("Inserting into refresh_tokens table")
+	query := `INSERT INTO refresh_tokens (token_hash, family_id, user_id, expires_at)
+	          VALUES ($1, $2, $3, $4)`
+	_, err := r.db.ExecContext(
+		ctx,
+		query,
+		token.TokenHash,
+		token.FamilyID,
+		token.UserID,
+		token.ExpiresAt,
+	)
+	if err != nil {
+		return HandleDatabaseError(err, ErrorContext{Operation: OpCreate, Table: "refresh_tokens"})
+	}
+	return nil
+}
+
+// ClaimRefreshToken marks the token used, returning it as it was beforehand. Its UsedAt is
+// only nil for the one caller that claimed it, however many try at once, and the token is
+// nil when there is no such token.
+func (r *PostgresUserRepo) ClaimRefreshToken(
+	ctx context.Context,
+	tokenHash string,
+	at time.Time,
+) (*model.RefreshToken, error) {
+	logger := util.LoggerFromContext(ctx)
+
+	logger.Debug("Updating refresh_tokens table")
+	query := `UPDATE refresh_tokens AS t
+	          SET used_at = COALESCE(t.used_at, $2)
+	          FROM (SELECT token_hash, used_at FROM refresh_tokens
+	                WHERE token_hash = $1 FOR UPDATE) AS prior
+	          WHERE t.token_hash = prior.token_hash
+	          RETURNING t.token_hash, t.family_id, t.user_id, t.expires_at, prior.used_at`
+	var token model.RefreshToken
+	var usedAt sql.NullTime
+	err := r.db.QueryRowContext(ctx, query, tokenHash, at).Scan(
+		&token.TokenHash,
+		&token.FamilyID,
+		&token.UserID,
+		&token.ExpiresAt,
+		&usedAt,
+	)
+	if errors.Is(err, sql.ErrNoRows) {
+		return nil, nil
+	}
+	if err != nil {
+		return nil, HandleDatabaseError(err, ErrorContext{Operation: OpUpdate, Table: "refresh_tokens"})
+	}
+	if usedAt.Valid {
+		token.UsedAt = &usedAt.Time
+	}
+	return &token, nil
+}
+
+func (r *PostgresUserRepo) DeleteRefreshTokenFamily(ctx context.Context, familyID string) error {
+	logger := util.LoggerFromContext(ctx)
+
+	logger.Debug("Deleting family from refresh_tokens table")
+	query := `DELETE FROM refresh_tokens
+	          WHERE family_id = $1`
+	_, err := r.db.ExecContext(ctx, query, familyID)
+	if err != nil {
+		return HandleDatabaseError(err, ErrorContext{Operation: OpDelete, Table: "refresh_tokens"})
+	}
+	return nil
+}
+
+// DeleteExpiredRefreshTokens forgets refresh tokens that expired before the given time,
+// whether or not they were used
+func (r *PostgresUserRepo) DeleteExpiredRefreshTokens(
+	ctx context.Context,
+	before time.Time,
+) (int64, error) {
+	logger := util.LoggerFromContext(ctx)
+
+	logger.Debug("Deleting expired tokens from refresh_tokens table")
+	query := `DELETE FROM refresh_tokens
+	          WHERE expires_at < $1`
+	result, err := r.db.ExecContext(ctx, query, before)
+	if err != nil {
+		return 0, HandleDatabaseError(
+			err,
+			ErrorContext{Operation: OpDelete, Table: "refresh_tokens"},
+		)
+	}
+	deleted, err := result.RowsAffected()
+	if err != nil {
+		return 0, HandleDatabaseError(
+			err,
+			ErrorContext{Operation: OpDelete, Table: "refresh_tokens"},
+		)
+	}
+	return deleted, nil
+}
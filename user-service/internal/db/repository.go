@@ -5,6 +5,7 @@ import (
 	"database/sql"
 
 	errs "github.com/COS301-SE-2025/Swift-Signals/shared/error"
+	"github.com/COS301-SE-2025/Swift-Signals/shared/events"
 	"github.com/COS301-SE-2025/Swift-Signals/user-service/internal/model"
 	"github.com/COS301-SE-2025/Swift-Signals/user-service/internal/util"
 )
@@ -20,16 +21,28 @@ func NewPostgresUserRepo(db *sql.DB) UserRepository {
 func (r *PostgresUserRepo) CreateUser(ctx context.Context, u *model.User) (*model.User, error) {
 	logger := util.LoggerFromContext(ctx)
 
-	logger.Debug("Inserting into users table")
-	query := `INSERT INTO users (uuid, name, email, password, is_admin, created_at, updated_at)
-	          VALUES ($1, $2, $3, $4, $5, NOW(), NOW())`
+	err := r.withTx(ctx, ErrorContext{Operation: OpCreate, Table: "users"}, func(tx *sql.Tx) error {
+		logger.Debug("Inserting into users table")
+		query := `INSERT INTO users (uuid, name, email, password, is_admin, created_at, updated_at)
+		          VALUES ($1, $2, $3, $4, $5, NOW(), NOW())`
 
-	_, err := r.db.ExecContext(ctx, query, u.ID, u.Name, u.Email, u.Password, u.IsAdmin)
-	if err != nil {
-		return nil, HandleDatabaseError(err, ErrorContext{
-			Operation: OpCreate,
-			Table:     "users",
+		_, err := tx.ExecContext(ctx, query, u.ID, u.Name, u.Email, u.Password, u.IsAdmin)
+		if err != nil {
+			return HandleDatabaseError(err, ErrorContext{
+				Operation: OpCreate,
+				Table:     "users",
+			})
+		}
+
+		return insertEvent(ctx, tx, events.UserRegistered, u.ID, events.UserRegisteredPayload{
+			UserID:  u.ID,
+			Name:    u.Name,
+			Email:   u.Email,
+			IsAdmin: u.IsAdmin,
 		})
+	})
+	if err != nil {
+		return nil, err
 	}
 
 	return u, nil
@@ -132,14 +145,22 @@ func (r *PostgresUserRepo) UpdateUser(ctx context.Context, u *model.User) (*mode
 func (r *PostgresUserRepo) DeleteUser(ctx context.Context, id string) error {
 	logger := util.LoggerFromContext(ctx)
 
-	logger.Debug("deleting user from users table")
-	query := `DELETE FROM users
-	          WHERE uuid = $1`
-	_, err := r.db.ExecContext(ctx, query, id)
-	if err != nil {
-		return HandleDatabaseError(err, ErrorContext{Operation: OpDelete, Table: "users"})
-	}
-	return nil
+	return r.withTx(ctx, ErrorContext{Operation: OpDelete, Table: "users"}, func(tx *sql.Tx) error {
+		logger.Debug("deleting user from users table")
+		query := `DELETE FROM users
+		          WHERE uuid = $1`
+		result, err := tx.ExecContext(ctx, query, id)
+		if err != nil {
+			return HandleDatabaseError(err, ErrorContext{Operation: OpDelete, Table: "users"})
+		}
+
+		// NOTE: Nothing happened, so there is nothing to announce
+		if deleted, err := result.RowsAffected(); err != nil || deleted == 0 {
+			return nil
+		}
+
+		return insertEvent(ctx, tx, events.UserDeleted, id, events.UserDeletedPayload{UserID: id})
+	})
 }
 
 func (r *PostgresUserRepo) ListUsers(
@@ -156,6 +177,32 @@ func (r *PostgresUserRepo) ListUsers(
 	if err != nil {
 		return nil, HandleDatabaseError(err, ErrorContext{Operation: OpRead, Table: "users"})
 	}
+	return r.scanUsers(ctx, rows)
+}
+
+// ListUsersAfter lists up to limit users whose ID sorts after afterID
+func (r *PostgresUserRepo) ListUsersAfter(
+	ctx context.Context,
+	afterID string,
+	limit int,
+) ([]*model.User, error) {
+	logger := util.LoggerFromContext(ctx)
+
+	logger.Debug("Selecting users after ID from user table")
+	query := `SELECT uuid, name, email, password, is_admin, created_at, updated_at
+	          FROM users
+	          WHERE uuid > $1
+	          ORDER BY uuid LIMIT $2`
+	rows, err := r.db.QueryContext(ctx, query, afterID, limit)
+	if err != nil {
+		return nil, HandleDatabaseError(err, ErrorContext{Operation: OpRead, Table: "users"})
+	}
+	return r.scanUsers(ctx, rows)
+}
+
+// scanUsers reads a page of users along with their intersection IDs
+func (r *PostgresUserRepo) scanUsers(ctx context.Context, rows *sql.Rows) ([]*model.User, error) {
+	logger := util.LoggerFromContext(ctx)
 	defer func() {
 		if err := rows.Close(); err != nil {
 			logger.Warn("Failed to close rows", "Error", err)
@@ -253,6 +300,24 @@ func (r *PostgresUserRepo) GetIntersectionsByUserID(
 	return ids, nil
 }
 
+// DeleteIntersectionID removes an intersection from the list of every user holding it,
+// returning how many lists it was removed from
+func (r *PostgresUserRepo) DeleteIntersectionID(ctx context.Context, intID string) (int64, error) {
+	logger := util.LoggerFromContext(ctx)
+
+	logger.Debug("Deleting intersection_id from user_intersections")
+	query := `DELETE FROM user_intersections
+	          WHERE intersection_id = $1`
+	result, err := r.db.ExecContext(ctx, query, intID)
+	if err != nil {
+		return 0, HandleDatabaseError(
+			err,
+			ErrorContext{Operation: OpDelete, Table: "user_intersections"},
+		)
+	}
+	return result.RowsAffected()
+}
+
 func (r *PostgresUserRepo) AdminExists(ctx context.Context) (bool, error) {
 	logger := util.LoggerFromContext(ctx)
 	logger.Debug("checking if any user has admin privileges")
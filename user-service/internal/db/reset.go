@@ -0,0 +1,122 @@
+package db
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"time"
+
+	"github.com/COS301-SE-2025/Swift-Signals/user-service/internal/model"
+	"github.com/COS301-SE-2025/Swift-Signals/user-service/internal/util"
+)
+
+func (r *PostgresUserRepo) CreatePasswordResetToken(
+	ctx context.Context,
+	token *model.PasswordResetToken,
+) error {
+	logger := util.LoggerFromContext(ctx)
+
+	logger.Debug("Inserting into password_reset_tokens table")
+	query := `INSERT INTO password_reset_tokens (token_hash, user_id, expires_at)
+	          VALUES ($1, $2, $3)`
+	_, err := r.db.ExecContext(ctx, query, token.TokenHash, token.UserID, token.ExpiresAt)
+	if err != nil {
+		return HandleDatabaseError(
+			err,
+			ErrorContext{Operation: OpCreate, Table: "password_reset_tokens"},
+		)
+	}
+	return nil
+}
+
+// ResetPassword uses up the reset token and sets the password of the user it was issued to,
+// returning their ID, which is empty if the token was already used or has expired. The
+// user's other reset tokens are used up too, the tokens issued to them before now revoked
+// and their refresh tokens deleted, so that every session has to log in with the new
+// password.
+func (r *PostgresUserRepo) ResetPassword(
+	ctx context.Context,
+	tokenHash, password string,
+	at time.Time,
+) (string, error) {
+	logger := util.LoggerFromContext(ctx)
+
+	var userID string
+	errCtx := ErrorContext{Operation: OpUpdate, Table: "password_reset_tokens"}
+	err := r.withTx(ctx, errCtx, func(tx *sql.Tx) error {
+		logger.Debug("Updating password_reset_tokens table")
+		query := `UPDATE password_reset_tokens
+		          SET used_at = $2
+		          WHERE token_hash = $1 AND used_at IS NULL AND expires_at > $2
+		          RETURNING user_id`
+		err := tx.QueryRowContext(ctx, query, tokenHash, at).Scan(&userID)
+		if errors.Is(err, sql.ErrNoRows) {
+			return nil
+		}
+		if err != nil {
+			return HandleDatabaseError(err, errCtx)
+		}
+
+		// NOTE: Tokens only carry their issue time to the second, so the cutoff is too, or a
+		// login in the same second as the reset would be revoked
+		logger.Debug("updating password in users table")
+		query = `UPDATE users
+		         SET password = $1, tokens_valid_after = $3, updated_at = NOW()
+		         WHERE uuid = $2`
+		_, err = tx.ExecContext(ctx, query, password, userID, at.Truncate(time.Second))
+		if err != nil {
+			return HandleDatabaseError(err, ErrorContext{Operation: OpUpdate, Table: "users"})
+		}
+
+		logger.Debug("Updating user's other tokens in password_reset_tokens table")
+		query = `UPDATE password_reset_tokens
+		         SET used_at = $2
+		         WHERE user_id = $1 AND used_at IS NULL`
+		if _, err := tx.ExecContext(ctx, query, userID, at); err != nil {
+			return HandleDatabaseError(err, errCtx)
+		}
+
+		logger.Debug("Deleting user's tokens from refresh_tokens table")
+		query = `DELETE FROM refresh_tokens
+		         WHERE user_id = $1`
+		if _, err := tx.ExecContext(ctx, query, userID); err != nil {
+			return HandleDatabaseError(
+				err,
+				ErrorContext{Operation: OpDelete, Table: "refresh_tokens"},
+			)
+		}
+		return nil
+	})
+	if err != nil {
+		return "", err
+	}
+	return userID, nil
+}
+
+// DeleteExpiredPasswordResetTokens forgets reset tokens that expired before the given time,
+// whether or not they were used
+func (r *PostgresUserRepo) DeleteExpiredPasswordResetTokens(
+	ctx context.Context,
+	before time.Time,
+) (int64, error) {
+	logger := util.LoggerFromContext(ctx)
+
+	logger.Debug("Deleting expired tokens from password_reset_tokens table")
+	query := `DELETE FROM password_reset_tokens
+	          WHERE expires_at < $1`
+	result, err := r.db.ExecContext(ctx, query, before)
+	if err != nil {
+		return 0, HandleDatabaseError(
+			err,
+			ErrorContext{Operation: OpDelete, Table: "password_reset_tokens"},
+		)
+	}
+	deleted, err := result.RowsAffected()
+	if err != nil {
+		return 0, HandleDatabaseError(
+			err,
+			ErrorContext{Operation: OpDelete, Table: "password_reset_tokens"},
+		)
+	}
+	return deleted, nil
+}
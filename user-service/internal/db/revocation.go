@@ -0,0 +1,82 @@
+package db
+
+import (
+	"context"
+	"time"
+
+	"github.com/COS301-SE-2025/Swift-Signals/user-service/internal/util"
+)
+
+// RevokeToken records that the token can no longer be used. Revoking a token twice keeps
+// the first record.
+func (r *PostgresUserRepo) RevokeToken(
+	ctx context.Context,
+	tokenID, userID string,
+	expiresAt time.Time,
+) error {
+	logger := util.LoggerFromContext(ctx)
+
+	logger.Debug("Inserting into revoked_tokens table")
+	query := `INSERT INTO revoked_tokens (token_id, user_id, expires_at)
+	          VALUES ($1, $2, $3)
+	          ON CONFLICT (token_id) DO NOTHING`
+	_, err := r.db.ExecContext(ctx, query, tokenID, userID, expiresAt)
+	if err != nil {
+		return HandleDatabaseError(err, ErrorContext{Operation: OpCreate, Table: "revoked_tokens"})
+	}
+	return nil
+}
+
+// IsTokenRevoked reports whether the token was revoked, was issued before its user's
+// password was reset or its user no longer exists, in a single query since it is asked on
+// every authenticated request
+func (r *PostgresUserRepo) IsTokenRevoked(
+	ctx context.Context,
+	tokenID, userID string,
+	issuedAt time.Time,
+) (bool, error) {
+	logger := util.LoggerFromContext(ctx)
+
+	logger.Debug("Selecting from revoked_tokens and users tables")
+	query := `SELECT EXISTS (SELECT 1 FROM revoked_tokens WHERE token_id = $1)
+	              OR NOT EXISTS (SELECT 1 FROM users
+	                             WHERE uuid = $2
+	                               AND (tokens_valid_after IS NULL OR tokens_valid_after <= $3))`
+	var revoked bool
+	err := r.db.QueryRowContext(ctx, query, tokenID, userID, issuedAt).Scan(&revoked)
+	if err != nil {
+		return false, HandleDatabaseError(
+			err,
+			ErrorContext{Operation: OpRead, Table: "revoked_tokens"},
+		)
+	}
+	return revoked, nil
+}
+
+// DeleteExpiredTokens forgets tokens that expired before the given time, which are
+// rejected on their expiry alone
+func (r *PostgresUserRepo) DeleteExpiredTokens(
+	ctx context.Context,
+	before time.Time,
+) (int64, error) {
+	logger := util.LoggerFromContext(ctx)
+
+	logger.Debug("Deleting expired tokens from revoked_tokens table")
+	query := `DELETE FROM revoked_tokens
+	          WHERE expires_at < $1`
+	result, err := r.db.ExecContext(ctx, query, before)
+	if err != nil {
+		return 0, HandleDatabaseError(
+			err,
+			ErrorContext{Operation: OpDelete, Table: "revoked_tokens"},
+		)
+	}
+	deleted, err := result.RowsAffected()
+	if err != nil {
+		return 0, HandleDatabaseError(
+			err,
+			ErrorContext{Operation: OpDelete, Table: "revoked_tokens"},
+		)
+	}
+	return deleted, nil
+}
@@ -1,3 +1,7 @@
+DROP TABLE IF EXISTS password_reset_tokens;
+DROP TABLE IF EXISTS refresh_tokens;
+DROP TABLE IF EXISTS revoked_tokens;
+DROP TABLE IF EXISTS outbox_events;
 DROP TABLE IF EXISTS user_intersections;
 DROP TABLE IF EXISTS users;
 
@@ -7,6 +11,8 @@ CREATE TABLE users (
     email TEXT UNIQUE NOT NULL,
     password TEXT NOT NULL,
     is_admin BOOLEAN DEFAULT FALSE,
+    -- Tokens issued before this time are revoked, as set when the password is reset
+    tokens_valid_after TIMESTAMPTZ,
     created_at TIMESTAMPTZ DEFAULT NOW(),
     updated_at TIMESTAMPTZ DEFAULT NOW()
 );
@@ -17,6 +23,52 @@ CREATE TABLE user_intersections (
     PRIMARY KEY (user_id, intersection_id)
 );
 
+-- Domain events written in the same transaction as the change they describe, and deleted
+-- once the relay has published them
+CREATE TABLE outbox_events (
+    id TEXT PRIMARY KEY,
+    type TEXT NOT NULL,
+    aggregate_id TEXT NOT NULL,
+    payload JSONB NOT NULL,
+    occurred_at TIMESTAMPTZ NOT NULL
+);
+
+CREATE INDEX outbox_events_occurred_at_idx ON outbox_events (occurred_at, id);
+
+-- IDs (jti claims) of tokens logged out before they expire, kept only until they would have
+-- expired anyway
+CREATE TABLE revoked_tokens (
+    token_id TEXT PRIMARY KEY,
+    user_id UUID NOT NULL,
+    expires_at TIMESTAMPTZ NOT NULL
+);
+
+CREATE INDEX revoked_tokens_expires_at_idx ON revoked_tokens (expires_at);
+
+-- Hashes of refresh tokens, each used at most once. The tokens a login leads to share a
+-- family, which is deleted whole if one of its used tokens is presented again.
+CREATE TABLE refresh_tokens (
+    token_hash TEXT PRIMARY KEY,
+    family_id UUID NOT NULL,
+    user_id UUID NOT NULL REFERENCES users(uuid) ON DELETE CASCADE,
+    expires_at TIMESTAMPTZ NOT NULL,
+    used_at TIMESTAMPTZ
+);
+
+CREATE INDEX refresh_tokens_family_id_idx ON refresh_tokens (family_id);
+CREATE INDEX refresh_tokens_expires_at_idx ON refresh_tokens (expires_at);
+
+-- Hashes of password reset tokens, each of which can be used once before it expires
+CREATE TABLE password_reset_tokens (
+    token_hash TEXT PRIMARY KEY,
+    user_id UUID NOT NULL REFERENCES users(uuid) ON DELETE CASCADE,
+    expires_at TIMESTAMPTZ NOT NULL,
+    used_at TIMESTAMPTZ
+);
+
+CREATE INDEX password_reset_tokens_user_id_idx ON password_reset_tokens (user_id);
+CREATE INDEX password_reset_tokens_expires_at_idx ON password_reset_tokens (expires_at);
+
 INSERT INTO users (uuid, name, email, password, is_admin)
 VALUES
     ('9b9b1c5c-2e57-4e18-a15c-e3219be9dc01', 'Alice Smith', 'alice@example.com', 'password123', false),
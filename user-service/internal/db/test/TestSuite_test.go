@@ -91,7 +91,57 @@ WHERE email = \$1`
 	listUsersQuery = `SELECT uuid, name, email, password, is_admin, created_at, updated_at
 	          FROM users
 	          ORDER BY uuid LIMIT \$1 OFFSET \$2`
+	listUsersAfterQuery = `SELECT uuid, name, email, password, is_admin, created_at, updated_at
+	          FROM users
+	          WHERE uuid > \$1
+	          ORDER BY uuid LIMIT \$2`
 	addIntersectionIDQuery = `INSERT INTO user_intersections \(user_id, intersection_id\)
 	          VALUES \(\$1, \$2\)
 	          ON CONFLICT DO NOTHING`
+	deleteIntersectionIDQuery = `DELETE FROM user_intersections
+	          WHERE intersection_id = \$1`
+	insertEventQuery = `INSERT INTO outbox_events \(id, type, aggregate_id, payload, occurred_at\)
+	          VALUES \(\$1, \$2, \$3, \$4, \$5\)`
+	pendingEventsQuery = `SELECT id, type, aggregate_id, payload, occurred_at
+	          FROM outbox_events
+	          ORDER BY occurred_at, id LIMIT \$1`
+	markEventsPublishedQuery = `DELETE FROM outbox_events
+	          WHERE id = ANY\(\$1\)`
+	revokeTokenQuery = `INSERT INTO revoked_tokens \(token_id, user_id, expires_at\)
+	          VALUES \(\$1, \$2, \$3\)
+	          ON CONFLICT \(token_id\) DO NOTHING`
+	isTokenRevokedQuery = `SELECT EXISTS \(SELECT 1 FROM revoked_tokens WHERE token_id = \$1\)
+	              OR NOT EXISTS \(SELECT 1 FROM users
+	                             WHERE uuid = \$2
+	                               AND \(tokens_valid_after IS NULL OR tokens_valid_after <= \$3\)\)`
+	deleteExpiredTokensQuery = `DELETE FROM revoked_tokens
+	          WHERE expires_at < \$1`
+	createRefreshTokenQuery = `INSERT INTO refresh_tokens \(token_hash, family_id, user_id, expires_at\)
+	          VALUES \(\$1, \$2, \$3, \$4\)`
+	claimRefreshTokenQuery = `UPDATE refresh_tokens AS t
+	          SET used_at = COALESCE\(t.used_at, \$2\)
+	          FROM \(SELECT token_hash, used_at FROM refresh_tokens
+	                WHERE token_hash = \$1 FOR UPDATE\) AS prior
+	          WHERE t.token_hash = prior.token_hash
+	          RETURNING t.token_hash, t.family_id, t.user_id, t.expires_at, prior.used_at`
+	deleteRefreshTokenFamilyQuery = `DELETE FROM refresh_tokens
+	          WHERE family_id = \$1`
+	deleteExpiredRefreshTokensQuery = `DELETE FROM refresh_tokens
+	          WHERE expires_at < \$1`
+	createPasswordResetTokenQuery = `INSERT INTO password_reset_tokens \(token_hash, user_id, expires_at\)
+	          VALUES \(\$1, \$2, \$3\)`
+	claimPasswordResetTokenQuery = `UPDATE password_reset_tokens
+	          SET used_at = \$2
+	          WHERE token_hash = \$1 AND used_at IS NULL AND expires_at > \$2
+	          RETURNING user_id`
+	resetUserPasswordQuery = `UPDATE users
+	          SET password = \$1, tokens_valid_after = \$3, updated_at = NOW\(\)
+	          WHERE uuid = \$2`
+	usePasswordResetTokensQuery = `UPDATE password_reset_tokens
+	          SET used_at = \$2
+	          WHERE user_id = \$1 AND used_at IS NULL`
+	deleteUserRefreshTokensQuery = `DELETE FROM refresh_tokens
+	          WHERE user_id = \$1`
+	deleteExpiredPasswordResetTokensQuery = `DELETE FROM password_reset_tokens
+	          WHERE expires_at < \$1`
 )
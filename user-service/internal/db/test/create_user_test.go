@@ -9,9 +9,14 @@ import (
 )
 
 func (suite *TestSuite) TestCreateUser_Success() {
+	suite.mock.ExpectBegin()
 	suite.mock.ExpectExec(insertUserQuery).
 		WithArgs(testUser.ID, testUser.Name, testUser.Email, testUser.Password, testUser.IsAdmin).
 		WillReturnResult(sqlmock.NewResult(1, 1))
+	suite.mock.ExpectExec(insertEventQuery).
+		WithArgs(sqlmock.AnyArg(), "user.registered", testUser.ID, sqlmock.AnyArg(), sqlmock.AnyArg()).
+		WillReturnResult(sqlmock.NewResult(1, 1))
+	suite.mock.ExpectCommit()
 
 	ctx := context.Background()
 
@@ -30,9 +35,11 @@ func (suite *TestSuite) TestCreateUser_Duplicate_Email() {
 		Detail: `Key (email)=(test@gmail.com) already exists.`,
 	}
 
+	suite.mock.ExpectBegin()
 	suite.mock.ExpectExec(insertUserQuery).
 		WithArgs(testUser.ID, testUser.Name, testUser.Email, testUser.Password, testUser.IsAdmin).
 		WillReturnError(pqError)
+	suite.mock.ExpectRollback()
 
 	ctx := context.Background()
 
@@ -56,9 +63,11 @@ func (suite *TestSuite) TestCreateUser_Duplicate_UUID() {
 		Table:  "users",
 		Detail: "uuid",
 	}
+	suite.mock.ExpectBegin()
 	suite.mock.ExpectExec(insertUserQuery).
 		WithArgs(testUser.ID, testUser.Name, testUser.Email, testUser.Password, testUser.IsAdmin).
 		WillReturnError(pqError)
+	suite.mock.ExpectRollback()
 
 	ctx := context.Background()
 
@@ -81,9 +90,11 @@ func (suite *TestSuite) TestCreateUser_Invalid_Reference() {
 		Code: "23503",
 	}
 
+	suite.mock.ExpectBegin()
 	suite.mock.ExpectExec(insertUserQuery).
 		WithArgs(testUser.ID, testUser.Name, testUser.Email, testUser.Password, testUser.IsAdmin).
 		WillReturnError(pqError)
+	suite.mock.ExpectRollback()
 
 	ctx := context.Background()
 	result, err := suite.repo.CreateUser(ctx, testUser)
@@ -105,9 +116,11 @@ func (suite *TestSuite) TestCreateUser_Missing_Required_Field() {
 		Column: `column where field is missing`,
 	}
 
+	suite.mock.ExpectBegin()
 	suite.mock.ExpectExec(insertUserQuery).
 		WithArgs(testUser.ID, testUser.Name, testUser.Email, testUser.Password, testUser.IsAdmin).
 		WillReturnError(pqError)
+	suite.mock.ExpectRollback()
 
 	ctx := context.Background()
 	result, err := suite.repo.CreateUser(ctx, testUser)
@@ -130,9 +143,11 @@ func (suite *TestSuite) TestCreateUser_Field_Too_Long() {
 		Column: `column where field is too long`,
 	}
 
+	suite.mock.ExpectBegin()
 	suite.mock.ExpectExec(insertUserQuery).
 		WithArgs(testUser.ID, testUser.Name, testUser.Email, testUser.Password, testUser.IsAdmin).
 		WillReturnError(pqError)
+	suite.mock.ExpectRollback()
 
 	ctx := context.Background()
 	result, err := suite.repo.CreateUser(ctx, testUser)
@@ -148,3 +163,51 @@ func (suite *TestSuite) TestCreateUser_Field_Too_Long() {
 
 	suite.NoError(suite.mock.ExpectationsWereMet())
 }
+
+func (suite *TestSuite) TestCreateUser_EventInsertFails() {
+	suite.mock.ExpectBegin()
+	suite.mock.ExpectExec(insertUserQuery).
+		WithArgs(testUser.ID, testUser.Name, testUser.Email, testUser.Password, testUser.IsAdmin).
+		WillReturnResult(sqlmock.NewResult(1, 1))
+	suite.mock.ExpectExec(insertEventQuery).
+		WithArgs(sqlmock.AnyArg(), "user.registered", testUser.ID, sqlmock.AnyArg(), sqlmock.AnyArg()).
+		WillReturnError(&pq.Error{Code: "08006"})
+	suite.mock.ExpectRollback()
+
+	ctx := context.Background()
+	result, err := suite.repo.CreateUser(ctx, testUser)
+
+	suite.Nil(result)
+
+	svcError, ok := err.(*errs.ServiceError)
+
+	suite.True(ok)
+	suite.Equal(errs.ErrDatabase, svcError.Code)
+	suite.Equal("database connection lost", svcError.Message)
+
+	suite.NoError(suite.mock.ExpectationsWereMet())
+}
+
+func (suite *TestSuite) TestCreateUser_CommitFails() {
+	suite.mock.ExpectBegin()
+	suite.mock.ExpectExec(insertUserQuery).
+		WithArgs(testUser.ID, testUser.Name, testUser.Email, testUser.Password, testUser.IsAdmin).
+		WillReturnResult(sqlmock.NewResult(1, 1))
+	suite.mock.ExpectExec(insertEventQuery).
+		WithArgs(sqlmock.AnyArg(), "user.registered", testUser.ID, sqlmock.AnyArg(), sqlmock.AnyArg()).
+		WillReturnResult(sqlmock.NewResult(1, 1))
+	suite.mock.ExpectCommit().WillReturnError(&pq.Error{Code: "40001"})
+
+	ctx := context.Background()
+	result, err := suite.repo.CreateUser(ctx, testUser)
+
+	suite.Nil(result)
+
+	svcError, ok := err.(*errs.ServiceError)
+
+	suite.True(ok)
+	suite.Equal(errs.ErrDatabase, svcError.Code)
+	suite.Equal("transaction conflict, please retry", svcError.Message)
+
+	suite.NoError(suite.mock.ExpectationsWereMet())
+}
@@ -0,0 +1,33 @@
+package test
+
+import (
+	"context"
+	"database/sql"
+
+	"github.com/DATA-DOG/go-sqlmock"
+)
+
+func (suite *TestSuite) TestDeleteIntersectionID_Success() {
+	suite.mock.ExpectExec(deleteIntersectionIDQuery).
+		WithArgs(testIntersectionIDs[0]).
+		WillReturnResult(sqlmock.NewResult(0, 2))
+
+	ctx := context.Background()
+	removed, err := suite.repo.DeleteIntersectionID(ctx, testIntersectionIDs[0])
+
+	suite.Require().NoError(err)
+	suite.Equal(int64(2), removed)
+	suite.NoError(suite.mock.ExpectationsWereMet())
+}
+
+func (suite *TestSuite) TestDeleteIntersectionID_DatabaseError() {
+	suite.mock.ExpectExec(deleteIntersectionIDQuery).
+		WithArgs(testIntersectionIDs[0]).
+		WillReturnError(sql.ErrConnDone)
+
+	ctx := context.Background()
+	_, err := suite.repo.DeleteIntersectionID(ctx, testIntersectionIDs[0])
+
+	suite.Require().Error(err)
+	suite.NoError(suite.mock.ExpectationsWereMet())
+}
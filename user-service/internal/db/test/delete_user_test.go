@@ -9,9 +9,14 @@ import (
 )
 
 func (suite *TestSuite) TestDeleteUser_Success() {
+	suite.mock.ExpectBegin()
 	suite.mock.ExpectExec(deleteUserQuery).
 		WithArgs(testUser.ID).
 		WillReturnResult(sqlmock.NewResult(1, 1))
+	suite.mock.ExpectExec(insertEventQuery).
+		WithArgs(sqlmock.AnyArg(), "user.deleted", testUser.ID, sqlmock.AnyArg(), sqlmock.AnyArg()).
+		WillReturnResult(sqlmock.NewResult(1, 1))
+	suite.mock.ExpectCommit()
 
 	ctx := context.Background()
 
@@ -22,9 +27,12 @@ func (suite *TestSuite) TestDeleteUser_Success() {
 }
 
 func (suite *TestSuite) TestDeleteUser_Success_UserNotExists() {
+	suite.mock.ExpectBegin()
 	suite.mock.ExpectExec(deleteUserQuery).
 		WithArgs(testUser.ID).
 		WillReturnResult(sqlmock.NewResult(0, 0))
+	// NOTE: No event is written for a user that was not there
+	suite.mock.ExpectCommit()
 
 	ctx := context.Background()
 
@@ -40,9 +48,11 @@ func (suite *TestSuite) TestDeleteUser_ForeignKeyConstraint() {
 		Detail: "Key (uuid)=(test-id) is still referenced from table \"user_intersections\"",
 	}
 
+	suite.mock.ExpectBegin()
 	suite.mock.ExpectExec(deleteUserQuery).
 		WithArgs(testUser.ID).
 		WillReturnError(pqError)
+	suite.mock.ExpectRollback()
 
 	ctx := context.Background()
 
@@ -64,9 +74,11 @@ func (suite *TestSuite) TestDeleteUser_InvalidParameterFormat() {
 		Detail: "invalid input syntax for type uuid",
 	}
 
+	suite.mock.ExpectBegin()
 	suite.mock.ExpectExec(deleteUserQuery).
 		WithArgs(testUser.ID).
 		WillReturnError(pqError)
+	suite.mock.ExpectRollback()
 
 	ctx := context.Background()
 
@@ -134,3 +134,54 @@ func (suite *TestSuite) TestListUsers_GetIntersectionsError() {
 	suite.Nil(result)
 	suite.NoError(suite.mock.ExpectationsWereMet())
 }
+
+func (suite *TestSuite) TestListUsersAfter_Success() {
+	afterID := "550e8400-e29b-41d4-a716-446655440000"
+	limit := 10
+
+	rows := sqlmock.NewRows([]string{
+		"uuid", "name", "email", "password", "is_admin", "created_at", "updated_at",
+	}).AddRow(
+		testUsers[1].ID,
+		testUsers[1].Name,
+		testUsers[1].Email,
+		testUsers[1].Password,
+		testUsers[1].IsAdmin,
+		testUsers[1].CreatedAt,
+		testUsers[1].UpdatedAt,
+	)
+
+	suite.mock.ExpectQuery(listUsersAfterQuery).
+		WithArgs(afterID, limit).
+		WillReturnRows(rows)
+
+	suite.mock.ExpectQuery(getIntersectionIDQuery).
+		WithArgs(testUsers[1].ID).
+		WillReturnRows(sqlmock.NewRows([]string{"intersection_id"}).
+			AddRow("int-3"))
+
+	ctx := context.Background()
+	result, err := suite.repo.ListUsersAfter(ctx, afterID, limit)
+
+	suite.Require().NoError(err)
+	suite.Require().Len(result, 1)
+	suite.Equal(testUsers[1].ID, result[0].ID)
+	suite.Equal([]string{"int-3"}, result[0].IntersectionIDs)
+	suite.NoError(suite.mock.ExpectationsWereMet())
+}
+
+func (suite *TestSuite) TestListUsersAfter_DatabaseError() {
+	afterID := "550e8400-e29b-41d4-a716-446655440000"
+	limit := 10
+
+	suite.mock.ExpectQuery(listUsersAfterQuery).
+		WithArgs(afterID, limit).
+		WillReturnError(sql.ErrConnDone)
+
+	ctx := context.Background()
+	result, err := suite.repo.ListUsersAfter(ctx, afterID, limit)
+
+	suite.Require().Error(err)
+	suite.Nil(result)
+	suite.NoError(suite.mock.ExpectationsWereMet())
+}
@@ -0,0 +1,104 @@
+package test
+
+import (
+	"context"
+	"encoding/json"
+	"time"
+
+	errs "github.com/COS301-SE-2025/Swift-Signals/shared/error"
+	"github.com/COS301-SE-2025/Swift-Signals/shared/events"
+	"github.com/DATA-DOG/go-sqlmock"
+	"github.com/lib/pq"
+)
+
+func (suite *TestSuite) TestPendingEvents_Success() {
+	occurredAt := time.Now()
+	payload := []byte(`{"user_id":"` + testUser.ID + `"}`)
+	rows := sqlmock.NewRows([]string{"id", "type", "aggregate_id", "payload", "occurred_at"}).
+		AddRow("event-1", "user.registered", testUser.ID, payload, occurredAt).
+		AddRow("event-2", "user.deleted", testUser.ID, payload, occurredAt)
+
+	suite.mock.ExpectQuery(pendingEventsQuery).
+		WithArgs(10).
+		WillReturnRows(rows)
+
+	ctx := context.Background()
+	pending, err := suite.repo.PendingEvents(ctx, 10)
+
+	suite.Require().NoError(err)
+	suite.Require().Len(pending, 2)
+	suite.Equal("event-1", pending[0].ID)
+	suite.Equal(events.UserRegistered, pending[0].Type)
+	suite.Equal(testUser.ID, pending[0].AggregateID)
+	suite.Equal(occurredAt, pending[0].OccurredAt)
+	suite.Equal(events.UserDeleted, pending[1].Type)
+
+	var deleted events.UserDeletedPayload
+	suite.Require().NoError(json.Unmarshal(pending[1].Payload, &deleted))
+	suite.Equal(testUser.ID, deleted.UserID)
+
+	suite.NoError(suite.mock.ExpectationsWereMet())
+}
+
+func (suite *TestSuite) TestPendingEvents_Empty() {
+	rows := sqlmock.NewRows([]string{"id", "type", "aggregate_id", "payload", "occurred_at"})
+
+	suite.mock.ExpectQuery(pendingEventsQuery).
+		WithArgs(10).
+		WillReturnRows(rows)
+
+	ctx := context.Background()
+	pending, err := suite.repo.PendingEvents(ctx, 10)
+
+	suite.NoError(err)
+	suite.Empty(pending)
+	suite.NoError(suite.mock.ExpectationsWereMet())
+}
+
+func (suite *TestSuite) TestPendingEvents_QueryError() {
+	suite.mock.ExpectQuery(pendingEventsQuery).
+		WithArgs(10).
+		WillReturnError(&pq.Error{Code: "08003"})
+
+	ctx := context.Background()
+	pending, err := suite.repo.PendingEvents(ctx, 10)
+
+	suite.Nil(pending)
+
+	svcError, ok := err.(*errs.ServiceError)
+
+	suite.True(ok)
+	suite.Equal(errs.ErrDatabase, svcError.Code)
+	suite.Equal("database connection lost", svcError.Message)
+
+	suite.NoError(suite.mock.ExpectationsWereMet())
+}
+
+func (suite *TestSuite) TestMarkEventsPublished_Success() {
+	suite.mock.ExpectExec(markEventsPublishedQuery).
+		WithArgs(pq.Array([]string{"event-1", "event-2"})).
+		WillReturnResult(sqlmock.NewResult(0, 2))
+
+	ctx := context.Background()
+	err := suite.repo.MarkEventsPublished(ctx, []string{"event-1", "event-2"})
+
+	suite.NoError(err)
+	suite.NoError(suite.mock.ExpectationsWereMet())
+}
+
+func (suite *TestSuite) TestMarkEventsPublished_Error() {
+	suite.mock.ExpectExec(markEventsPublishedQuery).
+		WithArgs(pq.Array([]string{"event-1"})).
+		WillReturnError(&pq.Error{Code: "57014"})
+
+	ctx := context.Background()
+	err := suite.repo.MarkEventsPublished(ctx, []string{"event-1"})
+
+	svcError, ok := err.(*errs.ServiceError)
+
+	suite.True(ok)
+	suite.Equal(errs.ErrDatabase, svcError.Code)
+	suite.Equal("query was canceled", svcError.Message)
+
+	suite.NoError(suite.mock.ExpectationsWereMet())
+}
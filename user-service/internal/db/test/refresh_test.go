@@ -0,0 +1,144 @@
+package test
+
+import (
+	"context"
+	"time"
+
+	errs "github.com/COS301-SE-2025/Swift-Signals/shared/error"
+	"github.com/COS301-SE-2025/Swift-Signals/user-service/internal/model"
+	"github.com/DATA-DOG/go-sqlmock"
+	"github.com/lib/pq"
+)
+
+var refreshTokenColumns = []string{"token_hash", "family_id", "user_id", "expires_at", "used_at"}
+
+func (suite *TestSuite) TestCreateRefreshToken_Success() {
+	token := &model.RefreshToken{
+		TokenHash: "hash-1",
+		FamilyID:  "family-1",
+		UserID:    testUser.ID,
+		ExpiresAt: time.Now().Add(time.Hour),
+	}
+	suite.mock.ExpectExec(createRefreshTokenQuery).
+		WithArgs(token.TokenHash, token.FamilyID, token.UserID, token.ExpiresAt).
+		WillReturnResult(sqlmock.NewResult(0, 1))
+
+	ctx := context.Background()
+	err := suite.repo.CreateRefreshToken(ctx, token)
+
+	suite.NoError(err)
+	suite.NoError(suite.mock.ExpectationsWereMet())
+}
+
+func (suite *TestSuite) TestCreateRefreshToken_UserDeleted() {
+	token := &model.RefreshToken{
+		TokenHash: "hash-1",
+		FamilyID:  "family-1",
+		UserID:    testUser.ID,
+		ExpiresAt: time.Now().Add(time.Hour),
+	}
+	suite.mock.ExpectExec(createRefreshTokenQuery).
+		WithArgs(token.TokenHash, token.FamilyID, token.UserID, token.ExpiresAt).
+		WillReturnError(&pq.Error{Code: "23503"})
+
+	ctx := context.Background()
+	err := suite.repo.CreateRefreshToken(ctx, token)
+
+	_, ok := err.(*errs.ServiceError)
+	suite.True(ok)
+	suite.NoError(suite.mock.ExpectationsWereMet())
+}
+
+func (suite *TestSuite) TestClaimRefreshToken_Unused() {
+	now := time.Now()
+	expiresAt := now.Add(time.Hour)
+	suite.mock.ExpectQuery(claimRefreshTokenQuery).
+		WithArgs("hash-1", now).
+		WillReturnRows(sqlmock.NewRows(refreshTokenColumns).
+			AddRow("hash-1", "family-1", testUser.ID, expiresAt, nil))
+
+	ctx := context.Background()
+	token, err := suite.repo.ClaimRefreshToken(ctx, "hash-1", now)
+
+	suite.Require().NoError(err)
+	suite.Equal(&model.RefreshToken{
+		TokenHash: "hash-1",
+		FamilyID:  "family-1",
+		UserID:    testUser.ID,
+		ExpiresAt: expiresAt,
+	}, token)
+	suite.NoError(suite.mock.ExpectationsWereMet())
+}
+
+func (suite *TestSuite) TestClaimRefreshToken_AlreadyUsed() {
+	now := time.Now()
+	usedAt := now.Add(-time.Minute)
+	suite.mock.ExpectQuery(claimRefreshTokenQuery).
+		WithArgs("hash-1", now).
+		WillReturnRows(sqlmock.NewRows(refreshTokenColumns).
+			AddRow("hash-1", "family-1", testUser.ID, now.Add(time.Hour), usedAt))
+
+	ctx := context.Background()
+	token, err := suite.repo.ClaimRefreshToken(ctx, "hash-1", now)
+
+	suite.Require().NoError(err)
+	suite.Require().NotNil(token.UsedAt)
+	suite.Equal(usedAt, *token.UsedAt)
+	suite.NoError(suite.mock.ExpectationsWereMet())
+}
+
+func (suite *TestSuite) TestClaimRefreshToken_NotFound() {
+	now := time.Now()
+	suite.mock.ExpectQuery(claimRefreshTokenQuery).
+		WithArgs("hash-1", now).
+		WillReturnRows(sqlmock.NewRows(refreshTokenColumns))
+
+	ctx := context.Background()
+	token, err := suite.repo.ClaimRefreshToken(ctx, "hash-1", now)
+
+	suite.NoError(err)
+	suite.Nil(token)
+	suite.NoError(suite.mock.ExpectationsWereMet())
+}
+
+func (suite *TestSuite) TestClaimRefreshToken_QueryError() {
+	now := time.Now()
+	suite.mock.ExpectQuery(claimRefreshTokenQuery).
+		WithArgs("hash-1", now).
+		WillReturnError(&pq.Error{Code: "57014"})
+
+	ctx := context.Background()
+	token, err := suite.repo.ClaimRefreshToken(ctx, "hash-1", now)
+
+	suite.Nil(token)
+	svcError, ok := err.(*errs.ServiceError)
+	suite.True(ok)
+	suite.Equal("query was canceled", svcError.Message)
+	suite.NoError(suite.mock.ExpectationsWereMet())
+}
+
+func (suite *TestSuite) TestDeleteRefreshTokenFamily_Success() {
+	suite.mock.ExpectExec(deleteRefreshTokenFamilyQuery).
+		WithArgs("family-1").
+		WillReturnResult(sqlmock.NewResult(0, 3))
+
+	ctx := context.Background()
+	err := suite.repo.DeleteRefreshTokenFamily(ctx, "family-1")
+
+	suite.NoError(err)
+	suite.NoError(suite.mock.ExpectationsWereMet())
+}
+
+func (suite *TestSuite) TestDeleteExpiredRefreshTokens_Success() {
+	before := time.Now()
+	suite.mock.ExpectExec(deleteExpiredRefreshTokensQuery).
+		WithArgs(before).
+		WillReturnResult(sqlmock.NewResult(0, 5))
+
+	ctx := context.Background()
+	deleted, err := suite.repo.DeleteExpiredRefreshTokens(ctx, before)
+
+	suite.Require().NoError(err)
+	suite.Equal(int64(5), deleted)
+	suite.NoError(suite.mock.ExpectationsWereMet())
+}
@@ -0,0 +1,104 @@
+package test
+
+import (
+	"context"
+	"time"
+
+	errs "github.com/COS301-SE-2025/Swift-Signals/shared/error"
+	"github.com/COS301-SE-2025/Swift-Signals/user-service/internal/model"
+	"github.com/DATA-DOG/go-sqlmock"
+	"github.com/lib/pq"
+)
+
+func (suite *TestSuite) TestCreatePasswordResetToken_Success() {
+	token := &model.PasswordResetToken{
+		TokenHash: "hash-1",
+		UserID:    testUser.ID,
+		ExpiresAt: time.Now().Add(time.Hour),
+	}
+	suite.mock.ExpectExec(createPasswordResetTokenQuery).
+		WithArgs(token.TokenHash, token.UserID, token.ExpiresAt).
+		WillReturnResult(sqlmock.NewResult(0, 1))
+
+	ctx := context.Background()
+	err := suite.repo.CreatePasswordResetToken(ctx, token)
+
+	suite.NoError(err)
+	suite.NoError(suite.mock.ExpectationsWereMet())
+}
+
+func (suite *TestSuite) TestResetPassword_Success() {
+	now := time.Now()
+	suite.mock.ExpectBegin()
+	suite.mock.ExpectQuery(claimPasswordResetTokenQuery).
+		WithArgs("hash-1", now).
+		WillReturnRows(sqlmock.NewRows([]string{"user_id"}).AddRow(testUser.ID))
+	suite.mock.ExpectExec(resetUserPasswordQuery).
+		WithArgs("new-hashed-password", testUser.ID, now.Truncate(time.Second)).
+		WillReturnResult(sqlmock.NewResult(0, 1))
+	suite.mock.ExpectExec(usePasswordResetTokensQuery).
+		WithArgs(testUser.ID, now).
+		WillReturnResult(sqlmock.NewResult(0, 1))
+	suite.mock.ExpectExec(deleteUserRefreshTokensQuery).
+		WithArgs(testUser.ID).
+		WillReturnResult(sqlmock.NewResult(0, 2))
+	suite.mock.ExpectCommit()
+
+	ctx := context.Background()
+	userID, err := suite.repo.ResetPassword(ctx, "hash-1", "new-hashed-password", now)
+
+	suite.Require().NoError(err)
+	suite.Equal(testUser.ID, userID)
+	suite.NoError(suite.mock.ExpectationsWereMet())
+}
+
+func (suite *TestSuite) TestResetPassword_TokenUnusable() {
+	now := time.Now()
+	suite.mock.ExpectBegin()
+	suite.mock.ExpectQuery(claimPasswordResetTokenQuery).
+		WithArgs("hash-1", now).
+		WillReturnRows(sqlmock.NewRows([]string{"user_id"}))
+	suite.mock.ExpectCommit()
+
+	ctx := context.Background()
+	userID, err := suite.repo.ResetPassword(ctx, "hash-1", "new-hashed-password", now)
+
+	suite.Require().NoError(err)
+	suite.Empty(userID)
+	suite.NoError(suite.mock.ExpectationsWereMet())
+}
+
+func (suite *TestSuite) TestResetPassword_UpdateError() {
+	now := time.Now()
+	suite.mock.ExpectBegin()
+	suite.mock.ExpectQuery(claimPasswordResetTokenQuery).
+		WithArgs("hash-1", now).
+		WillReturnRows(sqlmock.NewRows([]string{"user_id"}).AddRow(testUser.ID))
+	suite.mock.ExpectExec(resetUserPasswordQuery).
+		WithArgs("new-hashed-password", testUser.ID, now.Truncate(time.Second)).
+		WillReturnError(&pq.Error{Code: "57014"})
+	suite.mock.ExpectRollback()
+
+	ctx := context.Background()
+	userID, err := suite.repo.ResetPassword(ctx, "hash-1", "new-hashed-password", now)
+
+	suite.Empty(userID)
+	svcError, ok := err.(*errs.ServiceError)
+	suite.True(ok)
+	suite.Equal("query was canceled", svcError.Message)
+	suite.NoError(suite.mock.ExpectationsWereMet())
+}
+
+func (suite *TestSuite) TestDeleteExpiredPasswordResetTokens_Success() {
+	before := time.Now()
+	suite.mock.ExpectExec(deleteExpiredPasswordResetTokensQuery).
+		WithArgs(before).
+		WillReturnResult(sqlmock.NewResult(0, 2))
+
+	ctx := context.Background()
+	deleted, err := suite.repo.DeleteExpiredPasswordResetTokens(ctx, before)
+
+	suite.Require().NoError(err)
+	suite.Equal(int64(2), deleted)
+	suite.NoError(suite.mock.ExpectationsWereMet())
+}
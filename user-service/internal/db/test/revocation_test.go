@@ -0,0 +1,84 @@
+package test
+
+import (
+	"context"
+	"time"
+
+	errs "github.com/COS301-SE-2025/Swift-Signals/shared/error"
+	"github.com/DATA-DOG/go-sqlmock"
+	"github.com/lib/pq"
+)
+
+func (suite *TestSuite) TestRevokeToken_Success() {
+	expiresAt := time.Now().Add(time.Hour)
+	suite.mock.ExpectExec(revokeTokenQuery).
+		WithArgs("token-1", testUser.ID, expiresAt).
+		WillReturnResult(sqlmock.NewResult(0, 1))
+
+	ctx := context.Background()
+	err := suite.repo.RevokeToken(ctx, "token-1", testUser.ID, expiresAt)
+
+	suite.NoError(err)
+	suite.NoError(suite.mock.ExpectationsWereMet())
+}
+
+func (suite *TestSuite) TestRevokeToken_ConnectionLost() {
+	expiresAt := time.Now().Add(time.Hour)
+	suite.mock.ExpectExec(revokeTokenQuery).
+		WithArgs("token-1", testUser.ID, expiresAt).
+		WillReturnError(&pq.Error{Code: "08006"})
+
+	ctx := context.Background()
+	err := suite.repo.RevokeToken(ctx, "token-1", testUser.ID, expiresAt)
+
+	svcError, ok := err.(*errs.ServiceError)
+	suite.True(ok)
+	suite.Equal(errs.ErrDatabase, svcError.Code)
+	suite.NoError(suite.mock.ExpectationsWereMet())
+}
+
+func (suite *TestSuite) TestIsTokenRevoked() {
+	issuedAt := time.Now().Truncate(time.Second)
+	for _, revoked := range []bool{true, false} {
+		suite.mock.ExpectQuery(isTokenRevokedQuery).
+			WithArgs("token-1", testUser.ID, issuedAt).
+			WillReturnRows(sqlmock.NewRows([]string{"revoked"}).AddRow(revoked))
+
+		ctx := context.Background()
+		actual, err := suite.repo.IsTokenRevoked(ctx, "token-1", testUser.ID, issuedAt)
+
+		suite.Require().NoError(err)
+		suite.Equal(revoked, actual)
+	}
+	suite.NoError(suite.mock.ExpectationsWereMet())
+}
+
+func (suite *TestSuite) TestIsTokenRevoked_QueryError() {
+	issuedAt := time.Now().Truncate(time.Second)
+	suite.mock.ExpectQuery(isTokenRevokedQuery).
+		WithArgs("token-1", testUser.ID, issuedAt).
+		WillReturnError(&pq.Error{Code: "57014"})
+
+	ctx := context.Background()
+	revoked, err := suite.repo.IsTokenRevoked(ctx, "token-1", testUser.ID, issuedAt)
+
+	suite.False(revoked)
+	svcError, ok := err.(*errs.ServiceError)
+	suite.True(ok)
+	suite.Equal("query was canceled", svcError.Message)
+	suite.NoError(suite.mock.ExpectationsWereMet())
+}
+
+func (suite *TestSuite) TestDeleteExpiredTokens_Success() {
+	before := time.Now()
+	suite.mock.ExpectExec(deleteExpiredTokensQuery).
+		WithArgs(before).
+		WillReturnResult(sqlmock.NewResult(0, 3))
+
+	ctx := context.Background()
+	deleted, err := suite.repo.DeleteExpiredTokens(ctx, before)
+
+	suite.NoError(err)
+	suite.Equal(int64(3), deleted)
+	suite.NoError(suite.mock.ExpectationsWereMet())
+}
@@ -5,6 +5,7 @@ import (
 
 	userpb "github.com/COS301-SE-2025/Swift-Signals/protos/gen/swiftsignals/user/v1"
 	errs "github.com/COS301-SE-2025/Swift-Signals/shared/error"
+	"github.com/COS301-SE-2025/Swift-Signals/user-service/internal/model"
 	"github.com/COS301-SE-2025/Swift-Signals/user-service/internal/service"
 	"github.com/COS301-SE-2025/Swift-Signals/user-service/internal/util"
 	"google.golang.org/protobuf/types/known/emptypb"
@@ -55,7 +56,7 @@ func (h *Handler) LoginUser(
 ) (*userpb.LoginUserResponse, error) {
 	logger := util.LoggerFromContext(ctx)
 	logger.Info("processing LoginUser request")
-	token, expiryTime, err := h.service.LoginUser(ctx, req.GetEmail(), req.GetPassword())
+	tokens, err := h.service.LoginUser(ctx, req.GetEmail(), req.GetPassword())
 	if err != nil {
 		logger.Error("login failed",
 			"error", err.Error(),
@@ -63,23 +64,37 @@ func (h *Handler) LoginUser(
 		return nil, errs.HandleServiceError(err)
 	}
 
-	logger.Info("LoginUser successful",
-		"token", token,
-	)
-	return &userpb.LoginUserResponse{
-		Token:     token,
-		ExpiresAt: timestamppb.New(expiryTime),
-	}, nil
+	logger.Info("LoginUser successful")
+	return tokensToProto(tokens), nil
+}
+
+func (h *Handler) RefreshToken(
+	ctx context.Context,
+	req *userpb.RefreshTokenRequest,
+) (*userpb.LoginUserResponse, error) {
+	logger := util.LoggerFromContext(ctx)
+	logger.Info("processing RefreshToken request")
+
+	tokens, err := h.service.RefreshToken(ctx, req.GetRefreshToken())
+	if err != nil {
+		logger.Error("refresh failed",
+			"error", err.Error(),
+		)
+		return nil, errs.HandleServiceError(err)
+	}
+
+	logger.Info("RefreshToken successful")
+	return tokensToProto(tokens), nil
 }
 
 func (h *Handler) LogoutUser(
 	ctx context.Context,
-	req *userpb.UserIDRequest,
+	req *userpb.LogoutUserRequest,
 ) (*emptypb.Empty, error) {
 	logger := util.LoggerFromContext(ctx)
 	logger.Info("processing LogoutUser request")
 
-	err := h.service.LogoutUser(ctx, req.GetUserId())
+	err := h.service.LogoutUser(ctx, req.GetToken(), req.GetRefreshToken())
 	if err != nil {
 		logger.Error("logout failed",
 			"error", err.Error(),
@@ -91,6 +106,32 @@ func (h *Handler) LogoutUser(
 	return &emptypb.Empty{}, nil
 }
 
+func (h *Handler) IsTokenRevoked(
+	ctx context.Context,
+	req *userpb.TokenRevokedRequest,
+) (*userpb.TokenRevokedResponse, error) {
+	logger := util.LoggerFromContext(ctx)
+	logger.Debug("processing IsTokenRevoked request")
+
+	revoked, err := h.service.IsTokenRevoked(
+		ctx,
+		req.GetUserId(),
+		req.GetTokenId(),
+		req.GetIssuedAt().AsTime(),
+	)
+	if err != nil {
+		logger.Error("failed to check token revocation",
+			"error", err.Error(),
+		)
+		return nil, errs.HandleServiceError(err)
+	}
+
+	logger.Debug("IsTokenRevoked successful",
+		"revoked", revoked,
+	)
+	return &userpb.TokenRevokedResponse{Revoked: revoked}, nil
+}
+
 func (h *Handler) GetUserByID(
 	ctx context.Context,
 	req *userpb.UserIDRequest,
@@ -158,7 +199,13 @@ func (h *Handler) GetAllUsers(
 	logger := util.LoggerFromContext(ctx)
 	logger.Info("processing GetAllUsers request")
 
-	users, err := h.service.GetAllUsers(ctx, req.GetPage(), req.GetPageSize(), req.GetFilter())
+	var users []*model.User
+	var err error
+	if req.GetAfterId() != "" {
+		users, err = h.service.GetUsersAfter(ctx, req.GetAfterId(), req.GetPageSize())
+	} else {
+		users, err = h.service.GetAllUsers(ctx, req.GetPage(), req.GetPageSize(), req.GetFilter())
+	}
 	if err != nil {
 		logger.Error("failed to get all users",
 			"error", err.Error(),
@@ -350,6 +397,25 @@ func (h *Handler) ResetPassword(
 	return &emptypb.Empty{}, nil
 }
 
+func (h *Handler) ConfirmPasswordReset(
+	ctx context.Context,
+	req *userpb.ConfirmPasswordResetRequest,
+) (*emptypb.Empty, error) {
+	logger := util.LoggerFromContext(ctx)
+	logger.Info("processing ConfirmPasswordReset request")
+
+	err := h.service.ConfirmPasswordReset(ctx, req.GetToken(), req.GetNewPassword())
+	if err != nil {
+		logger.Error("failed to confirm password reset",
+			"error", err.Error(),
+		)
+		return nil, errs.HandleServiceError(err)
+	}
+
+	logger.Info("ConfirmPasswordReset successful")
+	return &emptypb.Empty{}, nil
+}
+
 func (h *Handler) MakeAdmin(ctx context.Context, req *userpb.AdminRequest) (*emptypb.Empty, error) {
 	logger := util.LoggerFromContext(ctx)
 	logger.Info("processing MakeAdmin request")
@@ -384,3 +450,12 @@ func (h *Handler) RemoveAdmin(
 	logger.Info("RemoveAdmin successful")
 	return &emptypb.Empty{}, nil
 }
+
+func tokensToProto(tokens *model.Tokens) *userpb.LoginUserResponse {
+	return &userpb.LoginUserResponse{
+		Token:            tokens.AccessToken,
+		ExpiresAt:        timestamppb.New(tokens.AccessExpiresAt),
+		RefreshToken:     tokens.RefreshToken,
+		RefreshExpiresAt: timestamppb.New(tokens.RefreshExpiresAt),
+	}
+}
@@ -0,0 +1,52 @@
+package test
+
+import (
+	"context"
+
+	userpb "github.com/COS301-SE-2025/Swift-Signals/protos/gen/swiftsignals/user/v1"
+	errs "github.com/COS301-SE-2025/Swift-Signals/shared/error"
+	"github.com/stretchr/testify/mock"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+func (suite *TestSuite) TestConfirmPasswordReset_Success() {
+	req := &userpb.ConfirmPasswordResetRequest{
+		Token:       "reset-token",
+		NewPassword: "newpassword456",
+	}
+
+	suite.service.On("ConfirmPasswordReset", mock.Anything, req.GetToken(), req.GetNewPassword()).
+		Return(nil)
+
+	ctx := context.Background()
+
+	result, err := suite.handler.ConfirmPasswordReset(ctx, req)
+
+	suite.Require().NoError(err)
+	suite.NotNil(result)
+
+	suite.service.AssertExpectations(suite.T())
+}
+
+func (suite *TestSuite) TestConfirmPasswordReset_InvalidToken() {
+	req := &userpb.ConfirmPasswordResetRequest{
+		Token:       "used-token",
+		NewPassword: "newpassword456",
+	}
+
+	suite.service.On("ConfirmPasswordReset", mock.Anything, req.GetToken(), req.GetNewPassword()).
+		Return(errs.NewUnauthorizedError("invalid or expired reset token", nil))
+
+	ctx := context.Background()
+
+	result, err := suite.handler.ConfirmPasswordReset(ctx, req)
+
+	suite.Nil(result)
+	st, ok := status.FromError(err)
+	suite.True(ok)
+	suite.Equal(codes.Unauthenticated, st.Code())
+	suite.Equal("invalid or expired reset token", st.Message())
+
+	suite.service.AssertExpectations(suite.T())
+}
@@ -146,3 +146,44 @@ func (suite *TestSuite) TestGetAllUsers_StreamSendFailure() {
 	suite.service.AssertExpectations(suite.T())
 	mockStream.AssertExpectations(suite.T())
 }
+
+func (suite *TestSuite) TestGetAllUsers_AfterID() {
+	req := &userpb.GetAllUsersRequest{
+		PageSize: 10,
+		AfterId:  "user1",
+	}
+
+	expectedUsers := []*model.User{
+		{
+			ID:              "user2",
+			Name:            "Jane Smith",
+			Email:           "jane@example.com",
+			IntersectionIDs: []string{"intersection2"},
+			CreatedAt:       time.Now(),
+			UpdatedAt:       time.Now(),
+		},
+	}
+
+	ctx := context.Background()
+
+	mockStream := grpcmocks.NewMockUserService_GetAllUsersServer[userpb.UserResponse](suite.T())
+
+	mockStream.On("Context").Return(ctx)
+
+	suite.service.On("GetUsersAfter", ctx, req.AfterId, req.PageSize).
+		Return(expectedUsers, nil)
+
+	mockStream.On("Send", mock.MatchedBy(func(resp *userpb.UserResponse) bool {
+		return resp.Id == "user2"
+	})).Return(nil)
+
+	err := suite.handler.GetAllUsers(req, mockStream)
+
+	suite.Require().NoError(err)
+
+	suite.service.AssertExpectations(suite.T())
+	suite.service.AssertNotCalled(
+		suite.T(), "GetAllUsers", mock.Anything, mock.Anything, mock.Anything, mock.Anything,
+	)
+	mockStream.AssertExpectations(suite.T())
+}
@@ -0,0 +1,78 @@
+package test
+
+import (
+	"context"
+	"errors"
+	"time"
+
+	userpb "github.com/COS301-SE-2025/Swift-Signals/protos/gen/swiftsignals/user/v1"
+	"github.com/stretchr/testify/mock"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+	"google.golang.org/protobuf/types/known/timestamppb"
+)
+
+func (suite *TestSuite) TestIsTokenRevoked_Revoked() {
+	req := &userpb.TokenRevokedRequest{
+		UserId:   "valid-user-id",
+		TokenId:  "token-1",
+		IssuedAt: timestamppb.New(time.Unix(1700000000, 0)),
+	}
+
+	ctx := context.Background()
+
+	suite.service.On("IsTokenRevoked", ctx, req.UserId, req.TokenId, req.IssuedAt.AsTime()).
+		Return(true, nil)
+
+	result, err := suite.handler.IsTokenRevoked(ctx, req)
+
+	suite.Require().NoError(err)
+	suite.True(result.GetRevoked())
+
+	suite.service.AssertExpectations(suite.T())
+}
+
+func (suite *TestSuite) TestIsTokenRevoked_NotRevoked() {
+	req := &userpb.TokenRevokedRequest{
+		UserId:   "valid-user-id",
+		TokenId:  "token-1",
+		IssuedAt: timestamppb.New(time.Unix(1700000000, 0)),
+	}
+
+	ctx := context.Background()
+
+	suite.service.On("IsTokenRevoked", ctx, req.UserId, req.TokenId, req.IssuedAt.AsTime()).
+		Return(false, nil)
+
+	result, err := suite.handler.IsTokenRevoked(ctx, req)
+
+	suite.Require().NoError(err)
+	suite.False(result.GetRevoked())
+
+	suite.service.AssertExpectations(suite.T())
+}
+
+func (suite *TestSuite) TestIsTokenRevoked_Failure() {
+	req := &userpb.TokenRevokedRequest{
+		UserId:   "valid-user-id",
+		TokenId:  "token-1",
+		IssuedAt: timestamppb.New(time.Unix(1700000000, 0)),
+	}
+
+	suite.service.On("IsTokenRevoked", mock.Anything, req.GetUserId(), req.GetTokenId(),
+		mock.Anything).
+		Return(false, errors.New("database unavailable"))
+
+	ctx := context.Background()
+
+	result, err := suite.handler.IsTokenRevoked(ctx, req)
+
+	suite.Nil(result)
+	suite.Require().Error(err)
+
+	st, ok := status.FromError(err)
+	suite.True(ok)
+	suite.Equal(codes.Internal, st.Code())
+
+	suite.service.AssertExpectations(suite.T())
+}
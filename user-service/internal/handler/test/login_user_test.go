@@ -6,6 +6,7 @@ import (
 	"time"
 
 	userpb "github.com/COS301-SE-2025/Swift-Signals/protos/gen/swiftsignals/user/v1"
+	"github.com/COS301-SE-2025/Swift-Signals/user-service/internal/model"
 	"github.com/stretchr/testify/mock"
 	"google.golang.org/grpc/codes"
 	"google.golang.org/grpc/status"
@@ -17,19 +18,28 @@ func (suite *TestSuite) TestLoginUser_Success() {
 		Password: "validpassword",
 	}
 
-	expectedToken := "jwt-token-12345"
-	expectedExpiryTime := time.Now().Add(24 * time.Hour)
+	expectedTokens := &model.Tokens{
+		AccessToken:      "jwt-token-12345",
+		AccessExpiresAt:  time.Now().Add(15 * time.Minute),
+		RefreshToken:     "refresh-token-12345",
+		RefreshExpiresAt: time.Now().Add(7 * 24 * time.Hour),
+	}
 
 	ctx := context.Background()
 
 	suite.service.On("LoginUser", ctx, req.Email, req.Password).
-		Return(expectedToken, expectedExpiryTime, nil)
+		Return(expectedTokens, nil)
 
 	result, err := suite.handler.LoginUser(ctx, req)
 
 	suite.Require().NoError(err)
-	suite.Equal(expectedToken, result.GetToken())
-	suite.Equal(expectedExpiryTime.Unix(), result.GetExpiresAt().GetSeconds())
+	suite.Equal(expectedTokens.AccessToken, result.GetToken())
+	suite.Equal(expectedTokens.AccessExpiresAt.Unix(), result.GetExpiresAt().GetSeconds())
+	suite.Equal(expectedTokens.RefreshToken, result.GetRefreshToken())
+	suite.Equal(
+		expectedTokens.RefreshExpiresAt.Unix(),
+		result.GetRefreshExpiresAt().GetSeconds(),
+	)
 
 	suite.service.AssertExpectations(suite.T())
 }
@@ -41,7 +51,7 @@ func (suite *TestSuite) TestLoginUser_Failure() {
 	}
 
 	suite.service.On("LoginUser", mock.Anything, req.GetEmail(), req.GetPassword()).
-		Return("", time.Time{}, errors.New("invalid credentials"))
+		Return(nil, errors.New("invalid credentials"))
 
 	ctx := context.Background()
 
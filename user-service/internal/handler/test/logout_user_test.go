@@ -5,19 +5,21 @@ import (
 	"errors"
 
 	userpb "github.com/COS301-SE-2025/Swift-Signals/protos/gen/swiftsignals/user/v1"
+	errs "github.com/COS301-SE-2025/Swift-Signals/shared/error"
 	"github.com/stretchr/testify/mock"
 	"google.golang.org/grpc/codes"
 	"google.golang.org/grpc/status"
 )
 
 func (suite *TestSuite) TestLogoutUser_Success() {
-	req := &userpb.UserIDRequest{
-		UserId: "valid-user-id",
+	req := &userpb.LogoutUserRequest{
+		Token:        "valid.jwt.token",
+		RefreshToken: "refresh-token-12345",
 	}
 
 	ctx := context.Background()
 
-	suite.service.On("LogoutUser", ctx, req.UserId).
+	suite.service.On("LogoutUser", ctx, req.Token, req.RefreshToken).
 		Return(nil)
 
 	result, err := suite.handler.LogoutUser(ctx, req)
@@ -28,13 +30,36 @@ func (suite *TestSuite) TestLogoutUser_Success() {
 	suite.service.AssertExpectations(suite.T())
 }
 
+func (suite *TestSuite) TestLogoutUser_InvalidToken() {
+	req := &userpb.LogoutUserRequest{
+		Token: "invalid-token",
+	}
+
+	suite.service.On("LogoutUser", mock.Anything, req.GetToken(), req.GetRefreshToken()).
+		Return(errs.NewUnauthorizedError("invalid token", nil))
+
+	ctx := context.Background()
+
+	result, err := suite.handler.LogoutUser(ctx, req)
+
+	suite.Nil(result)
+	suite.Require().Error(err)
+
+	st, ok := status.FromError(err)
+	suite.True(ok)
+	suite.Equal(codes.Unauthenticated, st.Code())
+	suite.Equal("invalid token", st.Message())
+
+	suite.service.AssertExpectations(suite.T())
+}
+
 func (suite *TestSuite) TestLogoutUser_Failure() {
-	req := &userpb.UserIDRequest{
-		UserId: "invalid-user-id",
+	req := &userpb.LogoutUserRequest{
+		Token: "valid.jwt.token",
 	}
 
-	suite.service.On("LogoutUser", mock.Anything, req.GetUserId()).
-		Return(errors.New("user not found"))
+	suite.service.On("LogoutUser", mock.Anything, req.GetToken(), req.GetRefreshToken()).
+		Return(errors.New("database unavailable"))
 
 	ctx := context.Background()
 
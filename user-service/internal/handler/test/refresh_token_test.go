@@ -0,0 +1,66 @@
+package test
+
+import (
+	"context"
+	"time"
+
+	userpb "github.com/COS301-SE-2025/Swift-Signals/protos/gen/swiftsignals/user/v1"
+	errs "github.com/COS301-SE-2025/Swift-Signals/shared/error"
+	"github.com/COS301-SE-2025/Swift-Signals/user-service/internal/model"
+	"github.com/stretchr/testify/mock"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+func (suite *TestSuite) TestRefreshToken_Success() {
+	req := &userpb.RefreshTokenRequest{
+		RefreshToken: "refresh-token-12345",
+	}
+
+	expectedTokens := &model.Tokens{
+		AccessToken:      "jwt-token-67890",
+		AccessExpiresAt:  time.Now().Add(15 * time.Minute),
+		RefreshToken:     "refresh-token-67890",
+		RefreshExpiresAt: time.Now().Add(7 * 24 * time.Hour),
+	}
+
+	ctx := context.Background()
+
+	suite.service.On("RefreshToken", ctx, req.RefreshToken).
+		Return(expectedTokens, nil)
+
+	result, err := suite.handler.RefreshToken(ctx, req)
+
+	suite.Require().NoError(err)
+	suite.Equal(expectedTokens.AccessToken, result.GetToken())
+	suite.Equal(expectedTokens.RefreshToken, result.GetRefreshToken())
+	suite.Equal(
+		expectedTokens.RefreshExpiresAt.Unix(),
+		result.GetRefreshExpiresAt().GetSeconds(),
+	)
+
+	suite.service.AssertExpectations(suite.T())
+}
+
+func (suite *TestSuite) TestRefreshToken_Reused() {
+	req := &userpb.RefreshTokenRequest{
+		RefreshToken: "refresh-token-12345",
+	}
+
+	suite.service.On("RefreshToken", mock.Anything, req.GetRefreshToken()).
+		Return(nil, errs.NewUnauthorizedError("refresh token has already been used", nil))
+
+	ctx := context.Background()
+
+	result, err := suite.handler.RefreshToken(ctx, req)
+
+	suite.Nil(result)
+	suite.Require().Error(err)
+
+	st, ok := status.FromError(err)
+	suite.True(ok)
+	suite.Equal(codes.Unauthenticated, st.Code())
+	suite.Equal("refresh token has already been used", st.Message())
+
+	suite.service.AssertExpectations(suite.T())
+}
@@ -5,6 +5,7 @@ import (
 	"errors"
 
 	userpb "github.com/COS301-SE-2025/Swift-Signals/protos/gen/swiftsignals/user/v1"
+	errs "github.com/COS301-SE-2025/Swift-Signals/shared/error"
 	"github.com/stretchr/testify/mock"
 	"google.golang.org/grpc/codes"
 	"google.golang.org/grpc/status"
@@ -50,3 +51,22 @@ func (suite *TestSuite) TestResetPassword_Failure() {
 
 	suite.service.AssertExpectations(suite.T())
 }
+
+func (suite *TestSuite) TestResetPassword_RateLimited() {
+	req := &userpb.ResetPasswordRequest{
+		Email: "valid@example.com",
+	}
+
+	suite.service.On("ResetPassword", mock.Anything, req.GetEmail()).
+		Return(errs.NewRateLimitedError("too many password reset requests, try again later", nil))
+
+	ctx := context.Background()
+
+	result, err := suite.handler.ResetPassword(ctx, req)
+
+	suite.Nil(result)
+	st, ok := status.FromError(err)
+	suite.True(ok)
+	suite.Equal(codes.ResourceExhausted, st.Code())
+	suite.Equal("too many password reset requests, try again later", st.Message())
+}
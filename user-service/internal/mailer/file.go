@@ -0,0 +1,43 @@
+package mailer
+
+import (
+	"context"
+	"crypto/rand"
+	"fmt"
+	"os"
+	"path/filepath"
+	"time"
+)
+
+// FileMailer drops each email into a directory as an .eml file, for tests and
+// environments where another tool picks mail up from disk
+type FileMailer struct {
+	dir  string
+	from string
+}
+
+// NewFileMailer creates the directory if it does not exist yet
+func NewFileMailer(dir, from string) (*FileMailer, error) {
+	if err := os.MkdirAll(dir, 0o700); err != nil {
+		return nil, err
+	}
+	return &FileMailer{
+		dir:  dir,
+		from: from,
+	}, nil
+}
+
+// Send writes the message to a file named by when it was sent, so that the directory lists
+// emails in order. The files are only readable by the service, as they hold reset links.
+func (m *FileMailer) Send(_ context.Context, msg Message) error {
+	now := time.Now()
+	body, err := format(m.from, msg, now)
+	if err != nil {
+		return err
+	}
+	name := fmt.Sprintf("%s-%s.eml", now.UTC().Format("20060102T150405.000000000Z"), rand.Text())
+	return os.WriteFile(filepath.Join(m.dir, name), body, 0o600)
+}
+
+// NOTE: Asserts Interface Implementation
+var _ Mailer = (*FileMailer)(nil)
@@ -0,0 +1,46 @@
+// Package mailer sends the emails the user service needs, such as password reset links,
+// through SMTP or, where there is no mail server, to stdout or files on disk
+package mailer
+
+import (
+	"bytes"
+	"context"
+	"fmt"
+	"strings"
+	"time"
+)
+
+// Message is a plain text email
+type Message struct {
+	To      string
+	Subject string
+	Body    string
+}
+
+type Mailer interface {
+	Send(ctx context.Context, msg Message) error
+}
+
+// format renders the message as an RFC 5322 email. Headers containing line breaks are
+// refused, so that a message cannot smuggle in headers of its own.
+func format(from string, msg Message, date time.Time) ([]byte, error) {
+	headers := [][2]string{
+		{"From", from},
+		{"To", msg.To},
+		{"Subject", msg.Subject},
+	}
+	var buf bytes.Buffer
+	for _, header := range headers {
+		if strings.ContainsAny(header[1], "\r\n") {
+			return nil, fmt.Errorf("mailer: %s header contains a line break", header[0])
+		}
+		fmt.Fprintf(&buf, "%s: %s\r\n", header[0], header[1])
+	}
+	fmt.Fprintf(&buf, "Date: %s\r\n", date.Format(time.RFC1123Z))
+	buf.WriteString("MIME-Version: 1.0\r\n")
+	buf.WriteString("Content-Type: text/plain; charset=UTF-8\r\n")
+	buf.WriteString("\r\n")
+	buf.WriteString(strings.ReplaceAll(strings.ReplaceAll(msg.Body, "\r\n", "\n"), "\n", "\r\n"))
+	buf.WriteString("\r\n")
+	return buf.Bytes(), nil
+}
@@ -0,0 +1,45 @@
+package mailer
+
+import (
+	"context"
+	"io"
+	"sync"
+	"time"
+)
+
+// StdoutMailer writes emails out instead of sending them, which is enough for development
+// where the links they contain can be copied from the logs
+type StdoutMailer struct {
+	mu   sync.Mutex
+	w    io.Writer
+	from string
+}
+
+// NewStdoutMailer writes to w, which is normally os.Stdout
+func NewStdoutMailer(w io.Writer, from string) *StdoutMailer {
+	return &StdoutMailer{
+		w:    w,
+		from: from,
+	}
+}
+
+func (m *StdoutMailer) Send(_ context.Context, msg Message) error {
+	body, err := format(m.from, msg, time.Now())
+	if err != nil {
+		return err
+	}
+
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	if _, err := io.WriteString(m.w, "----- email -----\n"); err != nil {
+		return err
+	}
+	if _, err := m.w.Write(body); err != nil {
+		return err
+	}
+	_, err = io.WriteString(m.w, "----- end of email -----\n")
+	return err
+}
+
+// NOTE: Asserts Interface Implementation
+var _ Mailer = (*StdoutMailer)(nil)
@@ -0,0 +1,88 @@
+package test
+
+import (
+	"bytes"
+	"context"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/COS301-SE-2025/Swift-Signals/user-service/internal/mailer"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+const testFrom = "Swift Signals <no-reply@swiftsignals.example>"
+
+var testMessage = mailer.Message{
+	To:      "test@example.com",
+	Subject: "Reset your password",
+	Body:    "Follow this link:\nhttps://swiftsignals.example/reset?token=ABC\n",
+}
+
+func TestStdoutMailer_Send(t *testing.T) {
+	var buf bytes.Buffer
+	m := mailer.NewStdoutMailer(&buf, testFrom)
+
+	err := m.Send(context.Background(), testMessage)
+
+	require.NoError(t, err)
+	out := buf.String()
+	assert.Contains(t, out, "From: "+testFrom+"\r\n")
+	assert.Contains(t, out, "To: test@example.com\r\n")
+	assert.Contains(t, out, "Subject: Reset your password\r\n")
+	assert.Contains(t, out, "\r\n\r\nFollow this link:\r\nhttps://swiftsignals.example/reset?token=ABC\r\n")
+}
+
+func TestStdoutMailer_RejectsHeaderInjection(t *testing.T) {
+	var buf bytes.Buffer
+	m := mailer.NewStdoutMailer(&buf, testFrom)
+	msg := testMessage
+	msg.Subject = "Hello\r\nBcc: attacker@example.com"
+
+	err := m.Send(context.Background(), msg)
+
+	assert.Error(t, err)
+	assert.Empty(t, buf.String())
+}
+
+func TestFileMailer_Send(t *testing.T) {
+	dir := filepath.Join(t.TempDir(), "mail")
+	m, err := mailer.NewFileMailer(dir, testFrom)
+	require.NoError(t, err)
+
+	require.NoError(t, m.Send(context.Background(), testMessage))
+	require.NoError(t, m.Send(context.Background(), testMessage))
+
+	entries, err := os.ReadDir(dir)
+	require.NoError(t, err)
+	require.Len(t, entries, 2, "each email should get its own file")
+	for _, entry := range entries {
+		assert.True(t, strings.HasSuffix(entry.Name(), ".eml"))
+
+		info, err := entry.Info()
+		require.NoError(t, err)
+		assert.Equal(t, os.FileMode(0o600), info.Mode().Perm())
+
+		content, err := os.ReadFile(filepath.Join(dir, entry.Name()))
+		require.NoError(t, err)
+		assert.Contains(t, string(content), "To: test@example.com\r\n")
+		assert.Contains(t, string(content), "https://swiftsignals.example/reset?token=ABC")
+	}
+}
+
+func TestFileMailer_RejectsHeaderInjection(t *testing.T) {
+	dir := t.TempDir()
+	m, err := mailer.NewFileMailer(dir, testFrom)
+	require.NoError(t, err)
+	msg := testMessage
+	msg.To = "test@example.com\nBcc: attacker@example.com"
+
+	err = m.Send(context.Background(), msg)
+
+	assert.Error(t, err)
+	entries, err := os.ReadDir(dir)
+	require.NoError(t, err)
+	assert.Empty(t, entries)
+}
@@ -26,3 +26,31 @@ func (u *User) PublicUser() *User {
 		UpdatedAt:       u.UpdatedAt,
 	}
 }
+
+// RefreshToken is kept by the hash of the token, so that the token itself is only ever
+// known to the client it was issued to
+type RefreshToken struct {
+	TokenHash string     `db:"token_hash"`
+	FamilyID  string     `db:"family_id"`
+	UserID    string     `db:"user_id"`
+	ExpiresAt time.Time  `db:"expires_at"`
+	UsedAt    *time.Time `db:"used_at"`
+}
+
+// PasswordResetToken is kept by the hash of the token, like a refresh token, as the token
+// itself is only ever sent to the user's email address
+type PasswordResetToken struct {
+	TokenHash string     `db:"token_hash"`
+	UserID    string     `db:"user_id"`
+	ExpiresAt time.Time  `db:"expires_at"`
+	UsedAt    *time.Time `db:"used_at"`
+}
+
+// Tokens are what a login or refresh issues: a short-lived access token along with the
+// refresh token that replaces it once it expires
+type Tokens struct {
+	AccessToken      string
+	AccessExpiresAt  time.Time
+	RefreshToken     string
+	RefreshExpiresAt time.Time
+}
@@ -4,23 +4,31 @@ import (
 	"context"
 	"time"
 
+	"github.com/COS301-SE-2025/Swift-Signals/shared/events"
+
 	"github.com/COS301-SE-2025/Swift-Signals/user-service/internal/model"
 )
 
 type UserService interface {
 	RegisterUser(ctx context.Context, name, email, password string) (*model.User, error)
-	LoginUser(ctx context.Context, email, password string) (string, time.Time, error)
-	LogoutUser(ctx context.Context, userID string) error
+	LoginUser(ctx context.Context, email, password string) (*model.Tokens, error)
+	RefreshToken(ctx context.Context, refreshToken string) (*model.Tokens, error)
+	LogoutUser(ctx context.Context, token, refreshToken string) error
+	IsTokenRevoked(ctx context.Context, userID, tokenID string, issuedAt time.Time) (bool, error)
+	PurgeExpiredTokens(ctx context.Context) (int64, error)
 	GetUserByID(ctx context.Context, userID string) (*model.User, error)
 	GetUserByEmail(ctx context.Context, email string) (*model.User, error)
 	GetAllUsers(ctx context.Context, page, pageSize int32, filter string) ([]*model.User, error)
+	GetUsersAfter(ctx context.Context, afterID string, pageSize int32) ([]*model.User, error)
 	UpdateUser(ctx context.Context, userID, name, email string) (*model.User, error)
 	DeleteUser(ctx context.Context, userID string) error
 	GetUserIntersectionIDs(ctx context.Context, userID string) ([]string, error)
 	AddIntersectionID(ctx context.Context, userID string, intersectionID string) error
 	RemoveIntersectionIDs(ctx context.Context, userID string, intersectionIDs []string) error
+	HandleEvent(ctx context.Context, event events.Event) error
 	ChangePassword(ctx context.Context, userID, currentPassword, newPassword string) error
 	ResetPassword(ctx context.Context, email string) error
+	ConfirmPasswordReset(ctx context.Context, token, newPassword string) error
 	MakeAdmin(ctx context.Context, userID, adminUserID string) error
 	RemoveAdmin(ctx context.Context, userID, adminUserID string) error
 }
@@ -52,6 +60,11 @@ type ResetPasswordRequest struct {
 	Email string `validate:"required,email,max=255" json:"email"`
 }
 
+type ConfirmPasswordResetRequest struct {
+	Token       string `validate:"required,max=128"       json:"token"`
+	NewPassword string `validate:"required,min=8,max=128" json:"new_password"`
+}
+
 type GetUserByIDRequest struct {
 	UserID string `validate:"required,uuid4" json:"user_id"`
 }
@@ -66,12 +79,28 @@ type GetAllUsersRequest struct {
 	Filter   string `validate:"max=255"       json:"filter"`
 }
 
+type GetUsersAfterRequest struct {
+	AfterID  string `validate:"required,uuid" json:"after_id"`
+	PageSize int32  `validate:"min=1,max=100" json:"page_size"`
+}
+
 type DeleteUserRequest struct {
 	UserID string `validate:"required,uuid4" json:"user_id"`
 }
 
+type RefreshTokenRequest struct {
+	RefreshToken string `validate:"required,max=128" json:"refresh_token"`
+}
+
 type LogoutUserRequest struct {
-	UserID string `validate:"required,uuid4" json:"user_id"`
+	Token        string `validate:"required" json:"token"`
+	RefreshToken string `validate:"max=128"  json:"refresh_token"`
+}
+
+type IsTokenRevokedRequest struct {
+	UserID   string    `validate:"required,uuid4" json:"user_id"`
+	TokenID  string    `validate:"max=64"         json:"token_id"`
+	IssuedAt time.Time `json:"issued_at"`
 }
 
 type GetUserIntersectionIDsRequest struct {
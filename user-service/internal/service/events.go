@@ -0,0 +1,54 @@
+package service
+
+import (
+	"context"
+	"errors"
+
+	errs "github.com/COS301-SE-2025/Swift-Signals/shared/error"
+	"github.com/COS301-SE-2025/Swift-Signals/shared/events"
+	"github.com/COS301-SE-2025/Swift-Signals/user-service/internal/util"
+)
+
+// HandleEvent reacts to the events other services relay to this one. Purged intersections
+// are dropped from every user's list, and other events are ignored.
+func (s *Service) HandleEvent(ctx context.Context, event events.Event) error {
+	logger := util.LoggerFromContext(ctx)
+
+	switch event.Type {
+	case events.IntersectionPurged:
+		var payload events.IntersectionPurgedPayload
+		if err := event.Decode(&payload); err != nil {
+			return errs.NewValidationError(
+				"invalid intersection purged event",
+				map[string]any{"eventID": event.ID},
+			)
+		}
+		if payload.IntersectionID == "" {
+			return errs.NewValidationError(
+				"intersection purged event has no intersection id",
+				map[string]any{"eventID": event.ID},
+			)
+		}
+
+		logger.Debug("removing purged intersection from users", "intersection_id", payload.IntersectionID)
+		removed, err := s.repo.DeleteIntersectionID(ctx, payload.IntersectionID)
+		if err != nil {
+			var svcErr *errs.ServiceError
+			if errors.As(err, &svcErr) {
+				return err
+			}
+			return errs.NewInternalError(
+				"failed to remove purged intersection",
+				err,
+				map[string]any{"intersectionID": payload.IntersectionID},
+			)
+		}
+		logger.Info("removed purged intersection from users",
+			"intersection_id", payload.IntersectionID,
+			"users", removed,
+		)
+	default:
+		logger.Debug("ignoring event", "event_type", event.Type)
+	}
+	return nil
+}
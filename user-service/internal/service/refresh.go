@@ -0,0 +1,180 @@
+package service
+
+import (
+	"context"
+	"crypto/rand"
+	"crypto/sha256"
+	"encoding/hex"
+	"errors"
+	"time"
+
+	errs "github.com/COS301-SE-2025/Swift-Signals/shared/error"
+	"github.com/COS301-SE-2025/Swift-Signals/shared/jwt"
+	"github.com/COS301-SE-2025/Swift-Signals/user-service/internal/model"
+	"github.com/COS301-SE-2025/Swift-Signals/user-service/internal/util"
+)
+
+const (
+	// accessTokenTTL is short, as the refresh token renews access long after
+	accessTokenTTL  = 15 * time.Minute
+	refreshTokenTTL = 7 * 24 * time.Hour
+)
+
+// RefreshToken exchanges the refresh token for new tokens. A refresh token that was already
+// used is presumed stolen, so the rest of its family is revoked with it.
+func (s *Service) RefreshToken(ctx context.Context, refreshToken string) (*model.Tokens, error) {
+	logger := util.LoggerFromContext(ctx)
+
+	logger.Debug("validating input")
+	req := RefreshTokenRequest{
+		RefreshToken: refreshToken,
+	}
+	if err := s.validator.Struct(req); err != nil {
+		return nil, handleValidationError(err)
+	}
+
+	logger.Debug("claiming refresh token")
+	claimed, err := s.repo.ClaimRefreshToken(ctx, hashToken(req.RefreshToken), time.Now())
+	if err != nil {
+		var svcErr *errs.ServiceError
+		if errors.As(err, &svcErr) {
+			return nil, err
+		}
+		return nil, errs.NewInternalError("failed to claim refresh token", err, nil)
+	}
+	if claimed == nil {
+		return nil, errs.NewUnauthorizedError("invalid refresh token", map[string]any{})
+	}
+
+	if claimed.UsedAt != nil {
+		logger.Warn("refresh token reused, revoking its family",
+			"userID", claimed.UserID,
+			"familyID", claimed.FamilyID,
+		)
+		if err := s.deleteRefreshTokenFamily(ctx, claimed); err != nil {
+			return nil, err
+		}
+		return nil, errs.NewUnauthorizedError(
+			"refresh token has already been used",
+			map[string]any{"userID": claimed.UserID},
+		)
+	}
+	if !time.Now().Before(claimed.ExpiresAt) {
+		return nil, errs.NewUnauthorizedError(
+			"refresh token has expired",
+			map[string]any{"userID": claimed.UserID},
+		)
+	}
+
+	logger.Debug("finding user")
+	user, err := s.repo.GetUserByID(ctx, claimed.UserID)
+	if err != nil {
+		var svcErr *errs.ServiceError
+		if errors.As(err, &svcErr) {
+			return nil, err
+		}
+		return nil, errs.NewInternalError(
+			"failed to find user",
+			err,
+			map[string]any{"userID": claimed.UserID},
+		)
+	}
+	if user == nil {
+		return nil, errs.NewUnauthorizedError("invalid refresh token", map[string]any{})
+	}
+
+	logger.Debug("generating tokens")
+	return s.issueTokens(ctx, user, claimed.FamilyID)
+}
+
+// issueTokens signs an access token for the user and saves a refresh token for it in the
+// family, which starts with a login and continues through each refresh
+func (s *Service) issueTokens(
+	ctx context.Context,
+	user *model.User,
+	familyID string,
+) (*model.Tokens, error) {
+	role := "regular"
+	if user.IsAdmin {
+		role = "admin"
+	}
+	now := time.Now()
+	accessToken, err := jwt.GenerateToken(user.ID, role, accessTokenTTL)
+	if err != nil {
+		return nil, errs.NewInternalError(
+			"failed to generated token",
+			err,
+			map[string]any{"user": user.PublicUser()},
+		)
+	}
+
+	refreshToken := rand.Text()
+	err = s.repo.CreateRefreshToken(ctx, &model.RefreshToken{
+		TokenHash: hashToken(refreshToken),
+		FamilyID:  familyID,
+		UserID:    user.ID,
+		ExpiresAt: now.Add(refreshTokenTTL),
+	})
+	if err != nil {
+		var svcErr *errs.ServiceError
+		if errors.As(err, &svcErr) {
+			return nil, err
+		}
+		return nil, errs.NewInternalError(
+			"failed to save refresh token",
+			err,
+			map[string]any{"user": user.PublicUser()},
+		)
+	}
+
+	return &model.Tokens{
+		AccessToken:      accessToken,
+		AccessExpiresAt:  now.Add(accessTokenTTL),
+		RefreshToken:     refreshToken,
+		RefreshExpiresAt: now.Add(refreshTokenTTL),
+	}, nil
+}
+
+// revokeRefreshTokens revokes the family of the user's refresh token. A token that is not
+// theirs, or no longer exists, is ignored so that logging out still succeeds.
+func (s *Service) revokeRefreshTokens(ctx context.Context, userID, refreshToken string) error {
+	claimed, err := s.repo.ClaimRefreshToken(ctx, hashToken(refreshToken), time.Now())
+	if err != nil {
+		var svcErr *errs.ServiceError
+		if errors.As(err, &svcErr) {
+			return err
+		}
+		return errs.NewInternalError(
+			"failed to revoke refresh token",
+			err,
+			map[string]any{"userID": userID},
+		)
+	}
+	if claimed == nil || claimed.UserID != userID {
+		return nil
+	}
+	return s.deleteRefreshTokenFamily(ctx, claimed)
+}
+
+func (s *Service) deleteRefreshTokenFamily(ctx context.Context, token *model.RefreshToken) error {
+	err := s.repo.DeleteRefreshTokenFamily(ctx, token.FamilyID)
+	if err != nil {
+		var svcErr *errs.ServiceError
+		if errors.As(err, &svcErr) {
+			return err
+		}
+		return errs.NewInternalError(
+			"failed to revoke refresh token",
+			err,
+			map[string]any{"userID": token.UserID},
+		)
+	}
+	return nil
+}
+
+// hashToken is what refresh and password reset tokens are kept by. Unlike passwords they
+// are random enough that a fast hash cannot be brute forced.
+func hashToken(token string) string {
+	sum := sha256.Sum256([]byte(token))
+	return hex.EncodeToString(sum[:])
+}
@@ -0,0 +1,232 @@
+package service
+
+import (
+	"context"
+	"crypto/rand"
+	"errors"
+	"fmt"
+	"net/url"
+	"strings"
+	"sync"
+	"time"
+
+	errs "github.com/COS301-SE-2025/Swift-Signals/shared/error"
+	"github.com/COS301-SE-2025/Swift-Signals/user-service/internal/mailer"
+	"github.com/COS301-SE-2025/Swift-Signals/user-service/internal/model"
+	"github.com/COS301-SE-2025/Swift-Signals/user-service/internal/util"
+)
+
+const (
+	resetTokenTTL = time.Hour
+	// resetRequestLimit is how many reset emails can be asked for per email address in
+	// each window, so that the service cannot be used to flood someone's inbox
+	resetRequestLimit  = 3
+	resetRequestWindow = time.Hour
+)
+
+// ResetPassword emails the user a link to choose a new password. Whether anyone is
+// registered under the email address is not revealed, so asking about an unknown address
+// succeeds without sending anything.
+func (s *Service) ResetPassword(ctx context.Context, email string) error {
+	logger := util.LoggerFromContext(ctx)
+
+	logger.Debug("validating input")
+	req := ResetPasswordRequest{
+		Email: normalizeEmail(email),
+	}
+	if err := s.validator.Struct(req); err != nil {
+		return handleValidationError(err)
+	}
+
+	// NOTE: Limited before the lookup, so that known and unknown addresses are treated alike
+	if !s.resetLimiter.allow(req.Email, time.Now()) {
+		return errs.NewRateLimitedError(
+			"too many password reset requests, try again later",
+			map[string]any{"email": req.Email},
+		)
+	}
+
+	logger.Debug("finding user")
+	user, err := s.repo.GetUserByEmail(ctx, req.Email)
+	if err != nil {
+		var svcErr *errs.ServiceError
+		if errors.As(err, &svcErr) {
+			return err
+		}
+		return errs.NewInternalError(
+			"failed to find user",
+			err,
+			map[string]any{"email": req.Email},
+		)
+	}
+	if user == nil {
+		logger.Debug("no user with email, not sending reset link")
+		return nil
+	}
+
+	logger.Debug("saving reset token")
+	token := rand.Text()
+	err = s.repo.CreatePasswordResetToken(ctx, &model.PasswordResetToken{
+		TokenHash: hashToken(token),
+		UserID:    user.ID,
+		ExpiresAt: time.Now().Add(resetTokenTTL),
+	})
+	if err != nil {
+		var svcErr *errs.ServiceError
+		if errors.As(err, &svcErr) {
+			return err
+		}
+		return errs.NewInternalError(
+			"failed to save reset token",
+			err,
+			map[string]any{"userID": user.ID},
+		)
+	}
+
+	link, err := s.resetLink(token)
+	if err != nil {
+		return errs.NewInternalError(
+			"failed to create reset link",
+			err,
+			map[string]any{"userID": user.ID},
+		)
+	}
+
+	logger.Debug("sending reset email")
+	err = s.mailer.Send(ctx, mailer.Message{
+		To:      user.Email,
+		Subject: "Reset your Swift Signals password",
+		Body: fmt.Sprintf(
+			"Hi %s,\n\n"+
+				"Someone asked to reset the password of your Swift Signals account. "+
+				"To choose a new password, follow this link within %d minutes:\n\n"+
+				"%s\n\n"+
+				"If it was not you, you can ignore this email and your password will stay the same.\n",
+			user.Name,
+			int(resetTokenTTL.Minutes()),
+			link,
+		),
+	})
+	if err != nil {
+		return errs.NewExternalError(
+			"failed to send reset email",
+			err,
+			map[string]any{"userID": user.ID},
+		)
+	}
+
+	return nil
+}
+
+// ConfirmPasswordReset sets a new password using a token from a reset email. Every
+// session of the user is logged out, so whoever knew the old password has to log in again.
+func (s *Service) ConfirmPasswordReset(ctx context.Context, token, newPassword string) error {
+	logger := util.LoggerFromContext(ctx)
+
+	logger.Debug("validating input")
+	req := ConfirmPasswordResetRequest{
+		Token:       strings.TrimSpace(token),
+		NewPassword: newPassword,
+	}
+	if err := s.validator.Struct(req); err != nil {
+		return handleValidationError(err)
+	}
+
+	logger.Debug("hashing new password")
+	newPasswordHashed, err := hashPassword(req.NewPassword)
+	if err != nil {
+		return errs.NewExternalError("failed to hash password", err, nil)
+	}
+
+	logger.Debug("resetting password")
+	userID, err := s.repo.ResetPassword(
+		ctx,
+		hashToken(req.Token),
+		string(newPasswordHashed),
+		time.Now(),
+	)
+	if err != nil {
+		var svcErr *errs.ServiceError
+		if errors.As(err, &svcErr) {
+			return err
+		}
+		return errs.NewInternalError("failed to reset password", err, nil)
+	}
+	if userID == "" {
+		return errs.NewUnauthorizedError("invalid or expired reset token", map[string]any{})
+	}
+
+	return nil
+}
+
+// resetLink adds the token to the reset URL, keeping any query it already has
+func (s *Service) resetLink(token string) (string, error) {
+	link, err := url.Parse(s.resetURL)
+	if err != nil {
+		return "", err
+	}
+	query := link.Query()
+	query.Set("token", token)
+	link.RawQuery = query.Encode()
+	return link.String(), nil
+}
+
+// rateLimiter allows each key a number of requests in a sliding window.
+// NOTE: It only counts the requests this instance of the service has seen
+type rateLimiter struct {
+	limit  int
+	window time.Duration
+
+	mu        sync.Mutex
+	requests  map[string][]time.Time
+	nextPrune time.Time
+}
+
+func newRateLimiter(limit int, window time.Duration) *rateLimiter {
+	return &rateLimiter{
+		limit:    limit,
+		window:   window,
+		requests: make(map[string][]time.Time),
+	}
+}
+
+// allow records the request and reports whether the key is still within its limit.
+// Requests that are refused are not recorded, so they do not extend the wait.
+func (l *rateLimiter) allow(key string, now time.Time) bool {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+	l.prune(now)
+
+	recent := l.recent(l.requests[key], now)
+	if len(recent) >= l.limit {
+		l.requests[key] = recent
+		return false
+	}
+	l.requests[key] = append(recent, now)
+	return true
+}
+
+// recent returns the requests still inside the window, which are kept oldest first
+func (l *rateLimiter) recent(requests []time.Time, now time.Time) []time.Time {
+	start := now.Add(-l.window)
+	for i, at := range requests {
+		if at.After(start) {
+			return requests[i:]
+		}
+	}
+	return nil
+}
+
+// prune drops keys without recent requests at most once per window, so that keys which
+// are never seen again do not pile up. The caller must hold mu.
+func (l *rateLimiter) prune(now time.Time) {
+	if now.Before(l.nextPrune) {
+		return
+	}
+	l.nextPrune = now.Add(l.window)
+	for key, requests := range l.requests {
+		if len(l.recent(requests, now)) == 0 {
+			delete(l.requests, key)
+		}
+	}
+}
@@ -0,0 +1,113 @@
+package service
+
+import (
+	"context"
+	"errors"
+	"log/slog"
+	"time"
+
+	errs "github.com/COS301-SE-2025/Swift-Signals/shared/error"
+	"github.com/COS301-SE-2025/Swift-Signals/user-service/internal/util"
+)
+
+// IsTokenRevoked reports whether the user's token was logged out, issued before the user's
+// password was reset or the user deleted. A token without an ID, issued before tokens had
+// one, is not revoked by logging out.
+func (s *Service) IsTokenRevoked(
+	ctx context.Context,
+	userID, tokenID string,
+	issuedAt time.Time,
+) (bool, error) {
+	logger := util.LoggerFromContext(ctx)
+
+	logger.Debug("validating input")
+	req := IsTokenRevokedRequest{
+		UserID:   userID,
+		TokenID:  tokenID,
+		IssuedAt: issuedAt,
+	}
+	if err := s.validator.Struct(req); err != nil {
+		return false, handleValidationError(err)
+	}
+
+	logger.Debug("checking if token is revoked")
+	revoked, err := s.repo.IsTokenRevoked(ctx, req.TokenID, req.UserID, req.IssuedAt)
+	if err != nil {
+		var svcErr *errs.ServiceError
+		if errors.As(err, &svcErr) {
+			return false, err
+		}
+		return false, errs.NewInternalError(
+			"failed to check token revocation",
+			err,
+			map[string]any{"userID": userID},
+		)
+	}
+
+	return revoked, nil
+}
+
+// PurgeExpiredTokens forgets revoked, refresh and password reset tokens that have since
+// expired, since their expiry alone is enough to reject them
+func (s *Service) PurgeExpiredTokens(ctx context.Context) (int64, error) {
+	logger := util.LoggerFromContext(ctx)
+	now := time.Now()
+
+	logger.Debug("deleting expired revoked tokens")
+	revoked, err := s.repo.DeleteExpiredTokens(ctx, now)
+	if err != nil {
+		var svcErr *errs.ServiceError
+		if errors.As(err, &svcErr) {
+			return 0, err
+		}
+		return 0, errs.NewInternalError("failed to purge expired tokens", err, nil)
+	}
+
+	logger.Debug("deleting expired refresh tokens")
+	refresh, err := s.repo.DeleteExpiredRefreshTokens(ctx, now)
+	if err != nil {
+		var svcErr *errs.ServiceError
+		if errors.As(err, &svcErr) {
+			return 0, err
+		}
+		return 0, errs.NewInternalError("failed to purge expired refresh tokens", err, nil)
+	}
+
+	logger.Debug("deleting expired password reset tokens")
+	reset, err := s.repo.DeleteExpiredPasswordResetTokens(ctx, now)
+	if err != nil {
+		var svcErr *errs.ServiceError
+		if errors.As(err, &svcErr) {
+			return 0, err
+		}
+		return 0, errs.NewInternalError("failed to purge expired password reset tokens", err, nil)
+	}
+
+	return revoked + refresh + reset, nil
+}
+
+// RunTokenCleanup purges expired revoked tokens on every interval until ctx is cancelled
+func RunTokenCleanup(ctx context.Context, s UserService, interval time.Duration) {
+	logger := slog.Default().With("component", "token-cleanup")
+
+	ticker := time.NewTicker(interval)
+	defer ticker.Stop()
+	for {
+		deleted, err := s.PurgeExpiredTokens(ctx)
+		if err != nil {
+			logger.Error("failed to purge expired tokens",
+				"error", err.Error(),
+			)
+		} else if deleted > 0 {
+			logger.Info("purged expired tokens",
+				"deleted", deleted,
+			)
+		}
+
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+		}
+	}
+}
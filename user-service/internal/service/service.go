@@ -13,6 +13,7 @@ import (
 	errs "github.com/COS301-SE-2025/Swift-Signals/shared/error"
 	"github.com/COS301-SE-2025/Swift-Signals/shared/jwt"
 	"github.com/COS301-SE-2025/Swift-Signals/user-service/internal/db"
+	"github.com/COS301-SE-2025/Swift-Signals/user-service/internal/mailer"
 	"github.com/COS301-SE-2025/Swift-Signals/user-service/internal/model"
 	"github.com/COS301-SE-2025/Swift-Signals/user-service/internal/util"
 	"github.com/go-playground/validator/v10"
@@ -22,12 +23,21 @@ import (
 type Service struct {
 	repo      db.UserRepository
 	validator *validator.Validate
+
+	mailer       mailer.Mailer
+	resetURL     string
+	resetLimiter *rateLimiter
 }
 
-func NewUserService(r db.UserRepository) UserService {
+// NewUserService emails password reset links through m, pointing them at resetURL with
+// the token added as a query parameter
+func NewUserService(r db.UserRepository, m mailer.Mailer, resetURL string) UserService {
 	service := &Service{
-		repo:      r,
-		validator: validator.New(),
+		repo:         r,
+		validator:    validator.New(),
+		mailer:       m,
+		resetURL:     resetURL,
+		resetLimiter: newRateLimiter(resetRequestLimit, resetRequestWindow),
 	}
 
 	if err := service.ensureAdminExists(); err != nil {
@@ -136,7 +146,7 @@ func (s *Service) RegisterUser(
 func (s *Service) LoginUser(
 	ctx context.Context,
 	email, password string,
-) (string, time.Time, error) {
+) (*model.Tokens, error) {
 	logger := util.LoggerFromContext(ctx)
 
 	logger.Debug("validating input")
@@ -145,19 +155,19 @@ func (s *Service) LoginUser(
 		Password: password,
 	}
 	if err := s.validator.Struct(req); err != nil {
-		return "", time.Time{}, handleValidationError(err)
+		return nil, handleValidationError(err)
 	}
 
 	logger.Debug("checking if email already exists")
 	user, err := s.repo.GetUserByEmail(ctx, normalizeEmail(email))
 	if err != nil {
-		return "", time.Time{}, errs.NewInternalError(
+		return nil, errs.NewInternalError(
 			"failed to check existing user",
 			err,
 			map[string]any{"email": email})
 	}
 	if user == nil {
-		return "", time.Time{}, errs.NewInternalError(
+		return nil, errs.NewInternalError(
 			"user does not exist",
 			err,
 			map[string]any{"email": email},
@@ -167,52 +177,61 @@ func (s *Service) LoginUser(
 	logger.Debug("checking if password is correct")
 	err = checkPassword(password, user.Password)
 	if err != nil {
-		return "", time.Time{}, errs.NewUnauthorizedError(
+		return nil, errs.NewUnauthorizedError(
 			"password is incorrect",
 			map[string]any{"user": user.PublicUser()},
 		)
 	}
 
-	logger.Debug("generating token")
-	role := "regular"
-	if user.IsAdmin {
-		role = "admin"
-	}
-	expiryDate := time.Now().Add(time.Hour * 72)
-	token, err := jwt.GenerateToken(user.ID, role, time.Hour*72)
-	if err != nil {
-		return "", time.Time{}, errs.NewInternalError(
-			"failed to generated token",
-			err,
-			map[string]any{"user": user.PublicUser()},
-		)
-	}
-
-	return token, expiryDate, nil
+	logger.Debug("generating tokens")
+	return s.issueTokens(ctx, user, uuid.NewString())
 }
 
-func (s *Service) LogoutUser(ctx context.Context, userID string) error {
+// LogoutUser revokes the token, so it stops working before it expires, along with every
+// refresh token of the session when one is given
+func (s *Service) LogoutUser(ctx context.Context, token, refreshToken string) error {
 	logger := util.LoggerFromContext(ctx)
 
 	logger.Debug("validating input")
 	req := LogoutUserRequest{
-		UserID: userID,
+		Token:        token,
+		RefreshToken: refreshToken,
 	}
 	if err := s.validator.Struct(req); err != nil {
 		return handleValidationError(err)
 	}
 
-	logger.Debug("checking if user exists")
-	_, err := s.repo.GetUserByID(ctx, req.UserID)
+	logger.Debug("parsing token")
+	claims, err := jwt.ParseToken(req.Token)
+	if err != nil {
+		return errs.NewUnauthorizedError("invalid token", map[string]any{})
+	}
+	if claims.ID == "" || claims.ExpiresAt == nil {
+		return errs.NewValidationError(
+			"token cannot be revoked",
+			map[string]any{"userID": claims.UserID},
+		)
+	}
+
+	logger.Debug("revoking token")
+	err = s.repo.RevokeToken(ctx, claims.ID, claims.UserID, claims.ExpiresAt.Time)
 	if err != nil {
 		var svcErr *errs.ServiceError
 		if errors.As(err, &svcErr) {
 			return err
 		}
-		return errs.NewInternalError("failed to find user", err, map[string]any{})
+		return errs.NewInternalError(
+			"failed to revoke token",
+			err,
+			map[string]any{"userID": claims.UserID},
+		)
 	}
 
-	return nil
+	if req.RefreshToken == "" {
+		return nil
+	}
+	logger.Debug("revoking refresh tokens")
+	return s.revokeRefreshTokens(ctx, claims.UserID, req.RefreshToken)
 }
 
 func (s *Service) GetUserByID(ctx context.Context, userID string) (*model.User, error) {
@@ -320,6 +339,40 @@ func (s *Service) GetAllUsers(
 	return users, nil
 }
 
+// GetUsersAfter returns a page of the users whose ID sorts after afterID. Unlike a page
+// number, the position cannot shift as users are created or deleted between pages.
+func (s *Service) GetUsersAfter(
+	ctx context.Context,
+	afterID string,
+	pageSize int32,
+) ([]*model.User, error) {
+	logger := util.LoggerFromContext(ctx)
+
+	logger.Debug("validating pagination parameters")
+	req := GetUsersAfterRequest{
+		AfterID:  strings.TrimSpace(afterID),
+		PageSize: pageSize,
+	}
+	if err := s.validator.Struct(req); err != nil {
+		return nil, handleValidationError(err)
+	}
+
+	logger.Debug("querying database after user", "afterID", req.AfterID, "pageSize", pageSize)
+	users, err := s.repo.ListUsersAfter(ctx, req.AfterID, int(req.PageSize))
+	if err != nil {
+		return nil, errs.NewInternalError(
+			"failed to retrieve users",
+			err,
+			map[string]any{
+				"afterID":  req.AfterID,
+				"pageSize": pageSize,
+			},
+		)
+	}
+
+	return users, nil
+}
+
 func (s *Service) UpdateUser(ctx context.Context, userID, name, email string) (*model.User, error) {
 	logger := util.LoggerFromContext(ctx)
 
@@ -736,11 +789,6 @@ func (s *Service) ChangePassword(
 	return nil
 }
 
-func (s *Service) ResetPassword(ctx context.Context, email string) error {
-	// TODO: Implement password reset
-	return nil
-}
-
 func (s *Service) MakeAdmin(ctx context.Context, userID, adminUserID string) error {
 	logger := util.LoggerFromContext(ctx)
 
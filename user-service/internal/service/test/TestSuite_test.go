@@ -4,6 +4,7 @@ import (
 	"testing"
 
 	mocks "github.com/COS301-SE-2025/Swift-Signals/user-service/internal/mocks/db"
+	mailermocks "github.com/COS301-SE-2025/Swift-Signals/user-service/internal/mocks/mailer"
 	"github.com/COS301-SE-2025/Swift-Signals/user-service/internal/service"
 	"github.com/stretchr/testify/mock"
 	"github.com/stretchr/testify/suite"
@@ -12,15 +13,19 @@ import (
 type TestSuite struct {
 	suite.Suite
 	repo    *mocks.MockUserRepository
+	mailer  *mailermocks.MockMailer
 	service service.UserService
 }
 
 func (suite *TestSuite) SetupTest() {
 	suite.repo = new(mocks.MockUserRepository)
 	suite.repo.On("AdminExists", mock.Anything).Return(true, nil)
-	suite.service = service.NewUserService(suite.repo)
+	suite.mailer = new(mailermocks.MockMailer)
+	suite.service = service.NewUserService(suite.repo, suite.mailer, testResetURL)
 }
 
+const testResetURL = "https://swiftsignals.example/reset-password"
+
 func TestService(t *testing.T) {
 	suite.Run(t, new(TestSuite))
 }
@@ -0,0 +1,92 @@
+package test
+
+import (
+	"context"
+	"errors"
+
+	errs "github.com/COS301-SE-2025/Swift-Signals/shared/error"
+	"github.com/stretchr/testify/mock"
+	"golang.org/x/crypto/bcrypt"
+)
+
+func (suite *TestSuite) TestConfirmPasswordReset_Success() {
+	newPassword := "newpassword456"
+	suite.repo.On("ResetPassword", mock.Anything, hashToken("reset-token"),
+		mock.MatchedBy(func(password string) bool {
+			return bcrypt.CompareHashAndPassword([]byte(password), []byte(newPassword)) == nil
+		}), mock.Anything).
+		Return("550e8400-e29b-41d4-a716-446655440000", nil)
+
+	ctx := context.Background()
+
+	err := suite.service.ConfirmPasswordReset(ctx, "reset-token", newPassword)
+
+	suite.Require().NoError(err)
+	suite.repo.AssertExpectations(suite.T())
+}
+
+func (suite *TestSuite) TestConfirmPasswordReset_InvalidToken() {
+	suite.repo.On("ResetPassword", mock.Anything, hashToken("reset-token"), mock.Anything, mock.Anything).
+		Return("", nil)
+
+	ctx := context.Background()
+
+	err := suite.service.ConfirmPasswordReset(ctx, "reset-token", "newpassword456")
+
+	svcError, ok := err.(*errs.ServiceError)
+	suite.True(ok)
+	suite.Equal(errs.ErrUnauthorized, svcError.Code)
+	suite.Equal("invalid or expired reset token", svcError.Message)
+}
+
+func (suite *TestSuite) TestConfirmPasswordReset_MissingToken() {
+	ctx := context.Background()
+
+	err := suite.service.ConfirmPasswordReset(ctx, "  ", "newpassword456")
+
+	svcError, ok := err.(*errs.ServiceError)
+	suite.True(ok)
+	suite.Equal(errs.ErrValidation, svcError.Code)
+	suite.Contains(svcError.Context["validation errors"], "token")
+	suite.repo.AssertNotCalled(
+		suite.T(),
+		"ResetPassword",
+		mock.Anything,
+		mock.Anything,
+		mock.Anything,
+		mock.Anything,
+	)
+}
+
+func (suite *TestSuite) TestConfirmPasswordReset_WeakPassword() {
+	ctx := context.Background()
+
+	err := suite.service.ConfirmPasswordReset(ctx, "reset-token", "short")
+
+	svcError, ok := err.(*errs.ServiceError)
+	suite.True(ok)
+	suite.Equal(errs.ErrValidation, svcError.Code)
+	suite.Contains(svcError.Context["validation errors"], "newpassword")
+	suite.repo.AssertNotCalled(
+		suite.T(),
+		"ResetPassword",
+		mock.Anything,
+		mock.Anything,
+		mock.Anything,
+		mock.Anything,
+	)
+}
+
+func (suite *TestSuite) TestConfirmPasswordReset_RepositoryError() {
+	suite.repo.On("ResetPassword", mock.Anything, mock.Anything, mock.Anything, mock.Anything).
+		Return("", errors.New("database connection failed"))
+
+	ctx := context.Background()
+
+	err := suite.service.ConfirmPasswordReset(ctx, "reset-token", "newpassword456")
+
+	svcError, ok := err.(*errs.ServiceError)
+	suite.True(ok)
+	suite.Equal(errs.ErrInternal, svcError.Code)
+	suite.Equal("failed to reset password", svcError.Message)
+}
@@ -0,0 +1,68 @@
+package test
+
+import (
+	"context"
+	"errors"
+
+	errs "github.com/COS301-SE-2025/Swift-Signals/shared/error"
+	"github.com/COS301-SE-2025/Swift-Signals/user-service/internal/model"
+	"github.com/stretchr/testify/mock"
+)
+
+func (suite *TestSuite) TestGetUsersAfter_Success() {
+	afterID := "550e8400-e29b-41d4-a716-446655440000"
+	expectedUsers := []*model.User{
+		{
+			ID:      "550e8400-e29b-41d4-a716-446655440001",
+			Name:    "User Two",
+			Email:   "user2@example.com",
+			IsAdmin: false,
+		},
+	}
+
+	suite.repo.On("ListUsersAfter", mock.Anything, afterID, 10).Return(expectedUsers, nil)
+
+	ctx := context.Background()
+
+	result, err := suite.service.GetUsersAfter(ctx, afterID, 10)
+
+	suite.Require().NoError(err)
+	suite.Equal(expectedUsers, result)
+
+	suite.repo.AssertExpectations(suite.T())
+}
+
+func (suite *TestSuite) TestGetUsersAfter_InvalidAfterID() {
+	ctx := context.Background()
+
+	result, err := suite.service.GetUsersAfter(ctx, "not-a-uuid", 10)
+
+	suite.Require().Error(err)
+	suite.Nil(result)
+
+	var svcError *errs.ServiceError
+	suite.Require().True(errors.As(err, &svcError))
+	suite.Equal(errs.ErrValidation, svcError.Code)
+
+	suite.repo.AssertNotCalled(suite.T(), "ListUsersAfter", mock.Anything, mock.Anything, mock.Anything)
+}
+
+func (suite *TestSuite) TestGetUsersAfter_RepositoryFailure() {
+	afterID := "550e8400-e29b-41d4-a716-446655440000"
+
+	suite.repo.On("ListUsersAfter", mock.Anything, afterID, 10).
+		Return(nil, errors.New("database error"))
+
+	ctx := context.Background()
+
+	result, err := suite.service.GetUsersAfter(ctx, afterID, 10)
+
+	suite.Require().Error(err)
+	suite.Nil(result)
+
+	var svcError *errs.ServiceError
+	suite.Require().True(errors.As(err, &svcError))
+	suite.Equal(errs.ErrInternal, svcError.Code)
+
+	suite.repo.AssertExpectations(suite.T())
+}
@@ -0,0 +1,77 @@
+package test
+
+import (
+	"context"
+	"errors"
+
+	errs "github.com/COS301-SE-2025/Swift-Signals/shared/error"
+	"github.com/COS301-SE-2025/Swift-Signals/shared/events"
+	"github.com/stretchr/testify/mock"
+)
+
+func (suite *TestSuite) TestHandleEvent_IntersectionPurged() {
+	event, err := events.NewEvent(
+		events.IntersectionPurged,
+		"intersection-1",
+		events.IntersectionPurgedPayload{IntersectionID: "intersection-1"},
+	)
+	suite.Require().NoError(err)
+
+	suite.repo.On("DeleteIntersectionID", mock.Anything, "intersection-1").Return(int64(2), nil)
+
+	err = suite.service.HandleEvent(context.Background(), event)
+
+	suite.Require().NoError(err)
+	suite.repo.AssertExpectations(suite.T())
+}
+
+func (suite *TestSuite) TestHandleEvent_IntersectionPurgedFailure() {
+	event, err := events.NewEvent(
+		events.IntersectionPurged,
+		"intersection-1",
+		events.IntersectionPurgedPayload{IntersectionID: "intersection-1"},
+	)
+	suite.Require().NoError(err)
+
+	suite.repo.On("DeleteIntersectionID", mock.Anything, "intersection-1").
+		Return(int64(0), errors.New("connection lost"))
+
+	err = suite.service.HandleEvent(context.Background(), event)
+
+	suite.Require().Error(err)
+	var svcErr *errs.ServiceError
+	suite.Require().True(errors.As(err, &svcErr))
+	suite.Equal(errs.ErrInternal, svcErr.Code)
+	suite.repo.AssertExpectations(suite.T())
+}
+
+func (suite *TestSuite) TestHandleEvent_MissingIntersectionID() {
+	event, err := events.NewEvent(
+		events.IntersectionPurged,
+		"intersection-1",
+		events.IntersectionPurgedPayload{},
+	)
+	suite.Require().NoError(err)
+
+	err = suite.service.HandleEvent(context.Background(), event)
+
+	suite.Require().Error(err)
+	var svcErr *errs.ServiceError
+	suite.Require().True(errors.As(err, &svcErr))
+	suite.Equal(errs.ErrValidation, svcErr.Code)
+	suite.repo.AssertNotCalled(suite.T(), "DeleteIntersectionID", mock.Anything, mock.Anything)
+}
+
+func (suite *TestSuite) TestHandleEvent_IgnoresOtherEvents() {
+	event, err := events.NewEvent(
+		events.UserDeleted,
+		"user-1",
+		events.UserDeletedPayload{UserID: "user-1"},
+	)
+	suite.Require().NoError(err)
+
+	err = suite.service.HandleEvent(context.Background(), event)
+
+	suite.Require().NoError(err)
+	suite.repo.AssertNotCalled(suite.T(), "DeleteIntersectionID", mock.Anything, mock.Anything)
+}
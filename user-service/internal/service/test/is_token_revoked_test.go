@@ -0,0 +1,143 @@
+package test
+
+import (
+	"context"
+	"errors"
+	"time"
+
+	errs "github.com/COS301-SE-2025/Swift-Signals/shared/error"
+	"github.com/stretchr/testify/mock"
+)
+
+func (suite *TestSuite) TestIsTokenRevoked_Success() {
+	userID := "550e8400-e29b-41d4-a716-446655440000"
+	issuedAt := time.Now().Truncate(time.Second)
+
+	for _, revoked := range []bool{true, false} {
+		suite.SetupTest()
+		suite.repo.On("IsTokenRevoked", mock.Anything, "token-1", userID, issuedAt).
+			Return(revoked, nil)
+
+		ctx := context.Background()
+
+		actual, err := suite.service.IsTokenRevoked(ctx, userID, "token-1", issuedAt)
+
+		suite.Require().NoError(err)
+		suite.Equal(revoked, actual)
+		suite.repo.AssertExpectations(suite.T())
+	}
+}
+
+func (suite *TestSuite) TestIsTokenRevoked_WithoutTokenID() {
+	userID := "550e8400-e29b-41d4-a716-446655440000"
+
+	// NOTE: A token issued before tokens had IDs is not revoked by logging out
+	suite.repo.On("IsTokenRevoked", mock.Anything, "", userID, mock.Anything).Return(false, nil)
+
+	ctx := context.Background()
+
+	revoked, err := suite.service.IsTokenRevoked(ctx, userID, "", time.Now())
+
+	suite.Require().NoError(err)
+	suite.False(revoked)
+}
+
+func (suite *TestSuite) TestIsTokenRevoked_InvalidUserID() {
+	ctx := context.Background()
+
+	_, err := suite.service.IsTokenRevoked(ctx, "invalid-uuid", "token-1", time.Now())
+
+	svcError, ok := err.(*errs.ServiceError)
+	suite.True(ok)
+	suite.Equal(errs.ErrValidation, svcError.Code)
+	expectedErrors := map[string]string{
+		"userid": "UserID must be a valid UUID",
+	}
+	suite.Equal(map[string]any{"validation errors": expectedErrors}, svcError.Context)
+
+	suite.repo.AssertNotCalled(suite.T(), "IsTokenRevoked",
+		mock.Anything, mock.Anything, mock.Anything, mock.Anything)
+}
+
+func (suite *TestSuite) TestIsTokenRevoked_RepositoryError() {
+	userID := "550e8400-e29b-41d4-a716-446655440000"
+	suite.repo.On("IsTokenRevoked", mock.Anything, "token-1", userID, mock.Anything).
+		Return(false, errors.New("database connection failed"))
+
+	ctx := context.Background()
+
+	revoked, err := suite.service.IsTokenRevoked(ctx, userID, "token-1", time.Now())
+
+	suite.False(revoked)
+	svcError, ok := err.(*errs.ServiceError)
+	suite.True(ok)
+	suite.Equal(errs.ErrInternal, svcError.Code)
+	suite.Equal("failed to check token revocation", svcError.Message)
+}
+
+func (suite *TestSuite) TestPurgeExpiredTokens_Success() {
+	suite.repo.On("DeleteExpiredTokens", mock.Anything,
+		mock.MatchedBy(func(before time.Time) bool {
+			return time.Since(before) < time.Minute
+		})).
+		Return(int64(4), nil)
+	suite.repo.On("DeleteExpiredRefreshTokens", mock.Anything, mock.Anything).
+		Return(int64(3), nil)
+	suite.repo.On("DeleteExpiredPasswordResetTokens", mock.Anything, mock.Anything).
+		Return(int64(2), nil)
+
+	ctx := context.Background()
+
+	deleted, err := suite.service.PurgeExpiredTokens(ctx)
+
+	suite.Require().NoError(err)
+	suite.Equal(int64(9), deleted)
+	suite.repo.AssertExpectations(suite.T())
+}
+
+func (suite *TestSuite) TestPurgeExpiredTokens_RepositoryError() {
+	suite.repo.On("DeleteExpiredTokens", mock.Anything, mock.Anything).
+		Return(int64(0), errs.NewDatabaseError("database connection lost", nil, nil))
+
+	ctx := context.Background()
+
+	_, err := suite.service.PurgeExpiredTokens(ctx)
+
+	svcError, ok := err.(*errs.ServiceError)
+	suite.True(ok)
+	suite.Equal(errs.ErrDatabase, svcError.Code)
+}
+
+func (suite *TestSuite) TestPurgeExpiredTokens_RefreshTokenError() {
+	suite.repo.On("DeleteExpiredTokens", mock.Anything, mock.Anything).
+		Return(int64(4), nil)
+	suite.repo.On("DeleteExpiredRefreshTokens", mock.Anything, mock.Anything).
+		Return(int64(0), errors.New("database connection failed"))
+
+	ctx := context.Background()
+
+	_, err := suite.service.PurgeExpiredTokens(ctx)
+
+	svcError, ok := err.(*errs.ServiceError)
+	suite.True(ok)
+	suite.Equal(errs.ErrInternal, svcError.Code)
+	suite.Equal("failed to purge expired refresh tokens", svcError.Message)
+}
+
+func (suite *TestSuite) TestPurgeExpiredTokens_PasswordResetTokenError() {
+	suite.repo.On("DeleteExpiredTokens", mock.Anything, mock.Anything).
+		Return(int64(4), nil)
+	suite.repo.On("DeleteExpiredRefreshTokens", mock.Anything, mock.Anything).
+		Return(int64(3), nil)
+	suite.repo.On("DeleteExpiredPasswordResetTokens", mock.Anything, mock.Anything).
+		Return(int64(0), errors.New("database connection failed"))
+
+	ctx := context.Background()
+
+	_, err := suite.service.PurgeExpiredTokens(ctx)
+
+	svcError, ok := err.(*errs.ServiceError)
+	suite.True(ok)
+	suite.Equal(errs.ErrInternal, svcError.Code)
+	suite.Equal("failed to purge expired password reset tokens", svcError.Message)
+}
@@ -2,6 +2,8 @@ package test
 
 import (
 	"context"
+	"crypto/sha256"
+	"encoding/hex"
 	"errors"
 	"time"
 
@@ -25,15 +27,22 @@ func (suite *TestSuite) TestLoginUser_Success_RegularUser() {
 	}
 
 	suite.repo.On("GetUserByEmail", mock.Anything, email).Return(user, nil)
+	suite.repo.On("CreateRefreshToken", mock.Anything, mock.MatchedBy(
+		func(token *model.RefreshToken) bool {
+			return token.UserID == user.ID && token.FamilyID != ""
+		})).
+		Return(nil)
 
 	ctx := context.Background()
 
-	token, expiryDate, err := suite.service.LoginUser(ctx, email, plainPassword)
+	tokens, err := suite.service.LoginUser(ctx, email, plainPassword)
 
 	suite.Require().NoError(err)
-	suite.NotEmpty(token)
-	suite.True(expiryDate.After(time.Now()))
-	suite.True(expiryDate.Before(time.Now().Add(time.Hour * 73)))
+	suite.NotEmpty(tokens.AccessToken)
+	suite.True(tokens.AccessExpiresAt.After(time.Now()))
+	suite.True(tokens.AccessExpiresAt.Before(time.Now().Add(time.Minute * 16)))
+	suite.NotEmpty(tokens.RefreshToken)
+	suite.True(tokens.RefreshExpiresAt.After(tokens.AccessExpiresAt))
 
 	suite.repo.AssertExpectations(suite.T())
 }
@@ -52,15 +61,22 @@ func (suite *TestSuite) TestLoginUser_Success_AdminUser() {
 	}
 
 	suite.repo.On("GetUserByEmail", mock.Anything, email).Return(user, nil)
+	suite.repo.On("CreateRefreshToken", mock.Anything, mock.MatchedBy(
+		func(token *model.RefreshToken) bool {
+			return token.UserID == user.ID && token.FamilyID != ""
+		})).
+		Return(nil)
 
 	ctx := context.Background()
 
-	token, expiryDate, err := suite.service.LoginUser(ctx, email, plainPassword)
+	tokens, err := suite.service.LoginUser(ctx, email, plainPassword)
 
 	suite.Require().NoError(err)
-	suite.NotEmpty(token)
-	suite.True(expiryDate.After(time.Now()))
-	suite.True(expiryDate.Before(time.Now().Add(time.Hour * 73)))
+	suite.NotEmpty(tokens.AccessToken)
+	suite.True(tokens.AccessExpiresAt.After(time.Now()))
+	suite.True(tokens.AccessExpiresAt.Before(time.Now().Add(time.Minute * 16)))
+	suite.NotEmpty(tokens.RefreshToken)
+	suite.True(tokens.RefreshExpiresAt.After(tokens.AccessExpiresAt))
 
 	suite.repo.AssertExpectations(suite.T())
 }
@@ -71,10 +87,9 @@ func (suite *TestSuite) TestLoginUser_Invalid_Input() {
 
 	ctx := context.Background()
 
-	token, expiryDate, err := suite.service.LoginUser(ctx, email, plainPassword)
+	tokens, err := suite.service.LoginUser(ctx, email, plainPassword)
 
-	suite.Empty(token)
-	suite.Equal(time.Time{}, expiryDate)
+	suite.Nil(tokens)
 
 	svcError, ok := err.(*errs.ServiceError)
 	suite.True(ok)
@@ -97,10 +112,9 @@ func (suite *TestSuite) TestLoginUser_Repository_Error() {
 
 	ctx := context.Background()
 
-	token, expiryDate, err := suite.service.LoginUser(ctx, email, plainPassword)
+	tokens, err := suite.service.LoginUser(ctx, email, plainPassword)
 
-	suite.Empty(token)
-	suite.Equal(time.Time{}, expiryDate)
+	suite.Nil(tokens)
 
 	svcError, ok := err.(*errs.ServiceError)
 	suite.True(ok)
@@ -119,10 +133,9 @@ func (suite *TestSuite) TestLoginUser_User_Not_Found() {
 
 	ctx := context.Background()
 
-	token, expiryDate, err := suite.service.LoginUser(ctx, email, plainPassword)
+	tokens, err := suite.service.LoginUser(ctx, email, plainPassword)
 
-	suite.Empty(token)
-	suite.Equal(time.Time{}, expiryDate)
+	suite.Nil(tokens)
 
 	svcError, ok := err.(*errs.ServiceError)
 	suite.True(ok)
@@ -151,10 +164,9 @@ func (suite *TestSuite) TestLoginUser_Incorrect_Password() {
 
 	ctx := context.Background()
 
-	token, expiryDate, err := suite.service.LoginUser(ctx, email, plainPassword)
+	tokens, err := suite.service.LoginUser(ctx, email, plainPassword)
 
-	suite.Empty(token)
-	suite.Equal(time.Time{}, expiryDate)
+	suite.Nil(tokens)
 
 	svcError, ok := err.(*errs.ServiceError)
 	suite.True(ok)
@@ -180,14 +192,81 @@ func (suite *TestSuite) TestLoginUser_Email_Normalization() {
 	}
 
 	suite.repo.On("GetUserByEmail", mock.Anything, normalizedEmail).Return(user, nil)
+	suite.repo.On("CreateRefreshToken", mock.Anything, mock.MatchedBy(
+		func(token *model.RefreshToken) bool {
+			return token.UserID == user.ID && token.FamilyID != ""
+		})).
+		Return(nil)
 
 	ctx := context.Background()
 
-	token, expiryDate, err := suite.service.LoginUser(ctx, email, plainPassword)
+	tokens, err := suite.service.LoginUser(ctx, email, plainPassword)
 
 	suite.Require().NoError(err)
-	suite.NotEmpty(token)
-	suite.True(expiryDate.After(time.Now()))
+	suite.NotEmpty(tokens.AccessToken)
+	suite.True(tokens.AccessExpiresAt.After(time.Now()))
+
+	suite.repo.AssertExpectations(suite.T())
+}
+
+func (suite *TestSuite) TestLoginUser_KeepsOnlyRefreshTokenHash() {
+	email := "valid@gmail.com"
+	plainPassword := "password123"
+	hashedPassword, _ := bcrypt.GenerateFromPassword([]byte(plainPassword), bcrypt.DefaultCost)
+
+	user := &model.User{
+		ID:       "user-id-123",
+		Name:     "Valid User",
+		Email:    email,
+		Password: string(hashedPassword),
+	}
+
+	var saved *model.RefreshToken
+	suite.repo.On("GetUserByEmail", mock.Anything, email).Return(user, nil)
+	suite.repo.On("CreateRefreshToken", mock.Anything, mock.Anything).
+		Run(func(args mock.Arguments) {
+			saved = args.Get(1).(*model.RefreshToken)
+		}).
+		Return(nil)
+
+	ctx := context.Background()
+
+	tokens, err := suite.service.LoginUser(ctx, email, plainPassword)
+
+	suite.Require().NoError(err)
+	suite.Require().NotNil(saved)
+	sum := sha256.Sum256([]byte(tokens.RefreshToken))
+	suite.Equal(hex.EncodeToString(sum[:]), saved.TokenHash)
+	suite.WithinDuration(tokens.RefreshExpiresAt, saved.ExpiresAt, time.Second)
+	suite.Nil(saved.UsedAt)
+}
+
+func (suite *TestSuite) TestLoginUser_RefreshTokenSaveError() {
+	email := "valid@gmail.com"
+	plainPassword := "password123"
+	hashedPassword, _ := bcrypt.GenerateFromPassword([]byte(plainPassword), bcrypt.DefaultCost)
+
+	user := &model.User{
+		ID:       "user-id-123",
+		Name:     "Valid User",
+		Email:    email,
+		Password: string(hashedPassword),
+	}
+
+	suite.repo.On("GetUserByEmail", mock.Anything, email).Return(user, nil)
+	suite.repo.On("CreateRefreshToken", mock.Anything, mock.Anything).
+		Return(errors.New("database error"))
+
+	ctx := context.Background()
+
+	tokens, err := suite.service.LoginUser(ctx, email, plainPassword)
+
+	suite.Nil(tokens)
+
+	svcError, ok := err.(*errs.ServiceError)
+	suite.True(ok)
+	suite.Equal(errs.ErrInternal, svcError.Code)
+	suite.Equal("failed to save refresh token", svcError.Message)
 
 	suite.repo.AssertExpectations(suite.T())
 }
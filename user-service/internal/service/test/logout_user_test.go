@@ -3,116 +3,181 @@ package test
 import (
 	"context"
 	"errors"
+	"time"
 
 	errs "github.com/COS301-SE-2025/Swift-Signals/shared/error"
+	"github.com/COS301-SE-2025/Swift-Signals/shared/jwt"
 	"github.com/COS301-SE-2025/Swift-Signals/user-service/internal/model"
 	"github.com/stretchr/testify/mock"
 )
 
+// generateToken signs a token for the user, returning it along with its ID
+func (suite *TestSuite) generateToken(userID string, expiry time.Duration) (string, string) {
+	jwt.Init([]byte("test-secret"))
+	token, err := jwt.GenerateToken(userID, "regular", expiry)
+	suite.Require().NoError(err)
+	claims, err := jwt.ParseToken(token)
+	suite.Require().NoError(err)
+	return token, claims.ID
+}
+
 func (suite *TestSuite) TestLogoutUser_Success() {
 	userID := "550e8400-e29b-41d4-a716-446655440000"
-	expectedUser := &model.User{
-		ID:      userID,
-		Name:    "Test User",
-		Email:   "test@example.com",
-		IsAdmin: false,
-	}
+	token, tokenID := suite.generateToken(userID, time.Hour)
 
-	suite.repo.On("GetUserByID", mock.Anything, userID).Return(expectedUser, nil)
+	suite.repo.On("RevokeToken", mock.Anything, tokenID, userID,
+		mock.MatchedBy(func(expiresAt time.Time) bool {
+			return time.Until(expiresAt) > 59*time.Minute && time.Until(expiresAt) <= time.Hour
+		})).
+		Return(nil)
 
 	ctx := context.Background()
 
-	err := suite.service.LogoutUser(ctx, userID)
+	err := suite.service.LogoutUser(ctx, token, "")
 
 	suite.Require().NoError(err)
 
 	suite.repo.AssertExpectations(suite.T())
 }
 
-func (suite *TestSuite) TestLogoutUser_InvalidUserID() {
-	userID := "invalid-uuid"
-
+func (suite *TestSuite) TestLogoutUser_EmptyToken() {
 	ctx := context.Background()
 
-	err := suite.service.LogoutUser(ctx, userID)
+	err := suite.service.LogoutUser(ctx, "", "")
 
 	svcError, ok := err.(*errs.ServiceError)
 	suite.True(ok)
 	suite.Equal(errs.ErrValidation, svcError.Code)
 	suite.Equal("invalid input", svcError.Message)
 	expectedErrors := map[string]string{
-		"userid": "UserID must be a valid UUID",
+		"token": "Token is required",
 	}
 	suite.Equal(map[string]any{"validation errors": expectedErrors}, svcError.Context)
 
-	suite.repo.AssertExpectations(suite.T())
+	suite.repo.AssertNotCalled(suite.T(), "RevokeToken",
+		mock.Anything, mock.Anything, mock.Anything, mock.Anything)
 }
 
-func (suite *TestSuite) TestLogoutUser_EmptyUserID() {
-	userID := ""
-
+func (suite *TestSuite) TestLogoutUser_InvalidToken() {
 	ctx := context.Background()
 
-	err := suite.service.LogoutUser(ctx, userID)
+	err := suite.service.LogoutUser(ctx, "this.is.not.a.jwt", "")
 
 	svcError, ok := err.(*errs.ServiceError)
 	suite.True(ok)
-	suite.Equal(errs.ErrValidation, svcError.Code)
-	suite.Equal("invalid input", svcError.Message)
-	expectedErrors := map[string]string{
-		"userid": "UserID is required",
-	}
-	suite.Equal(map[string]any{"validation errors": expectedErrors}, svcError.Context)
+	suite.Equal(errs.ErrUnauthorized, svcError.Code)
+	suite.Equal("invalid token", svcError.Message)
 
-	suite.repo.AssertExpectations(suite.T())
+	suite.repo.AssertNotCalled(suite.T(), "RevokeToken",
+		mock.Anything, mock.Anything, mock.Anything, mock.Anything)
 }
 
-func (suite *TestSuite) TestLogoutUser_UserNotFound() {
-	userID := "550e8400-e29b-41d4-a716-446655440000"
-
-	suite.repo.On("GetUserByID", mock.Anything, userID).Return(nil, nil)
+func (suite *TestSuite) TestLogoutUser_ExpiredToken() {
+	jwt.Init([]byte("test-secret"))
+	token, err := jwt.GenerateToken("550e8400-e29b-41d4-a716-446655440000", "regular", -time.Minute)
+	suite.Require().NoError(err)
 
 	ctx := context.Background()
 
-	err := suite.service.LogoutUser(ctx, userID)
+	err = suite.service.LogoutUser(ctx, token, "")
 
-	suite.Require().NoError(err)
-
-	suite.repo.AssertExpectations(suite.T())
+	svcError, ok := err.(*errs.ServiceError)
+	suite.True(ok)
+	suite.Equal(errs.ErrUnauthorized, svcError.Code)
 }
 
 func (suite *TestSuite) TestLogoutUser_RepositoryError() {
 	userID := "550e8400-e29b-41d4-a716-446655440000"
+	token, tokenID := suite.generateToken(userID, time.Hour)
 	repoError := errors.New("database connection failed")
 
-	suite.repo.On("GetUserByID", mock.Anything, userID).Return(nil, repoError)
+	suite.repo.On("RevokeToken", mock.Anything, tokenID, userID, mock.Anything).
+		Return(repoError)
 
 	ctx := context.Background()
 
-	err := suite.service.LogoutUser(ctx, userID)
+	err := suite.service.LogoutUser(ctx, token, "")
 
 	svcError, ok := err.(*errs.ServiceError)
 	suite.True(ok)
 	suite.Equal(errs.ErrInternal, svcError.Code)
-	suite.Equal("failed to find user", svcError.Message)
+	suite.Equal("failed to revoke token", svcError.Message)
 
 	suite.repo.AssertExpectations(suite.T())
 }
 
 func (suite *TestSuite) TestLogoutUser_ServiceErrorPropagation() {
 	userID := "550e8400-e29b-41d4-a716-446655440000"
-	serviceError := errs.NewNotFoundError("user not found", map[string]any{"user_id": userID})
+	token, tokenID := suite.generateToken(userID, time.Hour)
+	serviceError := errs.NewDatabaseError("database connection lost", nil, nil)
 
-	suite.repo.On("GetUserByID", mock.Anything, userID).Return(nil, serviceError)
+	suite.repo.On("RevokeToken", mock.Anything, tokenID, userID, mock.Anything).
+		Return(serviceError)
 
 	ctx := context.Background()
 
-	err := suite.service.LogoutUser(ctx, userID)
+	err := suite.service.LogoutUser(ctx, token, "")
 
 	svcError, ok := err.(*errs.ServiceError)
 	suite.True(ok)
-	suite.Equal(errs.ErrNotFound, svcError.Code)
-	suite.Equal("user not found", svcError.Message)
+	suite.Equal(errs.ErrDatabase, svcError.Code)
+	suite.Equal("database connection lost", svcError.Message)
 
 	suite.repo.AssertExpectations(suite.T())
 }
+
+func (suite *TestSuite) TestLogoutUser_RevokesRefreshTokenFamily() {
+	userID := "550e8400-e29b-41d4-a716-446655440000"
+	token, tokenID := suite.generateToken(userID, time.Hour)
+
+	suite.repo.On("RevokeToken", mock.Anything, tokenID, userID, mock.Anything).
+		Return(nil)
+	suite.repo.On("ClaimRefreshToken", mock.Anything, hashToken("refresh-token"), mock.Anything).
+		Return(&model.RefreshToken{FamilyID: "family-1", UserID: userID}, nil)
+	suite.repo.On("DeleteRefreshTokenFamily", mock.Anything, "family-1").
+		Return(nil)
+
+	ctx := context.Background()
+
+	err := suite.service.LogoutUser(ctx, token, "refresh-token")
+
+	suite.Require().NoError(err)
+
+	suite.repo.AssertExpectations(suite.T())
+}
+
+func (suite *TestSuite) TestLogoutUser_IgnoresOtherUsersRefreshToken() {
+	userID := "550e8400-e29b-41d4-a716-446655440000"
+	token, tokenID := suite.generateToken(userID, time.Hour)
+
+	suite.repo.On("RevokeToken", mock.Anything, tokenID, userID, mock.Anything).
+		Return(nil)
+	suite.repo.On("ClaimRefreshToken", mock.Anything, hashToken("refresh-token"), mock.Anything).
+		Return(&model.RefreshToken{FamilyID: "family-1", UserID: "another-user"}, nil)
+
+	ctx := context.Background()
+
+	err := suite.service.LogoutUser(ctx, token, "refresh-token")
+
+	suite.Require().NoError(err)
+
+	suite.repo.AssertNotCalled(suite.T(), "DeleteRefreshTokenFamily", mock.Anything, mock.Anything)
+}
+
+func (suite *TestSuite) TestLogoutUser_UnknownRefreshToken() {
+	userID := "550e8400-e29b-41d4-a716-446655440000"
+	token, tokenID := suite.generateToken(userID, time.Hour)
+
+	suite.repo.On("RevokeToken", mock.Anything, tokenID, userID, mock.Anything).
+		Return(nil)
+	suite.repo.On("ClaimRefreshToken", mock.Anything, hashToken("refresh-token"), mock.Anything).
+		Return(nil, nil)
+
+	ctx := context.Background()
+
+	err := suite.service.LogoutUser(ctx, token, "refresh-token")
+
+	suite.Require().NoError(err)
+
+	suite.repo.AssertNotCalled(suite.T(), "DeleteRefreshTokenFamily", mock.Anything, mock.Anything)
+}
@@ -0,0 +1,178 @@
+package test
+
+import (
+	"context"
+	"crypto/sha256"
+	"encoding/hex"
+	"errors"
+	"time"
+
+	errs "github.com/COS301-SE-2025/Swift-Signals/shared/error"
+	"github.com/COS301-SE-2025/Swift-Signals/shared/jwt"
+	"github.com/COS301-SE-2025/Swift-Signals/user-service/internal/model"
+	"github.com/stretchr/testify/mock"
+)
+
+// hashToken mirrors how the service keeps refresh and password reset tokens
+func hashToken(refreshToken string) string {
+	sum := sha256.Sum256([]byte(refreshToken))
+	return hex.EncodeToString(sum[:])
+}
+
+func (suite *TestSuite) TestRefreshToken_Success() {
+	jwt.Init([]byte("test-secret"))
+	user := &model.User{
+		ID:      "550e8400-e29b-41d4-a716-446655440000",
+		IsAdmin: true,
+	}
+
+	suite.repo.On("ClaimRefreshToken", mock.Anything, hashToken("refresh-token"), mock.Anything).
+		Return(&model.RefreshToken{
+			TokenHash: hashToken("refresh-token"),
+			FamilyID:  "family-1",
+			UserID:    user.ID,
+			ExpiresAt: time.Now().Add(time.Hour),
+		}, nil)
+	suite.repo.On("GetUserByID", mock.Anything, user.ID).Return(user, nil)
+	suite.repo.On("CreateRefreshToken", mock.Anything, mock.MatchedBy(
+		func(token *model.RefreshToken) bool {
+			return token.FamilyID == "family-1" &&
+				token.UserID == user.ID &&
+				token.TokenHash != hashToken("refresh-token")
+		})).
+		Return(nil)
+
+	ctx := context.Background()
+
+	tokens, err := suite.service.RefreshToken(ctx, "refresh-token")
+
+	suite.Require().NoError(err)
+	suite.NotEqual("refresh-token", tokens.RefreshToken)
+	suite.True(tokens.AccessExpiresAt.Before(time.Now().Add(16 * time.Minute)))
+
+	claims, err := jwt.ParseToken(tokens.AccessToken)
+	suite.Require().NoError(err)
+	suite.Equal(user.ID, claims.UserID)
+	suite.Equal("admin", claims.Role)
+
+	suite.repo.AssertExpectations(suite.T())
+}
+
+func (suite *TestSuite) TestRefreshToken_EmptyToken() {
+	ctx := context.Background()
+
+	tokens, err := suite.service.RefreshToken(ctx, "")
+
+	suite.Nil(tokens)
+	svcError, ok := err.(*errs.ServiceError)
+	suite.True(ok)
+	suite.Equal(errs.ErrValidation, svcError.Code)
+	expectedErrors := map[string]string{
+		"refreshtoken": "RefreshToken is required",
+	}
+	suite.Equal(map[string]any{"validation errors": expectedErrors}, svcError.Context)
+
+	suite.repo.AssertNotCalled(suite.T(), "ClaimRefreshToken",
+		mock.Anything, mock.Anything, mock.Anything)
+}
+
+func (suite *TestSuite) TestRefreshToken_UnknownToken() {
+	suite.repo.On("ClaimRefreshToken", mock.Anything, hashToken("refresh-token"), mock.Anything).
+		Return(nil, nil)
+
+	ctx := context.Background()
+
+	tokens, err := suite.service.RefreshToken(ctx, "refresh-token")
+
+	suite.Nil(tokens)
+	svcError, ok := err.(*errs.ServiceError)
+	suite.True(ok)
+	suite.Equal(errs.ErrUnauthorized, svcError.Code)
+	suite.Equal("invalid refresh token", svcError.Message)
+
+	suite.repo.AssertExpectations(suite.T())
+}
+
+func (suite *TestSuite) TestRefreshToken_ReuseRevokesFamily() {
+	usedAt := time.Now().Add(-time.Minute)
+	suite.repo.On("ClaimRefreshToken", mock.Anything, hashToken("refresh-token"), mock.Anything).
+		Return(&model.RefreshToken{
+			FamilyID:  "family-1",
+			UserID:    "550e8400-e29b-41d4-a716-446655440000",
+			ExpiresAt: time.Now().Add(time.Hour),
+			UsedAt:    &usedAt,
+		}, nil)
+	suite.repo.On("DeleteRefreshTokenFamily", mock.Anything, "family-1").Return(nil)
+
+	ctx := context.Background()
+
+	tokens, err := suite.service.RefreshToken(ctx, "refresh-token")
+
+	suite.Nil(tokens)
+	svcError, ok := err.(*errs.ServiceError)
+	suite.True(ok)
+	suite.Equal(errs.ErrUnauthorized, svcError.Code)
+	suite.Equal("refresh token has already been used", svcError.Message)
+
+	suite.repo.AssertExpectations(suite.T())
+	suite.repo.AssertNotCalled(suite.T(), "CreateRefreshToken", mock.Anything, mock.Anything)
+}
+
+func (suite *TestSuite) TestRefreshToken_ReuseRevocationError() {
+	usedAt := time.Now().Add(-time.Minute)
+	suite.repo.On("ClaimRefreshToken", mock.Anything, hashToken("refresh-token"), mock.Anything).
+		Return(&model.RefreshToken{
+			FamilyID:  "family-1",
+			UserID:    "550e8400-e29b-41d4-a716-446655440000",
+			ExpiresAt: time.Now().Add(time.Hour),
+			UsedAt:    &usedAt,
+		}, nil)
+	suite.repo.On("DeleteRefreshTokenFamily", mock.Anything, "family-1").
+		Return(errors.New("database connection failed"))
+
+	ctx := context.Background()
+
+	_, err := suite.service.RefreshToken(ctx, "refresh-token")
+
+	svcError, ok := err.(*errs.ServiceError)
+	suite.True(ok)
+	suite.Equal(errs.ErrInternal, svcError.Code)
+	suite.Equal("failed to revoke refresh token", svcError.Message)
+}
+
+func (suite *TestSuite) TestRefreshToken_Expired() {
+	suite.repo.On("ClaimRefreshToken", mock.Anything, hashToken("refresh-token"), mock.Anything).
+		Return(&model.RefreshToken{
+			FamilyID:  "family-1",
+			UserID:    "550e8400-e29b-41d4-a716-446655440000",
+			ExpiresAt: time.Now().Add(-time.Minute),
+		}, nil)
+
+	ctx := context.Background()
+
+	tokens, err := suite.service.RefreshToken(ctx, "refresh-token")
+
+	suite.Nil(tokens)
+	svcError, ok := err.(*errs.ServiceError)
+	suite.True(ok)
+	suite.Equal(errs.ErrUnauthorized, svcError.Code)
+	suite.Equal("refresh token has expired", svcError.Message)
+
+	suite.repo.AssertNotCalled(suite.T(), "DeleteRefreshTokenFamily", mock.Anything, mock.Anything)
+	suite.repo.AssertNotCalled(suite.T(), "CreateRefreshToken", mock.Anything, mock.Anything)
+}
+
+func (suite *TestSuite) TestRefreshToken_RepositoryError() {
+	suite.repo.On("ClaimRefreshToken", mock.Anything, hashToken("refresh-token"), mock.Anything).
+		Return(nil, errors.New("database connection failed"))
+
+	ctx := context.Background()
+
+	tokens, err := suite.service.RefreshToken(ctx, "refresh-token")
+
+	suite.Nil(tokens)
+	svcError, ok := err.(*errs.ServiceError)
+	suite.True(ok)
+	suite.Equal(errs.ErrInternal, svcError.Code)
+	suite.Equal("failed to claim refresh token", svcError.Message)
+}
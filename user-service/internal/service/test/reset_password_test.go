@@ -0,0 +1,172 @@
+package test
+
+import (
+	"context"
+	"errors"
+	"regexp"
+	"strings"
+	"time"
+
+	errs "github.com/COS301-SE-2025/Swift-Signals/shared/error"
+	"github.com/COS301-SE-2025/Swift-Signals/user-service/internal/mailer"
+	"github.com/COS301-SE-2025/Swift-Signals/user-service/internal/model"
+	"github.com/COS301-SE-2025/Swift-Signals/user-service/internal/service"
+	"github.com/stretchr/testify/mock"
+)
+
+var resetLinkPattern = regexp.MustCompile(regexp.QuoteMeta(testResetURL) + `\?token=(\w+)`)
+
+func (suite *TestSuite) TestResetPassword_Success() {
+	user := &model.User{
+		ID:    "550e8400-e29b-41d4-a716-446655440000",
+		Name:  "Test User",
+		Email: "test@example.com",
+	}
+
+	var saved *model.PasswordResetToken
+	suite.repo.On("GetUserByEmail", mock.Anything, "test@example.com").Return(user, nil)
+	suite.repo.On("CreatePasswordResetToken", mock.Anything,
+		mock.MatchedBy(func(token *model.PasswordResetToken) bool {
+			return token.UserID == user.ID &&
+				time.Until(token.ExpiresAt) > 59*time.Minute &&
+				time.Until(token.ExpiresAt) <= time.Hour
+		})).
+		Run(func(args mock.Arguments) {
+			saved = args.Get(1).(*model.PasswordResetToken)
+		}).
+		Return(nil)
+
+	var sent mailer.Message
+	suite.mailer.On("Send", mock.Anything, mock.AnythingOfType("mailer.Message")).
+		Run(func(args mock.Arguments) {
+			sent = args.Get(1).(mailer.Message)
+		}).
+		Return(nil)
+
+	ctx := context.Background()
+
+	err := suite.service.ResetPassword(ctx, "  Test@Example.com ")
+
+	suite.Require().NoError(err)
+	suite.Equal(user.Email, sent.To)
+	suite.Contains(sent.Body, user.Name)
+	match := resetLinkPattern.FindStringSubmatch(sent.Body)
+	suite.Require().Len(match, 2, "email should contain a reset link")
+	suite.Require().NotNil(saved)
+	suite.Equal(saved.TokenHash, hashToken(match[1]), "only the token's hash should be saved")
+	suite.repo.AssertExpectations(suite.T())
+	suite.mailer.AssertExpectations(suite.T())
+}
+
+func (suite *TestSuite) TestResetPassword_UnknownEmail() {
+	suite.repo.On("GetUserByEmail", mock.Anything, "nobody@example.com").Return(nil, nil)
+
+	ctx := context.Background()
+
+	err := suite.service.ResetPassword(ctx, "nobody@example.com")
+
+	suite.Require().NoError(err)
+	suite.repo.AssertNotCalled(suite.T(), "CreatePasswordResetToken", mock.Anything, mock.Anything)
+	suite.mailer.AssertNotCalled(suite.T(), "Send", mock.Anything, mock.Anything)
+}
+
+func (suite *TestSuite) TestResetPassword_InvalidEmail() {
+	ctx := context.Background()
+
+	err := suite.service.ResetPassword(ctx, "not-an-email")
+
+	svcError, ok := err.(*errs.ServiceError)
+	suite.True(ok)
+	suite.Equal(errs.ErrValidation, svcError.Code)
+	suite.Equal("invalid input", svcError.Message)
+	suite.repo.AssertNotCalled(suite.T(), "GetUserByEmail", mock.Anything, mock.Anything)
+}
+
+func (suite *TestSuite) TestResetPassword_RateLimited() {
+	suite.repo.On("GetUserByEmail", mock.Anything, mock.Anything).Return(nil, nil)
+
+	ctx := context.Background()
+
+	for range 3 {
+		suite.Require().NoError(suite.service.ResetPassword(ctx, "test@example.com"))
+	}
+
+	err := suite.service.ResetPassword(ctx, "TEST@example.com")
+
+	svcError, ok := err.(*errs.ServiceError)
+	suite.True(ok)
+	suite.Equal(errs.ErrRateLimited, svcError.Code)
+	suite.repo.AssertNumberOfCalls(suite.T(), "GetUserByEmail", 3)
+
+	err = suite.service.ResetPassword(ctx, "other@example.com")
+
+	suite.NoError(err, "other email addresses should not be limited")
+}
+
+func (suite *TestSuite) TestResetPassword_RepositoryError() {
+	suite.repo.On("GetUserByEmail", mock.Anything, "test@example.com").
+		Return(nil, errors.New("database connection failed"))
+
+	ctx := context.Background()
+
+	err := suite.service.ResetPassword(ctx, "test@example.com")
+
+	svcError, ok := err.(*errs.ServiceError)
+	suite.True(ok)
+	suite.Equal(errs.ErrInternal, svcError.Code)
+	suite.Equal("failed to find user", svcError.Message)
+}
+
+func (suite *TestSuite) TestResetPassword_SaveTokenError() {
+	user := &model.User{ID: "550e8400-e29b-41d4-a716-446655440000", Email: "test@example.com"}
+	suite.repo.On("GetUserByEmail", mock.Anything, "test@example.com").Return(user, nil)
+	suite.repo.On("CreatePasswordResetToken", mock.Anything, mock.Anything).
+		Return(errs.NewDatabaseError("database connection lost", nil, nil))
+
+	ctx := context.Background()
+
+	err := suite.service.ResetPassword(ctx, "test@example.com")
+
+	svcError, ok := err.(*errs.ServiceError)
+	suite.True(ok)
+	suite.Equal(errs.ErrDatabase, svcError.Code)
+	suite.mailer.AssertNotCalled(suite.T(), "Send", mock.Anything, mock.Anything)
+}
+
+func (suite *TestSuite) TestResetPassword_MailerError() {
+	user := &model.User{ID: "550e8400-e29b-41d4-a716-446655440000", Email: "test@example.com"}
+	suite.repo.On("GetUserByEmail", mock.Anything, "test@example.com").Return(user, nil)
+	suite.repo.On("CreatePasswordResetToken", mock.Anything, mock.Anything).Return(nil)
+	suite.mailer.On("Send", mock.Anything, mock.Anything).
+		Return(errors.New("connection refused"))
+
+	ctx := context.Background()
+
+	err := suite.service.ResetPassword(ctx, "test@example.com")
+
+	svcError, ok := err.(*errs.ServiceError)
+	suite.True(ok)
+	suite.Equal(errs.ErrExternal, svcError.Code)
+	suite.Equal("failed to send reset email", svcError.Message)
+}
+
+func (suite *TestSuite) TestResetPassword_LinkKeepsQuery() {
+	suite.service = service.NewUserService(
+		suite.repo,
+		suite.mailer,
+		"https://swiftsignals.example/reset?lang=en",
+	)
+	user := &model.User{ID: "550e8400-e29b-41d4-a716-446655440000", Email: "test@example.com"}
+	suite.repo.On("GetUserByEmail", mock.Anything, "test@example.com").Return(user, nil)
+	suite.repo.On("CreatePasswordResetToken", mock.Anything, mock.Anything).Return(nil)
+	suite.mailer.On("Send", mock.Anything, mock.MatchedBy(func(msg mailer.Message) bool {
+		return strings.Contains(msg.Body, "https://swiftsignals.example/reset?lang=en&token=")
+	})).Return(nil)
+
+	ctx := context.Background()
+
+	err := suite.service.ResetPassword(ctx, "test@example.com")
+
+	suite.Require().NoError(err)
+	suite.mailer.AssertExpectations(suite.T())
+}